@@ -3,55 +3,34 @@ package account
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"payter-bank/features/approval"
+	"payter-bank/features/mfa"
 	"payter-bank/internal/api"
 	"payter-bank/internal/auth"
+	"payter-bank/internal/database/models"
 )
 
 type Handler struct {
-	service Service
+	service   Service
+	approvals approval.Service
 }
 
-func NewHandler(service Service) *Handler {
+func NewHandler(service Service, approvals approval.Service) *Handler {
 	return &Handler{
-		service: service,
+		service:   service,
+		approvals: approvals,
 	}
 }
 
-// CreateUserHandler godoc
-// @Summary      Create user
-// @Description  Create a new CUSTOMER user
-// @Tags         accounts
-// @Accept       json
-// @Produce      json
-// @Param        account  body  CreateUserParams  true  "Create users params"
-// @Success      200  {object}  api.SuccessResponse{data=CreateUserResponse}
-// @Failure      400  {object}  api.ErrorResponse
-// @Failure      404  {object}  api.ErrorResponse
-// @Failure      500  {object}  api.ErrorResponse
-// @Router       /v1/api/users [post]
-func (h *Handler) CreateUserHandler(ctx *gin.Context) api.Response {
-	var params CreateUserParams
-	if err := ctx.ShouldBindJSON(&params); err != nil {
-		return api.BadRequest(err.Error())
-	}
-
-	params.UserType = "CUSTOMER"
-	user, err := h.service.CreateUser(ctx, params)
-	if err != nil {
-		return api.Error(err)
-	}
-
-	return api.OK("user created successfully", user)
-}
-
 // CreateAdminUserHandler godoc
 // @Summary      Create user
-// @Description  Create a new ADMIN user. caller MUST be an admin
+// @Description  Create a new CUSTOMER or ADMIN user. caller MUST have the users:create permission. Users created by an admin do not need to verify their email, new admins have no roles until they are given some. Creating an admin may need a second admin's approval
 // @Tags         accounts
 // @Accept       json
 // @Produce      json
 // @Param        account  body  CreateUserParams  true  "Create users params"
 // @Success      200  {object}  api.SuccessResponse{data=CreateUserResponse}
+// @Success      202  {object}  api.SuccessResponse{data=approval.Request}
 // @Failure      400  {object}  api.ErrorResponse
 // @Failure      404  {object}  api.ErrorResponse
 // @Failure      500  {object}  api.ErrorResponse
@@ -62,13 +41,23 @@ func (h *Handler) CreateAdminUserHandler(ctx *gin.Context) api.Response {
 		return api.BadRequest(err.Error())
 	}
 
-	profile, err := auth.GetCurrentProfile(ctx)
-	if err != nil {
-		return api.Unauthorized("unauthorized")
-	}
-
-	if profile.UserType != "ADMIN" {
-		return api.Forbidden("only admin can create admin users")
+	if params.UserType == string(models.UserTypeADMIN) && h.approvals.Requires(approval.OperationAdminCreate, 0) {
+		profile, err := auth.GetCurrentProfile(ctx)
+		if err != nil {
+			return api.Unauthorized("unauthorized")
+		}
+
+		// the password is checked now, the approver never sees it
+		passwordHash, err := h.service.HashPassword(ctx, params.Password, params.Email)
+		if err != nil {
+			return api.Error(err)
+		}
+
+		request, err := h.approvals.Submit(ctx, adminCreationRequest(params, passwordHash, profile.UserID))
+		if err != nil {
+			return api.Error(err)
+		}
+		return api.Accepted("admin user is awaiting approval", request)
 	}
 
 	user, err := h.service.CreateUser(ctx, params)
@@ -107,13 +96,14 @@ func (h *Handler) CreateAccountHandler(ctx *gin.Context) api.Response {
 
 // AuthenticateAccountHandler godoc
 // @Summary      Authenticate account
-// @Description  Authenticate an account using email and password
+// @Description  Authenticate an account using email and password. Repeated failures lock out the email and the client IP for an increasing time
 // @Tags         accounts
 // @Accept       json
 // @Produce      json
 // @Param        account  body  AuthenticateAccountParams  true  "authenticate account params"
 // @Success      200  {object}  api.SuccessResponse{data=AccessToken}
 // @Failure      400  {object}  api.ErrorResponse
+// @Failure      401  {object}  api.ErrorResponse
 // @Failure      404  {object}  api.ErrorResponse
 // @Failure      500  {object}  api.ErrorResponse
 // @Router       /v1/api/users/authenticate [post]
@@ -122,6 +112,8 @@ func (h *Handler) AuthenticateAccountHandler(ctx *gin.Context) api.Response {
 	if err := ctx.ShouldBindJSON(&params); err != nil {
 		return api.BadRequest(err.Error())
 	}
+	params.IP = ctx.ClientIP()
+	params.UserAgent = ctx.Request.UserAgent()
 
 	token, err := h.service.AuthenticateAccount(ctx, params)
 	if err != nil {
@@ -131,6 +123,34 @@ func (h *Handler) AuthenticateAccountHandler(ctx *gin.Context) api.Response {
 	return api.OK("account authenticated successfully", token)
 }
 
+// AuthenticateMFAHandler godoc
+// @Summary      Complete MFA login
+// @Description  Exchange the challenge token returned by authenticate and a code from the authenticator app, or a recovery code, for access tokens
+// @Tags         accounts
+// @Accept       json
+// @Produce      json
+// @Param        params  body  mfa.VerifyChallengeParams  true  "Challenge token and code"
+// @Success      200  {object}  api.SuccessResponse{data=AccessToken}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      401  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/users/authenticate/mfa [post]
+func (h *Handler) AuthenticateMFAHandler(ctx *gin.Context) api.Response {
+	var params mfa.VerifyChallengeParams
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		return api.BadRequest(err.Error())
+	}
+	params.IP = ctx.ClientIP()
+	params.UserAgent = ctx.Request.UserAgent()
+
+	token, err := h.service.AuthenticateMFA(ctx, params)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("account authenticated successfully", token)
+}
+
 // MeHandler godoc
 // @Summary      Get current user
 // @Description  return the current authenticated user
@@ -300,6 +320,44 @@ func (h *Handler) GetAllCurrentAccountsHandler(ctx *gin.Context) api.Response {
 	return api.OK("accounts retrieved successfully", data)
 }
 
+// SearchAccountsHandler doc
+// @Summary      Search accounts
+// @Description  Search customers and their accounts with filters, sorting and pagination - admin only endpoint.
+// @Tags         accounts
+// @Accept       json
+// @Produce      json
+// @Param        name              query  string  false  "Customer name, partial match"
+// @Param        email             query  string  false  "Customer email, partial match"
+// @Param        account_number    query  string  false  "Account number prefix"
+// @Param        status            query  string  false  "Account status"  Enums(PENDING, ACTIVE, SUSPENDED, CLOSED, DORMANT)
+// @Param        currency          query  string  false  "Account currency"  Enums(GBP, EUR, JPY)
+// @Param        min_balance       query  number  false  "Minimum balance"
+// @Param        max_balance       query  number  false  "Maximum balance"
+// @Param        created_from      query  string  false  "Created on or after (YYYY-MM-DD)"
+// @Param        created_to        query  string  false  "Created on or before (YYYY-MM-DD)"
+// @Param        include_internal  query  bool    false  "Include admin-owned and EXTERNAL accounts"
+// @Param        sort_by           query  string  false  "Sort field"  Enums(created_at, balance, account_number, name)
+// @Param        sort_order        query  string  false  "Sort order, defaults to desc"  Enums(asc, desc)
+// @Param        page              query  int     false  "Page number, starting at 1"
+// @Param        page_size         query  int     false  "Page size, at most 100"
+// @Success      200  {object}  api.SuccessResponse{data=AccountSearchResult}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/accounts/search [get]
+func (h *Handler) SearchAccountsHandler(ctx *gin.Context) api.Response {
+	var params SearchAccountsParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	result, err := h.service.SearchAccounts(ctx, params)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("accounts retrieved successfully", result)
+}
+
 // GetAccountsStatsHandler doc
 // @Summary      Get accounts stats
 // @Description  Get accounts stats - admin only endpoint.
@@ -344,3 +402,53 @@ func (h *Handler) GetAccountDetailsHandler(ctx *gin.Context) api.Response {
 
 	return api.OK("account details retrieved successfully", data)
 }
+
+// ValidateAccountNumberHandler godoc
+// @Summary      Validate account number
+// @Description  Validate a sort code and account number (format and modulus check) and report whether it belongs to an account of this bank.
+// @Tags         accounts
+// @Accept       json
+// @Produce      json
+// @Param        identifier  body  ValidateAccountNumberParams  true  "sort code and account number"
+// @Success      200  {object}  api.SuccessResponse{data=IdentifierValidation}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/accounts/validate [post]
+func (h *Handler) ValidateAccountNumberHandler(ctx *gin.Context) api.Response {
+	var params ValidateAccountNumberParams
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	result, err := h.service.ValidateAccountNumber(ctx, params)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("account number validated", result)
+}
+
+// ValidateIBANHandler godoc
+// @Summary      Validate IBAN
+// @Description  Validate an IBAN (format and mod-97 check digits) and report whether it belongs to an account of this bank.
+// @Tags         accounts
+// @Accept       json
+// @Produce      json
+// @Param        identifier  body  ValidateIBANParams  true  "iban"
+// @Success      200  {object}  api.SuccessResponse{data=IdentifierValidation}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/accounts/validate-iban [post]
+func (h *Handler) ValidateIBANHandler(ctx *gin.Context) api.Response {
+	var params ValidateIBANParams
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	result, err := h.service.ValidateIBAN(ctx, params)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("iban validated", result)
+}
@@ -11,10 +11,14 @@ import (
 	"go.uber.org/mock/gomock"
 	"net/http"
 	"net/http/httptest"
+	"payter-bank/features/approval"
+	"payter-bank/features/mfa"
 	"payter-bank/internal/api"
+	"payter-bank/internal/auth"
 	platformerrors "payter-bank/internal/errors"
 	"payter-bank/internal/pkg/generator"
 	"testing"
+	"time"
 )
 
 func TestHandler_CreateAccountHandler(t *testing.T) {
@@ -37,7 +41,7 @@ func TestHandler_CreateAccountHandler(t *testing.T) {
 		mockService.EXPECT().CreateAccount(gomock.Any(), expectedParam).
 			Return(expectedProfile, nil)
 
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 
@@ -51,7 +55,7 @@ func TestHandler_CreateAccountHandler(t *testing.T) {
 		mockService := NewMockService(gomock.NewController(t))
 
 		body := `{"currency":"GBP"}`
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 
@@ -74,7 +78,7 @@ func TestHandler_CreateAccountHandler(t *testing.T) {
 		mockService.EXPECT().CreateAccount(gomock.Any(), expectedParam).
 			Return(Profile{}, platformerrors.ErrInternal)
 
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 
@@ -95,7 +99,7 @@ func TestHandler_CreateAccountHandler(t *testing.T) {
 		mockService.EXPECT().CreateAccount(gomock.Any(), expectedParam).
 			Return(Profile{}, platformerrors.MakeApiError(http.StatusPreconditionFailed, "user already exists"))
 
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 
@@ -106,14 +110,93 @@ func TestHandler_CreateAccountHandler(t *testing.T) {
 	})
 }
 
+func TestHandler_CreateAdminUserHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("creates a customer without approval", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		expectedParam := CreateUserParams{
+			FirstName: "Jane",
+			LastName:  "Doe",
+			Email:     "jane@example.com",
+			Password:  "secret-password",
+			UserType:  "CUSTOMER",
+		}
+		response := CreateUserResponse{UserID: uuid.New()}
+		mockService.EXPECT().CreateUser(gomock.Any(), expectedParam).Return(response, nil)
+
+		body := `{"first_name":"Jane","last_name":"Doe","email":"jane@example.com","password":"secret-password","user_type":"CUSTOMER"}`
+		handler := NewHandler(mockService, withoutApprovals(t))
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/v1/api/admin/users", bytes.NewBufferString(body))
+
+		resp := handler.CreateAdminUserHandler(c)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, api.SuccessResponse{Data: response, Message: "user created successfully"}, resp.Data)
+	})
+
+	t.Run("admin creation waits for approval without exposing the password", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := NewMockService(ctrl)
+		approvals := approval.NewMockService(ctrl)
+		actorID := uuid.New()
+		request := approval.Request{ID: uuid.New(), Operation: approval.OperationAdminCreate, Status: "PENDING"}
+
+		approvals.EXPECT().Requires(approval.OperationAdminCreate, 0.0).Return(true)
+		mockService.EXPECT().HashPassword(gomock.Any(), "secret-password", "john@example.com").Return("hashed-password", nil)
+		approvals.EXPECT().Submit(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, param approval.SubmitParams) (approval.Request, error) {
+				assert.Equal(t, approval.OperationAdminCreate, param.Operation)
+				assert.Equal(t, actorID, param.RequestedBy)
+				assert.Equal(t, adminCreation{FirstName: "John", LastName: "Doe", Email: "john@example.com"}, param.Payload)
+				assert.Equal(t, adminCreationSecret{PasswordHash: "hashed-password"}, param.Secret)
+				return request, nil
+			})
+
+		body := `{"first_name":"John","last_name":"Doe","email":"john@example.com","password":"secret-password","user_type":"ADMIN"}`
+		handler := NewHandler(mockService, approvals)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/v1/api/admin/users", bytes.NewBufferString(body))
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), auth.ProfileKey, auth.Profile{UserID: actorID}))
+
+		resp := handler.CreateAdminUserHandler(c)
+		assert.Equal(t, http.StatusAccepted, resp.Code)
+		assert.Equal(t, api.SuccessResponse{Data: request, Message: "admin user is awaiting approval"}, resp.Data)
+	})
+
+	t.Run("rejects an admin password that breaks the policy before asking for approval", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := NewMockService(ctrl)
+		approvals := approval.NewMockService(ctrl)
+
+		approvals.EXPECT().Requires(approval.OperationAdminCreate, 0.0).Return(true)
+		mockService.EXPECT().HashPassword(gomock.Any(), "password", "john@example.com").
+			Return("", platformerrors.MakeApiError(http.StatusBadRequest, "password must be at least 12 characters long"))
+
+		body := `{"first_name":"John","last_name":"Doe","email":"john@example.com","password":"password","user_type":"ADMIN"}`
+		handler := NewHandler(mockService, approvals)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/v1/api/admin/users", bytes.NewBufferString(body))
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), auth.ProfileKey, auth.Profile{UserID: uuid.New()}))
+
+		resp := handler.CreateAdminUserHandler(c)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+}
+
 func TestHandler_AuthenticateAccountHandler(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	t.Run("successfully authenticate an account", func(t *testing.T) {
 		mockService := NewMockService(gomock.NewController(t))
 		expectedParam := AuthenticateAccountParams{
-			Email:    "jd@testmail.com",
-			Password: "$PASSword001",
+			Email:     "jd@testmail.com",
+			Password:  "$PASSword001",
+			IP:        "192.0.2.1",
+			UserAgent: "curl/8.5.0",
 		}
 		expectedToken := AccessToken{Token: uuid.NewString()}
 		expectedResponse := api.SuccessResponse{
@@ -125,11 +208,12 @@ func TestHandler_AuthenticateAccountHandler(t *testing.T) {
 		mockService.EXPECT().AuthenticateAccount(gomock.Any(), expectedParam).
 			Return(expectedToken, nil)
 
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 
 		c.Request = httptest.NewRequest("POST", "/v1/api/accounts/authenticate", bytes.NewBufferString(body))
+		c.Request.Header.Set("User-Agent", "curl/8.5.0")
 		resp := handler.AuthenticateAccountHandler(c)
 		assert.Equal(t, http.StatusOK, resp.Code)
 		assert.Equal(t, expectedResponse, resp.Data)
@@ -139,7 +223,7 @@ func TestHandler_AuthenticateAccountHandler(t *testing.T) {
 		mockService := NewMockService(gomock.NewController(t))
 		body := `{"email": "jd@testmailcom", "password": "$PASSword001"}`
 
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 
@@ -153,13 +237,14 @@ func TestHandler_AuthenticateAccountHandler(t *testing.T) {
 		expectedParam := AuthenticateAccountParams{
 			Email:    "jd@testmail.com",
 			Password: "$PASSword001",
+			IP:       "192.0.2.1",
 		}
 		body := `{"email": "jd@testmail.com", "password": "$PASSword001"}`
 
 		mockService.EXPECT().AuthenticateAccount(gomock.Any(), expectedParam).
 			Return(AccessToken{}, platformerrors.MakeApiError(http.StatusUnauthorized, "invalid login credentials"))
 
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 
@@ -170,6 +255,47 @@ func TestHandler_AuthenticateAccountHandler(t *testing.T) {
 	})
 }
 
+func TestHandler_AuthenticateMFAHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("completes login with mfa code", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		expectedToken := AccessToken{Token: "access", RefreshToken: "refresh", ExpiresIn: 900}
+
+		mockService.EXPECT().AuthenticateMFA(gomock.Any(), mfa.VerifyChallengeParams{
+			ChallengeToken: "challenge-token",
+			Code:           "123456",
+			IP:             "192.0.2.1",
+			UserAgent:      "curl/8.5.0",
+		}).Return(expectedToken, nil)
+
+		handler := NewHandler(mockService, withoutApprovals(t))
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		c.Request = httptest.NewRequest("POST", "/v1/api/users/authenticate/mfa",
+			bytes.NewBufferString(`{"challenge_token": "challenge-token", "code": "123456"}`))
+		c.Request.Header.Set("User-Agent", "curl/8.5.0")
+		resp := handler.AuthenticateMFAHandler(c)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    expectedToken,
+			Message: "account authenticated successfully",
+		}, resp.Data)
+	})
+
+	t.Run("rejects missing code", func(t *testing.T) {
+		handler := NewHandler(NewMockService(gomock.NewController(t)), withoutApprovals(t))
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		c.Request = httptest.NewRequest("POST", "/v1/api/users/authenticate/mfa",
+			bytes.NewBufferString(`{"challenge_token": "challenge-token"}`))
+		resp := handler.AuthenticateMFAHandler(c)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+}
+
 func TestHandler_MeHandler(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -182,7 +308,7 @@ func TestHandler_MeHandler(t *testing.T) {
 			Message: "user profile retrieved successfully",
 		}
 
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 
@@ -200,7 +326,7 @@ func TestHandler_MeHandler(t *testing.T) {
 	t.Run("failure to get user - not authenticated", func(t *testing.T) {
 		mockService := NewMockService(gomock.NewController(t))
 
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 
@@ -220,7 +346,7 @@ func TestHandler_SuspendAccountHandler(t *testing.T) {
 			Message: "account suspended successfully",
 		}
 
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 
@@ -244,7 +370,7 @@ func TestHandler_SuspendAccountHandler(t *testing.T) {
 			Error: "account not found",
 		}
 
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 
@@ -272,7 +398,7 @@ func TestHandler_ActivateAccountHandler(t *testing.T) {
 			Message: "account activated successfully",
 		}
 
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 
@@ -296,7 +422,7 @@ func TestHandler_ActivateAccountHandler(t *testing.T) {
 			Error: "account not found",
 		}
 
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 
@@ -323,7 +449,7 @@ func TestHandler_CloseAccountHandler(t *testing.T) {
 			Message: "account closed successfully",
 		}
 
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 
@@ -347,7 +473,7 @@ func TestHandler_CloseAccountHandler(t *testing.T) {
 			Error: "account not found",
 		}
 
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 
@@ -380,7 +506,7 @@ func TestHandler_GetAccountStatusHistoryHandler(t *testing.T) {
 			Message: "account status history retrieved successfully",
 		}
 
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 
@@ -409,3 +535,154 @@ func injectClaim(ctx *gin.Context, userID, accountID uuid.UUID) {
 	}
 	ctx.Request = ctx.Request.WithContext(context.WithValue(ctx.Request.Context(), jwtmiddleware.ContextKey{}, claims))
 }
+
+func TestHandler_ValidateAccountNumberHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("successfully validates an account number", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		expectedParam := ValidateAccountNumberParams{
+			SortCode:      "04-00-04",
+			AccountNumber: "12345679",
+		}
+		expectedResult := IdentifierValidation{
+			Valid:         true,
+			SortCode:      "040004",
+			AccountNumber: "12345679",
+			Exists:        true,
+			Currency:      "GBP",
+		}
+
+		body := `{"sort_code": "04-00-04", "account_number": "12345679"}`
+		mockService.EXPECT().ValidateAccountNumber(gomock.Any(), expectedParam).
+			Return(expectedResult, nil)
+
+		handler := NewHandler(mockService, withoutApprovals(t))
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		c.Request = httptest.NewRequest("POST", "/v1/api/accounts/validate", bytes.NewBufferString(body))
+		resp := handler.ValidateAccountNumberHandler(c)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    expectedResult,
+			Message: "account number validated",
+		}, resp.Data)
+	})
+
+	t.Run("fails when account number is missing", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService, withoutApprovals(t))
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		c.Request = httptest.NewRequest("POST", "/v1/api/accounts/validate", bytes.NewBufferString(`{"sort_code": "040004"}`))
+		resp := handler.ValidateAccountNumberHandler(c)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+}
+
+func TestHandler_ValidateIBANHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("successfully validates an iban", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		expectedResult := IdentifierValidation{
+			Valid:         true,
+			SortCode:      "123456",
+			AccountNumber: "98765432",
+			IBAN:          "GB82WEST12345698765432",
+		}
+
+		mockService.EXPECT().ValidateIBAN(gomock.Any(), ValidateIBANParams{IBAN: "GB82 WEST 1234 5698 7654 32"}).
+			Return(expectedResult, nil)
+
+		handler := NewHandler(mockService, withoutApprovals(t))
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		c.Request = httptest.NewRequest("POST", "/v1/api/accounts/validate-iban", bytes.NewBufferString(`{"iban": "GB82 WEST 1234 5698 7654 32"}`))
+		resp := handler.ValidateIBANHandler(c)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    expectedResult,
+			Message: "iban validated",
+		}, resp.Data)
+	})
+
+	t.Run("returns error when service fails", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		mockService.EXPECT().ValidateIBAN(gomock.Any(), gomock.Any()).
+			Return(IdentifierValidation{}, platformerrors.ErrInternal)
+
+		handler := NewHandler(mockService, withoutApprovals(t))
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		c.Request = httptest.NewRequest("POST", "/v1/api/accounts/validate-iban", bytes.NewBufferString(`{"iban": "GB82WEST12345698765432"}`))
+		resp := handler.ValidateIBANHandler(c)
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
+
+func TestHandler_SearchAccountsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("binds query filters", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		minBalance := 25.5
+		expectedResult := AccountSearchResult{Accounts: []Account{}, Page: 2, PageSize: 5}
+
+		mockService.EXPECT().SearchAccounts(gomock.Any(), SearchAccountsParams{
+			Name:            "jane",
+			Status:          "DORMANT",
+			MinBalance:      &minBalance,
+			CreatedFrom:     time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			IncludeInternal: true,
+			SortBy:          "name",
+			SortOrder:       "asc",
+			Page:            2,
+			PageSize:        5,
+		}).Return(expectedResult, nil)
+
+		handler := NewHandler(mockService, withoutApprovals(t))
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		c.Request = httptest.NewRequest("GET", "/v1/api/accounts/search?name=jane&status=DORMANT&min_balance=25.5"+
+			"&created_from=2026-03-01&include_internal=true&sort_by=name&sort_order=asc&page=2&page_size=5", nil)
+		resp := handler.SearchAccountsHandler(c)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    expectedResult,
+			Message: "accounts retrieved successfully",
+		}, resp.Data)
+	})
+
+	t.Run("rejects unknown sort field", func(t *testing.T) {
+		handler := NewHandler(NewMockService(gomock.NewController(t)), withoutApprovals(t))
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		c.Request = httptest.NewRequest("GET", "/v1/api/accounts/search?sort_by=password", nil)
+		resp := handler.SearchAccountsHandler(c)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+
+	t.Run("rejects page size over the limit", func(t *testing.T) {
+		handler := NewHandler(NewMockService(gomock.NewController(t)), withoutApprovals(t))
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		c.Request = httptest.NewRequest("GET", "/v1/api/accounts/search?page_size=500", nil)
+		resp := handler.SearchAccountsHandler(c)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+}
+
+// withoutApprovals lets every operation through without a second admin.
+func withoutApprovals(t *testing.T) approval.Service {
+	approvals := approval.NewMockService(gomock.NewController(t))
+	approvals.EXPECT().Requires(gomock.Any(), gomock.Any()).Return(false).AnyTimes()
+	return approvals
+}
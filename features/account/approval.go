@@ -0,0 +1,56 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"payter-bank/features/approval"
+	"payter-bank/internal/database/models"
+)
+
+// adminCreation is what the approver sees of a new admin, the password hash is kept in the
+// request secret.
+type adminCreation struct {
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+}
+
+type adminCreationSecret struct {
+	PasswordHash string `json:"password_hash"`
+}
+
+func adminCreationRequest(params CreateUserParams, passwordHash string, requestedBy uuid.UUID) approval.SubmitParams {
+	return approval.SubmitParams{
+		Operation: approval.OperationAdminCreate,
+		Payload: adminCreation{
+			FirstName: params.FirstName,
+			LastName:  params.LastName,
+			Email:     params.Email,
+		},
+		Secret:      adminCreationSecret{PasswordHash: passwordHash},
+		RequestedBy: requestedBy,
+	}
+}
+
+// AdminCreationExecutor creates the admin user of an approved admin creation request.
+func AdminCreationExecutor(service Service) approval.Executor {
+	return func(ctx context.Context, request approval.Request) (any, error) {
+		var admin adminCreation
+		if err := json.Unmarshal(request.Payload, &admin); err != nil {
+			return nil, err
+		}
+		var secret adminCreationSecret
+		if err := json.Unmarshal(request.Secret, &secret); err != nil {
+			return nil, err
+		}
+
+		return service.CreateUser(ctx, CreateUserParams{
+			FirstName:    admin.FirstName,
+			LastName:     admin.LastName,
+			Email:        admin.Email,
+			UserType:     string(models.UserTypeADMIN),
+			PasswordHash: secret.PasswordHash,
+		})
+	}
+}
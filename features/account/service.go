@@ -7,47 +7,102 @@ import (
 	"errors"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"math"
 	"payter-bank/features/auditlog"
+	"payter-bank/features/lockout"
+	"payter-bank/features/mfa"
+	"payter-bank/features/session"
 	"payter-bank/features/transaction"
+	"payter-bank/internal/auth"
+	"payter-bank/internal/config"
+	"payter-bank/internal/database"
 	"payter-bank/internal/database/models"
 	platformerrors "payter-bank/internal/errors"
 	"payter-bank/internal/logger"
 	"payter-bank/internal/pkg/generator"
+	"payter-bank/internal/pkg/identifier"
 	"payter-bank/internal/pkg/password"
+	"strings"
+	"time"
 )
 
 type Service interface {
+	// InitialiseAdmin creates the bootstrap admin, who must change the configured password on
+	// first login. An existing admin still using it is made to change it as well.
 	InitialiseAdmin(ctx context.Context, email, password string) error
 	CreateUser(ctx context.Context, param CreateUserParams) (CreateUserResponse, error)
 	CreateAccount(ctx context.Context, param CreateAccountParams) (Profile, error)
+	// OpenPendingAccount opens a GBP current account awaiting admin activation, unless the user
+	// already has one.
+	OpenPendingAccount(ctx context.Context, userID uuid.UUID) error
+	// AuthenticateAccount checks the user's password. Users with MFA enabled get a challenge token
+	// instead of access tokens, which is completed with AuthenticateMFA. Users who must change their
+	// password get a password reset token instead. Repeated failures lock out the email and the
+	// client IP. Password hashes made with older parameters are upgraded. Every attempt is added to
+	// the login history.
 	AuthenticateAccount(ctx context.Context, param AuthenticateAccountParams) (AccessToken, error)
+	AuthenticateMFA(ctx context.Context, param mfa.VerifyChallengeParams) (AccessToken, error)
 	GetProfile(ctx context.Context, userID uuid.UUID) (Profile, error)
 	SuspendAccount(ctx context.Context, param OperationParams) error
 	ActivateAccount(ctx context.Context, param OperationParams) error
 	CloseAccount(ctx context.Context, param OperationParams) error
 	GetAccountStatusHistory(ctx context.Context, accountID uuid.UUID) ([]ChangeHistory, error)
 	GetAllAccounts(ctx context.Context) ([]Account, error)
+	SearchAccounts(ctx context.Context, param SearchAccountsParams) (AccountSearchResult, error)
 	GetAccountsStats(ctx context.Context) (models.GetAccountStatsRow, error)
 	GetAccountDetails(ctx context.Context, id uuid.UUID) (Account, error)
+	BackfillIdentifiers(ctx context.Context) error
+	// HashPassword checks a new password against the password policy and hashes it.
+	HashPassword(ctx context.Context, pwd, email string) (string, error)
+	ValidateAccountNumber(ctx context.Context, param ValidateAccountNumberParams) (IdentifierValidation, error)
+	ValidateIBAN(ctx context.Context, param ValidateIBANParams) (IdentifierValidation, error)
 }
 
+const (
+	maxAccountNumberAttempts = 5
+	accountNumberConstraint  = "accounts_account_number_key"
+	ibanConstraint           = "accounts_iban_key"
+
+	// dummyPasswordHash is checked against when the email is unknown so that the response takes
+	// as long as it does for a wrong password.
+	dummyPasswordHash = "$argon2id$v=19$m=19456,t=2,p=1$8Q9s8f4XhHCqvl3aRx2ahw$vnHn4hDHwUJXxn2oIyQliljbfHwtb73hL3I7xzM0kGY"
+
+	// passwordChangeTokenTTL is how long a user who must change their password has to do so
+	// after logging in.
+	passwordChangeTokenTTL = 15 * time.Minute
+)
+
+var errInvalidCredentials = platformerrors.MakeApiError(401, "invalid login credentials")
+
 type service struct {
 	db                 models.Querier
 	auditLog           auditlog.Service
 	transactionService transaction.Service
-	tokenGenerator     generator.TokenGenerator
+	sessions           session.Service
+	mfa                mfa.Service
+	lockout            lockout.Service
+	passwords          password.Policy
+	bank               config.BankConfig
 }
 
 func NewService(
 	db models.Querier,
 	auditLog auditlog.Service,
 	txService transaction.Service,
-	tokenGenerator generator.TokenGenerator) Service {
+	sessions session.Service,
+	mfaService mfa.Service,
+	lockoutService lockout.Service,
+	passwords password.Policy,
+	bank config.BankConfig) Service {
 	return &service{
 		db:                 db,
 		auditLog:           auditLog,
 		transactionService: txService,
-		tokenGenerator:     tokenGenerator,
+		sessions:           sessions,
+		mfa:                mfaService,
+		lockout:            lockoutService,
+		passwords:          passwords,
+		bank:               bank,
 	}
 }
 
@@ -64,35 +119,49 @@ func (s service) InitialiseAdmin(ctx context.Context, email, pwd string) error {
 	}
 
 	if user.Email != "" {
+		if !user.PasswordChangeRequired && password.DefaultPasswordHasher.Validate(user.Password, pwd) {
+			logger.Warn(ctx, "admin user still has the configured password, it must be changed on next login")
+			return s.requirePasswordChange(ctx, user.ID)
+		}
 		logger.Info(ctx, "admin user has already been created", zap.String("email", email))
 		return nil
 	}
 
+	hashed, err := password.DefaultPasswordHasher.Hash(pwd)
+	if err != nil {
+		logger.Error(ctx, "failed to hash admin password", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+
 	newUser, err := s.db.SaveUser(ctx, models.SaveUserParams{
-		Email:     email,
-		Password:  password.DefaultPasswordHasher.Hash(pwd),
-		FirstName: "Admin",
-		LastName:  "Admin",
-		UserType:  models.UserTypeADMIN,
+		Email:         email,
+		Password:      hashed,
+		FirstName:     "Admin",
+		LastName:      "Admin",
+		UserType:      models.UserTypeADMIN,
+		EmailVerified: true,
 	})
 	if err != nil {
 		logger.Error(ctx, "failed to save user", zap.Error(err))
 		return platformerrors.ErrInternal
 	}
 
-	_, err = s.db.SaveAccount(ctx, models.SaveAccountParams{
-		UserID:        newUser.ID,
-		AccountType:   models.AccountTypeCURRENT,
-		Status:        models.StatusACTIVE,
-		AccountNumber: generator.DefaultNumberGenerator.Generate(),
-		Currency:      models.CurrencyGBP,
-	})
+	_, err = s.saveAccount(ctx, newUser.ID, models.StatusACTIVE, models.CurrencyGBP)
 	if err != nil {
 		logger.Error(ctx, "failed to save account", zap.Error(err))
 		return platformerrors.ErrInternal
 	}
 
-	return nil
+	err = s.db.SetUserRoles(ctx, models.SetUserRolesParams{
+		UserID:    newUser.ID,
+		RoleNames: []string{auth.RoleSuperAdmin},
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to give admin the super admin role", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+
+	return s.requirePasswordChange(ctx, newUser.ID)
 }
 
 func (s service) CreateUser(ctx context.Context, param CreateUserParams) (CreateUserResponse, error) {
@@ -108,12 +177,22 @@ func (s service) CreateUser(ctx context.Context, param CreateUserParams) (Create
 		return CreateUserResponse{}, platformerrors.MakeApiError(400, "user already exists")
 	}
 
+	passwordHash := param.PasswordHash
+	if passwordHash == "" {
+		passwordHash, err = s.HashPassword(ctx, param.Password, param.Email)
+		if err != nil {
+			return CreateUserResponse{}, err
+		}
+	}
+
+	// users created by an admin are trusted, self-registered users verify their email first
 	newUser, err := s.db.SaveUser(ctx, models.SaveUserParams{
-		Email:     param.Email,
-		Password:  password.DefaultPasswordHasher.Hash(param.Password),
-		FirstName: param.FirstName,
-		LastName:  param.LastName,
-		UserType:  models.UserType(param.UserType),
+		Email:         param.Email,
+		Password:      passwordHash,
+		FirstName:     param.FirstName,
+		LastName:      param.LastName,
+		UserType:      models.UserType(param.UserType),
+		EmailVerified: true,
 	})
 	if err != nil {
 		logger.Error(ctx, "failed to save user", zap.Error(err))
@@ -138,6 +217,10 @@ func (s service) CreateAccount(ctx context.Context, param CreateAccountParams) (
 		return Profile{}, platformerrors.ErrInternal
 	}
 
+	if user.DeletedAt.Valid {
+		return Profile{}, platformerrors.MakeApiError(412, "user has been deactivated")
+	}
+
 	existingAccount, err := s.db.GetAccountByCurrency(ctx, models.GetAccountByCurrencyParams{
 		Currency: models.Currency(param.Currency),
 		UserID:   param.UserID,
@@ -153,15 +236,7 @@ func (s service) CreateAccount(ctx context.Context, param CreateAccountParams) (
 		return Profile{}, platformerrors.MakeApiError(400, "account already exists")
 	}
 
-	account := models.SaveAccountParams{
-		UserID:        user.ID,
-		AccountType:   models.AccountTypeCURRENT,
-		Status:        models.StatusACTIVE,
-		AccountNumber: generator.DefaultNumberGenerator.Generate(),
-		Currency:      models.Currency(param.Currency),
-	}
-
-	newAccount, err := s.db.SaveAccount(ctx, account)
+	newAccount, err := s.saveAccount(ctx, user.ID, models.StatusACTIVE, models.Currency(param.Currency))
 	if err != nil {
 		logger.Error(ctx, "failed to save account", zap.Error(err))
 		return Profile{}, platformerrors.ErrInternal
@@ -195,22 +270,79 @@ func (s service) CreateAccount(ctx context.Context, param CreateAccountParams) (
 	return ProfileFromQueryResult(p), nil
 }
 
+func (s service) OpenPendingAccount(ctx context.Context, userID uuid.UUID) error {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "OpenPendingAccount"),
+		zap.Any(logger.RequestFields, userID))
+
+	existingAccount, err := s.db.GetAccountByCurrency(ctx, models.GetAccountByCurrencyParams{
+		Currency: models.CurrencyGBP,
+		UserID:   userID,
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		logger.Error(ctx, "failed to get account by currency", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+
+	if existingAccount.ID != uuid.Nil {
+		return nil
+	}
+
+	newAccount, err := s.saveAccount(ctx, userID, models.StatusPENDING, models.CurrencyGBP)
+	if err != nil {
+		logger.Error(ctx, "failed to save account", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+
+	err = s.auditLog.Submit(ctx, auditlog.NewEvent(auditlog.ActionCreateAccount, userID, newAccount.ID, CreateAccountParams{
+		Currency: string(models.CurrencyGBP),
+		UserID:   userID,
+	}))
+	if err != nil {
+		logger.Error(ctx, "failed to queue audit log", zap.Error(err))
+	}
+	return nil
+}
+
 func (s service) AuthenticateAccount(ctx context.Context, param AuthenticateAccountParams) (AccessToken, error) {
 	ctx = logger.With(ctx,
 		zap.String(logger.FunctionName, "AuthenticateAccount"),
 		zap.Any(logger.RequestFields, param.Email))
 
+	attempt := lockout.Attempt{Email: param.Email, IP: param.IP}
+	if err := s.lockout.Check(ctx, attempt); err != nil {
+		s.recordLogin(ctx, param, uuid.Nil, session.OutcomeLockedOut)
+		return AccessToken{}, err
+	}
+
 	user, err := s.db.GetUserByEmail(ctx, param.Email)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return AccessToken{}, platformerrors.MakeApiError(401, "invalid login credentials")
+			password.DefaultPasswordHasher.Validate(dummyPasswordHash, param.Password)
+			s.lockout.RecordFailure(ctx, attempt)
+			s.recordLogin(ctx, param, uuid.Nil, session.OutcomeInvalidCredentials)
+			return AccessToken{}, errInvalidCredentials
 		}
 		logger.Error(ctx, "failed to get user by email", zap.Error(err))
 		return AccessToken{}, platformerrors.ErrInternal
 	}
 
 	if !password.DefaultPasswordHasher.Validate(user.Password, param.Password) {
-		return AccessToken{}, platformerrors.MakeApiError(401, "invalid login credentials")
+		s.lockout.RecordFailure(ctx, attempt)
+		s.recordLogin(ctx, param, user.ID, session.OutcomeInvalidCredentials)
+		return AccessToken{}, errInvalidCredentials
+	}
+	s.lockout.RecordSuccess(ctx, attempt)
+	s.rehashPassword(ctx, user.ID, user.Password, param.Password)
+
+	if user.DeletedAt.Valid {
+		s.recordLogin(ctx, param, user.ID, session.OutcomeDeactivated)
+		return AccessToken{}, platformerrors.MakeApiError(403, "user has been deactivated")
+	}
+
+	if !user.EmailVerifiedAt.Valid {
+		s.recordLogin(ctx, param, user.ID, session.OutcomeEmailUnverified)
+		return AccessToken{}, platformerrors.MakeApiError(403, "email address has not been verified")
 	}
 
 	profile, err := s.db.GetProfileByUserID(ctx, user.ID)
@@ -219,17 +351,180 @@ func (s service) AuthenticateAccount(ctx context.Context, param AuthenticateAcco
 		return AccessToken{}, platformerrors.ErrInternal
 	}
 
-	tokenData := generator.TokenData{
+	mfaEnabled, err := s.mfa.IsEnabled(ctx, user.ID)
+	if err != nil {
+		return AccessToken{}, err
+	}
+	if mfaEnabled {
+		challenge, err := s.mfa.Challenge(ctx, user.ID)
+		if err != nil {
+			return AccessToken{}, err
+		}
+		s.recordLogin(ctx, param, user.ID, session.OutcomeMFARequired)
+		return AccessToken{
+			MFARequired:    true,
+			ChallengeToken: challenge,
+		}, nil
+	}
+
+	if profile.PasswordChangeRequired {
+		s.recordLogin(ctx, param, user.ID, session.OutcomePasswordChangeRequired)
+		return s.passwordChangeToken(ctx, profile.UserID)
+	}
+
+	return s.startSession(ctx, profile.Email, generator.TokenData{
 		UserID:    profile.UserID,
 		AccountID: profile.AccountID,
+		IP:        param.IP,
+		UserAgent: param.UserAgent,
+	})
+}
+
+func (s service) AuthenticateMFA(ctx context.Context, param mfa.VerifyChallengeParams) (AccessToken, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "AuthenticateMFA"))
+
+	userID, err := s.mfa.VerifyChallenge(ctx, param)
+	if err != nil {
+		return AccessToken{}, err
 	}
-	token, err := s.tokenGenerator.Generate(tokenData)
+
+	// the user may have been deactivated since the challenge was issued
+	profile, err := s.db.GetProfileByUserID(ctx, userID)
 	if err != nil {
-		logger.Error(ctx, "failed to generate token", zap.Error(err))
+		if errors.Is(err, sql.ErrNoRows) {
+			return AccessToken{}, errInvalidCredentials
+		}
+		logger.Error(ctx, "failed to get profile by user id", zap.Error(err))
 		return AccessToken{}, platformerrors.ErrInternal
 	}
+
+	if profile.PasswordChangeRequired {
+		s.sessions.RecordLogin(ctx, session.LoginAttempt{
+			UserID:    profile.UserID,
+			Email:     profile.Email,
+			IP:        param.IP,
+			UserAgent: param.UserAgent,
+			Outcome:   session.OutcomePasswordChangeRequired,
+		})
+		return s.passwordChangeToken(ctx, profile.UserID)
+	}
+
+	return s.startSession(ctx, profile.Email, generator.TokenData{
+		UserID:        profile.UserID,
+		AccountID:     profile.AccountID,
+		MFAVerifiedAt: time.Now(),
+		IP:            param.IP,
+		UserAgent:     param.UserAgent,
+	})
+}
+
+// recordLogin adds a password login that did not start a session to the login history.
+func (s service) recordLogin(ctx context.Context, param AuthenticateAccountParams, userID uuid.UUID, outcome string) {
+	s.sessions.RecordLogin(ctx, session.LoginAttempt{
+		UserID:    userID,
+		Email:     param.Email,
+		IP:        param.IP,
+		UserAgent: param.UserAgent,
+		Outcome:   outcome,
+	})
+}
+
+// passwordChangeToken is returned instead of access tokens to a user who must change their
+// password. The token is sent with the new password to /users/reset-password, after which the
+// user logs in again.
+func (s service) passwordChangeToken(ctx context.Context, userID uuid.UUID) (AccessToken, error) {
+	err := s.db.InvalidateUserTokens(ctx, models.InvalidateUserTokensParams{
+		UserID:  userID,
+		Purpose: PurposePasswordReset,
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to invalidate previous password reset tokens", zap.Error(err))
+		return AccessToken{}, platformerrors.ErrInternal
+	}
+
+	token, hash := generator.DefaultUserTokenGenerator.Generate()
+	_, err = s.db.SaveUserToken(ctx, models.SaveUserTokenParams{
+		UserID:    userID,
+		Purpose:   PurposePasswordReset,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(passwordChangeTokenTTL),
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to save password reset token", zap.Error(err))
+		return AccessToken{}, platformerrors.ErrInternal
+	}
+
+	return AccessToken{
+		PasswordChangeRequired: true,
+		PasswordChangeToken:    token,
+	}, nil
+}
+
+// rehashPassword upgrades the hash of a password that has just been checked when it was made
+// with bcrypt or older argon2id parameters. The login goes ahead if this fails, it is tried again
+// on the next one.
+func (s service) rehashPassword(ctx context.Context, userID uuid.UUID, hashed, pwd string) {
+	if !password.DefaultPasswordHasher.NeedsRehash(hashed) {
+		return
+	}
+
+	rehashed, err := password.DefaultPasswordHasher.Hash(pwd)
+	if err != nil {
+		logger.Warn(ctx, "failed to rehash password", zap.Error(err))
+		return
+	}
+
+	// the update is skipped if the password was changed in the meantime
+	err = s.db.RehashUserPassword(ctx, models.RehashUserPasswordParams{
+		NewPassword: rehashed,
+		ID:          userID,
+		OldPassword: hashed,
+	})
+	if err != nil {
+		logger.Warn(ctx, "failed to save rehashed password", zap.Error(err))
+	}
+}
+
+func (s service) requirePasswordChange(ctx context.Context, userID uuid.UUID) error {
+	if err := s.db.RequirePasswordChange(ctx, userID); err != nil {
+		logger.Error(ctx, "failed to require a password change", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+	return nil
+}
+
+func (s service) HashPassword(ctx context.Context, pwd, email string) (string, error) {
+	if err := s.passwords.Check(pwd, email); err != nil {
+		return "", platformerrors.MakeApiError(400, err.Error())
+	}
+
+	hashed, err := password.DefaultPasswordHasher.Hash(pwd)
+	if err != nil {
+		logger.Error(ctx, "failed to hash password", zap.Error(err))
+		return "", platformerrors.ErrInternal
+	}
+	return hashed, nil
+}
+
+// startSession issues access tokens to a user who has logged in and records the login.
+func (s service) startSession(ctx context.Context, email string, tokenData generator.TokenData) (AccessToken, error) {
+	tokens, err := s.sessions.Create(ctx, tokenData)
+	if err != nil {
+		return AccessToken{}, err
+	}
+	s.sessions.RecordLogin(ctx, session.LoginAttempt{
+		UserID:    tokenData.UserID,
+		Email:     email,
+		IP:        tokenData.IP,
+		UserAgent: tokenData.UserAgent,
+		Outcome:   session.OutcomeSuccess,
+		SessionID: tokens.SessionID,
+	})
 	return AccessToken{
-		Token: token,
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
 	}, nil
 }
 
@@ -305,6 +600,10 @@ func (s service) ActivateAccount(ctx context.Context, param OperationParams) err
 		return nil
 	}
 
+	if account.Status == models.StatusDORMANT {
+		return platformerrors.MakeApiError(412, "dormant accounts must be reactivated through the reactivation flow")
+	}
+
 	err = s.db.UpdateAccountStatus(ctx, models.UpdateAccountStatusParams{
 		ID:     account.ID,
 		Status: models.StatusACTIVE,
@@ -401,6 +700,74 @@ func (s service) GetAllAccounts(ctx context.Context) ([]Account, error) {
 	return accounts, nil
 }
 
+func (s service) SearchAccounts(ctx context.Context, param SearchAccountsParams) (AccountSearchResult, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "SearchAccounts"),
+		zap.Any(logger.RequestFields, param))
+
+	if param.MinBalance != nil && param.MaxBalance != nil && *param.MinBalance > *param.MaxBalance {
+		return AccountSearchResult{}, platformerrors.MakeApiError(400, "min_balance cannot be greater than max_balance")
+	}
+	if !param.CreatedFrom.IsZero() && !param.CreatedTo.IsZero() && param.CreatedFrom.After(param.CreatedTo) {
+		return AccountSearchResult{}, platformerrors.MakeApiError(400, "created_from cannot be after created_to")
+	}
+
+	page, pageSize := param.Page, param.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultSearchPageSize
+	}
+	pageSize = min(pageSize, maxSearchPageSize)
+
+	createdTo := sql.NullTime{}
+	if !param.CreatedTo.IsZero() {
+		createdTo = sql.NullTime{Time: param.CreatedTo.AddDate(0, 0, 1), Valid: true}
+	}
+
+	sortBy := param.SortBy
+	if sortBy == "" {
+		sortBy = "created_at"
+	}
+
+	query := models.SearchAccountsParams{
+		Name:            nullString(strings.TrimSpace(param.Name)),
+		Email:           nullString(strings.TrimSpace(param.Email)),
+		AccountNumber:   nullString(strings.TrimSpace(param.AccountNumber)),
+		Status:          models.NullStatus{Status: models.Status(param.Status), Valid: param.Status != ""},
+		Currency:        models.NullCurrency{Currency: models.Currency(param.Currency), Valid: param.Currency != ""},
+		MinBalance:      nullMinorUnits(param.MinBalance),
+		MaxBalance:      nullMinorUnits(param.MaxBalance),
+		CreatedFrom:     sql.NullTime{Time: param.CreatedFrom, Valid: !param.CreatedFrom.IsZero()},
+		CreatedTo:       createdTo,
+		IncludeInternal: param.IncludeInternal,
+		SortBy:          sortBy,
+		SortDesc:        param.SortOrder != "asc",
+		PageSize:        int32(pageSize),
+		PageOffset:      int32((page - 1) * pageSize),
+	}
+	rows, err := s.db.SearchAccounts(ctx, query)
+	if err != nil {
+		logger.Error(ctx, "failed to search accounts", zap.Error(err))
+		return AccountSearchResult{}, platformerrors.ErrInternal
+	}
+
+	result := AccountSearchResult{
+		Accounts: make([]Account, 0, len(rows)),
+		Page:     page,
+		PageSize: pageSize,
+	}
+	for _, row := range rows {
+		result.Accounts = append(result.Accounts, AccountFromSearchRow(row))
+	}
+	if len(rows) > 0 {
+		result.TotalCount = rows[0].TotalCount
+		result.TotalPages = (result.TotalCount + int64(pageSize) - 1) / int64(pageSize)
+	}
+	return result, nil
+}
+
 func (s service) GetAccountsStats(ctx context.Context) (models.GetAccountStatsRow, error) {
 	return s.db.GetAccountStats(ctx)
 }
@@ -416,3 +783,175 @@ func (s service) GetAccountDetails(ctx context.Context, id uuid.UUID) (Account,
 	}
 	return AccountFromDetailsRow(row), nil
 }
+
+// saveAccount allocates a new account number and saves a CURRENT account with it. Account numbers
+// are random, when the generated number is already taken the save is retried with a fresh one.
+func (s service) saveAccount(ctx context.Context, userID uuid.UUID, status models.Status, currency models.Currency) (models.Account, error) {
+	for attempt := 1; ; attempt++ {
+		accountNumber := generator.DefaultNumberGenerator.Generate()
+		sortCode, iban, err := s.identifiers(accountNumber, currency)
+		if err != nil {
+			return models.Account{}, err
+		}
+
+		account, err := s.db.SaveAccount(ctx, models.SaveAccountParams{
+			UserID:        userID,
+			AccountType:   models.AccountTypeCURRENT,
+			Status:        status,
+			AccountNumber: accountNumber,
+			Currency:      currency,
+			SortCode:      sortCode,
+			Iban:          iban,
+		})
+		if err == nil {
+			return account, nil
+		}
+
+		conflict := database.IsUniqueViolation(err, accountNumberConstraint) || database.IsUniqueViolation(err, ibanConstraint)
+		if !conflict || attempt == maxAccountNumberAttempts {
+			return models.Account{}, err
+		}
+		logger.Warn(ctx, "generated account number is already taken, retrying",
+			zap.String("account_number", accountNumber),
+			zap.Int("attempt", attempt))
+	}
+}
+
+// identifiers returns the sort code and, for EUR accounts, the IBAN of an account number.
+func (s service) identifiers(accountNumber string, currency models.Currency) (sql.NullString, sql.NullString, error) {
+	sortCode := sql.NullString{String: s.bank.SortCode, Valid: true}
+	if currency != models.CurrencyEUR {
+		return sortCode, sql.NullString{}, nil
+	}
+
+	iban, err := identifier.NewIBAN(s.bank.CountryCode, s.bank.BankCode, s.bank.SortCode, accountNumber)
+	if err != nil {
+		return sql.NullString{}, sql.NullString{}, err
+	}
+	return sortCode, sql.NullString{String: iban, Valid: true}, nil
+}
+
+// BackfillIdentifiers sets the sort code and IBAN of accounts created before they were assigned.
+func (s service) BackfillIdentifiers(ctx context.Context) error {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "BackfillIdentifiers"))
+
+	accounts, err := s.db.GetAccountsMissingIdentifiers(ctx)
+	if err != nil {
+		logger.Error(ctx, "failed to get accounts missing identifiers", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+
+	for _, account := range accounts {
+		sortCode, iban, err := s.identifiers(account.AccountNumber, account.Currency)
+		if err != nil {
+			logger.Warn(ctx, "cannot derive account identifiers",
+				zap.String("account_id", account.ID.String()), zap.Error(err))
+			continue
+		}
+
+		err = s.db.UpdateAccountIdentifiers(ctx, models.UpdateAccountIdentifiersParams{
+			SortCode: sortCode,
+			Iban:     iban,
+			ID:       account.ID,
+		})
+		if err != nil {
+			logger.Error(ctx, "failed to update account identifiers",
+				zap.String("account_id", account.ID.String()), zap.Error(err))
+			return platformerrors.ErrInternal
+		}
+	}
+
+	if len(accounts) > 0 {
+		logger.Info(ctx, "account identifiers backfilled", zap.Int("count", len(accounts)))
+	}
+	return nil
+}
+
+func (s service) ValidateAccountNumber(ctx context.Context, param ValidateAccountNumberParams) (IdentifierValidation, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "ValidateAccountNumber"),
+		zap.Any(logger.RequestFields, param))
+
+	sortCode, err := identifier.NormaliseSortCode(param.SortCode)
+	if err != nil {
+		return IdentifierValidation{Reason: err.Error()}, nil
+	}
+
+	if err := identifier.ValidateAccountNumber(param.AccountNumber); err != nil {
+		return IdentifierValidation{SortCode: sortCode, Reason: err.Error()}, nil
+	}
+
+	result := IdentifierValidation{
+		Valid:         true,
+		SortCode:      sortCode,
+		AccountNumber: param.AccountNumber,
+	}
+	if sortCode != s.bank.SortCode {
+		return result, nil
+	}
+
+	return s.withAccount(ctx, result, func() (accountLookup, error) {
+		row, err := s.db.GetAccountByNumber(ctx, param.AccountNumber)
+		return accountLookup{currency: row.Currency, iban: row.Iban}, err
+	})
+}
+
+func (s service) ValidateIBAN(ctx context.Context, param ValidateIBANParams) (IdentifierValidation, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "ValidateIBAN"),
+		zap.Any(logger.RequestFields, param))
+
+	iban, err := identifier.ParseIBAN(param.IBAN)
+	if err != nil {
+		return IdentifierValidation{Reason: err.Error()}, nil
+	}
+
+	result := IdentifierValidation{
+		Valid:         true,
+		SortCode:      iban.SortCode,
+		AccountNumber: iban.AccountNumber,
+		IBAN:          iban.CountryCode + iban.CheckDigits + iban.BankCode + iban.SortCode + iban.AccountNumber,
+	}
+	if iban.CountryCode != s.bank.CountryCode || iban.BankCode != s.bank.BankCode || iban.SortCode != s.bank.SortCode {
+		return result, nil
+	}
+
+	return s.withAccount(ctx, result, func() (accountLookup, error) {
+		row, err := s.db.GetAccountByIBAN(ctx, sql.NullString{String: result.IBAN, Valid: true})
+		return accountLookup{currency: row.Currency, iban: row.Iban}, err
+	})
+}
+
+type accountLookup struct {
+	currency models.Currency
+	iban     sql.NullString
+}
+
+// withAccount marks a valid identifier issued by this bank as belonging to an existing account.
+func (s service) withAccount(ctx context.Context, result IdentifierValidation, lookup func() (accountLookup, error)) (IdentifierValidation, error) {
+	account, err := lookup()
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return result, nil
+		}
+		logger.Error(ctx, "failed to look up account", zap.Error(err))
+		return IdentifierValidation{}, platformerrors.ErrInternal
+	}
+
+	result.Exists = true
+	result.Currency = string(account.currency)
+	result.IBAN = account.iban.String
+	return result, nil
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+func nullMinorUnits(amount *float64) sql.NullInt64 {
+	if amount == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(math.Round(*amount * 100)), Valid: true}
+}
@@ -11,6 +11,7 @@ package account
 
 import (
 	context "context"
+	mfa "payter-bank/features/mfa"
 	models "payter-bank/internal/database/models"
 	reflect "reflect"
 
@@ -71,6 +72,35 @@ func (mr *MockServiceMockRecorder) AuthenticateAccount(ctx, param any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateAccount", reflect.TypeOf((*MockService)(nil).AuthenticateAccount), ctx, param)
 }
 
+// AuthenticateMFA mocks base method.
+func (m *MockService) AuthenticateMFA(ctx context.Context, param mfa.VerifyChallengeParams) (AccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateMFA", ctx, param)
+	ret0, _ := ret[0].(AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateMFA indicates an expected call of AuthenticateMFA.
+func (mr *MockServiceMockRecorder) AuthenticateMFA(ctx, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateMFA", reflect.TypeOf((*MockService)(nil).AuthenticateMFA), ctx, param)
+}
+
+// BackfillIdentifiers mocks base method.
+func (m *MockService) BackfillIdentifiers(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackfillIdentifiers", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BackfillIdentifiers indicates an expected call of BackfillIdentifiers.
+func (mr *MockServiceMockRecorder) BackfillIdentifiers(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackfillIdentifiers", reflect.TypeOf((*MockService)(nil).BackfillIdentifiers), ctx)
+}
+
 // CloseAccount mocks base method.
 func (m *MockService) CloseAccount(ctx context.Context, param OperationParams) error {
 	m.ctrl.T.Helper()
@@ -190,6 +220,21 @@ func (mr *MockServiceMockRecorder) GetProfile(ctx, userID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfile", reflect.TypeOf((*MockService)(nil).GetProfile), ctx, userID)
 }
 
+// HashPassword mocks base method.
+func (m *MockService) HashPassword(ctx context.Context, pwd, email string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HashPassword", ctx, pwd, email)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HashPassword indicates an expected call of HashPassword.
+func (mr *MockServiceMockRecorder) HashPassword(ctx, pwd, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashPassword", reflect.TypeOf((*MockService)(nil).HashPassword), ctx, pwd, email)
+}
+
 // InitialiseAdmin mocks base method.
 func (m *MockService) InitialiseAdmin(ctx context.Context, email, password string) error {
 	m.ctrl.T.Helper()
@@ -204,6 +249,35 @@ func (mr *MockServiceMockRecorder) InitialiseAdmin(ctx, email, password any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InitialiseAdmin", reflect.TypeOf((*MockService)(nil).InitialiseAdmin), ctx, email, password)
 }
 
+// OpenPendingAccount mocks base method.
+func (m *MockService) OpenPendingAccount(ctx context.Context, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenPendingAccount", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OpenPendingAccount indicates an expected call of OpenPendingAccount.
+func (mr *MockServiceMockRecorder) OpenPendingAccount(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenPendingAccount", reflect.TypeOf((*MockService)(nil).OpenPendingAccount), ctx, userID)
+}
+
+// SearchAccounts mocks base method.
+func (m *MockService) SearchAccounts(ctx context.Context, param SearchAccountsParams) (AccountSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchAccounts", ctx, param)
+	ret0, _ := ret[0].(AccountSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchAccounts indicates an expected call of SearchAccounts.
+func (mr *MockServiceMockRecorder) SearchAccounts(ctx, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchAccounts", reflect.TypeOf((*MockService)(nil).SearchAccounts), ctx, param)
+}
+
 // SuspendAccount mocks base method.
 func (m *MockService) SuspendAccount(ctx context.Context, param OperationParams) error {
 	m.ctrl.T.Helper()
@@ -217,3 +291,33 @@ func (mr *MockServiceMockRecorder) SuspendAccount(ctx, param any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuspendAccount", reflect.TypeOf((*MockService)(nil).SuspendAccount), ctx, param)
 }
+
+// ValidateAccountNumber mocks base method.
+func (m *MockService) ValidateAccountNumber(ctx context.Context, param ValidateAccountNumberParams) (IdentifierValidation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateAccountNumber", ctx, param)
+	ret0, _ := ret[0].(IdentifierValidation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateAccountNumber indicates an expected call of ValidateAccountNumber.
+func (mr *MockServiceMockRecorder) ValidateAccountNumber(ctx, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateAccountNumber", reflect.TypeOf((*MockService)(nil).ValidateAccountNumber), ctx, param)
+}
+
+// ValidateIBAN mocks base method.
+func (m *MockService) ValidateIBAN(ctx context.Context, param ValidateIBANParams) (IdentifierValidation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateIBAN", ctx, param)
+	ret0, _ := ret[0].(IdentifierValidation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateIBAN indicates an expected call of ValidateIBAN.
+func (mr *MockServiceMockRecorder) ValidateIBAN(ctx, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateIBAN", reflect.TypeOf((*MockService)(nil).ValidateIBAN), ctx, param)
+}
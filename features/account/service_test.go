@@ -5,10 +5,15 @@ import (
 	"database/sql"
 	"errors"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"payter-bank/features/auditlog"
+	"payter-bank/features/lockout"
+	"payter-bank/features/mfa"
+	"payter-bank/features/session"
 	"payter-bank/features/transaction"
+	"payter-bank/internal/config"
 	"payter-bank/internal/database/models"
 	databasemocks "payter-bank/internal/database/models/mocks"
 	platformerrors "payter-bank/internal/errors"
@@ -17,6 +22,7 @@ import (
 	"payter-bank/internal/pkg/password"
 	passwordhashermocks "payter-bank/internal/pkg/password/mocks"
 	"testing"
+	"time"
 )
 
 func TestService_InitialiseAdmin(t *testing.T) {
@@ -25,11 +31,12 @@ func TestService_InitialiseAdmin(t *testing.T) {
 		email, pwd := "testmail@test.com", "password"
 		userID, accountID, accountNumber := uuid.New(), uuid.New(), "00001111"
 		expectedSaveUserParams := models.SaveUserParams{
-			Email:     email,
-			Password:  "hashedPassword",
-			FirstName: "Admin",
-			LastName:  "Admin",
-			UserType:  "ADMIN",
+			Email:         email,
+			Password:      "hashedPassword",
+			FirstName:     "Admin",
+			LastName:      "Admin",
+			UserType:      "ADMIN",
+			EmailVerified: true,
 		}
 		expectedSaveAccountParams := models.SaveAccountParams{
 			UserID:        userID,
@@ -37,16 +44,22 @@ func TestService_InitialiseAdmin(t *testing.T) {
 			Status:        "ACTIVE",
 			AccountType:   "CURRENT",
 			Currency:      "GBP",
+			SortCode:      sql.NullString{String: "040004", Valid: true},
 		}
 
 		m.db.EXPECT().GetUserByEmail(gomock.Any(), email).
 			Return(models.GetUserByEmailRow{}, nil)
 		m.numberGenerator.EXPECT().Generate().Return("00001111")
-		m.passwordHasher.EXPECT().Hash("password").Return("hashedPassword")
+		m.passwordHasher.EXPECT().Hash("password").Return("hashedPassword", nil)
 		m.db.EXPECT().SaveUser(gomock.Any(), expectedSaveUserParams).
 			Return(models.SaveUserRow{ID: userID}, nil)
 		m.db.EXPECT().SaveAccount(gomock.Any(), expectedSaveAccountParams).
 			Return(models.Account{ID: accountID}, nil)
+		m.db.EXPECT().SetUserRoles(gomock.Any(), models.SetUserRolesParams{
+			UserID:    userID,
+			RoleNames: []string{"super_admin"},
+		}).Return(nil)
+		m.db.EXPECT().RequirePasswordChange(gomock.Any(), userID).Return(nil)
 
 		err := m.service.InitialiseAdmin(context.TODO(), email, pwd)
 		assert.Nil(t, err)
@@ -60,8 +73,45 @@ func TestService_InitialiseAdmin(t *testing.T) {
 			Return(models.GetUserByEmailRow{
 				ID:       uuid.New(),
 				Email:    email,
+				Password: "hashedPassword",
+				UserType: "ADMIN",
+			}, nil)
+		m.passwordHasher.EXPECT().Validate("hashedPassword", pwd).Return(false)
+
+		err := m.service.InitialiseAdmin(context.TODO(), email, pwd)
+		assert.NoError(t, err)
+	})
+
+	t.Run("requires a change when the admin still has the configured password", func(t *testing.T) {
+		m := mockAccountService(t)
+		email, pwd, userID := "admin@test.com", "password", uuid.New()
+
+		m.db.EXPECT().GetUserByEmail(gomock.Any(), email).
+			Return(models.GetUserByEmailRow{
+				ID:       userID,
+				Email:    email,
+				Password: "hashedPassword",
 				UserType: "ADMIN",
 			}, nil)
+		m.passwordHasher.EXPECT().Validate("hashedPassword", pwd).Return(true)
+		m.db.EXPECT().RequirePasswordChange(gomock.Any(), userID).Return(nil)
+
+		err := m.service.InitialiseAdmin(context.TODO(), email, pwd)
+		assert.NoError(t, err)
+	})
+
+	t.Run("leaves an admin that already has to change the password", func(t *testing.T) {
+		m := mockAccountService(t)
+		email, pwd := "admin@test.com", "password"
+
+		m.db.EXPECT().GetUserByEmail(gomock.Any(), email).
+			Return(models.GetUserByEmailRow{
+				ID:                     uuid.New(),
+				Email:                  email,
+				Password:               "hashedPassword",
+				UserType:               "ADMIN",
+				PasswordChangeRequired: true,
+			}, nil)
 
 		err := m.service.InitialiseAdmin(context.TODO(), email, pwd)
 		assert.NoError(t, err)
@@ -73,48 +123,164 @@ func TestService_AuthenticateAccount(t *testing.T) {
 		m := mockAccountService(t)
 		email, pwd := "test@example.com", "password"
 		userID, accountID := uuid.New(), uuid.New()
-		generatedToken := uuid.NewString()
+		tokens := session.Tokens{
+			AccessToken:  uuid.NewString(),
+			RefreshToken: uuid.NewString(),
+			ExpiresIn:    900,
+			SessionID:    uuid.New(),
+		}
 		expectedToken := AccessToken{
-			Token: generatedToken,
+			Token:        tokens.AccessToken,
+			RefreshToken: tokens.RefreshToken,
+			ExpiresIn:    tokens.ExpiresIn,
 		}
 		profile := models.GetProfileByUserIDRow{
 			AccountID: accountID,
 			UserID:    userID,
+			Email:     email,
 		}
 
+		attempt := lockout.Attempt{Email: email, IP: "10.0.0.1"}
+		m.lockout.EXPECT().Check(gomock.Any(), attempt).Return(nil)
+		m.lockout.EXPECT().RecordSuccess(gomock.Any(), attempt)
 		m.db.EXPECT().GetUserByEmail(gomock.Any(), email).
 			Return(models.GetUserByEmailRow{
-				ID:       userID,
-				Email:    email,
-				Password: "hashedPassword",
-				UserType: "ADMIN",
+				ID:              userID,
+				Email:           email,
+				Password:        "hashedPassword",
+				UserType:        "ADMIN",
+				EmailVerifiedAt: sql.NullTime{Time: time.Now(), Valid: true},
 			}, nil)
 		m.passwordHasher.EXPECT().Validate("hashedPassword", "password").
 			Return(true)
+		m.passwordHasher.EXPECT().NeedsRehash("hashedPassword").Return(false)
 		m.db.EXPECT().GetProfileByUserID(gomock.Any(), userID).
 			Return(profile, nil)
 
 		tokenData := generator.TokenData{
 			UserID:    userID,
 			AccountID: accountID,
+			IP:        "10.0.0.1",
+			UserAgent: "curl/8.5.0",
 		}
-		m.generator.EXPECT().Generate(tokenData).Return(generatedToken, nil)
+		m.mfa.EXPECT().IsEnabled(gomock.Any(), userID).Return(false, nil)
+		m.sessions.EXPECT().Create(gomock.Any(), tokenData).Return(tokens, nil)
+		m.sessions.EXPECT().RecordLogin(gomock.Any(), session.LoginAttempt{
+			UserID:    userID,
+			Email:     email,
+			IP:        "10.0.0.1",
+			UserAgent: "curl/8.5.0",
+			Outcome:   session.OutcomeSuccess,
+			SessionID: tokens.SessionID,
+		})
 
 		result, err := m.service.AuthenticateAccount(context.TODO(), AuthenticateAccountParams{
-			Email:    email,
-			Password: pwd,
+			Email:     email,
+			Password:  pwd,
+			IP:        "10.0.0.1",
+			UserAgent: "curl/8.5.0",
 		})
 
 		assert.NoError(t, err)
 		assert.Equal(t, expectedToken, result)
 	})
 
+	t.Run("fails without checking password when locked out", func(t *testing.T) {
+		m := mockAccountService(t)
+		attempt := lockout.Attempt{Email: "test@example.com", IP: "10.0.0.1"}
+
+		m.lockout.EXPECT().Check(gomock.Any(), attempt).Return(lockout.ErrLocked)
+		m.sessions.EXPECT().RecordLogin(gomock.Any(), session.LoginAttempt{
+			Email:   attempt.Email,
+			IP:      attempt.IP,
+			Outcome: session.OutcomeLockedOut,
+		})
+
+		result, err := m.service.AuthenticateAccount(context.TODO(), AuthenticateAccountParams{
+			Email:    attempt.Email,
+			Password: "password",
+			IP:       attempt.IP,
+		})
+
+		assert.Empty(t, result)
+		assert.Equal(t, lockout.ErrLocked, err)
+	})
+
+	t.Run("fails when user has been deactivated", func(t *testing.T) {
+		m := mockAccountService(t)
+		email, userID := "test@example.com", uuid.New()
+
+		m.lockout.EXPECT().Check(gomock.Any(), lockout.Attempt{Email: email}).Return(nil)
+		m.lockout.EXPECT().RecordSuccess(gomock.Any(), lockout.Attempt{Email: email})
+		m.db.EXPECT().GetUserByEmail(gomock.Any(), email).
+			Return(models.GetUserByEmailRow{
+				ID:        userID,
+				Email:     email,
+				Password:  "hashedPassword",
+				DeletedAt: sql.NullTime{Time: time.Now(), Valid: true},
+			}, nil)
+		m.passwordHasher.EXPECT().Validate("hashedPassword", "password").
+			Return(true)
+		m.passwordHasher.EXPECT().NeedsRehash("hashedPassword").Return(false)
+		m.sessions.EXPECT().RecordLogin(gomock.Any(), session.LoginAttempt{
+			UserID:  userID,
+			Email:   email,
+			Outcome: session.OutcomeDeactivated,
+		})
+
+		result, err := m.service.AuthenticateAccount(context.TODO(), AuthenticateAccountParams{
+			Email:    email,
+			Password: "password",
+		})
+
+		assert.Empty(t, result)
+		assert.Equal(t, platformerrors.MakeApiError(403, "user has been deactivated"), err)
+	})
+
+	t.Run("fails when email has not been verified", func(t *testing.T) {
+		m := mockAccountService(t)
+		email, userID := "test@example.com", uuid.New()
+
+		m.lockout.EXPECT().Check(gomock.Any(), lockout.Attempt{Email: email}).Return(nil)
+		m.lockout.EXPECT().RecordSuccess(gomock.Any(), lockout.Attempt{Email: email})
+		m.db.EXPECT().GetUserByEmail(gomock.Any(), email).
+			Return(models.GetUserByEmailRow{
+				ID:       userID,
+				Email:    email,
+				Password: "hashedPassword",
+			}, nil)
+		m.passwordHasher.EXPECT().Validate("hashedPassword", "password").
+			Return(true)
+		m.passwordHasher.EXPECT().NeedsRehash("hashedPassword").Return(false)
+		m.sessions.EXPECT().RecordLogin(gomock.Any(), session.LoginAttempt{
+			UserID:  userID,
+			Email:   email,
+			Outcome: session.OutcomeEmailUnverified,
+		})
+
+		result, err := m.service.AuthenticateAccount(context.TODO(), AuthenticateAccountParams{
+			Email:    email,
+			Password: "password",
+		})
+
+		assert.Empty(t, result)
+		assert.Equal(t, platformerrors.MakeApiError(403, "email address has not been verified"), err)
+	})
+
 	t.Run("fails when user not found", func(t *testing.T) {
 		m := mockAccountService(t)
 		email, pwd := "nonexistent@example.com", "password"
 
+		attempt := lockout.Attempt{Email: email}
+		m.lockout.EXPECT().Check(gomock.Any(), attempt).Return(nil)
+		m.passwordHasher.EXPECT().Validate(dummyPasswordHash, pwd).Return(false)
+		m.lockout.EXPECT().RecordFailure(gomock.Any(), attempt)
 		m.db.EXPECT().GetUserByEmail(gomock.Any(), email).
 			Return(models.GetUserByEmailRow{}, sql.ErrNoRows)
+		m.sessions.EXPECT().RecordLogin(gomock.Any(), session.LoginAttempt{
+			Email:   email,
+			Outcome: session.OutcomeInvalidCredentials,
+		})
 
 		result, err := m.service.AuthenticateAccount(context.TODO(), AuthenticateAccountParams{
 			Email:    email,
@@ -131,6 +297,9 @@ func TestService_AuthenticateAccount(t *testing.T) {
 		email, pwd := "test@example.com", "wrongpassword"
 		userID := uuid.New()
 
+		attempt := lockout.Attempt{Email: email}
+		m.lockout.EXPECT().Check(gomock.Any(), attempt).Return(nil)
+		m.lockout.EXPECT().RecordFailure(gomock.Any(), attempt)
 		m.db.EXPECT().GetUserByEmail(gomock.Any(), email).
 			Return(models.GetUserByEmailRow{
 				ID:       userID,
@@ -141,6 +310,11 @@ func TestService_AuthenticateAccount(t *testing.T) {
 
 		m.passwordHasher.EXPECT().Validate("hashedPassword", "wrongpassword").
 			Return(false)
+		m.sessions.EXPECT().RecordLogin(gomock.Any(), session.LoginAttempt{
+			UserID:  userID,
+			Email:   email,
+			Outcome: session.OutcomeInvalidCredentials,
+		})
 
 		result, err := m.service.AuthenticateAccount(context.TODO(), AuthenticateAccountParams{
 			Email:    email,
@@ -152,7 +326,7 @@ func TestService_AuthenticateAccount(t *testing.T) {
 		assert.Contains(t, err.Error(), "invalid login credentials")
 	})
 
-	t.Run("fails when token generation fails", func(t *testing.T) {
+	t.Run("fails when session cannot be created", func(t *testing.T) {
 		m := mockAccountService(t)
 		email, pwd := "test@example.com", "password"
 		userID, accountID := uuid.New(), uuid.New()
@@ -161,21 +335,26 @@ func TestService_AuthenticateAccount(t *testing.T) {
 			UserID:    userID,
 		}
 
+		m.lockout.EXPECT().Check(gomock.Any(), lockout.Attempt{Email: email}).Return(nil)
+		m.lockout.EXPECT().RecordSuccess(gomock.Any(), lockout.Attempt{Email: email})
 		m.db.EXPECT().GetUserByEmail(gomock.Any(), email).
 			Return(models.GetUserByEmailRow{
-				ID:       userID,
-				Email:    email,
-				Password: "hashedPassword",
-				UserType: "ADMIN",
+				ID:              userID,
+				Email:           email,
+				Password:        "hashedPassword",
+				UserType:        "ADMIN",
+				EmailVerifiedAt: sql.NullTime{Time: time.Now(), Valid: true},
 			}, nil)
 
 		m.passwordHasher.EXPECT().Validate("hashedPassword", "password").
 			Return(true)
+		m.passwordHasher.EXPECT().NeedsRehash("hashedPassword").Return(false)
 
-		m.generator.EXPECT().Generate(generator.TokenData{
+		m.mfa.EXPECT().IsEnabled(gomock.Any(), userID).Return(false, nil)
+		m.sessions.EXPECT().Create(gomock.Any(), generator.TokenData{
 			AccountID: accountID,
 			UserID:    userID,
-		}).Return("", errors.New("failed to generate token"))
+		}).Return(session.Tokens{}, platformerrors.ErrInternal)
 		m.db.EXPECT().GetProfileByUserID(gomock.Any(), userID).
 			Return(profile, nil)
 
@@ -190,6 +369,232 @@ func TestService_AuthenticateAccount(t *testing.T) {
 	})
 }
 
+func TestService_AuthenticateAccount_MFA(t *testing.T) {
+	t.Run("returns challenge when mfa is enabled", func(t *testing.T) {
+		m := mockAccountService(t)
+		userID := uuid.New()
+
+		m.lockout.EXPECT().Check(gomock.Any(), lockout.Attempt{Email: "test@example.com"}).Return(nil)
+		m.lockout.EXPECT().RecordSuccess(gomock.Any(), lockout.Attempt{Email: "test@example.com"})
+		m.db.EXPECT().GetUserByEmail(gomock.Any(), "test@example.com").
+			Return(models.GetUserByEmailRow{
+				ID:              userID,
+				Password:        "hashedPassword",
+				EmailVerifiedAt: sql.NullTime{Time: time.Now(), Valid: true},
+			}, nil)
+		m.passwordHasher.EXPECT().Validate("hashedPassword", "password").Return(true)
+		m.passwordHasher.EXPECT().NeedsRehash("hashedPassword").Return(false)
+		m.db.EXPECT().GetProfileByUserID(gomock.Any(), userID).
+			Return(models.GetProfileByUserIDRow{UserID: userID}, nil)
+		m.mfa.EXPECT().IsEnabled(gomock.Any(), userID).Return(true, nil)
+		m.mfa.EXPECT().Challenge(gomock.Any(), userID).Return("challenge-token", nil)
+		m.sessions.EXPECT().RecordLogin(gomock.Any(), session.LoginAttempt{
+			UserID:  userID,
+			Email:   "test@example.com",
+			Outcome: session.OutcomeMFARequired,
+		})
+
+		result, err := m.service.AuthenticateAccount(context.TODO(), AuthenticateAccountParams{
+			Email:    "test@example.com",
+			Password: "password",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, AccessToken{MFARequired: true, ChallengeToken: "challenge-token"}, result)
+	})
+}
+
+func TestService_AuthenticateAccount_Password(t *testing.T) {
+	email, pwd := "admin@example.com", "password"
+	userID, accountID := uuid.New(), uuid.New()
+	user := models.GetUserByEmailRow{
+		ID:              userID,
+		Email:           email,
+		Password:        "hashedPassword",
+		UserType:        "ADMIN",
+		EmailVerifiedAt: sql.NullTime{Time: time.Now(), Valid: true},
+	}
+	attempt := lockout.Attempt{Email: email}
+
+	t.Run("issues a password change token instead of access tokens", func(t *testing.T) {
+		m := mockAccountService(t)
+
+		m.lockout.EXPECT().Check(gomock.Any(), attempt).Return(nil)
+		m.lockout.EXPECT().RecordSuccess(gomock.Any(), attempt)
+		m.db.EXPECT().GetUserByEmail(gomock.Any(), email).Return(user, nil)
+		m.passwordHasher.EXPECT().Validate("hashedPassword", pwd).Return(true)
+		m.passwordHasher.EXPECT().NeedsRehash("hashedPassword").Return(false)
+		m.db.EXPECT().GetProfileByUserID(gomock.Any(), userID).
+			Return(models.GetProfileByUserIDRow{UserID: userID, AccountID: accountID, PasswordChangeRequired: true}, nil)
+		m.mfa.EXPECT().IsEnabled(gomock.Any(), userID).Return(false, nil)
+		m.sessions.EXPECT().RecordLogin(gomock.Any(), session.LoginAttempt{
+			UserID:  userID,
+			Email:   email,
+			Outcome: session.OutcomePasswordChangeRequired,
+		})
+		m.db.EXPECT().InvalidateUserTokens(gomock.Any(), models.InvalidateUserTokensParams{
+			UserID:  userID,
+			Purpose: PurposePasswordReset,
+		}).Return(nil)
+		m.tokenGenerator.EXPECT().Generate().Return("change-token", "change-token-hash")
+		m.db.EXPECT().SaveUserToken(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, arg models.SaveUserTokenParams) (models.UserToken, error) {
+				assert.Equal(t, userID, arg.UserID)
+				assert.Equal(t, PurposePasswordReset, arg.Purpose)
+				assert.Equal(t, "change-token-hash", arg.TokenHash)
+				assert.WithinDuration(t, time.Now().Add(passwordChangeTokenTTL), arg.ExpiresAt, time.Minute)
+				return models.UserToken{}, nil
+			})
+
+		result, err := m.service.AuthenticateAccount(context.TODO(), AuthenticateAccountParams{Email: email, Password: pwd})
+		assert.NoError(t, err)
+		assert.Equal(t, AccessToken{PasswordChangeRequired: true, PasswordChangeToken: "change-token"}, result)
+	})
+
+	t.Run("upgrades a legacy hash", func(t *testing.T) {
+		m := mockAccountService(t)
+		tokens := session.Tokens{AccessToken: "access", RefreshToken: "refresh", ExpiresIn: 900}
+
+		m.lockout.EXPECT().Check(gomock.Any(), attempt).Return(nil)
+		m.lockout.EXPECT().RecordSuccess(gomock.Any(), attempt)
+		m.db.EXPECT().GetUserByEmail(gomock.Any(), email).Return(user, nil)
+		m.passwordHasher.EXPECT().Validate("hashedPassword", pwd).Return(true)
+		m.passwordHasher.EXPECT().NeedsRehash("hashedPassword").Return(true)
+		m.passwordHasher.EXPECT().Hash(pwd).Return("argon2idHash", nil)
+		m.db.EXPECT().RehashUserPassword(gomock.Any(), models.RehashUserPasswordParams{
+			NewPassword: "argon2idHash",
+			ID:          userID,
+			OldPassword: "hashedPassword",
+		}).Return(nil)
+		m.db.EXPECT().GetProfileByUserID(gomock.Any(), userID).
+			Return(models.GetProfileByUserIDRow{UserID: userID, AccountID: accountID}, nil)
+		m.mfa.EXPECT().IsEnabled(gomock.Any(), userID).Return(false, nil)
+		m.sessions.EXPECT().Create(gomock.Any(), generator.TokenData{UserID: userID, AccountID: accountID}).Return(tokens, nil)
+		m.sessions.EXPECT().RecordLogin(gomock.Any(), gomock.Any())
+
+		result, err := m.service.AuthenticateAccount(context.TODO(), AuthenticateAccountParams{Email: email, Password: pwd})
+		assert.NoError(t, err)
+		assert.Equal(t, AccessToken{Token: "access", RefreshToken: "refresh", ExpiresIn: 900}, result)
+	})
+
+	t.Run("logs in when the upgraded hash cannot be saved", func(t *testing.T) {
+		m := mockAccountService(t)
+		tokens := session.Tokens{AccessToken: "access", RefreshToken: "refresh", ExpiresIn: 900}
+
+		m.lockout.EXPECT().Check(gomock.Any(), attempt).Return(nil)
+		m.lockout.EXPECT().RecordSuccess(gomock.Any(), attempt)
+		m.db.EXPECT().GetUserByEmail(gomock.Any(), email).Return(user, nil)
+		m.passwordHasher.EXPECT().Validate("hashedPassword", pwd).Return(true)
+		m.passwordHasher.EXPECT().NeedsRehash("hashedPassword").Return(true)
+		m.passwordHasher.EXPECT().Hash(pwd).Return("argon2idHash", nil)
+		m.db.EXPECT().RehashUserPassword(gomock.Any(), gomock.Any()).Return(sql.ErrConnDone)
+		m.db.EXPECT().GetProfileByUserID(gomock.Any(), userID).
+			Return(models.GetProfileByUserIDRow{UserID: userID, AccountID: accountID}, nil)
+		m.mfa.EXPECT().IsEnabled(gomock.Any(), userID).Return(false, nil)
+		m.sessions.EXPECT().Create(gomock.Any(), gomock.Any()).Return(tokens, nil)
+		m.sessions.EXPECT().RecordLogin(gomock.Any(), gomock.Any())
+
+		_, err := m.service.AuthenticateAccount(context.TODO(), AuthenticateAccountParams{Email: email, Password: pwd})
+		assert.NoError(t, err)
+	})
+}
+
+func TestService_HashPassword(t *testing.T) {
+	t.Run("hashes a password that follows the policy", func(t *testing.T) {
+		m := mockAccountService(t)
+		m.passwordHasher.EXPECT().Hash("Tangerine-Kite-42").Return("hashedPassword", nil)
+
+		hashed, err := m.service.HashPassword(context.TODO(), "Tangerine-Kite-42", "jane@example.com")
+		assert.NoError(t, err)
+		assert.Equal(t, "hashedPassword", hashed)
+	})
+
+	t.Run("rejects a password that breaks the policy", func(t *testing.T) {
+		m := mockAccountService(t)
+
+		_, err := m.service.HashPassword(context.TODO(), "admin", "admin@example.com")
+		assert.Equal(t, platformerrors.MakeApiError(400, "password must be at least 12 characters long"), err)
+
+		_, err = m.service.HashPassword(context.TODO(), "Password2025", "admin@example.com")
+		assert.Equal(t, platformerrors.MakeApiError(400, "password has appeared in a data breach, choose another one"), err)
+	})
+}
+
+func TestService_AuthenticateMFA(t *testing.T) {
+	params := mfa.VerifyChallengeParams{ChallengeToken: "challenge-token", Code: "123456", IP: "10.0.0.1", UserAgent: "curl/8.5.0"}
+
+	t.Run("starts mfa verified session", func(t *testing.T) {
+		m := mockAccountService(t)
+		userID, accountID, sessionID := uuid.New(), uuid.New(), uuid.New()
+
+		m.mfa.EXPECT().VerifyChallenge(gomock.Any(), params).Return(userID, nil)
+		m.db.EXPECT().GetProfileByUserID(gomock.Any(), userID).
+			Return(models.GetProfileByUserIDRow{UserID: userID, AccountID: accountID, Email: "test@example.com"}, nil)
+		m.sessions.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, data generator.TokenData) (session.Tokens, error) {
+				assert.Equal(t, userID, data.UserID)
+				assert.Equal(t, accountID, data.AccountID)
+				assert.False(t, data.MFAVerifiedAt.IsZero())
+				assert.Equal(t, "10.0.0.1", data.IP)
+				assert.Equal(t, "curl/8.5.0", data.UserAgent)
+				return session.Tokens{AccessToken: "access", RefreshToken: "refresh", ExpiresIn: 900, SessionID: sessionID}, nil
+			})
+		m.sessions.EXPECT().RecordLogin(gomock.Any(), session.LoginAttempt{
+			UserID:    userID,
+			Email:     "test@example.com",
+			IP:        "10.0.0.1",
+			UserAgent: "curl/8.5.0",
+			Outcome:   session.OutcomeSuccess,
+			SessionID: sessionID,
+		})
+
+		result, err := m.service.AuthenticateMFA(context.TODO(), params)
+		assert.NoError(t, err)
+		assert.Equal(t, AccessToken{Token: "access", RefreshToken: "refresh", ExpiresIn: 900}, result)
+	})
+
+	t.Run("asks for a password change once the code is verified", func(t *testing.T) {
+		m := mockAccountService(t)
+		userID := uuid.New()
+
+		m.mfa.EXPECT().VerifyChallenge(gomock.Any(), params).Return(userID, nil)
+		m.db.EXPECT().GetProfileByUserID(gomock.Any(), userID).
+			Return(models.GetProfileByUserIDRow{UserID: userID, PasswordChangeRequired: true}, nil)
+		m.sessions.EXPECT().RecordLogin(gomock.Any(), session.LoginAttempt{
+			UserID:    userID,
+			IP:        "10.0.0.1",
+			UserAgent: "curl/8.5.0",
+			Outcome:   session.OutcomePasswordChangeRequired,
+		})
+		m.db.EXPECT().InvalidateUserTokens(gomock.Any(), gomock.Any()).Return(nil)
+		m.tokenGenerator.EXPECT().Generate().Return("change-token", "change-token-hash")
+		m.db.EXPECT().SaveUserToken(gomock.Any(), gomock.Any()).Return(models.UserToken{}, nil)
+
+		result, err := m.service.AuthenticateMFA(context.TODO(), params)
+		assert.NoError(t, err)
+		assert.Equal(t, AccessToken{PasswordChangeRequired: true, PasswordChangeToken: "change-token"}, result)
+	})
+
+	t.Run("fails with invalid code", func(t *testing.T) {
+		m := mockAccountService(t)
+		invalidCode := platformerrors.MakeApiError(401, "invalid mfa code")
+		m.mfa.EXPECT().VerifyChallenge(gomock.Any(), params).Return(uuid.Nil, invalidCode)
+
+		_, err := m.service.AuthenticateMFA(context.TODO(), params)
+		assert.Equal(t, invalidCode, err)
+	})
+
+	t.Run("fails when user was deactivated", func(t *testing.T) {
+		m := mockAccountService(t)
+		userID := uuid.New()
+		m.mfa.EXPECT().VerifyChallenge(gomock.Any(), params).Return(userID, nil)
+		m.db.EXPECT().GetProfileByUserID(gomock.Any(), userID).
+			Return(models.GetProfileByUserIDRow{}, sql.ErrNoRows)
+
+		_, err := m.service.AuthenticateMFA(context.TODO(), params)
+		assert.Equal(t, platformerrors.MakeApiError(401, "invalid login credentials"), err)
+	})
+}
+
 func TestService_GetProfile(t *testing.T) {
 	t.Run("successfully gets user profile", func(t *testing.T) {
 		m := mockAccountService(t)
@@ -379,6 +784,20 @@ func TestService_ActivateAccount(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("activate account - refuses dormant account", func(t *testing.T) {
+		m := mockAccountService(t)
+		accountID, userID := uuid.New(), uuid.New()
+		m.db.EXPECT().GetAccountByID(gomock.Any(), accountID).
+			Return(models.GetAccountByIDRow{ID: accountID, Status: models.StatusDORMANT}, nil)
+
+		err := m.service.ActivateAccount(context.TODO(), OperationParams{
+			UserID:    userID,
+			AccountID: accountID,
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "reactivation flow")
+	})
+
 	t.Run("activate account - fails when account not found", func(t *testing.T) {
 		m := mockAccountService(t)
 		accountID, userID := uuid.New(), uuid.New()
@@ -572,10 +991,393 @@ func TestService_GetAccountStatusHistory(t *testing.T) {
 	})
 }
 
+func TestService_CreateAccount(t *testing.T) {
+	t.Run("retries with a new account number when the generated one is taken", func(t *testing.T) {
+		m := mockAccountService(t)
+		userID, adminID, accountID := uuid.New(), uuid.New(), uuid.New()
+		param := CreateAccountParams{
+			Currency:    "EUR",
+			UserID:      userID,
+			AdminUserID: adminID,
+		}
+		conflict := &pq.Error{Code: "23505", Constraint: "accounts_account_number_key"}
+
+		m.db.EXPECT().GetUserByID(gomock.Any(), userID).
+			Return(models.GetUserByIDRow{ID: userID}, nil)
+		m.db.EXPECT().GetAccountByCurrency(gomock.Any(), models.GetAccountByCurrencyParams{
+			Currency: models.CurrencyEUR,
+			UserID:   userID,
+		}).Return(models.Account{}, sql.ErrNoRows)
+		gomock.InOrder(
+			m.numberGenerator.EXPECT().Generate().Return("12345679"),
+			m.numberGenerator.EXPECT().Generate().Return("98765432"),
+		)
+		gomock.InOrder(
+			m.db.EXPECT().SaveAccount(gomock.Any(), models.SaveAccountParams{
+				UserID:        userID,
+				AccountNumber: "12345679",
+				Status:        models.StatusACTIVE,
+				AccountType:   models.AccountTypeCURRENT,
+				Currency:      models.CurrencyEUR,
+				SortCode:      sql.NullString{String: "040004", Valid: true},
+				Iban:          sql.NullString{String: "GB88PAYT04000412345679", Valid: true},
+			}).Return(models.Account{}, conflict),
+			m.db.EXPECT().SaveAccount(gomock.Any(), models.SaveAccountParams{
+				UserID:        userID,
+				AccountNumber: "98765432",
+				Status:        models.StatusACTIVE,
+				AccountType:   models.AccountTypeCURRENT,
+				Currency:      models.CurrencyEUR,
+				SortCode:      sql.NullString{String: "040004", Valid: true},
+				Iban:          sql.NullString{String: "GB11PAYT04000498765432", Valid: true},
+			}).Return(models.Account{ID: accountID}, nil),
+		)
+		m.auditLog.EXPECT().Submit(gomock.Any(), gomock.Any()).Return(nil)
+		m.db.EXPECT().GetProfileByUserID(gomock.Any(), userID).
+			Return(models.GetProfileByUserIDRow{UserID: userID, AccountID: accountID}, nil)
+
+		profile, err := m.service.CreateAccount(context.TODO(), param)
+		assert.NoError(t, err)
+		assert.Equal(t, accountID, profile.AccountID)
+	})
+
+	t.Run("gives up after repeated account number conflicts", func(t *testing.T) {
+		m := mockAccountService(t)
+		userID := uuid.New()
+		conflict := &pq.Error{Code: "23505", Constraint: "accounts_account_number_key"}
+
+		m.db.EXPECT().GetUserByID(gomock.Any(), userID).
+			Return(models.GetUserByIDRow{ID: userID}, nil)
+		m.db.EXPECT().GetAccountByCurrency(gomock.Any(), gomock.Any()).
+			Return(models.Account{}, sql.ErrNoRows)
+		m.numberGenerator.EXPECT().Generate().Return("12345679").Times(maxAccountNumberAttempts)
+		m.db.EXPECT().SaveAccount(gomock.Any(), gomock.Any()).
+			Return(models.Account{}, conflict).Times(maxAccountNumberAttempts)
+
+		_, err := m.service.CreateAccount(context.TODO(), CreateAccountParams{Currency: "GBP", UserID: userID})
+		assert.Equal(t, platformerrors.ErrInternal, err)
+	})
+
+	t.Run("does not retry other save errors", func(t *testing.T) {
+		m := mockAccountService(t)
+		userID := uuid.New()
+
+		m.db.EXPECT().GetUserByID(gomock.Any(), userID).
+			Return(models.GetUserByIDRow{ID: userID}, nil)
+		m.db.EXPECT().GetAccountByCurrency(gomock.Any(), gomock.Any()).
+			Return(models.Account{}, sql.ErrNoRows)
+		m.numberGenerator.EXPECT().Generate().Return("12345679")
+		m.db.EXPECT().SaveAccount(gomock.Any(), gomock.Any()).
+			Return(models.Account{}, sql.ErrConnDone)
+
+		_, err := m.service.CreateAccount(context.TODO(), CreateAccountParams{Currency: "GBP", UserID: userID})
+		assert.Equal(t, platformerrors.ErrInternal, err)
+	})
+}
+
+func TestService_OpenPendingAccount(t *testing.T) {
+	t.Run("opens pending gbp account", func(t *testing.T) {
+		m := mockAccountService(t)
+		userID, accountID := uuid.New(), uuid.New()
+
+		m.db.EXPECT().GetAccountByCurrency(gomock.Any(), models.GetAccountByCurrencyParams{
+			Currency: models.CurrencyGBP,
+			UserID:   userID,
+		}).Return(models.Account{}, sql.ErrNoRows)
+		m.numberGenerator.EXPECT().Generate().Return("12345678")
+		m.db.EXPECT().SaveAccount(gomock.Any(), models.SaveAccountParams{
+			UserID:        userID,
+			AccountNumber: "12345678",
+			Status:        models.StatusPENDING,
+			AccountType:   models.AccountTypeCURRENT,
+			Currency:      models.CurrencyGBP,
+			SortCode:      sql.NullString{String: "040004", Valid: true},
+		}).Return(models.Account{ID: accountID}, nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionCreateAccount, userID, accountID,
+			CreateAccountParams{Currency: "GBP", UserID: userID})).Return(nil)
+
+		err := m.service.OpenPendingAccount(context.TODO(), userID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("does nothing when user already has an account", func(t *testing.T) {
+		m := mockAccountService(t)
+		userID := uuid.New()
+
+		m.db.EXPECT().GetAccountByCurrency(gomock.Any(), gomock.Any()).
+			Return(models.Account{ID: uuid.New()}, nil)
+
+		err := m.service.OpenPendingAccount(context.TODO(), userID)
+		assert.NoError(t, err)
+	})
+}
+
+func TestService_BackfillIdentifiers(t *testing.T) {
+	t.Run("sets sort code and eur iban on existing accounts", func(t *testing.T) {
+		m := mockAccountService(t)
+		gbpAccount, eurAccount := uuid.New(), uuid.New()
+
+		m.db.EXPECT().GetAccountsMissingIdentifiers(gomock.Any()).
+			Return([]models.GetAccountsMissingIdentifiersRow{
+				{ID: gbpAccount, AccountNumber: "12345679", Currency: models.CurrencyGBP},
+				{ID: eurAccount, AccountNumber: "98765432", Currency: models.CurrencyEUR},
+			}, nil)
+		m.db.EXPECT().UpdateAccountIdentifiers(gomock.Any(), models.UpdateAccountIdentifiersParams{
+			SortCode: sql.NullString{String: "040004", Valid: true},
+			ID:       gbpAccount,
+		}).Return(nil)
+		m.db.EXPECT().UpdateAccountIdentifiers(gomock.Any(), models.UpdateAccountIdentifiersParams{
+			SortCode: sql.NullString{String: "040004", Valid: true},
+			Iban:     sql.NullString{String: "GB11PAYT04000498765432", Valid: true},
+			ID:       eurAccount,
+		}).Return(nil)
+
+		assert.NoError(t, m.service.BackfillIdentifiers(context.TODO()))
+	})
+}
+
+func TestService_ValidateAccountNumber(t *testing.T) {
+	t.Run("valid account number of an existing account", func(t *testing.T) {
+		m := mockAccountService(t)
+		m.db.EXPECT().GetAccountByNumber(gomock.Any(), "12345679").
+			Return(models.GetAccountByNumberRow{Currency: models.CurrencyGBP}, nil)
+
+		result, err := m.service.ValidateAccountNumber(context.TODO(), ValidateAccountNumberParams{
+			SortCode:      "04-00-04",
+			AccountNumber: "12345679",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, IdentifierValidation{
+			Valid:         true,
+			SortCode:      "040004",
+			AccountNumber: "12345679",
+			Exists:        true,
+			Currency:      "GBP",
+		}, result)
+	})
+
+	t.Run("valid account number without an account", func(t *testing.T) {
+		m := mockAccountService(t)
+		m.db.EXPECT().GetAccountByNumber(gomock.Any(), "12345679").
+			Return(models.GetAccountByNumberRow{}, sql.ErrNoRows)
+
+		result, err := m.service.ValidateAccountNumber(context.TODO(), ValidateAccountNumberParams{
+			SortCode:      "040004",
+			AccountNumber: "12345679",
+		})
+		assert.NoError(t, err)
+		assert.True(t, result.Valid)
+		assert.False(t, result.Exists)
+	})
+
+	t.Run("another bank's sort code is not looked up", func(t *testing.T) {
+		m := mockAccountService(t)
+
+		result, err := m.service.ValidateAccountNumber(context.TODO(), ValidateAccountNumberParams{
+			SortCode:      "200000",
+			AccountNumber: "12345679",
+		})
+		assert.NoError(t, err)
+		assert.True(t, result.Valid)
+		assert.False(t, result.Exists)
+	})
+
+	t.Run("account number failing the modulus check", func(t *testing.T) {
+		m := mockAccountService(t)
+
+		result, err := m.service.ValidateAccountNumber(context.TODO(), ValidateAccountNumberParams{
+			SortCode:      "040004",
+			AccountNumber: "12345678",
+		})
+		assert.NoError(t, err)
+		assert.False(t, result.Valid)
+		assert.Equal(t, "account number failed modulus check", result.Reason)
+	})
+
+	t.Run("lookup failure", func(t *testing.T) {
+		m := mockAccountService(t)
+		m.db.EXPECT().GetAccountByNumber(gomock.Any(), "12345679").
+			Return(models.GetAccountByNumberRow{}, sql.ErrConnDone)
+
+		_, err := m.service.ValidateAccountNumber(context.TODO(), ValidateAccountNumberParams{
+			SortCode:      "040004",
+			AccountNumber: "12345679",
+		})
+		assert.Equal(t, platformerrors.ErrInternal, err)
+	})
+}
+
+func TestService_ValidateIBAN(t *testing.T) {
+	t.Run("valid iban of an existing account", func(t *testing.T) {
+		m := mockAccountService(t)
+		iban := "GB11PAYT04000498765432"
+		m.db.EXPECT().GetAccountByIBAN(gomock.Any(), sql.NullString{String: iban, Valid: true}).
+			Return(models.GetAccountByIBANRow{
+				Currency: models.CurrencyEUR,
+				Iban:     sql.NullString{String: iban, Valid: true},
+			}, nil)
+
+		result, err := m.service.ValidateIBAN(context.TODO(), ValidateIBANParams{IBAN: "GB11 PAYT 0400 0498 7654 32"})
+		assert.NoError(t, err)
+		assert.Equal(t, IdentifierValidation{
+			Valid:         true,
+			SortCode:      "040004",
+			AccountNumber: "98765432",
+			IBAN:          iban,
+			Exists:        true,
+			Currency:      "EUR",
+		}, result)
+	})
+
+	t.Run("iban of another bank", func(t *testing.T) {
+		m := mockAccountService(t)
+
+		result, err := m.service.ValidateIBAN(context.TODO(), ValidateIBANParams{IBAN: "GB82WEST12345698765432"})
+		assert.NoError(t, err)
+		assert.True(t, result.Valid)
+		assert.False(t, result.Exists)
+	})
+
+	t.Run("iban with wrong check digits", func(t *testing.T) {
+		m := mockAccountService(t)
+
+		result, err := m.service.ValidateIBAN(context.TODO(), ValidateIBANParams{IBAN: "GB12PAYT04000498765432"})
+		assert.NoError(t, err)
+		assert.False(t, result.Valid)
+		assert.Equal(t, "iban failed mod-97 check", result.Reason)
+	})
+}
+
+func TestService_GetAllAccounts(t *testing.T) {
+	t.Run("maps currency from the account", func(t *testing.T) {
+		m := mockAccountService(t)
+		m.db.EXPECT().GetAllCurrentAccounts(gomock.Any()).Return([]models.GetAllCurrentAccountsRow{{
+			AccountID: uuid.New(),
+			Balance:   sql.NullInt64{Int64: 1050, Valid: true},
+			Status:    models.StatusACTIVE,
+			Currency:  models.CurrencyEUR,
+		}}, nil)
+
+		accounts, err := m.service.GetAllAccounts(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, "EUR", accounts[0].Currency)
+		assert.Equal(t, "ACTIVE", accounts[0].Status)
+		assert.Equal(t, Amount{Amount: 10.5, Currency: "EUR"}, accounts[0].Balance)
+	})
+}
+
+func TestService_SearchAccounts(t *testing.T) {
+	t.Run("applies defaults", func(t *testing.T) {
+		m := mockAccountService(t)
+		m.db.EXPECT().SearchAccounts(gomock.Any(), models.SearchAccountsParams{
+			SortBy:   "created_at",
+			SortDesc: true,
+			PageSize: 20,
+		}).Return(nil, nil)
+
+		result, err := m.service.SearchAccounts(context.TODO(), SearchAccountsParams{})
+		assert.NoError(t, err)
+		assert.Equal(t, AccountSearchResult{Accounts: []Account{}, Page: 1, PageSize: 20}, result)
+	})
+
+	t.Run("maps filters and paginates", func(t *testing.T) {
+		m := mockAccountService(t)
+		minBalance, maxBalance := 10.0, 250.55
+		accountID := uuid.New()
+		m.db.EXPECT().SearchAccounts(gomock.Any(), models.SearchAccountsParams{
+			Name:            sql.NullString{String: "jane", Valid: true},
+			Email:           sql.NullString{String: "example.com", Valid: true},
+			AccountNumber:   sql.NullString{String: "1234", Valid: true},
+			Status:          models.NullStatus{Status: models.StatusACTIVE, Valid: true},
+			Currency:        models.NullCurrency{Currency: models.CurrencyGBP, Valid: true},
+			MinBalance:      sql.NullInt64{Int64: 1000, Valid: true},
+			MaxBalance:      sql.NullInt64{Int64: 25055, Valid: true},
+			CreatedFrom:     sql.NullTime{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+			CreatedTo:       sql.NullTime{Time: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+			IncludeInternal: true,
+			SortBy:          "balance",
+			SortDesc:        false,
+			PageSize:        10,
+			PageOffset:      20,
+		}).Return([]models.SearchAccountsRow{{
+			AccountID:   accountID,
+			Email:       "jane@example.com",
+			UserType:    models.UserTypeCUSTOMER,
+			AccountType: models.AccountTypeCURRENT,
+			Status:      models.StatusACTIVE,
+			Currency:    models.CurrencyGBP,
+			Balance:     5000,
+			TotalCount:  21,
+		}}, nil)
+
+		result, err := m.service.SearchAccounts(context.TODO(), SearchAccountsParams{
+			Name:            " jane ",
+			Email:           "example.com",
+			AccountNumber:   "1234",
+			Status:          "ACTIVE",
+			Currency:        "GBP",
+			MinBalance:      &minBalance,
+			MaxBalance:      &maxBalance,
+			CreatedFrom:     time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			CreatedTo:       time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC),
+			IncludeInternal: true,
+			SortBy:          "balance",
+			SortOrder:       "asc",
+			Page:            3,
+			PageSize:        10,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(21), result.TotalCount)
+		assert.Equal(t, int64(3), result.TotalPages)
+		assert.Equal(t, 3, result.Page)
+		assert.Len(t, result.Accounts, 1)
+		assert.Equal(t, accountID, result.Accounts[0].AccountID)
+		assert.Equal(t, "GBP", result.Accounts[0].Currency)
+		assert.Equal(t, Amount{Amount: 50, Currency: "GBP"}, result.Accounts[0].Balance)
+	})
+
+	t.Run("rejects inverted balance range", func(t *testing.T) {
+		m := mockAccountService(t)
+		minBalance, maxBalance := 100.0, 10.0
+
+		_, err := m.service.SearchAccounts(context.TODO(), SearchAccountsParams{MinBalance: &minBalance, MaxBalance: &maxBalance})
+		assert.Equal(t, platformerrors.MakeApiError(400, "min_balance cannot be greater than max_balance"), err)
+	})
+
+	t.Run("rejects inverted date range", func(t *testing.T) {
+		m := mockAccountService(t)
+
+		_, err := m.service.SearchAccounts(context.TODO(), SearchAccountsParams{
+			CreatedFrom: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+			CreatedTo:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		})
+		assert.Equal(t, platformerrors.MakeApiError(400, "created_from cannot be after created_to"), err)
+	})
+
+	t.Run("fails when query fails", func(t *testing.T) {
+		m := mockAccountService(t)
+		m.db.EXPECT().SearchAccounts(gomock.Any(), gomock.Any()).Return(nil, errors.New("db down"))
+
+		_, err := m.service.SearchAccounts(context.TODO(), SearchAccountsParams{})
+		assert.Equal(t, platformerrors.ErrInternal, err)
+	})
+}
+
+var testPasswordPolicy = password.Policy{
+	MinLength:      12,
+	RequireUpper:   true,
+	RequireLower:   true,
+	RequireDigit:   true,
+	RejectBreached: true,
+}
+
 type accountServiceMocker struct {
 	db              *databasemocks.MockQuerier
-	generator       *generatormocks.MockTokenGenerator
+	sessions        *session.MockService
+	mfa             *mfa.MockService
+	lockout         *lockout.MockService
 	numberGenerator *generatormocks.MockNumberGenerator
+	tokenGenerator  *generatormocks.MockUserTokenGenerator
 	passwordHasher  *passwordhashermocks.MockHasher
 	txService       *transaction.MockService
 	auditLog        *auditlog.MockService
@@ -586,22 +1388,35 @@ type accountServiceMocker struct {
 func mockAccountService(t *testing.T) *accountServiceMocker {
 	ctrl := gomock.NewController(t)
 	mockDB := databasemocks.NewMockQuerier(ctrl)
-	mockGenerator := generatormocks.NewMockTokenGenerator(ctrl)
+	sessionsMock := session.NewMockService(ctrl)
+	mfaMock := mfa.NewMockService(ctrl)
+	lockoutMock := lockout.NewMockService(ctrl)
 	mockNumberGen := generatormocks.NewMockNumberGenerator(ctrl)
+	tokenGenerator := generatormocks.NewMockUserTokenGenerator(ctrl)
 	passwordHasher := passwordhashermocks.NewMockHasher(ctrl)
 	txServiceMock := transaction.NewMockService(ctrl)
 	auditLogMock := auditlog.NewMockService(ctrl)
 
 	generator.DefaultNumberGenerator = mockNumberGen
+	generator.DefaultUserTokenGenerator = tokenGenerator
 	password.DefaultPasswordHasher = passwordHasher
 
-	svc := NewService(mockDB, auditLogMock, txServiceMock, mockGenerator)
+	bank := config.BankConfig{
+		SortCode:    "040004",
+		BankCode:    "PAYT",
+		CountryCode: "GB",
+	}
+
+	svc := NewService(mockDB, auditLogMock, txServiceMock, sessionsMock, mfaMock, lockoutMock, testPasswordPolicy, bank)
 	return &accountServiceMocker{
 		db:              mockDB,
-		generator:       mockGenerator,
+		sessions:        sessionsMock,
+		mfa:             mfaMock,
+		lockout:         lockoutMock,
 		txService:       txServiceMock,
 		auditLog:        auditLogMock,
 		numberGenerator: mockNumberGen,
+		tokenGenerator:  tokenGenerator,
 		passwordHasher:  passwordHasher,
 		service:         svc,
 	}
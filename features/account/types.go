@@ -6,6 +6,12 @@ import (
 	"time"
 )
 
+const (
+	// PurposePasswordReset marks tokens that let a user set a new password without the current
+	// one. They are emailed on request and issued on login to users who must change their password.
+	PurposePasswordReset = "password_reset"
+)
+
 type Profile struct {
 	AccountID    uuid.UUID `json:"account_id"`
 	UserID       uuid.UUID `json:"user_id"`
@@ -32,15 +38,20 @@ func ProfileFromQueryResult(r models.GetProfileByUserIDRow) Profile {
 
 type AuthenticateAccountParams struct {
 	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required"`
+	Password  string `json:"password" binding:"required"`
+	IP        string `json:"-"`
+	UserAgent string `json:"-"`
 }
 
+// CreateUserParams creates a user. PasswordHash is set instead of Password when an approved admin
+// creation runs, the password is hashed when the request is made so it is never stored.
 type CreateUserParams struct {
-	FirstName string `json:"first_name" binding:"required"`
-	LastName  string `json:"last_name" binding:"required"`
-	Email     string `json:"email" binding:"required,email"`
-	Password  string `json:"password" binding:"required"`
-	UserType  string `json:"user_type" binding:"required,oneof=CUSTOMER ADMIN"`
+	FirstName    string `json:"first_name" binding:"required"`
+	LastName     string `json:"last_name" binding:"required"`
+	Email        string `json:"email" binding:"required,email"`
+	Password     string `json:"password" binding:"required"`
+	UserType     string `json:"user_type" binding:"required,oneof=CUSTOMER ADMIN"`
+	PasswordHash string `json:"-"`
 }
 
 type CreateUserResponse struct {
@@ -59,8 +70,18 @@ type OperationParams struct {
 	AccountID uuid.UUID
 }
 
+// AccessToken is returned on login. The access token is short-lived, the refresh token is
+// exchanged for a new pair at /users/refresh. When MFARequired is set no tokens are issued yet,
+// ChallengeToken is sent with a code to /users/authenticate/mfa. When PasswordChangeRequired is
+// set PasswordChangeToken is sent with a new password to /users/reset-password instead.
 type AccessToken struct {
-	Token string `json:"token"`
+	Token                  string `json:"token,omitempty"`
+	RefreshToken           string `json:"refresh_token,omitempty"`
+	ExpiresIn              int64  `json:"expires_in,omitempty"`
+	MFARequired            bool   `json:"mfa_required,omitempty"`
+	ChallengeToken         string `json:"challenge_token,omitempty"`
+	PasswordChangeRequired bool   `json:"password_change_required,omitempty"`
+	PasswordChangeToken    string `json:"password_change_token,omitempty"`
 }
 
 type ChangeHistory struct {
@@ -101,6 +122,13 @@ type Account struct {
 	CreatedAt     time.Time `json:"created_at"`
 	FirstName     string    `json:"first_name"`
 	LastName      string    `json:"last_name"`
+	SortCode      string    `json:"sort_code,omitempty"`
+	IBAN          string    `json:"iban,omitempty"`
+	Email         string    `json:"email,omitempty"`
+	UserType      string    `json:"user_type,omitempty"`
+	// AccruedInterest is interest accrued since it was last capitalised, it is not part of the
+	// balance yet. It is only set on the details of a single account.
+	AccruedInterest *Amount `json:"accrued_interest,omitempty"`
 }
 
 func AccountFromQuery(row models.GetAllCurrentAccountsRow) Account {
@@ -109,7 +137,7 @@ func AccountFromQuery(row models.GetAllCurrentAccountsRow) Account {
 		AccountID:     row.AccountID,
 		AccountNumber: row.AccountNumber,
 		AccountType:   string(row.AccountType),
-		Currency:      string(row.Status),
+		Currency:      string(row.Currency),
 		Balance: Amount{
 			Amount:   float64(row.Balance.Int64) / 100,
 			Currency: string(row.Currency),
@@ -136,5 +164,87 @@ func AccountFromDetailsRow(row models.GetAccountDetailsByIDRow) Account {
 		LastName:  row.LastName,
 		Status:    string(row.Status),
 		CreatedAt: row.CreatedAt.Time,
+		SortCode:  row.SortCode.String,
+		IBAN:      row.Iban.String,
+		AccruedInterest: &Amount{
+			Amount:   row.AccruedInterest / 100,
+			Currency: string(row.Currency),
+		},
+	}
+}
+
+type ValidateAccountNumberParams struct {
+	SortCode      string `json:"sort_code" binding:"required"`
+	AccountNumber string `json:"account_number" binding:"required"`
+}
+
+type ValidateIBANParams struct {
+	IBAN string `json:"iban" binding:"required"`
+}
+
+// IdentifierValidation is the outcome of validating an account number or IBAN. Exists is only
+// set for identifiers issued by this bank that belong to an account.
+type IdentifierValidation struct {
+	Valid         bool   `json:"valid"`
+	Reason        string `json:"reason,omitempty"`
+	SortCode      string `json:"sort_code,omitempty"`
+	AccountNumber string `json:"account_number,omitempty"`
+	IBAN          string `json:"iban,omitempty"`
+	Exists        bool   `json:"exists"`
+	Currency      string `json:"currency,omitempty"`
+}
+
+const (
+	defaultSearchPageSize = 20
+	maxSearchPageSize     = 100
+)
+
+// SearchAccountsParams filters the admin account search. Balances are in major units and
+// CreatedTo is inclusive of the whole day. Admin-owned and EXTERNAL accounts are left out
+// unless IncludeInternal is set.
+type SearchAccountsParams struct {
+	Name            string    `form:"name"`
+	Email           string    `form:"email"`
+	AccountNumber   string    `form:"account_number"`
+	Status          string    `form:"status" binding:"omitempty,oneof=PENDING ACTIVE SUSPENDED CLOSED DORMANT"`
+	Currency        string    `form:"currency" binding:"omitempty,oneof=GBP EUR JPY"`
+	MinBalance      *float64  `form:"min_balance"`
+	MaxBalance      *float64  `form:"max_balance"`
+	CreatedFrom     time.Time `form:"created_from" time_format:"2006-01-02" time_utc:"1"`
+	CreatedTo       time.Time `form:"created_to" time_format:"2006-01-02" time_utc:"1"`
+	IncludeInternal bool      `form:"include_internal"`
+	SortBy          string    `form:"sort_by" binding:"omitempty,oneof=created_at balance account_number name"`
+	SortOrder       string    `form:"sort_order" binding:"omitempty,oneof=asc desc"`
+	Page            int       `form:"page" binding:"omitempty,min=1"`
+	PageSize        int       `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+type AccountSearchResult struct {
+	Accounts   []Account `json:"accounts"`
+	Page       int       `json:"page"`
+	PageSize   int       `json:"page_size"`
+	TotalCount int64     `json:"total_count"`
+	TotalPages int64     `json:"total_pages"`
+}
+
+func AccountFromSearchRow(row models.SearchAccountsRow) Account {
+	return Account{
+		UserID:        row.UserID,
+		AccountID:     row.AccountID,
+		AccountNumber: row.AccountNumber,
+		AccountType:   string(row.AccountType),
+		Currency:      string(row.Currency),
+		Balance: Amount{
+			Amount:   float64(row.Balance) / 100,
+			Currency: string(row.Currency),
+		},
+		Status:    string(row.Status),
+		CreatedAt: row.CreatedAt.Time,
+		FirstName: row.FirstName,
+		LastName:  row.LastName,
+		SortCode:  row.SortCode.String,
+		IBAN:      row.Iban.String,
+		Email:     row.Email,
+		UserType:  string(row.UserType),
 	}
 }
@@ -0,0 +1,158 @@
+package approval
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"io"
+	"payter-bank/internal/api"
+	"payter-bank/internal/auth"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// ListHandler godoc
+// @Summary      List approval requests
+// @Description  List approval requests, newest first. Pending requests past their expiry are expired first
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        status  query  string  false  "PENDING, APPROVED, REJECTED, EXPIRED, EXECUTED or FAILED"
+// @Success      200  {object}  api.SuccessResponse{data=[]Request}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      403  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/admin/approvals [get]
+func (h *Handler) ListHandler(ctx *gin.Context) api.Response {
+	var params ListParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	requests, err := h.service.List(ctx, params)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("approval requests retrieved successfully", requests)
+}
+
+// GetHandler godoc
+// @Summary      Get an approval request
+// @Description  Get an approval request with its payload and, once decided, its outcome
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id  path  string  true  "Approval request ID"
+// @Success      200  {object}  api.SuccessResponse{data=Request}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/admin/approvals/{id} [get]
+func (h *Handler) GetHandler(ctx *gin.Context) api.Response {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return api.BadRequest("approval request id is required")
+	}
+
+	request, err := h.service.Get(ctx, id)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("approval request retrieved successfully", request)
+}
+
+// ApproveHandler godoc
+// @Summary      Approve a request
+// @Description  Approve a pending request and run its operation. The request must have been made by a different admin. A failed operation is recorded on the request with status FAILED
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id      path  string          true   "Approval request ID"
+// @Param        params  body  DecisionParams  false  "Optional reason"
+// @Success      200  {object}  api.SuccessResponse{data=Request}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      403  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      412  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/admin/approvals/{id}/approve [post]
+func (h *Handler) ApproveHandler(ctx *gin.Context) api.Response {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return api.BadRequest("approval request id is required")
+	}
+
+	// the body is optional when approving
+	var params DecisionParams
+	if err := ctx.ShouldBindJSON(&params); err != nil && !errors.Is(err, io.EOF) {
+		return api.BadRequest(err.Error())
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+	params.ID = id
+	params.ActorID = profile.UserID
+
+	request, err := h.service.Approve(ctx, params)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	if request.Error != "" {
+		return api.OK("request approved but the operation failed", request)
+	}
+	return api.OK("request approved successfully", request)
+}
+
+// RejectHandler godoc
+// @Summary      Reject a request
+// @Description  Reject a pending request, its operation is not run. The request must have been made by a different admin
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id      path  string          true  "Approval request ID"
+// @Param        params  body  DecisionParams  true  "Reason"
+// @Success      200  {object}  api.SuccessResponse{data=Request}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      403  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      412  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/admin/approvals/{id}/reject [post]
+func (h *Handler) RejectHandler(ctx *gin.Context) api.Response {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return api.BadRequest("approval request id is required")
+	}
+
+	var params DecisionParams
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+	params.ID = id
+	params.ActorID = profile.UserID
+
+	request, err := h.service.Reject(ctx, params)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("request rejected successfully", request)
+}
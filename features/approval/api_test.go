@@ -0,0 +1,201 @@
+package approval
+
+import (
+	"bytes"
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"payter-bank/internal/api"
+	"payter-bank/internal/auth"
+	platformerrors "payter-bank/internal/errors"
+	"testing"
+)
+
+func TestHandler_ListHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("lists requests by status", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/v1/api/admin/approvals?status=PENDING", nil)
+
+		requests := []Request{{ID: uuid.New(), Operation: OperationCredit, Status: "PENDING"}}
+		mockService.EXPECT().List(gomock.Any(), ListParams{Status: "PENDING"}).Return(requests, nil)
+
+		response := handler.ListHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    requests,
+			Message: "approval requests retrieved successfully",
+		}, response.Data)
+	})
+
+	t.Run("rejects unknown status", func(t *testing.T) {
+		handler := NewHandler(NewMockService(gomock.NewController(t)))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/v1/api/admin/approvals?status=DONE", nil)
+
+		response := handler.ListHandler(c)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestHandler_GetHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("returns request", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		id := uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/v1/api/admin/approvals/"+id.String(), nil)
+		c.Params = gin.Params{{Key: "id", Value: id.String()}}
+
+		request := Request{ID: id, Operation: OperationAdminCreate, Status: "PENDING"}
+		mockService.EXPECT().Get(gomock.Any(), id).Return(request, nil)
+
+		response := handler.GetHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    request,
+			Message: "approval request retrieved successfully",
+		}, response.Data)
+	})
+
+	t.Run("rejects invalid id", func(t *testing.T) {
+		handler := NewHandler(NewMockService(gomock.NewController(t)))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/v1/api/admin/approvals/abc", nil)
+		c.Params = gin.Params{{Key: "id", Value: "abc"}}
+
+		response := handler.GetHandler(c)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestHandler_ApproveHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("approves without a body", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		id, actorID := uuid.New(), uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/admin/approvals/"+id.String()+"/approve", nil)
+		c.Params = gin.Params{{Key: "id", Value: id.String()}}
+		injectProfile(c, auth.Profile{UserID: actorID})
+
+		request := Request{ID: id, Operation: OperationCredit, Status: "EXECUTED"}
+		mockService.EXPECT().Approve(gomock.Any(), DecisionParams{ID: id, ActorID: actorID}).Return(request, nil)
+
+		response := handler.ApproveHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    request,
+			Message: "request approved successfully",
+		}, response.Data)
+	})
+
+	t.Run("reports a failed operation", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		id, actorID := uuid.New(), uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/admin/approvals/"+id.String()+"/approve",
+			bytes.NewBufferString(`{"reason":"checked with finance"}`))
+		c.Params = gin.Params{{Key: "id", Value: id.String()}}
+		injectProfile(c, auth.Profile{UserID: actorID})
+
+		request := Request{ID: id, Operation: OperationCredit, Status: "FAILED", Error: "account not found"}
+		mockService.EXPECT().Approve(gomock.Any(), DecisionParams{ID: id, Reason: "checked with finance", ActorID: actorID}).
+			Return(request, nil)
+
+		response := handler.ApproveHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    request,
+			Message: "request approved but the operation failed",
+		}, response.Data)
+	})
+
+	t.Run("forbids the requester", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		id, actorID := uuid.New(), uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/admin/approvals/"+id.String()+"/approve", nil)
+		c.Params = gin.Params{{Key: "id", Value: id.String()}}
+		injectProfile(c, auth.Profile{UserID: actorID})
+
+		mockService.EXPECT().Approve(gomock.Any(), DecisionParams{ID: id, ActorID: actorID}).
+			Return(Request{}, platformerrors.MakeApiError(http.StatusForbidden, "approval requests must be decided by a different admin"))
+
+		response := handler.ApproveHandler(c)
+		assert.Equal(t, http.StatusForbidden, response.Code)
+	})
+}
+
+func TestHandler_RejectHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("rejects with a reason", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		id, actorID := uuid.New(), uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/admin/approvals/"+id.String()+"/reject",
+			bytes.NewBufferString(`{"reason":"wrong account"}`))
+		c.Params = gin.Params{{Key: "id", Value: id.String()}}
+		injectProfile(c, auth.Profile{UserID: actorID})
+
+		request := Request{ID: id, Operation: OperationCredit, Status: "REJECTED", DecisionReason: "wrong account"}
+		mockService.EXPECT().Reject(gomock.Any(), DecisionParams{ID: id, Reason: "wrong account", ActorID: actorID}).
+			Return(request, nil)
+
+		response := handler.RejectHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    request,
+			Message: "request rejected successfully",
+		}, response.Data)
+	})
+
+	t.Run("requires a body", func(t *testing.T) {
+		handler := NewHandler(NewMockService(gomock.NewController(t)))
+
+		id := uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/admin/approvals/"+id.String()+"/reject", nil)
+		c.Params = gin.Params{{Key: "id", Value: id.String()}}
+		injectProfile(c, auth.Profile{UserID: uuid.New()})
+
+		response := handler.RejectHandler(c)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+}
+
+func injectProfile(ctx *gin.Context, profile auth.Profile) {
+	ctx.Request = ctx.Request.WithContext(context.WithValue(ctx.Request.Context(), auth.ProfileKey, profile))
+}
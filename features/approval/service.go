@@ -0,0 +1,315 @@
+//go:generate mockgen -source=service.go -destination=service_mock.go -package=approval
+
+package approval
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/sqlc-dev/pqtype"
+	"go.uber.org/zap"
+	"net/http"
+	"payter-bank/features/auditlog"
+	"payter-bank/internal/auth"
+	"payter-bank/internal/config"
+	"payter-bank/internal/database/models"
+	platformerrors "payter-bank/internal/errors"
+	"payter-bank/internal/logger"
+	"slices"
+	"strings"
+	"time"
+)
+
+type Service interface {
+	// Register sets how an approved operation is carried out and the permission its approver needs.
+	// Operations are registered once at startup.
+	Register(operation Operation, permission auth.Permission, execute Executor)
+	// Requires reports whether an operation must be approved by a second admin, amount is only
+	// used for credits.
+	Requires(operation Operation, amount float64) bool
+	Submit(ctx context.Context, param SubmitParams) (Request, error)
+	List(ctx context.Context, param ListParams) ([]Request, error)
+	Get(ctx context.Context, id uuid.UUID) (Request, error)
+	// Approve runs the operation of a pending request. The approver must not be the admin who
+	// submitted it and must hold the permission the operation was registered with.
+	Approve(ctx context.Context, param DecisionParams) (Request, error)
+	Reject(ctx context.Context, param DecisionParams) (Request, error)
+}
+
+type registration struct {
+	permission auth.Permission
+	execute    Executor
+}
+
+type service struct {
+	db         models.Querier
+	auditLog   auditlog.Service
+	cfg        config.ApprovalConfig
+	systemUser uuid.UUID
+	operations map[Operation]registration
+	now        func() time.Time
+}
+
+func NewService(db models.Querier, auditLog auditlog.Service, cfg config.Config) Service {
+	return &service{
+		db:         db,
+		auditLog:   auditLog,
+		cfg:        cfg.Approval,
+		systemUser: cfg.App.SystemUserID,
+		operations: make(map[Operation]registration),
+		now:        time.Now,
+	}
+}
+
+func (s *service) Register(operation Operation, permission auth.Permission, execute Executor) {
+	s.operations[operation] = registration{permission: permission, execute: execute}
+}
+
+func (s *service) Requires(operation Operation, amount float64) bool {
+	if !slices.Contains(s.cfg.Operations, operation.String()) {
+		return false
+	}
+	switch operation {
+	case OperationCredit:
+		return amount > s.cfg.CreditThreshold
+	case OperationDebit:
+		return amount > s.cfg.DebitThreshold
+	default:
+		return true
+	}
+}
+
+func (s *service) Submit(ctx context.Context, param SubmitParams) (Request, error) {
+	// the secret is left out of the logs
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Submit"),
+		zap.String("operation", param.Operation.String()),
+		zap.String("requested_by", param.RequestedBy.String()))
+
+	if _, ok := s.operations[param.Operation]; !ok {
+		logger.Error(ctx, "no executor registered for operation")
+		return Request{}, platformerrors.ErrInternal
+	}
+
+	payload, err := json.Marshal(param.Payload)
+	if err != nil {
+		logger.Error(ctx, "failed to marshal approval payload", zap.Error(err))
+		return Request{}, platformerrors.ErrInternal
+	}
+
+	var secret pqtype.NullRawMessage
+	if param.Secret != nil {
+		data, err := json.Marshal(param.Secret)
+		if err != nil {
+			logger.Error(ctx, "failed to marshal approval secret", zap.Error(err))
+			return Request{}, platformerrors.ErrInternal
+		}
+		secret = pqtype.NullRawMessage{RawMessage: data, Valid: true}
+	}
+
+	row, err := s.db.SaveApprovalRequest(ctx, models.SaveApprovalRequestParams{
+		Operation:   param.Operation.String(),
+		Payload:     payload,
+		Secret:      secret,
+		RequestedBy: param.RequestedBy,
+		ExpiresAt:   s.now().Add(s.cfg.Expiry),
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to save approval request", zap.Error(err))
+		return Request{}, platformerrors.ErrInternal
+	}
+
+	request := RequestFromModel(row)
+	s.submitAudit(ctx, auditlog.ActionApprovalRequested, param.RequestedBy, request, "", "")
+	return request, nil
+}
+
+func (s *service) List(ctx context.Context, param ListParams) ([]Request, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "List"),
+		zap.Any(logger.RequestFields, param))
+
+	if err := s.expirePending(ctx); err != nil {
+		return nil, err
+	}
+
+	status := models.NullApprovalStatus{
+		ApprovalStatus: models.ApprovalStatus(param.Status),
+		Valid:          param.Status != "",
+	}
+	rows, err := s.db.GetApprovalRequests(ctx, status)
+	if err != nil {
+		logger.Error(ctx, "failed to get approval requests", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	requests := make([]Request, 0, len(rows))
+	for _, row := range rows {
+		requests = append(requests, RequestFromModel(row))
+	}
+	return requests, nil
+}
+
+func (s *service) Get(ctx context.Context, id uuid.UUID) (Request, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Get"),
+		zap.Any(logger.RequestFields, id))
+
+	if err := s.expirePending(ctx); err != nil {
+		return Request{}, err
+	}
+	return s.get(ctx, id)
+}
+
+func (s *service) Approve(ctx context.Context, param DecisionParams) (Request, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Approve"),
+		zap.Any(logger.RequestFields, param))
+
+	request, operation, err := s.decide(ctx, param, models.ApprovalStatusAPPROVED)
+	if err != nil {
+		return Request{}, err
+	}
+	s.submitAudit(ctx, auditlog.ActionApprovalApproved, param.ActorID, request, param.Reason, "")
+
+	completion := models.CompleteApprovalRequestParams{
+		ID:     request.ID,
+		Status: models.ApprovalStatusEXECUTED,
+	}
+	result, err := operation.execute(ctx, request)
+	if err != nil {
+		completion.Status = models.ApprovalStatusFAILED
+		completion.Error = sql.NullString{String: err.Error(), Valid: true}
+		s.submitAudit(ctx, auditlog.ActionApprovalFailed, param.ActorID, request, param.Reason, err.Error())
+	} else if data, err := json.Marshal(result); err != nil {
+		logger.Error(ctx, "failed to marshal approval result", zap.Error(err))
+	} else {
+		completion.Result = pqtype.NullRawMessage{RawMessage: data, Valid: true}
+	}
+
+	row, err := s.db.CompleteApprovalRequest(ctx, completion)
+	if err != nil {
+		logger.Error(ctx, "failed to complete approval request", zap.Error(err))
+		return Request{}, platformerrors.ErrInternal
+	}
+	return RequestFromModel(row), nil
+}
+
+func (s *service) Reject(ctx context.Context, param DecisionParams) (Request, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Reject"),
+		zap.Any(logger.RequestFields, param))
+
+	if strings.TrimSpace(param.Reason) == "" {
+		return Request{}, platformerrors.MakeApiError(http.StatusBadRequest, "a reason is required to reject a request")
+	}
+
+	request, _, err := s.decide(ctx, param, models.ApprovalStatusREJECTED)
+	if err != nil {
+		return Request{}, err
+	}
+	s.submitAudit(ctx, auditlog.ActionApprovalRejected, param.ActorID, request, param.Reason, "")
+
+	return s.get(ctx, request.ID)
+}
+
+// decide moves a pending request to status. The update only matches a pending, unexpired request so
+// of two admins deciding the same request at once only one succeeds.
+func (s *service) decide(ctx context.Context, param DecisionParams, status models.ApprovalStatus) (Request, registration, error) {
+	request, err := s.get(ctx, param.ID)
+	if err != nil {
+		return Request{}, registration{}, err
+	}
+
+	if request.RequestedBy == param.ActorID {
+		return Request{}, registration{}, platformerrors.MakeApiError(http.StatusForbidden, "approval requests must be decided by a different admin")
+	}
+
+	if request.Status != string(models.ApprovalStatusPENDING) {
+		return Request{}, registration{}, platformerrors.MakeApiError(http.StatusPreconditionFailed,
+			fmt.Sprintf("approval request is already %s", strings.ToLower(request.Status)))
+	}
+
+	if !s.now().Before(request.ExpiresAt) {
+		if err := s.expirePending(ctx); err != nil {
+			return Request{}, registration{}, err
+		}
+		return Request{}, registration{}, platformerrors.MakeApiError(http.StatusPreconditionFailed, "approval request has expired")
+	}
+
+	operation, ok := s.operations[request.Operation]
+	if !ok {
+		logger.Error(ctx, "no executor registered for operation", zap.String("operation", request.Operation.String()))
+		return Request{}, registration{}, platformerrors.ErrInternal
+	}
+
+	permissions, err := s.db.GetUserPermissions(ctx, param.ActorID)
+	if err != nil {
+		logger.Error(ctx, "failed to get user permissions", zap.Error(err))
+		return Request{}, registration{}, platformerrors.ErrInternal
+	}
+	if !slices.Contains(permissions, operation.permission.String()) {
+		return Request{}, registration{}, platformerrors.MakeApiError(http.StatusForbidden,
+			fmt.Sprintf("you need the %s permission to decide this request", operation.permission))
+	}
+
+	decided, err := s.db.DecideApprovalRequest(ctx, models.DecideApprovalRequestParams{
+		Status:         status,
+		DecidedBy:      uuid.NullUUID{UUID: param.ActorID, Valid: true},
+		DecisionReason: sql.NullString{String: param.Reason, Valid: param.Reason != ""},
+		Now:            s.now(),
+		ID:             request.ID,
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to decide approval request", zap.Error(err))
+		return Request{}, registration{}, platformerrors.ErrInternal
+	}
+	if decided == 0 {
+		return Request{}, registration{}, platformerrors.MakeApiError(http.StatusPreconditionFailed, "approval request has already been decided")
+	}
+
+	return request, operation, nil
+}
+
+func (s *service) get(ctx context.Context, id uuid.UUID) (Request, error) {
+	row, err := s.db.GetApprovalRequest(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Request{}, platformerrors.MakeApiError(http.StatusNotFound, "approval request not found")
+		}
+		logger.Error(ctx, "failed to get approval request", zap.Error(err))
+		return Request{}, platformerrors.ErrInternal
+	}
+	return RequestFromModel(row), nil
+}
+
+// expirePending marks pending requests past their expiry as expired, requests are expired when they
+// are next read rather than by a job.
+func (s *service) expirePending(ctx context.Context) error {
+	rows, err := s.db.ExpireApprovalRequests(ctx, s.now())
+	if err != nil {
+		logger.Error(ctx, "failed to expire approval requests", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+
+	for _, row := range rows {
+		s.submitAudit(ctx, auditlog.ActionApprovalExpired, s.systemUser, RequestFromModel(row), "", "")
+	}
+	return nil
+}
+
+func (s *service) submitAudit(ctx context.Context, action auditlog.Action, actorID uuid.UUID, request Request, reason, failure string) {
+	event := auditlog.NewEvent(action, actorID, uuid.Nil, auditlog.ApprovalMetadata{
+		RequestID:   request.ID,
+		Operation:   request.Operation.String(),
+		RequestedBy: request.RequestedBy,
+		Reason:      reason,
+		Error:       failure,
+	})
+	if err := s.auditLog.Submit(ctx, event); err != nil {
+		logger.Error(ctx, "failed to queue audit log", zap.Error(err))
+	}
+}
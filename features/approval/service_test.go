@@ -0,0 +1,335 @@
+package approval
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/sqlc-dev/pqtype"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"payter-bank/features/auditlog"
+	"payter-bank/internal/auth"
+	"payter-bank/internal/config"
+	"payter-bank/internal/database/models"
+	databasemocks "payter-bank/internal/database/models/mocks"
+	platformerrors "payter-bank/internal/errors"
+	"testing"
+	"time"
+)
+
+var (
+	testNow        = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	testSystemUser = uuid.MustParse("00000000-2222-2222-2222-000000000000")
+)
+
+func TestService_Requires(t *testing.T) {
+	m := newApprovalMocker(t)
+	m.cfg.Operations = []string{"credit", "debit", "admin_create"}
+	m.cfg.DebitThreshold = 500
+
+	assert.True(t, m.service.Requires(OperationCredit, 10000.01))
+	assert.False(t, m.service.Requires(OperationCredit, 10000))
+	assert.True(t, m.service.Requires(OperationDebit, 500.01))
+	assert.False(t, m.service.Requires(OperationDebit, 500))
+	assert.True(t, m.service.Requires(OperationAdminCreate, 0))
+	assert.False(t, m.service.Requires(OperationInterestRateUpdate, 0))
+}
+
+func TestService_Submit(t *testing.T) {
+	t.Run("saves request and audits it", func(t *testing.T) {
+		m := newApprovalMocker(t)
+		requesterID, requestID := uuid.New(), uuid.New()
+
+		m.db.EXPECT().SaveApprovalRequest(gomock.Any(), models.SaveApprovalRequestParams{
+			Operation:   "credit",
+			Payload:     json.RawMessage(`{"amount":50000}`),
+			Secret:      pqtype.NullRawMessage{RawMessage: json.RawMessage(`{"token":"x"}`), Valid: true},
+			RequestedBy: requesterID,
+			ExpiresAt:   testNow.Add(24 * time.Hour),
+		}).Return(models.ApprovalRequest{
+			ID:          requestID,
+			Operation:   "credit",
+			Payload:     json.RawMessage(`{"amount":50000}`),
+			Status:      models.ApprovalStatusPENDING,
+			RequestedBy: requesterID,
+			ExpiresAt:   testNow.Add(24 * time.Hour),
+		}, nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionApprovalRequested, requesterID, uuid.Nil,
+			auditlog.ApprovalMetadata{RequestID: requestID, Operation: "credit", RequestedBy: requesterID})).Return(nil)
+
+		request, err := m.service.Submit(context.TODO(), SubmitParams{
+			Operation:   OperationCredit,
+			Payload:     map[string]int{"amount": 50000},
+			Secret:      map[string]string{"token": "x"},
+			RequestedBy: requesterID,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, requestID, request.ID)
+		assert.Equal(t, "PENDING", request.Status)
+	})
+
+	t.Run("fails for operation without executor", func(t *testing.T) {
+		m := newApprovalMocker(t)
+
+		_, err := m.service.Submit(context.TODO(), SubmitParams{
+			Operation:   OperationAdminCreate,
+			Payload:     map[string]string{},
+			RequestedBy: uuid.New(),
+		})
+		assert.Equal(t, platformerrors.ErrInternal, err)
+	})
+}
+
+func TestService_List(t *testing.T) {
+	t.Run("expires overdue requests before listing", func(t *testing.T) {
+		m := newApprovalMocker(t)
+		expired := models.ApprovalRequest{ID: uuid.New(), Operation: "credit", RequestedBy: uuid.New(), Status: models.ApprovalStatusEXPIRED}
+		pending := models.ApprovalRequest{ID: uuid.New(), Operation: "credit", RequestedBy: uuid.New(), Status: models.ApprovalStatusPENDING}
+
+		m.db.EXPECT().ExpireApprovalRequests(gomock.Any(), testNow).Return([]models.ApprovalRequest{expired}, nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionApprovalExpired, testSystemUser, uuid.Nil,
+			auditlog.ApprovalMetadata{RequestID: expired.ID, Operation: "credit", RequestedBy: expired.RequestedBy})).Return(nil)
+		m.db.EXPECT().GetApprovalRequests(gomock.Any(), models.NullApprovalStatus{ApprovalStatus: models.ApprovalStatusPENDING, Valid: true}).
+			Return([]models.ApprovalRequest{pending}, nil)
+
+		requests, err := m.service.List(context.TODO(), ListParams{Status: "PENDING"})
+		assert.NoError(t, err)
+		assert.Equal(t, []Request{RequestFromModel(pending)}, requests)
+	})
+
+	t.Run("lists every status without a filter", func(t *testing.T) {
+		m := newApprovalMocker(t)
+		m.db.EXPECT().ExpireApprovalRequests(gomock.Any(), testNow).Return(nil, nil)
+		m.db.EXPECT().GetApprovalRequests(gomock.Any(), models.NullApprovalStatus{}).Return(nil, nil)
+
+		requests, err := m.service.List(context.TODO(), ListParams{})
+		assert.NoError(t, err)
+		assert.Equal(t, []Request{}, requests)
+	})
+}
+
+func TestService_Get(t *testing.T) {
+	t.Run("returns not found", func(t *testing.T) {
+		m := newApprovalMocker(t)
+		id := uuid.New()
+		m.db.EXPECT().ExpireApprovalRequests(gomock.Any(), testNow).Return(nil, nil)
+		m.db.EXPECT().GetApprovalRequest(gomock.Any(), id).Return(models.ApprovalRequest{}, sql.ErrNoRows)
+
+		_, err := m.service.Get(context.TODO(), id)
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusNotFound, "approval request not found"), err)
+	})
+}
+
+func TestService_Approve(t *testing.T) {
+	t.Run("approves and runs the operation", func(t *testing.T) {
+		m := newApprovalMocker(t)
+		row := pendingRequest()
+		approverID := uuid.New()
+		m.expectDecision(row, approverID, models.ApprovalStatusAPPROVED, "looks right")
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionApprovalApproved, approverID, uuid.Nil,
+			auditlog.ApprovalMetadata{RequestID: row.ID, Operation: "credit", RequestedBy: row.RequestedBy, Reason: "looks right"})).Return(nil)
+
+		completed := row
+		completed.Status = models.ApprovalStatusEXECUTED
+		completed.Result = pqtype.NullRawMessage{RawMessage: json.RawMessage(`{"transaction_id":"tx"}`), Valid: true}
+		m.db.EXPECT().CompleteApprovalRequest(gomock.Any(), models.CompleteApprovalRequestParams{
+			ID:     row.ID,
+			Status: models.ApprovalStatusEXECUTED,
+			Result: completed.Result,
+		}).Return(completed, nil)
+
+		request, err := m.service.Approve(context.TODO(), DecisionParams{ID: row.ID, Reason: "looks right", ActorID: approverID})
+		assert.NoError(t, err)
+		assert.Equal(t, "EXECUTED", request.Status)
+		assert.Equal(t, []Request{RequestFromModel(row)}, m.executed)
+	})
+
+	t.Run("records a failed operation", func(t *testing.T) {
+		m := newApprovalMocker(t)
+		m.executeErr = platformerrors.MakeApiError(http.StatusNotFound, "account not found")
+		row := pendingRequest()
+		approverID := uuid.New()
+		m.expectDecision(row, approverID, models.ApprovalStatusAPPROVED, "")
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionApprovalApproved, approverID, uuid.Nil,
+			auditlog.ApprovalMetadata{RequestID: row.ID, Operation: "credit", RequestedBy: row.RequestedBy})).Return(nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionApprovalFailed, approverID, uuid.Nil,
+			auditlog.ApprovalMetadata{RequestID: row.ID, Operation: "credit", RequestedBy: row.RequestedBy, Error: "account not found"})).Return(nil)
+
+		failed := row
+		failed.Status = models.ApprovalStatusFAILED
+		failed.Error = sql.NullString{String: "account not found", Valid: true}
+		m.db.EXPECT().CompleteApprovalRequest(gomock.Any(), models.CompleteApprovalRequestParams{
+			ID:     row.ID,
+			Status: models.ApprovalStatusFAILED,
+			Error:  failed.Error,
+		}).Return(failed, nil)
+
+		request, err := m.service.Approve(context.TODO(), DecisionParams{ID: row.ID, ActorID: approverID})
+		assert.NoError(t, err)
+		assert.Equal(t, "FAILED", request.Status)
+		assert.Equal(t, "account not found", request.Error)
+	})
+
+	t.Run("forbids the requester", func(t *testing.T) {
+		m := newApprovalMocker(t)
+		row := pendingRequest()
+		m.db.EXPECT().GetApprovalRequest(gomock.Any(), row.ID).Return(row, nil)
+
+		_, err := m.service.Approve(context.TODO(), DecisionParams{ID: row.ID, ActorID: row.RequestedBy})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusForbidden, "approval requests must be decided by a different admin"), err)
+		assert.Empty(t, m.executed)
+	})
+
+	t.Run("forbids approver without the operation permission", func(t *testing.T) {
+		m := newApprovalMocker(t)
+		row := pendingRequest()
+		approverID := uuid.New()
+		m.db.EXPECT().GetApprovalRequest(gomock.Any(), row.ID).Return(row, nil)
+		m.db.EXPECT().GetUserPermissions(gomock.Any(), approverID).Return([]string{"approvals:decide"}, nil)
+
+		_, err := m.service.Approve(context.TODO(), DecisionParams{ID: row.ID, ActorID: approverID})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusForbidden, "you need the transactions:write permission to decide this request"), err)
+		assert.Empty(t, m.executed)
+	})
+
+	t.Run("fails for request that is no longer pending", func(t *testing.T) {
+		m := newApprovalMocker(t)
+		row := pendingRequest()
+		row.Status = models.ApprovalStatusREJECTED
+		m.db.EXPECT().GetApprovalRequest(gomock.Any(), row.ID).Return(row, nil)
+
+		_, err := m.service.Approve(context.TODO(), DecisionParams{ID: row.ID, ActorID: uuid.New()})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusPreconditionFailed, "approval request is already rejected"), err)
+	})
+
+	t.Run("expires overdue request", func(t *testing.T) {
+		m := newApprovalMocker(t)
+		row := pendingRequest()
+		row.ExpiresAt = testNow.Add(-time.Minute)
+		m.db.EXPECT().GetApprovalRequest(gomock.Any(), row.ID).Return(row, nil)
+		m.db.EXPECT().ExpireApprovalRequests(gomock.Any(), testNow).Return([]models.ApprovalRequest{row}, nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), gomock.Any()).Return(nil)
+
+		_, err := m.service.Approve(context.TODO(), DecisionParams{ID: row.ID, ActorID: uuid.New()})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusPreconditionFailed, "approval request has expired"), err)
+		assert.Empty(t, m.executed)
+	})
+
+	t.Run("loses a concurrent decision", func(t *testing.T) {
+		m := newApprovalMocker(t)
+		row := pendingRequest()
+		approverID := uuid.New()
+		m.db.EXPECT().GetApprovalRequest(gomock.Any(), row.ID).Return(row, nil)
+		m.db.EXPECT().GetUserPermissions(gomock.Any(), approverID).Return([]string{"transactions:write"}, nil)
+		m.db.EXPECT().DecideApprovalRequest(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+
+		_, err := m.service.Approve(context.TODO(), DecisionParams{ID: row.ID, ActorID: approverID})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusPreconditionFailed, "approval request has already been decided"), err)
+		assert.Empty(t, m.executed)
+	})
+}
+
+func TestService_Reject(t *testing.T) {
+	t.Run("rejects without running the operation", func(t *testing.T) {
+		m := newApprovalMocker(t)
+		row := pendingRequest()
+		approverID := uuid.New()
+		m.expectDecision(row, approverID, models.ApprovalStatusREJECTED, "amount is wrong")
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionApprovalRejected, approverID, uuid.Nil,
+			auditlog.ApprovalMetadata{RequestID: row.ID, Operation: "credit", RequestedBy: row.RequestedBy, Reason: "amount is wrong"})).Return(nil)
+
+		rejected := row
+		rejected.Status = models.ApprovalStatusREJECTED
+		m.db.EXPECT().GetApprovalRequest(gomock.Any(), row.ID).Return(rejected, nil)
+
+		request, err := m.service.Reject(context.TODO(), DecisionParams{ID: row.ID, Reason: "amount is wrong", ActorID: approverID})
+		assert.NoError(t, err)
+		assert.Equal(t, "REJECTED", request.Status)
+		assert.Empty(t, m.executed)
+	})
+
+	t.Run("requires a reason", func(t *testing.T) {
+		m := newApprovalMocker(t)
+
+		_, err := m.service.Reject(context.TODO(), DecisionParams{ID: uuid.New(), ActorID: uuid.New()})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, "a reason is required to reject a request"), err)
+	})
+
+	t.Run("fails on database error", func(t *testing.T) {
+		m := newApprovalMocker(t)
+		id := uuid.New()
+		m.db.EXPECT().GetApprovalRequest(gomock.Any(), id).Return(models.ApprovalRequest{}, errors.New("db down"))
+
+		_, err := m.service.Reject(context.TODO(), DecisionParams{ID: id, Reason: "no", ActorID: uuid.New()})
+		assert.Equal(t, platformerrors.ErrInternal, err)
+	})
+}
+
+func pendingRequest() models.ApprovalRequest {
+	return models.ApprovalRequest{
+		ID:          uuid.New(),
+		Operation:   "credit",
+		Payload:     json.RawMessage(`{"amount":50000}`),
+		Status:      models.ApprovalStatusPENDING,
+		RequestedBy: uuid.New(),
+		ExpiresAt:   testNow.Add(time.Hour),
+	}
+}
+
+type approvalMocker struct {
+	db         *databasemocks.MockQuerier
+	auditLog   *auditlog.MockService
+	cfg        *config.ApprovalConfig
+	executed   []Request
+	executeErr error
+
+	service *service
+}
+
+// expectDecision expects row to be read and moved to status by an approver holding transactions:write.
+func (m *approvalMocker) expectDecision(row models.ApprovalRequest, approverID uuid.UUID, status models.ApprovalStatus, reason string) {
+	m.db.EXPECT().GetApprovalRequest(gomock.Any(), row.ID).Return(row, nil)
+	m.db.EXPECT().GetUserPermissions(gomock.Any(), approverID).Return([]string{"approvals:decide", "transactions:write"}, nil)
+	m.db.EXPECT().DecideApprovalRequest(gomock.Any(), models.DecideApprovalRequestParams{
+		Status:         status,
+		DecidedBy:      uuid.NullUUID{UUID: approverID, Valid: true},
+		DecisionReason: sql.NullString{String: reason, Valid: reason != ""},
+		Now:            testNow,
+		ID:             row.ID,
+	}).Return(int64(1), nil)
+}
+
+func newApprovalMocker(t *testing.T) *approvalMocker {
+	ctrl := gomock.NewController(t)
+	db := databasemocks.NewMockQuerier(ctrl)
+	auditLog := auditlog.NewMockService(ctrl)
+
+	svc := NewService(db, auditLog, config.Config{
+		App: config.AppConfig{SystemUserID: testSystemUser},
+		Approval: config.ApprovalConfig{
+			Operations:      []string{"credit", "interest_rate_create", "interest_rate_update", "interest_frequency_update", "admin_create"},
+			CreditThreshold: 10000,
+			DebitThreshold:  10000,
+			Expiry:          24 * time.Hour,
+		},
+	}).(*service)
+	svc.now = func() time.Time { return testNow }
+
+	m := &approvalMocker{
+		db:       db,
+		auditLog: auditLog,
+		cfg:      &svc.cfg,
+		service:  svc,
+	}
+	svc.Register(OperationCredit, auth.PermTransactionsWrite, func(_ context.Context, request Request) (any, error) {
+		m.executed = append(m.executed, request)
+		if m.executeErr != nil {
+			return nil, m.executeErr
+		}
+		return map[string]string{"transaction_id": "tx"}, nil
+	})
+	return m
+}
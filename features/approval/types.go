@@ -0,0 +1,100 @@
+package approval
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"payter-bank/internal/database/models"
+	"time"
+)
+
+type Operation string
+
+const (
+	OperationCredit                     Operation = "credit"
+	OperationDebit                      Operation = "debit"
+	OperationReversal                   Operation = "reversal"
+	OperationInterestRateCreate         Operation = "interest_rate_create"
+	OperationInterestRateUpdate         Operation = "interest_rate_update"
+	OperationInterestFrequencyUpdate    Operation = "interest_frequency_update"
+	OperationInterestRateCardCreate     Operation = "interest_rate_card_create"
+	OperationInterestRateCardUpdate     Operation = "interest_rate_card_update"
+	OperationInterestRateCardDelete     Operation = "interest_rate_card_delete"
+	OperationInterestRateOverrideCreate Operation = "interest_rate_override_create"
+	OperationInterestRateOverrideUpdate Operation = "interest_rate_override_update"
+	OperationInterestRateOverrideDelete Operation = "interest_rate_override_delete"
+	OperationAdminCreate                Operation = "admin_create"
+)
+
+func (o Operation) String() string {
+	return string(o)
+}
+
+// Executor carries out an approved operation from the payload it was submitted with. The result is
+// stored on the approval request.
+type Executor func(ctx context.Context, request Request) (any, error)
+
+// Request is an operation held until a second admin approves or rejects it. Secret is never
+// returned by the API and is cleared once the request is decided.
+type Request struct {
+	ID             uuid.UUID       `json:"id"`
+	Operation      Operation       `json:"operation"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+	Secret         json.RawMessage `json:"-"`
+	Status         string          `json:"status"`
+	RequestedBy    uuid.UUID       `json:"requested_by"`
+	DecidedBy      *uuid.UUID      `json:"decided_by,omitempty"`
+	DecisionReason string          `json:"decision_reason,omitempty"`
+	Result         json.RawMessage `json:"result,omitempty" swaggertype:"object"`
+	Error          string          `json:"error,omitempty"`
+	ExpiresAt      time.Time       `json:"expires_at"`
+	DecidedAt      *time.Time      `json:"decided_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+func RequestFromModel(m models.ApprovalRequest) Request {
+	r := Request{
+		ID:             m.ID,
+		Operation:      Operation(m.Operation),
+		Payload:        m.Payload,
+		Status:         string(m.Status),
+		RequestedBy:    m.RequestedBy,
+		DecisionReason: m.DecisionReason.String,
+		Error:          m.Error.String,
+		ExpiresAt:      m.ExpiresAt,
+		CreatedAt:      m.CreatedAt.Time,
+	}
+	if m.Secret.Valid {
+		r.Secret = m.Secret.RawMessage
+	}
+	if m.Result.Valid {
+		r.Result = m.Result.RawMessage
+	}
+	if m.DecidedBy.Valid {
+		r.DecidedBy = &m.DecidedBy.UUID
+	}
+	if m.DecidedAt.Valid {
+		r.DecidedAt = &m.DecidedAt.Time
+	}
+	return r
+}
+
+// SubmitParams holds an operation for approval. Payload is shown to the approver, Secret is only
+// handed to the executor.
+type SubmitParams struct {
+	Operation   Operation
+	Payload     any
+	Secret      any
+	RequestedBy uuid.UUID
+}
+
+type ListParams struct {
+	Status string `form:"status" binding:"omitempty,oneof=PENDING APPROVED REJECTED EXPIRED EXECUTED FAILED"`
+}
+
+// DecisionParams approves or rejects a request, a reason is required to reject it.
+type DecisionParams struct {
+	ID      uuid.UUID `json:"-"`
+	Reason  string    `json:"reason"`
+	ActorID uuid.UUID `json:"-"`
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/hibiken/asynq"
 	"github.com/sqlc-dev/pqtype"
 	"go.uber.org/zap"
+	"payter-bank/internal/auth"
 	"payter-bank/internal/config"
 	"payter-bank/internal/database/models"
 	"payter-bank/internal/logger"
@@ -83,6 +84,10 @@ func (s *service) Submit(ctx context.Context, event Event) error {
 		zap.String(logger.FunctionName, "Submit#AuditLog"),
 		zap.Any("event", event))
 
+	if event.APIKeyID == uuid.Nil {
+		event.APIKeyID = auth.APIKeyID(ctx)
+	}
+
 	payload, err := json.Marshal(event)
 	if err != nil {
 		return err
@@ -132,6 +137,10 @@ func (s *service) ProcessTask(ctx context.Context, task *asynq.Task) error {
 			RawMessage: metadata,
 			Valid:      metadata != nil,
 		},
+		ApiKeyID: uuid.NullUUID{
+			UUID:  event.APIKeyID,
+			Valid: event.APIKeyID != uuid.Nil,
+		},
 	})
 	if err != nil {
 		logger.Error(ctx, "failed to save audit log", zap.Error(err))
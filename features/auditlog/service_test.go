@@ -9,6 +9,7 @@ import (
 	"github.com/sqlc-dev/pqtype"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"payter-bank/internal/auth"
 	"payter-bank/internal/config"
 	"payter-bank/internal/database/models"
 	databasemocks "payter-bank/internal/database/models/mocks"
@@ -56,6 +57,25 @@ func TestService_Submit(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to enqueue task")
 	})
+
+	t.Run("should attribute event to the api key of the request", func(t *testing.T) {
+		apiKeyID := uuid.New()
+		ev := Event{
+			Action: "test_action",
+			UserID: uuid.New(),
+		}
+		expected := ev
+		expected.APIKeyID = apiKeyID
+		expectedPayload, _ := json.Marshal(expected)
+
+		mocker := newAuditLogServiceMocker(t)
+		mocker.client.EXPECT().Enqueue(asynq.NewTask(auditLogTaskName, expectedPayload)).
+			Return(&asynq.TaskInfo{}, nil)
+
+		ctx := context.WithValue(context.TODO(), auth.APIKeyIDKey, apiKeyID)
+		err := mocker.service.Submit(ctx, ev)
+		assert.NoError(t, err)
+	})
 }
 
 func TestService_ProcessTask(t *testing.T) {
@@ -11,6 +11,8 @@ type Event struct {
 	UserID    uuid.UUID `json:"user_id"`
 	AccountID uuid.UUID `json:"account_id"`
 	Metadata  any       `json:"metadata"`
+	// APIKeyID is the key a service account used, Submit takes it from the request when unset
+	APIKeyID uuid.UUID `json:"api_key_id"`
 }
 
 func NewEvent(action Action, userID, accountID uuid.UUID, metadata any) Event {
@@ -32,7 +34,43 @@ const (
 	ActionAccountCredit       Action = "account_credit"
 	ActionAccountDebit        Action = "account_debit"
 	ActionAccountTransfer     Action = "account_transfer"
+	ActionTransactionReversal Action = "transaction_reversal"
 	ActionInterestRateChange  Action = "interest_rate_change"
+	ActionRateCardChange      Action = "interest_rate_card_change"
+	ActionInterestRunRetry    Action = "interest_run_retry"
+	ActionRateOverrideChange  Action = "interest_rate_override_change"
+	ActionInterestApplied     Action = "interest_applied"
+	ActionUserRegistered      Action = "user_registered"
+	ActionUserEmailVerified   Action = "user_email_verified"
+	ActionUserProfileChange   Action = "user_profile_change"
+	ActionUserEmailChange     Action = "user_email_change"
+	ActionUserPasswordChange  Action = "user_password_change"
+	ActionUserPasswordReset   Action = "user_password_reset"
+	ActionUserDeactivated     Action = "user_deactivated"
+	ActionUserReactivated     Action = "user_reactivated"
+	ActionUserSessionsRevoked Action = "user_sessions_revoked"
+	ActionSessionTerminated   Action = "session_terminated"
+	ActionRefreshTokenReuse   Action = "refresh_token_reuse"
+	ActionMFAEnabled          Action = "mfa_enabled"
+	ActionMFADisabled         Action = "mfa_disabled"
+	ActionMFAReset            Action = "mfa_reset"
+	ActionMFARecoveryCodeUsed Action = "mfa_recovery_code_used"
+	ActionLoginLockout        Action = "login_lockout"
+	ActionLoginUnlock         Action = "login_unlock"
+	ActionUserRolesChange     Action = "user_roles_change"
+	ActionUserTaxStatusChange Action = "user_tax_status_change"
+	ActionApprovalRequested   Action = "approval_requested"
+	ActionApprovalApproved    Action = "approval_approved"
+	ActionApprovalRejected    Action = "approval_rejected"
+	ActionApprovalExpired     Action = "approval_expired"
+	ActionApprovalFailed      Action = "approval_failed"
+	ActionServiceAccountNew   Action = "service_account_created"
+	ActionAPIKeyCreated       Action = "api_key_created"
+	ActionAPIKeyRotated       Action = "api_key_rotated"
+	ActionAPIKeyRevoked       Action = "api_key_revoked"
+	ActionOAuthClientCreated  Action = "oauth_client_created"
+	ActionOAuthClientRevoked  Action = "oauth_client_revoked"
+	ActionOAuthConsentGranted Action = "oauth_consent_granted"
 )
 
 func (a Action) String() string {
@@ -44,11 +82,179 @@ type AccountStatusChangeMetadata struct {
 	NewStatus string `json:"new_status"`
 }
 
+// AccountReactivationMetadata records how the owner of a dormant account was re-verified
+// before the account was reactivated.
+type AccountReactivationMetadata struct {
+	AccountStatusChangeMetadata
+	VerificationMethod    string `json:"verification_method"`
+	VerificationReference string `json:"verification_reference"`
+	Notes                 string `json:"notes,omitempty"`
+}
+
+// TransactionReversalMetadata records a transaction being reversed by posting its opposite, Amount is
+// in minor units.
+type TransactionReversalMetadata struct {
+	TransactionID uuid.UUID `json:"transaction_id"`
+	ReversalID    uuid.UUID `json:"reversal_id"`
+	Amount        int64     `json:"amount"`
+	Currency      string    `json:"currency"`
+	Reason        string    `json:"reason"`
+}
+
+// UserProfileChangeMetadata records a profile update. NewEmail is the address awaiting
+// verification, the email only changes once it is verified.
+type UserProfileChangeMetadata struct {
+	UserID       uuid.UUID `json:"user_id"`
+	OldFirstName string    `json:"old_first_name"`
+	NewFirstName string    `json:"new_first_name"`
+	OldLastName  string    `json:"old_last_name"`
+	NewLastName  string    `json:"new_last_name"`
+	OldEmail     string    `json:"old_email"`
+	NewEmail     string    `json:"new_email"`
+}
+
+type UserEmailChangeMetadata struct {
+	UserID   uuid.UUID `json:"user_id"`
+	OldEmail string    `json:"old_email"`
+	NewEmail string    `json:"new_email"`
+}
+
+type UserPasswordChangeMetadata struct {
+	UserID uuid.UUID `json:"user_id"`
+}
+
+type UserLifecycleMetadata struct {
+	UserID            uuid.UUID   `json:"user_id"`
+	Reason            string      `json:"reason,omitempty"`
+	SuspendedAccounts []uuid.UUID `json:"suspended_accounts,omitempty"`
+}
+
+// SessionRevocationMetadata records sessions revoked by an admin, a session terminated by its
+// user or an admin, or the session revoked because one of its refresh tokens was used twice.
+type SessionRevocationMetadata struct {
+	UserID    uuid.UUID `json:"user_id"`
+	SessionID uuid.UUID `json:"session_id,omitempty"`
+	Reason    string    `json:"reason"`
+	Revoked   int64     `json:"revoked"`
+}
+
+type MFAChangeMetadata struct {
+	UserID uuid.UUID `json:"user_id"`
+}
+
+// LoginLockoutMetadata identifies the email or client IP that was locked out or unlocked.
+type LoginLockoutMetadata struct {
+	Email       string    `json:"email,omitempty"`
+	IP          string    `json:"ip,omitempty"`
+	Failures    int64     `json:"failures,omitempty"`
+	Lockouts    int64     `json:"lockouts,omitempty"`
+	LockedUntil time.Time `json:"locked_until,omitempty"`
+}
+
+type UserRolesChangeMetadata struct {
+	UserID   uuid.UUID `json:"user_id"`
+	OldRoles []string  `json:"old_roles"`
+	NewRoles []string  `json:"new_roles"`
+}
+
+// UserTaxStatusChangeMetadata records a change to the tax residency and exemption interest tax is
+// withheld by. An empty residency is unknown.
+type UserTaxStatusChangeMetadata struct {
+	UserID       uuid.UUID `json:"user_id"`
+	OldResidency string    `json:"old_residency"`
+	NewResidency string    `json:"new_residency"`
+	OldExempt    bool      `json:"old_exempt"`
+	NewExempt    bool      `json:"new_exempt"`
+}
+
+// ApprovalMetadata records a step in the life of an approval request. Error is set when the
+// approved operation failed.
+type ApprovalMetadata struct {
+	RequestID   uuid.UUID `json:"request_id"`
+	Operation   string    `json:"operation"`
+	RequestedBy uuid.UUID `json:"requested_by"`
+	Reason      string    `json:"reason,omitempty"`
+	Error       string    `json:"error,omitempty"`
+}
+
+type ServiceAccountMetadata struct {
+	ServiceAccountID uuid.UUID `json:"service_account_id"`
+	Name             string    `json:"name"`
+}
+
+// APIKeyMetadata records a change to an API key, ReplacedKeyID is the key revoked by a rotation.
+type APIKeyMetadata struct {
+	ServiceAccountID uuid.UUID `json:"service_account_id"`
+	KeyID            uuid.UUID `json:"key_id"`
+	Scopes           []string  `json:"scopes,omitempty"`
+	ReplacedKeyID    uuid.UUID `json:"replaced_key_id,omitempty"`
+}
+
+// OAuthClientMetadata records a change to an OAuth client or a user authorizing it, Scopes are the
+// scopes the client may ask for or the user granted. Revoked is the number of sessions revoked with it.
+type OAuthClientMetadata struct {
+	ClientID uuid.UUID `json:"client_id"`
+	Name     string    `json:"name,omitempty"`
+	Scopes   []string  `json:"scopes,omitempty"`
+	Revoked  int64     `json:"revoked,omitempty"`
+}
+
+// InterestRateChangeMetadata records a change of the rate or calculation frequency. EffectiveFrom is
+// the day a rate change takes effect.
 type InterestRateChangeMetadata struct {
 	OldRate                 int64  `json:"old_rate"`
 	OldCalculationFrequency string `json:"old_calculation_frequency"`
 	NewRate                 int64  `json:"new_rate"`
 	NewCalculationFrequency string `json:"new_calculation_frequency"`
+	EffectiveFrom           string `json:"effective_from,omitempty"`
+}
+
+// InterestRateCardChangeMetadata records a rate card being created, changed or deleted with its
+// terms before and after. Old is unset for a new card and New for a deleted one.
+type InterestRateCardChangeMetadata struct {
+	CardID   uuid.UUID `json:"card_id"`
+	Currency string    `json:"currency"`
+	Product  string    `json:"product"`
+	Old      any       `json:"old,omitempty"`
+	New      any       `json:"new,omitempty"`
+}
+
+// InterestRateOverrideChangeMetadata records a rate override of an account being created, changed
+// or removed. The old values are unset for a new override and the new values for a removed one.
+type InterestRateOverrideChangeMetadata struct {
+	OverrideID  uuid.UUID `json:"override_id"`
+	OldKind     string    `json:"old_kind,omitempty"`
+	OldRate     int64     `json:"old_rate"`
+	OldStartsOn string    `json:"old_starts_on,omitempty"`
+	OldEndsOn   string    `json:"old_ends_on,omitempty"`
+	NewKind     string    `json:"new_kind,omitempty"`
+	NewRate     int64     `json:"new_rate"`
+	NewStartsOn string    `json:"new_starts_on,omitempty"`
+	NewEndsOn   string    `json:"new_ends_on,omitempty"`
+	Reason      string    `json:"reason,omitempty"`
+}
+
+// InterestAppliedMetadata records interest paid into an account at the end of a period. Amount is
+// what the account was paid, Gross less the tax withheld, in minor units. Rate and Balance are the
+// basis it was worked out on: the annual rate in basis points, bonus included, and the end-of-day
+// balance, both averaged over the days that accrued.
+type InterestAppliedMetadata struct {
+	TransactionID uuid.UUID `json:"transaction_id"`
+	Period        string    `json:"period"`
+	PeriodEnd     string    `json:"period_end"`
+	Days          int64     `json:"days"`
+	Rate          int64     `json:"rate"`
+	Balance       int64     `json:"balance"`
+	Gross         int64     `json:"gross"`
+	Tax           int64     `json:"tax"`
+	Amount        int64     `json:"amount"`
+	Currency      string    `json:"currency"`
+}
+
+// InterestRunRetryMetadata records an admin re-running a failed account of an interest run.
+type InterestRunRetryMetadata struct {
+	RunID   uuid.UUID `json:"run_id"`
+	RunDate string    `json:"run_date"`
 }
 
 type AuditLog struct {
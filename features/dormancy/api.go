@@ -0,0 +1,74 @@
+package dormancy
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"payter-bank/internal/api"
+	"payter-bank/internal/auth"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// ReactivateAccountHandler godoc
+// @Summary      Reactivate dormant account
+// @Description  Reactivate a DORMANT account after the owner has been re-verified. Only an admin can do this
+// @Tags         accounts
+// @Accept       json
+// @Produce      json
+// @Param        params  body  ReactivateAccountParams  true  "Re-verification details"
+// @Success      200  {object}  api.SuccessResponse
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      412  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/accounts/:id/reactivate [post]
+func (h *Handler) ReactivateAccountHandler(ctx *gin.Context) api.Response {
+	accountID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return api.BadRequest("account id is required")
+	}
+
+	var params ReactivateAccountParams
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+
+	params.AccountID = accountID
+	params.AdminUserID = profile.UserID
+	if err := h.service.ReactivateAccount(ctx, params); err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("account reactivated successfully", nil)
+}
+
+// GetDormancyReportHandler godoc
+// @Summary      Dormancy report
+// @Description  List dormant accounts with their balances and totals per currency. Only an admin can do this
+// @Tags         reports
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  api.SuccessResponse{data=Report}
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/reports/dormancy [get]
+func (h *Handler) GetDormancyReportHandler(ctx *gin.Context) api.Response {
+	report, err := h.service.GetDormancyReport(ctx)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("dormancy report generated successfully", report)
+}
@@ -0,0 +1,119 @@
+package dormancy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"payter-bank/internal/api"
+	"payter-bank/internal/auth"
+	platformerrors "payter-bank/internal/errors"
+	"testing"
+)
+
+func TestHandler_ReactivateAccountHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("reactivates account", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := NewMockService(ctrl)
+		handler := NewHandler(mockService)
+
+		adminID, accountID := uuid.New(), uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		body, _ := json.Marshal(map[string]string{
+			"verification_method":    "VIDEO_CALL",
+			"verification_reference": "CALL-42",
+		})
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/accounts/"+accountID.String()+"/reactivate", bytes.NewBuffer(body))
+		c.Params = gin.Params{{Key: "id", Value: accountID.String()}}
+		injectProfile(c, auth.Profile{UserID: adminID, UserType: "ADMIN"})
+
+		mockService.EXPECT().ReactivateAccount(gomock.Any(), ReactivateAccountParams{
+			VerificationMethod:    "VIDEO_CALL",
+			VerificationReference: "CALL-42",
+			AccountID:             accountID,
+			AdminUserID:           adminID,
+		}).Return(nil)
+
+		response := handler.ReactivateAccountHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+
+	t.Run("rejects unknown verification method", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		handler := NewHandler(NewMockService(ctrl))
+
+		accountID := uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		body, _ := json.Marshal(map[string]string{
+			"verification_method":    "EMAIL",
+			"verification_reference": "x",
+		})
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/accounts/"+accountID.String()+"/reactivate", bytes.NewBuffer(body))
+		c.Params = gin.Params{{Key: "id", Value: accountID.String()}}
+		injectProfile(c, auth.Profile{UserID: uuid.New(), UserType: "ADMIN"})
+
+		response := handler.ReactivateAccountHandler(c)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+
+	t.Run("returns service error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := NewMockService(ctrl)
+		handler := NewHandler(mockService)
+
+		accountID := uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		body, _ := json.Marshal(map[string]string{
+			"verification_method":    "IN_BRANCH",
+			"verification_reference": "BR-1",
+		})
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/accounts/"+accountID.String()+"/reactivate", bytes.NewBuffer(body))
+		c.Params = gin.Params{{Key: "id", Value: accountID.String()}}
+		injectProfile(c, auth.Profile{UserID: uuid.New(), UserType: "ADMIN"})
+
+		mockService.EXPECT().ReactivateAccount(gomock.Any(), gomock.Any()).
+			Return(platformerrors.MakeApiError(http.StatusPreconditionFailed, "account is not dormant"))
+
+		response := handler.ReactivateAccountHandler(c)
+		assert.Equal(t, http.StatusPreconditionFailed, response.Code)
+		assert.Equal(t, "account is not dormant", response.Error.Message)
+	})
+}
+
+func TestHandler_GetDormancyReportHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("returns report", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := NewMockService(ctrl)
+		handler := NewHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/v1/api/reports/dormancy", nil)
+
+		report := &Report{Totals: []CurrencyTotal{{Currency: "GBP", Accounts: 1, Balance: 10}}}
+		mockService.EXPECT().GetDormancyReport(gomock.Any()).Return(report, nil)
+
+		response := handler.GetDormancyReportHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    report,
+			Message: "dormancy report generated successfully",
+		}, response.Data)
+	})
+}
+
+func injectProfile(ctx *gin.Context, profile auth.Profile) {
+	ctx.Request = ctx.Request.WithContext(context.WithValue(ctx.Request.Context(), auth.ProfileKey, profile))
+}
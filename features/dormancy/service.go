@@ -0,0 +1,237 @@
+//go:generate mockgen -source=service.go -destination=service_mock.go -package=dormancy
+
+package dormancy
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"net/http"
+	"payter-bank/features/auditlog"
+	"payter-bank/features/notification"
+	"payter-bank/internal/config"
+	"payter-bank/internal/database/models"
+	platformerrors "payter-bank/internal/errors"
+	"payter-bank/internal/logger"
+	"payter-bank/internal/scheduler"
+	"sort"
+	"time"
+)
+
+type Service interface {
+	// DetectDormantAccounts moves ACTIVE customer accounts with no customer-initiated activity
+	// within the configured period to DORMANT and notifies their owners.
+	DetectDormantAccounts(ctx context.Context) (*DetectionResult, error)
+	ReactivateAccount(ctx context.Context, param ReactivateAccountParams) error
+	GetDormancyReport(ctx context.Context) (*Report, error)
+	Start(ctx context.Context) error
+}
+
+// Scheduler schedules the detection job.
+type Scheduler interface {
+	Schedule(job scheduler.Job) error
+}
+
+const dormancyJob = "dormancy"
+
+type service struct {
+	db           models.Querier
+	auditLog     auditlog.Service
+	notification notification.Service
+	scheduler    Scheduler
+	cfg          config.DormancyConfig
+	app          config.AppConfig
+	now          func() time.Time
+}
+
+func NewService(db models.Querier, auditLog auditlog.Service, notificationService notification.Service,
+	scheduler Scheduler, cfg config.Config) Service {
+	return &service{
+		db:           db,
+		auditLog:     auditLog,
+		notification: notificationService,
+		scheduler:    scheduler,
+		cfg:          cfg.Dormancy,
+		app:          cfg.App,
+		now:          time.Now,
+	}
+}
+
+// Start schedules the detection job on DORMANCY_SCHEDULE.
+func (s *service) Start(ctx context.Context) error {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Start"))
+
+	err := s.scheduler.Schedule(scheduler.Job{
+		Name: dormancyJob,
+		Spec: s.cfg.Schedule,
+		Task: func(ctx context.Context) {
+			if _, err := s.DetectDormantAccounts(ctx); err != nil {
+				logger.Error(ctx, "dormancy detection failed", zap.Error(err))
+			}
+		},
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to schedule dormancy detection job", zap.Error(err))
+		return err
+	}
+
+	logger.Info(ctx, "dormancy detection job scheduled",
+		zap.String("schedule", s.cfg.Schedule))
+	return nil
+}
+
+func (s *service) DetectDormantAccounts(ctx context.Context) (*DetectionResult, error) {
+	cutoff := s.now().Add(-s.cfg.Period)
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "DetectDormantAccounts"),
+		zap.Time("cutoff", cutoff))
+
+	candidates, err := s.db.GetDormancyCandidates(ctx, sql.NullTime{Time: cutoff, Valid: true})
+	if err != nil {
+		logger.Error(ctx, "failed to get dormancy candidates", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	result := &DetectionResult{}
+	for _, candidate := range candidates {
+		// the status guard in the update makes this safe against a payment or status change
+		// that landed after the candidates were read
+		affected, err := s.db.MarkAccountDormant(ctx, candidate.AccountID)
+		if err != nil {
+			logger.Error(ctx, "failed to mark account dormant",
+				zap.String("account_id", candidate.AccountID.String()), zap.Error(err))
+			continue
+		}
+		if affected == 0 {
+			continue
+		}
+		result.Flagged++
+
+		auditEvent := auditlog.NewEvent(
+			auditlog.ActionAccountStatusChange,
+			s.app.SystemUserID,
+			candidate.AccountID,
+			auditlog.AccountStatusChangeMetadata{OldStatus: string(models.StatusACTIVE), NewStatus: string(models.StatusDORMANT)})
+		if err := s.auditLog.Submit(ctx, auditEvent); err != nil {
+			logger.Error(ctx, "failed to queue audit log", zap.Error(err))
+		}
+
+		err = s.notification.Notify(ctx, notification.Message{
+			UserID:  candidate.UserID,
+			Type:    notification.TypeAccountDormant,
+			Subject: "Your account is now dormant",
+			Message: fmt.Sprintf(
+				"There has been no activity on account %s since %s, so it has been marked dormant. "+
+					"Payments out of the account are blocked until you contact us to reactivate it.",
+				candidate.AccountNumber, candidate.LastActivityAt.Format(time.DateOnly)),
+			Metadata: map[string]any{
+				"account_id":       candidate.AccountID,
+				"last_activity_at": candidate.LastActivityAt,
+			},
+		})
+		if err != nil {
+			logger.Error(ctx, "failed to notify account owner", zap.Error(err))
+		}
+	}
+
+	logger.Info(ctx, "dormancy detection completed",
+		zap.Int("candidates", len(candidates)),
+		zap.Int("flagged", result.Flagged))
+	return result, nil
+}
+
+func (s *service) ReactivateAccount(ctx context.Context, param ReactivateAccountParams) error {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "ReactivateAccount"),
+		zap.Any(logger.RequestFields, param))
+
+	account, err := s.db.GetAccountByID(ctx, param.AccountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return platformerrors.MakeApiError(http.StatusNotFound, "account not found")
+		}
+		logger.Error(ctx, "failed to get account by id", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+
+	if account.Status != models.StatusDORMANT {
+		return platformerrors.MakeApiError(http.StatusPreconditionFailed, "account is not dormant")
+	}
+
+	affected, err := s.db.ReactivateDormantAccount(ctx, account.ID)
+	if err != nil {
+		logger.Error(ctx, "failed to reactivate account", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+	if affected == 0 {
+		return platformerrors.MakeApiError(http.StatusPreconditionFailed, "account is not dormant")
+	}
+
+	auditEvent := auditlog.NewEvent(
+		auditlog.ActionAccountStatusChange,
+		param.AdminUserID,
+		account.ID,
+		auditlog.AccountReactivationMetadata{
+			AccountStatusChangeMetadata: auditlog.AccountStatusChangeMetadata{
+				OldStatus: string(models.StatusDORMANT),
+				NewStatus: string(models.StatusACTIVE),
+			},
+			VerificationMethod:    param.VerificationMethod,
+			VerificationReference: param.VerificationReference,
+			Notes:                 param.Notes,
+		})
+	if err := s.auditLog.Submit(ctx, auditEvent); err != nil {
+		logger.Error(ctx, "failed to queue audit log", zap.Error(err))
+	}
+
+	err = s.notification.Notify(ctx, notification.Message{
+		UserID:   account.UserID,
+		Type:     notification.TypeAccountReactivated,
+		Subject:  "Your account has been reactivated",
+		Message:  fmt.Sprintf("Account %s is active again and can be used for payments.", account.AccountNumber),
+		Metadata: map[string]any{"account_id": account.ID},
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to notify account owner", zap.Error(err))
+	}
+	return nil
+}
+
+func (s *service) GetDormancyReport(ctx context.Context) (*Report, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "GetDormancyReport"))
+
+	rows, err := s.db.GetDormantAccounts(ctx)
+	if err != nil {
+		logger.Error(ctx, "failed to get dormant accounts", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	report := &Report{
+		GeneratedAt: s.now(),
+		Accounts:    make([]DormantAccount, 0, len(rows)),
+		Totals:      make([]CurrencyTotal, 0),
+	}
+	totals := make(map[models.Currency]int64)
+	counts := make(map[models.Currency]int)
+	for _, row := range rows {
+		report.Accounts = append(report.Accounts, DormantAccountFromRow(row))
+		totals[row.Currency] += row.Balance
+		counts[row.Currency]++
+	}
+
+	for currency, total := range totals {
+		report.Totals = append(report.Totals, CurrencyTotal{
+			Currency: string(currency),
+			Accounts: counts[currency],
+			Balance:  float64(total) / 100,
+		})
+	}
+	sort.Slice(report.Totals, func(i, j int) bool {
+		return report.Totals[i].Currency < report.Totals[j].Currency
+	})
+	return report, nil
+}
@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=service_mock.go -package=dormancy
+//
+
+// Package dormancy is a generated GoMock package.
+package dormancy
+
+import (
+	context "context"
+	scheduler "payter-bank/internal/scheduler"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// DetectDormantAccounts mocks base method.
+func (m *MockService) DetectDormantAccounts(ctx context.Context) (*DetectionResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetectDormantAccounts", ctx)
+	ret0, _ := ret[0].(*DetectionResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DetectDormantAccounts indicates an expected call of DetectDormantAccounts.
+func (mr *MockServiceMockRecorder) DetectDormantAccounts(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetectDormantAccounts", reflect.TypeOf((*MockService)(nil).DetectDormantAccounts), ctx)
+}
+
+// GetDormancyReport mocks base method.
+func (m *MockService) GetDormancyReport(ctx context.Context) (*Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDormancyReport", ctx)
+	ret0, _ := ret[0].(*Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDormancyReport indicates an expected call of GetDormancyReport.
+func (mr *MockServiceMockRecorder) GetDormancyReport(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDormancyReport", reflect.TypeOf((*MockService)(nil).GetDormancyReport), ctx)
+}
+
+// ReactivateAccount mocks base method.
+func (m *MockService) ReactivateAccount(ctx context.Context, param ReactivateAccountParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReactivateAccount", ctx, param)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReactivateAccount indicates an expected call of ReactivateAccount.
+func (mr *MockServiceMockRecorder) ReactivateAccount(ctx, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReactivateAccount", reflect.TypeOf((*MockService)(nil).ReactivateAccount), ctx, param)
+}
+
+// Start mocks base method.
+func (m *MockService) Start(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockServiceMockRecorder) Start(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockService)(nil).Start), ctx)
+}
+
+// MockScheduler is a mock of Scheduler interface.
+type MockScheduler struct {
+	ctrl     *gomock.Controller
+	recorder *MockSchedulerMockRecorder
+	isgomock struct{}
+}
+
+// MockSchedulerMockRecorder is the mock recorder for MockScheduler.
+type MockSchedulerMockRecorder struct {
+	mock *MockScheduler
+}
+
+// NewMockScheduler creates a new mock instance.
+func NewMockScheduler(ctrl *gomock.Controller) *MockScheduler {
+	mock := &MockScheduler{ctrl: ctrl}
+	mock.recorder = &MockSchedulerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScheduler) EXPECT() *MockSchedulerMockRecorder {
+	return m.recorder
+}
+
+// Schedule mocks base method.
+func (m *MockScheduler) Schedule(job scheduler.Job) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Schedule", job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Schedule indicates an expected call of Schedule.
+func (mr *MockSchedulerMockRecorder) Schedule(job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Schedule", reflect.TypeOf((*MockScheduler)(nil).Schedule), job)
+}
@@ -0,0 +1,237 @@
+package dormancy
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"payter-bank/features/auditlog"
+	"payter-bank/features/notification"
+	"payter-bank/internal/config"
+	"payter-bank/internal/database/models"
+	databasemocks "payter-bank/internal/database/models/mocks"
+	platformerrors "payter-bank/internal/errors"
+	"payter-bank/internal/scheduler"
+	"testing"
+	"time"
+)
+
+var (
+	systemUserID = uuid.MustParse("00000000-2222-2222-2222-000000000000")
+	fixedNow     = time.Date(2026, 3, 1, 2, 0, 0, 0, time.UTC)
+)
+
+func TestService_DetectDormantAccounts(t *testing.T) {
+	t.Run("marks inactive accounts dormant, audits and notifies owners", func(t *testing.T) {
+		m := newDormancyMocker(t)
+		accountID, userID := uuid.New(), uuid.New()
+		lastActivity := fixedNow.AddDate(-1, -2, 0)
+
+		m.db.EXPECT().
+			GetDormancyCandidates(gomock.Any(), sql.NullTime{Time: fixedNow.Add(-365 * 24 * time.Hour), Valid: true}).
+			Return([]models.GetDormancyCandidatesRow{{
+				AccountID:      accountID,
+				UserID:         userID,
+				AccountNumber:  "12345679",
+				Currency:       models.CurrencyGBP,
+				LastActivityAt: lastActivity,
+			}}, nil)
+		m.db.EXPECT().MarkAccountDormant(gomock.Any(), accountID).Return(int64(1), nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(
+			auditlog.ActionAccountStatusChange, systemUserID, accountID,
+			auditlog.AccountStatusChangeMetadata{OldStatus: "ACTIVE", NewStatus: "DORMANT"},
+		)).Return(nil)
+		m.notification.EXPECT().Notify(gomock.Any(), gomock.Cond(func(x any) bool {
+			message := x.(notification.Message)
+			return message.UserID == userID && message.Type == notification.TypeAccountDormant
+		})).Return(nil)
+
+		result, err := m.service.DetectDormantAccounts(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, &DetectionResult{Flagged: 1}, result)
+	})
+
+	t.Run("skips accounts that changed after they were selected", func(t *testing.T) {
+		m := newDormancyMocker(t)
+		accountID := uuid.New()
+
+		m.db.EXPECT().GetDormancyCandidates(gomock.Any(), gomock.Any()).
+			Return([]models.GetDormancyCandidatesRow{{AccountID: accountID, UserID: uuid.New()}}, nil)
+		m.db.EXPECT().MarkAccountDormant(gomock.Any(), accountID).Return(int64(0), nil)
+
+		result, err := m.service.DetectDormantAccounts(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, 0, result.Flagged)
+	})
+
+	t.Run("continues when one account fails", func(t *testing.T) {
+		m := newDormancyMocker(t)
+		failing, ok := uuid.New(), uuid.New()
+
+		m.db.EXPECT().GetDormancyCandidates(gomock.Any(), gomock.Any()).
+			Return([]models.GetDormancyCandidatesRow{
+				{AccountID: failing, UserID: uuid.New()},
+				{AccountID: ok, UserID: uuid.New()},
+			}, nil)
+		m.db.EXPECT().MarkAccountDormant(gomock.Any(), failing).Return(int64(0), errors.New("db error"))
+		m.db.EXPECT().MarkAccountDormant(gomock.Any(), ok).Return(int64(1), nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), gomock.Any()).Return(nil)
+		m.notification.EXPECT().Notify(gomock.Any(), gomock.Any()).Return(nil)
+
+		result, err := m.service.DetectDormantAccounts(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.Flagged)
+	})
+
+	t.Run("fails when candidates cannot be loaded", func(t *testing.T) {
+		m := newDormancyMocker(t)
+		m.db.EXPECT().GetDormancyCandidates(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("db error"))
+
+		_, err := m.service.DetectDormantAccounts(context.TODO())
+		assert.Equal(t, platformerrors.ErrInternal, err)
+	})
+}
+
+func TestService_ReactivateAccount(t *testing.T) {
+	t.Run("reactivates dormant account with verification details", func(t *testing.T) {
+		m := newDormancyMocker(t)
+		accountID, ownerID, adminID := uuid.New(), uuid.New(), uuid.New()
+		param := ReactivateAccountParams{
+			VerificationMethod:    "ID_DOCUMENT",
+			VerificationReference: "PASSPORT-123",
+			Notes:                 "customer visited branch",
+			AccountID:             accountID,
+			AdminUserID:           adminID,
+		}
+
+		m.db.EXPECT().GetAccountByID(gomock.Any(), accountID).
+			Return(models.GetAccountByIDRow{ID: accountID, UserID: ownerID, Status: models.StatusDORMANT}, nil)
+		m.db.EXPECT().ReactivateDormantAccount(gomock.Any(), accountID).Return(int64(1), nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(
+			auditlog.ActionAccountStatusChange, adminID, accountID,
+			auditlog.AccountReactivationMetadata{
+				AccountStatusChangeMetadata: auditlog.AccountStatusChangeMetadata{OldStatus: "DORMANT", NewStatus: "ACTIVE"},
+				VerificationMethod:          "ID_DOCUMENT",
+				VerificationReference:       "PASSPORT-123",
+				Notes:                       "customer visited branch",
+			},
+		)).Return(nil)
+		m.notification.EXPECT().Notify(gomock.Any(), gomock.Cond(func(x any) bool {
+			message := x.(notification.Message)
+			return message.UserID == ownerID && message.Type == notification.TypeAccountReactivated
+		})).Return(nil)
+
+		err := m.service.ReactivateAccount(context.TODO(), param)
+		assert.NoError(t, err)
+	})
+
+	t.Run("fails when account is not dormant", func(t *testing.T) {
+		m := newDormancyMocker(t)
+		accountID := uuid.New()
+		m.db.EXPECT().GetAccountByID(gomock.Any(), accountID).
+			Return(models.GetAccountByIDRow{ID: accountID, Status: models.StatusACTIVE}, nil)
+
+		err := m.service.ReactivateAccount(context.TODO(), ReactivateAccountParams{AccountID: accountID})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusPreconditionFailed, "account is not dormant"), err)
+	})
+
+	t.Run("fails when account not found", func(t *testing.T) {
+		m := newDormancyMocker(t)
+		accountID := uuid.New()
+		m.db.EXPECT().GetAccountByID(gomock.Any(), accountID).
+			Return(models.GetAccountByIDRow{}, sql.ErrNoRows)
+
+		err := m.service.ReactivateAccount(context.TODO(), ReactivateAccountParams{AccountID: accountID})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusNotFound, "account not found"), err)
+	})
+}
+
+func TestService_GetDormancyReport(t *testing.T) {
+	t.Run("lists dormant accounts with totals per currency", func(t *testing.T) {
+		m := newDormancyMocker(t)
+		dormantSince := sql.NullTime{Time: fixedNow.AddDate(0, -1, 0), Valid: true}
+		rows := []models.GetDormantAccountsRow{
+			{AccountID: uuid.New(), Currency: models.CurrencyGBP, Balance: 10050, DormantSince: dormantSince},
+			{AccountID: uuid.New(), Currency: models.CurrencyEUR, Balance: 2000, DormantSince: dormantSince},
+			{AccountID: uuid.New(), Currency: models.CurrencyGBP, Balance: 950, DormantSince: dormantSince},
+		}
+		m.db.EXPECT().GetDormantAccounts(gomock.Any()).Return(rows, nil)
+
+		report, err := m.service.GetDormancyReport(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, fixedNow, report.GeneratedAt)
+		assert.Len(t, report.Accounts, 3)
+		assert.Equal(t, 100.50, report.Accounts[0].Balance)
+		assert.Nil(t, report.Accounts[0].LastActivityAt)
+		assert.Equal(t, []CurrencyTotal{
+			{Currency: "EUR", Accounts: 1, Balance: 20},
+			{Currency: "GBP", Accounts: 2, Balance: 110},
+		}, report.Totals)
+	})
+
+	t.Run("fails when dormant accounts cannot be loaded", func(t *testing.T) {
+		m := newDormancyMocker(t)
+		m.db.EXPECT().GetDormantAccounts(gomock.Any()).Return(nil, errors.New("db error"))
+
+		_, err := m.service.GetDormancyReport(context.TODO())
+		assert.Equal(t, platformerrors.ErrInternal, err)
+	})
+}
+
+type dormancyMocker struct {
+	db           *databasemocks.MockQuerier
+	auditLog     *auditlog.MockService
+	notification *notification.MockService
+	jobs         *MockScheduler
+
+	service Service
+}
+
+func TestService_Start(t *testing.T) {
+	m := newDormancyMocker(t)
+
+	var job scheduler.Job
+	m.jobs.EXPECT().
+		Schedule(gomock.Any()).
+		DoAndReturn(func(j scheduler.Job) error {
+			job = j
+			return nil
+		})
+
+	err := m.service.Start(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "dormancy", job.Name)
+	assert.Equal(t, "0 2 * * *", job.Spec)
+	assert.False(t, job.RunOnStart)
+
+	// the task runs detection
+	m.db.EXPECT().GetDormancyCandidates(gomock.Any(), gomock.Any()).Return(nil, nil)
+	job.Task(context.Background())
+}
+
+func newDormancyMocker(t *testing.T) *dormancyMocker {
+	ctrl := gomock.NewController(t)
+	db := databasemocks.NewMockQuerier(ctrl)
+	auditLog := auditlog.NewMockService(ctrl)
+	notificationService := notification.NewMockService(ctrl)
+	cfg := config.Config{
+		App:      config.AppConfig{SystemUserID: systemUserID},
+		Dormancy: config.DormancyConfig{Period: 365 * 24 * time.Hour, Schedule: "0 2 * * *"},
+	}
+
+	jobs := NewMockScheduler(ctrl)
+	svc := NewService(db, auditLog, notificationService, jobs, cfg)
+	svc.(*service).now = func() time.Time { return fixedNow }
+	return &dormancyMocker{
+		db:           db,
+		auditLog:     auditLog,
+		notification: notificationService,
+		jobs:         jobs,
+		service:      svc,
+	}
+}
@@ -0,0 +1,62 @@
+package dormancy
+
+import (
+	"github.com/google/uuid"
+	"payter-bank/internal/database/models"
+	"time"
+)
+
+type ReactivateAccountParams struct {
+	VerificationMethod    string    `json:"verification_method" binding:"required,oneof=ID_DOCUMENT VIDEO_CALL IN_BRANCH"`
+	VerificationReference string    `json:"verification_reference" binding:"required"`
+	Notes                 string    `json:"notes"`
+	AccountID             uuid.UUID `json:"-"`
+	AdminUserID           uuid.UUID `json:"-"`
+}
+
+type DetectionResult struct {
+	Flagged int `json:"flagged"`
+}
+
+type DormantAccount struct {
+	AccountID      uuid.UUID  `json:"account_id"`
+	UserID         uuid.UUID  `json:"user_id"`
+	FirstName      string     `json:"first_name"`
+	LastName       string     `json:"last_name"`
+	AccountNumber  string     `json:"account_number"`
+	Currency       string     `json:"currency"`
+	Balance        float64    `json:"balance"`
+	LastActivityAt *time.Time `json:"last_activity_at"`
+	DormantSince   *time.Time `json:"dormant_since"`
+}
+
+func DormantAccountFromRow(row models.GetDormantAccountsRow) DormantAccount {
+	account := DormantAccount{
+		AccountID:     row.AccountID,
+		UserID:        row.UserID,
+		FirstName:     row.FirstName,
+		LastName:      row.LastName,
+		AccountNumber: row.AccountNumber,
+		Currency:      string(row.Currency),
+		Balance:       float64(row.Balance) / 100,
+	}
+	if row.LastActivityAt.Valid {
+		account.LastActivityAt = &row.LastActivityAt.Time
+	}
+	if row.DormantSince.Valid {
+		account.DormantSince = &row.DormantSince.Time
+	}
+	return account
+}
+
+type CurrencyTotal struct {
+	Currency string  `json:"currency"`
+	Accounts int     `json:"accounts"`
+	Balance  float64 `json:"balance"`
+}
+
+type Report struct {
+	GeneratedAt time.Time        `json:"generated_at"`
+	Accounts    []DormantAccount `json:"accounts"`
+	Totals      []CurrencyTotal  `json:"totals"`
+}
@@ -1,18 +1,23 @@
 package interestrate
 
 import (
+	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"payter-bank/features/approval"
 	"payter-bank/internal/api"
 	"payter-bank/internal/auth"
 )
 
 type Handler struct {
-	service Service
+	service   Service
+	approvals approval.Service
 }
 
-func NewHandler(service Service) *Handler {
+func NewHandler(service Service, approvals approval.Service) *Handler {
 	return &Handler{
-		service: service,
+		service:   service,
+		approvals: approvals,
 	}
 }
 
@@ -24,6 +29,7 @@ func NewHandler(service Service) *Handler {
 // @Produce      json
 // @Param        interest_rate  body  CreateInterestRateParam  true  "Create interest rate params"
 // @Success      200  {object}  api.SuccessResponse{data=Response}
+// @Success      202  {object}  api.SuccessResponse{data=approval.Request}
 // @Failure      400  {object}  api.ErrorResponse
 // @Failure      404  {object}  api.ErrorResponse
 // @Failure      500  {object}  api.ErrorResponse
@@ -40,6 +46,18 @@ func (h *Handler) CreateInterestRateHandler(ctx *gin.Context) api.Response {
 	}
 
 	param.UserID = profile.UserID
+	if h.approvals.Requires(approval.OperationInterestRateCreate, 0) {
+		request, err := h.approvals.Submit(ctx, approval.SubmitParams{
+			Operation:   approval.OperationInterestRateCreate,
+			Payload:     param,
+			RequestedBy: profile.UserID,
+		})
+		if err != nil {
+			return api.Error(err)
+		}
+		return api.Accepted("interest rate is awaiting approval", request)
+	}
+
 	response, err := h.service.CreateInterestRate(ctx, param)
 	if err != nil {
 		return api.Error(err)
@@ -50,12 +68,13 @@ func (h *Handler) CreateInterestRateHandler(ctx *gin.Context) api.Response {
 
 // UpdateRateHandler godoc
 // @Summary      Update interest rate
-// @Description  Update an existing interest rate
+// @Description  Change the interest rate from effective_from, today when it is not set. A later date schedules the change
 // @Tags         interest-rate
 // @Accept       json
 // @Produce      json
 // @Param        interest_rate  body  UpdateRateParam  true  "Update interest rate params"
 // @Success      200  {object}  api.SuccessResponse{data=Response}
+// @Success      202  {object}  api.SuccessResponse{data=approval.Request}
 // @Failure      400  {object}  api.ErrorResponse
 // @Failure      404  {object}  api.ErrorResponse
 // @Failure      500  {object}  api.ErrorResponse
@@ -72,6 +91,18 @@ func (h *Handler) UpdateRateHandler(ctx *gin.Context) api.Response {
 	}
 
 	param.UserID = profile.UserID
+	if h.approvals.Requires(approval.OperationInterestRateUpdate, 0) {
+		request, err := h.approvals.Submit(ctx, approval.SubmitParams{
+			Operation:   approval.OperationInterestRateUpdate,
+			Payload:     param,
+			RequestedBy: profile.UserID,
+		})
+		if err != nil {
+			return api.Error(err)
+		}
+		return api.Accepted("interest rate change is awaiting approval", request)
+	}
+
 	response, err := h.service.UpdateRate(ctx, param)
 	if err != nil {
 		return api.Error(err)
@@ -88,6 +119,7 @@ func (h *Handler) UpdateRateHandler(ctx *gin.Context) api.Response {
 // @Produce      json
 // @Param        calculation_frequency  body  UpdateCalculationFrequencyParam  true  "Update calculation frequency params"
 // @Success      200  {object}  api.SuccessResponse{data=Response}
+// @Success      202  {object}  api.SuccessResponse{data=approval.Request}
 // @Failure      400  {object}  api.ErrorResponse
 // @Failure      404  {object}  api.ErrorResponse
 // @Failure      500  {object}  api.ErrorResponse
@@ -104,6 +136,18 @@ func (h *Handler) UpdateCalculationFrequencyHandler(ctx *gin.Context) api.Respon
 	}
 
 	param.UserID = profile.UserID
+	if h.approvals.Requires(approval.OperationInterestFrequencyUpdate, 0) {
+		request, err := h.approvals.Submit(ctx, approval.SubmitParams{
+			Operation:   approval.OperationInterestFrequencyUpdate,
+			Payload:     param,
+			RequestedBy: profile.UserID,
+		})
+		if err != nil {
+			return api.Error(err)
+		}
+		return api.Accepted("calculation frequency change is awaiting approval", request)
+	}
+
 	response, err := h.service.UpdateCalculationFrequency(ctx, param)
 	if err != nil {
 		return api.Error(err)
@@ -131,3 +175,561 @@ func (h *Handler) GetCurrentRateHandler(ctx *gin.Context) api.Response {
 
 	return api.OK("current interest rate retrieved successfully", response)
 }
+
+// GetRateHistoryHandler godoc
+// @Summary      Get interest rate history
+// @Description  List every interest rate version with the days it is in effect, scheduled changes first
+// @Tags         interest-rate
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  api.SuccessResponse{data=[]RateVersion}
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/interest-rate/history [get]
+func (h *Handler) GetRateHistoryHandler(ctx *gin.Context) api.Response {
+	response, err := h.service.GetRateHistory(ctx)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("interest rate history retrieved successfully", response)
+}
+
+// SimulateHandler godoc
+// @Summary      Simulate interest rate
+// @Description  Work out the interest every active account would earn over a period at a candidate rate, nothing is posted
+// @Tags         interest-rate
+// @Accept       json
+// @Produce      json
+// @Param        simulation  body  SimulateParam  true  "Simulate interest rate params"
+// @Success      200  {object}  api.SuccessResponse{data=Simulation}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/interest-rate/simulate [post]
+func (h *Handler) SimulateHandler(ctx *gin.Context) api.Response {
+	var param SimulateParam
+	if err := ctx.ShouldBindJSON(&param); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	response, err := h.service.Simulate(ctx, param)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("interest rate simulated successfully", response)
+}
+
+// CreateRateCardHandler godoc
+// @Summary      Create rate card
+// @Description  Create the rate card of a currency and product, it takes precedence over the global rate
+// @Tags         interest-rate
+// @Accept       json
+// @Produce      json
+// @Param        rate_card  body  CreateRateCardParam  true  "Create rate card params"
+// @Success      200  {object}  api.SuccessResponse{data=RateCard}
+// @Success      202  {object}  api.SuccessResponse{data=approval.Request}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      409  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/interest-rate/cards [post]
+func (h *Handler) CreateRateCardHandler(ctx *gin.Context) api.Response {
+	var param CreateRateCardParam
+	if err := ctx.ShouldBindJSON(&param); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+
+	param.UserID = profile.UserID
+	if h.approvals.Requires(approval.OperationInterestRateCardCreate, 0) {
+		request, err := h.approvals.Submit(ctx, approval.SubmitParams{
+			Operation:   approval.OperationInterestRateCardCreate,
+			Payload:     param,
+			RequestedBy: profile.UserID,
+		})
+		if err != nil {
+			return api.Error(err)
+		}
+		return api.Accepted("rate card is awaiting approval", request)
+	}
+
+	response, err := h.service.CreateRateCard(ctx, param)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("rate card created successfully", response)
+}
+
+// UpdateRateCardHandler godoc
+// @Summary      Update rate card
+// @Description  Replace the terms of a rate card
+// @Tags         interest-rate
+// @Accept       json
+// @Produce      json
+// @Param        id         path  string         true  "Rate card ID"
+// @Param        rate_card  body  RateCardTerms  true  "Rate card terms"
+// @Success      200  {object}  api.SuccessResponse{data=RateCard}
+// @Success      202  {object}  api.SuccessResponse{data=approval.Request}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/interest-rate/cards/{id} [put]
+func (h *Handler) UpdateRateCardHandler(ctx *gin.Context) api.Response {
+	cardID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return api.BadRequest("rate card id is required")
+	}
+
+	var param UpdateRateCardParam
+	if err := ctx.ShouldBindJSON(&param); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+
+	param.UserID = profile.UserID
+	param.CardID = cardID
+	if h.approvals.Requires(approval.OperationInterestRateCardUpdate, 0) {
+		request, err := h.approvals.Submit(ctx, approval.SubmitParams{
+			Operation:   approval.OperationInterestRateCardUpdate,
+			Payload:     param,
+			RequestedBy: profile.UserID,
+		})
+		if err != nil {
+			return api.Error(err)
+		}
+		return api.Accepted("rate card change is awaiting approval", request)
+	}
+
+	response, err := h.service.UpdateRateCard(ctx, param)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("rate card updated successfully", response)
+}
+
+// DeleteRateCardHandler godoc
+// @Summary      Delete rate card
+// @Description  Delete a rate card, its accounts go back to the global rate
+// @Tags         interest-rate
+// @Accept       json
+// @Produce      json
+// @Param        id  path  string  true  "Rate card ID"
+// @Success      200  {object}  api.SuccessResponse
+// @Success      202  {object}  api.SuccessResponse{data=approval.Request}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/interest-rate/cards/{id} [delete]
+func (h *Handler) DeleteRateCardHandler(ctx *gin.Context) api.Response {
+	cardID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return api.BadRequest("rate card id is required")
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+
+	param := DeleteRateCardParam{UserID: profile.UserID, CardID: cardID}
+	if h.approvals.Requires(approval.OperationInterestRateCardDelete, 0) {
+		request, err := h.approvals.Submit(ctx, approval.SubmitParams{
+			Operation:   approval.OperationInterestRateCardDelete,
+			Payload:     param,
+			RequestedBy: profile.UserID,
+		})
+		if err != nil {
+			return api.Error(err)
+		}
+		return api.Accepted("rate card deletion is awaiting approval", request)
+	}
+
+	if err := h.service.DeleteRateCard(ctx, param); err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("rate card deleted successfully", nil)
+}
+
+// GetRateCardHandler godoc
+// @Summary      Get rate card
+// @Description  Get a rate card
+// @Tags         interest-rate
+// @Accept       json
+// @Produce      json
+// @Param        id  path  string  true  "Rate card ID"
+// @Success      200  {object}  api.SuccessResponse{data=RateCard}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/interest-rate/cards/{id} [get]
+func (h *Handler) GetRateCardHandler(ctx *gin.Context) api.Response {
+	cardID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return api.BadRequest("rate card id is required")
+	}
+
+	response, err := h.service.GetRateCard(ctx, cardID)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("rate card retrieved successfully", response)
+}
+
+// ListRateCardsHandler godoc
+// @Summary      List rate cards
+// @Description  List the rate cards of every currency and product
+// @Tags         interest-rate
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  api.SuccessResponse{data=[]RateCard}
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/interest-rate/cards [get]
+func (h *Handler) ListRateCardsHandler(ctx *gin.Context) api.Response {
+	response, err := h.service.ListRateCards(ctx)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("rate cards retrieved successfully", response)
+}
+
+// CreateRateOverrideHandler godoc
+// @Summary      Create rate override
+// @Description  Give an account a fixed rate or a boost on top of its rate for a while, it takes precedence over the global and product rate
+// @Tags         interest-rate
+// @Accept       json
+// @Produce      json
+// @Param        id        path  string             true  "Account ID"
+// @Param        override  body  RateOverrideTerms  true  "Rate override terms"
+// @Success      200  {object}  api.SuccessResponse{data=RateOverride}
+// @Success      202  {object}  api.SuccessResponse{data=approval.Request}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      409  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/accounts/{id}/interest-overrides [post]
+func (h *Handler) CreateRateOverrideHandler(ctx *gin.Context) api.Response {
+	accountID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return api.BadRequest("account id is required")
+	}
+
+	var param CreateRateOverrideParam
+	if err := ctx.ShouldBindJSON(&param); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+
+	param.UserID = profile.UserID
+	param.AccountID = accountID
+	if h.approvals.Requires(approval.OperationInterestRateOverrideCreate, 0) {
+		request, err := h.approvals.Submit(ctx, approval.SubmitParams{
+			Operation:   approval.OperationInterestRateOverrideCreate,
+			Payload:     param,
+			RequestedBy: profile.UserID,
+		})
+		if err != nil {
+			return api.Error(err)
+		}
+		return api.Accepted("rate override is awaiting approval", request)
+	}
+
+	response, err := h.service.CreateRateOverride(ctx, param)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("rate override created successfully", response)
+}
+
+// UpdateRateOverrideHandler godoc
+// @Summary      Update rate override
+// @Description  Replace the terms of a rate override, an override that has started can only be ended or have its rate changed
+// @Tags         interest-rate
+// @Accept       json
+// @Produce      json
+// @Param        id           path  string             true  "Account ID"
+// @Param        override_id  path  string             true  "Rate override ID"
+// @Param        override     body  RateOverrideTerms  true  "Rate override terms"
+// @Success      200  {object}  api.SuccessResponse{data=RateOverride}
+// @Success      202  {object}  api.SuccessResponse{data=approval.Request}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      409  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/accounts/{id}/interest-overrides/{override_id} [put]
+func (h *Handler) UpdateRateOverrideHandler(ctx *gin.Context) api.Response {
+	accountID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return api.BadRequest("account id is required")
+	}
+	overrideID, err := uuid.Parse(ctx.Param("override_id"))
+	if err != nil {
+		return api.BadRequest("rate override id is required")
+	}
+
+	var param UpdateRateOverrideParam
+	if err := ctx.ShouldBindJSON(&param); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+
+	param.UserID = profile.UserID
+	param.AccountID = accountID
+	param.OverrideID = overrideID
+	if h.approvals.Requires(approval.OperationInterestRateOverrideUpdate, 0) {
+		request, err := h.approvals.Submit(ctx, approval.SubmitParams{
+			Operation:   approval.OperationInterestRateOverrideUpdate,
+			Payload:     param,
+			RequestedBy: profile.UserID,
+		})
+		if err != nil {
+			return api.Error(err)
+		}
+		return api.Accepted("rate override change is awaiting approval", request)
+	}
+
+	response, err := h.service.UpdateRateOverride(ctx, param)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("rate override updated successfully", response)
+}
+
+// DeleteRateOverrideHandler godoc
+// @Summary      Delete rate override
+// @Description  Delete a rate override that has not started
+// @Tags         interest-rate
+// @Accept       json
+// @Produce      json
+// @Param        id           path  string  true  "Account ID"
+// @Param        override_id  path  string  true  "Rate override ID"
+// @Success      200  {object}  api.SuccessResponse
+// @Success      202  {object}  api.SuccessResponse{data=approval.Request}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/accounts/{id}/interest-overrides/{override_id} [delete]
+func (h *Handler) DeleteRateOverrideHandler(ctx *gin.Context) api.Response {
+	accountID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return api.BadRequest("account id is required")
+	}
+	overrideID, err := uuid.Parse(ctx.Param("override_id"))
+	if err != nil {
+		return api.BadRequest("rate override id is required")
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+
+	param := DeleteRateOverrideParam{UserID: profile.UserID, AccountID: accountID, OverrideID: overrideID}
+	if h.approvals.Requires(approval.OperationInterestRateOverrideDelete, 0) {
+		request, err := h.approvals.Submit(ctx, approval.SubmitParams{
+			Operation:   approval.OperationInterestRateOverrideDelete,
+			Payload:     param,
+			RequestedBy: profile.UserID,
+		})
+		if err != nil {
+			return api.Error(err)
+		}
+		return api.Accepted("rate override deletion is awaiting approval", request)
+	}
+
+	if err := h.service.DeleteRateOverride(ctx, param); err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("rate override deleted successfully", nil)
+}
+
+// ListRateOverridesHandler godoc
+// @Summary      List rate overrides
+// @Description  List the rate overrides of an account, latest start first
+// @Tags         interest-rate
+// @Accept       json
+// @Produce      json
+// @Param        id  path  string  true  "Account ID"
+// @Success      200  {object}  api.SuccessResponse{data=[]RateOverride}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/accounts/{id}/interest-overrides [get]
+func (h *Handler) ListRateOverridesHandler(ctx *gin.Context) api.Response {
+	accountID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return api.BadRequest("account id is required")
+	}
+
+	response, err := h.service.ListRateOverrides(ctx, accountID)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("rate overrides retrieved successfully", response)
+}
+
+// ListRunsHandler godoc
+// @Summary      List interest runs
+// @Description  List interest runs, one a day, newest first
+// @Tags         interest-rate
+// @Accept       json
+// @Produce      json
+// @Param        status  query  string  false  "RUNNING, COMPLETED or FAILED"
+// @Success      200  {object}  api.SuccessResponse{data=[]Run}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/interest-rate/runs [get]
+func (h *Handler) ListRunsHandler(ctx *gin.Context) api.Response {
+	var params ListRunsParam
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	response, err := h.service.ListRuns(ctx, params)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("interest runs retrieved successfully", response)
+}
+
+// GetRunHandler godoc
+// @Summary      Get interest run
+// @Description  Get an interest run with the result of each of its accounts, failed accounts first
+// @Tags         interest-rate
+// @Accept       json
+// @Produce      json
+// @Param        id  path  string  true  "Interest run ID"
+// @Success      200  {object}  api.SuccessResponse{data=Run}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/interest-rate/runs/{id} [get]
+func (h *Handler) GetRunHandler(ctx *gin.Context) api.Response {
+	runID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return api.BadRequest("interest run id is required")
+	}
+
+	response, err := h.service.GetRun(ctx, runID)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("interest run retrieved successfully", response)
+}
+
+// RetryRunAccountHandler godoc
+// @Summary      Re-run a failed account
+// @Description  Run a failed account of an interest run again, it is not accrued or paid twice
+// @Tags         interest-rate
+// @Accept       json
+// @Produce      json
+// @Param        id          path  string  true  "Interest run ID"
+// @Param        account_id  path  string  true  "Account ID"
+// @Success      200  {object}  api.SuccessResponse{data=Run}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      409  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/interest-rate/runs/{id}/accounts/{account_id}/retry [post]
+func (h *Handler) RetryRunAccountHandler(ctx *gin.Context) api.Response {
+	runID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return api.BadRequest("interest run id is required")
+	}
+	accountID, err := uuid.Parse(ctx.Param("account_id"))
+	if err != nil {
+		return api.BadRequest("account id is required")
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+
+	response, err := h.service.RetryRunAccount(ctx, RetryRunAccountParam{
+		UserID:    profile.UserID,
+		RunID:     runID,
+		AccountID: accountID,
+	})
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("interest run account re-run successfully", response)
+}
+
+// TaxCertificateHandler godoc
+// @Summary      Get tax certificate
+// @Description  Get the interest paid into an account in a tax year with the tax withheld from it, as JSON or as a PDF with format=pdf. Customers can only get the certificates of their own account
+// @Tags         interest-rate
+// @Accept       json
+// @Produce      json,application/pdf
+// @Param        id      path   string  true   "Account ID"
+// @Param        year    query  int     true   "Tax year"
+// @Param        format  query  string  false  "json or pdf"
+// @Success      200  {object}  api.SuccessResponse{data=TaxCertificate}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      401  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/accounts/{id}/tax-certificate [get]
+func (h *Handler) TaxCertificateHandler(ctx *gin.Context) api.Response {
+	accountID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return api.BadRequest("account ID is required")
+	}
+
+	var param TaxCertificateParam
+	if err := ctx.ShouldBindQuery(&param); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+
+	if !profile.HasPermission(auth.PermInterestRead) && profile.AccountID != accountID {
+		return api.Unauthorized("you are not authorized to view this account's tax certificates")
+	}
+
+	param.AccountID = accountID
+	certificate, err := h.service.GetTaxCertificate(ctx, param)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	if param.Format == "pdf" {
+		return api.Attachment(api.File{
+			Name:        fmt.Sprintf("tax-certificate-%s-%d.pdf", certificate.AccountNumber, certificate.Year),
+			ContentType: "application/pdf",
+			Content:     certificate.PDF(),
+		})
+	}
+
+	return api.OK("tax certificate retrieved successfully", certificate)
+}
@@ -11,10 +11,12 @@ import (
 	"go.uber.org/mock/gomock"
 	"net/http"
 	"net/http/httptest"
+	"payter-bank/features/approval"
 	"payter-bank/internal/api"
 	"payter-bank/internal/auth"
 	"payter-bank/internal/database/models"
 	platformerrors "payter-bank/internal/errors"
+	"strings"
 	"testing"
 )
 
@@ -23,7 +25,7 @@ func TestHandler_CreateInterestRateHandler(t *testing.T) {
 	t.Run("successfully creates interest rate", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := NewMockService(ctrl)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 
 		userID, accountID := uuid.New(), uuid.New()
 		rateID := uuid.New()
@@ -67,7 +69,7 @@ func TestHandler_CreateInterestRateHandler(t *testing.T) {
 	t.Run("returns error when request body is invalid", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := NewMockService(ctrl)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 
 		gin.SetMode(gin.TestMode)
 		w := httptest.NewRecorder()
@@ -83,7 +85,7 @@ func TestHandler_CreateInterestRateHandler(t *testing.T) {
 	t.Run("returns error when auth profile is missing", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := NewMockService(ctrl)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 
 		gin.SetMode(gin.TestMode)
 		w := httptest.NewRecorder()
@@ -105,7 +107,7 @@ func TestHandler_CreateInterestRateHandler(t *testing.T) {
 	t.Run("returns error when service fails", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := NewMockService(ctrl)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 
 		userID := uuid.New()
 		accountID := uuid.New()
@@ -165,7 +167,7 @@ func TestHandler_CreateInterestRateHandler(t *testing.T) {
 			t.Run(tc.name, func(t *testing.T) {
 				ctrl := gomock.NewController(t)
 				mockService := NewMockService(ctrl)
-				handler := NewHandler(mockService)
+				handler := NewHandler(mockService, withoutApprovals(t))
 
 				gin.SetMode(gin.TestMode)
 				w := httptest.NewRecorder()
@@ -191,7 +193,7 @@ func TestHandler_UpdateRateHandler(t *testing.T) {
 	t.Run("successfully updates interest rate", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := NewMockService(ctrl)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 
 		userID := uuid.New()
 		rateID := uuid.New()
@@ -232,10 +234,43 @@ func TestHandler_UpdateRateHandler(t *testing.T) {
 		}, response.Data)
 	})
 
+	t.Run("rate change waits for approval", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := NewMockService(ctrl)
+		approvals := approval.NewMockService(ctrl)
+		handler := NewHandler(mockService, approvals)
+
+		userID := uuid.New()
+		request := approval.Request{ID: uuid.New(), Operation: approval.OperationInterestRateUpdate, Status: "PENDING"}
+
+		gin.SetMode(gin.TestMode)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		jsonBody, _ := json.Marshal(UpdateRateParam{Rate: 6.5})
+		c.Request = httptest.NewRequest(http.MethodPut, "/v1/api/interest-rate", bytes.NewBuffer(jsonBody))
+		injectProfile(c, auth.Profile{UserID: userID})
+
+		approvals.EXPECT().Requires(approval.OperationInterestRateUpdate, 0.0).Return(true)
+		approvals.EXPECT().Submit(gomock.Any(), approval.SubmitParams{
+			Operation:   approval.OperationInterestRateUpdate,
+			Payload:     UpdateRateParam{Rate: 6.5, UserID: userID},
+			RequestedBy: userID,
+		}).Return(request, nil)
+
+		response := handler.UpdateRateHandler(c)
+
+		assert.Equal(t, http.StatusAccepted, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    request,
+			Message: "interest rate change is awaiting approval",
+		}, response.Data)
+	})
+
 	t.Run("returns error when request body is invalid", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := NewMockService(ctrl)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 
 		gin.SetMode(gin.TestMode)
 		w := httptest.NewRecorder()
@@ -252,7 +287,7 @@ func TestHandler_UpdateRateHandler(t *testing.T) {
 	t.Run("returns error when auth profile is missing", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := NewMockService(ctrl)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 
 		gin.SetMode(gin.TestMode)
 		w := httptest.NewRecorder()
@@ -273,7 +308,7 @@ func TestHandler_UpdateRateHandler(t *testing.T) {
 	t.Run("returns error when service fails", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := NewMockService(ctrl)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 
 		userID := uuid.New()
 
@@ -320,7 +355,7 @@ func TestHandler_UpdateRateHandler(t *testing.T) {
 			t.Run(tc.name, func(t *testing.T) {
 				ctrl := gomock.NewController(t)
 				mockService := NewMockService(ctrl)
-				handler := NewHandler(mockService)
+				handler := NewHandler(mockService, withoutApprovals(t))
 
 				gin.SetMode(gin.TestMode)
 				w := httptest.NewRecorder()
@@ -345,7 +380,7 @@ func TestHandler_UpdateCalculationFrequencyHandler(t *testing.T) {
 	t.Run("successfully updates calculation frequency", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := NewMockService(ctrl)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 
 		userID := uuid.New()
 		rateID := uuid.New()
@@ -355,7 +390,7 @@ func TestHandler_UpdateCalculationFrequencyHandler(t *testing.T) {
 		c, _ := gin.CreateTestContext(w)
 
 		reqBody := UpdateCalculationFrequencyParam{
-			CalculationFrequency: "weekly",
+			CalculationFrequency: "quarterly",
 		}
 		jsonBody, _ := json.Marshal(reqBody)
 		c.Request = httptest.NewRequest(http.MethodPut, "/v1/api/interest-rate/calculation-frequency", bytes.NewBuffer(jsonBody))
@@ -370,7 +405,7 @@ func TestHandler_UpdateCalculationFrequencyHandler(t *testing.T) {
 
 		mockService.EXPECT().
 			UpdateCalculationFrequency(gomock.Any(), UpdateCalculationFrequencyParam{
-				CalculationFrequency: "weekly",
+				CalculationFrequency: "quarterly",
 				UserID:               userID,
 			}).
 			Return(expectedResponse, nil)
@@ -387,7 +422,7 @@ func TestHandler_UpdateCalculationFrequencyHandler(t *testing.T) {
 	t.Run("returns error when request body is invalid", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := NewMockService(ctrl)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 
 		gin.SetMode(gin.TestMode)
 		w := httptest.NewRecorder()
@@ -404,14 +439,14 @@ func TestHandler_UpdateCalculationFrequencyHandler(t *testing.T) {
 	t.Run("returns error when auth profile is missing", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := NewMockService(ctrl)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 
 		gin.SetMode(gin.TestMode)
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 
 		reqBody := UpdateCalculationFrequencyParam{
-			CalculationFrequency: "weekly",
+			CalculationFrequency: "quarterly",
 		}
 		jsonBody, _ := json.Marshal(reqBody)
 		c.Request = httptest.NewRequest(http.MethodPut, "/v1/api/interest-rate/calculation-frequency", bytes.NewBuffer(jsonBody))
@@ -426,7 +461,7 @@ func TestHandler_UpdateCalculationFrequencyHandler(t *testing.T) {
 	t.Run("returns error when service fails", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := NewMockService(ctrl)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 
 		userID := uuid.New()
 
@@ -435,7 +470,7 @@ func TestHandler_UpdateCalculationFrequencyHandler(t *testing.T) {
 		c, _ := gin.CreateTestContext(w)
 
 		reqBody := UpdateCalculationFrequencyParam{
-			CalculationFrequency: "weekly",
+			CalculationFrequency: "quarterly",
 		}
 		jsonBody, _ := json.Marshal(reqBody)
 		c.Request = httptest.NewRequest(http.MethodPut, "/v1/api/interest-rate/calculation-frequency", bytes.NewBuffer(jsonBody))
@@ -443,7 +478,7 @@ func TestHandler_UpdateCalculationFrequencyHandler(t *testing.T) {
 
 		mockService.EXPECT().
 			UpdateCalculationFrequency(gomock.Any(), UpdateCalculationFrequencyParam{
-				CalculationFrequency: "weekly",
+				CalculationFrequency: "quarterly",
 				UserID:               userID,
 			}).
 			Return(nil, platformerrors.ErrInternal)
@@ -470,7 +505,11 @@ func TestHandler_UpdateCalculationFrequencyHandler(t *testing.T) {
 			},
 			{
 				name:                 "wrong case",
-				calculationFrequency: "WEEKLY",
+				calculationFrequency: "QUARTERLY",
+			},
+			{
+				name:                 "shorter than a month",
+				calculationFrequency: "daily",
 			},
 		}
 
@@ -478,7 +517,7 @@ func TestHandler_UpdateCalculationFrequencyHandler(t *testing.T) {
 			t.Run(tc.name, func(t *testing.T) {
 				ctrl := gomock.NewController(t)
 				mockService := NewMockService(ctrl)
-				handler := NewHandler(mockService)
+				handler := NewHandler(mockService, withoutApprovals(t))
 
 				gin.SetMode(gin.TestMode)
 				w := httptest.NewRecorder()
@@ -499,13 +538,13 @@ func TestHandler_UpdateCalculationFrequencyHandler(t *testing.T) {
 	})
 
 	t.Run("validates supported frequencies", func(t *testing.T) {
-		validFrequencies := []string{"hourly", "daily", "weekly", "monthly", "yearly"}
+		validFrequencies := []string{"monthly", "quarterly", "yearly"}
 
 		for _, freq := range validFrequencies {
 			t.Run(fmt.Sprintf("accepts %s frequency", freq), func(t *testing.T) {
 				ctrl := gomock.NewController(t)
 				mockService := NewMockService(ctrl)
-				handler := NewHandler(mockService)
+				handler := NewHandler(mockService, withoutApprovals(t))
 
 				userID := uuid.New()
 				rateID := uuid.New()
@@ -544,7 +583,7 @@ func TestHandler_GetCurrentRateHandler(t *testing.T) {
 	t.Run("successfully gets current interest rate", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := NewMockService(ctrl)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 
 		gin.SetMode(gin.TestMode)
 		w := httptest.NewRecorder()
@@ -574,7 +613,7 @@ func TestHandler_GetCurrentRateHandler(t *testing.T) {
 	t.Run("returns error when rate is not initialized", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := NewMockService(ctrl)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 
 		gin.SetMode(gin.TestMode)
 		w := httptest.NewRecorder()
@@ -600,7 +639,7 @@ func TestHandler_GetCurrentRateHandler(t *testing.T) {
 	t.Run("returns error when service fails", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := NewMockService(ctrl)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 
 		gin.SetMode(gin.TestMode)
 		w := httptest.NewRecorder()
@@ -630,9 +669,9 @@ func TestHandler_GetCurrentRateHandler(t *testing.T) {
 				calculationFrequency: "monthly",
 			},
 			{
-				name:                 "low rate daily",
+				name:                 "low rate quarterly",
 				rate:                 100,
-				calculationFrequency: "daily",
+				calculationFrequency: "quarterly",
 			},
 			{
 				name:                 "zero rate yearly",
@@ -645,7 +684,7 @@ func TestHandler_GetCurrentRateHandler(t *testing.T) {
 			t.Run(tc.name, func(t *testing.T) {
 				ctrl := gomock.NewController(t)
 				mockService := NewMockService(ctrl)
-				handler := NewHandler(mockService)
+				handler := NewHandler(mockService, withoutApprovals(t))
 
 				gin.SetMode(gin.TestMode)
 				w := httptest.NewRecorder()
@@ -671,6 +710,538 @@ func TestHandler_GetCurrentRateHandler(t *testing.T) {
 	})
 }
 
+func TestHandler_GetRateHistoryHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := NewMockService(ctrl)
+	handler := NewHandler(mockService, withoutApprovals(t))
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	c.Request = httptest.NewRequest(http.MethodGet, "/v1/api/interest-rate/history", nil)
+
+	history := []RateVersion{
+		{ID: uuid.New(), Rate: 4.5, CalculationFrequency: "monthly", EffectiveFrom: "2026-07-01", Status: "scheduled"},
+		{ID: uuid.New(), Rate: 5, CalculationFrequency: "monthly", EffectiveFrom: "2026-01-01", EffectiveTo: "2026-07-01", Status: "current"},
+	}
+
+	mockService.EXPECT().
+		GetRateHistory(gomock.Any()).
+		Return(history, nil)
+
+	response := handler.GetRateHistoryHandler(c)
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, api.SuccessResponse{
+		Data:    history,
+		Message: "interest rate history retrieved successfully",
+	}, response.Data)
+}
+
+func TestHandler_SimulateHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("simulates a candidate rate", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService, withoutApprovals(t))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/interest-rate/simulate",
+			strings.NewReader(`{"rate":3,"calculation_frequency":"monthly","period":"2026-04-15"}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		simulation := &Simulation{
+			Rate:                 3,
+			CalculationFrequency: "monthly",
+			Period:               "April 2026",
+			From:                 "2026-04-01",
+			To:                   "2026-04-30",
+			Accounts:             []SimulatedAccount{{AccountID: uuid.New(), Currency: "GBP", Interest: 24.6575342466, Paid: 24.66}},
+			Totals:               []SimulatedTotal{{Currency: "GBP", Accounts: 1, Interest: 24.6575342466, Paid: 24.66}},
+		}
+		mockService.EXPECT().
+			Simulate(gomock.Any(), SimulateParam{Rate: 3, CalculationFrequency: "monthly", Period: "2026-04-15"}).
+			Return(simulation, nil)
+
+		response := handler.SimulateHandler(c)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    simulation,
+			Message: "interest rate simulated successfully",
+		}, response.Data)
+	})
+
+	t.Run("rejects an unknown calculation frequency", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService, withoutApprovals(t))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/interest-rate/simulate",
+			strings.NewReader(`{"rate":3,"calculation_frequency":"weekly","period":"2026-04-15"}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		response := handler.SimulateHandler(c)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestHandler_CreateRateCardHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	body := `{"currency":"GBP","product":"CURRENT","method":"tiered","bands":[{"min_balance":0,"rate":1},{"min_balance":10000,"rate":2}],` +
+		`"bonus_rate":0.5,"bonus_conditions":["no_withdrawals"]}`
+	terms := RateCardTerms{
+		Method:          "tiered",
+		Bands:           []RateBand{{MinBalance: 0, Rate: 1}, {MinBalance: 10000, Rate: 2}},
+		BonusRate:       0.5,
+		BonusConditions: []Condition{NoWithdrawals},
+	}
+
+	t.Run("creates a rate card", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService, withoutApprovals(t))
+
+		userID := uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/interest-rate/cards", bytes.NewBufferString(body))
+		injectProfile(c, auth.Profile{UserID: userID})
+
+		card := &RateCard{ID: uuid.New(), Currency: "GBP", Product: "CURRENT", RateCardTerms: terms}
+		mockService.EXPECT().
+			CreateRateCard(gomock.Any(), CreateRateCardParam{
+				UserID:        userID,
+				Currency:      "GBP",
+				Product:       "CURRENT",
+				RateCardTerms: terms,
+			}).
+			Return(card, nil)
+
+		response := handler.CreateRateCardHandler(c)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    card,
+			Message: "rate card created successfully",
+		}, response.Data)
+	})
+
+	t.Run("rejects an unknown bonus condition", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService, withoutApprovals(t))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/interest-rate/cards",
+			bytes.NewBufferString(strings.Replace(body, "no_withdrawals", "no_fun", 1)))
+		injectProfile(c, auth.Profile{UserID: uuid.New()})
+
+		response := handler.CreateRateCardHandler(c)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+
+	t.Run("rate card waits for approval", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := NewMockService(ctrl)
+		approvals := approval.NewMockService(ctrl)
+		handler := NewHandler(mockService, approvals)
+
+		userID := uuid.New()
+		request := approval.Request{ID: uuid.New(), Operation: approval.OperationInterestRateCardCreate, Status: "PENDING"}
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/interest-rate/cards", bytes.NewBufferString(body))
+		injectProfile(c, auth.Profile{UserID: userID})
+
+		approvals.EXPECT().Requires(approval.OperationInterestRateCardCreate, 0.0).Return(true)
+		approvals.EXPECT().Submit(gomock.Any(), approval.SubmitParams{
+			Operation:   approval.OperationInterestRateCardCreate,
+			Payload:     CreateRateCardParam{UserID: userID, Currency: "GBP", Product: "CURRENT", RateCardTerms: terms},
+			RequestedBy: userID,
+		}).Return(request, nil)
+
+		response := handler.CreateRateCardHandler(c)
+
+		assert.Equal(t, http.StatusAccepted, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    request,
+			Message: "rate card is awaiting approval",
+		}, response.Data)
+	})
+}
+
+func TestHandler_UpdateRateCardHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := NewMockService(gomock.NewController(t))
+	handler := NewHandler(mockService, withoutApprovals(t))
+
+	userID, cardID := uuid.New(), uuid.New()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPut, "/v1/api/interest-rate/cards/"+cardID.String(),
+		bytes.NewBufferString(`{"method":"whole_balance","bands":[{"min_balance":0,"rate":1.5}]}`))
+	c.Params = gin.Params{{Key: "id", Value: cardID.String()}}
+	injectProfile(c, auth.Profile{UserID: userID})
+
+	card := &RateCard{ID: cardID}
+	mockService.EXPECT().
+		UpdateRateCard(gomock.Any(), UpdateRateCardParam{
+			UserID: userID,
+			CardID: cardID,
+			RateCardTerms: RateCardTerms{
+				Method: "whole_balance",
+				Bands:  []RateBand{{MinBalance: 0, Rate: 1.5}},
+			},
+		}).
+		Return(card, nil)
+
+	response := handler.UpdateRateCardHandler(c)
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, api.SuccessResponse{
+		Data:    card,
+		Message: "rate card updated successfully",
+	}, response.Data)
+}
+
+func TestHandler_CreateRateOverrideHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	body := `{"kind":"BOOST","rate":1,"starts_on":"2026-05-01","ends_on":"2026-07-31","reason":"Spring promotion"}`
+
+	t.Run("creates a rate override of the account", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService, withoutApprovals(t))
+
+		userID, accountID := uuid.New(), uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/accounts/"+accountID.String()+"/interest-overrides",
+			bytes.NewBufferString(body))
+		c.Params = gin.Params{{Key: "id", Value: accountID.String()}}
+		injectProfile(c, auth.Profile{UserID: userID})
+
+		terms := RateOverrideTerms{Kind: BoostOverride, Rate: 1, StartsOn: "2026-05-01", EndsOn: "2026-07-31", Reason: "Spring promotion"}
+		override := &RateOverride{ID: uuid.New(), AccountID: accountID, RateOverrideTerms: terms, Status: "scheduled"}
+		mockService.EXPECT().
+			CreateRateOverride(gomock.Any(), CreateRateOverrideParam{
+				UserID:            userID,
+				AccountID:         accountID,
+				RateOverrideTerms: terms,
+			}).
+			Return(override, nil)
+
+		response := handler.CreateRateOverrideHandler(c)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    override,
+			Message: "rate override created successfully",
+		}, response.Data)
+	})
+
+	t.Run("rejects an unknown kind", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService, withoutApprovals(t))
+
+		accountID := uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/accounts/"+accountID.String()+"/interest-overrides",
+			bytes.NewBufferString(strings.Replace(body, "BOOST", "BONUS", 1)))
+		c.Params = gin.Params{{Key: "id", Value: accountID.String()}}
+		injectProfile(c, auth.Profile{UserID: uuid.New()})
+
+		response := handler.CreateRateOverrideHandler(c)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+
+	t.Run("rate override waits for approval", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := NewMockService(ctrl)
+		approvals := approval.NewMockService(ctrl)
+		handler := NewHandler(mockService, approvals)
+
+		userID, accountID := uuid.New(), uuid.New()
+		request := approval.Request{ID: uuid.New(), Operation: approval.OperationInterestRateOverrideCreate, Status: "PENDING"}
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/accounts/"+accountID.String()+"/interest-overrides",
+			bytes.NewBufferString(body))
+		c.Params = gin.Params{{Key: "id", Value: accountID.String()}}
+		injectProfile(c, auth.Profile{UserID: userID})
+
+		terms := RateOverrideTerms{Kind: BoostOverride, Rate: 1, StartsOn: "2026-05-01", EndsOn: "2026-07-31", Reason: "Spring promotion"}
+		approvals.EXPECT().Requires(approval.OperationInterestRateOverrideCreate, 0.0).Return(true)
+		approvals.EXPECT().Submit(gomock.Any(), approval.SubmitParams{
+			Operation:   approval.OperationInterestRateOverrideCreate,
+			Payload:     CreateRateOverrideParam{UserID: userID, AccountID: accountID, RateOverrideTerms: terms},
+			RequestedBy: userID,
+		}).Return(request, nil)
+
+		response := handler.CreateRateOverrideHandler(c)
+
+		assert.Equal(t, http.StatusAccepted, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    request,
+			Message: "rate override is awaiting approval",
+		}, response.Data)
+	})
+}
+
+func TestHandler_UpdateRateOverrideHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("rate override change waits for approval", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := NewMockService(ctrl)
+		approvals := approval.NewMockService(ctrl)
+		handler := NewHandler(mockService, approvals)
+
+		userID, accountID, overrideID := uuid.New(), uuid.New(), uuid.New()
+		request := approval.Request{ID: uuid.New(), Operation: approval.OperationInterestRateOverrideUpdate, Status: "PENDING"}
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPut,
+			"/v1/api/accounts/"+accountID.String()+"/interest-overrides/"+overrideID.String(),
+			bytes.NewBufferString(`{"kind":"FIXED","rate":350,"starts_on":"2026-05-01"}`))
+		c.Params = gin.Params{{Key: "id", Value: accountID.String()}, {Key: "override_id", Value: overrideID.String()}}
+		injectProfile(c, auth.Profile{UserID: userID})
+
+		approvals.EXPECT().Requires(approval.OperationInterestRateOverrideUpdate, 0.0).Return(true)
+		approvals.EXPECT().Submit(gomock.Any(), approval.SubmitParams{
+			Operation: approval.OperationInterestRateOverrideUpdate,
+			Payload: UpdateRateOverrideParam{
+				UserID:            userID,
+				AccountID:         accountID,
+				OverrideID:        overrideID,
+				RateOverrideTerms: RateOverrideTerms{Kind: FixedOverride, Rate: 350, StartsOn: "2026-05-01"},
+			},
+			RequestedBy: userID,
+		}).Return(request, nil)
+
+		response := handler.UpdateRateOverrideHandler(c)
+
+		assert.Equal(t, http.StatusAccepted, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    request,
+			Message: "rate override change is awaiting approval",
+		}, response.Data)
+	})
+}
+
+func TestHandler_DeleteRateOverrideHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("deletes the rate override", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService, withoutApprovals(t))
+
+		userID, accountID, overrideID := uuid.New(), uuid.New(), uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodDelete,
+			"/v1/api/accounts/"+accountID.String()+"/interest-overrides/"+overrideID.String(), nil)
+		c.Params = gin.Params{{Key: "id", Value: accountID.String()}, {Key: "override_id", Value: overrideID.String()}}
+		injectProfile(c, auth.Profile{UserID: userID})
+
+		mockService.EXPECT().
+			DeleteRateOverride(gomock.Any(), DeleteRateOverrideParam{UserID: userID, AccountID: accountID, OverrideID: overrideID}).
+			Return(nil)
+
+		response := handler.DeleteRateOverrideHandler(c)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, api.SuccessResponse{Message: "rate override deleted successfully"}, response.Data)
+	})
+
+	t.Run("rate override deletion waits for approval", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := NewMockService(ctrl)
+		approvals := approval.NewMockService(ctrl)
+		handler := NewHandler(mockService, approvals)
+
+		userID, accountID, overrideID := uuid.New(), uuid.New(), uuid.New()
+		request := approval.Request{ID: uuid.New(), Operation: approval.OperationInterestRateOverrideDelete, Status: "PENDING"}
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodDelete,
+			"/v1/api/accounts/"+accountID.String()+"/interest-overrides/"+overrideID.String(), nil)
+		c.Params = gin.Params{{Key: "id", Value: accountID.String()}, {Key: "override_id", Value: overrideID.String()}}
+		injectProfile(c, auth.Profile{UserID: userID})
+
+		approvals.EXPECT().Requires(approval.OperationInterestRateOverrideDelete, 0.0).Return(true)
+		approvals.EXPECT().Submit(gomock.Any(), approval.SubmitParams{
+			Operation:   approval.OperationInterestRateOverrideDelete,
+			Payload:     DeleteRateOverrideParam{UserID: userID, AccountID: accountID, OverrideID: overrideID},
+			RequestedBy: userID,
+		}).Return(request, nil)
+
+		response := handler.DeleteRateOverrideHandler(c)
+
+		assert.Equal(t, http.StatusAccepted, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    request,
+			Message: "rate override deletion is awaiting approval",
+		}, response.Data)
+	})
+}
+
 func injectProfile(ctx *gin.Context, profile auth.Profile) {
 	ctx.Request = ctx.Request.WithContext(context.WithValue(ctx.Request.Context(), auth.ProfileKey, profile))
 }
+
+// withoutApprovals lets every operation through without a second admin.
+func withoutApprovals(t *testing.T) approval.Service {
+	approvals := approval.NewMockService(gomock.NewController(t))
+	approvals.EXPECT().Requires(gomock.Any(), gomock.Any()).Return(false).AnyTimes()
+	return approvals
+}
+
+func TestHandler_ListRunsHandler(t *testing.T) {
+	t.Run("filters runs by status", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := NewMockService(ctrl)
+		handler := NewHandler(mockService, withoutApprovals(t))
+
+		gin.SetMode(gin.TestMode)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/v1/api/interest-rate/runs?status=FAILED", nil)
+
+		runs := []Run{{ID: uuid.New(), RunDate: "2026-04-14", Status: "FAILED", Accounts: 2, Failed: 1}}
+		mockService.EXPECT().ListRuns(gomock.Any(), ListRunsParam{Status: "FAILED"}).Return(runs, nil)
+
+		response := handler.ListRunsHandler(c)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    runs,
+			Message: "interest runs retrieved successfully",
+		}, response.Data)
+	})
+
+	t.Run("rejects an unknown status", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := NewMockService(ctrl)
+		handler := NewHandler(mockService, withoutApprovals(t))
+
+		gin.SetMode(gin.TestMode)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/v1/api/interest-rate/runs?status=PENDING", nil)
+
+		response := handler.ListRunsHandler(c)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestHandler_RetryRunAccountHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := NewMockService(ctrl)
+	handler := NewHandler(mockService, withoutApprovals(t))
+
+	userID, runID, accountID := uuid.New(), uuid.New(), uuid.New()
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost,
+		"/v1/api/interest-rate/runs/"+runID.String()+"/accounts/"+accountID.String()+"/retry", nil)
+	c.Params = gin.Params{{Key: "id", Value: runID.String()}, {Key: "account_id", Value: accountID.String()}}
+	injectProfile(c, auth.Profile{UserID: userID})
+
+	run := &Run{ID: runID, RunDate: "2026-04-14", Status: "COMPLETED"}
+	mockService.EXPECT().
+		RetryRunAccount(gomock.Any(), RetryRunAccountParam{UserID: userID, RunID: runID, AccountID: accountID}).
+		Return(run, nil)
+
+	response := handler.RetryRunAccountHandler(c)
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, api.SuccessResponse{
+		Data:    run,
+		Message: "interest run account re-run successfully",
+	}, response.Data)
+}
+
+func TestHandler_TaxCertificateHandler(t *testing.T) {
+	accountID := uuid.New()
+	certificate := &TaxCertificate{AccountID: accountID, AccountNumber: "12345678", Currency: "GBP", Year: 2026,
+		Gross: 41.09, Tax: 8.21, Net: 32.88}
+
+	request := func(t *testing.T, query string, profile auth.Profile) *gin.Context {
+		gin.SetMode(gin.TestMode)
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/v1/api/accounts/"+accountID.String()+"/tax-certificate?"+query, nil)
+		c.Params = gin.Params{{Key: "id", Value: accountID.String()}}
+		injectProfile(c, profile)
+		return c
+	}
+
+	t.Run("returns the certificate of the customer's account", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService, withoutApprovals(t))
+
+		mockService.EXPECT().
+			GetTaxCertificate(gomock.Any(), TaxCertificateParam{Year: 2026, AccountID: accountID}).
+			Return(certificate, nil)
+
+		response := handler.TaxCertificateHandler(request(t, "year=2026", auth.Profile{AccountID: accountID}))
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    certificate,
+			Message: "tax certificate retrieved successfully",
+		}, response.Data)
+	})
+
+	t.Run("returns the certificate as a PDF", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService, withoutApprovals(t))
+
+		mockService.EXPECT().
+			GetTaxCertificate(gomock.Any(), TaxCertificateParam{Year: 2026, Format: "pdf", AccountID: accountID}).
+			Return(certificate, nil)
+
+		response := handler.TaxCertificateHandler(request(t, "year=2026&format=pdf",
+			auth.Profile{AccountID: uuid.New(), Permissions: []auth.Permission{auth.PermInterestRead}}))
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		file, ok := response.Data.(api.File)
+		assert.True(t, ok)
+		assert.Equal(t, "tax-certificate-12345678-2026.pdf", file.Name)
+		assert.Equal(t, "application/pdf", file.ContentType)
+		assert.True(t, bytes.HasPrefix(file.Content, []byte("%PDF-")))
+		assert.Contains(t, string(file.Content), "(32.88)")
+	})
+
+	t.Run("rejects another customer's account", func(t *testing.T) {
+		handler := NewHandler(NewMockService(gomock.NewController(t)), withoutApprovals(t))
+
+		response := handler.TaxCertificateHandler(request(t, "year=2026", auth.Profile{AccountID: uuid.New()}))
+
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+	})
+
+	t.Run("requires the year", func(t *testing.T) {
+		handler := NewHandler(NewMockService(gomock.NewController(t)), withoutApprovals(t))
+
+		response := handler.TaxCertificateHandler(request(t, "format=pdf", auth.Profile{AccountID: accountID}))
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+}
@@ -0,0 +1,109 @@
+package interestrate
+
+import (
+	"context"
+	"encoding/json"
+	"payter-bank/features/approval"
+)
+
+// The executors below apply approved interest rate changes. Changes are recorded against the admin who
+// requested them, the approval request records who approved them.
+
+func CreateRateExecutor(service Service) approval.Executor {
+	return func(ctx context.Context, request approval.Request) (any, error) {
+		var param CreateInterestRateParam
+		if err := json.Unmarshal(request.Payload, &param); err != nil {
+			return nil, err
+		}
+		param.UserID = request.RequestedBy
+		return service.CreateInterestRate(ctx, param)
+	}
+}
+
+func UpdateRateExecutor(service Service) approval.Executor {
+	return func(ctx context.Context, request approval.Request) (any, error) {
+		var param UpdateRateParam
+		if err := json.Unmarshal(request.Payload, &param); err != nil {
+			return nil, err
+		}
+		param.UserID = request.RequestedBy
+		return service.UpdateRate(ctx, param)
+	}
+}
+
+func UpdateCalculationFrequencyExecutor(service Service) approval.Executor {
+	return func(ctx context.Context, request approval.Request) (any, error) {
+		var param UpdateCalculationFrequencyParam
+		if err := json.Unmarshal(request.Payload, &param); err != nil {
+			return nil, err
+		}
+		param.UserID = request.RequestedBy
+		return service.UpdateCalculationFrequency(ctx, param)
+	}
+}
+
+func CreateRateCardExecutor(service Service) approval.Executor {
+	return func(ctx context.Context, request approval.Request) (any, error) {
+		var param CreateRateCardParam
+		if err := json.Unmarshal(request.Payload, &param); err != nil {
+			return nil, err
+		}
+		param.UserID = request.RequestedBy
+		return service.CreateRateCard(ctx, param)
+	}
+}
+
+func UpdateRateCardExecutor(service Service) approval.Executor {
+	return func(ctx context.Context, request approval.Request) (any, error) {
+		var param UpdateRateCardParam
+		if err := json.Unmarshal(request.Payload, &param); err != nil {
+			return nil, err
+		}
+		param.UserID = request.RequestedBy
+		return service.UpdateRateCard(ctx, param)
+	}
+}
+
+func DeleteRateCardExecutor(service Service) approval.Executor {
+	return func(ctx context.Context, request approval.Request) (any, error) {
+		var param DeleteRateCardParam
+		if err := json.Unmarshal(request.Payload, &param); err != nil {
+			return nil, err
+		}
+		param.UserID = request.RequestedBy
+		return nil, service.DeleteRateCard(ctx, param)
+	}
+}
+
+func CreateRateOverrideExecutor(service Service) approval.Executor {
+	return func(ctx context.Context, request approval.Request) (any, error) {
+		var param CreateRateOverrideParam
+		if err := json.Unmarshal(request.Payload, &param); err != nil {
+			return nil, err
+		}
+		param.UserID = request.RequestedBy
+		return service.CreateRateOverride(ctx, param)
+	}
+}
+
+func UpdateRateOverrideExecutor(service Service) approval.Executor {
+	return func(ctx context.Context, request approval.Request) (any, error) {
+		var param UpdateRateOverrideParam
+		if err := json.Unmarshal(request.Payload, &param); err != nil {
+			return nil, err
+		}
+		param.UserID = request.RequestedBy
+		return service.UpdateRateOverride(ctx, param)
+	}
+}
+
+func DeleteRateOverrideExecutor(service Service) approval.Executor {
+	return func(ctx context.Context, request approval.Request) (any, error) {
+		var param DeleteRateOverrideParam
+		if err := json.Unmarshal(request.Payload, &param); err != nil {
+			return nil, err
+		}
+		param.UserID = request.RequestedBy
+		return nil, service.DeleteRateOverride(ctx, param)
+	}
+}
@@ -0,0 +1,61 @@
+package interestrate
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"payter-bank/features/approval"
+	"testing"
+)
+
+func TestRateOverrideExecutors(t *testing.T) {
+	// the account and override come from the path, they must survive the round trip through the payload
+	requesterID, accountID, overrideID := uuid.New(), uuid.New(), uuid.New()
+	terms := RateOverrideTerms{Kind: FixedOverride, Rate: 350, StartsOn: "2026-05-01"}
+
+	t.Run("update", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		payload, _ := json.Marshal(UpdateRateOverrideParam{
+			UserID:            uuid.New(),
+			AccountID:         accountID,
+			OverrideID:        overrideID,
+			RateOverrideTerms: terms,
+		})
+
+		override := &RateOverride{ID: overrideID, AccountID: accountID, RateOverrideTerms: terms}
+		mockService.EXPECT().UpdateRateOverride(gomock.Any(), UpdateRateOverrideParam{
+			UserID:            requesterID,
+			AccountID:         accountID,
+			OverrideID:        overrideID,
+			RateOverrideTerms: terms,
+		}).Return(override, nil)
+
+		result, err := UpdateRateOverrideExecutor(mockService)(context.TODO(), approval.Request{
+			Payload:     payload,
+			RequestedBy: requesterID,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, override, result)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		payload, _ := json.Marshal(DeleteRateOverrideParam{AccountID: accountID, OverrideID: overrideID})
+
+		mockService.EXPECT().DeleteRateOverride(gomock.Any(), DeleteRateOverrideParam{
+			UserID:     requesterID,
+			AccountID:  accountID,
+			OverrideID: overrideID,
+		}).Return(nil)
+
+		_, err := DeleteRateOverrideExecutor(mockService)(context.TODO(), approval.Request{
+			Payload:     payload,
+			RequestedBy: requesterID,
+		})
+
+		assert.NoError(t, err)
+	})
+}
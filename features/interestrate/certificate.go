@@ -0,0 +1,54 @@
+package interestrate
+
+import (
+	"fmt"
+	"payter-bank/internal/pkg/pdf"
+	"time"
+)
+
+// columns of the payments table, in points from the left margin
+var certificateColumns = []float64{0, 110, 200, 290, 350, 420}
+
+// PDF renders the certificate as a document the customer can keep or hand to a tax authority.
+func (c TaxCertificate) PDF() []byte {
+	doc := pdf.New()
+	doc.Text(18, true, "Interest tax certificate")
+	doc.Text(11, false, fmt.Sprintf("Tax year 1 January %d to 31 December %d", c.Year, c.Year))
+	doc.Space(12)
+
+	doc.Line(10, false, pdf.Cell{Text: "Account holder"}, pdf.Cell{X: 110, Text: c.AccountHolder})
+	doc.Line(10, false, pdf.Cell{Text: "Account number"}, pdf.Cell{X: 110, Text: c.AccountNumber})
+	doc.Line(10, false, pdf.Cell{Text: "Currency"}, pdf.Cell{X: 110, Text: c.Currency})
+	doc.Line(10, false, pdf.Cell{Text: "Issued"}, pdf.Cell{X: 110, Text: c.IssuedAt.Format(time.DateOnly)})
+	doc.Space(12)
+
+	doc.Text(12, true, "Summary")
+	doc.Line(10, false, pdf.Cell{Text: "Gross interest"}, pdf.Cell{X: 110, Text: amount(c.Gross)})
+	doc.Line(10, false, pdf.Cell{Text: "Tax withheld"}, pdf.Cell{X: 110, Text: amount(c.Tax)})
+	doc.Line(10, true, pdf.Cell{Text: "Net interest paid"}, pdf.Cell{X: 110, Text: amount(c.Net)})
+	doc.Space(12)
+
+	doc.Text(12, true, "Payments")
+	if len(c.Payments) == 0 {
+		doc.Text(10, false, "No interest was paid in the tax year.")
+		return doc.Bytes()
+	}
+	row(doc, true, "Period", "Paid on", "Gross", "Tax rate", "Tax", "Net")
+	for _, p := range c.Payments {
+		row(doc, false, p.Period, p.PaidAt.Format(time.DateOnly), amount(p.Gross),
+			fmt.Sprintf("%g%%", p.TaxRate), amount(p.Tax), amount(p.Net))
+	}
+	return doc.Bytes()
+}
+
+func row(doc *pdf.Document, bold bool, texts ...string) {
+	cells := make([]pdf.Cell, len(texts))
+	for i, text := range texts {
+		cells[i] = pdf.Cell{X: certificateColumns[i], Text: text}
+	}
+	doc.Line(10, bold, cells...)
+}
+
+func amount(f float64) string {
+	return fmt.Sprintf("%.2f", f)
+}
@@ -5,62 +5,89 @@ package interestrate
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
-	"github.com/go-co-op/gocron/v2"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"math"
+	"math/big"
 	"net/http"
-	"os"
-	"os/signal"
 	"payter-bank/features/auditlog"
+	"payter-bank/features/notification"
 	"payter-bank/internal/config"
+	"payter-bank/internal/database"
 	"payter-bank/internal/database/models"
 	platformerrors "payter-bank/internal/errors"
 	"payter-bank/internal/logger"
 	"payter-bank/internal/pkg/generator"
-	"syscall"
+	"payter-bank/internal/pkg/interest"
+	"payter-bank/internal/scheduler"
+	"sort"
 	"time"
 )
 
-var (
-	interestRateApplicationJobID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
-)
+// interestJob is the name the job that accrues and capitalises interest is scheduled under.
+const interestJob = "interest"
+
+const rateCardConstraint = "interest_rate_cards_currency_product_idx"
+
+// staleClaim is how long an account can be claimed by a run without a result before another run
+// takes it over, the run that claimed it is taken to have died.
+const staleClaim = time.Hour
 
 type Service interface {
 	CreateInterestRate(ctx context.Context, param CreateInterestRateParam) (*Response, error)
 	UpdateRate(ctx context.Context, param UpdateRateParam) (*Response, error)
 	UpdateCalculationFrequency(ctx context.Context, param UpdateCalculationFrequencyParam) (*Response, error)
 	GetCurrentRate(ctx context.Context) (*models.InterestRate, error)
+	GetRateHistory(ctx context.Context) ([]RateVersion, error)
+	CreateRateCard(ctx context.Context, param CreateRateCardParam) (*RateCard, error)
+	UpdateRateCard(ctx context.Context, param UpdateRateCardParam) (*RateCard, error)
+	DeleteRateCard(ctx context.Context, param DeleteRateCardParam) error
+	GetRateCard(ctx context.Context, cardID uuid.UUID) (*RateCard, error)
+	ListRateCards(ctx context.Context) ([]RateCard, error)
+	// CreateRateOverride gives an account a bespoke or promotional rate for a while.
+	CreateRateOverride(ctx context.Context, param CreateRateOverrideParam) (*RateOverride, error)
+	UpdateRateOverride(ctx context.Context, param UpdateRateOverrideParam) (*RateOverride, error)
+	// DeleteRateOverride removes an override that has not started, one that has is ended instead.
+	DeleteRateOverride(ctx context.Context, param DeleteRateOverrideParam) error
+	ListRateOverrides(ctx context.Context, accountID uuid.UUID) ([]RateOverride, error)
 	ApplyRates(ctx context.Context) error
+	Simulate(ctx context.Context, param SimulateParam) (*Simulation, error)
+	ListRuns(ctx context.Context, param ListRunsParam) ([]Run, error)
+	GetRun(ctx context.Context, runID uuid.UUID) (*Run, error)
+	RetryRunAccount(ctx context.Context, param RetryRunAccountParam) (*Run, error)
+	// GetTaxCertificate sums up the interest paid into an account in a tax year and the tax
+	// withheld from it.
+	GetTaxCertificate(ctx context.Context, param TaxCertificateParam) (*TaxCertificate, error)
 	Start(ctx context.Context) error
 }
 
-// Runner is responsible for starting/restarting the CRON job that calculates and applies interests
-// based on configuration
-type Runner interface {
-	Start(ctx context.Context) error
-}
-
-func NewRunner(db models.Querier, cfg config.AppConfig) Runner {
-	return &service{
-		db:  db,
-		cfg: cfg,
-	}
+// Scheduler runs jobs on a cron schedule, see internal/scheduler.
+type Scheduler interface {
+	Schedule(job scheduler.Job) error
 }
 
 type service struct {
-	db       models.Querier
-	auditLog auditlog.Service
-	cfg      config.AppConfig
-	runner   Runner
+	db           models.Querier
+	auditLog     auditlog.Service
+	notification notification.Service
+	cfg          config.Config
+	scheduler    Scheduler
+	calculator   *interest.Calculator
+	now          func() time.Time
 }
 
-func NewService(db models.Querier, cfg config.AppConfig, auditLog auditlog.Service, runner Runner) Service {
+func NewService(db models.Querier, cfg config.Config, auditLog auditlog.Service, notificationService notification.Service,
+	scheduler Scheduler, calculator *interest.Calculator) Service {
 	return &service{
-		db:       db,
-		cfg:      cfg,
-		auditLog: auditLog,
-		runner:   runner,
+		db:           db,
+		cfg:          cfg,
+		auditLog:     auditLog,
+		notification: notificationService,
+		scheduler:    scheduler,
+		calculator:   calculator,
+		now:          time.Now,
 	}
 }
 
@@ -83,6 +110,8 @@ func (s *service) CreateInterestRate(ctx context.Context, param CreateInterestRa
 	newRate, err := s.db.SaveInterestRate(ctx, models.SaveInterestRateParams{
 		Rate:                 int64(param.Rate * 100),
 		CalculationFrequency: param.CalculationFrequency,
+		EffectiveFrom:        s.today(),
+		CreatedBy:            uuid.NullUUID{UUID: param.UserID, Valid: true},
 	})
 	if err != nil {
 		logger.Error(ctx, "failed to save interest rate", zap.Error(err))
@@ -101,8 +130,6 @@ func (s *service) CreateInterestRate(ctx context.Context, param CreateInterestRa
 		logger.Warn(ctx, "failed to submit audit log", zap.Error(err))
 	}
 
-	// restart the scheduler to apply the new rate
-	_ = s.runner.Start(ctx)
 	return &Response{InterestRateID: newRate.ID}, nil
 }
 
@@ -111,35 +138,50 @@ func (s *service) UpdateRate(ctx context.Context, param UpdateRateParam) (*Respo
 		zap.String(logger.FunctionName, "UpdateRate"),
 		zap.Any(logger.RequestFields, param))
 
-	rate, err := s.GetCurrentRate(ctx)
+	effectiveFrom := s.today()
+	if param.EffectiveFrom != "" {
+		day, err := time.Parse(time.DateOnly, param.EffectiveFrom)
+		if err != nil {
+			return nil, platformerrors.MakeApiError(http.StatusBadRequest, "effective_from must be a date (YYYY-MM-DD)")
+		}
+		if day.Before(effectiveFrom) {
+			return nil, platformerrors.MakeApiError(http.StatusBadRequest, "effective_from cannot be in the past")
+		}
+		effectiveFrom = day
+	}
+
+	rate, err := s.rateAt(ctx, effectiveFrom)
 	if err != nil {
 		return nil, err
 	}
 
-	err = s.db.UpdateRate(ctx, models.UpdateRateParams{
-		ID:   rate.ID,
-		Rate: int64(param.Rate * 100),
+	version, err := s.schedule(ctx, rate, models.SaveInterestRateParams{
+		Rate:                 int64(param.Rate * 100),
+		CalculationFrequency: rate.CalculationFrequency,
+		EffectiveFrom:        effectiveFrom,
+		CreatedBy:            uuid.NullUUID{UUID: param.UserID, Valid: true},
 	})
 	if err != nil {
-		logger.Error(ctx, "failed to update interest rate", zap.Error(err))
-		return nil, platformerrors.ErrInternal
+		return nil, err
 	}
+
 	auditEvent := auditlog.NewEvent(
 		auditlog.ActionInterestRateChange, param.UserID, uuid.Nil,
 		auditlog.InterestRateChangeMetadata{
-			OldRate: rate.Rate,
-			NewRate: int64(param.Rate * 100),
+			OldRate:       rate.Rate,
+			NewRate:       int64(param.Rate * 100),
+			EffectiveFrom: effectiveFrom.Format(time.DateOnly),
 		})
 	err = s.auditLog.Submit(ctx, auditEvent)
 	if err != nil {
 		logger.Warn(ctx, "failed to submit audit log", zap.Error(err))
 	}
 
-	// restart the scheduler to apply the new rate
-	_ = s.runner.Start(ctx)
-	return &Response{InterestRateID: rate.ID}, nil
+	return &Response{InterestRateID: version.ID}, nil
 }
 
+// UpdateCalculationFrequency changes the calculation frequency from today. Scheduled rate changes
+// have not taken effect yet and take the new frequency too.
 func (s *service) UpdateCalculationFrequency(ctx context.Context, param UpdateCalculationFrequencyParam) (*Response, error) {
 	ctx = logger.With(ctx,
 		zap.String(logger.FunctionName, "UpdateCalculationFrequency"),
@@ -154,14 +196,25 @@ func (s *service) UpdateCalculationFrequency(ctx context.Context, param UpdateCa
 		return &Response{InterestRateID: rate.ID}, nil
 	}
 
-	err = s.db.UpdateCalculationFrequency(ctx, models.UpdateCalculationFrequencyParams{
-		ID:                   rate.ID,
+	version, err := s.schedule(ctx, rate, models.SaveInterestRateParams{
+		Rate:                 rate.Rate,
+		CalculationFrequency: param.CalculationFrequency,
+		EffectiveFrom:        s.today(),
+		CreatedBy:            uuid.NullUUID{UUID: param.UserID, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.UpdateScheduledCalculationFrequency(ctx, models.UpdateScheduledCalculationFrequencyParams{
 		CalculationFrequency: param.CalculationFrequency,
+		After:                s.today(),
 	})
 	if err != nil {
-		logger.Error(ctx, "failed to update interest rate", zap.Error(err))
+		logger.Error(ctx, "failed to update scheduled interest rates", zap.Error(err))
 		return nil, platformerrors.ErrInternal
 	}
+
 	auditEvent := auditlog.NewEvent(auditlog.ActionInterestRateChange, param.UserID, uuid.Nil,
 		auditlog.InterestRateChangeMetadata{
 			OldCalculationFrequency: rate.CalculationFrequency,
@@ -172,139 +225,1320 @@ func (s *service) UpdateCalculationFrequency(ctx context.Context, param UpdateCa
 		logger.Warn(ctx, "failed to submit audit log", zap.Error(err))
 	}
 
-	// restart the scheduler to apply the new rate
-	_ = s.runner.Start(ctx)
-	return &Response{InterestRateID: rate.ID}, nil
+	return &Response{InterestRateID: version.ID}, nil
+}
+
+// schedule saves a rate version that takes effect on version.EffectiveFrom. current is the version in
+// effect on that day: it ends where the new version starts, or is cancelled when it starts on the
+// same day, which it can only do when it has not taken effect yet. The new version ends where current
+// did, so a change scheduled after it still takes over.
+func (s *service) schedule(ctx context.Context, current *models.InterestRate, version models.SaveInterestRateParams) (*models.InterestRate, error) {
+	version.EffectiveTo = current.EffectiveTo
+	saved, err := s.db.SaveInterestRate(ctx, version)
+	if err != nil {
+		logger.Error(ctx, "failed to save interest rate", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	if current.EffectiveFrom.Equal(version.EffectiveFrom) {
+		err = s.db.CancelInterestRate(ctx, current.ID)
+	} else {
+		err = s.db.CloseInterestRate(ctx, models.CloseInterestRateParams{
+			ID:          current.ID,
+			EffectiveTo: sql.NullTime{Time: version.EffectiveFrom, Valid: true},
+		})
+	}
+	if err != nil {
+		logger.Error(ctx, "failed to end interest rate", zap.Error(err),
+			zap.String("interest_rate_id", current.ID.String()))
+		return nil, platformerrors.ErrInternal
+	}
+
+	return &saved, nil
 }
 
+// ApplyRates runs interest for every day that has ended since the last run, so days missed while
+// the server was down are caught up, and resumes runs that did not complete. See run.
 func (s *service) ApplyRates(ctx context.Context) error {
 	ctx = logger.With(ctx,
 		zap.String(logger.FunctionName, "ApplyRates"))
 
-	rate, err := s.GetCurrentRate(ctx)
+	days, err := s.pendingDays(ctx)
 	if err != nil {
-		logger.Error(ctx, "failed to get current rate", zap.Error(err))
 		return err
 	}
 
+	for _, day := range days {
+		if _, err := s.run(ctx, day); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pendingDays lists the days of the runs that did not complete and every day after the last run up
+// to yesterday, oldest first. Before the first run it is only yesterday.
+func (s *service) pendingDays(ctx context.Context) ([]time.Time, error) {
+	unfinished, err := s.db.GetUnfinishedInterestRuns(ctx)
+	if err != nil {
+		logger.Error(ctx, "failed to get unfinished interest runs", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	days := make([]time.Time, 0, len(unfinished)+1)
+	for _, run := range unfinished {
+		days = append(days, run.RunDate)
+	}
+
+	yesterday := s.today().AddDate(0, 0, -1)
+	next := yesterday
+	last, err := s.db.GetLastInterestRun(ctx)
+	if err == nil {
+		next = last.RunDate.AddDate(0, 0, 1)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		logger.Error(ctx, "failed to get last interest run", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	for day := next; !day.After(yesterday); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// run accrues a day of interest on the end-of-day balance of every active account at the rate in
+// effect on that day, dormant accounts included as dormancy only blocks payments out. When the day
+// closes a period of the calculation frequency the interest accrued over the period is capitalised,
+// that is paid into the account. Each account's result is recorded in the run, an account that
+// already has a result for the day is skipped unless it failed, so a day is never accrued or paid
+// twice. It returns nil when the day has already been run, or when there was no rate yet on that day.
+func (s *service) run(ctx context.Context, day time.Time) (*models.InterestRun, error) {
+	ctx = logger.With(ctx, zap.String("run_date", day.Format(time.DateOnly)))
+
+	rate, err := s.db.GetInterestRateAt(ctx, day)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		logger.Error(ctx, "failed to get interest rate", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	frequency := Frequency(rate.CalculationFrequency)
+	if !frequency.valid() {
+		return nil, fmt.Errorf("unknown frequency: %s", rate.CalculationFrequency)
+	}
+
+	var period sql.NullString
+	if frequency.periodEnds(day) {
+		period = sql.NullString{String: frequency.periodName(day), Valid: true}
+	}
+
+	run, err := s.db.StartInterestRun(ctx, models.StartInterestRunParams{
+		RunDate: day,
+		RateID:  rate.ID,
+		Period:  period,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		logger.Error(ctx, "failed to start interest run", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
 	accounts, err := s.db.GetAllActiveAccounts(ctx)
 	if err != nil {
 		logger.Error(ctx, "failed to get all active accounts", zap.Error(err))
-		return platformerrors.ErrInternal
+		return nil, platformerrors.ErrInternal
+	}
+
+	prices, err := s.loadPricings(ctx, day, day)
+	if err != nil {
+		return nil, err
 	}
 
+	active := make(map[uuid.UUID]bool, len(accounts))
 	for _, account := range accounts {
-		balance, err := s.db.GetAccountBalance(ctx, account.AccountID)
+		active[account.AccountID] = true
+		s.runAccount(ctx, run, &rate, prices, account.AccountID, &account)
+	}
+
+	if run.Period.Valid {
+		// accounts that are no longer active are still paid what they accrued while they were
+		accruals, err := s.db.GetUncapitalisedInterest(ctx, day)
 		if err != nil {
-			logger.Error(ctx, "failed to get account balance", zap.Error(err))
-			continue
-		}
-
-		if balance.Balance > 0 {
-			gain := float64(balance.Balance) * (float64(rate.Rate) / 10000)
-			txn := models.SaveTransactionParams{
-				FromAccountID:   s.cfg.InterestRateAccountID,
-				ToAccountID:     account.AccountID,
-				Amount:          int64(gain),
-				ReferenceNumber: generator.DefaultNumberGenerator.Generate(),
-				Description: sql.NullString{
-					String: fmt.Sprintf("Interest gained on %s", time.Now().Format(time.DateOnly)),
-				},
-				Status:   "COMPLETED",
-				Currency: string(account.Currency),
-			}
-			newTxn, err := s.db.SaveTransaction(ctx, txn)
-			if err != nil {
-				logger.Error(ctx, "failed to save transaction", zap.Error(err))
-				continue
+			logger.Error(ctx, "failed to get accrued interest", zap.Error(err))
+			return nil, platformerrors.ErrInternal
+		}
+		for _, accrual := range accruals {
+			if !active[accrual.AccountID] {
+				s.runAccount(ctx, run, &rate, prices, accrual.AccountID, nil)
 			}
-			logger.Info(ctx, "interest applied successfully",
-				zap.String("account_id", account.AccountID.String()),
-				zap.String("transaction_id", newTxn.ID.String()))
 		}
 	}
 
-	return nil
+	finished, err := s.db.FinishInterestRun(ctx, run.ID)
+	if err != nil {
+		logger.Error(ctx, "failed to finish interest run", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	logger.Info(ctx, "interest run finished",
+		zap.String("status", finished.Status),
+		zap.Int32("accounts", finished.Accounts),
+		zap.Int32("failed", finished.Failed))
+	return &finished, nil
 }
 
-func (s *service) GetCurrentRate(ctx context.Context) (*models.InterestRate, error) {
-	existingRates, err := s.db.GetInterestRates(ctx)
+// runAccount claims the account in run, accrues its interest for the day when it is active, pays
+// the interest of the period when the run closes one, and records the result. The account is
+// skipped when it already has a result for the day that did not fail.
+func (s *service) runAccount(ctx context.Context, run models.InterestRun, rate *models.InterestRate,
+	prices pricings, accountID uuid.UUID, active *models.GetAllActiveAccountsRow) {
+	ctx = logger.With(ctx, zap.String("account_id", accountID.String()))
+
+	claim, err := s.db.ClaimInterestRunAccount(ctx, models.ClaimInterestRunAccountParams{
+		RunID:       run.ID,
+		AccountID:   accountID,
+		RunDate:     run.RunDate,
+		StaleBefore: s.now().Add(-staleClaim),
+	})
 	if err != nil {
-		return nil, err
+		if !errors.Is(err, sql.ErrNoRows) {
+			logger.Error(ctx, "failed to claim account for interest run", zap.Error(err))
+		}
+		return
 	}
 
-	if len(existingRates) == 0 {
-		return nil, platformerrors.MakeApiError(http.StatusPreconditionFailed, "interest rate has not been initialized")
+	accrued := new(big.Rat)
+	if active != nil {
+		accrued, err = s.accrue(ctx, rate, prices, *active, run.RunDate)
+	}
+	if err == nil && run.Period.Valid {
+		err = s.capitalise(ctx, run, claim)
 	}
 
-	rate := existingRates[0]
-	return &rate, nil
+	if err != nil {
+		logger.Error(ctx, "failed to apply interest", zap.Error(err))
+		err = s.db.FailInterestRunAccount(ctx, models.FailInterestRunAccountParams{
+			ID:    claim.ID,
+			Error: sql.NullString{String: err.Error(), Valid: true},
+		})
+	} else {
+		err = s.db.CompleteInterestRunAccount(ctx, models.CompleteInterestRunAccountParams{
+			ID:      claim.ID,
+			Accrued: interest.Format(accrued),
+		})
+	}
+	if err != nil {
+		logger.Error(ctx, "failed to record interest run result", zap.Error(err))
+	}
 }
 
-func (s *service) Start(ctx context.Context) error {
-	// create a context that will be cancelled when the application is shutting down
-	ctx, _ = signal.NotifyContext(ctx, os.Interrupt, os.Kill, syscall.SIGTERM, syscall.SIGINT)
-	ctx = logger.With(ctx,
-		zap.String(logger.FunctionName, "Start"))
+// accrue records the interest the account earned on day, under the rate card of its currency and
+// product or else the global rate and any rate override of the account, and returns it. Nothing is
+// recorded when the account has no positive balance at the end of the day, or when the day has
+// already been accrued.
+func (s *service) accrue(ctx context.Context, rate *models.InterestRate, prices pricings,
+	account models.GetAllActiveAccountsRow, day time.Time) (*big.Rat, error) {
+	p := prices.of(account, rate.Rate, day)
 
-	rate, err := s.GetCurrentRate(ctx)
+	bonus, err := s.bonusEarned(ctx, account.AccountID, p, day)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("check bonus conditions: %w", err)
 	}
 
-	scheduler, err := gocron.NewScheduler()
+	balance, err := s.db.GetAccountBalanceAt(ctx, models.GetAccountBalanceAtParams{
+		AccountID: account.AccountID,
+		Before:    day.AddDate(0, 0, 1),
+	})
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("get account balance: %w", err)
+	}
+
+	if balance <= 0 {
+		return new(big.Rat), nil
+	}
+
+	currency := string(account.Currency)
+	earned := s.interestOn(p, balance, bonus, day, currency)
+	if earned.total().Sign() == 0 {
+		// a 0% band, or the 31st of a month under 30/360
+		return new(big.Rat), nil
 	}
 
-	cronExpression, err := s.frequencyToCron(Frequency(rate.CalculationFrequency))
+	_, err = s.db.SaveInterestAccrual(ctx, models.SaveInterestAccrualParams{
+		AccountID:   account.AccountID,
+		AccrualDate: day,
+		Balance:     balance,
+		Rate:        interest.HalfEven.Round(earned.rate, 0).Num().Int64(),
+		Amount:      interest.Format(earned.amount),
+		DayCount:    string(s.calculator.Convention(currency)),
+		CardID:      p.cardID,
+		BonusRate:   earned.bonusRate,
+		BonusAmount: interest.Format(earned.bonusAmount),
+	})
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("save interest accrual: %w", err)
+	}
+	return earned.total(), nil
+}
+
+// interestOn works out the interest balance earns on day under p, and the bonus when it is earned.
+// ApplyRates and Simulate both use it, so a simulation works interest out as it is paid.
+func (s *service) interestOn(p pricing, balance int64, bonus bool, day time.Time, currency string) dayInterest {
+	earned := dayInterest{rate: p.method.Rate(balance, p.bands), bonusAmount: new(big.Rat)}
+	earned.rate.Add(earned.rate, big.NewRat(p.boost, 1))
+	earned.amount = s.calculator.Accrue(balance, earned.rate, day, currency)
+	if bonus {
+		earned.bonusRate = p.bonusRate
+		earned.bonusAmount = s.calculator.Accrue(balance, big.NewRat(p.bonusRate, 1), day, currency)
 	}
+	return earned
+}
 
-	err = scheduler.RemoveJob(interestRateApplicationJobID) // tries to remove the job if it exists
+// loadPricings loads the rate cards and the rate overrides in effect on any day from from to to.
+func (s *service) loadPricings(ctx context.Context, from, to time.Time) (pricings, error) {
+	cards, err := s.db.ListInterestRateCards(ctx)
 	if err != nil {
-		logger.Warn(ctx, "failed to remove existing job", zap.Error(err))
+		logger.Error(ctx, "failed to list interest rate cards", zap.Error(err))
+		return pricings{}, platformerrors.ErrInternal
+	}
+
+	prices := pricings{
+		cards:     make(map[string]pricing, len(cards)),
+		overrides: make(map[uuid.UUID][]models.InterestRateOverride),
+	}
+	for _, card := range cards {
+		p, err := pricingFromCard(card)
+		if err != nil {
+			logger.Error(ctx, "failed to read interest rate card", zap.Error(err),
+				zap.String("card_id", card.ID.String()))
+			return pricings{}, platformerrors.ErrInternal
+		}
+		prices.cards[pricingKey(card.Currency, card.Product)] = p
 	}
 
-	job, err := scheduler.NewJob(
-		gocron.CronJob(cronExpression, false),
-		gocron.NewTask(s.ApplyRates, ctx),
-		gocron.WithIdentifier(interestRateApplicationJobID))
+	overrides, err := s.db.ListInterestRateOverrides(ctx, models.ListInterestRateOverridesParams{
+		To:   to,
+		From: from,
+	})
 	if err != nil {
-		return err
+		logger.Error(ctx, "failed to list interest rate overrides", zap.Error(err))
+		return pricings{}, platformerrors.ErrInternal
+	}
+	for _, o := range overrides {
+		prices.overrides[o.AccountID] = append(prices.overrides[o.AccountID], o)
+	}
+	return prices, nil
+}
+
+// bonusEarned reports whether the account earns the bonus rate on day, that is whether the month
+// so far meets the bonus conditions. On the last day of a month the whole month is known, when it
+// fails the conditions the bonus accrued earlier in the month is forfeited.
+func (s *service) bonusEarned(ctx context.Context, accountID uuid.UUID, p pricing, day time.Time) (bool, error) {
+	if p.bonusRate == 0 {
+		return false, nil
 	}
 
-	logger.Info(ctx, "interest rate job queued",
-		zap.Any("ID", job.ID()))
+	monthStart := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	met, err := s.bonusMet(ctx, accountID, p, monthStart, day.AddDate(0, 0, 1))
+	if err != nil {
+		return false, err
+	}
 
-	scheduler.Start()
-	for {
-		select {
-		case <-ctx.Done():
-			logger.Info(ctx, "shutting down scheduler")
-			if err := scheduler.Shutdown(); err != nil {
-				logger.Error(ctx, "failed to shutdown scheduler", zap.Error(err))
-			}
-			return nil
-		default:
+	if !met && day.AddDate(0, 0, 1).Day() == 1 {
+		_, err = s.db.ForfeitInterestBonus(ctx, models.ForfeitInterestBonusParams{
+			AccountID: accountID,
+			From:      monthStart,
+			To:        day,
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+	return met, nil
+}
+
+// bonusMet reports whether what moved in and out of the account from from up to to meets the bonus
+// conditions of p.
+func (s *service) bonusMet(ctx context.Context, accountID uuid.UUID, p pricing, from, to time.Time) (bool, error) {
+	if len(p.conditions) == 0 {
+		return true, nil
+	}
+
+	flows, err := s.db.GetAccountFlows(ctx, models.GetAccountFlowsParams{
+		AccountID:         accountID,
+		InterestAccountID: s.cfg.App.InterestRateAccountID,
+		From:              from,
+		To:                to,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	met := true
+	for _, condition := range p.conditions {
+		switch condition {
+		case NoWithdrawals:
+			met = met && flows.Withdrawn == 0
+		case MonthlyDeposit:
+			met = met && flows.Deposited >= p.minMonthlyDeposit
+		}
+	}
+	return met, nil
+}
+
+// capitalise pays the interest the account accrued up to and including the day of run into it.
+// Interest is paid in whole minor units, the fraction left over is carried into the next period.
+// Tax is withheld from the interest at the rate of the customer's residency: the account is paid
+// the interest net of tax and the tax is paid into the tax account of the interest's currency. Each
+// payment is recorded in the claim as soon as it is made, a retry of the claim does not pay again,
+// nor audit it or notify the customer again.
+func (s *service) capitalise(ctx context.Context, run models.InterestRun, claim models.InterestRunAccount) error {
+	accrual, err := s.db.GetAccountUncapitalisedInterest(ctx, models.GetAccountUncapitalisedInterestParams{
+		AccrualDate: run.RunDate,
+		AccountID:   claim.AccountID,
+	})
+	if err != nil {
+		return fmt.Errorf("get accrued interest: %w", err)
+	}
+
+	accrued, err := interest.Parse(accrual.Amount)
+	if err != nil {
+		return fmt.Errorf("read accrued interest: %w", err)
+	}
+	carried, err := interest.Parse(accrual.Carry)
+	if err != nil {
+		return fmt.Errorf("read carried interest: %w", err)
+	}
+	amount, remainder := s.calculator.Settle(accrued, carried)
+
+	transactionID, tax := claim.TransactionID, claim.Tax
+	if amount > 0 && !transactionID.Valid {
+		taxRate, err := s.taxRate(ctx, claim.AccountID)
+		if err != nil {
+			return err
+		}
+		tax = s.calculator.Withhold(amount, taxRate)
+
+		description := fmt.Sprintf("Interest for %s", run.Period.String)
+		if tax > 0 {
+			description += " (net of tax)"
+		}
+		txn, err := s.db.SaveTransaction(ctx, models.SaveTransactionParams{
+			FromAccountID:   s.cfg.App.InterestRateAccountID,
+			ToAccountID:     claim.AccountID,
+			Amount:          amount - tax,
+			ReferenceNumber: generator.DefaultReferenceGenerator.Generate(),
+			Description: sql.NullString{
+				String: description,
+				Valid:  true,
+			},
+			Status:   "COMPLETED",
+			Currency: string(accrual.Currency),
+		})
+		if err != nil {
+			return fmt.Errorf("save transaction: %w", err)
+		}
+		transactionID = uuid.NullUUID{UUID: txn.ID, Valid: true}
+
+		err = s.db.SetInterestRunAccountTransaction(ctx, models.SetInterestRunAccountTransactionParams{
+			ID:            claim.ID,
+			TransactionID: transactionID,
+			Paid:          amount,
+			Tax:           tax,
+			TaxRate:       taxRate,
+		})
+		if err != nil {
+			return fmt.Errorf("record interest transaction %s: %w", txn.ID, err)
+		}
+		s.interestApplied(ctx, run, claim.AccountID, accrual, amount, tax, txn.ID)
+	}
+
+	var taxAccount models.Account
+	if tax > 0 {
+		taxAccount, err = s.db.GetAccountByCurrency(ctx, models.GetAccountByCurrencyParams{
+			Currency: accrual.Currency,
+			UserID:   s.cfg.App.TaxUserID,
+		})
+		if err != nil {
+			return fmt.Errorf("get %s tax account: %w", accrual.Currency, err)
+		}
+	}
+
+	if tax > 0 && !claim.TaxTransactionID.Valid {
+		txn, err := s.db.SaveTransaction(ctx, models.SaveTransactionParams{
+			FromAccountID:   s.cfg.App.InterestRateAccountID,
+			ToAccountID:     taxAccount.ID,
+			Amount:          tax,
+			ReferenceNumber: generator.DefaultReferenceGenerator.Generate(),
+			Description: sql.NullString{
+				String: fmt.Sprintf("Tax withheld from interest for %s", run.Period.String),
+				Valid:  true,
+			},
+			Status:   "COMPLETED",
+			Currency: string(accrual.Currency),
+		})
+		if err != nil {
+			return fmt.Errorf("save tax transaction: %w", err)
+		}
+
+		err = s.db.SetInterestRunAccountTaxTransaction(ctx, models.SetInterestRunAccountTaxTransactionParams{
+			ID:               claim.ID,
+			TaxTransactionID: uuid.NullUUID{UUID: txn.ID, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("record tax transaction %s: %w", txn.ID, err)
+		}
+	}
+
+	_, err = s.db.CapitaliseInterestAccruals(ctx, models.CapitaliseInterestAccrualsParams{
+		AccountID:     claim.AccountID,
+		AccrualDate:   run.RunDate,
+		TransactionID: transactionID,
+	})
+	if err != nil {
+		return fmt.Errorf("mark interest accruals capitalised: %w", err)
+	}
+
+	err = s.db.SetInterestCarry(ctx, models.SetInterestCarryParams{
+		ID:            claim.AccountID,
+		InterestCarry: interest.Format(remainder),
+	})
+	if err != nil {
+		return fmt.Errorf("save carried interest: %w", err)
+	}
+
+	if transactionID.Valid {
+		s.updateBalance(ctx, claim.AccountID)
+		s.updateBalance(ctx, s.cfg.App.InterestRateAccountID)
+		if tax > 0 {
+			s.updateBalance(ctx, taxAccount.ID)
 		}
+		logger.Info(ctx, "interest applied successfully",
+			zap.String("transaction_id", transactionID.UUID.String()))
+	}
+	return nil
+}
+
+// interestApplied audits interest paid into an account, with the rate and balance it was worked
+// out on, and tells the account holder. The payment has been made by then, so failures are only
+// logged.
+func (s *service) interestApplied(ctx context.Context, run models.InterestRun, accountID uuid.UUID,
+	accrual models.GetAccountUncapitalisedInterestRow, paid, tax int64, transactionID uuid.UUID) {
+	currency := string(accrual.Currency)
+	auditEvent := auditlog.NewEvent(auditlog.ActionInterestApplied, s.cfg.App.SystemUserID, accountID,
+		auditlog.InterestAppliedMetadata{
+			TransactionID: transactionID,
+			Period:        run.Period.String,
+			PeriodEnd:     run.RunDate.Format(time.DateOnly),
+			Days:          accrual.Days,
+			Rate:          accrual.AverageRate,
+			Balance:       accrual.AverageBalance,
+			Gross:         paid,
+			Tax:           tax,
+			Amount:        paid - tax,
+			Currency:      currency,
+		})
+	if err := s.auditLog.Submit(ctx, auditEvent); err != nil {
+		logger.Error(ctx, "failed to queue audit log", zap.Error(err))
+	}
+
+	account, err := s.db.GetAccountByID(ctx, accountID)
+	if err != nil {
+		logger.Error(ctx, "failed to get account to notify", zap.Error(err))
+		return
+	}
+
+	message := fmt.Sprintf("Account %s earned %s %s interest for %s, at an average rate of %g%% on an average balance of %s %s.",
+		account.AccountNumber, amount(float64(paid)/100), currency, run.Period.String,
+		float64(accrual.AverageRate)/100, amount(float64(accrual.AverageBalance)/100), currency)
+	if tax > 0 {
+		message += fmt.Sprintf(" %s %s tax was withheld and %s %s has been paid into the account.",
+			amount(float64(tax)/100), currency, amount(float64(paid-tax)/100), currency)
+	} else {
+		message += " It has been paid into the account."
+	}
+
+	err = s.notification.Notify(ctx, notification.Message{
+		UserID:  account.UserID,
+		Type:    notification.TypeInterestPaid,
+		Subject: fmt.Sprintf("Your interest for %s", run.Period.String),
+		Message: message,
+		Metadata: map[string]any{
+			"account_id":     accountID,
+			"transaction_id": transactionID,
+			"period":         run.Period.String,
+			"amount":         paid - tax,
+			"currency":       currency,
+		},
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to notify account owner", zap.Error(err))
 	}
 }
 
-func (s *service) frequencyToCron(frequency Frequency) (string, error) {
-	switch frequency {
-	case Hourly:
-		return "0 * * * *", nil
-	case Daily:
-		return "0 0 * * *", nil
-	case Weekly:
-		return "0 0 * * 0", nil
-	case Monthly:
-		return "0 0 1 * *", nil
-	case Yearly:
-		return "0 0 1 1 *", nil
-	default:
-		return "", fmt.Errorf("unknown frequency: %s", frequency)
+// updateBalance refreshes the balance stored on an account from its transactions.
+func (s *service) updateBalance(ctx context.Context, accountID uuid.UUID) {
+	if err := s.db.UpdateBalance(ctx, accountID); err != nil {
+		logger.Error(ctx, "failed to update account balance", zap.Error(err))
+	}
+}
+
+// taxRate is the rate tax is withheld from the interest of the account at, nothing when its holder
+// is exempt.
+func (s *service) taxRate(ctx context.Context, accountID uuid.UUID) (int64, error) {
+	status, err := s.db.GetAccountTaxStatus(ctx, accountID)
+	if err != nil {
+		return 0, fmt.Errorf("get tax status: %w", err)
+	}
+	if status.TaxExempt {
+		return 0, nil
+	}
+	return s.calculator.TaxRate(status.TaxResidency.String), nil
+}
+
+// Simulate works out the interest every active account would earn over a capitalisation period at
+// a candidate global rate, the way ApplyRates would, without saving or paying anything. Days that
+// have ended earn on the account's end-of-day balance and the days after on its latest one. Bonus
+// conditions are checked on each month up to today, a month that has not started has had no money
+// in or out. Accounts under a rate card earn its rates, the candidate only replaces the global rate.
+func (s *service) Simulate(ctx context.Context, param SimulateParam) (*Simulation, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Simulate"),
+		zap.Any(logger.RequestFields, param))
+
+	day, err := time.Parse(time.DateOnly, param.Period)
+	if err != nil {
+		return nil, platformerrors.MakeApiError(http.StatusBadRequest, "period must be a date (YYYY-MM-DD)")
+	}
+
+	frequency := Frequency(param.CalculationFrequency)
+	from := frequency.periodStart(day)
+	to := from
+	for !frequency.periodEnds(to) {
+		to = to.AddDate(0, 0, 1)
+	}
+
+	accounts, err := s.db.GetAllActiveAccounts(ctx)
+	if err != nil {
+		logger.Error(ctx, "failed to get all active accounts", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	prices, err := s.loadPricings(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	simulation := &Simulation{
+		Rate:                 param.Rate,
+		CalculationFrequency: param.CalculationFrequency,
+		Period:               frequency.periodName(from),
+		From:                 from.Format(time.DateOnly),
+		To:                   to.Format(time.DateOnly),
+		Accounts:             make([]SimulatedAccount, 0, len(accounts)),
+		Totals:               []SimulatedTotal{},
+	}
+
+	type total struct {
+		accounts int
+		interest *big.Rat
+		paid     int64
+	}
+	totals := make(map[string]*total)
+	candidate := int64(math.Round(param.Rate * 100))
+	for _, account := range accounts {
+		p := accountPricing(prices.cards, account, candidate)
+		accrued, err := s.simulateAccount(ctx, account, p, prices.overrides[account.AccountID], from, to)
+		if err != nil {
+			logger.Error(ctx, "failed to simulate interest", zap.Error(err),
+				zap.String("account_id", account.AccountID.String()))
+			return nil, platformerrors.ErrInternal
+		}
+		paid, _ := s.calculator.Settle(accrued, new(big.Rat))
+
+		result := SimulatedAccount{
+			AccountID: account.AccountID,
+			Currency:  string(account.Currency),
+			Interest:  majorUnits(accrued),
+			Paid:      float64(paid) / 100,
+		}
+		if p.cardID.Valid {
+			result.CardID = &p.cardID.UUID
+		}
+		simulation.Accounts = append(simulation.Accounts, result)
+
+		t, ok := totals[result.Currency]
+		if !ok {
+			t = &total{interest: new(big.Rat)}
+			totals[result.Currency] = t
+		}
+		t.accounts++
+		t.interest.Add(t.interest, accrued)
+		t.paid += paid
+	}
+
+	for currency, t := range totals {
+		simulation.Totals = append(simulation.Totals, SimulatedTotal{
+			Currency: currency,
+			Accounts: t.accounts,
+			Interest: majorUnits(t.interest),
+			Paid:     float64(t.paid) / 100,
+		})
 	}
+	sort.Slice(simulation.Totals, func(i, j int) bool {
+		return simulation.Totals[i].Currency < simulation.Totals[j].Currency
+	})
+	return simulation, nil
+}
+
+// simulateAccount adds up the interest the account would earn under p, or its overrides on the days
+// they are in effect, every day from from to to.
+func (s *service) simulateAccount(ctx context.Context, account models.GetAllActiveAccountsRow, p pricing,
+	overrides []models.InterestRateOverride, from, to time.Time) (*big.Rat, error) {
+	today := s.today()
+	accrued := new(big.Rat)
+	bonus := false
+	var balance int64
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if p.bonusRate > 0 && (day.Equal(from) || day.Day() == 1) {
+			monthStart := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+			until := monthStart.AddDate(0, 1, 0)
+			if until.After(today) {
+				until = today
+			}
+			if until.Before(monthStart) {
+				until = monthStart
+			}
+			met, err := s.bonusMet(ctx, account.AccountID, p, monthStart, until)
+			if err != nil {
+				return nil, fmt.Errorf("check bonus conditions: %w", err)
+			}
+			bonus = met
+		}
+
+		if day.Equal(from) || day.Before(today) {
+			before := day.AddDate(0, 0, 1)
+			if before.After(today) {
+				before = today
+			}
+			b, err := s.db.GetAccountBalanceAt(ctx, models.GetAccountBalanceAtParams{
+				AccountID: account.AccountID,
+				Before:    before,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("get account balance: %w", err)
+			}
+			balance = b
+		}
+
+		if balance > 0 {
+			accrued.Add(accrued, s.interestOn(overridden(p, overrides, day), balance, bonus, day, string(account.Currency)).total())
+		}
+	}
+	return accrued, nil
+}
+
+func (s *service) ListRuns(ctx context.Context, param ListRunsParam) ([]Run, error) {
+	runs, err := s.db.ListInterestRuns(ctx, sql.NullString{String: param.Status, Valid: param.Status != ""})
+	if err != nil {
+		logger.Error(ctx, "failed to list interest runs", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	result := make([]Run, 0, len(runs))
+	for _, run := range runs {
+		result = append(result, runFromModel(run))
+	}
+	return result, nil
+}
+
+// GetRun returns a run with the result of each of its accounts, failed accounts first.
+func (s *service) GetRun(ctx context.Context, runID uuid.UUID) (*Run, error) {
+	run, err := s.getRun(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	return s.withResults(ctx, run)
+}
+
+// RetryRunAccount runs a failed account of a run again.
+func (s *service) RetryRunAccount(ctx context.Context, param RetryRunAccountParam) (*Run, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "RetryRunAccount"),
+		zap.Any(logger.RequestFields, param))
+
+	run, err := s.getRun(ctx, param.RunID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.db.GetInterestRunAccount(ctx, models.GetInterestRunAccountParams{
+		RunID:     run.ID,
+		AccountID: param.AccountID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, platformerrors.MakeApiError(http.StatusNotFound, "account is not part of the interest run")
+		}
+		logger.Error(ctx, "failed to get interest run account", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+	if result.Status != "FAILED" {
+		return nil, platformerrors.MakeApiError(http.StatusConflict, "only a failed account can be re-run")
+	}
+
+	rate, err := s.db.GetInterestRateAt(ctx, run.RunDate)
+	if err != nil {
+		logger.Error(ctx, "failed to get interest rate", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	prices, err := s.loadPricings(ctx, run.RunDate, run.RunDate)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := s.db.GetAllActiveAccounts(ctx)
+	if err != nil {
+		logger.Error(ctx, "failed to get all active accounts", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	var active *models.GetAllActiveAccountsRow
+	for i := range accounts {
+		if accounts[i].AccountID == param.AccountID {
+			active = &accounts[i]
+			break
+		}
+	}
+	s.runAccount(ctx, run, &rate, prices, param.AccountID, active)
+
+	run, err = s.db.FinishInterestRun(ctx, run.ID)
+	if err != nil {
+		logger.Error(ctx, "failed to finish interest run", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	auditEvent := auditlog.NewEvent(auditlog.ActionInterestRunRetry, param.UserID, param.AccountID,
+		auditlog.InterestRunRetryMetadata{
+			RunID:   run.ID,
+			RunDate: run.RunDate.Format(time.DateOnly),
+		})
+	if err := s.auditLog.Submit(ctx, auditEvent); err != nil {
+		logger.Warn(ctx, "failed to submit audit log", zap.Error(err))
+	}
+
+	return s.withResults(ctx, run)
+}
+
+func (s *service) getRun(ctx context.Context, runID uuid.UUID) (models.InterestRun, error) {
+	run, err := s.db.GetInterestRun(ctx, runID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return run, platformerrors.MakeApiError(http.StatusNotFound, "interest run not found")
+		}
+		logger.Error(ctx, "failed to get interest run", zap.Error(err))
+		return run, platformerrors.ErrInternal
+	}
+	return run, nil
+}
+
+func (s *service) withResults(ctx context.Context, run models.InterestRun) (*Run, error) {
+	results, err := s.db.GetInterestRunAccounts(ctx, run.ID)
+	if err != nil {
+		logger.Error(ctx, "failed to get interest run accounts", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	r := runFromModel(run)
+	r.Results = make([]RunAccount, 0, len(results))
+	for _, result := range results {
+		r.Results = append(r.Results, runAccountFromModel(result))
+	}
+	return &r, nil
+}
+
+func (s *service) GetCurrentRate(ctx context.Context) (*models.InterestRate, error) {
+	return s.rateAt(ctx, s.today())
+}
+
+// GetRateHistory lists every rate version, scheduled changes first and the oldest rate last.
+func (s *service) GetRateHistory(ctx context.Context) ([]RateVersion, error) {
+	rates, err := s.db.GetInterestRates(ctx)
+	if err != nil {
+		logger.Error(ctx, "failed to get interest rates", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	today := s.today()
+	history := make([]RateVersion, 0, len(rates))
+	for _, rate := range rates {
+		history = append(history, rateVersionFromModel(rate, today))
+	}
+	return history, nil
+}
+
+// rateAt returns the rate version in effect on day.
+func (s *service) rateAt(ctx context.Context, day time.Time) (*models.InterestRate, error) {
+	rate, err := s.db.GetInterestRateAt(ctx, day)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, platformerrors.MakeApiError(http.StatusPreconditionFailed, "interest rate has not been initialized")
+		}
+		return nil, err
+	}
+	return &rate, nil
+}
+
+// today is the current date in UTC, rates take effect and interest accrues by UTC days.
+func (s *service) today() time.Time {
+	now := s.now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (s *service) CreateRateCard(ctx context.Context, param CreateRateCardParam) (*RateCard, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "CreateRateCard"),
+		zap.Any(logger.RequestFields, param))
+
+	bands, conditions, err := param.stored()
+	if err != nil {
+		return nil, err
+	}
+
+	card, err := s.db.CreateInterestRateCard(ctx, models.CreateInterestRateCardParams{
+		Currency:          models.Currency(param.Currency),
+		Product:           models.AccountType(param.Product),
+		Method:            param.Method,
+		Bands:             bands,
+		BonusRate:         int64(math.Round(param.BonusRate * 100)),
+		BonusConditions:   conditions,
+		MinMonthlyDeposit: int64(math.Round(param.MinMonthlyDeposit * 100)),
+		CreatedBy:         param.UserID,
+	})
+	if err != nil {
+		if database.IsUniqueViolation(err, rateCardConstraint) {
+			return nil, platformerrors.MakeApiError(http.StatusConflict,
+				fmt.Sprintf("a rate card already exists for %s %s accounts", param.Currency, param.Product))
+		}
+		logger.Error(ctx, "failed to save interest rate card", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	return s.rateCardChanged(ctx, param.UserID, nil, card)
+}
+
+func (s *service) UpdateRateCard(ctx context.Context, param UpdateRateCardParam) (*RateCard, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "UpdateRateCard"),
+		zap.Any(logger.RequestFields, param))
+
+	old, err := s.GetRateCard(ctx, param.CardID)
+	if err != nil {
+		return nil, err
+	}
+
+	bands, conditions, err := param.stored()
+	if err != nil {
+		return nil, err
+	}
+
+	card, err := s.db.UpdateInterestRateCard(ctx, models.UpdateInterestRateCardParams{
+		ID:                param.CardID,
+		Method:            param.Method,
+		Bands:             bands,
+		BonusRate:         int64(math.Round(param.BonusRate * 100)),
+		BonusConditions:   conditions,
+		MinMonthlyDeposit: int64(math.Round(param.MinMonthlyDeposit * 100)),
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to update interest rate card", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	return s.rateCardChanged(ctx, param.UserID, old, card)
+}
+
+func (s *service) DeleteRateCard(ctx context.Context, param DeleteRateCardParam) error {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "DeleteRateCard"),
+		zap.Any(logger.RequestFields, param))
+
+	old, err := s.GetRateCard(ctx, param.CardID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.db.DeleteInterestRateCard(ctx, param.CardID); err != nil {
+		logger.Error(ctx, "failed to delete interest rate card", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+
+	s.submitRateCardChange(ctx, param.UserID, auditlog.InterestRateCardChangeMetadata{
+		CardID:   old.ID,
+		Currency: old.Currency,
+		Product:  old.Product,
+		Old:      old.RateCardTerms,
+	})
+	return nil
+}
+
+func (s *service) GetRateCard(ctx context.Context, cardID uuid.UUID) (*RateCard, error) {
+	card, err := s.db.GetInterestRateCard(ctx, cardID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, platformerrors.MakeApiError(http.StatusNotFound, "rate card not found")
+		}
+		logger.Error(ctx, "failed to get interest rate card", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	r, err := rateCardFromModel(card)
+	if err != nil {
+		logger.Error(ctx, "failed to read interest rate card", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+	return &r, nil
+}
+
+func (s *service) ListRateCards(ctx context.Context) ([]RateCard, error) {
+	cards, err := s.db.ListInterestRateCards(ctx)
+	if err != nil {
+		logger.Error(ctx, "failed to list interest rate cards", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	result := make([]RateCard, 0, len(cards))
+	for _, card := range cards {
+		r, err := rateCardFromModel(card)
+		if err != nil {
+			logger.Error(ctx, "failed to read interest rate card", zap.Error(err))
+			return nil, platformerrors.ErrInternal
+		}
+		result = append(result, r)
+	}
+	return result, nil
+}
+
+// rateCardChanged audits a new or changed rate card and returns it.
+func (s *service) rateCardChanged(ctx context.Context, userID uuid.UUID, old *RateCard, card models.InterestRateCard) (*RateCard, error) {
+	r, err := rateCardFromModel(card)
+	if err != nil {
+		logger.Error(ctx, "failed to read interest rate card", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	metadata := auditlog.InterestRateCardChangeMetadata{
+		CardID:   r.ID,
+		Currency: r.Currency,
+		Product:  r.Product,
+		New:      r.RateCardTerms,
+	}
+	if old != nil {
+		metadata.Old = old.RateCardTerms
+	}
+	s.submitRateCardChange(ctx, userID, metadata)
+	return &r, nil
+}
+
+func (s *service) submitRateCardChange(ctx context.Context, userID uuid.UUID, metadata auditlog.InterestRateCardChangeMetadata) {
+	auditEvent := auditlog.NewEvent(auditlog.ActionRateCardChange, userID, uuid.Nil, metadata)
+	if err := s.auditLog.Submit(ctx, auditEvent); err != nil {
+		logger.Warn(ctx, "failed to submit audit log", zap.Error(err))
+	}
+}
+
+func (s *service) CreateRateOverride(ctx context.Context, param CreateRateOverrideParam) (*RateOverride, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "CreateRateOverride"),
+		zap.Any(logger.RequestFields, param))
+
+	if _, err := s.db.GetAccountByID(ctx, param.AccountID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, platformerrors.MakeApiError(http.StatusNotFound, "account not found")
+		}
+		logger.Error(ctx, "failed to get account", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	startsOn, endsOn, err := param.check()
+	if err != nil {
+		return nil, err
+	}
+	if startsOn.Before(s.today()) {
+		return nil, platformerrors.MakeApiError(http.StatusBadRequest, "an override cannot start in the past")
+	}
+	if err := s.checkOverlap(ctx, param.AccountID, uuid.Nil, param.Kind, startsOn, endsOn); err != nil {
+		return nil, err
+	}
+
+	override, err := s.db.CreateInterestRateOverride(ctx, models.CreateInterestRateOverrideParams{
+		AccountID: param.AccountID,
+		Kind:      string(param.Kind),
+		Rate:      int64(math.Round(param.Rate * 100)),
+		StartsOn:  startsOn,
+		EndsOn:    endsOn,
+		Reason:    param.Reason,
+		CreatedBy: param.UserID,
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to save interest rate override", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	return s.rateOverrideChanged(ctx, param.UserID, nil, &override), nil
+}
+
+// UpdateRateOverride replaces the terms of an override. Days that have been accrued keep the rate
+// they were accrued at, so an override that has started keeps its start and can only be ended
+// from yesterday on.
+func (s *service) UpdateRateOverride(ctx context.Context, param UpdateRateOverrideParam) (*RateOverride, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "UpdateRateOverride"),
+		zap.Any(logger.RequestFields, param))
+
+	old, err := s.getRateOverride(ctx, param.AccountID, param.OverrideID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := s.today()
+	if old.EndsOn.Valid && old.EndsOn.Time.Before(today) {
+		return nil, platformerrors.MakeApiError(http.StatusBadRequest, "override has ended")
+	}
+
+	startsOn, endsOn, err := param.check()
+	if err != nil {
+		return nil, err
+	}
+	if !startsOn.Equal(old.StartsOn) {
+		if old.StartsOn.Before(today) {
+			return nil, platformerrors.MakeApiError(http.StatusBadRequest, "the start of an override that has started cannot change")
+		}
+		if startsOn.Before(today) {
+			return nil, platformerrors.MakeApiError(http.StatusBadRequest, "an override cannot start in the past")
+		}
+	}
+	if endsOn.Valid && endsOn.Time.Before(today.AddDate(0, 0, -1)) {
+		return nil, platformerrors.MakeApiError(http.StatusBadRequest, "an override cannot end before yesterday")
+	}
+	if err := s.checkOverlap(ctx, param.AccountID, old.ID, param.Kind, startsOn, endsOn); err != nil {
+		return nil, err
+	}
+
+	override, err := s.db.UpdateInterestRateOverride(ctx, models.UpdateInterestRateOverrideParams{
+		ID:       old.ID,
+		Kind:     string(param.Kind),
+		Rate:     int64(math.Round(param.Rate * 100)),
+		StartsOn: startsOn,
+		EndsOn:   endsOn,
+		Reason:   param.Reason,
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to update interest rate override", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	return s.rateOverrideChanged(ctx, param.UserID, &old, &override), nil
+}
+
+func (s *service) DeleteRateOverride(ctx context.Context, param DeleteRateOverrideParam) error {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "DeleteRateOverride"),
+		zap.Any(logger.RequestFields, param))
+
+	old, err := s.getRateOverride(ctx, param.AccountID, param.OverrideID)
+	if err != nil {
+		return err
+	}
+
+	if old.StartsOn.Before(s.today()) {
+		return platformerrors.MakeApiError(http.StatusBadRequest, "override has started, end it instead")
+	}
+
+	if _, err := s.db.DeleteInterestRateOverride(ctx, old.ID); err != nil {
+		logger.Error(ctx, "failed to delete interest rate override", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+
+	s.rateOverrideChanged(ctx, param.UserID, &old, nil)
+	return nil
+}
+
+func (s *service) ListRateOverrides(ctx context.Context, accountID uuid.UUID) ([]RateOverride, error) {
+	overrides, err := s.db.ListAccountInterestRateOverrides(ctx, accountID)
+	if err != nil {
+		logger.Error(ctx, "failed to list interest rate overrides", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	today := s.today()
+	result := make([]RateOverride, 0, len(overrides))
+	for _, o := range overrides {
+		result = append(result, rateOverrideFromModel(o, today))
+	}
+	return result, nil
+}
+
+func (s *service) getRateOverride(ctx context.Context, accountID, overrideID uuid.UUID) (models.InterestRateOverride, error) {
+	override, err := s.db.GetInterestRateOverride(ctx, models.GetInterestRateOverrideParams{
+		ID:        overrideID,
+		AccountID: accountID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return override, platformerrors.MakeApiError(http.StatusNotFound, "rate override not found")
+		}
+		logger.Error(ctx, "failed to get interest rate override", zap.Error(err))
+		return override, platformerrors.ErrInternal
+	}
+	return override, nil
+}
+
+// checkOverlap checks that the account has no other override of the kind on any of the days, id is
+// the override being changed.
+func (s *service) checkOverlap(ctx context.Context, accountID, id uuid.UUID, kind OverrideKind,
+	startsOn time.Time, endsOn sql.NullTime) error {
+	overlapping, err := s.db.CountOverlappingInterestRateOverrides(ctx, models.CountOverlappingInterestRateOverridesParams{
+		AccountID: accountID,
+		Kind:      string(kind),
+		ID:        id,
+		EndsOn:    endsOn,
+		StartsOn:  startsOn,
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to check interest rate overrides", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+	if overlapping > 0 {
+		return platformerrors.MakeApiError(http.StatusConflict,
+			fmt.Sprintf("the account already has a %s override on some of these days", kind))
+	}
+	return nil
+}
+
+// rateOverrideChanged audits an override being created, changed or removed with its values before
+// and after, and returns it as it is now.
+func (s *service) rateOverrideChanged(ctx context.Context, userID uuid.UUID, old, override *models.InterestRateOverride) *RateOverride {
+	today := s.today()
+	metadata := auditlog.InterestRateOverrideChangeMetadata{}
+	accountID := uuid.Nil
+	if old != nil {
+		o := rateOverrideFromModel(*old, today)
+		accountID = o.AccountID
+		metadata.OverrideID = o.ID
+		metadata.OldKind = string(o.Kind)
+		metadata.OldRate = old.Rate
+		metadata.OldStartsOn = o.StartsOn
+		metadata.OldEndsOn = o.EndsOn
+		metadata.Reason = o.Reason
+	}
+
+	var result *RateOverride
+	if override != nil {
+		o := rateOverrideFromModel(*override, today)
+		result = &o
+		accountID = o.AccountID
+		metadata.OverrideID = o.ID
+		metadata.NewKind = string(o.Kind)
+		metadata.NewRate = override.Rate
+		metadata.NewStartsOn = o.StartsOn
+		metadata.NewEndsOn = o.EndsOn
+		metadata.Reason = o.Reason
+	}
+
+	auditEvent := auditlog.NewEvent(auditlog.ActionRateOverrideChange, userID, accountID, metadata)
+	if err := s.auditLog.Submit(ctx, auditEvent); err != nil {
+		logger.Warn(ctx, "failed to submit audit log", zap.Error(err))
+	}
+	return result
+}
+
+func (s *service) GetTaxCertificate(ctx context.Context, param TaxCertificateParam) (*TaxCertificate, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "GetTaxCertificate"),
+		zap.Any(logger.RequestFields, param))
+
+	now := s.now().UTC()
+	if param.Year > now.Year() {
+		return nil, platformerrors.MakeApiError(http.StatusBadRequest, "tax year has not started")
+	}
+
+	account, err := s.db.GetAccountDetailsByID(ctx, param.AccountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, platformerrors.MakeApiError(http.StatusNotFound, "account not found")
+		}
+		logger.Error(ctx, "failed to get account", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	payments, err := s.db.GetInterestPayments(ctx, models.GetInterestPaymentsParams{
+		AccountID: param.AccountID,
+		From:      time.Date(param.Year, time.January, 1, 0, 0, 0, 0, time.UTC),
+		To:        time.Date(param.Year+1, time.January, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to get interest payments", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	certificate := &TaxCertificate{
+		AccountID:     account.AccountID,
+		AccountNumber: account.AccountNumber,
+		AccountHolder: account.FirstName + " " + account.LastName,
+		Currency:      string(account.Currency),
+		Year:          param.Year,
+		Payments:      make([]TaxCertificatePayment, 0, len(payments)),
+		IssuedAt:      now,
+	}
+	// totals are added up in minor units so that they match the payments to the cent
+	var gross, tax int64
+	for _, payment := range payments {
+		gross += payment.Paid
+		tax += payment.Tax
+		certificate.Payments = append(certificate.Payments, TaxCertificatePayment{
+			Period:  payment.Period.String,
+			PaidAt:  payment.PaidAt.Time,
+			Gross:   float64(payment.Paid) / 100,
+			TaxRate: float64(payment.TaxRate) / 100,
+			Tax:     float64(payment.Tax) / 100,
+			Net:     float64(payment.Paid-payment.Tax) / 100,
+		})
+	}
+	certificate.Gross = float64(gross) / 100
+	certificate.Tax = float64(tax) / 100
+	certificate.Net = float64(gross-tax) / 100
+	return certificate, nil
+}
+
+// Start schedules the job that accrues and capitalises interest on INTEREST_SCHEDULE. The job also
+// runs when the scheduler starts, to catch up the days that ended while no replica was running. It
+// looks up the rate in effect on each day it runs, so rate changes need no rescheduling.
+func (s *service) Start(ctx context.Context) error {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Start"))
+
+	err := s.scheduler.Schedule(scheduler.Job{
+		Name:       interestJob,
+		Spec:       s.cfg.Interest.Schedule,
+		RunOnStart: true,
+		Task: func(ctx context.Context) {
+			if err := s.ApplyRates(ctx); err != nil {
+				logger.Error(ctx, "failed to apply interest rates", zap.Error(err))
+			}
+		},
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to schedule interest job", zap.Error(err))
+		return err
+	}
+
+	logger.Info(ctx, "interest job scheduled",
+		zap.String("schedule", s.cfg.Interest.Schedule))
+	return nil
 }
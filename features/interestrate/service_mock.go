@@ -12,8 +12,10 @@ package interestrate
 import (
 	context "context"
 	models "payter-bank/internal/database/models"
+	scheduler "payter-bank/internal/scheduler"
 	reflect "reflect"
 
+	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -70,6 +72,64 @@ func (mr *MockServiceMockRecorder) CreateInterestRate(ctx, param any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInterestRate", reflect.TypeOf((*MockService)(nil).CreateInterestRate), ctx, param)
 }
 
+// CreateRateCard mocks base method.
+func (m *MockService) CreateRateCard(ctx context.Context, param CreateRateCardParam) (*RateCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRateCard", ctx, param)
+	ret0, _ := ret[0].(*RateCard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRateCard indicates an expected call of CreateRateCard.
+func (mr *MockServiceMockRecorder) CreateRateCard(ctx, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRateCard", reflect.TypeOf((*MockService)(nil).CreateRateCard), ctx, param)
+}
+
+// CreateRateOverride mocks base method.
+func (m *MockService) CreateRateOverride(ctx context.Context, param CreateRateOverrideParam) (*RateOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRateOverride", ctx, param)
+	ret0, _ := ret[0].(*RateOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRateOverride indicates an expected call of CreateRateOverride.
+func (mr *MockServiceMockRecorder) CreateRateOverride(ctx, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRateOverride", reflect.TypeOf((*MockService)(nil).CreateRateOverride), ctx, param)
+}
+
+// DeleteRateCard mocks base method.
+func (m *MockService) DeleteRateCard(ctx context.Context, param DeleteRateCardParam) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRateCard", ctx, param)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRateCard indicates an expected call of DeleteRateCard.
+func (mr *MockServiceMockRecorder) DeleteRateCard(ctx, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRateCard", reflect.TypeOf((*MockService)(nil).DeleteRateCard), ctx, param)
+}
+
+// DeleteRateOverride mocks base method.
+func (m *MockService) DeleteRateOverride(ctx context.Context, param DeleteRateOverrideParam) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRateOverride", ctx, param)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRateOverride indicates an expected call of DeleteRateOverride.
+func (mr *MockServiceMockRecorder) DeleteRateOverride(ctx, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRateOverride", reflect.TypeOf((*MockService)(nil).DeleteRateOverride), ctx, param)
+}
+
 // GetCurrentRate mocks base method.
 func (m *MockService) GetCurrentRate(ctx context.Context) (*models.InterestRate, error) {
 	m.ctrl.T.Helper()
@@ -85,6 +145,141 @@ func (mr *MockServiceMockRecorder) GetCurrentRate(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentRate", reflect.TypeOf((*MockService)(nil).GetCurrentRate), ctx)
 }
 
+// GetRateCard mocks base method.
+func (m *MockService) GetRateCard(ctx context.Context, cardID uuid.UUID) (*RateCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRateCard", ctx, cardID)
+	ret0, _ := ret[0].(*RateCard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRateCard indicates an expected call of GetRateCard.
+func (mr *MockServiceMockRecorder) GetRateCard(ctx, cardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRateCard", reflect.TypeOf((*MockService)(nil).GetRateCard), ctx, cardID)
+}
+
+// GetRateHistory mocks base method.
+func (m *MockService) GetRateHistory(ctx context.Context) ([]RateVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRateHistory", ctx)
+	ret0, _ := ret[0].([]RateVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRateHistory indicates an expected call of GetRateHistory.
+func (mr *MockServiceMockRecorder) GetRateHistory(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRateHistory", reflect.TypeOf((*MockService)(nil).GetRateHistory), ctx)
+}
+
+// GetRun mocks base method.
+func (m *MockService) GetRun(ctx context.Context, runID uuid.UUID) (*Run, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRun", ctx, runID)
+	ret0, _ := ret[0].(*Run)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRun indicates an expected call of GetRun.
+func (mr *MockServiceMockRecorder) GetRun(ctx, runID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRun", reflect.TypeOf((*MockService)(nil).GetRun), ctx, runID)
+}
+
+// GetTaxCertificate mocks base method.
+func (m *MockService) GetTaxCertificate(ctx context.Context, param TaxCertificateParam) (*TaxCertificate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaxCertificate", ctx, param)
+	ret0, _ := ret[0].(*TaxCertificate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaxCertificate indicates an expected call of GetTaxCertificate.
+func (mr *MockServiceMockRecorder) GetTaxCertificate(ctx, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaxCertificate", reflect.TypeOf((*MockService)(nil).GetTaxCertificate), ctx, param)
+}
+
+// ListRateCards mocks base method.
+func (m *MockService) ListRateCards(ctx context.Context) ([]RateCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRateCards", ctx)
+	ret0, _ := ret[0].([]RateCard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRateCards indicates an expected call of ListRateCards.
+func (mr *MockServiceMockRecorder) ListRateCards(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRateCards", reflect.TypeOf((*MockService)(nil).ListRateCards), ctx)
+}
+
+// ListRateOverrides mocks base method.
+func (m *MockService) ListRateOverrides(ctx context.Context, accountID uuid.UUID) ([]RateOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRateOverrides", ctx, accountID)
+	ret0, _ := ret[0].([]RateOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRateOverrides indicates an expected call of ListRateOverrides.
+func (mr *MockServiceMockRecorder) ListRateOverrides(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRateOverrides", reflect.TypeOf((*MockService)(nil).ListRateOverrides), ctx, accountID)
+}
+
+// ListRuns mocks base method.
+func (m *MockService) ListRuns(ctx context.Context, param ListRunsParam) ([]Run, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRuns", ctx, param)
+	ret0, _ := ret[0].([]Run)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRuns indicates an expected call of ListRuns.
+func (mr *MockServiceMockRecorder) ListRuns(ctx, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRuns", reflect.TypeOf((*MockService)(nil).ListRuns), ctx, param)
+}
+
+// RetryRunAccount mocks base method.
+func (m *MockService) RetryRunAccount(ctx context.Context, param RetryRunAccountParam) (*Run, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryRunAccount", ctx, param)
+	ret0, _ := ret[0].(*Run)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetryRunAccount indicates an expected call of RetryRunAccount.
+func (mr *MockServiceMockRecorder) RetryRunAccount(ctx, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryRunAccount", reflect.TypeOf((*MockService)(nil).RetryRunAccount), ctx, param)
+}
+
+// Simulate mocks base method.
+func (m *MockService) Simulate(ctx context.Context, param SimulateParam) (*Simulation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Simulate", ctx, param)
+	ret0, _ := ret[0].(*Simulation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Simulate indicates an expected call of Simulate.
+func (mr *MockServiceMockRecorder) Simulate(ctx, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Simulate", reflect.TypeOf((*MockService)(nil).Simulate), ctx, param)
+}
+
 // Start mocks base method.
 func (m *MockService) Start(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -129,40 +324,70 @@ func (mr *MockServiceMockRecorder) UpdateRate(ctx, param any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRate", reflect.TypeOf((*MockService)(nil).UpdateRate), ctx, param)
 }
 
-// MockRunner is a mock of Runner interface.
-type MockRunner struct {
+// UpdateRateCard mocks base method.
+func (m *MockService) UpdateRateCard(ctx context.Context, param UpdateRateCardParam) (*RateCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRateCard", ctx, param)
+	ret0, _ := ret[0].(*RateCard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRateCard indicates an expected call of UpdateRateCard.
+func (mr *MockServiceMockRecorder) UpdateRateCard(ctx, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRateCard", reflect.TypeOf((*MockService)(nil).UpdateRateCard), ctx, param)
+}
+
+// UpdateRateOverride mocks base method.
+func (m *MockService) UpdateRateOverride(ctx context.Context, param UpdateRateOverrideParam) (*RateOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRateOverride", ctx, param)
+	ret0, _ := ret[0].(*RateOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRateOverride indicates an expected call of UpdateRateOverride.
+func (mr *MockServiceMockRecorder) UpdateRateOverride(ctx, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRateOverride", reflect.TypeOf((*MockService)(nil).UpdateRateOverride), ctx, param)
+}
+
+// MockScheduler is a mock of Scheduler interface.
+type MockScheduler struct {
 	ctrl     *gomock.Controller
-	recorder *MockRunnerMockRecorder
+	recorder *MockSchedulerMockRecorder
 	isgomock struct{}
 }
 
-// MockRunnerMockRecorder is the mock recorder for MockRunner.
-type MockRunnerMockRecorder struct {
-	mock *MockRunner
+// MockSchedulerMockRecorder is the mock recorder for MockScheduler.
+type MockSchedulerMockRecorder struct {
+	mock *MockScheduler
 }
 
-// NewMockRunner creates a new mock instance.
-func NewMockRunner(ctrl *gomock.Controller) *MockRunner {
-	mock := &MockRunner{ctrl: ctrl}
-	mock.recorder = &MockRunnerMockRecorder{mock}
+// NewMockScheduler creates a new mock instance.
+func NewMockScheduler(ctrl *gomock.Controller) *MockScheduler {
+	mock := &MockScheduler{ctrl: ctrl}
+	mock.recorder = &MockSchedulerMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockRunner) EXPECT() *MockRunnerMockRecorder {
+func (m *MockScheduler) EXPECT() *MockSchedulerMockRecorder {
 	return m.recorder
 }
 
-// Start mocks base method.
-func (m *MockRunner) Start(ctx context.Context) error {
+// Schedule mocks base method.
+func (m *MockScheduler) Schedule(job scheduler.Job) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Start", ctx)
+	ret := m.ctrl.Call(m, "Schedule", job)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Start indicates an expected call of Start.
-func (mr *MockRunnerMockRecorder) Start(ctx any) *gomock.Call {
+// Schedule indicates an expected call of Schedule.
+func (mr *MockSchedulerMockRecorder) Schedule(job any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockRunner)(nil).Start), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Schedule", reflect.TypeOf((*MockScheduler)(nil).Schedule), job)
 }
@@ -3,16 +3,23 @@ package interestrate
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"net/http"
 	"payter-bank/features/auditlog"
+	"payter-bank/features/notification"
 	"payter-bank/internal/config"
 	"payter-bank/internal/database/models"
 	databasemocks "payter-bank/internal/database/models/mocks"
 	platformerrors "payter-bank/internal/errors"
+	"payter-bank/internal/pkg/interest"
+	"payter-bank/internal/scheduler"
+	"strings"
 	"testing"
 	"time"
 )
@@ -20,6 +27,7 @@ import (
 func TestService_CreateInterestRate(t *testing.T) {
 	t.Run("successfully creates new interest rate when none exists", func(t *testing.T) {
 		mocker := newInterestRateMocker(t)
+		mocker.at(time.Date(2026, 6, 10, 9, 30, 0, 0, time.UTC))
 
 		rateID := uuid.New()
 		userID := uuid.New()
@@ -39,6 +47,8 @@ func TestService_CreateInterestRate(t *testing.T) {
 			SaveInterestRate(gomock.Any(), models.SaveInterestRateParams{
 				Rate:                 550, // 5.5 * 100
 				CalculationFrequency: "monthly",
+				EffectiveFrom:        time.Date(2026, 6, 10, 0, 0, 0, 0, time.UTC),
+				CreatedBy:            uuid.NullUUID{UUID: userID, Valid: true},
 			}).
 			Return(models.InterestRate{
 				ID:                   rateID,
@@ -60,10 +70,6 @@ func TestService_CreateInterestRate(t *testing.T) {
 			Submit(gomock.Any(), expectedAuditEvent).
 			Return(nil)
 
-		mocker.runner.EXPECT().Start(gomock.Any()).
-			Return(nil).
-			Times(1)
-
 		response, err := mocker.service.CreateInterestRate(context.TODO(), param)
 
 		assert.NoError(t, err)
@@ -96,245 +102,803 @@ func TestService_CreateInterestRate(t *testing.T) {
 }
 
 func TestService_ApplyRates(t *testing.T) {
-	t.Run("successfully applies rates to active accounts with positive balance", func(t *testing.T) {
+	rate := models.InterestRate{
+		ID:                   uuid.New(),
+		Rate:                 500, // 5%
+		CalculationFrequency: "monthly",
+	}
+
+	t.Run("accrues a day of interest on end-of-day balances", func(t *testing.T) {
 		mocker := newInterestRateMocker(t)
+		mocker.at(time.Date(2026, 4, 15, 0, 5, 0, 0, time.UTC))
 
-		rateID := uuid.New()
 		account1ID := uuid.New()
 		account2ID := uuid.New()
-		txnID := uuid.New()
-
-		mocker.db.EXPECT().
-			GetInterestRates(gomock.Any()).
-			Return([]models.InterestRate{{
-				ID:                   rateID,
-				Rate:                 500, // 5%
-				CalculationFrequency: "monthly",
-			}}, nil)
+		account3ID := uuid.New()
+		day := time.Date(2026, 4, 14, 0, 0, 0, 0, time.UTC)
+		endOfDay := time.Date(2026, 4, 15, 0, 0, 0, 0, time.UTC)
 
+		mocker.expectRun(day, rate)
 		mocker.db.EXPECT().
 			GetAllActiveAccounts(gomock.Any()).
 			Return([]models.GetAllActiveAccountsRow{
-				{AccountID: account1ID, Currency: "EUR"},
-				{AccountID: account2ID, Currency: "USD"},
+				// a dormant balance keeps earning interest
+				{AccountID: account1ID, Currency: "GBP", Status: models.StatusDORMANT},
+				{AccountID: account2ID, Currency: "EUR"},
+				{AccountID: account3ID, Currency: "JPY"},
 			}, nil)
 
-		mocker.db.EXPECT().
-			GetAccountBalance(gomock.Any(), account1ID).
-			Return(models.GetAccountBalanceRow{Balance: 10000}, nil) // 100.00
+		mocker.db.EXPECT().ListInterestRateCards(gomock.Any()).Return(nil, nil)
+		mocker.db.EXPECT().ListInterestRateOverrides(gomock.Any(), gomock.Any()).Return(nil, nil)
 
 		mocker.db.EXPECT().
-			GetAccountBalance(gomock.Any(), account2ID).
-			Return(models.GetAccountBalanceRow{Balance: 20000}, nil) // 200.00
+			GetAccountBalanceAt(gomock.Any(), models.GetAccountBalanceAtParams{AccountID: account1ID, Before: endOfDay}).
+			Return(int64(1000000), nil) // 10,000.00
+		mocker.db.EXPECT().
+			GetAccountBalanceAt(gomock.Any(), models.GetAccountBalanceAtParams{AccountID: account2ID, Before: endOfDay}).
+			Return(int64(0), nil)
+		mocker.db.EXPECT().
+			GetAccountBalanceAt(gomock.Any(), models.GetAccountBalanceAtParams{AccountID: account3ID, Before: endOfDay}).
+			Return(int64(-1000), nil)
 
+		// 5% a year of 10,000.00 is 1.369863... a day
 		mocker.db.EXPECT().
-			SaveTransaction(gomock.Any(), gomock.Any()).
-			DoAndReturn(func(_ context.Context, params models.SaveTransactionParams) (models.Transaction, error) {
-				assert.Equal(t, account1ID, params.ToAccountID)
-				assert.Equal(t, int64(500), params.Amount) // 5.00 (5% of 100.00)
-				assert.Equal(t, "EUR", params.Currency)
-				return models.Transaction{ID: txnID}, nil
-			}).Times(1)
+			SaveInterestAccrual(gomock.Any(), models.SaveInterestAccrualParams{
+				AccountID:   account1ID,
+				AccrualDate: day,
+				Balance:     1000000,
+				Rate:        500,
+				Amount:      "136.9863013699",
+				DayCount:    "ACT/365",
+				BonusAmount: "0.0000000000",
+			}).
+			Return(int64(1), nil)
 
 		mocker.db.EXPECT().
-			SaveTransaction(gomock.Any(), gomock.Any()).
-			DoAndReturn(func(_ context.Context, params models.SaveTransactionParams) (models.Transaction, error) {
-				assert.Equal(t, account2ID, params.ToAccountID)
-				assert.Equal(t, int64(1000), params.Amount) // 10.00 (5% of 200.00)
-				assert.Equal(t, "USD", params.Currency)
-				return models.Transaction{ID: txnID}, nil
-			}).Times(1)
+			CompleteInterestRunAccount(gomock.Any(), models.CompleteInterestRunAccountParams{
+				ID:      account1ID,
+				Accrued: "136.9863013699",
+			}).
+			Return(nil)
+		mocker.db.EXPECT().
+			CompleteInterestRunAccount(gomock.Any(), models.CompleteInterestRunAccountParams{ID: account2ID, Accrued: "0.0000000000"}).
+			Return(nil)
+		mocker.db.EXPECT().
+			CompleteInterestRunAccount(gomock.Any(), models.CompleteInterestRunAccountParams{ID: account3ID, Accrued: "0.0000000000"}).
+			Return(nil)
 
 		err := mocker.service.ApplyRates(context.Background())
 
 		assert.NoError(t, err)
 	})
 
-	t.Run("skips accounts with zero or negative balance", func(t *testing.T) {
+	t.Run("accrues under the rate card of the account's currency and product", func(t *testing.T) {
 		mocker := newInterestRateMocker(t)
+		mocker.at(time.Date(2026, 4, 30, 0, 5, 0, 0, time.UTC))
 
-		rateID := uuid.New()
-		account1ID := uuid.New()
-		account2ID := uuid.New()
+		cardID, cardAccountID, globalAccountID := uuid.New(), uuid.New(), uuid.New()
+		day := time.Date(2026, 4, 29, 0, 0, 0, 0, time.UTC)
 
+		mocker.expectRun(day, rate)
 		mocker.db.EXPECT().
-			GetInterestRates(gomock.Any()).
-			Return([]models.InterestRate{{
-				ID:                   rateID,
-				Rate:                 500,
-				CalculationFrequency: "monthly",
-			}}, nil)
+			GetAllActiveAccounts(gomock.Any()).
+			Return([]models.GetAllActiveAccountsRow{
+				{AccountID: cardAccountID, Currency: "GBP", AccountType: "CURRENT"},
+				{AccountID: globalAccountID, Currency: "EUR", AccountType: "CURRENT"},
+			}, nil)
+		mocker.db.EXPECT().ListInterestRateCards(gomock.Any()).Return([]models.InterestRateCard{testRateCard(cardID)}, nil)
+		mocker.db.EXPECT().ListInterestRateOverrides(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		mocker.db.EXPECT().
+			GetAccountFlows(gomock.Any(), models.GetAccountFlowsParams{
+				AccountID: cardAccountID,
+				From:      time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+				To:        time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC),
+			}).
+			Return(models.GetAccountFlowsRow{Deposited: 100000}, nil)
+		mocker.db.EXPECT().GetAccountBalanceAt(gomock.Any(), gomock.Any()).Return(int64(1500000), nil).Times(2)
+
+		// 1% on the first 10,000.00 and 2% on the other 5,000.00 is 133 basis points blended, with a
+		// bonus of 0.5% on the whole balance as nothing was withdrawn
+		mocker.db.EXPECT().
+			SaveInterestAccrual(gomock.Any(), models.SaveInterestAccrualParams{
+				AccountID:   cardAccountID,
+				AccrualDate: day,
+				Balance:     1500000,
+				Rate:        133,
+				Amount:      "54.7945205479",
+				DayCount:    "ACT/365",
+				CardID:      uuid.NullUUID{UUID: cardID, Valid: true},
+				BonusRate:   50,
+				BonusAmount: "20.5479452055",
+			}).
+			Return(int64(1), nil)
+		mocker.db.EXPECT().
+			SaveInterestAccrual(gomock.Any(), models.SaveInterestAccrualParams{
+				AccountID:   globalAccountID,
+				AccrualDate: day,
+				Balance:     1500000,
+				Rate:        500,
+				Amount:      "205.4794520548",
+				DayCount:    "ACT/365",
+				BonusAmount: "0.0000000000",
+			}).
+			Return(int64(1), nil)
+
+		mocker.db.EXPECT().
+			CompleteInterestRunAccount(gomock.Any(), models.CompleteInterestRunAccountParams{
+				ID:      cardAccountID,
+				Accrued: "75.3424657534",
+			}).
+			Return(nil)
+		mocker.db.EXPECT().CompleteInterestRunAccount(gomock.Any(), gomock.Any()).Return(nil)
+
+		err := mocker.service.ApplyRates(context.Background())
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("accrues at the rate overrides of an account in effect on the day", func(t *testing.T) {
+		mocker := newInterestRateMocker(t)
+		mocker.at(time.Date(2026, 4, 30, 0, 5, 0, 0, time.UTC))
+
+		cardID, fixedAccountID, boostedAccountID := uuid.New(), uuid.New(), uuid.New()
+		day := time.Date(2026, 4, 29, 0, 0, 0, 0, time.UTC)
 
+		mocker.expectRun(day, rate)
 		mocker.db.EXPECT().
 			GetAllActiveAccounts(gomock.Any()).
 			Return([]models.GetAllActiveAccountsRow{
-				{AccountID: account1ID, Currency: "EUR"},
-				{AccountID: account2ID, Currency: "USD"},
+				{AccountID: fixedAccountID, Currency: "GBP", AccountType: "CURRENT"},
+				{AccountID: boostedAccountID, Currency: "EUR", AccountType: "CURRENT"},
 			}, nil)
+		mocker.db.EXPECT().ListInterestRateCards(gomock.Any()).Return([]models.InterestRateCard{testRateCard(cardID)}, nil)
+		mocker.db.EXPECT().
+			ListInterestRateOverrides(gomock.Any(), models.ListInterestRateOverridesParams{To: day, From: day}).
+			Return([]models.InterestRateOverride{
+				{
+					AccountID: fixedAccountID,
+					Kind:      "FIXED",
+					Rate:      300,
+					StartsOn:  time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+				},
+				{
+					AccountID: fixedAccountID,
+					Kind:      "BOOST",
+					Rate:      100,
+					StartsOn:  time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+					EndsOn:    sql.NullTime{Time: time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC), Valid: true},
+				},
+				{
+					AccountID: boostedAccountID,
+					Kind:      "BOOST",
+					Rate:      100,
+					StartsOn:  time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+					EndsOn:    sql.NullTime{Time: time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC), Valid: true},
+				},
+			}, nil)
+		mocker.db.EXPECT().GetAccountBalanceAt(gomock.Any(), gomock.Any()).Return(int64(1000000), nil).Times(2)
 
+		// the fixed rate takes the place of the card, with none of its bands or bonus, and the boost
+		// that has ended is not paid
+		mocker.db.EXPECT().
+			SaveInterestAccrual(gomock.Any(), models.SaveInterestAccrualParams{
+				AccountID:   fixedAccountID,
+				AccrualDate: day,
+				Balance:     1000000,
+				Rate:        300,
+				Amount:      "82.1917808219",
+				DayCount:    "ACT/365",
+				BonusAmount: "0.0000000000",
+			}).
+			Return(int64(1), nil)
+		// 1% on top of the global 5%
 		mocker.db.EXPECT().
-			GetAccountBalance(gomock.Any(), account1ID).
-			Return(models.GetAccountBalanceRow{Balance: 0}, nil)
+			SaveInterestAccrual(gomock.Any(), models.SaveInterestAccrualParams{
+				AccountID:   boostedAccountID,
+				AccrualDate: day,
+				Balance:     1000000,
+				Rate:        600,
+				Amount:      "164.3835616438",
+				DayCount:    "ACT/365",
+				BonusAmount: "0.0000000000",
+			}).
+			Return(int64(1), nil)
+		mocker.db.EXPECT().CompleteInterestRunAccount(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+		err := mocker.service.ApplyRates(context.Background())
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("forfeits the bonus of a month that misses a condition", func(t *testing.T) {
+		mocker := newInterestRateMocker(t)
+		mocker.at(time.Date(2026, 5, 1, 0, 5, 0, 0, time.UTC))
+
+		cardID, accountID := uuid.New(), uuid.New()
+		day := time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC)
+
+		mocker.expectRun(day, models.InterestRate{ID: uuid.New(), Rate: 500, CalculationFrequency: "quarterly"})
+		mocker.db.EXPECT().
+			GetAllActiveAccounts(gomock.Any()).
+			Return([]models.GetAllActiveAccountsRow{{AccountID: accountID, Currency: "GBP", AccountType: "CURRENT"}}, nil)
+		mocker.db.EXPECT().ListInterestRateCards(gomock.Any()).Return([]models.InterestRateCard{testRateCard(cardID)}, nil)
+		mocker.db.EXPECT().ListInterestRateOverrides(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		mocker.db.EXPECT().
+			GetAccountFlows(gomock.Any(), gomock.Any()).
+			Return(models.GetAccountFlowsRow{Withdrawn: 2000, Deposited: 100000}, nil)
+		mocker.db.EXPECT().
+			ForfeitInterestBonus(gomock.Any(), models.ForfeitInterestBonusParams{
+				AccountID: accountID,
+				From:      time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+				To:        day,
+			}).
+			Return(int64(29), nil)
+		mocker.db.EXPECT().GetAccountBalanceAt(gomock.Any(), gomock.Any()).Return(int64(500000), nil)
 
 		mocker.db.EXPECT().
-			GetAccountBalance(gomock.Any(), account2ID).
-			Return(models.GetAccountBalanceRow{Balance: -1000}, nil)
+			SaveInterestAccrual(gomock.Any(), models.SaveInterestAccrualParams{
+				AccountID:   accountID,
+				AccrualDate: day,
+				Balance:     500000,
+				Rate:        100,
+				Amount:      "13.6986301370",
+				DayCount:    "ACT/365",
+				CardID:      uuid.NullUUID{UUID: cardID, Valid: true},
+				BonusAmount: "0.0000000000",
+			}).
+			Return(int64(1), nil)
+		mocker.db.EXPECT().CompleteInterestRunAccount(gomock.Any(), gomock.Any()).Return(nil)
 
 		err := mocker.service.ApplyRates(context.Background())
 
 		assert.NoError(t, err)
 	})
 
-	t.Run("handles errors gracefully", func(t *testing.T) {
+	t.Run("capitalises accrued interest at the end of each period", func(t *testing.T) {
 		testCases := []struct {
-			name          string
-			setupMocks    func(*interestRateMocker)
-			expectedError error
+			frequency   string
+			now         time.Time
+			description string
 		}{
-			{
-				name: "current rate not found",
-				setupMocks: func(m *interestRateMocker) {
-					m.db.EXPECT().
-						GetInterestRates(gomock.Any()).
-						Return(nil, nil)
-				},
-				expectedError: platformerrors.MakeApiError(http.StatusPreconditionFailed, "interest rate has not been initialized"),
-			},
-			{
-				name: "get active accounts error",
-				setupMocks: func(m *interestRateMocker) {
-					m.db.EXPECT().
-						GetInterestRates(gomock.Any()).
-						Return([]models.InterestRate{{ID: uuid.New()}}, nil)
+			{"monthly", time.Date(2026, 5, 1, 0, 5, 0, 0, time.UTC), "Interest for April 2026"},
+			{"quarterly", time.Date(2026, 4, 1, 0, 5, 0, 0, time.UTC), "Interest for Q1 2026"},
+			{"yearly", time.Date(2027, 1, 1, 0, 5, 0, 0, time.UTC), "Interest for 2026"},
+		}
 
-					m.db.EXPECT().
-						GetAllActiveAccounts(gomock.Any()).
-						Return(nil, platformerrors.ErrInternal)
-				},
-				expectedError: platformerrors.ErrInternal,
-			},
-			{
-				name: "get balance error",
-				setupMocks: func(m *interestRateMocker) {
-					accountID := uuid.New()
+		for _, tc := range testCases {
+			t.Run(tc.frequency, func(t *testing.T) {
+				mocker := newInterestRateMocker(t)
+				mocker.at(tc.now)
 
-					m.db.EXPECT().
-						GetInterestRates(gomock.Any()).
-						Return([]models.InterestRate{{ID: uuid.New()}}, nil)
+				accountID := uuid.New()
+				closedAccountID := uuid.New()
+				txnID := uuid.New()
+				userID := uuid.New()
+				day := time.Date(tc.now.Year(), tc.now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
 
-					m.db.EXPECT().
-						GetAllActiveAccounts(gomock.Any()).
-						Return([]models.GetAllActiveAccountsRow{{AccountID: accountID}}, nil)
+				run := mocker.expectRun(day, models.InterestRate{ID: uuid.New(), Rate: 500, CalculationFrequency: tc.frequency})
+				assert.Equal(t, sql.NullString{String: tc.description[len("Interest for "):], Valid: true}, run.Period)
 
-					m.db.EXPECT().
-						GetAccountBalance(gomock.Any(), accountID).
-						Return(models.GetAccountBalanceRow{}, sql.ErrConnDone)
-				},
-				expectedError: nil, // Should continue with next account
-			},
-			{
-				name: "save transaction error",
-				setupMocks: func(m *interestRateMocker) {
-					accountID := uuid.New()
+				mocker.db.EXPECT().
+					GetAllActiveAccounts(gomock.Any()).
+					Return([]models.GetAllActiveAccountsRow{{AccountID: accountID, Currency: "GBP"}}, nil)
+				mocker.db.EXPECT().ListInterestRateCards(gomock.Any()).Return(nil, nil)
+				mocker.db.EXPECT().ListInterestRateOverrides(gomock.Any(), gomock.Any()).Return(nil, nil)
+				mocker.db.EXPECT().GetAccountBalanceAt(gomock.Any(), gomock.Any()).Return(int64(0), nil)
 
-					m.db.EXPECT().
-						GetInterestRates(gomock.Any()).
-						Return([]models.InterestRate{{ID: uuid.New(), Rate: 500}}, nil)
+				// an account closed during the period is still paid what it accrued
+				mocker.db.EXPECT().
+					GetUncapitalisedInterest(gomock.Any(), day).
+					Return([]models.GetUncapitalisedInterestRow{
+						{AccountID: accountID, Currency: "GBP"},
+						{AccountID: closedAccountID, Currency: "EUR"},
+					}, nil)
 
-					m.db.EXPECT().
-						GetAllActiveAccounts(gomock.Any()).
-						Return([]models.GetAllActiveAccountsRow{{AccountID: accountID}}, nil)
+				mocker.db.EXPECT().
+					GetAccountUncapitalisedInterest(gomock.Any(), models.GetAccountUncapitalisedInterestParams{
+						AccrualDate: day,
+						AccountID:   accountID,
+					}).
+					Return(models.GetAccountUncapitalisedInterestRow{
+						Currency:       "GBP",
+						Amount:         "4109.2465753424",
+						Carry:          "-0.4000000000",
+						Days:           30,
+						AverageBalance: 1000000,
+						AverageRate:    500,
+					}, nil)
+				// an exempt customer is paid the interest in full
+				mocker.db.EXPECT().
+					GetAccountTaxStatus(gomock.Any(), accountID).
+					Return(models.GetAccountTaxStatusRow{TaxResidency: sql.NullString{String: "GB", Valid: true}, TaxExempt: true}, nil)
 
-					m.db.EXPECT().
-						GetAccountBalance(gomock.Any(), accountID).
-						Return(models.GetAccountBalanceRow{Balance: 10000}, nil)
+				mocker.db.EXPECT().
+					SaveTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, params models.SaveTransactionParams) (models.Transaction, error) {
+						assert.Equal(t, uuid.Nil, params.FromAccountID)
+						assert.Equal(t, accountID, params.ToAccountID)
+						assert.Equal(t, int64(4109), params.Amount) // 4108.8465753424 rounded
+						assert.Equal(t, "GBP", params.Currency)
+						assert.Equal(t, sql.NullString{String: tc.description, Valid: true}, params.Description)
+						return models.Transaction{ID: txnID}, nil
+					})
+				mocker.db.EXPECT().
+					SetInterestRunAccountTransaction(gomock.Any(), models.SetInterestRunAccountTransactionParams{
+						ID:            accountID,
+						TransactionID: uuid.NullUUID{UUID: txnID, Valid: true},
+						Paid:          4109,
+					}).
+					Return(nil)
+
+				// the payment is audited with what it was worked out on and the customer is told
+				mocker.auditLog.EXPECT().
+					Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionInterestApplied, systemUserID, accountID,
+						auditlog.InterestAppliedMetadata{
+							TransactionID: txnID,
+							Period:        run.Period.String,
+							PeriodEnd:     day.Format(time.DateOnly),
+							Days:          30,
+							Rate:          500,
+							Balance:       1000000,
+							Gross:         4109,
+							Amount:        4109,
+							Currency:      "GBP",
+						})).
+					Return(nil)
+				mocker.db.EXPECT().
+					GetAccountByID(gomock.Any(), accountID).
+					Return(models.GetAccountByIDRow{ID: accountID, UserID: userID, AccountNumber: "12345678"}, nil)
+				mocker.notification.EXPECT().
+					Notify(gomock.Any(), notification.Message{
+						UserID:  userID,
+						Type:    notification.TypeInterestPaid,
+						Subject: "Your interest for " + run.Period.String,
+						Message: "Account 12345678 earned 41.09 GBP interest for " + run.Period.String +
+							", at an average rate of 5% on an average balance of 10000.00 GBP. It has been paid into the account.",
+						Metadata: map[string]any{
+							"account_id":     accountID,
+							"transaction_id": txnID,
+							"period":         run.Period.String,
+							"amount":         int64(4109),
+							"currency":       "GBP",
+						},
+					}).
+					Return(nil)
+
+				// the balances of the account and of the interest account are brought up to date
+				mocker.db.EXPECT().UpdateBalance(gomock.Any(), accountID).Return(nil)
+				mocker.db.EXPECT().UpdateBalance(gomock.Any(), uuid.Nil).Return(nil)
 
-					m.db.EXPECT().
-						SaveTransaction(gomock.Any(), gomock.Any()).
-						Return(models.Transaction{}, sql.ErrTxDone)
-				},
-				expectedError: nil, // Should continue with next account
-			},
+				mocker.db.EXPECT().
+					CapitaliseInterestAccruals(gomock.Any(), models.CapitaliseInterestAccrualsParams{
+						AccountID:     accountID,
+						AccrualDate:   day,
+						TransactionID: uuid.NullUUID{UUID: txnID, Valid: true},
+					}).
+					Return(int64(30), nil)
+				mocker.db.EXPECT().
+					SetInterestCarry(gomock.Any(), models.SetInterestCarryParams{
+						ID:            accountID,
+						InterestCarry: "-0.1534246576",
+					}).
+					Return(nil)
+
+				// less than a cent is not paid, it is carried into the next period
+				mocker.db.EXPECT().
+					GetAccountUncapitalisedInterest(gomock.Any(), models.GetAccountUncapitalisedInterestParams{
+						AccrualDate: day,
+						AccountID:   closedAccountID,
+					}).
+					Return(models.GetAccountUncapitalisedInterestRow{Currency: "EUR", Amount: "0.3000000000", Carry: "0.1000000000"}, nil)
+				mocker.db.EXPECT().
+					CapitaliseInterestAccruals(gomock.Any(), models.CapitaliseInterestAccrualsParams{
+						AccountID:   closedAccountID,
+						AccrualDate: day,
+					}).
+					Return(int64(30), nil)
+				mocker.db.EXPECT().
+					SetInterestCarry(gomock.Any(), models.SetInterestCarryParams{
+						ID:            closedAccountID,
+						InterestCarry: "0.4000000000",
+					}).
+					Return(nil)
+
+				mocker.db.EXPECT().CompleteInterestRunAccount(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+				err := mocker.service.ApplyRates(context.Background())
+
+				assert.NoError(t, err)
+			})
+		}
+	})
+
+	t.Run("withholds tax at the rate of the customer's residency", func(t *testing.T) {
+		eurTaxAccountID := uuid.MustParse("00000000-3333-3333-3333-000000000001")
+		testCases := []struct {
+			name         string
+			currency     models.Currency
+			residency    sql.NullString
+			tax          int64
+			taxRate      int64
+			taxAccountID uuid.UUID
+		}{
+			{"listed residency", "GBP", sql.NullString{String: "IE", Valid: true}, 1355, 3300, taxAccountID}, // 33% of 41.09 rounded down
+			{"unknown residency", "GBP", sql.NullString{}, 821, 2000, taxAccountID},
+			{"euro account", "EUR", sql.NullString{String: "GB", Valid: true}, 821, 2000, eurTaxAccountID},
 		}
 
 		for _, tc := range testCases {
 			t.Run(tc.name, func(t *testing.T) {
 				mocker := newInterestRateMocker(t)
-				tc.setupMocks(mocker)
+				mocker.at(time.Date(2026, 5, 1, 0, 5, 0, 0, time.UTC))
+				day := time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC)
+				accountID, txnID, taxTxnID := uuid.New(), uuid.New(), uuid.New()
+
+				mocker.expectRun(day, models.InterestRate{ID: uuid.New(), Rate: 500, CalculationFrequency: "monthly"})
+				mocker.db.EXPECT().GetAllActiveAccounts(gomock.Any()).Return(nil, nil)
+				mocker.db.EXPECT().ListInterestRateCards(gomock.Any()).Return(nil, nil)
+				mocker.db.EXPECT().ListInterestRateOverrides(gomock.Any(), gomock.Any()).Return(nil, nil)
+				mocker.db.EXPECT().
+					GetUncapitalisedInterest(gomock.Any(), day).
+					Return([]models.GetUncapitalisedInterestRow{{AccountID: accountID, Currency: tc.currency}}, nil)
+				mocker.db.EXPECT().
+					GetAccountUncapitalisedInterest(gomock.Any(), gomock.Any()).
+					Return(models.GetAccountUncapitalisedInterestRow{Currency: tc.currency, Amount: "4109.0000000000", Carry: "0"}, nil)
+				mocker.db.EXPECT().
+					GetAccountTaxStatus(gomock.Any(), accountID).
+					Return(models.GetAccountTaxStatusRow{TaxResidency: tc.residency}, nil)
+				mocker.db.EXPECT().
+					GetAccountByCurrency(gomock.Any(), models.GetAccountByCurrencyParams{Currency: tc.currency, UserID: taxUserID}).
+					Return(models.Account{ID: tc.taxAccountID, Currency: tc.currency}, nil)
+
+				// the customer is paid the interest net of tax and the tax is paid to the tax account of
+				// the interest's currency
+				gomock.InOrder(
+					mocker.db.EXPECT().
+						SaveTransaction(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ context.Context, params models.SaveTransactionParams) (models.Transaction, error) {
+							assert.Equal(t, accountID, params.ToAccountID)
+							assert.Equal(t, 4109-tc.tax, params.Amount)
+							assert.Equal(t, sql.NullString{String: "Interest for April 2026 (net of tax)", Valid: true}, params.Description)
+							return models.Transaction{ID: txnID}, nil
+						}),
+					mocker.db.EXPECT().
+						SetInterestRunAccountTransaction(gomock.Any(), models.SetInterestRunAccountTransactionParams{
+							ID:            accountID,
+							TransactionID: uuid.NullUUID{UUID: txnID, Valid: true},
+							Paid:          4109,
+							Tax:           tc.tax,
+							TaxRate:       tc.taxRate,
+						}).
+						Return(nil),
+					mocker.db.EXPECT().
+						SaveTransaction(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ context.Context, params models.SaveTransactionParams) (models.Transaction, error) {
+							assert.Equal(t, uuid.Nil, params.FromAccountID)
+							assert.Equal(t, tc.taxAccountID, params.ToAccountID)
+							assert.Equal(t, tc.tax, params.Amount)
+							assert.Equal(t, string(tc.currency), params.Currency)
+							assert.Equal(t, sql.NullString{String: "Tax withheld from interest for April 2026", Valid: true}, params.Description)
+							return models.Transaction{ID: taxTxnID}, nil
+						}),
+					mocker.db.EXPECT().
+						SetInterestRunAccountTaxTransaction(gomock.Any(), models.SetInterestRunAccountTaxTransactionParams{
+							ID:               accountID,
+							TaxTransactionID: uuid.NullUUID{UUID: taxTxnID, Valid: true},
+						}).
+						Return(nil),
+				)
+
+				mocker.auditLog.EXPECT().
+					Submit(gomock.Any(), gomock.Cond(func(x any) bool {
+						metadata := x.(auditlog.Event).Metadata.(auditlog.InterestAppliedMetadata)
+						return metadata.Gross == 4109 && metadata.Tax == tc.tax && metadata.Amount == 4109-tc.tax
+					})).
+					Return(nil)
+				mocker.db.EXPECT().GetAccountByID(gomock.Any(), accountID).Return(models.GetAccountByIDRow{AccountNumber: "12345678"}, nil)
+				mocker.notification.EXPECT().
+					Notify(gomock.Any(), gomock.Cond(func(x any) bool {
+						return strings.HasSuffix(x.(notification.Message).Message, fmt.Sprintf(
+							" %.2f %s tax was withheld and %.2f %s has been paid into the account.",
+							float64(tc.tax)/100, tc.currency, float64(4109-tc.tax)/100, tc.currency))
+					})).
+					Return(nil)
+				mocker.db.EXPECT().UpdateBalance(gomock.Any(), accountID).Return(nil)
+				mocker.db.EXPECT().UpdateBalance(gomock.Any(), uuid.Nil).Return(nil)
+				mocker.db.EXPECT().UpdateBalance(gomock.Any(), tc.taxAccountID).Return(nil)
+
+				mocker.db.EXPECT().CapitaliseInterestAccruals(gomock.Any(), gomock.Any()).Return(int64(30), nil)
+				mocker.db.EXPECT().SetInterestCarry(gomock.Any(), gomock.Any()).Return(nil)
+				mocker.db.EXPECT().CompleteInterestRunAccount(gomock.Any(), gomock.Any()).Return(nil)
 
 				err := mocker.service.ApplyRates(context.Background())
 
-				if tc.expectedError != nil {
-					assert.Equal(t, tc.expectedError, err)
-				} else {
-					assert.NoError(t, err)
-				}
+				assert.NoError(t, err)
 			})
 		}
 	})
-}
 
-func TestService_Start(t *testing.T) {
-	t.Run("successfully starts scheduler with correct cron expression", func(t *testing.T) {
+	t.Run("does not capitalise before the period ends", func(t *testing.T) {
+		for _, frequency := range []string{"quarterly", "yearly"} {
+			mocker := newInterestRateMocker(t)
+			mocker.at(time.Date(2026, 5, 1, 0, 5, 0, 0, time.UTC))
+
+			run := mocker.expectRun(time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC),
+				models.InterestRate{ID: uuid.New(), Rate: 500, CalculationFrequency: frequency})
+			assert.False(t, run.Period.Valid)
+			mocker.db.EXPECT().GetAllActiveAccounts(gomock.Any()).Return(nil, nil)
+			mocker.db.EXPECT().ListInterestRateCards(gomock.Any()).Return(nil, nil)
+			mocker.db.EXPECT().ListInterestRateOverrides(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+			err := mocker.service.ApplyRates(context.Background())
+
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("catches up missed days and resumes unfinished runs", func(t *testing.T) {
 		mocker := newInterestRateMocker(t)
+		mocker.at(time.Date(2026, 4, 15, 0, 5, 0, 0, time.UTC))
 
-		// Setup context with cancellation to test shutdown
-		ctx, cancel := context.WithCancel(context.Background())
+		mocker.db.EXPECT().
+			GetUnfinishedInterestRuns(gomock.Any()).
+			Return([]models.InterestRun{{RunDate: time.Date(2026, 4, 10, 0, 0, 0, 0, time.UTC), Status: "FAILED"}}, nil)
+		mocker.db.EXPECT().
+			GetLastInterestRun(gomock.Any()).
+			Return(models.InterestRun{RunDate: time.Date(2026, 4, 12, 0, 0, 0, 0, time.UTC)}, nil)
 
+		var days []string
+		mocker.db.EXPECT().GetInterestRateAt(gomock.Any(), gomock.Any()).Return(rate, nil).Times(3)
 		mocker.db.EXPECT().
-			GetInterestRates(gomock.Any()).
-			Return([]models.InterestRate{{
-				ID:                   uuid.New(),
-				Rate:                 500,
-				CalculationFrequency: "daily",
-			}}, nil)
+			StartInterestRun(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, arg models.StartInterestRunParams) (models.InterestRun, error) {
+				days = append(days, arg.RunDate.Format(time.DateOnly))
+				return models.InterestRun{ID: uuid.New(), RunDate: arg.RunDate}, nil
+			}).
+			Times(3)
+		mocker.db.EXPECT().GetAllActiveAccounts(gomock.Any()).Return(nil, nil).Times(3)
+		mocker.db.EXPECT().ListInterestRateCards(gomock.Any()).Return(nil, nil).Times(3)
+		mocker.db.EXPECT().ListInterestRateOverrides(gomock.Any(), gomock.Any()).Return(nil, nil).Times(3)
+		mocker.db.EXPECT().FinishInterestRun(gomock.Any(), gomock.Any()).Return(models.InterestRun{}, nil).Times(3)
 
-		errChan := make(chan error)
-		go func() {
-			errChan <- mocker.service.Start(ctx)
-		}()
+		err := mocker.service.ApplyRates(context.Background())
 
-		// Allow some time for scheduler to start
-		time.Sleep(100 * time.Millisecond)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"2026-04-10", "2026-04-13", "2026-04-14"}, days)
+	})
+
+	t.Run("runs only yesterday before the first run", func(t *testing.T) {
+		mocker := newInterestRateMocker(t)
+		mocker.at(time.Date(2026, 4, 15, 0, 5, 0, 0, time.UTC))
+		day := time.Date(2026, 4, 14, 0, 0, 0, 0, time.UTC)
+
+		mocker.db.EXPECT().GetUnfinishedInterestRuns(gomock.Any()).Return(nil, nil)
+		mocker.db.EXPECT().GetLastInterestRun(gomock.Any()).Return(models.InterestRun{}, sql.ErrNoRows)
+		// the rate was only initialised today, there is no interest for yesterday
+		mocker.db.EXPECT().GetInterestRateAt(gomock.Any(), day).Return(models.InterestRate{}, sql.ErrNoRows)
+
+		err := mocker.service.ApplyRates(context.Background())
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("skips a day that has already been run", func(t *testing.T) {
+		mocker := newInterestRateMocker(t)
+		mocker.at(time.Date(2026, 4, 15, 0, 5, 0, 0, time.UTC))
+
+		mocker.db.EXPECT().GetUnfinishedInterestRuns(gomock.Any()).Return(nil, nil)
+		mocker.db.EXPECT().
+			GetLastInterestRun(gomock.Any()).
+			Return(models.InterestRun{RunDate: time.Date(2026, 4, 13, 0, 0, 0, 0, time.UTC)}, nil)
+		mocker.db.EXPECT().GetInterestRateAt(gomock.Any(), gomock.Any()).Return(rate, nil)
+		// another instance completed the day in the meantime
+		mocker.db.EXPECT().StartInterestRun(gomock.Any(), gomock.Any()).Return(models.InterestRun{}, sql.ErrNoRows)
+
+		err := mocker.service.ApplyRates(context.Background())
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("skips an account that already has a result", func(t *testing.T) {
+		mocker := newInterestRateMocker(t)
+		mocker.at(time.Date(2026, 4, 15, 0, 5, 0, 0, time.UTC))
+		accountID := uuid.New()
+
+		run := models.InterestRun{ID: uuid.New(), RunDate: time.Date(2026, 4, 14, 0, 0, 0, 0, time.UTC)}
+		mocker.db.EXPECT().GetUnfinishedInterestRuns(gomock.Any()).Return(nil, nil)
+		mocker.db.EXPECT().GetLastInterestRun(gomock.Any()).Return(models.InterestRun{RunDate: run.RunDate.AddDate(0, 0, -1)}, nil)
+		mocker.db.EXPECT().GetInterestRateAt(gomock.Any(), gomock.Any()).Return(rate, nil)
+		mocker.db.EXPECT().StartInterestRun(gomock.Any(), gomock.Any()).Return(run, nil)
+		mocker.db.EXPECT().
+			GetAllActiveAccounts(gomock.Any()).
+			Return([]models.GetAllActiveAccountsRow{{AccountID: accountID, Currency: "GBP"}}, nil)
+		mocker.db.EXPECT().ListInterestRateCards(gomock.Any()).Return(nil, nil)
+		mocker.db.EXPECT().ListInterestRateOverrides(gomock.Any(), gomock.Any()).Return(nil, nil)
+		mocker.db.EXPECT().
+			ClaimInterestRunAccount(gomock.Any(), models.ClaimInterestRunAccountParams{
+				RunID:       run.ID,
+				AccountID:   accountID,
+				RunDate:     run.RunDate,
+				StaleBefore: time.Date(2026, 4, 14, 23, 5, 0, 0, time.UTC),
+			}).
+			Return(models.InterestRunAccount{}, sql.ErrNoRows)
+		mocker.db.EXPECT().FinishInterestRun(gomock.Any(), run.ID).Return(run, nil)
+
+		err := mocker.service.ApplyRates(context.Background())
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("does not pay again when a retried account was paid", func(t *testing.T) {
+		mocker := newInterestRateMocker(t)
+		mocker.at(time.Date(2026, 5, 1, 0, 5, 0, 0, time.UTC))
+		day := time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC)
+		accountID, claimID, txnID := uuid.New(), uuid.New(), uuid.New()
+
+		run := models.InterestRun{ID: uuid.New(), RunDate: day, Period: sql.NullString{String: "April 2026", Valid: true}}
+		mocker.db.EXPECT().GetUnfinishedInterestRuns(gomock.Any()).Return([]models.InterestRun{run}, nil)
+		mocker.db.EXPECT().GetLastInterestRun(gomock.Any()).Return(run, nil)
+		mocker.db.EXPECT().GetInterestRateAt(gomock.Any(), day).Return(rate, nil)
+		mocker.db.EXPECT().StartInterestRun(gomock.Any(), gomock.Any()).Return(run, nil)
+		mocker.db.EXPECT().GetAllActiveAccounts(gomock.Any()).Return(nil, nil)
+		mocker.db.EXPECT().ListInterestRateCards(gomock.Any()).Return(nil, nil)
+		mocker.db.EXPECT().ListInterestRateOverrides(gomock.Any(), gomock.Any()).Return(nil, nil)
+		mocker.db.EXPECT().
+			GetUncapitalisedInterest(gomock.Any(), day).
+			Return([]models.GetUncapitalisedInterestRow{{AccountID: accountID}}, nil)
+
+		// the interest was paid before marking the accruals capitalised failed
+		mocker.db.EXPECT().
+			ClaimInterestRunAccount(gomock.Any(), gomock.Any()).
+			Return(models.InterestRunAccount{
+				ID:            claimID,
+				AccountID:     accountID,
+				TransactionID: uuid.NullUUID{UUID: txnID, Valid: true},
+				Attempts:      2,
+			}, nil)
+		mocker.db.EXPECT().
+			GetAccountUncapitalisedInterest(gomock.Any(), gomock.Any()).
+			Return(models.GetAccountUncapitalisedInterestRow{Currency: "GBP", Amount: "4109.2465753424", Carry: "0"}, nil)
+		mocker.db.EXPECT().
+			CapitaliseInterestAccruals(gomock.Any(), models.CapitaliseInterestAccrualsParams{
+				AccountID:     accountID,
+				AccrualDate:   day,
+				TransactionID: uuid.NullUUID{UUID: txnID, Valid: true},
+			}).
+			Return(int64(30), nil)
+		mocker.db.EXPECT().SetInterestCarry(gomock.Any(), gomock.Any()).Return(nil)
+		// the payment is neither audited nor notified again, the balances are brought up to date
+		mocker.db.EXPECT().UpdateBalance(gomock.Any(), accountID).Return(nil)
+		mocker.db.EXPECT().UpdateBalance(gomock.Any(), uuid.Nil).Return(nil)
+		mocker.db.EXPECT().
+			CompleteInterestRunAccount(gomock.Any(), models.CompleteInterestRunAccountParams{ID: claimID, Accrued: "0.0000000000"}).
+			Return(nil)
+		mocker.db.EXPECT().FinishInterestRun(gomock.Any(), run.ID).Return(run, nil)
+
+		err := mocker.service.ApplyRates(context.Background())
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("pays the tax of a retried account that was paid without it", func(t *testing.T) {
+		mocker := newInterestRateMocker(t)
+		mocker.at(time.Date(2026, 5, 1, 0, 5, 0, 0, time.UTC))
+		day := time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC)
+		accountID, claimID, txnID, taxTxnID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+		run := models.InterestRun{ID: uuid.New(), RunDate: day, Period: sql.NullString{String: "April 2026", Valid: true}}
+		mocker.db.EXPECT().GetUnfinishedInterestRuns(gomock.Any()).Return([]models.InterestRun{run}, nil)
+		mocker.db.EXPECT().GetLastInterestRun(gomock.Any()).Return(run, nil)
+		mocker.db.EXPECT().GetInterestRateAt(gomock.Any(), day).Return(rate, nil)
+		mocker.db.EXPECT().StartInterestRun(gomock.Any(), gomock.Any()).Return(run, nil)
+		mocker.db.EXPECT().GetAllActiveAccounts(gomock.Any()).Return(nil, nil)
+		mocker.db.EXPECT().ListInterestRateCards(gomock.Any()).Return(nil, nil)
+		mocker.db.EXPECT().ListInterestRateOverrides(gomock.Any(), gomock.Any()).Return(nil, nil)
+		mocker.db.EXPECT().
+			GetUncapitalisedInterest(gomock.Any(), day).
+			Return([]models.GetUncapitalisedInterestRow{{AccountID: accountID}}, nil)
+
+		// the net interest was paid before paying the tax failed, the tax recorded then is paid
+		// whatever the customer's tax status is now
+		mocker.db.EXPECT().
+			ClaimInterestRunAccount(gomock.Any(), gomock.Any()).
+			Return(models.InterestRunAccount{
+				ID:            claimID,
+				AccountID:     accountID,
+				TransactionID: uuid.NullUUID{UUID: txnID, Valid: true},
+				Paid:          4109,
+				Tax:           821,
+				TaxRate:       2000,
+				Attempts:      2,
+			}, nil)
+		mocker.db.EXPECT().
+			GetAccountUncapitalisedInterest(gomock.Any(), gomock.Any()).
+			Return(models.GetAccountUncapitalisedInterestRow{Currency: "GBP", Amount: "4109.0000000000", Carry: "0"}, nil)
+		mocker.db.EXPECT().
+			GetAccountByCurrency(gomock.Any(), models.GetAccountByCurrencyParams{Currency: "GBP", UserID: taxUserID}).
+			Return(models.Account{ID: taxAccountID, Currency: "GBP"}, nil)
+		mocker.db.EXPECT().
+			SaveTransaction(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, params models.SaveTransactionParams) (models.Transaction, error) {
+				assert.Equal(t, taxAccountID, params.ToAccountID)
+				assert.Equal(t, int64(821), params.Amount)
+				return models.Transaction{ID: taxTxnID}, nil
+			})
+		mocker.db.EXPECT().
+			SetInterestRunAccountTaxTransaction(gomock.Any(), models.SetInterestRunAccountTaxTransactionParams{
+				ID:               claimID,
+				TaxTransactionID: uuid.NullUUID{UUID: taxTxnID, Valid: true},
+			}).
+			Return(nil)
+		mocker.db.EXPECT().CapitaliseInterestAccruals(gomock.Any(), gomock.Any()).Return(int64(30), nil)
+		mocker.db.EXPECT().SetInterestCarry(gomock.Any(), gomock.Any()).Return(nil)
+		mocker.db.EXPECT().UpdateBalance(gomock.Any(), gomock.Any()).Return(nil).Times(3)
+		mocker.db.EXPECT().CompleteInterestRunAccount(gomock.Any(), gomock.Any()).Return(nil)
+		mocker.db.EXPECT().FinishInterestRun(gomock.Any(), run.ID).Return(run, nil)
 
-		// Trigger shutdown
-		cancel()
+		err := mocker.service.ApplyRates(context.Background())
 
-		err := <-errChan
 		assert.NoError(t, err)
 	})
 
-	t.Run("handles all calculation frequencies", func(t *testing.T) {
-		frequencies := map[string]string{
-			"hourly":  "0 * * * *",
-			"daily":   "0 0 * * *",
-			"weekly":  "0 0 * * 0",
-			"monthly": "0 0 1 * *",
-			"yearly":  "0 0 1 1 *",
+	t.Run("records a failed account and goes on", func(t *testing.T) {
+		testCases := []struct {
+			name       string
+			setupMocks func(*interestRateMocker)
+			error      string
+		}{
+			{
+				name: "get balance error",
+				setupMocks: func(m *interestRateMocker) {
+					m.db.EXPECT().
+						GetAccountBalanceAt(gomock.Any(), gomock.Any()).
+						Return(int64(0), sql.ErrConnDone)
+				},
+				error: "get account balance: sql: connection is already closed",
+			},
+			{
+				name: "save accrual error",
+				setupMocks: func(m *interestRateMocker) {
+					m.db.EXPECT().
+						GetAccountBalanceAt(gomock.Any(), gomock.Any()).
+						Return(int64(10000), nil)
+					m.db.EXPECT().
+						SaveInterestAccrual(gomock.Any(), gomock.Any()).
+						Return(int64(0), sql.ErrConnDone)
+				},
+				error: "save interest accrual: sql: connection is already closed",
+			},
 		}
 
-		for freq, _ := range frequencies {
-			t.Run(fmt.Sprintf("frequency: %s", freq), func(t *testing.T) {
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
 				mocker := newInterestRateMocker(t)
-				ctx, cancel := context.WithCancel(context.Background())
+				mocker.at(time.Date(2026, 4, 15, 0, 5, 0, 0, time.UTC))
+				failedID, okID := uuid.New(), uuid.New()
+
+				mocker.expectRun(time.Date(2026, 4, 14, 0, 0, 0, 0, time.UTC), rate)
+				mocker.db.EXPECT().
+					GetAllActiveAccounts(gomock.Any()).
+					Return([]models.GetAllActiveAccountsRow{{AccountID: failedID}, {AccountID: okID}}, nil)
+				mocker.db.EXPECT().ListInterestRateCards(gomock.Any()).Return(nil, nil)
+				mocker.db.EXPECT().ListInterestRateOverrides(gomock.Any(), gomock.Any()).Return(nil, nil)
+				tc.setupMocks(mocker)
+				mocker.db.EXPECT().GetAccountBalanceAt(gomock.Any(), gomock.Any()).Return(int64(0), nil)
 
 				mocker.db.EXPECT().
-					GetInterestRates(gomock.Any()).
-					Return([]models.InterestRate{{
-						ID:                   uuid.New(),
-						Rate:                 500,
-						CalculationFrequency: freq,
-					}}, nil)
-
-				errChan := make(chan error)
-				go func() {
-					errChan <- mocker.service.Start(ctx)
-				}()
-
-				time.Sleep(100 * time.Millisecond)
-				cancel()
-
-				err := <-errChan
+					FailInterestRunAccount(gomock.Any(), models.FailInterestRunAccountParams{
+						ID:    failedID,
+						Error: sql.NullString{String: tc.error, Valid: true},
+					}).
+					Return(nil)
+				mocker.db.EXPECT().
+					CompleteInterestRunAccount(gomock.Any(), models.CompleteInterestRunAccountParams{ID: okID, Accrued: "0.0000000000"}).
+					Return(nil)
+
+				err := mocker.service.ApplyRates(context.Background())
+
 				assert.NoError(t, err)
 			})
 		}
@@ -347,165 +911,598 @@ func TestService_Start(t *testing.T) {
 			expectedError error
 		}{
 			{
-				name: "no current rate",
+				name: "get unfinished runs error",
+				setupMocks: func(m *interestRateMocker) {
+					m.db.EXPECT().
+						GetUnfinishedInterestRuns(gomock.Any()).
+						Return(nil, sql.ErrConnDone)
+				},
+				expectedError: platformerrors.ErrInternal,
+			},
+			{
+				name: "get rate error",
 				setupMocks: func(m *interestRateMocker) {
+					m.db.EXPECT().GetUnfinishedInterestRuns(gomock.Any()).Return(nil, nil)
+					m.db.EXPECT().GetLastInterestRun(gomock.Any()).Return(models.InterestRun{}, sql.ErrNoRows)
 					m.db.EXPECT().
-						GetInterestRates(gomock.Any()).
-						Return(nil, nil)
+						GetInterestRateAt(gomock.Any(), gomock.Any()).
+						Return(models.InterestRate{}, sql.ErrConnDone)
 				},
-				expectedError: platformerrors.MakeApiError(http.StatusPreconditionFailed, "interest rate has not been initialized"),
+				expectedError: platformerrors.ErrInternal,
 			},
 			{
-				name: "invalid calculation frequency",
+				name: "get active accounts error",
 				setupMocks: func(m *interestRateMocker) {
+					m.db.EXPECT().GetUnfinishedInterestRuns(gomock.Any()).Return(nil, nil)
+					m.db.EXPECT().GetLastInterestRun(gomock.Any()).Return(models.InterestRun{}, sql.ErrNoRows)
+					m.db.EXPECT().GetInterestRateAt(gomock.Any(), gomock.Any()).Return(rate, nil)
+					m.db.EXPECT().StartInterestRun(gomock.Any(), gomock.Any()).Return(models.InterestRun{ID: uuid.New()}, nil)
+
 					m.db.EXPECT().
-						GetInterestRates(gomock.Any()).
-						Return([]models.InterestRate{{
-							ID:                   uuid.New(),
-							Rate:                 500,
-							CalculationFrequency: "invalid",
-						}}, nil)
+						GetAllActiveAccounts(gomock.Any()).
+						Return(nil, platformerrors.ErrInternal)
 				},
-				expectedError: fmt.Errorf("unknown frequency: invalid"),
+				expectedError: platformerrors.ErrInternal,
 			},
 			{
-				name: "database error",
+				name: "get accrued interest error",
 				setupMocks: func(m *interestRateMocker) {
+					m.at(time.Date(2026, 5, 1, 0, 5, 0, 0, time.UTC))
+
+					m.db.EXPECT().GetUnfinishedInterestRuns(gomock.Any()).Return(nil, nil)
+					m.db.EXPECT().GetLastInterestRun(gomock.Any()).Return(models.InterestRun{}, sql.ErrNoRows)
+					m.db.EXPECT().GetInterestRateAt(gomock.Any(), gomock.Any()).Return(rate, nil)
 					m.db.EXPECT().
-						GetInterestRates(gomock.Any()).
+						StartInterestRun(gomock.Any(), gomock.Any()).
+						Return(models.InterestRun{ID: uuid.New(), Period: sql.NullString{String: "April 2026", Valid: true}}, nil)
+					m.db.EXPECT().GetAllActiveAccounts(gomock.Any()).Return(nil, nil)
+					m.db.EXPECT().ListInterestRateCards(gomock.Any()).Return(nil, nil)
+					m.db.EXPECT().ListInterestRateOverrides(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+					m.db.EXPECT().
+						GetUncapitalisedInterest(gomock.Any(), gomock.Any()).
 						Return(nil, sql.ErrConnDone)
 				},
-				expectedError: sql.ErrConnDone,
+				expectedError: platformerrors.ErrInternal,
 			},
 		}
 
 		for _, tc := range testCases {
 			t.Run(tc.name, func(t *testing.T) {
 				mocker := newInterestRateMocker(t)
+				mocker.at(time.Date(2026, 4, 15, 0, 5, 0, 0, time.UTC))
 				tc.setupMocks(mocker)
 
-				ctx := context.Background()
-				err := mocker.service.Start(ctx)
+				err := mocker.service.ApplyRates(context.Background())
 
-				assert.Error(t, err)
-				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				assert.Equal(t, tc.expectedError, err)
 			})
 		}
 	})
+}
+
+func TestService_Start(t *testing.T) {
+	t.Run("schedules the interest job to catch up on start and run on the schedule", func(t *testing.T) {
+		mocker := newInterestRateMocker(t)
+		mocker.at(time.Date(2026, 4, 15, 0, 5, 0, 0, time.UTC))
+
+		var job scheduler.Job
+		mocker.jobs.EXPECT().
+			Schedule(gomock.Any()).
+			DoAndReturn(func(j scheduler.Job) error {
+				job = j
+				return nil
+			})
+
+		err := mocker.service.Start(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, "interest", job.Name)
+		assert.Equal(t, "5 0 * * *", job.Spec)
+		assert.True(t, job.RunOnStart)
+
+		// the task runs the days that are due
+		day := time.Date(2026, 4, 14, 0, 0, 0, 0, time.UTC)
+		mocker.expectRun(day, models.InterestRate{ID: uuid.New(), Rate: 500, CalculationFrequency: "monthly"})
+		mocker.db.EXPECT().GetAllActiveAccounts(gomock.Any()).Return(nil, nil)
+		mocker.db.EXPECT().ListInterestRateCards(gomock.Any()).Return(nil, nil)
+		mocker.db.EXPECT().ListInterestRateOverrides(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		job.Task(context.Background())
+	})
 
-	t.Run("graceful shutdown", func(t *testing.T) {
+	t.Run("returns the error of a schedule that cannot be parsed", func(t *testing.T) {
 		mocker := newInterestRateMocker(t)
-		ctx, cancel := context.WithCancel(context.Background())
 
+		mocker.jobs.EXPECT().Schedule(gomock.Any()).Return(errors.New("invalid cron"))
+
+		err := mocker.service.Start(context.Background())
+
+		assert.EqualError(t, err, "invalid cron")
+	})
+}
+
+func TestService_GetRun(t *testing.T) {
+	mocker := newInterestRateMocker(t)
+	runID, accountID, txnID := uuid.New(), uuid.New(), uuid.New()
+	day := time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC)
+
+	mocker.db.EXPECT().
+		GetInterestRun(gomock.Any(), runID).
+		Return(models.InterestRun{
+			ID:       runID,
+			RunDate:  day,
+			Period:   sql.NullString{String: "April 2026", Valid: true},
+			Status:   "COMPLETED",
+			Accounts: 1,
+		}, nil)
+	mocker.db.EXPECT().
+		GetInterestRunAccounts(gomock.Any(), runID).
+		Return([]models.InterestRunAccount{{
+			AccountID:     accountID,
+			Status:        "COMPLETED",
+			Accrued:       "136.9863013699",
+			Paid:          4109,
+			TransactionID: uuid.NullUUID{UUID: txnID, Valid: true},
+			Attempts:      1,
+		}}, nil)
+
+	run, err := mocker.service.GetRun(context.Background(), runID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-04-30", run.RunDate)
+	assert.Equal(t, "April 2026", run.Period)
+	assert.Equal(t, []RunAccount{{
+		AccountID:     accountID,
+		Status:        "COMPLETED",
+		Accrued:       1.369863013699,
+		Paid:          41.09,
+		TransactionID: &txnID,
+		Attempts:      1,
+	}}, run.Results)
+
+	mocker.db.EXPECT().GetInterestRun(gomock.Any(), gomock.Any()).Return(models.InterestRun{}, sql.ErrNoRows)
+	_, err = mocker.service.GetRun(context.Background(), uuid.New())
+	assert.Equal(t, platformerrors.MakeApiError(http.StatusNotFound, "interest run not found"), err)
+}
+
+func TestService_RetryRunAccount(t *testing.T) {
+	day := time.Date(2026, 4, 14, 0, 0, 0, 0, time.UTC)
+	rate := models.InterestRate{ID: uuid.New(), Rate: 500, CalculationFrequency: "monthly"}
+
+	t.Run("runs a failed account again", func(t *testing.T) {
+		mocker := newInterestRateMocker(t)
+		mocker.at(time.Date(2026, 4, 15, 9, 0, 0, 0, time.UTC))
+		userID, accountID, claimID := uuid.New(), uuid.New(), uuid.New()
+		run := models.InterestRun{ID: uuid.New(), RunDate: day, Status: "FAILED", Accounts: 2, Failed: 1}
+
+		mocker.db.EXPECT().GetInterestRun(gomock.Any(), run.ID).Return(run, nil)
 		mocker.db.EXPECT().
-			GetInterestRates(gomock.Any()).
-			Return([]models.InterestRate{{
-				ID:                   uuid.New(),
-				Rate:                 500,
-				CalculationFrequency: "daily",
-			}}, nil)
+			GetInterestRunAccount(gomock.Any(), models.GetInterestRunAccountParams{RunID: run.ID, AccountID: accountID}).
+			Return(models.InterestRunAccount{ID: claimID, AccountID: accountID, Status: "FAILED"}, nil)
+		mocker.db.EXPECT().GetInterestRateAt(gomock.Any(), day).Return(rate, nil)
+		mocker.db.EXPECT().ListInterestRateCards(gomock.Any()).Return(nil, nil)
+		mocker.db.EXPECT().ListInterestRateOverrides(gomock.Any(), gomock.Any()).Return(nil, nil)
+		mocker.db.EXPECT().
+			GetAllActiveAccounts(gomock.Any()).
+			Return([]models.GetAllActiveAccountsRow{{AccountID: uuid.New()}, {AccountID: accountID, Currency: "GBP"}}, nil)
 
-		errChan := make(chan error)
-		go func() {
-			errChan <- mocker.service.Start(ctx)
-		}()
+		mocker.db.EXPECT().
+			ClaimInterestRunAccount(gomock.Any(), gomock.Any()).
+			Return(models.InterestRunAccount{ID: claimID, AccountID: accountID, Attempts: 2}, nil)
+		mocker.db.EXPECT().GetAccountBalanceAt(gomock.Any(), gomock.Any()).Return(int64(1000000), nil)
+		mocker.db.EXPECT().SaveInterestAccrual(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+		mocker.db.EXPECT().
+			CompleteInterestRunAccount(gomock.Any(), models.CompleteInterestRunAccountParams{ID: claimID, Accrued: "136.9863013699"}).
+			Return(nil)
+
+		finished := run
+		finished.Status, finished.Failed = "COMPLETED", 0
+		mocker.db.EXPECT().FinishInterestRun(gomock.Any(), run.ID).Return(finished, nil)
+		mocker.db.EXPECT().
+			GetInterestRunAccounts(gomock.Any(), run.ID).
+			Return([]models.InterestRunAccount{{AccountID: accountID, Status: "COMPLETED", Accrued: "136.9863013699", Attempts: 2}}, nil)
+
+		mocker.auditLog.EXPECT().
+			Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionInterestRunRetry, userID, accountID,
+				auditlog.InterestRunRetryMetadata{RunID: run.ID, RunDate: "2026-04-14"})).
+			Return(nil)
 
-		// Allow scheduler to start
-		time.Sleep(100 * time.Millisecond)
+		response, err := mocker.service.RetryRunAccount(context.Background(), RetryRunAccountParam{
+			UserID:    userID,
+			RunID:     run.ID,
+			AccountID: accountID,
+		})
 
-		// Test graceful shutdown
-		cancel()
-		err := <-errChan
 		assert.NoError(t, err)
+		assert.Equal(t, "COMPLETED", response.Status)
+		assert.Len(t, response.Results, 1)
 	})
 
-	t.Run("scheduler runs ApplyRates at correct intervals", func(t *testing.T) {
+	t.Run("only re-runs failed accounts", func(t *testing.T) {
 		mocker := newInterestRateMocker(t)
-		ctx, cancel := context.WithCancel(context.Background())
+		run := models.InterestRun{ID: uuid.New(), RunDate: day}
 
-		rateID := uuid.New()
+		mocker.db.EXPECT().GetInterestRun(gomock.Any(), run.ID).Return(run, nil)
 		mocker.db.EXPECT().
-			GetInterestRates(gomock.Any()).
-			Return([]models.InterestRate{{
-				ID:                   rateID,
-				Rate:                 500,
-				CalculationFrequency: "hourly",
-			}}, nil).
-			AnyTimes()
+			GetInterestRunAccount(gomock.Any(), gomock.Any()).
+			Return(models.InterestRunAccount{Status: "COMPLETED"}, nil)
+
+		response, err := mocker.service.RetryRunAccount(context.Background(), RetryRunAccountParam{
+			UserID:    uuid.New(),
+			RunID:     run.ID,
+			AccountID: uuid.New(),
+		})
+
+		assert.Nil(t, response)
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusConflict, "only a failed account can be re-run"), err)
+	})
+
+	t.Run("returns not found for an account outside the run", func(t *testing.T) {
+		mocker := newInterestRateMocker(t)
+
+		mocker.db.EXPECT().GetInterestRun(gomock.Any(), gomock.Any()).Return(models.InterestRun{ID: uuid.New()}, nil)
+		mocker.db.EXPECT().
+			GetInterestRunAccount(gomock.Any(), gomock.Any()).
+			Return(models.InterestRunAccount{}, sql.ErrNoRows)
+
+		_, err := mocker.service.RetryRunAccount(context.Background(), RetryRunAccountParam{
+			UserID:    uuid.New(),
+			RunID:     uuid.New(),
+			AccountID: uuid.New(),
+		})
+
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusNotFound, "account is not part of the interest run"), err)
+	})
+}
+
+func TestService_Simulate(t *testing.T) {
+	t.Run("works out the interest of a period at a candidate rate without posting it", func(t *testing.T) {
+		mocker := newInterestRateMocker(t)
+		mocker.at(time.Date(2026, 5, 10, 9, 0, 0, 0, time.UTC))
+
+		cardID, cardAccountID, gbpAccountID, eurAccountID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+		mocker.db.EXPECT().
+			GetAllActiveAccounts(gomock.Any()).
+			Return([]models.GetAllActiveAccountsRow{
+				{AccountID: cardAccountID, Currency: "GBP", AccountType: "CURRENT"},
+				{AccountID: gbpAccountID, Currency: "GBP", AccountType: "SAVINGS"},
+				{AccountID: eurAccountID, Currency: "EUR", AccountType: "CURRENT"},
+			}, nil)
+		mocker.db.EXPECT().ListInterestRateCards(gomock.Any()).Return([]models.InterestRateCard{testRateCard(cardID)}, nil)
+		mocker.db.EXPECT().ListInterestRateOverrides(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		mocker.db.EXPECT().
+			GetAccountFlows(gomock.Any(), models.GetAccountFlowsParams{
+				AccountID: cardAccountID,
+				From:      time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+				To:        time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+			}).
+			Return(models.GetAccountFlowsRow{Deposited: 100000}, nil)
+
+		// every day of April has ended, so each day earns on its own end-of-day balance
+		balances := map[uuid.UUID]int64{cardAccountID: 1500000, gbpAccountID: 1000000, eurAccountID: 500000}
+		mocker.db.EXPECT().
+			GetAccountBalanceAt(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, arg models.GetAccountBalanceAtParams) (int64, error) {
+				assert.False(t, arg.Before.After(time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)))
+				return balances[arg.AccountID], nil
+			}).
+			Times(90)
+
+		simulation, err := mocker.service.Simulate(context.Background(), SimulateParam{
+			Rate:                 3,
+			CalculationFrequency: "monthly",
+			Period:               "2026-04-15",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "April 2026", simulation.Period)
+		assert.Equal(t, "2026-04-01", simulation.From)
+		assert.Equal(t, "2026-04-30", simulation.To)
+		assert.Len(t, simulation.Accounts, 3)
+
+		// the card pays 1.33% blended and a 0.5% bonus on 15,000.00, the candidate 3% is only paid
+		// to accounts without a card
+		assert.Equal(t, &cardID, simulation.Accounts[0].CardID)
+		assert.InDelta(t, 22.6027397260, simulation.Accounts[0].Interest, 1e-9)
+		assert.Equal(t, 22.6, simulation.Accounts[0].Paid)
+		assert.Nil(t, simulation.Accounts[1].CardID)
+		assert.InDelta(t, 24.6575342466, simulation.Accounts[1].Interest, 1e-9)
+		assert.Equal(t, 24.66, simulation.Accounts[1].Paid)
+		assert.InDelta(t, 12.3287671233, simulation.Accounts[2].Interest, 1e-9)
+		assert.Equal(t, 12.33, simulation.Accounts[2].Paid)
+
+		assert.Len(t, simulation.Totals, 2)
+		assert.Equal(t, "EUR", simulation.Totals[0].Currency)
+		assert.Equal(t, 1, simulation.Totals[0].Accounts)
+		assert.Equal(t, 12.33, simulation.Totals[0].Paid)
+		assert.Equal(t, "GBP", simulation.Totals[1].Currency)
+		assert.Equal(t, 2, simulation.Totals[1].Accounts)
+		assert.InDelta(t, 47.2602739726, simulation.Totals[1].Interest, 1e-9)
+		assert.InDelta(t, 47.26, simulation.Totals[1].Paid, 1e-9)
+	})
 
+	t.Run("projects the rest of a period that has not ended on the latest balance", func(t *testing.T) {
+		mocker := newInterestRateMocker(t)
+		mocker.at(time.Date(2026, 6, 20, 9, 0, 0, 0, time.UTC))
+
+		accountID := uuid.New()
 		mocker.db.EXPECT().
 			GetAllActiveAccounts(gomock.Any()).
-			Return(nil, nil).
-			AnyTimes()
+			Return([]models.GetAllActiveAccountsRow{{AccountID: accountID, Currency: "GBP", AccountType: "SAVINGS"}}, nil)
+		mocker.db.EXPECT().ListInterestRateCards(gomock.Any()).Return(nil, nil)
+		mocker.db.EXPECT().ListInterestRateOverrides(gomock.Any(), gomock.Any()).Return(nil, nil)
 
-		// Start service
-		errChan := make(chan error)
-		go func() {
-			errChan <- mocker.service.Start(ctx)
-		}()
+		// April 1 to June 19 have ended, June 20 to 30 earn on the balance at the end of June 19
+		mocker.db.EXPECT().
+			GetAccountBalanceAt(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, arg models.GetAccountBalanceAtParams) (int64, error) {
+				assert.False(t, arg.Before.After(time.Date(2026, 6, 20, 0, 0, 0, 0, time.UTC)))
+				return int64(1000000), nil
+			}).
+			Times(80)
 
-		// Allow some time for at least one execution
-		time.Sleep(2 * time.Second)
-		cancel()
+		simulation, err := mocker.service.Simulate(context.Background(), SimulateParam{
+			Rate:                 3,
+			CalculationFrequency: "quarterly",
+			Period:               "2026-05-02",
+		})
 
-		err := <-errChan
 		assert.NoError(t, err)
+		assert.Equal(t, "Q2 2026", simulation.Period)
+		assert.Equal(t, "2026-04-01", simulation.From)
+		assert.Equal(t, "2026-06-30", simulation.To)
+		// 3% of 10,000.00 for 91 days
+		assert.Equal(t, 74.79, simulation.Accounts[0].Paid)
+	})
+
+	t.Run("rejects a period that is not a date", func(t *testing.T) {
+		mocker := newInterestRateMocker(t)
+
+		_, err := mocker.service.Simulate(context.Background(), SimulateParam{
+			Rate:                 3,
+			CalculationFrequency: "monthly",
+			Period:               "2026-02-30",
+		})
+
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, "period must be a date (YYYY-MM-DD)"), err)
+	})
+}
+
+func TestService_GetTaxCertificate(t *testing.T) {
+	t.Run("sums up the interest paid in the year and the tax withheld", func(t *testing.T) {
+		mocker := newInterestRateMocker(t)
+		mocker.at(time.Date(2027, 2, 1, 9, 0, 0, 0, time.UTC))
+		accountID := uuid.New()
+
+		mocker.db.EXPECT().
+			GetAccountDetailsByID(gomock.Any(), accountID).
+			Return(models.GetAccountDetailsByIDRow{AccountID: accountID, FirstName: "Jane", LastName: "Doe",
+				AccountNumber: "12345678", Currency: models.CurrencyGBP}, nil)
+		mocker.db.EXPECT().
+			GetInterestPayments(gomock.Any(), models.GetInterestPaymentsParams{
+				AccountID: accountID,
+				From:      time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+				To:        time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+			}).
+			Return([]models.GetInterestPaymentsRow{
+				{Period: sql.NullString{String: "November 2026", Valid: true}, PaidAt: sql.NullTime{Time: time.Date(2026, 12, 1, 0, 5, 0, 0, time.UTC), Valid: true},
+					Paid: 4109, Tax: 821, TaxRate: 2000},
+				// paid while the customer was exempt
+				{Period: sql.NullString{String: "December 2026", Valid: true}, PaidAt: sql.NullTime{Time: time.Date(2026, 12, 31, 0, 5, 0, 0, time.UTC), Valid: true},
+					Paid: 4245},
+			}, nil)
+
+		certificate, err := mocker.service.GetTaxCertificate(context.Background(), TaxCertificateParam{Year: 2026, AccountID: accountID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &TaxCertificate{
+			AccountID:     accountID,
+			AccountNumber: "12345678",
+			AccountHolder: "Jane Doe",
+			Currency:      "GBP",
+			Year:          2026,
+			Gross:         83.54,
+			Tax:           8.21,
+			Net:           75.33,
+			Payments: []TaxCertificatePayment{
+				{Period: "November 2026", PaidAt: time.Date(2026, 12, 1, 0, 5, 0, 0, time.UTC), Gross: 41.09, TaxRate: 20, Tax: 8.21, Net: 32.88},
+				{Period: "December 2026", PaidAt: time.Date(2026, 12, 31, 0, 5, 0, 0, time.UTC), Gross: 42.45, Net: 42.45},
+			},
+			IssuedAt: time.Date(2027, 2, 1, 9, 0, 0, 0, time.UTC),
+		}, certificate)
+	})
+
+	t.Run("rejects a year that has not started", func(t *testing.T) {
+		mocker := newInterestRateMocker(t)
+		mocker.at(time.Date(2026, 12, 31, 23, 0, 0, 0, time.UTC))
+
+		_, err := mocker.service.GetTaxCertificate(context.Background(), TaxCertificateParam{Year: 2027, AccountID: uuid.New()})
+
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, "tax year has not started"), err)
+	})
+
+	t.Run("returns not found for an unknown account", func(t *testing.T) {
+		mocker := newInterestRateMocker(t)
+		mocker.at(time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC))
+		accountID := uuid.New()
+
+		mocker.db.EXPECT().GetAccountDetailsByID(gomock.Any(), accountID).Return(models.GetAccountDetailsByIDRow{}, sql.ErrNoRows)
+
+		_, err := mocker.service.GetTaxCertificate(context.Background(), TaxCertificateParam{Year: 2026, AccountID: accountID})
+
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusNotFound, "account not found"), err)
 	})
 }
 
 func TestService_UpdateRate(t *testing.T) {
-	t.Run("successfully updates interest rate", func(t *testing.T) {
+	now := time.Date(2026, 6, 10, 9, 30, 0, 0, time.UTC)
+	today := time.Date(2026, 6, 10, 0, 0, 0, 0, time.UTC)
+
+	t.Run("replaces the current rate from today", func(t *testing.T) {
 		mocker := newInterestRateMocker(t)
+		mocker.at(now)
 
-		rateID := uuid.New()
+		currentID, newID := uuid.New(), uuid.New()
 		userID := uuid.New()
 
 		mocker.db.EXPECT().
-			GetInterestRates(gomock.Any()).
-			Return([]models.InterestRate{{
-				ID:   rateID,
-				Rate: 500, // 5%
-			}}, nil)
+			GetInterestRateAt(gomock.Any(), today).
+			Return(models.InterestRate{
+				ID:                   currentID,
+				Rate:                 500, // 5%
+				CalculationFrequency: "monthly",
+				EffectiveFrom:        time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			}, nil)
+
+		mocker.db.EXPECT().
+			SaveInterestRate(gomock.Any(), models.SaveInterestRateParams{
+				Rate:                 650, // 6.5%
+				CalculationFrequency: "monthly",
+				EffectiveFrom:        today,
+				CreatedBy:            uuid.NullUUID{UUID: userID, Valid: true},
+			}).
+			Return(models.InterestRate{ID: newID}, nil)
+
+		mocker.db.EXPECT().
+			CloseInterestRate(gomock.Any(), models.CloseInterestRateParams{
+				ID:          currentID,
+				EffectiveTo: sql.NullTime{Time: today, Valid: true},
+			}).
+			Return(nil)
 
+		expectedAuditEvent := auditlog.NewEvent(
+			auditlog.ActionInterestRateChange,
+			userID,
+			uuid.Nil,
+			auditlog.InterestRateChangeMetadata{
+				OldRate:       500,
+				NewRate:       650,
+				EffectiveFrom: "2026-06-10",
+			},
+		)
+		mocker.auditLog.EXPECT().
+			Submit(gomock.Any(), expectedAuditEvent).
+			Return(nil)
+
+		response, err := mocker.service.UpdateRate(context.Background(), UpdateRateParam{
+			UserID: userID,
+			Rate:   6.5,
+		})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, response)
+		assert.Equal(t, newID, response.InterestRateID)
+	})
+
+	t.Run("schedules a rate change ahead of a later one", func(t *testing.T) {
+		mocker := newInterestRateMocker(t)
+		mocker.at(now)
+
+		currentID, newID := uuid.New(), uuid.New()
+		userID := uuid.New()
+		effectiveFrom := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+		// a change to 4% is already scheduled for September
+		september := sql.NullTime{Time: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), Valid: true}
+
+		mocker.db.EXPECT().
+			GetInterestRateAt(gomock.Any(), effectiveFrom).
+			Return(models.InterestRate{
+				ID:                   currentID,
+				Rate:                 500,
+				CalculationFrequency: "monthly",
+				EffectiveFrom:        time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+				EffectiveTo:          september,
+			}, nil)
+
+		mocker.db.EXPECT().
+			SaveInterestRate(gomock.Any(), models.SaveInterestRateParams{
+				Rate:                 450,
+				CalculationFrequency: "monthly",
+				EffectiveFrom:        effectiveFrom,
+				EffectiveTo:          september,
+				CreatedBy:            uuid.NullUUID{UUID: userID, Valid: true},
+			}).
+			Return(models.InterestRate{ID: newID}, nil)
+
+		mocker.db.EXPECT().
+			CloseInterestRate(gomock.Any(), models.CloseInterestRateParams{
+				ID:          currentID,
+				EffectiveTo: sql.NullTime{Time: effectiveFrom, Valid: true},
+			}).
+			Return(nil)
+
+		mocker.auditLog.EXPECT().
+			Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionInterestRateChange, userID, uuid.Nil,
+				auditlog.InterestRateChangeMetadata{OldRate: 500, NewRate: 450, EffectiveFrom: "2026-07-01"})).
+			Return(nil)
+
+		response, err := mocker.service.UpdateRate(context.Background(), UpdateRateParam{
+			UserID:        userID,
+			Rate:          4.5,
+			EffectiveFrom: "2026-07-01",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, newID, response.InterestRateID)
+	})
+
+	t.Run("replaces a scheduled change on the same day", func(t *testing.T) {
+		mocker := newInterestRateMocker(t)
+		mocker.at(now)
+
+		scheduledID, newID := uuid.New(), uuid.New()
+		effectiveFrom := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+
+		mocker.db.EXPECT().
+			GetInterestRateAt(gomock.Any(), effectiveFrom).
+			Return(models.InterestRate{
+				ID:                   scheduledID,
+				Rate:                 450,
+				CalculationFrequency: "monthly",
+				EffectiveFrom:        effectiveFrom,
+			}, nil)
+
+		mocker.db.EXPECT().
+			SaveInterestRate(gomock.Any(), gomock.Any()).
+			Return(models.InterestRate{ID: newID}, nil)
 		mocker.db.EXPECT().
-			UpdateRate(gomock.Any(), models.UpdateRateParams{
-				ID:   rateID,
-				Rate: 650, // 6.5%
-			}).
+			CancelInterestRate(gomock.Any(), scheduledID).
 			Return(nil)
-		mocker.runner.EXPECT().Start(gomock.Any()).
-			Return(nil).
-			Times(1)
 
-		expectedAuditEvent := auditlog.NewEvent(
-			auditlog.ActionInterestRateChange,
-			userID,
-			uuid.Nil,
-			auditlog.InterestRateChangeMetadata{
-				OldRate: 500,
-				NewRate: 650,
-			},
-		)
-		mocker.auditLog.EXPECT().
-			Submit(gomock.Any(), expectedAuditEvent).
-			Return(nil)
+		mocker.auditLog.EXPECT().Submit(gomock.Any(), gomock.Any()).Return(nil)
 
 		response, err := mocker.service.UpdateRate(context.Background(), UpdateRateParam{
-			UserID: userID,
-			Rate:   6.5,
+			UserID:        uuid.New(),
+			Rate:          4,
+			EffectiveFrom: "2026-07-01",
 		})
 
 		assert.NoError(t, err)
-		assert.NotNil(t, response)
-		assert.Equal(t, rateID, response.InterestRateID)
+		assert.Equal(t, newID, response.InterestRateID)
+	})
+
+	t.Run("rejects a date in the past", func(t *testing.T) {
+		mocker := newInterestRateMocker(t)
+		mocker.at(now)
+
+		response, err := mocker.service.UpdateRate(context.Background(), UpdateRateParam{
+			UserID:        uuid.New(),
+			Rate:          6.5,
+			EffectiveFrom: "2026-06-09",
+		})
+
+		assert.Nil(t, response)
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, "effective_from cannot be in the past"), err)
 	})
 
 	t.Run("handles non-existent rate", func(t *testing.T) {
 		mocker := newInterestRateMocker(t)
 
 		mocker.db.EXPECT().
-			GetInterestRates(gomock.Any()).
-			Return(nil, nil)
+			GetInterestRateAt(gomock.Any(), gomock.Any()).
+			Return(models.InterestRate{}, sql.ErrNoRows)
 
 		response, err := mocker.service.UpdateRate(context.Background(), UpdateRateParam{
 			UserID: uuid.New(),
@@ -524,28 +1521,40 @@ func TestService_UpdateRate(t *testing.T) {
 			expectedError error
 		}{
 			{
-				name: "get rates error",
+				name: "get rate error",
 				setupMocks: func(m *interestRateMocker) {
 					m.db.EXPECT().
-						GetInterestRates(gomock.Any()).
-						Return(nil, sql.ErrConnDone)
+						GetInterestRateAt(gomock.Any(), gomock.Any()).
+						Return(models.InterestRate{}, sql.ErrConnDone)
 				},
 				expectedError: sql.ErrConnDone,
 			},
 			{
-				name: "update rate error",
+				name: "save rate error",
+				setupMocks: func(m *interestRateMocker) {
+					m.db.EXPECT().
+						GetInterestRateAt(gomock.Any(), gomock.Any()).
+						Return(models.InterestRate{ID: uuid.New(), Rate: 500}, nil)
+
+					m.db.EXPECT().
+						SaveInterestRate(gomock.Any(), gomock.Any()).
+						Return(models.InterestRate{}, sql.ErrConnDone)
+				},
+				expectedError: platformerrors.ErrInternal,
+			},
+			{
+				name: "close rate error",
 				setupMocks: func(m *interestRateMocker) {
-					rateID := uuid.New()
 					m.db.EXPECT().
-						GetInterestRates(gomock.Any()).
-						Return([]models.InterestRate{{
-							ID:   rateID,
-							Rate: 500,
-						}}, nil)
+						GetInterestRateAt(gomock.Any(), gomock.Any()).
+						Return(models.InterestRate{ID: uuid.New(), Rate: 500}, nil)
 
 					m.db.EXPECT().
-						UpdateRate(gomock.Any(), gomock.Any()).
-						Return(platformerrors.ErrInternal)
+						SaveInterestRate(gomock.Any(), gomock.Any()).
+						Return(models.InterestRate{ID: uuid.New()}, nil)
+					m.db.EXPECT().
+						CloseInterestRate(gomock.Any(), gomock.Any()).
+						Return(sql.ErrConnDone)
 				},
 				expectedError: platformerrors.ErrInternal,
 			},
@@ -570,24 +1579,45 @@ func TestService_UpdateRate(t *testing.T) {
 }
 
 func TestService_UpdateCalculationFrequency(t *testing.T) {
-	t.Run("successfully updates calculation frequency", func(t *testing.T) {
+	now := time.Date(2026, 6, 10, 9, 30, 0, 0, time.UTC)
+	today := time.Date(2026, 6, 10, 0, 0, 0, 0, time.UTC)
+
+	t.Run("changes the frequency from today and of scheduled changes", func(t *testing.T) {
 		mocker := newInterestRateMocker(t)
+		mocker.at(now)
 
-		rateID := uuid.New()
+		currentID, newID := uuid.New(), uuid.New()
 		userID := uuid.New()
 
 		mocker.db.EXPECT().
-			GetInterestRates(gomock.Any()).
-			Return([]models.InterestRate{{
-				ID:                   rateID,
+			GetInterestRateAt(gomock.Any(), today).
+			Return(models.InterestRate{
+				ID:                   currentID,
 				Rate:                 500,
 				CalculationFrequency: "monthly",
-			}}, nil)
+				EffectiveFrom:        time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			}, nil)
 
 		mocker.db.EXPECT().
-			UpdateCalculationFrequency(gomock.Any(), models.UpdateCalculationFrequencyParams{
-				ID:                   rateID,
-				CalculationFrequency: "weekly",
+			SaveInterestRate(gomock.Any(), models.SaveInterestRateParams{
+				Rate:                 500,
+				CalculationFrequency: "quarterly",
+				EffectiveFrom:        today,
+				CreatedBy:            uuid.NullUUID{UUID: userID, Valid: true},
+			}).
+			Return(models.InterestRate{ID: newID}, nil)
+
+		mocker.db.EXPECT().
+			CloseInterestRate(gomock.Any(), models.CloseInterestRateParams{
+				ID:          currentID,
+				EffectiveTo: sql.NullTime{Time: today, Valid: true},
+			}).
+			Return(nil)
+
+		mocker.db.EXPECT().
+			UpdateScheduledCalculationFrequency(gomock.Any(), models.UpdateScheduledCalculationFrequencyParams{
+				CalculationFrequency: "quarterly",
+				After:                today,
 			}).
 			Return(nil)
 
@@ -597,37 +1627,33 @@ func TestService_UpdateCalculationFrequency(t *testing.T) {
 			uuid.Nil,
 			auditlog.InterestRateChangeMetadata{
 				OldCalculationFrequency: "monthly",
-				NewCalculationFrequency: "weekly",
+				NewCalculationFrequency: "quarterly",
 			},
 		)
 		mocker.auditLog.EXPECT().
 			Submit(gomock.Any(), expectedAuditEvent).
 			Return(nil)
 
-		mocker.runner.EXPECT().Start(gomock.Any()).
-			Return(nil).
-			Times(1)
-
 		response, err := mocker.service.UpdateCalculationFrequency(context.Background(), UpdateCalculationFrequencyParam{
 			UserID:               userID,
-			CalculationFrequency: "weekly",
+			CalculationFrequency: "quarterly",
 		})
 
 		assert.NoError(t, err)
 		assert.NotNil(t, response)
-		assert.Equal(t, rateID, response.InterestRateID)
+		assert.Equal(t, newID, response.InterestRateID)
 	})
 
 	t.Run("handles non-existent rate", func(t *testing.T) {
 		mocker := newInterestRateMocker(t)
 
 		mocker.db.EXPECT().
-			GetInterestRates(gomock.Any()).
-			Return(nil, nil)
+			GetInterestRateAt(gomock.Any(), gomock.Any()).
+			Return(models.InterestRate{}, sql.ErrNoRows)
 
 		response, err := mocker.service.UpdateCalculationFrequency(context.Background(), UpdateCalculationFrequencyParam{
 			UserID:               uuid.New(),
-			CalculationFrequency: "monthly",
+			CalculationFrequency: "quarterly",
 		})
 
 		assert.Error(t, err)
@@ -642,28 +1668,30 @@ func TestService_UpdateCalculationFrequency(t *testing.T) {
 			expectedError error
 		}{
 			{
-				name: "get rates error",
+				name: "get rate error",
 				setupMocks: func(m *interestRateMocker) {
 					m.db.EXPECT().
-						GetInterestRates(gomock.Any()).
-						Return(nil, sql.ErrConnDone)
+						GetInterestRateAt(gomock.Any(), gomock.Any()).
+						Return(models.InterestRate{}, sql.ErrConnDone)
 				},
 				expectedError: sql.ErrConnDone,
 			},
 			{
-				name: "update frequency error",
+				name: "update scheduled rates error",
 				setupMocks: func(m *interestRateMocker) {
-					rateID := uuid.New()
 					m.db.EXPECT().
-						GetInterestRates(gomock.Any()).
-						Return([]models.InterestRate{{
-							ID:                   rateID,
-							CalculationFrequency: "monthly",
-						}}, nil)
+						GetInterestRateAt(gomock.Any(), gomock.Any()).
+						Return(models.InterestRate{ID: uuid.New(), CalculationFrequency: "monthly"}, nil)
 
 					m.db.EXPECT().
-						UpdateCalculationFrequency(gomock.Any(), gomock.Any()).
-						Return(platformerrors.ErrInternal)
+						SaveInterestRate(gomock.Any(), gomock.Any()).
+						Return(models.InterestRate{ID: uuid.New()}, nil)
+					m.db.EXPECT().
+						CloseInterestRate(gomock.Any(), gomock.Any()).
+						Return(nil)
+					m.db.EXPECT().
+						UpdateScheduledCalculationFrequency(gomock.Any(), gomock.Any()).
+						Return(sql.ErrConnDone)
 				},
 				expectedError: platformerrors.ErrInternal,
 			},
@@ -676,7 +1704,7 @@ func TestService_UpdateCalculationFrequency(t *testing.T) {
 
 				response, err := mocker.service.UpdateCalculationFrequency(context.Background(), UpdateCalculationFrequencyParam{
 					UserID:               uuid.New(),
-					CalculationFrequency: "weekly",
+					CalculationFrequency: "quarterly",
 				})
 
 				assert.Error(t, err)
@@ -695,8 +1723,8 @@ func TestService_UpdateCalculationFrequency(t *testing.T) {
 		}
 
 		mocker.db.EXPECT().
-			GetInterestRates(gomock.Any()).
-			Return([]models.InterestRate{rate}, nil)
+			GetInterestRateAt(gomock.Any(), gomock.Any()).
+			Return(rate, nil)
 
 		response, err := mocker.service.UpdateCalculationFrequency(context.Background(), UpdateCalculationFrequencyParam{
 			UserID:               uuid.New(),
@@ -708,28 +1736,654 @@ func TestService_UpdateCalculationFrequency(t *testing.T) {
 	})
 }
 
+func TestService_GetRateHistory(t *testing.T) {
+	mocker := newInterestRateMocker(t)
+	mocker.at(time.Date(2026, 6, 10, 9, 30, 0, 0, time.UTC))
+
+	scheduled := models.InterestRate{
+		ID:                   uuid.New(),
+		Rate:                 450,
+		CalculationFrequency: "monthly",
+		EffectiveFrom:        time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC),
+	}
+	current := models.InterestRate{
+		ID:                   uuid.New(),
+		Rate:                 500,
+		CalculationFrequency: "monthly",
+		EffectiveFrom:        time.Date(2026, 6, 10, 0, 0, 0, 0, time.UTC),
+		EffectiveTo:          sql.NullTime{Time: scheduled.EffectiveFrom, Valid: true},
+	}
+	past := models.InterestRate{
+		ID:                   uuid.New(),
+		Rate:                 550,
+		CalculationFrequency: "monthly",
+		EffectiveFrom:        time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		EffectiveTo:          sql.NullTime{Time: current.EffectiveFrom, Valid: true},
+	}
+
+	mocker.db.EXPECT().
+		GetInterestRates(gomock.Any()).
+		Return([]models.InterestRate{scheduled, current, past}, nil)
+
+	history, err := mocker.service.GetRateHistory(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, history, 3)
+	assert.Equal(t, []string{"scheduled", "current", "past"},
+		[]string{history[0].Status, history[1].Status, history[2].Status})
+	assert.Equal(t, 4.5, history[0].Rate)
+	assert.Equal(t, "2026-07-01", history[1].EffectiveTo)
+	assert.Equal(t, "2026-01-01", history[2].EffectiveFrom)
+}
+
+func TestService_CreateRateCard(t *testing.T) {
+	userID := uuid.New()
+	param := CreateRateCardParam{
+		UserID:   userID,
+		Currency: "GBP",
+		Product:  "CURRENT",
+		RateCardTerms: RateCardTerms{
+			Method:            "tiered",
+			Bands:             []RateBand{{MinBalance: 0, Rate: 1}, {MinBalance: 10000, Rate: 2}},
+			BonusRate:         0.5,
+			BonusConditions:   []Condition{MonthlyDeposit},
+			MinMonthlyDeposit: 500,
+		},
+	}
+
+	t.Run("creates and audits a rate card", func(t *testing.T) {
+		mocker := newInterestRateMocker(t)
+		cardID := uuid.New()
+
+		mocker.db.EXPECT().
+			CreateInterestRateCard(gomock.Any(), models.CreateInterestRateCardParams{
+				Currency:          "GBP",
+				Product:           "CURRENT",
+				Method:            "tiered",
+				Bands:             json.RawMessage(`[{"from":0,"rate":100},{"from":1000000,"rate":200}]`),
+				BonusRate:         50,
+				BonusConditions:   []string{"monthly_deposit"},
+				MinMonthlyDeposit: 50000,
+				CreatedBy:         userID,
+			}).
+			DoAndReturn(func(_ context.Context, arg models.CreateInterestRateCardParams) (models.InterestRateCard, error) {
+				return models.InterestRateCard{
+					ID:                cardID,
+					Currency:          arg.Currency,
+					Product:           arg.Product,
+					Method:            arg.Method,
+					Bands:             arg.Bands,
+					BonusRate:         arg.BonusRate,
+					BonusConditions:   arg.BonusConditions,
+					MinMonthlyDeposit: arg.MinMonthlyDeposit,
+					CreatedBy:         arg.CreatedBy,
+				}, nil
+			})
+		mocker.auditLog.EXPECT().
+			Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionRateCardChange, userID, uuid.Nil,
+				auditlog.InterestRateCardChangeMetadata{
+					CardID:   cardID,
+					Currency: "GBP",
+					Product:  "CURRENT",
+					New:      param.RateCardTerms,
+				})).
+			Return(nil)
+
+		card, err := mocker.service.CreateRateCard(context.Background(), param)
+
+		assert.NoError(t, err)
+		assert.Equal(t, cardID, card.ID)
+		assert.Equal(t, param.RateCardTerms, card.RateCardTerms)
+	})
+
+	t.Run("rejects invalid terms", func(t *testing.T) {
+		testCases := []struct {
+			name  string
+			terms func(*RateCardTerms)
+			err   string
+		}{
+			{"bands not starting at 0", func(t *RateCardTerms) { t.Bands[0].MinBalance = 100 }, "bands must start at 0 and increase"},
+			{"bands not increasing", func(t *RateCardTerms) { t.Bands[1].MinBalance = 0 }, "bands must start at 0 and increase"},
+			{"monthly deposit without minimum", func(t *RateCardTerms) { t.MinMonthlyDeposit = 0 },
+				"min_monthly_deposit is required for the monthly_deposit condition"},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				mocker := newInterestRateMocker(t)
+
+				invalid := param
+				invalid.Bands = append([]RateBand(nil), param.Bands...)
+				tc.terms(&invalid.RateCardTerms)
+
+				_, err := mocker.service.CreateRateCard(context.Background(), invalid)
+
+				assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, tc.err), err)
+			})
+		}
+	})
+
+	t.Run("rejects a second card for the same currency and product", func(t *testing.T) {
+		mocker := newInterestRateMocker(t)
+
+		mocker.db.EXPECT().
+			CreateInterestRateCard(gomock.Any(), gomock.Any()).
+			Return(models.InterestRateCard{}, &pq.Error{Code: "23505", Constraint: rateCardConstraint})
+
+		_, err := mocker.service.CreateRateCard(context.Background(), param)
+
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusConflict, "a rate card already exists for GBP CURRENT accounts"), err)
+	})
+}
+
+func TestService_UpdateRateCard(t *testing.T) {
+	t.Run("replaces the terms and audits old and new", func(t *testing.T) {
+		mocker := newInterestRateMocker(t)
+		userID, cardID := uuid.New(), uuid.New()
+
+		old := testRateCard(cardID)
+		terms := RateCardTerms{
+			Method:          "whole_balance",
+			Bands:           []RateBand{{MinBalance: 0, Rate: 1.5}},
+			BonusConditions: []Condition{},
+		}
+
+		mocker.db.EXPECT().GetInterestRateCard(gomock.Any(), cardID).Return(old, nil)
+		updated := old
+		updated.Method = "whole_balance"
+		updated.Bands = json.RawMessage(`[{"from":0,"rate":150}]`)
+		updated.BonusRate = 0
+		updated.BonusConditions = []string{}
+		mocker.db.EXPECT().
+			UpdateInterestRateCard(gomock.Any(), models.UpdateInterestRateCardParams{
+				ID:              cardID,
+				Method:          "whole_balance",
+				Bands:           updated.Bands,
+				BonusConditions: []string{},
+			}).
+			Return(updated, nil)
+
+		oldCard, err := rateCardFromModel(old)
+		assert.NoError(t, err)
+		mocker.auditLog.EXPECT().
+			Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionRateCardChange, userID, uuid.Nil,
+				auditlog.InterestRateCardChangeMetadata{
+					CardID:   cardID,
+					Currency: "GBP",
+					Product:  "CURRENT",
+					Old:      oldCard.RateCardTerms,
+					New:      terms,
+				})).
+			Return(nil)
+
+		card, err := mocker.service.UpdateRateCard(context.Background(), UpdateRateCardParam{
+			UserID:        userID,
+			CardID:        cardID,
+			RateCardTerms: terms,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, terms, card.RateCardTerms)
+	})
+
+	t.Run("returns not found for an unknown card", func(t *testing.T) {
+		mocker := newInterestRateMocker(t)
+
+		mocker.db.EXPECT().GetInterestRateCard(gomock.Any(), gomock.Any()).Return(models.InterestRateCard{}, sql.ErrNoRows)
+
+		_, err := mocker.service.UpdateRateCard(context.Background(), UpdateRateCardParam{CardID: uuid.New()})
+
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusNotFound, "rate card not found"), err)
+	})
+}
+
+func TestService_DeleteRateCard(t *testing.T) {
+	mocker := newInterestRateMocker(t)
+	userID, cardID := uuid.New(), uuid.New()
+
+	card := testRateCard(cardID)
+	mocker.db.EXPECT().GetInterestRateCard(gomock.Any(), cardID).Return(card, nil)
+	mocker.db.EXPECT().DeleteInterestRateCard(gomock.Any(), cardID).Return(int64(1), nil)
+
+	old, err := rateCardFromModel(card)
+	assert.NoError(t, err)
+	mocker.auditLog.EXPECT().
+		Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionRateCardChange, userID, uuid.Nil,
+			auditlog.InterestRateCardChangeMetadata{
+				CardID:   cardID,
+				Currency: "GBP",
+				Product:  "CURRENT",
+				Old:      old.RateCardTerms,
+			})).
+		Return(nil)
+
+	err = mocker.service.DeleteRateCard(context.Background(), DeleteRateCardParam{UserID: userID, CardID: cardID})
+
+	assert.NoError(t, err)
+}
+
+func TestService_CreateRateOverride(t *testing.T) {
+	userID, accountID := uuid.New(), uuid.New()
+	param := CreateRateOverrideParam{
+		UserID:    userID,
+		AccountID: accountID,
+		RateOverrideTerms: RateOverrideTerms{
+			Kind:     BoostOverride,
+			Rate:     1,
+			StartsOn: "2026-05-01",
+			EndsOn:   "2026-07-31",
+			Reason:   "Spring promotion",
+		},
+	}
+	startsOn := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	endsOn := sql.NullTime{Time: time.Date(2026, 7, 31, 0, 0, 0, 0, time.UTC), Valid: true}
+
+	t.Run("creates and audits a boost", func(t *testing.T) {
+		mocker := newInterestRateMocker(t)
+		mocker.at(time.Date(2026, 4, 15, 10, 0, 0, 0, time.UTC))
+		overrideID := uuid.New()
+
+		mocker.db.EXPECT().GetAccountByID(gomock.Any(), accountID).Return(models.GetAccountByIDRow{ID: accountID}, nil)
+		mocker.db.EXPECT().
+			CountOverlappingInterestRateOverrides(gomock.Any(), models.CountOverlappingInterestRateOverridesParams{
+				AccountID: accountID,
+				Kind:      "BOOST",
+				ID:        uuid.Nil,
+				EndsOn:    endsOn,
+				StartsOn:  startsOn,
+			}).
+			Return(int64(0), nil)
+		mocker.db.EXPECT().
+			CreateInterestRateOverride(gomock.Any(), models.CreateInterestRateOverrideParams{
+				AccountID: accountID,
+				Kind:      "BOOST",
+				Rate:      100,
+				StartsOn:  startsOn,
+				EndsOn:    endsOn,
+				Reason:    "Spring promotion",
+				CreatedBy: userID,
+			}).
+			DoAndReturn(func(_ context.Context, arg models.CreateInterestRateOverrideParams) (models.InterestRateOverride, error) {
+				return models.InterestRateOverride{
+					ID:        overrideID,
+					AccountID: arg.AccountID,
+					Kind:      arg.Kind,
+					Rate:      arg.Rate,
+					StartsOn:  arg.StartsOn,
+					EndsOn:    arg.EndsOn,
+					Reason:    arg.Reason,
+					CreatedBy: arg.CreatedBy,
+				}, nil
+			})
+		mocker.auditLog.EXPECT().
+			Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionRateOverrideChange, userID, accountID,
+				auditlog.InterestRateOverrideChangeMetadata{
+					OverrideID:  overrideID,
+					NewKind:     "BOOST",
+					NewRate:     100,
+					NewStartsOn: "2026-05-01",
+					NewEndsOn:   "2026-07-31",
+					Reason:      "Spring promotion",
+				})).
+			Return(nil)
+
+		override, err := mocker.service.CreateRateOverride(context.Background(), param)
+
+		assert.NoError(t, err)
+		assert.Equal(t, overrideID, override.ID)
+		assert.Equal(t, param.RateOverrideTerms, override.RateOverrideTerms)
+		assert.Equal(t, "scheduled", override.Status)
+	})
+
+	t.Run("rejects invalid terms", func(t *testing.T) {
+		testCases := []struct {
+			name  string
+			terms func(*RateOverrideTerms)
+			err   string
+		}{
+			{"boost of nothing", func(t *RateOverrideTerms) { t.Rate = 0 }, "a boost must add to the rate"},
+			{"end before start", func(t *RateOverrideTerms) { t.EndsOn = "2026-04-30" }, "an override cannot end before it starts"},
+			{"start in the past", func(t *RateOverrideTerms) { t.StartsOn = "2026-04-14" }, "an override cannot start in the past"},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				mocker := newInterestRateMocker(t)
+				mocker.at(time.Date(2026, 4, 15, 10, 0, 0, 0, time.UTC))
+				mocker.db.EXPECT().GetAccountByID(gomock.Any(), accountID).Return(models.GetAccountByIDRow{}, nil)
+
+				invalid := param
+				tc.terms(&invalid.RateOverrideTerms)
+
+				_, err := mocker.service.CreateRateOverride(context.Background(), invalid)
+
+				assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, tc.err), err)
+			})
+		}
+	})
+
+	t.Run("rejects an override that overlaps another of its kind", func(t *testing.T) {
+		mocker := newInterestRateMocker(t)
+		mocker.at(time.Date(2026, 4, 15, 10, 0, 0, 0, time.UTC))
+
+		mocker.db.EXPECT().GetAccountByID(gomock.Any(), accountID).Return(models.GetAccountByIDRow{}, nil)
+		mocker.db.EXPECT().CountOverlappingInterestRateOverrides(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+
+		_, err := mocker.service.CreateRateOverride(context.Background(), param)
+
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusConflict, "the account already has a BOOST override on some of these days"), err)
+	})
+
+	t.Run("returns not found for an unknown account", func(t *testing.T) {
+		mocker := newInterestRateMocker(t)
+
+		mocker.db.EXPECT().GetAccountByID(gomock.Any(), accountID).Return(models.GetAccountByIDRow{}, sql.ErrNoRows)
+
+		_, err := mocker.service.CreateRateOverride(context.Background(), param)
+
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusNotFound, "account not found"), err)
+	})
+}
+
+func TestService_UpdateRateOverride(t *testing.T) {
+	userID, accountID, overrideID := uuid.New(), uuid.New(), uuid.New()
+	active := models.InterestRateOverride{
+		ID:        overrideID,
+		AccountID: accountID,
+		Kind:      "FIXED",
+		Rate:      300,
+		StartsOn:  time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+		Reason:    "Retention offer",
+	}
+
+	t.Run("ends an override that has started and audits old and new", func(t *testing.T) {
+		mocker := newInterestRateMocker(t)
+		mocker.at(time.Date(2026, 4, 15, 10, 0, 0, 0, time.UTC))
+		terms := RateOverrideTerms{Kind: FixedOverride, Rate: 3.25, StartsOn: "2026-04-01", EndsOn: "2026-04-30", Reason: "Retention offer"}
+		endsOn := sql.NullTime{Time: time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC), Valid: true}
+
+		mocker.db.EXPECT().
+			GetInterestRateOverride(gomock.Any(), models.GetInterestRateOverrideParams{ID: overrideID, AccountID: accountID}).
+			Return(active, nil)
+		mocker.db.EXPECT().
+			CountOverlappingInterestRateOverrides(gomock.Any(), models.CountOverlappingInterestRateOverridesParams{
+				AccountID: accountID,
+				Kind:      "FIXED",
+				ID:        overrideID,
+				EndsOn:    endsOn,
+				StartsOn:  active.StartsOn,
+			}).
+			Return(int64(0), nil)
+		updated := active
+		updated.Rate = 325
+		updated.EndsOn = endsOn
+		mocker.db.EXPECT().
+			UpdateInterestRateOverride(gomock.Any(), models.UpdateInterestRateOverrideParams{
+				ID:       overrideID,
+				Kind:     "FIXED",
+				Rate:     325,
+				StartsOn: active.StartsOn,
+				EndsOn:   endsOn,
+				Reason:   "Retention offer",
+			}).
+			Return(updated, nil)
+		mocker.auditLog.EXPECT().
+			Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionRateOverrideChange, userID, accountID,
+				auditlog.InterestRateOverrideChangeMetadata{
+					OverrideID:  overrideID,
+					OldKind:     "FIXED",
+					OldRate:     300,
+					OldStartsOn: "2026-04-01",
+					NewKind:     "FIXED",
+					NewRate:     325,
+					NewStartsOn: "2026-04-01",
+					NewEndsOn:   "2026-04-30",
+					Reason:      "Retention offer",
+				})).
+			Return(nil)
+
+		override, err := mocker.service.UpdateRateOverride(context.Background(), UpdateRateOverrideParam{
+			UserID:            userID,
+			AccountID:         accountID,
+			OverrideID:        overrideID,
+			RateOverrideTerms: terms,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, terms, override.RateOverrideTerms)
+		assert.Equal(t, "active", override.Status)
+	})
+
+	t.Run("rejects changes to the days that have been accrued", func(t *testing.T) {
+		ended := active
+		ended.EndsOn = sql.NullTime{Time: time.Date(2026, 4, 10, 0, 0, 0, 0, time.UTC), Valid: true}
+
+		testCases := []struct {
+			name     string
+			override models.InterestRateOverride
+			terms    RateOverrideTerms
+			err      string
+		}{
+			{"moving the start of an override that has started", active,
+				RateOverrideTerms{Kind: FixedOverride, Rate: 3, StartsOn: "2026-04-20"},
+				"the start of an override that has started cannot change"},
+			{"ending an override before yesterday", active,
+				RateOverrideTerms{Kind: FixedOverride, Rate: 3, StartsOn: "2026-04-01", EndsOn: "2026-04-13"},
+				"an override cannot end before yesterday"},
+			{"changing an override that has ended", ended,
+				RateOverrideTerms{Kind: FixedOverride, Rate: 3, StartsOn: "2026-04-01"},
+				"override has ended"},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				mocker := newInterestRateMocker(t)
+				mocker.at(time.Date(2026, 4, 15, 10, 0, 0, 0, time.UTC))
+				mocker.db.EXPECT().GetInterestRateOverride(gomock.Any(), gomock.Any()).Return(tc.override, nil)
+
+				_, err := mocker.service.UpdateRateOverride(context.Background(), UpdateRateOverrideParam{
+					AccountID:         accountID,
+					OverrideID:        overrideID,
+					RateOverrideTerms: tc.terms,
+				})
+
+				assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, tc.err), err)
+			})
+		}
+	})
+
+	t.Run("returns not found for an override of another account", func(t *testing.T) {
+		mocker := newInterestRateMocker(t)
+
+		mocker.db.EXPECT().GetInterestRateOverride(gomock.Any(), gomock.Any()).Return(models.InterestRateOverride{}, sql.ErrNoRows)
+
+		_, err := mocker.service.UpdateRateOverride(context.Background(), UpdateRateOverrideParam{AccountID: uuid.New(), OverrideID: overrideID})
+
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusNotFound, "rate override not found"), err)
+	})
+}
+
+func TestService_DeleteRateOverride(t *testing.T) {
+	userID, accountID, overrideID := uuid.New(), uuid.New(), uuid.New()
+	scheduled := models.InterestRateOverride{
+		ID:        overrideID,
+		AccountID: accountID,
+		Kind:      "BOOST",
+		Rate:      100,
+		StartsOn:  time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("deletes and audits an override that has not started", func(t *testing.T) {
+		mocker := newInterestRateMocker(t)
+		mocker.at(time.Date(2026, 4, 15, 10, 0, 0, 0, time.UTC))
+
+		mocker.db.EXPECT().GetInterestRateOverride(gomock.Any(), gomock.Any()).Return(scheduled, nil)
+		mocker.db.EXPECT().DeleteInterestRateOverride(gomock.Any(), overrideID).Return(int64(1), nil)
+		mocker.auditLog.EXPECT().
+			Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionRateOverrideChange, userID, accountID,
+				auditlog.InterestRateOverrideChangeMetadata{
+					OverrideID:  overrideID,
+					OldKind:     "BOOST",
+					OldRate:     100,
+					OldStartsOn: "2026-05-01",
+				})).
+			Return(nil)
+
+		err := mocker.service.DeleteRateOverride(context.Background(), DeleteRateOverrideParam{
+			UserID:     userID,
+			AccountID:  accountID,
+			OverrideID: overrideID,
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects an override that has started", func(t *testing.T) {
+		mocker := newInterestRateMocker(t)
+		mocker.at(time.Date(2026, 5, 2, 10, 0, 0, 0, time.UTC))
+
+		mocker.db.EXPECT().GetInterestRateOverride(gomock.Any(), gomock.Any()).Return(scheduled, nil)
+
+		err := mocker.service.DeleteRateOverride(context.Background(), DeleteRateOverrideParam{
+			UserID:     userID,
+			AccountID:  accountID,
+			OverrideID: overrideID,
+		})
+
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, "override has started, end it instead"), err)
+	})
+}
+
+func TestService_ListRateOverrides(t *testing.T) {
+	mocker := newInterestRateMocker(t)
+	mocker.at(time.Date(2026, 4, 15, 10, 0, 0, 0, time.UTC))
+	accountID := uuid.New()
+
+	mocker.db.EXPECT().
+		ListAccountInterestRateOverrides(gomock.Any(), accountID).
+		Return([]models.InterestRateOverride{
+			{Kind: "BOOST", Rate: 100, StartsOn: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)},
+			{Kind: "FIXED", Rate: 250, StartsOn: time.Date(2026, 4, 15, 0, 0, 0, 0, time.UTC)},
+			{
+				Kind:     "BOOST",
+				Rate:     50,
+				StartsOn: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+				EndsOn:   sql.NullTime{Time: time.Date(2026, 4, 14, 0, 0, 0, 0, time.UTC), Valid: true},
+			},
+		}, nil)
+
+	overrides, err := mocker.service.ListRateOverrides(context.Background(), accountID)
+
+	assert.NoError(t, err)
+	assert.Len(t, overrides, 3)
+	assert.Equal(t, "scheduled", overrides[0].Status)
+	assert.Equal(t, "active", overrides[1].Status)
+	assert.Equal(t, 2.5, overrides[1].Rate)
+	assert.Equal(t, "ended", overrides[2].Status)
+	assert.Equal(t, "2026-04-14", overrides[2].EndsOn)
+}
+
+// testRateCard is a tiered GBP card paying 1% up to 10,000.00 and 2% above, with a 0.5% bonus for
+// months without withdrawals.
+func testRateCard(id uuid.UUID) models.InterestRateCard {
+	return models.InterestRateCard{
+		ID:              id,
+		Currency:        "GBP",
+		Product:         "CURRENT",
+		Method:          "tiered",
+		Bands:           json.RawMessage(`[{"from":0,"rate":100},{"from":1000000,"rate":200}]`),
+		BonusRate:       50,
+		BonusConditions: []string{"no_withdrawals"},
+	}
+}
+
 type interestRateMocker struct {
-	db       *databasemocks.MockQuerier
-	auditLog *auditlog.MockService
-	runner   *MockRunner
+	db           *databasemocks.MockQuerier
+	auditLog     *auditlog.MockService
+	notification *notification.MockService
+	jobs         *MockScheduler
 
 	service Service
 }
 
+var (
+	systemUserID = uuid.MustParse("00000000-1111-1111-1111-000000000000")
+	taxUserID    = uuid.MustParse("00000000-3333-3333-3333-000000000000")
+	taxAccountID = uuid.MustParse("00000000-3333-3333-3333-000000000000")
+)
+
 func newInterestRateMocker(t *testing.T) *interestRateMocker {
 	ctrl := gomock.NewController(t)
 	db := databasemocks.NewMockQuerier(ctrl)
 	auditLog := auditlog.NewMockService(ctrl)
-	runnerMock := NewMockRunner(ctrl)
-	cfg := config.AppConfig{
-		InterestRateAccountID: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
+	notificationService := notification.NewMockService(ctrl)
+	jobs := NewMockScheduler(ctrl)
+	cfg := config.Config{
+		App: config.AppConfig{
+			SystemUserID:          systemUserID,
+			InterestRateAccountID: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
+			TaxUserID:             taxUserID,
+		},
+		Interest: config.InterestConfig{
+			Schedule: "5 0 * * *",
+		},
 	}
 
-	svc := NewService(db, cfg, auditLog, runnerMock)
+	calculator, err := interest.NewCalculator(config.InterestConfig{
+		DayCount:       "ACT/365",
+		Rounding:       "half_even",
+		TaxRates:       map[string]string{"GB": "2000", "IE": "3300"},
+		TaxDefaultRate: 2000,
+	})
+	assert.NoError(t, err)
+
+	svc := NewService(db, cfg, auditLog, notificationService, jobs, calculator)
 	return &interestRateMocker{
-		db:       db,
-		auditLog: auditLog,
-		service:  svc,
-		runner:   runnerMock,
+		db:           db,
+		auditLog:     auditLog,
+		notification: notificationService,
+		service:      svc,
+		jobs:         jobs,
 	}
 }
+
+// at fixes the time the service sees as now.
+func (m *interestRateMocker) at(now time.Time) {
+	m.service.(*service).now = func() time.Time { return now }
+}
+
+// caughtUp expects every day to have been run.
+func (m *interestRateMocker) caughtUp() {
+	m.db.EXPECT().GetUnfinishedInterestRuns(gomock.Any()).Return(nil, nil).AnyTimes()
+	m.db.EXPECT().
+		GetLastInterestRun(gomock.Any()).
+		DoAndReturn(func(context.Context) (models.InterestRun, error) {
+			return models.InterestRun{RunDate: m.service.(*service).today().AddDate(0, 0, -1)}, nil
+		}).
+		AnyTimes()
+}
+
+// expectRun expects day to be the only day to run, with rate in effect on it, and returns the run.
+// Accounts are claimed with their own ID as the ID of their result.
+func (m *interestRateMocker) expectRun(day time.Time, rate models.InterestRate) models.InterestRun {
+	run := models.InterestRun{ID: uuid.New(), RunDate: day, RateID: rate.ID, Status: "RUNNING"}
+	frequency := Frequency(rate.CalculationFrequency)
+	if frequency.periodEnds(day) {
+		run.Period = sql.NullString{String: frequency.periodName(day), Valid: true}
+	}
+
+	m.db.EXPECT().GetUnfinishedInterestRuns(gomock.Any()).Return(nil, nil)
+	m.db.EXPECT().GetLastInterestRun(gomock.Any()).Return(models.InterestRun{RunDate: day.AddDate(0, 0, -1)}, nil)
+	m.db.EXPECT().GetInterestRateAt(gomock.Any(), day).Return(rate, nil)
+	m.db.EXPECT().
+		StartInterestRun(gomock.Any(), models.StartInterestRunParams{RunDate: day, RateID: rate.ID, Period: run.Period}).
+		Return(run, nil)
+	m.db.EXPECT().
+		ClaimInterestRunAccount(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg models.ClaimInterestRunAccountParams) (models.InterestRunAccount, error) {
+			return models.InterestRunAccount{ID: arg.AccountID, RunID: arg.RunID, AccountID: arg.AccountID, RunDate: arg.RunDate}, nil
+		}).
+		AnyTimes()
+	m.db.EXPECT().FinishInterestRun(gomock.Any(), run.ID).Return(run, nil)
+	return run
+}
@@ -1,33 +1,615 @@
 package interestrate
 
-import "github.com/google/uuid"
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"math"
+	"math/big"
+	"net/http"
+	"payter-bank/internal/database/models"
+	platformerrors "payter-bank/internal/errors"
+	"payter-bank/internal/pkg/interest"
+	"time"
+)
 
+// Frequency is how often accrued interest is capitalised, interest itself accrues daily.
 type Frequency string
 
 const (
-	Hourly  Frequency = "hourly"
-	Daily   Frequency = "daily"
-	Weekly  Frequency = "weekly"
-	Monthly Frequency = "monthly"
-	Yearly  Frequency = "yearly"
+	Monthly   Frequency = "monthly"
+	Quarterly Frequency = "quarterly"
+	Yearly    Frequency = "yearly"
 )
 
+func (f Frequency) valid() bool {
+	return f == Monthly || f == Quarterly || f == Yearly
+}
+
+// periodEnds reports whether day is the last day of a capitalisation period.
+func (f Frequency) periodEnds(day time.Time) bool {
+	next := day.AddDate(0, 0, 1)
+	switch f {
+	case Monthly:
+		return next.Day() == 1
+	case Quarterly:
+		return next.Day() == 1 && (next.Month()-1)%3 == 0
+	case Yearly:
+		return next.YearDay() == 1
+	default:
+		return false
+	}
+}
+
+// periodStart returns the first day of the capitalisation period day falls in.
+func (f Frequency) periodStart(day time.Time) time.Time {
+	switch f {
+	case Quarterly:
+		return time.Date(day.Year(), (day.Month()-1)/3*3+1, 1, 0, 0, 0, 0, time.UTC)
+	case Yearly:
+		return time.Date(day.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// periodName names the period day falls in, it is used in the description of interest payments.
+func (f Frequency) periodName(day time.Time) string {
+	switch f {
+	case Quarterly:
+		return fmt.Sprintf("Q%d %d", (day.Month()-1)/3+1, day.Year())
+	case Yearly:
+		return fmt.Sprintf("%d", day.Year())
+	default:
+		return day.Format("January 2006")
+	}
+}
+
 type CreateInterestRateParam struct {
 	UserID               uuid.UUID
 	Rate                 float64 `json:"rate" binding:"required,gt=0"`
-	CalculationFrequency string  `json:"calculation_frequency" binding:"required,oneof=hourly daily weekly monthly yearly"`
+	CalculationFrequency string  `json:"calculation_frequency" binding:"required,oneof=monthly quarterly yearly"`
 }
 
+// UpdateRateParam changes the rate from EffectiveFrom (YYYY-MM-DD), today when it is empty. A later
+// date schedules the change.
 type UpdateRateParam struct {
-	UserID uuid.UUID
-	Rate   float64 `json:"rate" binding:"required,gte=0"`
+	UserID        uuid.UUID
+	Rate          float64 `json:"rate" binding:"required,gte=0"`
+	EffectiveFrom string  `json:"effective_from" binding:"omitempty,datetime=2006-01-02"`
 }
 
 type UpdateCalculationFrequencyParam struct {
 	UserID               uuid.UUID
-	CalculationFrequency string `json:"calculation_frequency" binding:"required,oneof=hourly daily weekly monthly yearly"`
+	CalculationFrequency string `json:"calculation_frequency" binding:"required,oneof=monthly quarterly yearly"`
+}
+
+// SimulateParam is a candidate global rate and calculation frequency to simulate over the
+// capitalisation period Period (YYYY-MM-DD) falls in.
+type SimulateParam struct {
+	Rate                 float64 `json:"rate" binding:"required,gte=0"`
+	CalculationFrequency string  `json:"calculation_frequency" binding:"required,oneof=monthly quarterly yearly"`
+	Period               string  `json:"period" binding:"required,datetime=2006-01-02"`
+}
+
+// Simulation is the interest the active accounts would earn over a period, from From to To
+// inclusive, at a candidate rate. Amounts are in major units: Interest is what accrues and Paid what
+// would be paid at the end of the period, rounded to a minor unit.
+type Simulation struct {
+	Rate                 float64            `json:"rate"`
+	CalculationFrequency string             `json:"calculation_frequency"`
+	Period               string             `json:"period"`
+	From                 string             `json:"from"`
+	To                   string             `json:"to"`
+	Accounts             []SimulatedAccount `json:"accounts"`
+	Totals               []SimulatedTotal   `json:"totals"`
+}
+
+// SimulatedAccount is the interest of an account in a simulation. CardID is the rate card the
+// account earns under, it does not earn the candidate rate when it is set.
+type SimulatedAccount struct {
+	AccountID uuid.UUID  `json:"account_id"`
+	Currency  string     `json:"currency"`
+	CardID    *uuid.UUID `json:"card_id,omitempty"`
+	Interest  float64    `json:"interest"`
+	Paid      float64    `json:"paid"`
+}
+
+// SimulatedTotal is the interest of the accounts of a currency in a simulation.
+type SimulatedTotal struct {
+	Currency string  `json:"currency"`
+	Accounts int     `json:"accounts"`
+	Interest float64 `json:"interest"`
+	Paid     float64 `json:"paid"`
 }
 
 type Response struct {
 	InterestRateID uuid.UUID `json:"interest_rate_id"`
 }
+
+// RateVersion is a rate and the days it is in effect, from EffectiveFrom up to but not including
+// EffectiveTo. Status is scheduled, current or past.
+type RateVersion struct {
+	ID                   uuid.UUID  `json:"id"`
+	Rate                 float64    `json:"rate"`
+	CalculationFrequency string     `json:"calculation_frequency"`
+	EffectiveFrom        string     `json:"effective_from"`
+	EffectiveTo          string     `json:"effective_to,omitempty"`
+	Status               string     `json:"status"`
+	CreatedBy            *uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt            time.Time  `json:"created_at"`
+}
+
+func rateVersionFromModel(rate models.InterestRate, today time.Time) RateVersion {
+	v := RateVersion{
+		ID:                   rate.ID,
+		Rate:                 float64(rate.Rate) / 100,
+		CalculationFrequency: rate.CalculationFrequency,
+		EffectiveFrom:        rate.EffectiveFrom.Format(time.DateOnly),
+		Status:               "current",
+		CreatedAt:            rate.CreatedAt.Time,
+	}
+	if rate.EffectiveTo.Valid {
+		v.EffectiveTo = rate.EffectiveTo.Time.Format(time.DateOnly)
+		if !rate.EffectiveTo.Time.After(today) {
+			v.Status = "past"
+		}
+	}
+	if rate.EffectiveFrom.After(today) {
+		v.Status = "scheduled"
+	}
+	if rate.CreatedBy.Valid {
+		v.CreatedBy = &rate.CreatedBy.UUID
+	}
+	return v
+}
+
+// Condition is a condition a calendar month must meet for the bonus rate of a rate card to be paid
+// for it.
+type Condition string
+
+const (
+	// NoWithdrawals is met when nothing was paid out of the account in the month.
+	NoWithdrawals Condition = "no_withdrawals"
+	// MonthlyDeposit is met when at least the card's minimum monthly deposit was paid into the
+	// account in the month, interest aside.
+	MonthlyDeposit Condition = "monthly_deposit"
+)
+
+// RateBand is a balance band of a rate card, its rate applies to the balance from MinBalance up to
+// where the next band starts. Balances are in major units and rates are annual percentages.
+type RateBand struct {
+	MinBalance float64 `json:"min_balance" binding:"gte=0"`
+	Rate       float64 `json:"rate" binding:"gte=0"`
+}
+
+// RateCardTerms are the terms of a rate card. Bands must start at 0 and increase. BonusRate is paid
+// on the whole balance on top of the bands for every month that meets all of BonusConditions.
+type RateCardTerms struct {
+	Method            string      `json:"method" binding:"required,oneof=tiered whole_balance"`
+	Bands             []RateBand  `json:"bands" binding:"required,min=1,dive"`
+	BonusRate         float64     `json:"bonus_rate" binding:"gte=0"`
+	BonusConditions   []Condition `json:"bonus_conditions" binding:"dive,oneof=no_withdrawals monthly_deposit"`
+	MinMonthlyDeposit float64     `json:"min_monthly_deposit" binding:"gte=0"`
+}
+
+// RateCard sets the interest of the accounts of a currency and product, it takes precedence over
+// the global rate. The product is the account type.
+type RateCard struct {
+	ID       uuid.UUID `json:"id"`
+	Currency string    `json:"currency"`
+	Product  string    `json:"product"`
+	RateCardTerms
+	CreatedBy uuid.UUID `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CreateRateCardParam struct {
+	UserID   uuid.UUID
+	Currency string `json:"currency" binding:"required,oneof=GBP EUR JPY"`
+	Product  string `json:"product" binding:"required,oneof=CURRENT"`
+	RateCardTerms
+}
+
+type UpdateRateCardParam struct {
+	UserID uuid.UUID
+	CardID uuid.UUID `json:"card_id" swaggerignore:"true"`
+	RateCardTerms
+}
+
+type DeleteRateCardParam struct {
+	UserID uuid.UUID
+	CardID uuid.UUID `json:"card_id"`
+}
+
+// OverrideKind is how a rate override sets the interest of an account.
+type OverrideKind string
+
+const (
+	// FixedOverride is a bespoke rate paid in place of the account's rate card or the global rate.
+	FixedOverride OverrideKind = "FIXED"
+	// BoostOverride is a promotional rate paid on top of whichever rate applies.
+	BoostOverride OverrideKind = "BOOST"
+)
+
+// RateOverrideTerms are the terms of a rate override. StartsOn and EndsOn are dates (YYYY-MM-DD),
+// EndsOn is the last day of the override and is left empty for one that does not end.
+type RateOverrideTerms struct {
+	Kind     OverrideKind `json:"kind" binding:"required,oneof=FIXED BOOST"`
+	Rate     float64      `json:"rate" binding:"gte=0"`
+	StartsOn string       `json:"starts_on" binding:"required,datetime=2006-01-02"`
+	EndsOn   string       `json:"ends_on,omitempty" binding:"omitempty,datetime=2006-01-02"`
+	Reason   string       `json:"reason,omitempty" binding:"max=500"`
+}
+
+// RateOverride sets the interest of a single account for a while, it takes precedence over the
+// account's rate card and the global rate. Status is scheduled, active or ended.
+type RateOverride struct {
+	ID        uuid.UUID `json:"id"`
+	AccountID uuid.UUID `json:"account_id"`
+	RateOverrideTerms
+	Status    string    `json:"status"`
+	CreatedBy uuid.UUID `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CreateRateOverrideParam struct {
+	UserID    uuid.UUID
+	AccountID uuid.UUID `json:"account_id" swaggerignore:"true"`
+	RateOverrideTerms
+}
+
+type UpdateRateOverrideParam struct {
+	UserID     uuid.UUID
+	AccountID  uuid.UUID `json:"account_id" swaggerignore:"true"`
+	OverrideID uuid.UUID `json:"override_id" swaggerignore:"true"`
+	RateOverrideTerms
+}
+
+type DeleteRateOverrideParam struct {
+	UserID     uuid.UUID
+	AccountID  uuid.UUID `json:"account_id"`
+	OverrideID uuid.UUID `json:"override_id"`
+}
+
+// check validates the terms and reads the days the override runs from and to, to is unset for an
+// override that does not end.
+func (t RateOverrideTerms) check() (from time.Time, to sql.NullTime, err error) {
+	if t.Kind == BoostOverride && t.Rate == 0 {
+		return from, to, platformerrors.MakeApiError(http.StatusBadRequest, "a boost must add to the rate")
+	}
+
+	from, err = time.Parse(time.DateOnly, t.StartsOn)
+	if err != nil {
+		return from, to, platformerrors.MakeApiError(http.StatusBadRequest, "starts_on must be a date (YYYY-MM-DD)")
+	}
+	if t.EndsOn == "" {
+		return from, to, nil
+	}
+
+	end, err := time.Parse(time.DateOnly, t.EndsOn)
+	if err != nil {
+		return from, to, platformerrors.MakeApiError(http.StatusBadRequest, "ends_on must be a date (YYYY-MM-DD)")
+	}
+	if end.Before(from) {
+		return from, to, platformerrors.MakeApiError(http.StatusBadRequest, "an override cannot end before it starts")
+	}
+	return from, sql.NullTime{Time: end, Valid: true}, nil
+}
+
+func rateOverrideFromModel(o models.InterestRateOverride, today time.Time) RateOverride {
+	r := RateOverride{
+		ID:        o.ID,
+		AccountID: o.AccountID,
+		RateOverrideTerms: RateOverrideTerms{
+			Kind:     OverrideKind(o.Kind),
+			Rate:     float64(o.Rate) / 100,
+			StartsOn: o.StartsOn.Format(time.DateOnly),
+			Reason:   o.Reason,
+		},
+		Status:    "active",
+		CreatedBy: o.CreatedBy,
+		CreatedAt: o.CreatedAt.Time,
+		UpdatedAt: o.UpdatedAt.Time,
+	}
+	if o.EndsOn.Valid {
+		r.EndsOn = o.EndsOn.Time.Format(time.DateOnly)
+	}
+	switch {
+	case o.StartsOn.After(today):
+		r.Status = "scheduled"
+	case o.EndsOn.Valid && o.EndsOn.Time.Before(today):
+		r.Status = "ended"
+	}
+	return r
+}
+
+// covers reports whether the override is in effect on day.
+func covers(o models.InterestRateOverride, day time.Time) bool {
+	return !o.StartsOn.After(day) && (!o.EndsOn.Valid || !o.EndsOn.Time.Before(day))
+}
+
+// storedBand is a band as it is kept in interest_rate_cards.bands, in minor units and basis points.
+type storedBand struct {
+	From int64 `json:"from"`
+	Rate int64 `json:"rate"`
+}
+
+// pricing is how the interest of an account is worked out: the terms of the rate card of its
+// currency and product, or a single band at the global rate.
+type pricing struct {
+	cardID            uuid.NullUUID
+	method            interest.Method
+	bands             []interest.Band
+	bonusRate         int64
+	conditions        []Condition
+	minMonthlyDeposit int64
+	// boost is the rate of a promotion paid on top of the bands, in basis points
+	boost int64
+}
+
+func globalPricing(rate int64) pricing {
+	return pricing{
+		method: interest.WholeBalance,
+		bands:  []interest.Band{{From: 0, Rate: rate}},
+	}
+}
+
+func pricingFromCard(card models.InterestRateCard) (pricing, error) {
+	var stored []storedBand
+	if err := json.Unmarshal(card.Bands, &stored); err != nil {
+		return pricing{}, err
+	}
+
+	p := pricing{
+		cardID:            uuid.NullUUID{UUID: card.ID, Valid: true},
+		method:            interest.Method(card.Method),
+		bands:             make([]interest.Band, len(stored)),
+		bonusRate:         card.BonusRate,
+		minMonthlyDeposit: card.MinMonthlyDeposit,
+	}
+	for i, band := range stored {
+		p.bands[i] = interest.Band{From: band.From, Rate: band.Rate}
+	}
+	for _, condition := range card.BonusConditions {
+		p.conditions = append(p.conditions, Condition(condition))
+	}
+	return p, nil
+}
+
+// accountPricing is the pricing of the rate card of the account's currency and product, or else
+// the global rate.
+func accountPricing(cards map[string]pricing, account models.GetAllActiveAccountsRow, rate int64) pricing {
+	if p, ok := cards[pricingKey(account.Currency, account.AccountType)]; ok {
+		return p
+	}
+	return globalPricing(rate)
+}
+
+// pricings are what the interest of accounts is worked out under: the rate cards keyed by currency
+// and product, and the rate overrides of each account.
+type pricings struct {
+	cards     map[string]pricing
+	overrides map[uuid.UUID][]models.InterestRateOverride
+}
+
+// of is the pricing of the account on day when the global rate is rate.
+func (p pricings) of(account models.GetAllActiveAccountsRow, rate int64, day time.Time) pricing {
+	return overridden(accountPricing(p.cards, account, rate), p.overrides[account.AccountID], day)
+}
+
+// overridden is p under the overrides of the account that are in effect on day: a fixed rate
+// takes the place of p, the rate of a boost is paid on top.
+func overridden(p pricing, overrides []models.InterestRateOverride, day time.Time) pricing {
+	var boost int64
+	for _, o := range overrides {
+		if !covers(o, day) {
+			continue
+		}
+		switch OverrideKind(o.Kind) {
+		case FixedOverride:
+			p = globalPricing(o.Rate)
+		case BoostOverride:
+			boost += o.Rate
+		}
+	}
+	p.boost = boost
+	return p
+}
+
+// dayInterest is the interest a balance earns in a day. rate is the rate the balance earns, blended
+// across the bands of a tiered card, and the bonus is on top of it.
+type dayInterest struct {
+	rate        *big.Rat
+	amount      *big.Rat
+	bonusRate   int64
+	bonusAmount *big.Rat
+}
+
+func (d dayInterest) total() *big.Rat {
+	return new(big.Rat).Add(d.amount, d.bonusAmount)
+}
+
+func pricingKey(currency models.Currency, product models.AccountType) string {
+	return string(currency) + "/" + string(product)
+}
+
+func rateCardFromModel(card models.InterestRateCard) (RateCard, error) {
+	var stored []storedBand
+	if err := json.Unmarshal(card.Bands, &stored); err != nil {
+		return RateCard{}, err
+	}
+
+	r := RateCard{
+		ID:       card.ID,
+		Currency: string(card.Currency),
+		Product:  string(card.Product),
+		RateCardTerms: RateCardTerms{
+			Method:            card.Method,
+			Bands:             make([]RateBand, len(stored)),
+			BonusRate:         float64(card.BonusRate) / 100,
+			BonusConditions:   []Condition{},
+			MinMonthlyDeposit: float64(card.MinMonthlyDeposit) / 100,
+		},
+		CreatedBy: card.CreatedBy,
+		CreatedAt: card.CreatedAt.Time,
+		UpdatedAt: card.UpdatedAt.Time,
+	}
+	for i, band := range stored {
+		r.Bands[i] = RateBand{MinBalance: float64(band.From) / 100, Rate: float64(band.Rate) / 100}
+	}
+	for _, condition := range card.BonusConditions {
+		r.BonusConditions = append(r.BonusConditions, Condition(condition))
+	}
+	return r, nil
+}
+
+// stored converts the terms to minor units and basis points. Bands must start at 0 and increase,
+// and a monthly deposit condition needs a minimum deposit.
+func (t RateCardTerms) stored() (json.RawMessage, []string, error) {
+	bands := make([]storedBand, len(t.Bands))
+	check := make([]interest.Band, len(t.Bands))
+	for i, band := range t.Bands {
+		bands[i] = storedBand{From: int64(math.Round(band.MinBalance * 100)), Rate: int64(math.Round(band.Rate * 100))}
+		check[i] = interest.Band{From: bands[i].From, Rate: bands[i].Rate}
+	}
+	if !interest.ValidBands(check) {
+		return nil, nil, platformerrors.MakeApiError(http.StatusBadRequest, "bands must start at 0 and increase")
+	}
+
+	conditions := make([]string, 0, len(t.BonusConditions))
+	for _, condition := range t.BonusConditions {
+		if condition == MonthlyDeposit && t.MinMonthlyDeposit <= 0 {
+			return nil, nil, platformerrors.MakeApiError(http.StatusBadRequest,
+				"min_monthly_deposit is required for the monthly_deposit condition")
+		}
+		conditions = append(conditions, string(condition))
+	}
+
+	encoded, err := json.Marshal(bands)
+	if err != nil {
+		return nil, nil, err
+	}
+	return encoded, conditions, nil
+}
+
+// Run is a day of interest, see ApplyRates. Period is the capitalisation period the run closes, it
+// is empty when the run only accrues. Status is RUNNING, COMPLETED or FAILED.
+type Run struct {
+	ID         uuid.UUID    `json:"id"`
+	RunDate    string       `json:"run_date"`
+	RateID     uuid.UUID    `json:"rate_id"`
+	Period     string       `json:"period,omitempty"`
+	Status     string       `json:"status"`
+	Accounts   int32        `json:"accounts"`
+	Failed     int32        `json:"failed"`
+	StartedAt  time.Time    `json:"started_at"`
+	FinishedAt *time.Time   `json:"finished_at,omitempty"`
+	Results    []RunAccount `json:"results,omitempty"`
+}
+
+// RunAccount is the result of a run for an account. Accrued is the interest accrued for the day and
+// Paid the interest paid for the period, both in major units.
+type RunAccount struct {
+	AccountID        uuid.UUID  `json:"account_id"`
+	Status           string     `json:"status"`
+	Accrued          float64    `json:"accrued"`
+	Paid             float64    `json:"paid"` // gross of tax
+	Tax              float64    `json:"tax"`
+	TransactionID    *uuid.UUID `json:"transaction_id,omitempty"`
+	TaxTransactionID *uuid.UUID `json:"tax_transaction_id,omitempty"`
+	Error            string     `json:"error,omitempty"`
+	Attempts         int32      `json:"attempts"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+type ListRunsParam struct {
+	Status string `form:"status" binding:"omitempty,oneof=RUNNING COMPLETED FAILED"`
+}
+
+type RetryRunAccountParam struct {
+	UserID    uuid.UUID
+	RunID     uuid.UUID
+	AccountID uuid.UUID
+}
+
+func runFromModel(run models.InterestRun) Run {
+	r := Run{
+		ID:        run.ID,
+		RunDate:   run.RunDate.Format(time.DateOnly),
+		RateID:    run.RateID,
+		Period:    run.Period.String,
+		Status:    run.Status,
+		Accounts:  run.Accounts,
+		Failed:    run.Failed,
+		StartedAt: run.StartedAt,
+	}
+	if run.FinishedAt.Valid {
+		r.FinishedAt = &run.FinishedAt.Time
+	}
+	return r
+}
+
+func runAccountFromModel(result models.InterestRunAccount) RunAccount {
+	r := RunAccount{
+		AccountID: result.AccountID,
+		Status:    result.Status,
+		Paid:      float64(result.Paid) / 100,
+		Tax:       float64(result.Tax) / 100,
+		Error:     result.Error.String,
+		Attempts:  result.Attempts,
+		UpdatedAt: result.UpdatedAt,
+	}
+	if accrued, err := interest.Parse(result.Accrued); err == nil {
+		r.Accrued = majorUnits(accrued)
+	}
+	if result.TransactionID.Valid {
+		r.TransactionID = &result.TransactionID.UUID
+	}
+	if result.TaxTransactionID.Valid {
+		r.TaxTransactionID = &result.TaxTransactionID.UUID
+	}
+	return r
+}
+
+// majorUnits converts an amount in minor units to major units.
+func majorUnits(amount *big.Rat) float64 {
+	f, _ := new(big.Rat).Quo(amount, big.NewRat(100, 1)).Float64()
+	return f
+}
+
+// TaxCertificateParam asks for the certificate of the interest paid into an account in a tax
+// year, as JSON or as a PDF document.
+type TaxCertificateParam struct {
+	Year      int       `form:"year" binding:"required,gte=2000,lte=9999"`
+	Format    string    `form:"format" binding:"omitempty,oneof=json pdf"`
+	AccountID uuid.UUID `form:"-"`
+}
+
+// TaxCertificate sums up the interest paid into an account in a tax year, which runs with the
+// calendar year, and the tax withheld from it. Amounts are in major units, Net is what was paid
+// into the account.
+type TaxCertificate struct {
+	AccountID     uuid.UUID               `json:"account_id"`
+	AccountNumber string                  `json:"account_number"`
+	AccountHolder string                  `json:"account_holder"`
+	Currency      string                  `json:"currency"`
+	Year          int                     `json:"year"`
+	Gross         float64                 `json:"gross"`
+	Tax           float64                 `json:"tax"`
+	Net           float64                 `json:"net"`
+	Payments      []TaxCertificatePayment `json:"payments"`
+	IssuedAt      time.Time               `json:"issued_at"`
+}
+
+type TaxCertificatePayment struct {
+	Period  string    `json:"period"`
+	PaidAt  time.Time `json:"paid_at"`
+	Gross   float64   `json:"gross"`
+	TaxRate float64   `json:"tax_rate"` // percent
+	Tax     float64   `json:"tax"`
+	Net     float64   `json:"net"`
+}
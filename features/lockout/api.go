@@ -0,0 +1,73 @@
+package lockout
+
+import (
+	"github.com/gin-gonic/gin"
+	"payter-bank/internal/api"
+	"payter-bank/internal/auth"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// StatusHandler godoc
+// @Summary      Get login lockout status
+// @Description  Get the failed logins and lockout of an email or client IP. Only an admin can do this
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        email  query  string  false  "Email"
+// @Param        ip     query  string  false  "Client IP"
+// @Success      200  {object}  api.SuccessResponse{data=Status}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/admin/lockouts [get]
+func (h *Handler) StatusHandler(ctx *gin.Context) api.Response {
+	var params StatusParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	status, err := h.service.Status(ctx, params)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("lockout status retrieved successfully", status)
+}
+
+// UnlockHandler godoc
+// @Summary      Unlock login
+// @Description  Clear the failed logins and lockout of an email, a client IP or both. Only an admin can do this
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        params  body  UnlockParams  true  "Email and/or client IP to unlock"
+// @Success      200  {object}  api.SuccessResponse
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/admin/lockouts/unlock [post]
+func (h *Handler) UnlockHandler(ctx *gin.Context) api.Response {
+	var params UnlockParams
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+	params.ActorID = profile.UserID
+
+	if err := h.service.Unlock(ctx, params); err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("login unlocked successfully", nil)
+}
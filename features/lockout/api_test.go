@@ -0,0 +1,103 @@
+package lockout
+
+import (
+	"bytes"
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"payter-bank/internal/api"
+	"payter-bank/internal/auth"
+	platformerrors "payter-bank/internal/errors"
+	"testing"
+)
+
+func TestHandler_StatusHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("returns lockout status", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/v1/api/admin/lockouts?email=john@doe.com&ip=10.0.0.1", nil)
+
+		status := Status{Email: &LockStatus{Failures: 2}, IP: &LockStatus{Failures: 4}}
+		mockService.EXPECT().Status(gomock.Any(), StatusParams{Email: "john@doe.com", IP: "10.0.0.1"}).Return(status, nil)
+
+		response := handler.StatusHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    status,
+			Message: "lockout status retrieved successfully",
+		}, response.Data)
+	})
+
+	t.Run("rejects invalid ip", func(t *testing.T) {
+		handler := NewHandler(NewMockService(gomock.NewController(t)))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/v1/api/admin/lockouts?ip=not-an-ip", nil)
+
+		response := handler.StatusHandler(c)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestHandler_UnlockHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("unlocks login", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		adminID := uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/admin/lockouts/unlock", bytes.NewBufferString(
+			`{"email":"john@doe.com"}`))
+		injectProfile(c, auth.Profile{UserID: adminID, UserType: "ADMIN"})
+
+		mockService.EXPECT().Unlock(gomock.Any(), UnlockParams{Email: "john@doe.com", ActorID: adminID}).Return(nil)
+
+		response := handler.UnlockHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+
+	t.Run("rejects invalid email", func(t *testing.T) {
+		handler := NewHandler(NewMockService(gomock.NewController(t)))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/admin/lockouts/unlock", bytes.NewBufferString(
+			`{"email":"john"}`))
+
+		response := handler.UnlockHandler(c)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+
+	t.Run("returns bad request without email or ip", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/admin/lockouts/unlock", bytes.NewBufferString(`{}`))
+		injectProfile(c, auth.Profile{UserID: uuid.New()})
+
+		mockService.EXPECT().Unlock(gomock.Any(), gomock.Any()).
+			Return(platformerrors.MakeApiError(http.StatusBadRequest, "email or ip is required"))
+
+		response := handler.UnlockHandler(c)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+}
+
+func injectProfile(ctx *gin.Context, profile auth.Profile) {
+	ctx.Request = ctx.Request.WithContext(context.WithValue(ctx.Request.Context(), auth.ProfileKey, profile))
+}
@@ -0,0 +1,94 @@
+package lockout
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryEntry struct {
+	failures         int64
+	failuresExpireAt time.Time
+	lockouts         int64
+	lockedUntil      time.Time
+	historyExpireAt  time.Time
+}
+
+type memoryStore struct {
+	mu      sync.Mutex
+	entries map[string]*memoryEntry
+	now     func() time.Time
+}
+
+func NewMemoryStore() Store {
+	return &memoryStore{
+		entries: map[string]*memoryEntry{},
+		now:     time.Now,
+	}
+}
+
+func (m *memoryStore) Get(_ context.Context, key string) (State, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.state(m.entry(key)), nil
+}
+
+func (m *memoryStore) AddFailure(_ context.Context, key string, window time.Duration) (State, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := m.entry(key)
+	if entry.failures == 0 {
+		entry.failuresExpireAt = m.now().Add(window)
+	}
+	entry.failures++
+	return m.state(entry), nil
+}
+
+func (m *memoryStore) Lock(_ context.Context, key string, until time.Time, historyExpiry time.Duration) (State, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := m.entry(key)
+	entry.failures = 0
+	entry.lockouts++
+	entry.lockedUntil = until
+	entry.historyExpireAt = m.now().Add(historyExpiry)
+	return m.state(entry), nil
+}
+
+func (m *memoryStore) Reset(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.entries, key)
+	return nil
+}
+
+// entry returns the entry for key with expired values cleared.
+func (m *memoryStore) entry(key string) *memoryEntry {
+	entry, ok := m.entries[key]
+	if !ok {
+		entry = &memoryEntry{}
+		m.entries[key] = entry
+	}
+
+	now := m.now()
+	if entry.failures > 0 && !now.Before(entry.failuresExpireAt) {
+		entry.failures = 0
+	}
+	if entry.lockouts > 0 && !now.Before(entry.historyExpireAt) {
+		entry.lockouts = 0
+		entry.lockedUntil = time.Time{}
+	}
+	return entry
+}
+
+func (m *memoryStore) state(entry *memoryEntry) State {
+	return State{
+		Failures:    entry.failures,
+		Lockouts:    entry.lockouts,
+		LockedUntil: entry.lockedUntil,
+	}
+}
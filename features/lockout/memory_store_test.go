@@ -0,0 +1,62 @@
+package lockout
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestMemoryStore(t *testing.T) {
+	newStore := func(now *time.Time) Store {
+		store := NewMemoryStore()
+		store.(*memoryStore).now = func() time.Time { return *now }
+		return store
+	}
+
+	t.Run("counts failures within window", func(t *testing.T) {
+		now := fixedNow
+		store := newStore(&now)
+
+		_, _ = store.AddFailure(context.TODO(), "email:john@doe.com", time.Minute)
+		now = now.Add(30 * time.Second)
+		state, err := store.AddFailure(context.TODO(), "email:john@doe.com", time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, State{Failures: 2}, state)
+
+		now = now.Add(30 * time.Second)
+		state, err = store.Get(context.TODO(), "email:john@doe.com")
+		assert.NoError(t, err)
+		assert.Equal(t, State{}, state)
+	})
+
+	t.Run("lock clears failures and keeps history", func(t *testing.T) {
+		now := fixedNow
+		store := newStore(&now)
+		until := fixedNow.Add(time.Minute)
+
+		_, _ = store.AddFailure(context.TODO(), "ip:10.0.0.1", time.Minute)
+		state, err := store.Lock(context.TODO(), "ip:10.0.0.1", until, time.Hour)
+		assert.NoError(t, err)
+		assert.Equal(t, State{Lockouts: 1, LockedUntil: until}, state)
+
+		now = now.Add(59 * time.Minute)
+		state, _ = store.Get(context.TODO(), "ip:10.0.0.1")
+		assert.Equal(t, int64(1), state.Lockouts)
+
+		now = now.Add(time.Minute)
+		state, _ = store.Get(context.TODO(), "ip:10.0.0.1")
+		assert.Equal(t, State{}, state)
+	})
+
+	t.Run("reset forgets key", func(t *testing.T) {
+		now := fixedNow
+		store := newStore(&now)
+
+		_, _ = store.Lock(context.TODO(), "ip:10.0.0.1", fixedNow.Add(time.Minute), time.Hour)
+		assert.NoError(t, store.Reset(context.TODO(), "ip:10.0.0.1"))
+
+		state, _ := store.Get(context.TODO(), "ip:10.0.0.1")
+		assert.Equal(t, State{}, state)
+	})
+}
@@ -0,0 +1,100 @@
+package lockout
+
+import (
+	"context"
+	"errors"
+	"github.com/redis/go-redis/v9"
+	"strconv"
+	"time"
+)
+
+const redisKeyPrefix = "lockout:"
+
+type redisStore struct {
+	client *redis.Client
+}
+
+func NewRedisStore(client *redis.Client) Store {
+	return &redisStore{
+		client: client,
+	}
+}
+
+func (r *redisStore) Get(ctx context.Context, key string) (State, error) {
+	var (
+		failures *redis.StringCmd
+		history  *redis.SliceCmd
+	)
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		failures = pipe.Get(ctx, failuresKey(key))
+		history = pipe.HMGet(ctx, historyKey(key), "lockouts", "locked_until")
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return State{}, err
+	}
+
+	state := State{}
+	if value, err := failures.Int64(); err == nil {
+		state.Failures = value
+	}
+
+	values := history.Val()
+	if len(values) == 2 {
+		state.Lockouts = parseInt(values[0])
+		if until := parseInt(values[1]); until > 0 {
+			state.LockedUntil = time.Unix(until, 0)
+		}
+	}
+	return state, nil
+}
+
+func (r *redisStore) AddFailure(ctx context.Context, key string, window time.Duration) (State, error) {
+	failures, err := r.client.Incr(ctx, failuresKey(key)).Result()
+	if err != nil {
+		return State{}, err
+	}
+
+	// the window starts with the first failure, later failures do not extend it
+	if failures == 1 {
+		if err := r.client.Expire(ctx, failuresKey(key), window).Err(); err != nil {
+			return State{}, err
+		}
+	}
+	return r.Get(ctx, key)
+}
+
+func (r *redisStore) Lock(ctx context.Context, key string, until time.Time, historyExpiry time.Duration) (State, error) {
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, failuresKey(key))
+		pipe.HIncrBy(ctx, historyKey(key), "lockouts", 1)
+		pipe.HSet(ctx, historyKey(key), "locked_until", until.Unix())
+		pipe.Expire(ctx, historyKey(key), historyExpiry)
+		return nil
+	})
+	if err != nil {
+		return State{}, err
+	}
+	return r.Get(ctx, key)
+}
+
+func (r *redisStore) Reset(ctx context.Context, key string) error {
+	return r.client.Del(ctx, failuresKey(key), historyKey(key)).Err()
+}
+
+func failuresKey(key string) string {
+	return redisKeyPrefix + "failures:" + key
+}
+
+func historyKey(key string) string {
+	return redisKeyPrefix + "history:" + key
+}
+
+func parseInt(value interface{}) int64 {
+	s, ok := value.(string)
+	if !ok {
+		return 0
+	}
+	n, _ := strconv.ParseInt(s, 10, 64)
+	return n
+}
@@ -0,0 +1,227 @@
+//go:generate mockgen -source=service.go -destination=service_mock.go -package=lockout
+
+package lockout
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"net/http"
+	"payter-bank/features/auditlog"
+	"payter-bank/internal/config"
+	platformerrors "payter-bank/internal/errors"
+	"payter-bank/internal/logger"
+	"strings"
+	"time"
+)
+
+type Service interface {
+	// Check returns an error when the email or the client IP of the attempt is locked out.
+	Check(ctx context.Context, attempt Attempt) error
+	// RecordFailure counts a failed login against the email and the client IP, either of them is
+	// locked out once it reaches its limit. Unknown emails are counted the same as known ones.
+	RecordFailure(ctx context.Context, attempt Attempt)
+	// RecordSuccess clears the failures of the email. The client IP keeps its failures, logging in
+	// to one account says nothing about guesses made at others.
+	RecordSuccess(ctx context.Context, attempt Attempt)
+	Status(ctx context.Context, param StatusParams) (Status, error)
+	Unlock(ctx context.Context, param UnlockParams) error
+}
+
+var ErrLocked = platformerrors.MakeApiError(http.StatusUnauthorized, "too many failed login attempts, try again later")
+
+type service struct {
+	store    Store
+	auditLog auditlog.Service
+	cfg      config.Config
+	now      func() time.Time
+}
+
+func NewService(store Store, auditLog auditlog.Service, cfg config.Config) Service {
+	return &service{
+		store:    store,
+		auditLog: auditLog,
+		cfg:      cfg,
+		now:      time.Now,
+	}
+}
+
+func (s *service) Check(ctx context.Context, attempt Attempt) error {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Check"),
+		zap.Any(logger.RequestFields, attempt))
+
+	for _, key := range attemptKeys(attempt) {
+		state, err := s.store.Get(ctx, key)
+		if err != nil {
+			logger.Error(ctx, "failed to get lockout state", zap.Error(err))
+			return platformerrors.ErrInternal
+		}
+
+		if s.now().Before(state.LockedUntil) {
+			return ErrLocked
+		}
+	}
+	return nil
+}
+
+func (s *service) RecordFailure(ctx context.Context, attempt Attempt) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "RecordFailure"),
+		zap.Any(logger.RequestFields, attempt))
+
+	if email := normaliseEmail(attempt.Email); email != "" {
+		s.recordFailure(ctx, emailKey(email), s.cfg.Lockout.MaxFailures, auditlog.LoginLockoutMetadata{Email: email})
+	}
+	if attempt.IP != "" {
+		s.recordFailure(ctx, ipKey(attempt.IP), s.cfg.Lockout.MaxIPFailures, auditlog.LoginLockoutMetadata{IP: attempt.IP})
+	}
+}
+
+func (s *service) RecordSuccess(ctx context.Context, attempt Attempt) {
+	email := normaliseEmail(attempt.Email)
+	if email == "" {
+		return
+	}
+
+	if err := s.store.Reset(ctx, emailKey(email)); err != nil {
+		logger.Error(ctx, "failed to reset login failures", zap.Error(err))
+	}
+}
+
+func (s *service) Status(ctx context.Context, param StatusParams) (Status, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Status"),
+		zap.Any(logger.RequestFields, param))
+
+	if param.Email == "" && param.IP == "" {
+		return Status{}, platformerrors.MakeApiError(http.StatusBadRequest, "email or ip is required")
+	}
+
+	status := Status{}
+	if email := normaliseEmail(param.Email); email != "" {
+		lockStatus, err := s.lockStatus(ctx, emailKey(email))
+		if err != nil {
+			return Status{}, err
+		}
+		status.Email = &lockStatus
+	}
+	if param.IP != "" {
+		lockStatus, err := s.lockStatus(ctx, ipKey(param.IP))
+		if err != nil {
+			return Status{}, err
+		}
+		status.IP = &lockStatus
+	}
+	return status, nil
+}
+
+func (s *service) Unlock(ctx context.Context, param UnlockParams) error {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Unlock"),
+		zap.Any(logger.RequestFields, param))
+
+	attempt := Attempt{Email: param.Email, IP: param.IP}
+	keys := attemptKeys(attempt)
+	if len(keys) == 0 {
+		return platformerrors.MakeApiError(http.StatusBadRequest, "email or ip is required")
+	}
+
+	for _, key := range keys {
+		if err := s.store.Reset(ctx, key); err != nil {
+			logger.Error(ctx, "failed to reset lockout", zap.Error(err))
+			return platformerrors.ErrInternal
+		}
+	}
+
+	s.submitAudit(ctx, auditlog.NewEvent(auditlog.ActionLoginUnlock, param.ActorID, uuid.Nil,
+		auditlog.LoginLockoutMetadata{
+			Email: normaliseEmail(param.Email),
+			IP:    param.IP,
+		}))
+	return nil
+}
+
+func (s *service) recordFailure(ctx context.Context, key string, maxFailures int64, metadata auditlog.LoginLockoutMetadata) {
+	state, err := s.store.AddFailure(ctx, key, s.cfg.Lockout.Window)
+	if err != nil {
+		logger.Error(ctx, "failed to record login failure", zap.Error(err))
+		return
+	}
+
+	if state.Failures < maxFailures {
+		return
+	}
+
+	until := s.now().Add(s.backoff(state.Lockouts))
+	state, err = s.store.Lock(ctx, key, until, s.cfg.Lockout.HistoryExpiry)
+	if err != nil {
+		logger.Error(ctx, "failed to lock out login", zap.Error(err))
+		return
+	}
+
+	logger.Warn(ctx, "login locked out",
+		zap.String("key", key),
+		zap.Time("locked_until", until))
+
+	metadata.Failures = maxFailures
+	metadata.Lockouts = state.Lockouts
+	metadata.LockedUntil = until
+	s.submitAudit(ctx, auditlog.NewEvent(auditlog.ActionLoginLockout, s.cfg.App.SystemUserID, uuid.Nil, metadata))
+}
+
+// backoff doubles the lockout for every previous one, up to the maximum.
+func (s *service) backoff(previousLockouts int64) time.Duration {
+	duration := s.cfg.Lockout.BaseDuration
+	for i := int64(0); i < previousLockouts && duration < s.cfg.Lockout.MaxDuration; i++ {
+		duration *= 2
+	}
+	return min(duration, s.cfg.Lockout.MaxDuration)
+}
+
+func (s *service) lockStatus(ctx context.Context, key string) (LockStatus, error) {
+	state, err := s.store.Get(ctx, key)
+	if err != nil {
+		logger.Error(ctx, "failed to get lockout state", zap.Error(err))
+		return LockStatus{}, platformerrors.ErrInternal
+	}
+
+	status := LockStatus{
+		Failures: state.Failures,
+		Lockouts: state.Lockouts,
+		Locked:   s.now().Before(state.LockedUntil),
+	}
+	if status.Locked {
+		status.LockedUntil = &state.LockedUntil
+	}
+	return status, nil
+}
+
+func (s *service) submitAudit(ctx context.Context, event auditlog.Event) {
+	if err := s.auditLog.Submit(ctx, event); err != nil {
+		logger.Error(ctx, "failed to queue audit log", zap.Error(err))
+	}
+}
+
+func attemptKeys(attempt Attempt) []string {
+	keys := make([]string, 0, 2)
+	if email := normaliseEmail(attempt.Email); email != "" {
+		keys = append(keys, emailKey(email))
+	}
+	if attempt.IP != "" {
+		keys = append(keys, ipKey(attempt.IP))
+	}
+	return keys
+}
+
+func normaliseEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func emailKey(email string) string {
+	return "email:" + email
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
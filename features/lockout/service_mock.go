@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=service_mock.go -package=lockout
+//
+
+// Package lockout is a generated GoMock package.
+package lockout
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockService) Check(ctx context.Context, attempt Attempt) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, attempt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockServiceMockRecorder) Check(ctx, attempt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockService)(nil).Check), ctx, attempt)
+}
+
+// RecordFailure mocks base method.
+func (m *MockService) RecordFailure(ctx context.Context, attempt Attempt) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordFailure", ctx, attempt)
+}
+
+// RecordFailure indicates an expected call of RecordFailure.
+func (mr *MockServiceMockRecorder) RecordFailure(ctx, attempt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailure", reflect.TypeOf((*MockService)(nil).RecordFailure), ctx, attempt)
+}
+
+// RecordSuccess mocks base method.
+func (m *MockService) RecordSuccess(ctx context.Context, attempt Attempt) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordSuccess", ctx, attempt)
+}
+
+// RecordSuccess indicates an expected call of RecordSuccess.
+func (mr *MockServiceMockRecorder) RecordSuccess(ctx, attempt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordSuccess", reflect.TypeOf((*MockService)(nil).RecordSuccess), ctx, attempt)
+}
+
+// Status mocks base method.
+func (m *MockService) Status(ctx context.Context, param StatusParams) (Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status", ctx, param)
+	ret0, _ := ret[0].(Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockServiceMockRecorder) Status(ctx, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockService)(nil).Status), ctx, param)
+}
+
+// Unlock mocks base method.
+func (m *MockService) Unlock(ctx context.Context, param UnlockParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unlock", ctx, param)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unlock indicates an expected call of Unlock.
+func (mr *MockServiceMockRecorder) Unlock(ctx, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockService)(nil).Unlock), ctx, param)
+}
@@ -0,0 +1,259 @@
+package lockout
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"payter-bank/features/auditlog"
+	"payter-bank/internal/config"
+	platformerrors "payter-bank/internal/errors"
+	"testing"
+	"time"
+)
+
+var (
+	fixedNow     = time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	systemUserID = uuid.MustParse("00000000-2222-2222-2222-000000000000")
+)
+
+func TestService_Check(t *testing.T) {
+	t.Run("allows attempts without failures", func(t *testing.T) {
+		m := newLockoutMocker(t)
+
+		err := m.service.Check(context.TODO(), Attempt{Email: "john@doe.com", IP: "10.0.0.1"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("locks email after max failures", func(t *testing.T) {
+		m := newLockoutMocker(t)
+		m.auditLog.EXPECT().Submit(gomock.Any(), gomock.Any()).Return(nil)
+
+		attempt := Attempt{Email: "john@doe.com", IP: "10.0.0.1"}
+		for i := 0; i < 2; i++ {
+			m.service.RecordFailure(context.TODO(), attempt)
+			assert.NoError(t, m.service.Check(context.TODO(), attempt))
+		}
+		m.service.RecordFailure(context.TODO(), attempt)
+
+		assert.Equal(t, ErrLocked, m.service.Check(context.TODO(), attempt))
+		assert.Equal(t, ErrLocked, m.service.Check(context.TODO(), Attempt{Email: "JOHN@doe.com ", IP: "10.0.0.2"}))
+		assert.NoError(t, m.service.Check(context.TODO(), Attempt{Email: "jane@doe.com", IP: "10.0.0.1"}))
+	})
+
+	t.Run("locks ip after max ip failures across emails", func(t *testing.T) {
+		m := newLockoutMocker(t)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionLoginLockout, systemUserID, uuid.Nil,
+			auditlog.LoginLockoutMetadata{
+				IP:          "10.0.0.1",
+				Failures:    5,
+				Lockouts:    1,
+				LockedUntil: fixedNow.Add(time.Minute),
+			})).Return(nil)
+
+		for _, email := range []string{"a@doe.com", "b@doe.com", "c@doe.com", "d@doe.com", "e@doe.com"} {
+			m.service.RecordFailure(context.TODO(), Attempt{Email: email, IP: "10.0.0.1"})
+		}
+
+		assert.Equal(t, ErrLocked, m.service.Check(context.TODO(), Attempt{Email: "f@doe.com", IP: "10.0.0.1"}))
+		assert.NoError(t, m.service.Check(context.TODO(), Attempt{Email: "f@doe.com", IP: "10.0.0.2"}))
+	})
+
+	t.Run("allows attempts once lockout expires", func(t *testing.T) {
+		m := newLockoutMocker(t)
+		m.auditLog.EXPECT().Submit(gomock.Any(), gomock.Any()).Return(nil)
+
+		attempt := Attempt{Email: "john@doe.com"}
+		m.recordFailures(attempt, 3)
+		assert.Equal(t, ErrLocked, m.service.Check(context.TODO(), attempt))
+
+		m.advance(time.Minute)
+		assert.NoError(t, m.service.Check(context.TODO(), attempt))
+	})
+
+	t.Run("fails closed when store is unavailable", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := NewMockStore(ctrl)
+		svc := NewService(store, auditlog.NewMockService(ctrl), lockoutConfig())
+
+		store.EXPECT().Get(gomock.Any(), "email:john@doe.com").Return(State{}, errors.New("connection refused"))
+
+		err := svc.Check(context.TODO(), Attempt{Email: "john@doe.com"})
+		assert.Equal(t, platformerrors.ErrInternal, err)
+	})
+}
+
+func TestService_RecordFailure(t *testing.T) {
+	t.Run("audits lockout with system user as actor", func(t *testing.T) {
+		m := newLockoutMocker(t)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionLoginLockout, systemUserID, uuid.Nil,
+			auditlog.LoginLockoutMetadata{
+				Email:       "john@doe.com",
+				Failures:    3,
+				Lockouts:    1,
+				LockedUntil: fixedNow.Add(time.Minute),
+			})).Return(nil)
+
+		m.recordFailures(Attempt{Email: "John@Doe.com"}, 3)
+	})
+
+	t.Run("doubles lockout for every previous lockout", func(t *testing.T) {
+		m := newLockoutMocker(t)
+		attempt := Attempt{Email: "john@doe.com"}
+
+		for i, duration := range []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute} {
+			until := fixedNow.Add(time.Duration(i) * time.Hour).Add(duration)
+			m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionLoginLockout, systemUserID, uuid.Nil,
+				auditlog.LoginLockoutMetadata{
+					Email:       "john@doe.com",
+					Failures:    3,
+					Lockouts:    int64(i + 1),
+					LockedUntil: until,
+				})).Return(nil)
+
+			m.recordFailures(attempt, 3)
+			m.advance(time.Hour)
+		}
+	})
+
+	t.Run("caps lockout at max duration", func(t *testing.T) {
+		m := newLockoutMocker(t)
+		m.service.(*service).cfg.Lockout.MaxDuration = 3 * time.Minute
+
+		assert.Equal(t, time.Minute, m.service.(*service).backoff(0))
+		assert.Equal(t, 2*time.Minute, m.service.(*service).backoff(1))
+		assert.Equal(t, 3*time.Minute, m.service.(*service).backoff(2))
+		assert.Equal(t, 3*time.Minute, m.service.(*service).backoff(60))
+	})
+
+	t.Run("forgets failures outside window", func(t *testing.T) {
+		m := newLockoutMocker(t)
+		attempt := Attempt{Email: "john@doe.com"}
+
+		m.recordFailures(attempt, 2)
+		m.advance(15 * time.Minute)
+		m.recordFailures(attempt, 2)
+
+		assert.NoError(t, m.service.Check(context.TODO(), attempt))
+	})
+}
+
+func TestService_RecordSuccess(t *testing.T) {
+	t.Run("clears email failures but not ip failures", func(t *testing.T) {
+		m := newLockoutMocker(t)
+		attempt := Attempt{Email: "john@doe.com", IP: "10.0.0.1"}
+
+		m.recordFailures(attempt, 2)
+		m.service.RecordSuccess(context.TODO(), attempt)
+
+		status, err := m.service.Status(context.TODO(), StatusParams{Email: "john@doe.com", IP: "10.0.0.1"})
+		assert.NoError(t, err)
+		assert.Equal(t, Status{
+			Email: &LockStatus{},
+			IP:    &LockStatus{Failures: 2},
+		}, status)
+	})
+}
+
+func TestService_Status(t *testing.T) {
+	t.Run("returns locked status", func(t *testing.T) {
+		m := newLockoutMocker(t)
+		m.auditLog.EXPECT().Submit(gomock.Any(), gomock.Any()).Return(nil)
+
+		m.recordFailures(Attempt{Email: "john@doe.com"}, 4)
+
+		lockedUntil := fixedNow.Add(time.Minute)
+		status, err := m.service.Status(context.TODO(), StatusParams{Email: "john@doe.com"})
+		assert.NoError(t, err)
+		assert.Equal(t, Status{
+			Email: &LockStatus{Failures: 1, Lockouts: 1, Locked: true, LockedUntil: &lockedUntil},
+		}, status)
+	})
+
+	t.Run("requires email or ip", func(t *testing.T) {
+		m := newLockoutMocker(t)
+
+		_, err := m.service.Status(context.TODO(), StatusParams{})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, "email or ip is required"), err)
+	})
+}
+
+func TestService_Unlock(t *testing.T) {
+	t.Run("clears lockout and audits actor", func(t *testing.T) {
+		m := newLockoutMocker(t)
+		adminID := uuid.New()
+		attempt := Attempt{Email: "john@doe.com", IP: "10.0.0.1"}
+
+		m.auditLog.EXPECT().Submit(gomock.Any(), gomock.Any()).Return(nil)
+		m.recordFailures(attempt, 3)
+		assert.Equal(t, ErrLocked, m.service.Check(context.TODO(), attempt))
+
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionLoginUnlock, adminID, uuid.Nil,
+			auditlog.LoginLockoutMetadata{
+				Email: "john@doe.com",
+				IP:    "10.0.0.1",
+			})).Return(nil)
+
+		err := m.service.Unlock(context.TODO(), UnlockParams{Email: "John@doe.com", IP: "10.0.0.1", ActorID: adminID})
+		assert.NoError(t, err)
+		assert.NoError(t, m.service.Check(context.TODO(), attempt))
+	})
+
+	t.Run("requires email or ip", func(t *testing.T) {
+		m := newLockoutMocker(t)
+
+		err := m.service.Unlock(context.TODO(), UnlockParams{ActorID: uuid.New()})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, "email or ip is required"), err)
+	})
+}
+
+type lockoutMocker struct {
+	auditLog *auditlog.MockService
+	now      time.Time
+
+	service Service
+}
+
+func newLockoutMocker(t *testing.T) *lockoutMocker {
+	ctrl := gomock.NewController(t)
+	auditLog := auditlog.NewMockService(ctrl)
+
+	m := &lockoutMocker{
+		auditLog: auditLog,
+		now:      fixedNow,
+	}
+	clock := func() time.Time { return m.now }
+
+	store := NewMemoryStore()
+	store.(*memoryStore).now = clock
+	svc := NewService(store, auditLog, lockoutConfig())
+	svc.(*service).now = clock
+	m.service = svc
+	return m
+}
+
+func (m *lockoutMocker) recordFailures(attempt Attempt, n int) {
+	for i := 0; i < n; i++ {
+		m.service.RecordFailure(context.TODO(), attempt)
+	}
+}
+
+func (m *lockoutMocker) advance(d time.Duration) {
+	m.now = m.now.Add(d)
+}
+
+func lockoutConfig() config.Config {
+	return config.Config{
+		App: config.AppConfig{SystemUserID: systemUserID},
+		Lockout: config.LockoutConfig{
+			MaxFailures:   3,
+			MaxIPFailures: 5,
+			Window:        15 * time.Minute,
+			BaseDuration:  time.Minute,
+			MaxDuration:   time.Hour,
+			HistoryExpiry: 24 * time.Hour,
+		},
+	}
+}
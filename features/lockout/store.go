@@ -0,0 +1,44 @@
+//go:generate mockgen -source=store.go -destination=store_mock.go -package=lockout
+
+package lockout
+
+import (
+	"context"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"payter-bank/internal/config"
+	"time"
+)
+
+// State is what is known about the failed logins of one email or client IP.
+type State struct {
+	// Failures counts failed logins since the last lockout, within the failure window.
+	Failures int64
+	// Lockouts counts lockouts within the history expiry, each one doubles the next.
+	Lockouts    int64
+	LockedUntil time.Time
+}
+
+// Store keeps lockout state. Redis is used so every instance of the API shares it, the in-memory
+// store is for tests and single-instance development.
+type Store interface {
+	Get(ctx context.Context, key string) (State, error)
+	// AddFailure counts a failed login, failures are forgotten window after the first one.
+	AddFailure(ctx context.Context, key string, window time.Duration) (State, error)
+	// Lock locks key until the given time and clears its failures. The lockout is remembered for
+	// historyExpiry to calculate the backoff of the next one.
+	Lock(ctx context.Context, key string, until time.Time, historyExpiry time.Duration) (State, error)
+	Reset(ctx context.Context, key string) error
+}
+
+// NewStore returns the store configured in LOCKOUT_STORE.
+func NewStore(cfg config.Config) (Store, error) {
+	switch cfg.Lockout.Store {
+	case "redis":
+		return NewRedisStore(redis.NewClient(&redis.Options{Addr: cfg.Redis.Addr})), nil
+	case "memory":
+		return NewMemoryStore(), nil
+	default:
+		return nil, fmt.Errorf("unknown lockout store %q", cfg.Lockout.Store)
+	}
+}
@@ -0,0 +1,35 @@
+package lockout
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Attempt is a login attempt, both the email and the client IP it came from are tracked.
+type Attempt struct {
+	Email string
+	IP    string
+}
+
+type UnlockParams struct {
+	Email   string    `json:"email" binding:"omitempty,email"`
+	IP      string    `json:"ip" binding:"omitempty,ip"`
+	ActorID uuid.UUID `json:"-"`
+}
+
+type StatusParams struct {
+	Email string `form:"email" binding:"omitempty,email"`
+	IP    string `form:"ip" binding:"omitempty,ip"`
+}
+
+type LockStatus struct {
+	Failures    int64      `json:"failures"`
+	Lockouts    int64      `json:"lockouts"`
+	Locked      bool       `json:"locked"`
+	LockedUntil *time.Time `json:"locked_until,omitempty"`
+}
+
+type Status struct {
+	Email *LockStatus `json:"email,omitempty"`
+	IP    *LockStatus `json:"ip,omitempty"`
+}
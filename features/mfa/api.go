@@ -0,0 +1,204 @@
+package mfa
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"payter-bank/internal/api"
+	"payter-bank/internal/auth"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// StatusHandler godoc
+// @Summary      Get MFA status
+// @Description  Get whether MFA is enabled for the current user and how many recovery codes are left
+// @Tags         mfa
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  api.SuccessResponse{data=Status}
+// @Failure      401  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/me/mfa [get]
+func (h *Handler) StatusHandler(ctx *gin.Context) api.Response {
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+
+	status, err := h.service.Status(ctx, profile.UserID)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("mfa status retrieved successfully", status)
+}
+
+// EnrollHandler godoc
+// @Summary      Start MFA enrollment
+// @Description  Generate a TOTP secret for the current user. Render the provisioning URI as a QR code and confirm enrollment with a code from the authenticator app
+// @Tags         mfa
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  api.SuccessResponse{data=Enrollment}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      401  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/me/mfa/enroll [post]
+func (h *Handler) EnrollHandler(ctx *gin.Context) api.Response {
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+
+	enrollment, err := h.service.Enroll(ctx, profile.UserID)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("scan the qr code with your authenticator app and confirm with a code", enrollment)
+}
+
+// ConfirmHandler godoc
+// @Summary      Confirm MFA enrollment
+// @Description  Enable MFA with a code from the authenticator app. The recovery codes are only shown once
+// @Tags         mfa
+// @Accept       json
+// @Produce      json
+// @Param        params  body  CodeParams  true  "Authenticator code"
+// @Success      200  {object}  api.SuccessResponse{data=RecoveryCodes}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      401  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/me/mfa/confirm [post]
+func (h *Handler) ConfirmHandler(ctx *gin.Context) api.Response {
+	var params CodeParams
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+
+	codes, err := h.service.Confirm(ctx, ConfirmParams{
+		UserID: profile.UserID,
+		Code:   params.Code,
+	})
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("mfa enabled successfully, store your recovery codes somewhere safe", codes)
+}
+
+// DisableHandler godoc
+// @Summary      Disable MFA
+// @Description  Disable MFA for the current user with a code or recovery code. Admins cannot disable MFA
+// @Tags         mfa
+// @Accept       json
+// @Produce      json
+// @Param        params  body  CodeParams  true  "Authenticator or recovery code"
+// @Success      200  {object}  api.SuccessResponse
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      401  {object}  api.ErrorResponse
+// @Failure      403  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/me/mfa/disable [post]
+func (h *Handler) DisableHandler(ctx *gin.Context) api.Response {
+	var params CodeParams
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+
+	err = h.service.Disable(ctx, DisableParams{
+		UserID: profile.UserID,
+		Code:   params.Code,
+	})
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("mfa disabled successfully", nil)
+}
+
+// StepUpHandler godoc
+// @Summary      Step-up MFA
+// @Description  Verify a code for the current session and get an access token that is allowed to perform high-risk actions for a few minutes
+// @Tags         mfa
+// @Accept       json
+// @Produce      json
+// @Param        params  body  CodeParams  true  "Authenticator or recovery code"
+// @Success      200  {object}  api.SuccessResponse{data=session.Tokens}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      401  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/me/mfa/verify [post]
+func (h *Handler) StepUpHandler(ctx *gin.Context) api.Response {
+	var params CodeParams
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	tokenData, err := auth.GetTokenData(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+
+	tokens, err := h.service.StepUp(ctx, StepUpParams{
+		TokenData: tokenData,
+		Code:      params.Code,
+	})
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("session verified successfully", tokens)
+}
+
+// ResetHandler godoc
+// @Summary      Reset user MFA
+// @Description  Remove MFA from a user who has lost their authenticator device. Only an admin can do this
+// @Tags         mfa
+// @Accept       json
+// @Produce      json
+// @Param        id  path  string  true  "User ID"
+// @Success      200  {object}  api.SuccessResponse
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/admin/users/{id}/mfa/reset [post]
+func (h *Handler) ResetHandler(ctx *gin.Context) api.Response {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return api.BadRequest("user id is required")
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+
+	err = h.service.Reset(ctx, ResetParams{
+		UserID:  userID,
+		ActorID: profile.UserID,
+	})
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("mfa reset successfully", nil)
+}
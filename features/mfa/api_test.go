@@ -0,0 +1,186 @@
+package mfa
+
+import (
+	"bytes"
+	"context"
+	jwtmiddleware "github.com/auth0/go-jwt-middleware/v2"
+	"github.com/auth0/go-jwt-middleware/v2/validator"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"payter-bank/features/session"
+	"payter-bank/internal/api"
+	"payter-bank/internal/auth"
+	platformerrors "payter-bank/internal/errors"
+	"payter-bank/internal/pkg/generator"
+	"testing"
+)
+
+func TestHandler_EnrollHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("starts enrollment", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		userID := uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/me/mfa/enroll", nil)
+		injectProfile(c, auth.Profile{UserID: userID})
+
+		enrollment := Enrollment{Secret: "SECRET", ProvisioningURI: "otpauth://totp/PayterBank:jane@example.com?secret=SECRET"}
+		mockService.EXPECT().Enroll(gomock.Any(), userID).Return(enrollment, nil)
+
+		response := handler.EnrollHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    enrollment,
+			Message: "scan the qr code with your authenticator app and confirm with a code",
+		}, response.Data)
+	})
+}
+
+func TestHandler_ConfirmHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("confirms enrollment", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		userID := uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/me/mfa/confirm", bytes.NewBufferString(`{"code":"123456"}`))
+		injectProfile(c, auth.Profile{UserID: userID})
+
+		codes := RecoveryCodes{Codes: []string{"abcde-fghij"}}
+		mockService.EXPECT().Confirm(gomock.Any(), ConfirmParams{UserID: userID, Code: "123456"}).Return(codes, nil)
+
+		response := handler.ConfirmHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    codes,
+			Message: "mfa enabled successfully, store your recovery codes somewhere safe",
+		}, response.Data)
+	})
+
+	t.Run("rejects missing code", func(t *testing.T) {
+		handler := NewHandler(NewMockService(gomock.NewController(t)))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/me/mfa/confirm", bytes.NewBufferString(`{}`))
+
+		response := handler.ConfirmHandler(c)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestHandler_DisableHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("returns forbidden for admins", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		userID := uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/me/mfa/disable", bytes.NewBufferString(`{"code":"123456"}`))
+		injectProfile(c, auth.Profile{UserID: userID, UserType: "ADMIN"})
+
+		mockService.EXPECT().Disable(gomock.Any(), DisableParams{UserID: userID, Code: "123456"}).
+			Return(platformerrors.MakeApiError(http.StatusForbidden, "admins cannot disable mfa"))
+
+		response := handler.DisableHandler(c)
+		assert.Equal(t, http.StatusForbidden, response.Code)
+	})
+}
+
+func TestHandler_StepUpHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("returns elevated access token", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		tokenData := generator.TokenData{UserID: uuid.New(), SessionID: uuid.New()}
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/me/mfa/verify", bytes.NewBufferString(`{"code":"123456"}`))
+		injectClaim(c, tokenData)
+
+		tokens := session.Tokens{AccessToken: "access", ExpiresIn: 900}
+		mockService.EXPECT().StepUp(gomock.Any(), StepUpParams{TokenData: tokenData, Code: "123456"}).Return(tokens, nil)
+
+		response := handler.StepUpHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    tokens,
+			Message: "session verified successfully",
+		}, response.Data)
+	})
+
+	t.Run("returns unauthorized for wrong code", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/me/mfa/verify", bytes.NewBufferString(`{"code":"000000"}`))
+		injectClaim(c, generator.TokenData{UserID: uuid.New()})
+
+		mockService.EXPECT().StepUp(gomock.Any(), gomock.Any()).Return(session.Tokens{}, errInvalidCode)
+
+		response := handler.StepUpHandler(c)
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+	})
+}
+
+func TestHandler_ResetHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("resets user mfa", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		userID, adminID := uuid.New(), uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/admin/users/"+userID.String()+"/mfa/reset", nil)
+		c.Params = gin.Params{{Key: "id", Value: userID.String()}}
+		injectProfile(c, auth.Profile{UserID: adminID, UserType: "ADMIN"})
+
+		mockService.EXPECT().Reset(gomock.Any(), ResetParams{UserID: userID, ActorID: adminID}).Return(nil)
+
+		response := handler.ResetHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+
+	t.Run("rejects invalid user id", func(t *testing.T) {
+		handler := NewHandler(NewMockService(gomock.NewController(t)))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/admin/users/abc/mfa/reset", nil)
+		c.Params = gin.Params{{Key: "id", Value: "abc"}}
+
+		response := handler.ResetHandler(c)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+}
+
+func injectClaim(ctx *gin.Context, tokenData generator.TokenData) {
+	claims := &validator.ValidatedClaims{
+		CustomClaims: &generator.Claim{TokenData: tokenData},
+	}
+	ctx.Request = ctx.Request.WithContext(context.WithValue(ctx.Request.Context(), jwtmiddleware.ContextKey{}, claims))
+}
+
+func injectProfile(ctx *gin.Context, profile auth.Profile) {
+	ctx.Request = ctx.Request.WithContext(context.WithValue(ctx.Request.Context(), auth.ProfileKey, profile))
+}
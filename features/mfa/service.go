@@ -0,0 +1,469 @@
+//go:generate mockgen -source=service.go -destination=service_mock.go -package=mfa
+
+package mfa
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base32"
+	"errors"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"net/http"
+	"payter-bank/features/auditlog"
+	"payter-bank/features/session"
+	"payter-bank/internal/config"
+	"payter-bank/internal/database/models"
+	platformerrors "payter-bank/internal/errors"
+	"payter-bank/internal/logger"
+	"payter-bank/internal/pkg/generator"
+	"payter-bank/internal/pkg/totp"
+	"strings"
+	"time"
+)
+
+type Service interface {
+	Status(ctx context.Context, userID uuid.UUID) (Status, error)
+	// Enroll starts enrollment with a new secret. MFA is not enabled until Confirm is called
+	// with a code generated from it.
+	Enroll(ctx context.Context, userID uuid.UUID) (Enrollment, error)
+	Confirm(ctx context.Context, param ConfirmParams) (RecoveryCodes, error)
+	Disable(ctx context.Context, param DisableParams) error
+	// Reset removes MFA from a user who has lost their device, they can enroll again on their next login.
+	Reset(ctx context.Context, param ResetParams) error
+	IsEnabled(ctx context.Context, userID uuid.UUID) (bool, error)
+	// Challenge is issued once the password has been checked, the returned token is exchanged for
+	// access tokens together with a code through VerifyChallenge.
+	Challenge(ctx context.Context, userID uuid.UUID) (string, error)
+	VerifyChallenge(ctx context.Context, param VerifyChallengeParams) (uuid.UUID, error)
+	// StepUp checks a code for a user who is already logged in and reissues their access token
+	// as freshly verified, high-risk actions require this.
+	StepUp(ctx context.Context, param StepUpParams) (session.Tokens, error)
+}
+
+var (
+	errInvalidCode      = platformerrors.MakeApiError(http.StatusUnauthorized, "invalid mfa code")
+	errInvalidChallenge = platformerrors.MakeApiError(http.StatusUnauthorized, "mfa challenge is invalid or has expired")
+	errAlreadyEnabled   = platformerrors.MakeApiError(http.StatusBadRequest, "mfa is already enabled")
+	errNotEnabled       = platformerrors.MakeApiError(http.StatusBadRequest, "mfa is not enabled")
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type service struct {
+	db       models.Querier
+	auditLog auditlog.Service
+	sessions session.Service
+	cfg      config.MFAConfig
+	now      func() time.Time
+}
+
+func NewService(
+	db models.Querier,
+	auditLog auditlog.Service,
+	sessions session.Service,
+	cfg config.MFAConfig) Service {
+	return &service{
+		db:       db,
+		auditLog: auditLog,
+		sessions: sessions,
+		cfg:      cfg,
+		now:      time.Now,
+	}
+}
+
+func (s *service) Status(ctx context.Context, userID uuid.UUID) (Status, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Status"),
+		zap.Any(logger.RequestFields, userID))
+
+	enabled, err := s.IsEnabled(ctx, userID)
+	if err != nil || !enabled {
+		return Status{}, err
+	}
+
+	left, err := s.db.CountUnusedRecoveryCodes(ctx, userID)
+	if err != nil {
+		logger.Error(ctx, "failed to count recovery codes", zap.Error(err))
+		return Status{}, platformerrors.ErrInternal
+	}
+	return Status{Enabled: true, RecoveryCodesLeft: left}, nil
+}
+
+func (s *service) Enroll(ctx context.Context, userID uuid.UUID) (Enrollment, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Enroll"),
+		zap.Any(logger.RequestFields, userID))
+
+	user, err := s.db.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Enrollment{}, platformerrors.MakeApiError(http.StatusNotFound, "user not found")
+		}
+		logger.Error(ctx, "failed to get user by id", zap.Error(err))
+		return Enrollment{}, platformerrors.ErrInternal
+	}
+
+	enabled, err := s.IsEnabled(ctx, userID)
+	if err != nil {
+		return Enrollment{}, err
+	}
+	if enabled {
+		return Enrollment{}, errAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		logger.Error(ctx, "failed to generate totp secret", zap.Error(err))
+		return Enrollment{}, platformerrors.ErrInternal
+	}
+
+	row, err := s.db.UpsertUserMFA(ctx, models.UpsertUserMFAParams{
+		UserID: userID,
+		Secret: secret,
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to save totp secret", zap.Error(err))
+		return Enrollment{}, platformerrors.ErrInternal
+	}
+
+	return Enrollment{
+		Secret:          row.Secret,
+		ProvisioningURI: totp.ProvisioningURI(row.Secret, s.cfg.Issuer, user.Email),
+	}, nil
+}
+
+func (s *service) Confirm(ctx context.Context, param ConfirmParams) (RecoveryCodes, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Confirm"),
+		zap.Any(logger.RequestFields, param.UserID))
+
+	mfa, err := s.db.GetUserMFA(ctx, param.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return RecoveryCodes{}, platformerrors.MakeApiError(http.StatusBadRequest, "mfa enrollment has not been started")
+		}
+		logger.Error(ctx, "failed to get user mfa", zap.Error(err))
+		return RecoveryCodes{}, platformerrors.ErrInternal
+	}
+
+	if mfa.EnabledAt.Valid {
+		return RecoveryCodes{}, errAlreadyEnabled
+	}
+
+	ok, err := s.verifyTOTP(ctx, mfa, param.Code)
+	if err != nil {
+		return RecoveryCodes{}, err
+	}
+	if !ok {
+		return RecoveryCodes{}, errInvalidCode
+	}
+
+	codes, err := s.replaceRecoveryCodes(ctx, param.UserID)
+	if err != nil {
+		return RecoveryCodes{}, err
+	}
+
+	_, err = s.db.EnableUserMFA(ctx, param.UserID)
+	if err != nil {
+		logger.Error(ctx, "failed to enable mfa", zap.Error(err))
+		return RecoveryCodes{}, platformerrors.ErrInternal
+	}
+
+	s.submitAudit(ctx, auditlog.NewEvent(auditlog.ActionMFAEnabled, param.UserID, uuid.Nil,
+		auditlog.MFAChangeMetadata{UserID: param.UserID}))
+	return RecoveryCodes{Codes: codes}, nil
+}
+
+func (s *service) Disable(ctx context.Context, param DisableParams) error {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Disable"),
+		zap.Any(logger.RequestFields, param.UserID))
+
+	user, err := s.db.GetUserByID(ctx, param.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return platformerrors.MakeApiError(http.StatusNotFound, "user not found")
+		}
+		logger.Error(ctx, "failed to get user by id", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+
+	if user.UserType == models.UserTypeADMIN && s.cfg.RequiredForAdmins {
+		return platformerrors.MakeApiError(http.StatusForbidden, "admins cannot disable mfa")
+	}
+
+	mfa, err := s.enabledMFA(ctx, param.UserID)
+	if err != nil {
+		return err
+	}
+
+	ok, err := s.verifyCode(ctx, mfa, param.Code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errInvalidCode
+	}
+
+	if err := s.remove(ctx, param.UserID); err != nil {
+		return err
+	}
+
+	s.submitAudit(ctx, auditlog.NewEvent(auditlog.ActionMFADisabled, param.UserID, uuid.Nil,
+		auditlog.MFAChangeMetadata{UserID: param.UserID}))
+	return nil
+}
+
+func (s *service) Reset(ctx context.Context, param ResetParams) error {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Reset"),
+		zap.Any(logger.RequestFields, param))
+
+	_, err := s.db.GetUserByID(ctx, param.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return platformerrors.MakeApiError(http.StatusNotFound, "user not found")
+		}
+		logger.Error(ctx, "failed to get user by id", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+
+	if _, err := s.enabledMFA(ctx, param.UserID); err != nil {
+		return err
+	}
+
+	if err := s.remove(ctx, param.UserID); err != nil {
+		return err
+	}
+
+	s.submitAudit(ctx, auditlog.NewEvent(auditlog.ActionMFAReset, param.ActorID, uuid.Nil,
+		auditlog.MFAChangeMetadata{UserID: param.UserID}))
+	return nil
+}
+
+func (s *service) IsEnabled(ctx context.Context, userID uuid.UUID) (bool, error) {
+	mfa, err := s.db.GetUserMFA(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		logger.Error(ctx, "failed to get user mfa", zap.Error(err))
+		return false, platformerrors.ErrInternal
+	}
+	return mfa.EnabledAt.Valid, nil
+}
+
+func (s *service) Challenge(ctx context.Context, userID uuid.UUID) (string, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Challenge"),
+		zap.Any(logger.RequestFields, userID))
+
+	token, hash := generator.DefaultUserTokenGenerator.Generate()
+	_, err := s.db.SaveMFAChallenge(ctx, models.SaveMFAChallengeParams{
+		UserID:    userID,
+		TokenHash: hash,
+		ExpiresAt: s.now().Add(s.cfg.ChallengeTTL),
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to save mfa challenge", zap.Error(err))
+		return "", platformerrors.ErrInternal
+	}
+	return token, nil
+}
+
+func (s *service) VerifyChallenge(ctx context.Context, param VerifyChallengeParams) (uuid.UUID, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "VerifyChallenge"))
+
+	challenge, err := s.db.GetMFAChallengeByHash(ctx, generator.HashUserToken(param.ChallengeToken))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, errInvalidChallenge
+		}
+		logger.Error(ctx, "failed to get mfa challenge", zap.Error(err))
+		return uuid.Nil, platformerrors.ErrInternal
+	}
+
+	if challenge.UsedAt.Valid || !s.now().Before(challenge.ExpiresAt) || challenge.Attempts >= s.cfg.MaxAttempts {
+		return uuid.Nil, errInvalidChallenge
+	}
+
+	mfa, err := s.enabledMFA(ctx, challenge.UserID)
+	if err != nil {
+		if errors.Is(err, errNotEnabled) {
+			return uuid.Nil, errInvalidChallenge
+		}
+		return uuid.Nil, err
+	}
+
+	ok, err := s.verifyCode(ctx, mfa, param.Code)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !ok {
+		if err := s.db.IncrementMFAChallengeAttempts(ctx, challenge.ID); err != nil {
+			logger.Error(ctx, "failed to record mfa attempt", zap.Error(err))
+		}
+		return uuid.Nil, errInvalidCode
+	}
+
+	affected, err := s.db.MarkMFAChallengeUsed(ctx, challenge.ID)
+	if err != nil {
+		logger.Error(ctx, "failed to mark mfa challenge as used", zap.Error(err))
+		return uuid.Nil, platformerrors.ErrInternal
+	}
+	if affected == 0 {
+		return uuid.Nil, errInvalidChallenge
+	}
+	return challenge.UserID, nil
+}
+
+func (s *service) StepUp(ctx context.Context, param StepUpParams) (session.Tokens, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "StepUp"),
+		zap.Any(logger.RequestFields, param.TokenData.UserID))
+
+	mfa, err := s.enabledMFA(ctx, param.TokenData.UserID)
+	if err != nil {
+		return session.Tokens{}, err
+	}
+
+	ok, err := s.verifyCode(ctx, mfa, param.Code)
+	if err != nil {
+		return session.Tokens{}, err
+	}
+	if !ok {
+		return session.Tokens{}, errInvalidCode
+	}
+
+	return s.sessions.Elevate(ctx, param.TokenData)
+}
+
+func (s *service) enabledMFA(ctx context.Context, userID uuid.UUID) (models.UserMfa, error) {
+	mfa, err := s.db.GetUserMFA(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.UserMfa{}, errNotEnabled
+		}
+		logger.Error(ctx, "failed to get user mfa", zap.Error(err))
+		return models.UserMfa{}, platformerrors.ErrInternal
+	}
+
+	if !mfa.EnabledAt.Valid {
+		return models.UserMfa{}, errNotEnabled
+	}
+	return mfa, nil
+}
+
+// verifyCode accepts either a code from the authenticator app or an unused recovery code.
+func (s *service) verifyCode(ctx context.Context, mfa models.UserMfa, code string) (bool, error) {
+	code = strings.ReplaceAll(code, " ", "")
+	if len(code) == totp.Digits {
+		return s.verifyTOTP(ctx, mfa, code)
+	}
+
+	affected, err := s.db.UseRecoveryCode(ctx, models.UseRecoveryCodeParams{
+		UserID:   mfa.UserID,
+		CodeHash: hashRecoveryCode(code),
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to use recovery code", zap.Error(err))
+		return false, platformerrors.ErrInternal
+	}
+	if affected == 0 {
+		return false, nil
+	}
+
+	s.submitAudit(ctx, auditlog.NewEvent(auditlog.ActionMFARecoveryCodeUsed, mfa.UserID, uuid.Nil,
+		auditlog.MFAChangeMetadata{UserID: mfa.UserID}))
+	return true, nil
+}
+
+// verifyTOTP checks a code from the authenticator app. A code is only accepted once, the step it
+// matched must be later than the last one used.
+func (s *service) verifyTOTP(ctx context.Context, mfa models.UserMfa, code string) (bool, error) {
+	step, err := totp.Validate(mfa.Secret, code, s.now())
+	if err != nil {
+		if errors.Is(err, totp.ErrInvalidSecret) {
+			logger.Error(ctx, "stored totp secret is invalid", zap.Error(err))
+			return false, platformerrors.ErrInternal
+		}
+		return false, nil
+	}
+
+	affected, err := s.db.UpdateMFALastUsedStep(ctx, models.UpdateMFALastUsedStepParams{
+		UserID:       mfa.UserID,
+		LastUsedStep: step,
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to record totp step", zap.Error(err))
+		return false, platformerrors.ErrInternal
+	}
+	return affected == 1, nil
+}
+
+func (s *service) replaceRecoveryCodes(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := newRecoveryCode()
+		if err != nil {
+			logger.Error(ctx, "failed to generate recovery code", zap.Error(err))
+			return nil, platformerrors.ErrInternal
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+
+	if err := s.db.DeleteRecoveryCodes(ctx, userID); err != nil {
+		logger.Error(ctx, "failed to delete recovery codes", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	err := s.db.SaveRecoveryCodes(ctx, models.SaveRecoveryCodesParams{
+		UserID:     userID,
+		CodeHashes: hashes,
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to save recovery codes", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+	return codes, nil
+}
+
+func (s *service) remove(ctx context.Context, userID uuid.UUID) error {
+	if _, err := s.db.DeleteUserMFA(ctx, userID); err != nil {
+		logger.Error(ctx, "failed to delete user mfa", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+
+	if err := s.db.DeleteRecoveryCodes(ctx, userID); err != nil {
+		logger.Error(ctx, "failed to delete recovery codes", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+	return nil
+}
+
+func (s *service) submitAudit(ctx context.Context, event auditlog.Event) {
+	if err := s.auditLog.Submit(ctx, event); err != nil {
+		logger.Error(ctx, "failed to queue audit log", zap.Error(err))
+	}
+}
+
+// newRecoveryCode returns a code formatted as xxxxx-xxxxx for readability.
+func newRecoveryCode() (string, error) {
+	buf := make([]byte, 6)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	code := strings.ToLower(recoveryCodeEncoding.EncodeToString(buf))[:10]
+	return code[:5] + "-" + code[5:], nil
+}
+
+// hashRecoveryCode hashes a recovery code ignoring case and separators, as users type them in.
+func hashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	return generator.HashUserToken(code)
+}
@@ -0,0 +1,362 @@
+package mfa
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"payter-bank/features/auditlog"
+	"payter-bank/features/session"
+	"payter-bank/internal/config"
+	"payter-bank/internal/database/models"
+	databasemocks "payter-bank/internal/database/models/mocks"
+	platformerrors "payter-bank/internal/errors"
+	"payter-bank/internal/pkg/generator"
+	generatormocks "payter-bank/internal/pkg/generator/mocks"
+	"payter-bank/internal/pkg/totp"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSecret = "JBSWY3DPEHPK3PXP"
+
+var fixedNow = time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+
+func validCode(t *testing.T) string {
+	code, err := totp.Code(testSecret, fixedNow)
+	assert.NoError(t, err)
+	return code
+}
+
+func enabledMFA(userID uuid.UUID) models.UserMfa {
+	return models.UserMfa{
+		UserID:    userID,
+		Secret:    testSecret,
+		EnabledAt: sql.NullTime{Time: fixedNow.Add(-24 * time.Hour), Valid: true},
+	}
+}
+
+func TestService_Enroll(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("returns secret and provisioning uri", func(t *testing.T) {
+		m := newMFAMocker(t)
+		m.db.EXPECT().GetUserByID(gomock.Any(), userID).Return(models.GetUserByIDRow{ID: userID, Email: "jane@example.com"}, nil)
+		m.db.EXPECT().GetUserMFA(gomock.Any(), userID).Return(models.UserMfa{}, sql.ErrNoRows)
+		m.db.EXPECT().UpsertUserMFA(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, arg models.UpsertUserMFAParams) (models.UserMfa, error) {
+				assert.Equal(t, userID, arg.UserID)
+				assert.NotEmpty(t, arg.Secret)
+				return models.UserMfa{UserID: userID, Secret: testSecret}, nil
+			})
+
+		enrollment, err := m.service.Enroll(context.TODO(), userID)
+		assert.NoError(t, err)
+		assert.Equal(t, testSecret, enrollment.Secret)
+		assert.True(t, strings.HasPrefix(enrollment.ProvisioningURI, "otpauth://totp/PayterBank:jane@example.com?"))
+	})
+
+	t.Run("fails when already enabled", func(t *testing.T) {
+		m := newMFAMocker(t)
+		m.db.EXPECT().GetUserByID(gomock.Any(), userID).Return(models.GetUserByIDRow{ID: userID}, nil)
+		m.db.EXPECT().GetUserMFA(gomock.Any(), userID).Return(enabledMFA(userID), nil)
+
+		_, err := m.service.Enroll(context.TODO(), userID)
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, "mfa is already enabled"), err)
+	})
+}
+
+func TestService_Confirm(t *testing.T) {
+	userID := uuid.New()
+	pending := models.UserMfa{UserID: userID, Secret: testSecret}
+
+	t.Run("enables mfa and returns recovery codes", func(t *testing.T) {
+		m := newMFAMocker(t)
+		m.db.EXPECT().GetUserMFA(gomock.Any(), userID).Return(pending, nil)
+		m.db.EXPECT().UpdateMFALastUsedStep(gomock.Any(), models.UpdateMFALastUsedStepParams{
+			UserID:       userID,
+			LastUsedStep: totp.Step(fixedNow),
+		}).Return(int64(1), nil)
+		m.db.EXPECT().DeleteRecoveryCodes(gomock.Any(), userID).Return(nil)
+		m.db.EXPECT().SaveRecoveryCodes(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, arg models.SaveRecoveryCodesParams) error {
+				assert.Len(t, arg.CodeHashes, recoveryCodeCount)
+				return nil
+			})
+		m.db.EXPECT().EnableUserMFA(gomock.Any(), userID).Return(int64(1), nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionMFAEnabled, userID, uuid.Nil,
+			auditlog.MFAChangeMetadata{UserID: userID})).Return(nil)
+
+		codes, err := m.service.Confirm(context.TODO(), ConfirmParams{UserID: userID, Code: validCode(t)})
+		assert.NoError(t, err)
+		assert.Len(t, codes.Codes, recoveryCodeCount)
+		assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, codes.Codes[0])
+	})
+
+	t.Run("rejects wrong code", func(t *testing.T) {
+		m := newMFAMocker(t)
+		m.db.EXPECT().GetUserMFA(gomock.Any(), userID).Return(pending, nil)
+
+		_, err := m.service.Confirm(context.TODO(), ConfirmParams{UserID: userID, Code: "000000"})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusUnauthorized, "invalid mfa code"), err)
+	})
+
+	t.Run("fails when enrollment was not started", func(t *testing.T) {
+		m := newMFAMocker(t)
+		m.db.EXPECT().GetUserMFA(gomock.Any(), userID).Return(models.UserMfa{}, sql.ErrNoRows)
+
+		_, err := m.service.Confirm(context.TODO(), ConfirmParams{UserID: userID, Code: validCode(t)})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, "mfa enrollment has not been started"), err)
+	})
+}
+
+func TestService_Disable(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("disables mfa for customer", func(t *testing.T) {
+		m := newMFAMocker(t)
+		m.db.EXPECT().GetUserByID(gomock.Any(), userID).Return(models.GetUserByIDRow{ID: userID, UserType: models.UserTypeCUSTOMER}, nil)
+		m.db.EXPECT().GetUserMFA(gomock.Any(), userID).Return(enabledMFA(userID), nil)
+		m.db.EXPECT().UpdateMFALastUsedStep(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+		m.db.EXPECT().DeleteUserMFA(gomock.Any(), userID).Return(int64(1), nil)
+		m.db.EXPECT().DeleteRecoveryCodes(gomock.Any(), userID).Return(nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionMFADisabled, userID, uuid.Nil,
+			auditlog.MFAChangeMetadata{UserID: userID})).Return(nil)
+
+		err := m.service.Disable(context.TODO(), DisableParams{UserID: userID, Code: validCode(t)})
+		assert.NoError(t, err)
+	})
+
+	t.Run("admins cannot disable mfa", func(t *testing.T) {
+		m := newMFAMocker(t)
+		m.db.EXPECT().GetUserByID(gomock.Any(), userID).Return(models.GetUserByIDRow{ID: userID, UserType: models.UserTypeADMIN}, nil)
+
+		err := m.service.Disable(context.TODO(), DisableParams{UserID: userID, Code: validCode(t)})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusForbidden, "admins cannot disable mfa"), err)
+	})
+}
+
+func TestService_Reset(t *testing.T) {
+	userID, adminID := uuid.New(), uuid.New()
+
+	t.Run("removes mfa of user", func(t *testing.T) {
+		m := newMFAMocker(t)
+		m.db.EXPECT().GetUserByID(gomock.Any(), userID).Return(models.GetUserByIDRow{ID: userID}, nil)
+		m.db.EXPECT().GetUserMFA(gomock.Any(), userID).Return(enabledMFA(userID), nil)
+		m.db.EXPECT().DeleteUserMFA(gomock.Any(), userID).Return(int64(1), nil)
+		m.db.EXPECT().DeleteRecoveryCodes(gomock.Any(), userID).Return(nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionMFAReset, adminID, uuid.Nil,
+			auditlog.MFAChangeMetadata{UserID: userID})).Return(nil)
+
+		assert.NoError(t, m.service.Reset(context.TODO(), ResetParams{UserID: userID, ActorID: adminID}))
+	})
+
+	t.Run("fails when mfa is not enabled", func(t *testing.T) {
+		m := newMFAMocker(t)
+		m.db.EXPECT().GetUserByID(gomock.Any(), userID).Return(models.GetUserByIDRow{ID: userID}, nil)
+		m.db.EXPECT().GetUserMFA(gomock.Any(), userID).Return(models.UserMfa{}, sql.ErrNoRows)
+
+		err := m.service.Reset(context.TODO(), ResetParams{UserID: userID, ActorID: adminID})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, "mfa is not enabled"), err)
+	})
+}
+
+func TestService_Challenge(t *testing.T) {
+	m := newMFAMocker(t)
+	userID := uuid.New()
+
+	m.userTokenGenerator.EXPECT().Generate().Return("challenge-token", "challenge-hash")
+	m.db.EXPECT().SaveMFAChallenge(gomock.Any(), models.SaveMFAChallengeParams{
+		UserID:    userID,
+		TokenHash: "challenge-hash",
+		ExpiresAt: fixedNow.Add(5 * time.Minute),
+	}).Return(models.MfaChallenge{}, nil)
+
+	token, err := m.service.Challenge(context.TODO(), userID)
+	assert.NoError(t, err)
+	assert.Equal(t, "challenge-token", token)
+}
+
+func TestService_VerifyChallenge(t *testing.T) {
+	userID, challengeID := uuid.New(), uuid.New()
+	challenge := func() models.MfaChallenge {
+		return models.MfaChallenge{
+			ID:        challengeID,
+			UserID:    userID,
+			ExpiresAt: fixedNow.Add(time.Minute),
+		}
+	}
+
+	t.Run("accepts authenticator code", func(t *testing.T) {
+		m := newMFAMocker(t)
+		m.db.EXPECT().GetMFAChallengeByHash(gomock.Any(), generator.HashUserToken("challenge-token")).Return(challenge(), nil)
+		m.db.EXPECT().GetUserMFA(gomock.Any(), userID).Return(enabledMFA(userID), nil)
+		m.db.EXPECT().UpdateMFALastUsedStep(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+		m.db.EXPECT().MarkMFAChallengeUsed(gomock.Any(), challengeID).Return(int64(1), nil)
+
+		result, err := m.service.VerifyChallenge(context.TODO(), VerifyChallengeParams{
+			ChallengeToken: "challenge-token",
+			Code:           validCode(t),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, userID, result)
+	})
+
+	t.Run("accepts recovery code", func(t *testing.T) {
+		m := newMFAMocker(t)
+		m.db.EXPECT().GetMFAChallengeByHash(gomock.Any(), gomock.Any()).Return(challenge(), nil)
+		m.db.EXPECT().GetUserMFA(gomock.Any(), userID).Return(enabledMFA(userID), nil)
+		m.db.EXPECT().UseRecoveryCode(gomock.Any(), models.UseRecoveryCodeParams{
+			UserID:   userID,
+			CodeHash: generator.HashUserToken("abcdefghij"),
+		}).Return(int64(1), nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionMFARecoveryCodeUsed, userID, uuid.Nil,
+			auditlog.MFAChangeMetadata{UserID: userID})).Return(nil)
+		m.db.EXPECT().MarkMFAChallengeUsed(gomock.Any(), challengeID).Return(int64(1), nil)
+
+		result, err := m.service.VerifyChallenge(context.TODO(), VerifyChallengeParams{
+			ChallengeToken: "challenge-token",
+			Code:           "ABCDE-FGHIJ",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, userID, result)
+	})
+
+	t.Run("rejects replayed code", func(t *testing.T) {
+		m := newMFAMocker(t)
+		m.db.EXPECT().GetMFAChallengeByHash(gomock.Any(), gomock.Any()).Return(challenge(), nil)
+		m.db.EXPECT().GetUserMFA(gomock.Any(), userID).Return(enabledMFA(userID), nil)
+		m.db.EXPECT().UpdateMFALastUsedStep(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+		m.db.EXPECT().IncrementMFAChallengeAttempts(gomock.Any(), challengeID).Return(nil)
+
+		_, err := m.service.VerifyChallenge(context.TODO(), VerifyChallengeParams{
+			ChallengeToken: "challenge-token",
+			Code:           validCode(t),
+		})
+		assert.Equal(t, errInvalidCode, err)
+	})
+
+	t.Run("counts wrong codes", func(t *testing.T) {
+		m := newMFAMocker(t)
+		m.db.EXPECT().GetMFAChallengeByHash(gomock.Any(), gomock.Any()).Return(challenge(), nil)
+		m.db.EXPECT().GetUserMFA(gomock.Any(), userID).Return(enabledMFA(userID), nil)
+		m.db.EXPECT().IncrementMFAChallengeAttempts(gomock.Any(), challengeID).Return(nil)
+
+		_, err := m.service.VerifyChallenge(context.TODO(), VerifyChallengeParams{
+			ChallengeToken: "challenge-token",
+			Code:           "000000",
+		})
+		assert.Equal(t, errInvalidCode, err)
+	})
+
+	t.Run("rejects challenge after too many attempts", func(t *testing.T) {
+		m := newMFAMocker(t)
+		exhausted := challenge()
+		exhausted.Attempts = 5
+		m.db.EXPECT().GetMFAChallengeByHash(gomock.Any(), gomock.Any()).Return(exhausted, nil)
+
+		_, err := m.service.VerifyChallenge(context.TODO(), VerifyChallengeParams{
+			ChallengeToken: "challenge-token",
+			Code:           validCode(t),
+		})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusUnauthorized, "mfa challenge is invalid or has expired"), err)
+	})
+
+	t.Run("rejects expired challenge", func(t *testing.T) {
+		m := newMFAMocker(t)
+		expired := challenge()
+		expired.ExpiresAt = fixedNow.Add(-time.Second)
+		m.db.EXPECT().GetMFAChallengeByHash(gomock.Any(), gomock.Any()).Return(expired, nil)
+
+		_, err := m.service.VerifyChallenge(context.TODO(), VerifyChallengeParams{
+			ChallengeToken: "challenge-token",
+			Code:           validCode(t),
+		})
+		assert.Equal(t, errInvalidChallenge, err)
+	})
+
+	t.Run("rejects used challenge", func(t *testing.T) {
+		m := newMFAMocker(t)
+		used := challenge()
+		used.UsedAt = sql.NullTime{Time: fixedNow, Valid: true}
+		m.db.EXPECT().GetMFAChallengeByHash(gomock.Any(), gomock.Any()).Return(used, nil)
+
+		_, err := m.service.VerifyChallenge(context.TODO(), VerifyChallengeParams{
+			ChallengeToken: "challenge-token",
+			Code:           validCode(t),
+		})
+		assert.Equal(t, errInvalidChallenge, err)
+	})
+}
+
+func TestService_StepUp(t *testing.T) {
+	userID, sessionID := uuid.New(), uuid.New()
+	tokenData := generator.TokenData{UserID: userID, SessionID: sessionID}
+
+	t.Run("elevates session", func(t *testing.T) {
+		m := newMFAMocker(t)
+		m.db.EXPECT().GetUserMFA(gomock.Any(), userID).Return(enabledMFA(userID), nil)
+		m.db.EXPECT().UpdateMFALastUsedStep(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+		m.sessions.EXPECT().Elevate(gomock.Any(), tokenData).Return(session.Tokens{AccessToken: "access"}, nil)
+
+		tokens, err := m.service.StepUp(context.TODO(), StepUpParams{TokenData: tokenData, Code: validCode(t)})
+		assert.NoError(t, err)
+		assert.Equal(t, "access", tokens.AccessToken)
+	})
+
+	t.Run("fails when mfa is not enabled", func(t *testing.T) {
+		m := newMFAMocker(t)
+		m.db.EXPECT().GetUserMFA(gomock.Any(), userID).Return(models.UserMfa{UserID: userID, Secret: testSecret}, nil)
+
+		_, err := m.service.StepUp(context.TODO(), StepUpParams{TokenData: tokenData, Code: validCode(t)})
+		assert.Equal(t, errNotEnabled, err)
+	})
+
+	t.Run("fails on database error", func(t *testing.T) {
+		m := newMFAMocker(t)
+		m.db.EXPECT().GetUserMFA(gomock.Any(), userID).Return(models.UserMfa{}, errors.New("db down"))
+
+		_, err := m.service.StepUp(context.TODO(), StepUpParams{TokenData: tokenData, Code: validCode(t)})
+		assert.Equal(t, platformerrors.ErrInternal, err)
+	})
+}
+
+type mfaMocker struct {
+	db                 *databasemocks.MockQuerier
+	auditLog           *auditlog.MockService
+	sessions           *session.MockService
+	userTokenGenerator *generatormocks.MockUserTokenGenerator
+
+	service Service
+}
+
+func newMFAMocker(t *testing.T) *mfaMocker {
+	ctrl := gomock.NewController(t)
+	db := databasemocks.NewMockQuerier(ctrl)
+	auditLog := auditlog.NewMockService(ctrl)
+	sessions := session.NewMockService(ctrl)
+	userTokenGenerator := generatormocks.NewMockUserTokenGenerator(ctrl)
+
+	generator.DefaultUserTokenGenerator = userTokenGenerator
+
+	cfg := config.MFAConfig{
+		Issuer:            "PayterBank",
+		ChallengeTTL:      5 * time.Minute,
+		MaxAttempts:       5,
+		StepUpWindow:      5 * time.Minute,
+		RequiredForAdmins: true,
+	}
+	svc := NewService(db, auditLog, sessions, cfg)
+	svc.(*service).now = func() time.Time { return fixedNow }
+	return &mfaMocker{
+		db:                 db,
+		auditLog:           auditLog,
+		sessions:           sessions,
+		userTokenGenerator: userTokenGenerator,
+		service:            svc,
+	}
+}
@@ -0,0 +1,58 @@
+package mfa
+
+import (
+	"github.com/google/uuid"
+	"payter-bank/internal/pkg/generator"
+)
+
+const recoveryCodeCount = 10
+
+type Status struct {
+	Enabled           bool  `json:"enabled"`
+	RecoveryCodesLeft int64 `json:"recovery_codes_left"`
+}
+
+// Enrollment is shown to the user once, ProvisioningURI is rendered as a QR code for their
+// authenticator app and Secret can be typed in instead.
+type Enrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type CodeParams struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type ConfirmParams struct {
+	UserID uuid.UUID
+	Code   string
+}
+
+// RecoveryCodes are only returned when MFA is enabled, each one can be used once in place of a code.
+type RecoveryCodes struct {
+	Codes []string `json:"recovery_codes"`
+}
+
+type DisableParams struct {
+	UserID uuid.UUID
+	Code   string
+}
+
+type ResetParams struct {
+	UserID  uuid.UUID
+	ActorID uuid.UUID
+}
+
+// VerifyChallengeParams complete an MFA login. IP and UserAgent identify the client, they are
+// recorded on the session the login starts.
+type VerifyChallengeParams struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+	IP             string `json:"-"`
+	UserAgent      string `json:"-"`
+}
+
+type StepUpParams struct {
+	TokenData generator.TokenData
+	Code      string
+}
@@ -0,0 +1,75 @@
+package notification
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"payter-bank/internal/api"
+	"payter-bank/internal/auth"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// GetNotificationsHandler godoc
+// @Summary      List notifications
+// @Description  List the most recent notifications for the current user
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  api.SuccessResponse{data=[]Notification}
+// @Failure      401  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/me/notifications [get]
+func (h *Handler) GetNotificationsHandler(ctx *gin.Context) api.Response {
+	token, err := auth.GetTokenData(ctx)
+	if err != nil {
+		return api.Unauthorized(err.Error())
+	}
+
+	notifications, err := h.service.GetNotifications(ctx, token.UserID)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("notifications retrieved successfully", notifications)
+}
+
+// MarkReadHandler godoc
+// @Summary      Mark notification as read
+// @Description  Mark one of the current user's notifications as read
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  api.SuccessResponse
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/me/notifications/:id/read [patch]
+func (h *Handler) MarkReadHandler(ctx *gin.Context) api.Response {
+	notificationID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return api.BadRequest("notification id is required")
+	}
+
+	token, err := auth.GetTokenData(ctx)
+	if err != nil {
+		return api.Unauthorized(err.Error())
+	}
+
+	err = h.service.MarkRead(ctx, MarkReadParams{
+		UserID:         token.UserID,
+		NotificationID: notificationID,
+	})
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("notification marked as read", nil)
+}
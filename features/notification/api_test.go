@@ -0,0 +1,121 @@
+package notification
+
+import (
+	"context"
+	jwtmiddleware "github.com/auth0/go-jwt-middleware/v2"
+	"github.com/auth0/go-jwt-middleware/v2/validator"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"payter-bank/internal/api"
+	platformerrors "payter-bank/internal/errors"
+	"payter-bank/internal/pkg/generator"
+	"testing"
+)
+
+func TestHandler_GetNotificationsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("returns notifications for current user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := NewMockService(ctrl)
+		handler := NewHandler(mockService)
+
+		userID := uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/v1/api/me/notifications", nil)
+		injectClaim(c, userID)
+
+		notifications := []Notification{{ID: uuid.New(), Type: "account_dormant"}}
+		mockService.EXPECT().GetNotifications(gomock.Any(), userID).Return(notifications, nil)
+
+		response := handler.GetNotificationsHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    notifications,
+			Message: "notifications retrieved successfully",
+		}, response.Data)
+	})
+
+	t.Run("returns unauthorized without token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		handler := NewHandler(NewMockService(ctrl))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/v1/api/me/notifications", nil)
+
+		response := handler.GetNotificationsHandler(c)
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+	})
+}
+
+func TestHandler_MarkReadHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("marks notification as read", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := NewMockService(ctrl)
+		handler := NewHandler(mockService)
+
+		userID, notificationID := uuid.New(), uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPatch, "/v1/api/me/notifications/"+notificationID.String()+"/read", nil)
+		c.Params = gin.Params{{Key: "id", Value: notificationID.String()}}
+		injectClaim(c, userID)
+
+		mockService.EXPECT().MarkRead(gomock.Any(), MarkReadParams{
+			UserID:         userID,
+			NotificationID: notificationID,
+		}).Return(nil)
+
+		response := handler.MarkReadHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+
+	t.Run("returns service error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := NewMockService(ctrl)
+		handler := NewHandler(mockService)
+
+		notificationID := uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPatch, "/v1/api/me/notifications/"+notificationID.String()+"/read", nil)
+		c.Params = gin.Params{{Key: "id", Value: notificationID.String()}}
+		injectClaim(c, uuid.New())
+
+		mockService.EXPECT().MarkRead(gomock.Any(), gomock.Any()).
+			Return(platformerrors.MakeApiError(http.StatusNotFound, "notification not found or already read"))
+
+		response := handler.MarkReadHandler(c)
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	})
+
+	t.Run("rejects invalid notification id", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		handler := NewHandler(NewMockService(ctrl))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPatch, "/v1/api/me/notifications/x/read", nil)
+		c.Params = gin.Params{{Key: "id", Value: "x"}}
+
+		response := handler.MarkReadHandler(c)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+}
+
+func injectClaim(ctx *gin.Context, userID uuid.UUID) {
+	claims := &validator.ValidatedClaims{
+		CustomClaims: &generator.Claim{
+			TokenData: generator.TokenData{UserID: userID},
+		},
+	}
+	ctx.Request = ctx.Request.WithContext(context.WithValue(ctx.Request.Context(), jwtmiddleware.ContextKey{}, claims))
+}
@@ -0,0 +1,126 @@
+//go:generate mockgen -source=service.go -destination=service_mock.go -package=notification
+
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/sqlc-dev/pqtype"
+	"go.uber.org/zap"
+	"net/http"
+	"payter-bank/internal/database/models"
+	platformerrors "payter-bank/internal/errors"
+	"payter-bank/internal/logger"
+)
+
+type Service interface {
+	Notify(ctx context.Context, message Message) error
+	GetNotifications(ctx context.Context, userID uuid.UUID) ([]Notification, error)
+	MarkRead(ctx context.Context, param MarkReadParams) error
+}
+
+// Sender delivers a notification outside the application e.g. email or SMS.
+// Every notification is also kept in the user's in-app inbox regardless of the sender.
+type Sender interface {
+	Send(ctx context.Context, message Message) error
+}
+
+type logSender struct{}
+
+// NewLogSender returns a Sender that only logs messages, useful until a real channel is configured.
+func NewLogSender() Sender {
+	return logSender{}
+}
+
+func (logSender) Send(ctx context.Context, message Message) error {
+	logger.Info(ctx, "notification sent",
+		zap.String("user_id", message.UserID.String()),
+		zap.String("type", message.Type.String()),
+		zap.String("subject", message.Subject))
+	return nil
+}
+
+type service struct {
+	db     models.Querier
+	sender Sender
+}
+
+func NewService(db models.Querier, sender Sender) Service {
+	return &service{
+		db:     db,
+		sender: sender,
+	}
+}
+
+func (s *service) Notify(ctx context.Context, message Message) error {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Notify"),
+		zap.Any(logger.RequestFields, message))
+
+	metadata := pqtype.NullRawMessage{}
+	if message.Metadata != nil {
+		data, err := json.Marshal(message.Metadata)
+		if err != nil {
+			logger.Error(ctx, "failed to marshal notification metadata", zap.Error(err))
+			return platformerrors.ErrInternal
+		}
+		metadata = pqtype.NullRawMessage{RawMessage: data, Valid: true}
+	}
+
+	_, err := s.db.SaveNotification(ctx, models.SaveNotificationParams{
+		UserID:   message.UserID,
+		Type:     message.Type.String(),
+		Subject:  message.Subject,
+		Message:  message.Message,
+		Metadata: metadata,
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to save notification", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+
+	// the notification is already in the inbox, a delivery failure should not fail the caller
+	if err := s.sender.Send(ctx, message); err != nil {
+		logger.Warn(ctx, "failed to deliver notification", zap.Error(err))
+	}
+	return nil
+}
+
+func (s *service) GetNotifications(ctx context.Context, userID uuid.UUID) ([]Notification, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "GetNotifications"),
+		zap.Any(logger.RequestFields, userID))
+
+	rows, err := s.db.GetNotificationsByUserID(ctx, userID)
+	if err != nil {
+		logger.Error(ctx, "failed to get notifications", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	notifications := make([]Notification, 0, len(rows))
+	for _, row := range rows {
+		notifications = append(notifications, NotificationFromModel(row))
+	}
+	return notifications, nil
+}
+
+func (s *service) MarkRead(ctx context.Context, param MarkReadParams) error {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "MarkRead"),
+		zap.Any(logger.RequestFields, param))
+
+	affected, err := s.db.MarkNotificationRead(ctx, models.MarkNotificationReadParams{
+		ID:     param.NotificationID,
+		UserID: param.UserID,
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to mark notification as read", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+
+	if affected == 0 {
+		return platformerrors.MakeApiError(http.StatusNotFound, "notification not found or already read")
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/sqlc-dev/pqtype"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"payter-bank/internal/database/models"
+	databasemocks "payter-bank/internal/database/models/mocks"
+	platformerrors "payter-bank/internal/errors"
+	"testing"
+	"time"
+)
+
+func TestService_Notify(t *testing.T) {
+	t.Run("saves notification and sends it", func(t *testing.T) {
+		m := newNotificationMocker(t)
+		userID := uuid.New()
+		message := Message{
+			UserID:   userID,
+			Type:     TypeAccountDormant,
+			Subject:  "Your account is now dormant",
+			Message:  "body",
+			Metadata: map[string]string{"account_number": "12345679"},
+		}
+
+		m.db.EXPECT().SaveNotification(gomock.Any(), models.SaveNotificationParams{
+			UserID:   userID,
+			Type:     "account_dormant",
+			Subject:  "Your account is now dormant",
+			Message:  "body",
+			Metadata: pqtype.NullRawMessage{RawMessage: []byte(`{"account_number":"12345679"}`), Valid: true},
+		}).Return(models.Notification{ID: uuid.New()}, nil)
+		m.sender.EXPECT().Send(gomock.Any(), message).Return(nil)
+
+		err := m.service.Notify(context.TODO(), message)
+		assert.NoError(t, err)
+	})
+
+	t.Run("delivery failure does not fail the caller", func(t *testing.T) {
+		m := newNotificationMocker(t)
+		message := Message{UserID: uuid.New(), Type: TypeAccountReactivated, Subject: "s", Message: "m"}
+
+		m.db.EXPECT().SaveNotification(gomock.Any(), gomock.Any()).
+			Return(models.Notification{ID: uuid.New()}, nil)
+		m.sender.EXPECT().Send(gomock.Any(), message).Return(errors.New("smtp down"))
+
+		err := m.service.Notify(context.TODO(), message)
+		assert.NoError(t, err)
+	})
+
+	t.Run("fails when notification cannot be saved", func(t *testing.T) {
+		m := newNotificationMocker(t)
+		m.db.EXPECT().SaveNotification(gomock.Any(), gomock.Any()).
+			Return(models.Notification{}, errors.New("db error"))
+
+		err := m.service.Notify(context.TODO(), Message{UserID: uuid.New(), Type: TypeAccountDormant})
+		assert.Equal(t, platformerrors.ErrInternal, err)
+	})
+}
+
+func TestService_GetNotifications(t *testing.T) {
+	t.Run("returns notifications for user", func(t *testing.T) {
+		m := newNotificationMocker(t)
+		userID := uuid.New()
+		createdAt := time.Now()
+		row := models.Notification{
+			ID:        uuid.New(),
+			UserID:    userID,
+			Type:      "account_dormant",
+			Subject:   "subject",
+			Message:   "message",
+			Metadata:  pqtype.NullRawMessage{RawMessage: []byte(`{"a":1}`), Valid: true},
+			ReadAt:    sql.NullTime{Time: createdAt, Valid: true},
+			CreatedAt: sql.NullTime{Time: createdAt, Valid: true},
+		}
+		m.db.EXPECT().GetNotificationsByUserID(gomock.Any(), userID).
+			Return([]models.Notification{row}, nil)
+
+		notifications, err := m.service.GetNotifications(context.TODO(), userID)
+		assert.NoError(t, err)
+		assert.Equal(t, []Notification{{
+			ID:        row.ID,
+			Type:      "account_dormant",
+			Subject:   "subject",
+			Message:   "message",
+			Metadata:  []byte(`{"a":1}`),
+			Read:      true,
+			CreatedAt: createdAt,
+		}}, notifications)
+	})
+}
+
+func TestService_MarkRead(t *testing.T) {
+	t.Run("marks notification as read", func(t *testing.T) {
+		m := newNotificationMocker(t)
+		param := MarkReadParams{UserID: uuid.New(), NotificationID: uuid.New()}
+		m.db.EXPECT().MarkNotificationRead(gomock.Any(), models.MarkNotificationReadParams{
+			ID:     param.NotificationID,
+			UserID: param.UserID,
+		}).Return(int64(1), nil)
+
+		err := m.service.MarkRead(context.TODO(), param)
+		assert.NoError(t, err)
+	})
+
+	t.Run("returns not found when nothing was updated", func(t *testing.T) {
+		m := newNotificationMocker(t)
+		m.db.EXPECT().MarkNotificationRead(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+
+		err := m.service.MarkRead(context.TODO(), MarkReadParams{UserID: uuid.New(), NotificationID: uuid.New()})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusNotFound, "notification not found or already read"), err)
+	})
+}
+
+type notificationMocker struct {
+	db     *databasemocks.MockQuerier
+	sender *MockSender
+
+	service Service
+}
+
+func newNotificationMocker(t *testing.T) *notificationMocker {
+	ctrl := gomock.NewController(t)
+	db := databasemocks.NewMockQuerier(ctrl)
+	sender := NewMockSender(ctrl)
+	return &notificationMocker{
+		db:      db,
+		sender:  sender,
+		service: NewService(db, sender),
+	}
+}
@@ -0,0 +1,60 @@
+package notification
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"payter-bank/internal/database/models"
+	"time"
+)
+
+type Type string
+
+const (
+	TypeAccountDormant     Type = "account_dormant"
+	TypeAccountReactivated Type = "account_reactivated"
+	TypeNewDeviceLogin     Type = "new_device_login"
+	TypeInterestPaid       Type = "interest_paid"
+)
+
+func (t Type) String() string {
+	return string(t)
+}
+
+// Message is a notification addressed to a single user.
+type Message struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Type     Type      `json:"type"`
+	Subject  string    `json:"subject"`
+	Message  string    `json:"message"`
+	Metadata any       `json:"metadata"`
+}
+
+type Notification struct {
+	ID        uuid.UUID       `json:"id"`
+	Type      string          `json:"type"`
+	Subject   string          `json:"subject"`
+	Message   string          `json:"message"`
+	Metadata  json.RawMessage `json:"metadata,omitempty" swaggertype:"object"`
+	Read      bool            `json:"read"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+func NotificationFromModel(n models.Notification) Notification {
+	notification := Notification{
+		ID:        n.ID,
+		Type:      n.Type,
+		Subject:   n.Subject,
+		Message:   n.Message,
+		Read:      n.ReadAt.Valid,
+		CreatedAt: n.CreatedAt.Time,
+	}
+	if n.Metadata.Valid {
+		notification.Metadata = n.Metadata.RawMessage
+	}
+	return notification
+}
+
+type MarkReadParams struct {
+	UserID         uuid.UUID
+	NotificationID uuid.UUID
+}
@@ -0,0 +1,303 @@
+package oauth
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"payter-bank/internal/api"
+	"payter-bank/internal/auth"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// AuthorizeRedirectHandler godoc
+// @Summary      Start an authorization request
+// @Description  OAuth 2.0 authorization endpoint. Sends the user to the consent screen of the web app with the parameters of the request, the web app calls GET /api/v1/oauth/authorize to show it
+// @Tags         oauth
+// @Param        response_type          query  string  true   "Must be code"
+// @Param        client_id              query  string  true   "Client ID"
+// @Param        redirect_uri           query  string  true   "A redirect URI registered for the client"
+// @Param        scope                  query  string  true   "Space separated scopes"
+// @Param        state                  query  string  false  "Returned to the client unchanged"
+// @Param        code_challenge         query  string  true   "PKCE code challenge"
+// @Param        code_challenge_method  query  string  true   "Must be S256"
+// @Param        nonce                  query  string  false  "Put in the ID token"
+// @Success      302
+// @Router       /oauth/authorize [get]
+func (h *Handler) AuthorizeRedirectHandler(ctx *gin.Context) {
+	ctx.Redirect(http.StatusFound, h.service.ConsentURL(ctx.Request.URL.RawQuery))
+}
+
+// ConsentHandler godoc
+// @Summary      Get a consent request
+// @Description  Check an authorization request and return the client and scopes the logged in customer is asked to grant
+// @Tags         oauth
+// @Accept       json
+// @Produce      json
+// @Param        params  query  AuthorizeParams  true  "Authorization request"
+// @Success      200  {object}  api.SuccessResponse{data=Consent}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      403  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/oauth/authorize [get]
+func (h *Handler) ConsentHandler(ctx *gin.Context) api.Response {
+	var params AuthorizeParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+	params.UserID = profile.UserID
+	params.UserType = profile.UserType
+
+	consent, err := h.service.Authorize(ctx, params)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("consent request retrieved successfully", consent)
+}
+
+// DecideHandler godoc
+// @Summary      Answer a consent request
+// @Description  Approve or deny an authorization request. Returns the URI to send the user back to the client with, carrying an authorization code or error=access_denied
+// @Tags         oauth
+// @Accept       json
+// @Produce      json
+// @Param        params  body  ConsentParams  true  "Authorization request and answer"
+// @Success      200  {object}  api.SuccessResponse{data=Redirect}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      403  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/oauth/authorize [post]
+func (h *Handler) DecideHandler(ctx *gin.Context) api.Response {
+	var params ConsentParams
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+	params.UserID = profile.UserID
+	params.UserType = profile.UserType
+
+	redirect, err := h.service.Decide(ctx, params)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("consent recorded successfully", redirect)
+}
+
+// TokenHandler godoc
+// @Summary      Issue tokens
+// @Description  OAuth 2.0 token endpoint. Exchanges an authorization code with its PKCE code verifier, or a refresh token, for tokens. Confidential clients authenticate with HTTP basic auth or client_secret in the form
+// @Tags         oauth
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Param        params  formData  TokenParams  true  "Token request"
+// @Success      200  {object}  TokenResponse
+// @Failure      400  {object}  Error
+// @Failure      401  {object}  Error
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /oauth/token [post]
+func (h *Handler) TokenHandler(ctx *gin.Context) api.Response {
+	var params TokenParams
+	if err := ctx.ShouldBind(&params); err != nil {
+		return protocolError(ctx, invalidRequest(err.Error()))
+	}
+	clientCredentials(ctx, &params.ClientCredentials)
+
+	// tokens must not be cached, RFC 6749 section 5.1
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Header("Pragma", "no-cache")
+
+	tokens, err := h.service.Token(ctx, params)
+	if err != nil {
+		return protocolError(ctx, err)
+	}
+
+	return api.Response{Code: http.StatusOK, Data: tokens}
+}
+
+// IntrospectHandler godoc
+// @Summary      Introspect a token
+// @Description  RFC 7662 token introspection. Reports whether an access or refresh token issued to the calling client is active
+// @Tags         oauth
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Param        params  formData  ClientTokenParams  true  "Token"
+// @Success      200  {object}  Introspection
+// @Failure      400  {object}  Error
+// @Failure      401  {object}  Error
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /oauth/introspect [post]
+func (h *Handler) IntrospectHandler(ctx *gin.Context) api.Response {
+	var params ClientTokenParams
+	if err := ctx.ShouldBind(&params); err != nil {
+		return protocolError(ctx, invalidRequest(err.Error()))
+	}
+	clientCredentials(ctx, &params.ClientCredentials)
+
+	introspection, err := h.service.Introspect(ctx, params)
+	if err != nil {
+		return protocolError(ctx, err)
+	}
+
+	return api.Response{Code: http.StatusOK, Data: introspection}
+}
+
+// RevokeHandler godoc
+// @Summary      Revoke a token
+// @Description  RFC 7009 token revocation. Revokes the session of an access or refresh token issued to the calling client, unknown tokens are ignored
+// @Tags         oauth
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Param        params  formData  ClientTokenParams  true  "Token"
+// @Success      200
+// @Failure      400  {object}  Error
+// @Failure      401  {object}  Error
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /oauth/revoke [post]
+func (h *Handler) RevokeHandler(ctx *gin.Context) api.Response {
+	var params ClientTokenParams
+	if err := ctx.ShouldBind(&params); err != nil {
+		return protocolError(ctx, invalidRequest(err.Error()))
+	}
+	clientCredentials(ctx, &params.ClientCredentials)
+
+	if err := h.service.Revoke(ctx, params); err != nil {
+		return protocolError(ctx, err)
+	}
+
+	return api.Response{Code: http.StatusOK, Data: struct{}{}}
+}
+
+// DiscoveryHandler godoc
+// @Summary      OpenID Connect discovery
+// @Description  OpenID Connect discovery document, it answers with the bare document rather than the API envelope
+// @Tags         oauth
+// @Produce      json
+// @Success      200  {object}  Discovery
+// @Router       /.well-known/openid-configuration [get]
+func (h *Handler) DiscoveryHandler(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, h.service.Discovery())
+}
+
+// CreateClientHandler godoc
+// @Summary      Register an OAuth client
+// @Description  Register an app that customers can authorize to act on their behalf. The client secret is only returned in this response, public clients have none
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        params  body  CreateClientParams  true  "Client"
+// @Success      200  {object}  api.SuccessResponse{data=CreatedClient}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      403  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/admin/oauth/clients [post]
+func (h *Handler) CreateClientHandler(ctx *gin.Context) api.Response {
+	var params CreateClientParams
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+	params.ActorID = profile.UserID
+
+	client, err := h.service.CreateClient(ctx, params)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("oauth client created successfully", client)
+}
+
+// ListClientsHandler godoc
+// @Summary      List OAuth clients
+// @Description  List OAuth clients, newest first, including revoked ones
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  api.SuccessResponse{data=[]Client}
+// @Failure      403  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/admin/oauth/clients [get]
+func (h *Handler) ListClientsHandler(ctx *gin.Context) api.Response {
+	clients, err := h.service.ListClients(ctx)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("oauth clients retrieved successfully", clients)
+}
+
+// RevokeClientHandler godoc
+// @Summary      Revoke an OAuth client
+// @Description  Stop an OAuth client from being authorized and revoke every session it holds
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id  path  string  true  "Client ID"
+// @Success      200  {object}  api.SuccessResponse
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      412  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/admin/oauth/clients/{id}/revoke [post]
+func (h *Handler) RevokeClientHandler(ctx *gin.Context) api.Response {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return api.BadRequest("client id is required")
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+
+	err = h.service.RevokeClient(ctx, RevokeClientParams{ClientID: id, ActorID: profile.UserID})
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("oauth client revoked successfully", nil)
+}
+
+// clientCredentials takes the client credentials from HTTP basic auth when the client sent them there.
+func clientCredentials(ctx *gin.Context, credentials *ClientCredentials) {
+	if id, secret, ok := ctx.Request.BasicAuth(); ok {
+		credentials.ClientID = id
+		credentials.ClientSecret = secret
+	}
+}
+
+// protocolError answers with the error body of RFC 6749 section 5.2 rather than the API envelope.
+func protocolError(ctx *gin.Context, err error) api.Response {
+	var oauthErr *Error
+	if !errors.As(err, &oauthErr) {
+		return api.Error(err)
+	}
+	if oauthErr.Status == http.StatusUnauthorized {
+		ctx.Header("WWW-Authenticate", `Basic realm="oauth"`)
+	}
+	return api.Response{Code: oauthErr.Status, Data: oauthErr}
+}
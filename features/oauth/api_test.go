@@ -0,0 +1,143 @@
+package oauth
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"payter-bank/internal/api"
+	"payter-bank/internal/auth"
+	"strings"
+	"testing"
+)
+
+func TestHandler_AuthorizeRedirectHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := NewMockService(gomock.NewController(t))
+	handler := NewHandler(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/oauth/authorize?response_type=code&client_id=abc", nil)
+
+	mockService.EXPECT().ConsentURL("response_type=code&client_id=abc").
+		Return("http://localhost:5173/oauth/consent?response_type=code&client_id=abc")
+
+	handler.AuthorizeRedirectHandler(c)
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "http://localhost:5173/oauth/consent?response_type=code&client_id=abc", w.Header().Get("Location"))
+}
+
+func TestHandler_ConsentHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := NewMockService(gomock.NewController(t))
+	handler := NewHandler(mockService)
+
+	userID := uuid.New()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet,
+		"/v1/api/oauth/authorize?response_type=code&client_id=abc&scope=openid&state=xyz&code_challenge=challenge&code_challenge_method=S256", nil)
+	injectProfile(c, auth.Profile{UserID: userID, UserType: "CUSTOMER"})
+
+	consent := Consent{Client: ConsentClient{ID: uuid.New(), Name: "Budgeting App"}, State: "xyz"}
+	mockService.EXPECT().Authorize(gomock.Any(), AuthorizeParams{
+		ResponseType:        "code",
+		ClientID:            "abc",
+		Scope:               "openid",
+		State:               "xyz",
+		CodeChallenge:       "challenge",
+		CodeChallengeMethod: "S256",
+		UserID:              userID,
+		UserType:            "CUSTOMER",
+	}).Return(consent, nil)
+
+	response := handler.ConsentHandler(c)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, api.SuccessResponse{
+		Data:    consent,
+		Message: "consent request retrieved successfully",
+	}, response.Data)
+}
+
+func TestHandler_TokenHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {"auth-code"},
+		"redirect_uri":  {redirectURI},
+		"code_verifier": {codeVerifier},
+	}
+
+	t.Run("takes client credentials from basic auth", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/oauth/token", strings.NewReader(form.Encode()))
+		c.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		c.Request.SetBasicAuth("client-id", "client-secret")
+
+		tokens := TokenResponse{AccessToken: "access-token", TokenType: "Bearer", ExpiresIn: 900}
+		mockService.EXPECT().Token(gomock.Any(), TokenParams{
+			GrantType:         "authorization_code",
+			Code:              "auth-code",
+			RedirectURI:       redirectURI,
+			CodeVerifier:      codeVerifier,
+			ClientCredentials: ClientCredentials{ClientID: "client-id", ClientSecret: "client-secret"},
+		}).Return(tokens, nil)
+
+		response := handler.TokenHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, tokens, response.Data)
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	})
+
+	t.Run("answers with protocol error", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/oauth/token", strings.NewReader(form.Encode()))
+		c.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		mockService.EXPECT().Token(gomock.Any(), gomock.Any()).Return(TokenResponse{}, errInvalidClient)
+
+		response := handler.TokenHandler(c)
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+		assert.Equal(t, errInvalidClient, response.Data)
+		assert.Equal(t, `Basic realm="oauth"`, w.Header().Get("WWW-Authenticate"))
+	})
+}
+
+func TestHandler_RevokeClientHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := NewMockService(gomock.NewController(t))
+	handler := NewHandler(mockService)
+
+	actorID, clientID := uuid.New(), uuid.New()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/admin/oauth/clients/"+clientID.String()+"/revoke", nil)
+	c.Params = gin.Params{{Key: "id", Value: clientID.String()}}
+	injectProfile(c, auth.Profile{UserID: actorID})
+
+	mockService.EXPECT().RevokeClient(gomock.Any(), RevokeClientParams{ClientID: clientID, ActorID: actorID}).Return(nil)
+
+	response := handler.RevokeClientHandler(c)
+	assert.Equal(t, http.StatusOK, response.Code)
+}
+
+func injectProfile(ctx *gin.Context, profile auth.Profile) {
+	ctx.Request = ctx.Request.WithContext(context.WithValue(ctx.Request.Context(), auth.ProfileKey, profile))
+}
@@ -0,0 +1,673 @@
+//go:generate mockgen -source=service.go -destination=service_mock.go -package=oauth
+
+package oauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"net/http"
+	"net/url"
+	"payter-bank/features/auditlog"
+	"payter-bank/features/session"
+	"payter-bank/internal/api"
+	"payter-bank/internal/config"
+	"payter-bank/internal/database/models"
+	platformerrors "payter-bank/internal/errors"
+	"payter-bank/internal/logger"
+	"payter-bank/internal/pkg/generator"
+	"slices"
+	"strings"
+	"time"
+)
+
+type Service interface {
+	CreateClient(ctx context.Context, param CreateClientParams) (CreatedClient, error)
+	ListClients(ctx context.Context) ([]Client, error)
+	// RevokeClient stops a client from being authorized and revokes every session it holds.
+	RevokeClient(ctx context.Context, param RevokeClientParams) error
+	// Authorize checks an authorization request and returns what the user is asked to consent to.
+	Authorize(ctx context.Context, param AuthorizeParams) (Consent, error)
+	// Decide records the user's answer to an authorization request. The user is sent back to the
+	// client with an authorization code when they approve it and with access_denied when they do not.
+	Decide(ctx context.Context, param ConsentParams) (Redirect, error)
+	// Token exchanges an authorization code or a refresh token for tokens. A code presented a
+	// second time revokes the session it was exchanged for.
+	Token(ctx context.Context, param TokenParams) (TokenResponse, error)
+	// Introspect reports whether a token issued to the calling client is active.
+	Introspect(ctx context.Context, param ClientTokenParams) (Introspection, error)
+	// Revoke revokes the session of a token issued to the calling client. Unknown tokens are ignored.
+	Revoke(ctx context.Context, param ClientTokenParams) error
+	Discovery() Discovery
+	// ConsentURL is the page of the web app that asks the user to authorize a client, the query of
+	// the authorization request is passed on to it.
+	ConsentURL(query string) string
+}
+
+type service struct {
+	db             models.Querier
+	auditLog       auditlog.Service
+	sessions       session.Service
+	tokenGenerator generator.TokenGenerator
+	cfg            config.Config
+	now            func() time.Time
+}
+
+func NewService(
+	db models.Querier,
+	auditLog auditlog.Service,
+	sessions session.Service,
+	tokenGenerator generator.TokenGenerator,
+	cfg config.Config) Service {
+	return &service{
+		db:             db,
+		auditLog:       auditLog,
+		sessions:       sessions,
+		tokenGenerator: tokenGenerator,
+		cfg:            cfg,
+		now:            time.Now,
+	}
+}
+
+func (s *service) CreateClient(ctx context.Context, param CreateClientParams) (CreatedClient, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "CreateClient"),
+		zap.Any(logger.RequestFields, param))
+
+	for _, uri := range param.RedirectURIs {
+		if !validRedirectURI(uri) {
+			return CreatedClient{}, platformerrors.MakeApiError(http.StatusBadRequest,
+				"redirect uris must be absolute https urls, http is only allowed for localhost")
+		}
+	}
+	for _, scope := range param.Scopes {
+		if !slices.Contains(Scopes, scope) {
+			return CreatedClient{}, platformerrors.MakeApiError(http.StatusBadRequest, "unknown scope "+scope)
+		}
+	}
+
+	var secret string
+	var secretHash sql.NullString
+	if !param.Public {
+		secret, secretHash.String = generator.DefaultUserTokenGenerator.Generate()
+		secretHash.Valid = true
+	}
+
+	row, err := s.db.CreateOAuthClient(ctx, models.CreateOAuthClientParams{
+		Name:         param.Name,
+		SecretHash:   secretHash,
+		RedirectUris: param.RedirectURIs,
+		Scopes:       param.Scopes,
+		CreatedBy:    param.ActorID,
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to create oauth client", zap.Error(err))
+		return CreatedClient{}, platformerrors.ErrInternal
+	}
+
+	s.submitAudit(ctx, auditlog.ActionOAuthClientCreated, param.ActorID, auditlog.OAuthClientMetadata{
+		ClientID: row.ID,
+		Name:     row.Name,
+		Scopes:   row.Scopes,
+	})
+	return CreatedClient{Client: ClientFromModel(row), Secret: secret}, nil
+}
+
+func (s *service) ListClients(ctx context.Context) ([]Client, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "ListClients"))
+
+	rows, err := s.db.GetOAuthClients(ctx)
+	if err != nil {
+		logger.Error(ctx, "failed to get oauth clients", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	clients := make([]Client, 0, len(rows))
+	for _, row := range rows {
+		clients = append(clients, ClientFromModel(row))
+	}
+	return clients, nil
+}
+
+func (s *service) RevokeClient(ctx context.Context, param RevokeClientParams) error {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "RevokeClient"),
+		zap.Any(logger.RequestFields, param))
+
+	client, err := s.db.GetOAuthClient(ctx, param.ClientID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return platformerrors.MakeApiError(http.StatusNotFound, "oauth client not found")
+		}
+		logger.Error(ctx, "failed to get oauth client", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+
+	affected, err := s.db.RevokeOAuthClient(ctx, client.ID)
+	if err != nil {
+		logger.Error(ctx, "failed to revoke oauth client", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+	if affected == 0 {
+		return platformerrors.MakeApiError(http.StatusPreconditionFailed, "oauth client has already been revoked")
+	}
+
+	revoked, err := s.db.RevokeOAuthClientSessions(ctx, models.RevokeOAuthClientSessionsParams{
+		ClientID:      uuid.NullUUID{UUID: client.ID, Valid: true},
+		RevokedReason: sql.NullString{String: session.ReasonClientRevoked, Valid: true},
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to revoke oauth client sessions", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+
+	s.submitAudit(ctx, auditlog.ActionOAuthClientRevoked, param.ActorID, auditlog.OAuthClientMetadata{
+		ClientID: client.ID,
+		Name:     client.Name,
+		Revoked:  revoked,
+	})
+	return nil
+}
+
+func (s *service) Authorize(ctx context.Context, param AuthorizeParams) (Consent, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Authorize"),
+		zap.Any(logger.RequestFields, param))
+
+	client, scopes, err := s.validateAuthorization(ctx, param)
+	if err != nil {
+		return Consent{}, err
+	}
+
+	consent := Consent{
+		Client:      ConsentClient{ID: client.ID, Name: client.Name},
+		Scopes:      make([]Scope, 0, len(scopes)),
+		RedirectURI: param.RedirectURI,
+		State:       param.State,
+	}
+	for _, scope := range scopes {
+		consent.Scopes = append(consent.Scopes, Scope{Name: scope, Description: scopeDescriptions[scope]})
+	}
+	return consent, nil
+}
+
+func (s *service) Decide(ctx context.Context, param ConsentParams) (Redirect, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Decide"),
+		zap.Any(logger.RequestFields, param))
+
+	client, scopes, err := s.validateAuthorization(ctx, param.AuthorizeParams)
+	if err != nil {
+		return Redirect{}, err
+	}
+
+	if !param.Approve {
+		return redirect(param.RedirectURI, url.Values{"error": {"access_denied"}}, param.State)
+	}
+
+	code, hash := generator.DefaultUserTokenGenerator.Generate()
+	err = s.db.SaveAuthorizationCode(ctx, models.SaveAuthorizationCodeParams{
+		CodeHash:      hash,
+		ClientID:      client.ID,
+		UserID:        param.UserID,
+		RedirectUri:   param.RedirectURI,
+		Scopes:        scopes,
+		CodeChallenge: param.CodeChallenge,
+		Nonce:         sql.NullString{String: param.Nonce, Valid: param.Nonce != ""},
+		ExpiresAt:     s.now().Add(s.cfg.OAuth.CodeTTL),
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to save authorization code", zap.Error(err))
+		return Redirect{}, platformerrors.ErrInternal
+	}
+
+	s.submitAudit(ctx, auditlog.ActionOAuthConsentGranted, param.UserID, auditlog.OAuthClientMetadata{
+		ClientID: client.ID,
+		Name:     client.Name,
+		Scopes:   scopes,
+	})
+	return redirect(param.RedirectURI, url.Values{"code": {code}}, param.State)
+}
+
+func (s *service) Token(ctx context.Context, param TokenParams) (TokenResponse, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Token"),
+		zap.String("grant_type", param.GrantType),
+		zap.String("client_id", param.ClientID))
+
+	client, err := s.authenticateClient(ctx, param.ClientCredentials)
+	if err != nil {
+		return TokenResponse{}, err
+	}
+
+	switch param.GrantType {
+	case grantAuthorizationCode:
+		return s.exchangeCode(ctx, client, param)
+	case grantRefreshToken:
+		return s.refresh(ctx, client, param)
+	default:
+		return TokenResponse{}, errUnsupportedGrantType
+	}
+}
+
+func (s *service) Introspect(ctx context.Context, param ClientTokenParams) (Introspection, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Introspect"),
+		zap.String("client_id", param.ClientID))
+
+	client, err := s.authenticateClient(ctx, param.ClientCredentials)
+	if err != nil {
+		return Introspection{}, err
+	}
+	if param.Token == "" {
+		return Introspection{}, invalidRequest("token is required")
+	}
+
+	if claim, err := s.tokenGenerator.Parse(param.Token); err == nil {
+		if claim.ClientID != client.ID {
+			return Introspection{}, nil
+		}
+		jti, _ := uuid.Parse(claim.RegisteredClaims.ID)
+		revoked, err := s.db.IsAccessTokenRevoked(ctx, models.IsAccessTokenRevokedParams{
+			Jti:       jti,
+			SessionID: claim.SessionID,
+		})
+		if err != nil {
+			logger.Error(ctx, "failed to check access token revocation", zap.Error(err))
+			return Introspection{}, platformerrors.ErrInternal
+		}
+		if revoked {
+			return Introspection{}, nil
+		}
+		introspection := Introspection{
+			Active:    true,
+			Scope:     claim.Scope,
+			ClientID:  client.ID.String(),
+			Subject:   claim.Subject,
+			TokenType: tokenTypeBearer,
+			ExpiresAt: claim.RegisteredClaims.ExpiresAt.Unix(),
+			Issuer:    claim.Issuer,
+			TokenID:   claim.RegisteredClaims.ID,
+		}
+		if claim.IssuedAt != nil {
+			introspection.IssuedAt = claim.IssuedAt.Unix()
+		}
+		return introspection, nil
+	}
+
+	token, found, err := s.refreshToken(ctx, client, param.Token)
+	if err != nil || !found {
+		return Introspection{}, err
+	}
+	if token.UsedAt.Valid || token.SessionRevokedAt.Valid || !s.now().Before(token.ExpiresAt) {
+		return Introspection{}, nil
+	}
+	return Introspection{
+		Active:    true,
+		Scope:     strings.Join(token.Scopes, " "),
+		ClientID:  client.ID.String(),
+		Subject:   token.UserID.String(),
+		TokenType: grantRefreshToken,
+		ExpiresAt: token.ExpiresAt.Unix(),
+	}, nil
+}
+
+func (s *service) Revoke(ctx context.Context, param ClientTokenParams) error {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Revoke"),
+		zap.String("client_id", param.ClientID))
+
+	client, err := s.authenticateClient(ctx, param.ClientCredentials)
+	if err != nil {
+		return err
+	}
+	if param.Token == "" {
+		return invalidRequest("token is required")
+	}
+
+	var sessionID uuid.UUID
+	if claim, err := s.tokenGenerator.Parse(param.Token); err == nil {
+		if claim.ClientID != client.ID {
+			return nil
+		}
+		sessionID = claim.SessionID
+	} else {
+		token, found, err := s.refreshToken(ctx, client, param.Token)
+		if err != nil || !found {
+			return err
+		}
+		sessionID = token.SessionID
+	}
+
+	_, err = s.db.RevokeSession(ctx, models.RevokeSessionParams{
+		ID:            sessionID,
+		RevokedReason: sql.NullString{String: session.ReasonTokenRevoked, Valid: true},
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to revoke session", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+	return nil
+}
+
+func (s *service) Discovery() Discovery {
+	base := strings.TrimSuffix(s.cfg.OAuth.BaseURL, "/")
+	metadata := s.tokenGenerator.Metadata()
+	return Discovery{
+		Issuer:                            metadata.Issuer,
+		AuthorizationEndpoint:             base + "/oauth/authorize",
+		TokenEndpoint:                     base + "/oauth/token",
+		IntrospectionEndpoint:             base + "/oauth/introspect",
+		RevocationEndpoint:                base + "/oauth/revoke",
+		JWKSURI:                           base + "/.well-known/jwks.json",
+		ScopesSupported:                   Scopes,
+		ResponseTypesSupported:            []string{responseTypeCode},
+		GrantTypesSupported:               []string{grantAuthorizationCode, grantRefreshToken},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  metadata.SigningAlgorithms,
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{codeChallengeS256},
+		ClaimsSupported:                   metadata.Claims,
+	}
+}
+
+func (s *service) ConsentURL(query string) string {
+	consentURL := strings.TrimSuffix(s.cfg.App.WebURL, "/") + "/oauth/consent"
+	if query != "" {
+		consentURL += "?" + query
+	}
+	return consentURL
+}
+
+// validateAuthorization checks an authorization request against the client it names and returns the
+// client with the requested scopes. Only customers can authorize clients.
+func (s *service) validateAuthorization(ctx context.Context, param AuthorizeParams) (models.OauthClient, []string, error) {
+	if param.UserType != string(models.UserTypeCUSTOMER) {
+		return models.OauthClient{}, nil, platformerrors.MakeApiError(http.StatusForbidden, "only customers can authorize apps")
+	}
+
+	clientID, err := uuid.Parse(param.ClientID)
+	if err != nil {
+		return models.OauthClient{}, nil, platformerrors.MakeApiError(http.StatusBadRequest, "client_id is invalid")
+	}
+	client, err := s.db.GetOAuthClient(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.OauthClient{}, nil, platformerrors.MakeApiError(http.StatusBadRequest, "client_id is invalid")
+		}
+		logger.Error(ctx, "failed to get oauth client", zap.Error(err))
+		return models.OauthClient{}, nil, platformerrors.ErrInternal
+	}
+	if client.RevokedAt.Valid {
+		return models.OauthClient{}, nil, platformerrors.MakeApiError(http.StatusBadRequest, "client_id is invalid")
+	}
+
+	if !slices.Contains(client.RedirectUris, param.RedirectURI) {
+		return models.OauthClient{}, nil, platformerrors.MakeApiError(http.StatusBadRequest,
+			"redirect_uri is not registered for this client")
+	}
+	if param.ResponseType != responseTypeCode {
+		return models.OauthClient{}, nil, platformerrors.MakeApiError(http.StatusBadRequest, "response_type must be code")
+	}
+	if param.CodeChallenge == "" || param.CodeChallengeMethod != codeChallengeS256 {
+		return models.OauthClient{}, nil, platformerrors.MakeApiError(http.StatusBadRequest,
+			"a code_challenge with code_challenge_method S256 is required")
+	}
+
+	scopes := make([]string, 0)
+	for _, scope := range strings.Fields(param.Scope) {
+		if !slices.Contains(client.Scopes, scope) {
+			return models.OauthClient{}, nil, platformerrors.MakeApiError(http.StatusBadRequest,
+				"scope "+scope+" is not allowed for this client")
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	if len(scopes) == 0 {
+		return models.OauthClient{}, nil, platformerrors.MakeApiError(http.StatusBadRequest, "scope is required")
+	}
+	return client, scopes, nil
+}
+
+// authenticateClient checks the credentials of a client calling the token, introspection or
+// revocation endpoint. Public clients only send their id.
+func (s *service) authenticateClient(ctx context.Context, credentials ClientCredentials) (models.OauthClient, error) {
+	clientID, err := uuid.Parse(credentials.ClientID)
+	if err != nil {
+		return models.OauthClient{}, errInvalidClient
+	}
+
+	client, err := s.db.GetOAuthClient(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.OauthClient{}, errInvalidClient
+		}
+		logger.Error(ctx, "failed to get oauth client", zap.Error(err))
+		return models.OauthClient{}, platformerrors.ErrInternal
+	}
+	if client.RevokedAt.Valid {
+		return models.OauthClient{}, errInvalidClient
+	}
+
+	if client.SecretHash.Valid {
+		hash := generator.HashUserToken(credentials.ClientSecret)
+		if subtle.ConstantTimeCompare([]byte(hash), []byte(client.SecretHash.String)) != 1 {
+			return models.OauthClient{}, errInvalidClient
+		}
+	}
+	return client, nil
+}
+
+func (s *service) exchangeCode(ctx context.Context, client models.OauthClient, param TokenParams) (TokenResponse, error) {
+	if param.Code == "" || param.CodeVerifier == "" {
+		return TokenResponse{}, invalidRequest("code and code_verifier are required")
+	}
+
+	code, err := s.db.GetAuthorizationCodeByHash(ctx, generator.HashUserToken(param.Code))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return TokenResponse{}, invalidGrant("authorization code is invalid or has expired")
+		}
+		logger.Error(ctx, "failed to get authorization code", zap.Error(err))
+		return TokenResponse{}, platformerrors.ErrInternal
+	}
+	if code.ClientID != client.ID {
+		return TokenResponse{}, invalidGrant("authorization code is invalid or has expired")
+	}
+	if code.UsedAt.Valid {
+		s.revokeReusedCode(ctx, code)
+		return TokenResponse{}, invalidGrant("authorization code is invalid or has expired")
+	}
+	if !s.now().Before(code.ExpiresAt) {
+		return TokenResponse{}, invalidGrant("authorization code is invalid or has expired")
+	}
+	if param.RedirectURI != code.RedirectUri {
+		return TokenResponse{}, invalidGrant("redirect_uri does not match the authorization request")
+	}
+	if !verifyCodeChallenge(param.CodeVerifier, code.CodeChallenge) {
+		return TokenResponse{}, invalidGrant("code_verifier does not match the code_challenge")
+	}
+
+	affected, err := s.db.MarkAuthorizationCodeUsed(ctx, code.ID)
+	if err != nil {
+		logger.Error(ctx, "failed to mark authorization code as used", zap.Error(err))
+		return TokenResponse{}, platformerrors.ErrInternal
+	}
+	if affected == 0 {
+		// another request exchanged this code first
+		return TokenResponse{}, invalidGrant("authorization code is invalid or has expired")
+	}
+
+	// deactivated users have no profile, their codes cannot be exchanged
+	profile, err := s.db.GetProfileByUserID(ctx, code.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return TokenResponse{}, invalidGrant("authorization code is invalid or has expired")
+		}
+		logger.Error(ctx, "failed to get profile by user id", zap.Error(err))
+		return TokenResponse{}, platformerrors.ErrInternal
+	}
+
+	scope := strings.Join(code.Scopes, " ")
+	tokens, err := s.sessions.Create(ctx, generator.TokenData{
+		UserID:    profile.UserID,
+		AccountID: profile.AccountID,
+		ClientID:  client.ID,
+		Scope:     scope,
+	})
+	if err != nil {
+		return TokenResponse{}, err
+	}
+
+	err = s.db.SetAuthorizationCodeSession(ctx, models.SetAuthorizationCodeSessionParams{
+		ID:        code.ID,
+		SessionID: uuid.NullUUID{UUID: tokens.SessionID, Valid: true},
+	})
+	if err != nil {
+		logger.Warn(ctx, "failed to record session of authorization code", zap.Error(err))
+	}
+
+	response := tokenResponse(tokens)
+	if slices.Contains(code.Scopes, ScopeOpenID) {
+		data := generator.IDTokenData{
+			UserID:   profile.UserID,
+			ClientID: client.ID,
+			Nonce:    code.Nonce.String,
+		}
+		if slices.Contains(code.Scopes, ScopeProfile) {
+			data.Email = profile.Email
+			data.Name = strings.TrimSpace(profile.FirstName + " " + profile.LastName)
+		}
+		response.IDToken, err = s.tokenGenerator.GenerateIDToken(data)
+		if err != nil {
+			logger.Error(ctx, "failed to generate id token", zap.Error(err))
+			return TokenResponse{}, platformerrors.ErrInternal
+		}
+	}
+	return response, nil
+}
+
+func (s *service) refresh(ctx context.Context, client models.OauthClient, param TokenParams) (TokenResponse, error) {
+	if param.RefreshToken == "" {
+		return TokenResponse{}, invalidRequest("refresh_token is required")
+	}
+
+	tokens, err := s.sessions.Refresh(ctx, session.RefreshParams{
+		RefreshToken: param.RefreshToken,
+		ClientID:     client.ID,
+	})
+	if err != nil {
+		var apiErr *api.ApiError
+		if errors.As(err, &apiErr) && apiErr.Code == http.StatusUnauthorized {
+			return TokenResponse{}, invalidGrant(apiErr.Message)
+		}
+		return TokenResponse{}, err
+	}
+	return tokenResponse(tokens), nil
+}
+
+// refreshToken looks up a refresh token issued to client, found is false for unknown tokens and
+// tokens of other clients.
+func (s *service) refreshToken(ctx context.Context, client models.OauthClient, token string) (models.GetRefreshTokenByHashRow, bool, error) {
+	row, err := s.db.GetRefreshTokenByHash(ctx, generator.HashUserToken(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.GetRefreshTokenByHashRow{}, false, nil
+		}
+		logger.Error(ctx, "failed to get refresh token", zap.Error(err))
+		return models.GetRefreshTokenByHashRow{}, false, platformerrors.ErrInternal
+	}
+	if row.ClientID.UUID != client.ID {
+		return models.GetRefreshTokenByHashRow{}, false, nil
+	}
+	return row, true, nil
+}
+
+// revokeReusedCode revokes the session an authorization code was exchanged for when the code is
+// presented again, it is assumed to have been intercepted. RFC 6749 section 4.1.2.
+func (s *service) revokeReusedCode(ctx context.Context, code models.OauthAuthorizationCode) {
+	logger.Warn(ctx, "authorization code reused, revoking session",
+		zap.String("client_id", code.ClientID.String()),
+		zap.String("user_id", code.UserID.String()))
+	if !code.SessionID.Valid {
+		return
+	}
+
+	_, err := s.db.RevokeSession(ctx, models.RevokeSessionParams{
+		ID:            code.SessionID.UUID,
+		RevokedReason: sql.NullString{String: session.ReasonTokenReuse, Valid: true},
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to revoke session", zap.Error(err))
+	}
+}
+
+func (s *service) submitAudit(ctx context.Context, action auditlog.Action, actorID uuid.UUID, metadata any) {
+	if err := s.auditLog.Submit(ctx, auditlog.NewEvent(action, actorID, uuid.Nil, metadata)); err != nil {
+		logger.Error(ctx, "failed to queue audit log", zap.Error(err))
+	}
+}
+
+func tokenResponse(tokens session.Tokens) TokenResponse {
+	return TokenResponse{
+		AccessToken:  tokens.AccessToken,
+		TokenType:    tokenTypeBearer,
+		ExpiresIn:    tokens.ExpiresIn,
+		RefreshToken: tokens.RefreshToken,
+		Scope:        tokens.Scope,
+	}
+}
+
+// redirect adds params and state to the redirect uri of a client.
+func redirect(redirectURI string, params url.Values, state string) (Redirect, error) {
+	u, err := url.Parse(redirectURI)
+	if err != nil {
+		return Redirect{}, platformerrors.MakeApiError(http.StatusBadRequest, "redirect_uri is invalid")
+	}
+
+	query := u.Query()
+	for key, values := range params {
+		query[key] = values
+	}
+	if state != "" {
+		query.Set("state", state)
+	}
+	u.RawQuery = query.Encode()
+	return Redirect{RedirectURI: u.String()}, nil
+}
+
+// verifyCodeChallenge checks a PKCE code verifier against the S256 challenge sent with the
+// authorization request, RFC 7636 section 4.6.
+func verifyCodeChallenge(verifier, challenge string) bool {
+	if len(verifier) < 43 || len(verifier) > 128 {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	expected := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(challenge)) == 1
+}
+
+// validRedirectURI only accepts absolute https urls without a fragment, and http on localhost so
+// that apps can be developed against a local server.
+func validRedirectURI(uri string) bool {
+	u, err := url.Parse(uri)
+	if err != nil || u.Host == "" || u.Fragment != "" {
+		return false
+	}
+	switch u.Scheme {
+	case "https":
+		return true
+	case "http":
+		host := u.Hostname()
+		return host == "localhost" || host == "127.0.0.1" || host == "::1"
+	default:
+		return false
+	}
+}
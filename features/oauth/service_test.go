@@ -0,0 +1,810 @@
+package oauth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/url"
+	"payter-bank/features/auditlog"
+	"payter-bank/features/session"
+	"payter-bank/internal/config"
+	"payter-bank/internal/database/models"
+	databasemocks "payter-bank/internal/database/models/mocks"
+	platformerrors "payter-bank/internal/errors"
+	"payter-bank/internal/pkg/generator"
+	generatormocks "payter-bank/internal/pkg/generator/mocks"
+	"testing"
+	"time"
+)
+
+var fixedNow = time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+
+// code verifier and challenge from RFC 7636 appendix B
+const (
+	codeVerifier  = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	codeChallenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+)
+
+const redirectURI = "https://app.example.com/callback"
+
+var testConfig = config.Config{
+	App: config.AppConfig{WebURL: "http://localhost:5173"},
+	JWT: config.JWTConfig{
+		Secret:        "secret",
+		Expiry:        15 * time.Minute,
+		RefreshExpiry: 720 * time.Hour,
+		Issuer:        "payter-bank",
+		Audience:      "payter-bank",
+	},
+	OAuth: config.OAuthConfig{
+		BaseURL: "https://api.payterbank.app",
+		CodeTTL: 5 * time.Minute,
+	},
+}
+
+func TestService_CreateClient(t *testing.T) {
+	actorID := uuid.New()
+
+	t.Run("registers confidential client with a secret", func(t *testing.T) {
+		m := newOAuthMocker(t)
+		clientID := uuid.New()
+
+		m.userTokenGenerator.EXPECT().Generate().Return("client-secret", "secret-hash")
+		m.db.EXPECT().CreateOAuthClient(gomock.Any(), models.CreateOAuthClientParams{
+			Name:         "Budgeting App",
+			SecretHash:   sql.NullString{String: "secret-hash", Valid: true},
+			RedirectUris: []string{redirectURI},
+			Scopes:       []string{ScopeOpenID, ScopeAccountsRead},
+			CreatedBy:    actorID,
+		}).Return(models.OauthClient{
+			ID:           clientID,
+			Name:         "Budgeting App",
+			SecretHash:   sql.NullString{String: "secret-hash", Valid: true},
+			RedirectUris: []string{redirectURI},
+			Scopes:       []string{ScopeOpenID, ScopeAccountsRead},
+			CreatedBy:    actorID,
+		}, nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionOAuthClientCreated, actorID, uuid.Nil,
+			auditlog.OAuthClientMetadata{
+				ClientID: clientID,
+				Name:     "Budgeting App",
+				Scopes:   []string{ScopeOpenID, ScopeAccountsRead},
+			})).Return(nil)
+
+		client, err := m.service.CreateClient(context.TODO(), CreateClientParams{
+			Name:         "Budgeting App",
+			RedirectURIs: []string{redirectURI},
+			Scopes:       []string{ScopeOpenID, ScopeAccountsRead},
+			ActorID:      actorID,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "client-secret", client.Secret)
+		assert.Equal(t, clientID, client.ID)
+		assert.False(t, client.Public)
+	})
+
+	t.Run("registers public client without a secret", func(t *testing.T) {
+		m := newOAuthMocker(t)
+
+		m.db.EXPECT().CreateOAuthClient(gomock.Any(), models.CreateOAuthClientParams{
+			Name:         "Mobile App",
+			RedirectUris: []string{"http://localhost:8080/callback"},
+			Scopes:       []string{ScopePaymentsWrite},
+			CreatedBy:    actorID,
+		}).Return(models.OauthClient{ID: uuid.New(), Name: "Mobile App"}, nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), gomock.Any()).Return(nil)
+
+		client, err := m.service.CreateClient(context.TODO(), CreateClientParams{
+			Name:         "Mobile App",
+			RedirectURIs: []string{"http://localhost:8080/callback"},
+			Scopes:       []string{ScopePaymentsWrite},
+			Public:       true,
+			ActorID:      actorID,
+		})
+		assert.NoError(t, err)
+		assert.Empty(t, client.Secret)
+		assert.True(t, client.Public)
+	})
+
+	t.Run("rejects insecure redirect uri", func(t *testing.T) {
+		m := newOAuthMocker(t)
+
+		for _, uri := range []string{"http://app.example.com/callback", "/callback", "https://app.example.com/cb#frag", "myapp://callback"} {
+			_, err := m.service.CreateClient(context.TODO(), CreateClientParams{
+				Name:         "Budgeting App",
+				RedirectURIs: []string{uri},
+				Scopes:       []string{ScopeOpenID},
+				ActorID:      actorID,
+			})
+			assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest,
+				"redirect uris must be absolute https urls, http is only allowed for localhost"), err, uri)
+		}
+	})
+
+	t.Run("rejects unknown scope", func(t *testing.T) {
+		m := newOAuthMocker(t)
+
+		_, err := m.service.CreateClient(context.TODO(), CreateClientParams{
+			Name:         "Budgeting App",
+			RedirectURIs: []string{redirectURI},
+			Scopes:       []string{"accounts:manage"},
+			ActorID:      actorID,
+		})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, "unknown scope accounts:manage"), err)
+	})
+}
+
+func TestService_RevokeClient(t *testing.T) {
+	actorID, clientID := uuid.New(), uuid.New()
+
+	t.Run("revokes client and its sessions", func(t *testing.T) {
+		m := newOAuthMocker(t)
+
+		m.db.EXPECT().GetOAuthClient(gomock.Any(), clientID).Return(models.OauthClient{ID: clientID, Name: "Budgeting App"}, nil)
+		m.db.EXPECT().RevokeOAuthClient(gomock.Any(), clientID).Return(int64(1), nil)
+		m.db.EXPECT().RevokeOAuthClientSessions(gomock.Any(), models.RevokeOAuthClientSessionsParams{
+			ClientID:      uuid.NullUUID{UUID: clientID, Valid: true},
+			RevokedReason: sql.NullString{String: session.ReasonClientRevoked, Valid: true},
+		}).Return(int64(3), nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionOAuthClientRevoked, actorID, uuid.Nil,
+			auditlog.OAuthClientMetadata{
+				ClientID: clientID,
+				Name:     "Budgeting App",
+				Revoked:  3,
+			})).Return(nil)
+
+		err := m.service.RevokeClient(context.TODO(), RevokeClientParams{ClientID: clientID, ActorID: actorID})
+		assert.NoError(t, err)
+	})
+
+	t.Run("returns not found for unknown client", func(t *testing.T) {
+		m := newOAuthMocker(t)
+		m.db.EXPECT().GetOAuthClient(gomock.Any(), clientID).Return(models.OauthClient{}, sql.ErrNoRows)
+
+		err := m.service.RevokeClient(context.TODO(), RevokeClientParams{ClientID: clientID, ActorID: actorID})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusNotFound, "oauth client not found"), err)
+	})
+
+	t.Run("rejects client that is already revoked", func(t *testing.T) {
+		m := newOAuthMocker(t)
+		m.db.EXPECT().GetOAuthClient(gomock.Any(), clientID).Return(models.OauthClient{ID: clientID}, nil)
+		m.db.EXPECT().RevokeOAuthClient(gomock.Any(), clientID).Return(int64(0), nil)
+
+		err := m.service.RevokeClient(context.TODO(), RevokeClientParams{ClientID: clientID, ActorID: actorID})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusPreconditionFailed, "oauth client has already been revoked"), err)
+	})
+}
+
+func TestService_Authorize(t *testing.T) {
+	userID := uuid.New()
+	client := testClient()
+	request := func() AuthorizeParams {
+		return AuthorizeParams{
+			ResponseType:        "code",
+			ClientID:            client.ID.String(),
+			RedirectURI:         redirectURI,
+			Scope:               "openid accounts:read accounts:read",
+			State:               "xyz",
+			CodeChallenge:       codeChallenge,
+			CodeChallengeMethod: "S256",
+			UserID:              userID,
+			UserType:            string(models.UserTypeCUSTOMER),
+		}
+	}
+
+	t.Run("returns client and scopes to consent to", func(t *testing.T) {
+		m := newOAuthMocker(t)
+		m.db.EXPECT().GetOAuthClient(gomock.Any(), client.ID).Return(client, nil)
+
+		consent, err := m.service.Authorize(context.TODO(), request())
+		assert.NoError(t, err)
+		assert.Equal(t, Consent{
+			Client: ConsentClient{ID: client.ID, Name: client.Name},
+			Scopes: []Scope{
+				{Name: ScopeOpenID, Description: scopeDescriptions[ScopeOpenID]},
+				{Name: ScopeAccountsRead, Description: scopeDescriptions[ScopeAccountsRead]},
+			},
+			RedirectURI: redirectURI,
+			State:       "xyz",
+		}, consent)
+	})
+
+	t.Run("rejects invalid requests", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			modify  func(p *AuthorizeParams)
+			message string
+		}{
+			{"unregistered redirect uri", func(p *AuthorizeParams) { p.RedirectURI = "https://evil.example.com/callback" },
+				"redirect_uri is not registered for this client"},
+			{"unsupported response type", func(p *AuthorizeParams) { p.ResponseType = "token" },
+				"response_type must be code"},
+			{"missing code challenge", func(p *AuthorizeParams) { p.CodeChallenge = "" },
+				"a code_challenge with code_challenge_method S256 is required"},
+			{"plain code challenge", func(p *AuthorizeParams) { p.CodeChallengeMethod = "plain" },
+				"a code_challenge with code_challenge_method S256 is required"},
+			{"scope not allowed for client", func(p *AuthorizeParams) { p.Scope = "openid payments:write" },
+				"scope payments:write is not allowed for this client"},
+			{"no scope", func(p *AuthorizeParams) { p.Scope = "" },
+				"scope is required"},
+		}
+		for _, tt := range tests {
+			m := newOAuthMocker(t)
+			m.db.EXPECT().GetOAuthClient(gomock.Any(), client.ID).Return(client, nil)
+
+			param := request()
+			tt.modify(&param)
+			_, err := m.service.Authorize(context.TODO(), param)
+			assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, tt.message), err, tt.name)
+		}
+	})
+
+	t.Run("rejects revoked client", func(t *testing.T) {
+		m := newOAuthMocker(t)
+		revoked := testClient()
+		revoked.RevokedAt = sql.NullTime{Time: fixedNow, Valid: true}
+		m.db.EXPECT().GetOAuthClient(gomock.Any(), client.ID).Return(revoked, nil)
+
+		_, err := m.service.Authorize(context.TODO(), request())
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, "client_id is invalid"), err)
+	})
+
+	t.Run("only customers can authorize apps", func(t *testing.T) {
+		m := newOAuthMocker(t)
+		param := request()
+		param.UserType = string(models.UserTypeADMIN)
+
+		_, err := m.service.Authorize(context.TODO(), param)
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusForbidden, "only customers can authorize apps"), err)
+	})
+}
+
+func TestService_Decide(t *testing.T) {
+	userID := uuid.New()
+	client := testClient()
+	request := func(approve bool) ConsentParams {
+		return ConsentParams{
+			AuthorizeParams: AuthorizeParams{
+				ResponseType:        "code",
+				ClientID:            client.ID.String(),
+				RedirectURI:         redirectURI,
+				Scope:               "openid accounts:read",
+				State:               "xyz",
+				CodeChallenge:       codeChallenge,
+				CodeChallengeMethod: "S256",
+				Nonce:               "n-0S6_WzA2Mj",
+				UserID:              userID,
+				UserType:            string(models.UserTypeCUSTOMER),
+			},
+			Approve: approve,
+		}
+	}
+
+	t.Run("issues authorization code when user approves", func(t *testing.T) {
+		m := newOAuthMocker(t)
+		m.db.EXPECT().GetOAuthClient(gomock.Any(), client.ID).Return(client, nil)
+		m.userTokenGenerator.EXPECT().Generate().Return("auth-code", "code-hash")
+		m.db.EXPECT().SaveAuthorizationCode(gomock.Any(), models.SaveAuthorizationCodeParams{
+			CodeHash:      "code-hash",
+			ClientID:      client.ID,
+			UserID:        userID,
+			RedirectUri:   redirectURI,
+			Scopes:        []string{ScopeOpenID, ScopeAccountsRead},
+			CodeChallenge: codeChallenge,
+			Nonce:         sql.NullString{String: "n-0S6_WzA2Mj", Valid: true},
+			ExpiresAt:     fixedNow.Add(5 * time.Minute),
+		}).Return(nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionOAuthConsentGranted, userID, uuid.Nil,
+			auditlog.OAuthClientMetadata{
+				ClientID: client.ID,
+				Name:     client.Name,
+				Scopes:   []string{ScopeOpenID, ScopeAccountsRead},
+			})).Return(nil)
+
+		redirect, err := m.service.Decide(context.TODO(), request(true))
+		assert.NoError(t, err)
+		assert.Equal(t, redirectURI+"?code=auth-code&state=xyz", redirect.RedirectURI)
+	})
+
+	t.Run("sends user back with access_denied when they deny", func(t *testing.T) {
+		m := newOAuthMocker(t)
+		m.db.EXPECT().GetOAuthClient(gomock.Any(), client.ID).Return(client, nil)
+
+		redirect, err := m.service.Decide(context.TODO(), request(false))
+		assert.NoError(t, err)
+		assert.Equal(t, redirectURI+"?error=access_denied&state=xyz", redirect.RedirectURI)
+	})
+}
+
+func TestService_Token(t *testing.T) {
+	userID, accountID, sessionID := uuid.New(), uuid.New(), uuid.New()
+	client := testClient()
+	validCode := func() models.OauthAuthorizationCode {
+		return models.OauthAuthorizationCode{
+			ID:            uuid.New(),
+			ClientID:      client.ID,
+			UserID:        userID,
+			RedirectUri:   redirectURI,
+			Scopes:        []string{ScopeOpenID, ScopeProfile, ScopeAccountsRead},
+			CodeChallenge: codeChallenge,
+			Nonce:         sql.NullString{String: "n-0S6_WzA2Mj", Valid: true},
+			ExpiresAt:     fixedNow.Add(time.Minute),
+		}
+	}
+	exchange := func() TokenParams {
+		return TokenParams{
+			GrantType:         "authorization_code",
+			Code:              "auth-code",
+			RedirectURI:       redirectURI,
+			CodeVerifier:      codeVerifier,
+			ClientCredentials: ClientCredentials{ClientID: client.ID.String(), ClientSecret: "client-secret"},
+		}
+	}
+
+	t.Run("exchanges authorization code for tokens", func(t *testing.T) {
+		m := newOAuthMocker(t)
+		code := validCode()
+
+		m.db.EXPECT().GetOAuthClient(gomock.Any(), client.ID).Return(client, nil)
+		m.db.EXPECT().GetAuthorizationCodeByHash(gomock.Any(), generator.HashUserToken("auth-code")).Return(code, nil)
+		m.db.EXPECT().MarkAuthorizationCodeUsed(gomock.Any(), code.ID).Return(int64(1), nil)
+		m.db.EXPECT().GetProfileByUserID(gomock.Any(), userID).Return(models.GetProfileByUserIDRow{
+			UserID:    userID,
+			AccountID: accountID,
+			Email:     "jane@example.com",
+			FirstName: "Jane",
+			LastName:  "Doe",
+		}, nil)
+		m.sessions.EXPECT().Create(gomock.Any(), generator.TokenData{
+			UserID:    userID,
+			AccountID: accountID,
+			ClientID:  client.ID,
+			Scope:     "openid profile accounts:read",
+		}).Return(session.Tokens{
+			AccessToken:  "access-token",
+			RefreshToken: "refresh-token",
+			ExpiresIn:    900,
+			SessionID:    sessionID,
+			Scope:        "openid profile accounts:read",
+		}, nil)
+		m.db.EXPECT().SetAuthorizationCodeSession(gomock.Any(), models.SetAuthorizationCodeSessionParams{
+			ID:        code.ID,
+			SessionID: uuid.NullUUID{UUID: sessionID, Valid: true},
+		}).Return(nil)
+		m.tokenGenerator.EXPECT().GenerateIDToken(generator.IDTokenData{
+			UserID:   userID,
+			ClientID: client.ID,
+			Nonce:    "n-0S6_WzA2Mj",
+			Email:    "jane@example.com",
+			Name:     "Jane Doe",
+		}).Return("id-token", nil)
+
+		tokens, err := m.service.Token(context.TODO(), exchange())
+		assert.NoError(t, err)
+		assert.Equal(t, TokenResponse{
+			AccessToken:  "access-token",
+			TokenType:    "Bearer",
+			ExpiresIn:    900,
+			RefreshToken: "refresh-token",
+			Scope:        "openid profile accounts:read",
+			IDToken:      "id-token",
+		}, tokens)
+	})
+
+	t.Run("rejects code verifier that does not match", func(t *testing.T) {
+		m := newOAuthMocker(t)
+		m.db.EXPECT().GetOAuthClient(gomock.Any(), client.ID).Return(client, nil)
+		m.db.EXPECT().GetAuthorizationCodeByHash(gomock.Any(), gomock.Any()).Return(validCode(), nil)
+
+		param := exchange()
+		param.CodeVerifier = "wrong-verifier-wrong-verifier-wrong-verifier-wrong"
+		_, err := m.service.Token(context.TODO(), param)
+		assert.Equal(t, invalidGrant("code_verifier does not match the code_challenge"), err)
+	})
+
+	t.Run("rejects redirect uri that does not match", func(t *testing.T) {
+		m := newOAuthMocker(t)
+		m.db.EXPECT().GetOAuthClient(gomock.Any(), client.ID).Return(client, nil)
+		m.db.EXPECT().GetAuthorizationCodeByHash(gomock.Any(), gomock.Any()).Return(validCode(), nil)
+
+		param := exchange()
+		param.RedirectURI = "https://app.example.com/other"
+		_, err := m.service.Token(context.TODO(), param)
+		assert.Equal(t, invalidGrant("redirect_uri does not match the authorization request"), err)
+	})
+
+	t.Run("rejects expired code", func(t *testing.T) {
+		m := newOAuthMocker(t)
+		code := validCode()
+		code.ExpiresAt = fixedNow.Add(-time.Second)
+		m.db.EXPECT().GetOAuthClient(gomock.Any(), client.ID).Return(client, nil)
+		m.db.EXPECT().GetAuthorizationCodeByHash(gomock.Any(), gomock.Any()).Return(code, nil)
+
+		_, err := m.service.Token(context.TODO(), exchange())
+		assert.Equal(t, invalidGrant("authorization code is invalid or has expired"), err)
+	})
+
+	t.Run("rejects code issued to another client", func(t *testing.T) {
+		m := newOAuthMocker(t)
+		code := validCode()
+		code.ClientID = uuid.New()
+		m.db.EXPECT().GetOAuthClient(gomock.Any(), client.ID).Return(client, nil)
+		m.db.EXPECT().GetAuthorizationCodeByHash(gomock.Any(), gomock.Any()).Return(code, nil)
+
+		_, err := m.service.Token(context.TODO(), exchange())
+		assert.Equal(t, invalidGrant("authorization code is invalid or has expired"), err)
+	})
+
+	t.Run("revokes session when code is presented again", func(t *testing.T) {
+		m := newOAuthMocker(t)
+		code := validCode()
+		code.UsedAt = sql.NullTime{Time: fixedNow.Add(-time.Second), Valid: true}
+		code.SessionID = uuid.NullUUID{UUID: sessionID, Valid: true}
+		m.db.EXPECT().GetOAuthClient(gomock.Any(), client.ID).Return(client, nil)
+		m.db.EXPECT().GetAuthorizationCodeByHash(gomock.Any(), gomock.Any()).Return(code, nil)
+		m.db.EXPECT().RevokeSession(gomock.Any(), models.RevokeSessionParams{
+			ID:            sessionID,
+			RevokedReason: sql.NullString{String: session.ReasonTokenReuse, Valid: true},
+		}).Return(int64(1), nil)
+
+		_, err := m.service.Token(context.TODO(), exchange())
+		assert.Equal(t, invalidGrant("authorization code is invalid or has expired"), err)
+	})
+
+	t.Run("rejects wrong client secret", func(t *testing.T) {
+		m := newOAuthMocker(t)
+		m.db.EXPECT().GetOAuthClient(gomock.Any(), client.ID).Return(client, nil)
+
+		param := exchange()
+		param.ClientSecret = "wrong"
+		_, err := m.service.Token(context.TODO(), param)
+		assert.Equal(t, errInvalidClient, err)
+	})
+
+	t.Run("rejects unknown client", func(t *testing.T) {
+		m := newOAuthMocker(t)
+		m.db.EXPECT().GetOAuthClient(gomock.Any(), client.ID).Return(models.OauthClient{}, sql.ErrNoRows)
+
+		_, err := m.service.Token(context.TODO(), exchange())
+		assert.Equal(t, errInvalidClient, err)
+	})
+
+	t.Run("refreshes tokens of the client", func(t *testing.T) {
+		m := newOAuthMocker(t)
+		m.db.EXPECT().GetOAuthClient(gomock.Any(), client.ID).Return(client, nil)
+		m.sessions.EXPECT().Refresh(gomock.Any(), session.RefreshParams{RefreshToken: "refresh-token", ClientID: client.ID}).
+			Return(session.Tokens{AccessToken: "access-token", RefreshToken: "new-token", ExpiresIn: 900, Scope: "accounts:read"}, nil)
+
+		tokens, err := m.service.Token(context.TODO(), TokenParams{
+			GrantType:         "refresh_token",
+			RefreshToken:      "refresh-token",
+			ClientCredentials: ClientCredentials{ClientID: client.ID.String(), ClientSecret: "client-secret"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, TokenResponse{
+			AccessToken:  "access-token",
+			TokenType:    "Bearer",
+			ExpiresIn:    900,
+			RefreshToken: "new-token",
+			Scope:        "accounts:read",
+		}, tokens)
+	})
+
+	t.Run("rejects invalid refresh token", func(t *testing.T) {
+		m := newOAuthMocker(t)
+		m.db.EXPECT().GetOAuthClient(gomock.Any(), client.ID).Return(client, nil)
+		m.sessions.EXPECT().Refresh(gomock.Any(), gomock.Any()).
+			Return(session.Tokens{}, platformerrors.MakeApiError(http.StatusUnauthorized, "refresh token is invalid or has expired"))
+
+		_, err := m.service.Token(context.TODO(), TokenParams{
+			GrantType:         "refresh_token",
+			RefreshToken:      "refresh-token",
+			ClientCredentials: ClientCredentials{ClientID: client.ID.String(), ClientSecret: "client-secret"},
+		})
+		assert.Equal(t, invalidGrant("refresh token is invalid or has expired"), err)
+	})
+
+	t.Run("rejects unsupported grant type", func(t *testing.T) {
+		m := newOAuthMocker(t)
+		m.db.EXPECT().GetOAuthClient(gomock.Any(), client.ID).Return(client, nil)
+
+		_, err := m.service.Token(context.TODO(), TokenParams{
+			GrantType:         "password",
+			ClientCredentials: ClientCredentials{ClientID: client.ID.String(), ClientSecret: "client-secret"},
+		})
+		assert.Equal(t, errUnsupportedGrantType, err)
+	})
+}
+
+func TestService_Introspect(t *testing.T) {
+	client := testClient()
+	credentials := ClientCredentials{ClientID: client.ID.String(), ClientSecret: "client-secret"}
+
+	t.Run("reports refresh token of the client as active", func(t *testing.T) {
+		m := newOAuthMocker(t)
+		userID := uuid.New()
+		m.db.EXPECT().GetOAuthClient(gomock.Any(), client.ID).Return(client, nil)
+		m.tokenGenerator.EXPECT().Parse("refresh-token").Return(nil, errors.New("not a jwt"))
+		m.db.EXPECT().GetRefreshTokenByHash(gomock.Any(), generator.HashUserToken("refresh-token")).Return(models.GetRefreshTokenByHashRow{
+			UserID:    userID,
+			ExpiresAt: fixedNow.Add(time.Hour),
+			ClientID:  uuid.NullUUID{UUID: client.ID, Valid: true},
+			Scopes:    []string{ScopeAccountsRead},
+		}, nil)
+
+		introspection, err := m.service.Introspect(context.TODO(), ClientTokenParams{Token: "refresh-token", ClientCredentials: credentials})
+		assert.NoError(t, err)
+		assert.Equal(t, Introspection{
+			Active:    true,
+			Scope:     "accounts:read",
+			ClientID:  client.ID.String(),
+			Subject:   userID.String(),
+			TokenType: "refresh_token",
+			ExpiresAt: fixedNow.Add(time.Hour).Unix(),
+		}, introspection)
+	})
+
+	t.Run("reports token of another client as inactive", func(t *testing.T) {
+		m := newOAuthMocker(t)
+		m.db.EXPECT().GetOAuthClient(gomock.Any(), client.ID).Return(client, nil)
+		m.tokenGenerator.EXPECT().Parse("access-token").Return(&generator.Claim{
+			TokenData: generator.TokenData{ClientID: uuid.New()},
+		}, nil)
+
+		introspection, err := m.service.Introspect(context.TODO(), ClientTokenParams{Token: "access-token", ClientCredentials: credentials})
+		assert.NoError(t, err)
+		assert.Equal(t, Introspection{}, introspection)
+	})
+
+	t.Run("reports unknown token as inactive", func(t *testing.T) {
+		m := newOAuthMocker(t)
+		m.db.EXPECT().GetOAuthClient(gomock.Any(), client.ID).Return(client, nil)
+		m.tokenGenerator.EXPECT().Parse("unknown").Return(nil, errors.New("not a jwt"))
+		m.db.EXPECT().GetRefreshTokenByHash(gomock.Any(), gomock.Any()).Return(models.GetRefreshTokenByHashRow{}, sql.ErrNoRows)
+
+		introspection, err := m.service.Introspect(context.TODO(), ClientTokenParams{Token: "unknown", ClientCredentials: credentials})
+		assert.NoError(t, err)
+		assert.False(t, introspection.Active)
+	})
+}
+
+func TestService_Revoke(t *testing.T) {
+	client := testClient()
+	credentials := ClientCredentials{ClientID: client.ID.String(), ClientSecret: "client-secret"}
+
+	t.Run("revokes session of refresh token", func(t *testing.T) {
+		m := newOAuthMocker(t)
+		sessionID := uuid.New()
+		m.db.EXPECT().GetOAuthClient(gomock.Any(), client.ID).Return(client, nil)
+		m.tokenGenerator.EXPECT().Parse("refresh-token").Return(nil, errors.New("not a jwt"))
+		m.db.EXPECT().GetRefreshTokenByHash(gomock.Any(), gomock.Any()).Return(models.GetRefreshTokenByHashRow{
+			SessionID: sessionID,
+			ClientID:  uuid.NullUUID{UUID: client.ID, Valid: true},
+		}, nil)
+		m.db.EXPECT().RevokeSession(gomock.Any(), models.RevokeSessionParams{
+			ID:            sessionID,
+			RevokedReason: sql.NullString{String: session.ReasonTokenRevoked, Valid: true},
+		}).Return(int64(1), nil)
+
+		err := m.service.Revoke(context.TODO(), ClientTokenParams{Token: "refresh-token", ClientCredentials: credentials})
+		assert.NoError(t, err)
+	})
+
+	t.Run("ignores refresh token of another client", func(t *testing.T) {
+		m := newOAuthMocker(t)
+		m.db.EXPECT().GetOAuthClient(gomock.Any(), client.ID).Return(client, nil)
+		m.tokenGenerator.EXPECT().Parse("refresh-token").Return(nil, errors.New("not a jwt"))
+		m.db.EXPECT().GetRefreshTokenByHash(gomock.Any(), gomock.Any()).Return(models.GetRefreshTokenByHashRow{
+			SessionID: uuid.New(),
+		}, nil)
+
+		err := m.service.Revoke(context.TODO(), ClientTokenParams{Token: "refresh-token", ClientCredentials: credentials})
+		assert.NoError(t, err)
+	})
+}
+
+func TestService_Discovery(t *testing.T) {
+	m := newOAuthMocker(t)
+	m.tokenGenerator.EXPECT().Metadata().Return(generator.TokenMetadata{
+		Issuer:            "payter-bank",
+		SigningAlgorithms: []string{"ES256"},
+		Claims:            []string{"sub", "nonce"},
+	})
+
+	discovery := m.service.Discovery()
+	assert.Equal(t, "payter-bank", discovery.Issuer)
+	assert.Equal(t, "https://api.payterbank.app/oauth/authorize", discovery.AuthorizationEndpoint)
+	assert.Equal(t, "https://api.payterbank.app/oauth/token", discovery.TokenEndpoint)
+	assert.Equal(t, "https://api.payterbank.app/.well-known/jwks.json", discovery.JWKSURI)
+	assert.Equal(t, []string{"ES256"}, discovery.IDTokenSigningAlgValuesSupported)
+	assert.Equal(t, []string{"sub", "nonce"}, discovery.ClaimsSupported)
+	assert.Equal(t, []string{"S256"}, discovery.CodeChallengeMethodsSupported)
+}
+
+// TestAuthorizationCodeFlow runs a public client through consent, code exchange, refresh,
+// introspection and revocation with the real session service and token generator.
+func TestAuthorizationCodeFlow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	db := databasemocks.NewMockQuerier(ctrl)
+	auditLog := auditlog.NewMockService(ctrl)
+	auditLog.EXPECT().Submit(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	generator.DefaultUserTokenGenerator = generator.NewUserTokenGenerator()
+
+	tokenGenerator := generator.NewTokenGenerator(testConfig.JWT, generator.NewSecretKeySet(testConfig.JWT.Secret))
+	sessions := session.NewService(db, auditLog, nil, tokenGenerator, testConfig.JWT)
+	svc := NewService(db, auditLog, sessions, tokenGenerator, testConfig)
+
+	userID, accountID, sessionID := uuid.New(), uuid.New(), uuid.New()
+	client := models.OauthClient{
+		ID:           uuid.New(),
+		Name:         "Mobile App",
+		RedirectUris: []string{redirectURI},
+		Scopes:       []string{ScopeOpenID, ScopeAccountsRead},
+	}
+	db.EXPECT().GetOAuthClient(gomock.Any(), client.ID).Return(client, nil).AnyTimes()
+	db.EXPECT().GetProfileByUserID(gomock.Any(), userID).
+		Return(models.GetProfileByUserIDRow{UserID: userID, AccountID: accountID}, nil).AnyTimes()
+	db.EXPECT().IsAccessTokenRevoked(gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+
+	// the customer approves the request on the consent screen
+	var code models.OauthAuthorizationCode
+	db.EXPECT().SaveAuthorizationCode(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, arg models.SaveAuthorizationCodeParams) error {
+			code = models.OauthAuthorizationCode{
+				ID:            uuid.New(),
+				CodeHash:      arg.CodeHash,
+				ClientID:      arg.ClientID,
+				UserID:        arg.UserID,
+				RedirectUri:   arg.RedirectUri,
+				Scopes:        arg.Scopes,
+				CodeChallenge: arg.CodeChallenge,
+				Nonce:         arg.Nonce,
+				ExpiresAt:     arg.ExpiresAt,
+			}
+			return nil
+		})
+	redirect, err := svc.Decide(context.TODO(), ConsentParams{
+		AuthorizeParams: AuthorizeParams{
+			ResponseType:        "code",
+			ClientID:            client.ID.String(),
+			RedirectURI:         redirectURI,
+			Scope:               "openid accounts:read",
+			State:               "xyz",
+			CodeChallenge:       codeChallenge,
+			CodeChallengeMethod: "S256",
+			Nonce:               "n-0S6_WzA2Mj",
+			UserID:              userID,
+			UserType:            string(models.UserTypeCUSTOMER),
+		},
+		Approve: true,
+	})
+	assert.NoError(t, err)
+	callback, err := url.Parse(redirect.RedirectURI)
+	assert.NoError(t, err)
+	assert.Equal(t, "xyz", callback.Query().Get("state"))
+
+	// the app exchanges the code for tokens
+	var refreshHash string
+	db.EXPECT().GetAuthorizationCodeByHash(gomock.Any(), generator.HashUserToken(callback.Query().Get("code"))).
+		DoAndReturn(func(context.Context, string) (models.OauthAuthorizationCode, error) { return code, nil })
+	db.EXPECT().MarkAuthorizationCodeUsed(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+	db.EXPECT().SaveSession(gomock.Any(), models.SaveSessionParams{
+		UserID:   userID,
+		ClientID: uuid.NullUUID{UUID: client.ID, Valid: true},
+		Scopes:   []string{ScopeOpenID, ScopeAccountsRead},
+	}).Return(models.Session{ID: sessionID, UserID: userID}, nil)
+	db.EXPECT().SaveRefreshToken(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, arg models.SaveRefreshTokenParams) (models.RefreshToken, error) {
+			refreshHash = arg.TokenHash
+			return models.RefreshToken{}, nil
+		}).Times(2)
+	db.EXPECT().SetAuthorizationCodeSession(gomock.Any(), gomock.Any()).Return(nil)
+
+	tokens, err := svc.Token(context.TODO(), TokenParams{
+		GrantType:         "authorization_code",
+		Code:              callback.Query().Get("code"),
+		RedirectURI:       redirectURI,
+		CodeVerifier:      codeVerifier,
+		ClientCredentials: ClientCredentials{ClientID: client.ID.String()},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "openid accounts:read", tokens.Scope)
+	assert.NotEmpty(t, tokens.IDToken)
+
+	claim, err := tokenGenerator.Parse(tokens.AccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, client.ID, claim.ClientID)
+	assert.Equal(t, "openid accounts:read", claim.Scope)
+	assert.Equal(t, sessionID, claim.SessionID)
+
+	// the access token is active for the app
+	introspection, err := svc.Introspect(context.TODO(), ClientTokenParams{
+		Token:             tokens.AccessToken,
+		ClientCredentials: ClientCredentials{ClientID: client.ID.String()},
+	})
+	assert.NoError(t, err)
+	assert.True(t, introspection.Active)
+	assert.Equal(t, userID.String(), introspection.Subject)
+
+	// the app refreshes its tokens and keeps its scopes
+	refreshTokenID := uuid.New()
+	db.EXPECT().GetRefreshTokenByHash(gomock.Any(), generator.HashUserToken(tokens.RefreshToken)).
+		DoAndReturn(func(_ context.Context, hash string) (models.GetRefreshTokenByHashRow, error) {
+			assert.Equal(t, refreshHash, hash)
+			return models.GetRefreshTokenByHashRow{
+				ID:        refreshTokenID,
+				SessionID: sessionID,
+				ExpiresAt: time.Now().Add(time.Hour),
+				UserID:    userID,
+				ClientID:  uuid.NullUUID{UUID: client.ID, Valid: true},
+				Scopes:    []string{ScopeOpenID, ScopeAccountsRead},
+			}, nil
+		})
+	db.EXPECT().MarkRefreshTokenUsed(gomock.Any(), refreshTokenID).Return(int64(1), nil)
+
+	refreshed, err := svc.Token(context.TODO(), TokenParams{
+		GrantType:         "refresh_token",
+		RefreshToken:      tokens.RefreshToken,
+		ClientCredentials: ClientCredentials{ClientID: client.ID.String()},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "openid accounts:read", refreshed.Scope)
+
+	// the app revokes its access, which ends the session
+	db.EXPECT().RevokeSession(gomock.Any(), models.RevokeSessionParams{
+		ID:            sessionID,
+		RevokedReason: sql.NullString{String: session.ReasonTokenRevoked, Valid: true},
+	}).Return(int64(1), nil)
+
+	err = svc.Revoke(context.TODO(), ClientTokenParams{
+		Token:             refreshed.AccessToken,
+		ClientCredentials: ClientCredentials{ClientID: client.ID.String()},
+	})
+	assert.NoError(t, err)
+}
+
+type oauthMocker struct {
+	db                 *databasemocks.MockQuerier
+	auditLog           *auditlog.MockService
+	sessions           *session.MockService
+	tokenGenerator     *generatormocks.MockTokenGenerator
+	userTokenGenerator *generatormocks.MockUserTokenGenerator
+	service            Service
+}
+
+func newOAuthMocker(t *testing.T) *oauthMocker {
+	ctrl := gomock.NewController(t)
+	db := databasemocks.NewMockQuerier(ctrl)
+	auditLog := auditlog.NewMockService(ctrl)
+	sessions := session.NewMockService(ctrl)
+	tokenGenerator := generatormocks.NewMockTokenGenerator(ctrl)
+	userTokenGenerator := generatormocks.NewMockUserTokenGenerator(ctrl)
+
+	generator.DefaultUserTokenGenerator = userTokenGenerator
+
+	svc := NewService(db, auditLog, sessions, tokenGenerator, testConfig)
+	svc.(*service).now = func() time.Time { return fixedNow }
+	return &oauthMocker{
+		db:                 db,
+		auditLog:           auditLog,
+		sessions:           sessions,
+		tokenGenerator:     tokenGenerator,
+		userTokenGenerator: userTokenGenerator,
+		service:            svc,
+	}
+}
+
+// testClient is a confidential client whose secret is "client-secret".
+func testClient() models.OauthClient {
+	return models.OauthClient{
+		ID:           uuid.MustParse("0b7c5c3e-6a3c-4a3e-9f0e-6f1d2b3c4d5e"),
+		Name:         "Budgeting App",
+		SecretHash:   sql.NullString{String: generator.HashUserToken("client-secret"), Valid: true},
+		RedirectUris: []string{redirectURI},
+		Scopes:       []string{ScopeOpenID, ScopeProfile, ScopeAccountsRead},
+	}
+}
@@ -0,0 +1,230 @@
+package oauth
+
+import (
+	"github.com/google/uuid"
+	"net/http"
+	"payter-bank/internal/database/models"
+	"time"
+)
+
+// Scopes a client can ask for. A token issued to a client only reaches the routes its scopes allow,
+// see server/mw.go.
+const (
+	ScopeOpenID        = "openid"
+	ScopeProfile       = "profile"
+	ScopeAccountsRead  = "accounts:read"
+	ScopePaymentsWrite = "payments:write"
+)
+
+// Scopes are the supported scopes in the order they are shown on the consent screen.
+var Scopes = []string{ScopeOpenID, ScopeProfile, ScopeAccountsRead, ScopePaymentsWrite}
+
+// scopeDescriptions tell the user what they are granting on the consent screen.
+var scopeDescriptions = map[string]string{
+	ScopeOpenID:        "Confirm who you are",
+	ScopeProfile:       "See your name and email address",
+	ScopeAccountsRead:  "See your accounts, balances and transactions",
+	ScopePaymentsWrite: "Make payments from your accounts",
+}
+
+const (
+	responseTypeCode       = "code"
+	grantAuthorizationCode = "authorization_code"
+	grantRefreshToken      = "refresh_token"
+	codeChallengeS256      = "S256"
+	tokenTypeBearer        = "Bearer"
+)
+
+// Error is an error returned to a client in the format of RFC 6749 section 5.2, Status is the HTTP
+// status it is returned with.
+type Error struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+	Status      int    `json:"-"`
+}
+
+func (e *Error) Error() string {
+	return e.Code + ": " + e.Description
+}
+
+func invalidRequest(description string) *Error {
+	return &Error{Code: "invalid_request", Description: description, Status: http.StatusBadRequest}
+}
+
+func invalidGrant(description string) *Error {
+	return &Error{Code: "invalid_grant", Description: description, Status: http.StatusBadRequest}
+}
+
+var errInvalidClient = &Error{
+	Code:        "invalid_client",
+	Description: "client authentication failed",
+	Status:      http.StatusUnauthorized,
+}
+
+var errUnsupportedGrantType = &Error{
+	Code:        "unsupported_grant_type",
+	Description: "grant_type must be authorization_code or refresh_token",
+	Status:      http.StatusBadRequest,
+}
+
+// Client is an app registered to act on behalf of customers. Public clients, such as mobile apps,
+// have no secret and are only protected by PKCE.
+type Client struct {
+	ID           uuid.UUID  `json:"id"`
+	Name         string     `json:"name"`
+	Public       bool       `json:"public"`
+	RedirectURIs []string   `json:"redirect_uris"`
+	Scopes       []string   `json:"scopes"`
+	CreatedBy    uuid.UUID  `json:"created_by"`
+	CreatedAt    time.Time  `json:"created_at"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+}
+
+func ClientFromModel(m models.OauthClient) Client {
+	c := Client{
+		ID:           m.ID,
+		Name:         m.Name,
+		Public:       !m.SecretHash.Valid,
+		RedirectURIs: m.RedirectUris,
+		Scopes:       m.Scopes,
+		CreatedBy:    m.CreatedBy,
+		CreatedAt:    m.CreatedAt.Time,
+	}
+	if m.RevokedAt.Valid {
+		c.RevokedAt = &m.RevokedAt.Time
+	}
+	return c
+}
+
+// CreatedClient is returned when a client is registered, Secret cannot be retrieved again and is
+// empty for public clients.
+type CreatedClient struct {
+	Client
+	Secret string `json:"client_secret,omitempty"`
+}
+
+// CreateClientParams register a client. Redirect URIs must be https, or http on localhost for
+// development, and are matched exactly. Scopes are the ones the client may ask users for.
+type CreateClientParams struct {
+	Name         string    `json:"name" binding:"required"`
+	RedirectURIs []string  `json:"redirect_uris" binding:"required,min=1,dive,required"`
+	Scopes       []string  `json:"scopes" binding:"required,min=1,dive,required"`
+	Public       bool      `json:"public"`
+	ActorID      uuid.UUID `json:"-"`
+}
+
+type RevokeClientParams struct {
+	ClientID uuid.UUID `json:"-"`
+	ActorID  uuid.UUID `json:"-"`
+}
+
+// AuthorizeParams are the parameters of an authorization request, RFC 6749 section 4.1.1 with the
+// PKCE parameters of RFC 7636. Only the S256 code challenge method is accepted. UserID is the user
+// asked for consent.
+type AuthorizeParams struct {
+	ResponseType        string    `form:"response_type" json:"response_type"`
+	ClientID            string    `form:"client_id" json:"client_id"`
+	RedirectURI         string    `form:"redirect_uri" json:"redirect_uri"`
+	Scope               string    `form:"scope" json:"scope"`
+	State               string    `form:"state" json:"state"`
+	CodeChallenge       string    `form:"code_challenge" json:"code_challenge"`
+	CodeChallengeMethod string    `form:"code_challenge_method" json:"code_challenge_method"`
+	Nonce               string    `form:"nonce" json:"nonce"`
+	UserID              uuid.UUID `form:"-" json:"-"`
+	UserType            string    `form:"-" json:"-"`
+}
+
+// ConsentParams carry the user's answer to an authorization request.
+type ConsentParams struct {
+	AuthorizeParams
+	Approve bool `json:"approve"`
+}
+
+// Consent is what the consent screen shows the user before they authorize a client.
+type Consent struct {
+	Client      ConsentClient `json:"client"`
+	Scopes      []Scope       `json:"scopes"`
+	RedirectURI string        `json:"redirect_uri"`
+	State       string        `json:"state,omitempty"`
+}
+
+type ConsentClient struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+type Scope struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Redirect is where the user is sent back to the client, with either a code or an error.
+type Redirect struct {
+	RedirectURI string `json:"redirect_uri"`
+}
+
+// ClientCredentials authenticate a client, they are taken from the form or HTTP basic auth.
+// ClientSecret is empty for public clients.
+type ClientCredentials struct {
+	ClientID     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+}
+
+// TokenParams are the parameters of a token request, RFC 6749 sections 4.1.3 and 6.
+type TokenParams struct {
+	GrantType    string `form:"grant_type"`
+	Code         string `form:"code"`
+	RedirectURI  string `form:"redirect_uri"`
+	CodeVerifier string `form:"code_verifier"`
+	RefreshToken string `form:"refresh_token"`
+	ClientCredentials
+}
+
+// TokenResponse is RFC 6749 section 5.1, IDToken is set when the user granted the openid scope.
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Scope        string `json:"scope"`
+	IDToken      string `json:"id_token,omitempty"`
+}
+
+// ClientTokenParams name a token a client wants to introspect or revoke, RFC 7662 and RFC 7009.
+// TokenTypeHint is accepted but not needed, both token types are looked up.
+type ClientTokenParams struct {
+	Token         string `form:"token"`
+	TokenTypeHint string `form:"token_type_hint"`
+	ClientCredentials
+}
+
+// Introspection is RFC 7662 section 2.2. Tokens issued to another client are reported inactive.
+type Introspection struct {
+	Active    bool   `json:"active"`
+	Scope     string `json:"scope,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	Subject   string `json:"sub,omitempty"`
+	TokenType string `json:"token_type,omitempty"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+	IssuedAt  int64  `json:"iat,omitempty"`
+	Issuer    string `json:"iss,omitempty"`
+	TokenID   string `json:"jti,omitempty"`
+}
+
+// Discovery is the OpenID Connect discovery document, OpenID Connect Discovery 1.0 section 3.
+type Discovery struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	IntrospectionEndpoint             string   `json:"introspection_endpoint"`
+	RevocationEndpoint                string   `json:"revocation_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+}
@@ -0,0 +1,103 @@
+package rbac
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"payter-bank/internal/api"
+	"payter-bank/internal/auth"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// ListRolesHandler godoc
+// @Summary      List roles
+// @Description  List the roles that can be given to admin users and their permissions
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  api.SuccessResponse{data=[]Role}
+// @Failure      403  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/admin/roles [get]
+func (h *Handler) ListRolesHandler(ctx *gin.Context) api.Response {
+	roles, err := h.service.ListRoles(ctx)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("roles retrieved successfully", roles)
+}
+
+// GetUserRolesHandler godoc
+// @Summary      Get user roles
+// @Description  Get the roles of a user
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id  path  string  true  "User ID"
+// @Success      200  {object}  api.SuccessResponse{data=UserRoles}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/admin/users/{id}/roles [get]
+func (h *Handler) GetUserRolesHandler(ctx *gin.Context) api.Response {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return api.BadRequest("user id is required")
+	}
+
+	roles, err := h.service.GetUserRoles(ctx, userID)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("user roles retrieved successfully", roles)
+}
+
+// SetUserRolesHandler godoc
+// @Summary      Set user roles
+// @Description  Replace the roles of an admin user, an empty list removes all of them. Admins cannot change their own roles
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id      path  string              true  "User ID"
+// @Param        params  body  SetUserRolesParams  true  "Roles"
+// @Success      200  {object}  api.SuccessResponse{data=UserRoles}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      403  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/admin/users/{id}/roles [put]
+func (h *Handler) SetUserRolesHandler(ctx *gin.Context) api.Response {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return api.BadRequest("user id is required")
+	}
+
+	var params SetUserRolesParams
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+	params.UserID = userID
+	params.ActorID = profile.UserID
+
+	roles, err := h.service.SetUserRoles(ctx, params)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("user roles updated successfully", roles)
+}
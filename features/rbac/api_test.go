@@ -0,0 +1,144 @@
+package rbac
+
+import (
+	"bytes"
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"payter-bank/internal/api"
+	"payter-bank/internal/auth"
+	platformerrors "payter-bank/internal/errors"
+	"testing"
+)
+
+func TestHandler_ListRolesHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("lists roles", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/v1/api/admin/roles", nil)
+
+		roles := []Role{{Name: "treasury", Permissions: []string{"transactions:write"}}}
+		mockService.EXPECT().ListRoles(gomock.Any()).Return(roles, nil)
+
+		response := handler.ListRolesHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    roles,
+			Message: "roles retrieved successfully",
+		}, response.Data)
+	})
+}
+
+func TestHandler_GetUserRolesHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("returns user roles", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		userID := uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/v1/api/admin/users/"+userID.String()+"/roles", nil)
+		c.Params = gin.Params{{Key: "id", Value: userID.String()}}
+
+		roles := UserRoles{UserID: userID, Roles: []string{"operations"}}
+		mockService.EXPECT().GetUserRoles(gomock.Any(), userID).Return(roles, nil)
+
+		response := handler.GetUserRolesHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    roles,
+			Message: "user roles retrieved successfully",
+		}, response.Data)
+	})
+
+	t.Run("rejects invalid user id", func(t *testing.T) {
+		handler := NewHandler(NewMockService(gomock.NewController(t)))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/v1/api/admin/users/abc/roles", nil)
+		c.Params = gin.Params{{Key: "id", Value: "abc"}}
+
+		response := handler.GetUserRolesHandler(c)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestHandler_SetUserRolesHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("sets user roles", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		userID, adminID := uuid.New(), uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPut, "/v1/api/admin/users/"+userID.String()+"/roles",
+			bytes.NewBufferString(`{"roles":["operations"]}`))
+		c.Params = gin.Params{{Key: "id", Value: userID.String()}}
+		injectProfile(c, auth.Profile{UserID: adminID, UserType: "ADMIN"})
+
+		roles := UserRoles{UserID: userID, Roles: []string{"operations"}}
+		mockService.EXPECT().SetUserRoles(gomock.Any(), SetUserRolesParams{
+			UserID:  userID,
+			Roles:   []string{"operations"},
+			ActorID: adminID,
+		}).Return(roles, nil)
+
+		response := handler.SetUserRolesHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    roles,
+			Message: "user roles updated successfully",
+		}, response.Data)
+	})
+
+	t.Run("rejects missing roles", func(t *testing.T) {
+		handler := NewHandler(NewMockService(gomock.NewController(t)))
+
+		userID := uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPut, "/v1/api/admin/users/"+userID.String()+"/roles",
+			bytes.NewBufferString(`{}`))
+		c.Params = gin.Params{{Key: "id", Value: userID.String()}}
+
+		response := handler.SetUserRolesHandler(c)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+
+	t.Run("returns forbidden when changing own roles", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		adminID := uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPut, "/v1/api/admin/users/"+adminID.String()+"/roles",
+			bytes.NewBufferString(`{"roles":[]}`))
+		c.Params = gin.Params{{Key: "id", Value: adminID.String()}}
+		injectProfile(c, auth.Profile{UserID: adminID})
+
+		mockService.EXPECT().SetUserRoles(gomock.Any(), gomock.Any()).
+			Return(UserRoles{}, platformerrors.MakeApiError(http.StatusForbidden, "you cannot change your own roles"))
+
+		response := handler.SetUserRolesHandler(c)
+		assert.Equal(t, http.StatusForbidden, response.Code)
+	})
+}
+
+func injectProfile(ctx *gin.Context, profile auth.Profile) {
+	ctx.Request = ctx.Request.WithContext(context.WithValue(ctx.Request.Context(), auth.ProfileKey, profile))
+}
@@ -0,0 +1,157 @@
+//go:generate mockgen -source=service.go -destination=service_mock.go -package=rbac
+
+package rbac
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"net/http"
+	"payter-bank/features/auditlog"
+	"payter-bank/internal/database/models"
+	platformerrors "payter-bank/internal/errors"
+	"payter-bank/internal/logger"
+	"slices"
+)
+
+type Service interface {
+	ListRoles(ctx context.Context) ([]Role, error)
+	GetUserRoles(ctx context.Context, userID uuid.UUID) (UserRoles, error)
+	// SetUserRoles replaces the roles of an admin user. Admins cannot change their own roles.
+	SetUserRoles(ctx context.Context, param SetUserRolesParams) (UserRoles, error)
+}
+
+type service struct {
+	db       models.Querier
+	auditLog auditlog.Service
+}
+
+func NewService(db models.Querier, auditLog auditlog.Service) Service {
+	return &service{
+		db:       db,
+		auditLog: auditLog,
+	}
+}
+
+func (s *service) ListRoles(ctx context.Context) ([]Role, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "ListRoles"))
+
+	rows, err := s.db.GetRoles(ctx)
+	if err != nil {
+		logger.Error(ctx, "failed to get roles", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	roles := make([]Role, 0, len(rows))
+	for _, row := range rows {
+		roles = append(roles, RoleFromRow(row))
+	}
+	return roles, nil
+}
+
+func (s *service) GetUserRoles(ctx context.Context, userID uuid.UUID) (UserRoles, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "GetUserRoles"),
+		zap.Any(logger.RequestFields, userID))
+
+	if _, err := s.getUser(ctx, userID); err != nil {
+		return UserRoles{}, err
+	}
+
+	roles, err := s.userRoleNames(ctx, userID)
+	if err != nil {
+		return UserRoles{}, err
+	}
+	return UserRoles{UserID: userID, Roles: roles}, nil
+}
+
+func (s *service) SetUserRoles(ctx context.Context, param SetUserRolesParams) (UserRoles, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "SetUserRoles"),
+		zap.Any(logger.RequestFields, param))
+
+	if param.UserID == param.ActorID {
+		return UserRoles{}, platformerrors.MakeApiError(http.StatusForbidden, "you cannot change your own roles")
+	}
+
+	user, err := s.getUser(ctx, param.UserID)
+	if err != nil {
+		return UserRoles{}, err
+	}
+
+	newRoles := slices.Clone(param.Roles)
+	slices.Sort(newRoles)
+	newRoles = slices.Compact(newRoles)
+
+	if len(newRoles) > 0 && user.UserType != models.UserTypeADMIN {
+		return UserRoles{}, platformerrors.MakeApiError(http.StatusBadRequest, "roles can only be given to admin users")
+	}
+
+	known, err := s.db.GetRolesByNames(ctx, newRoles)
+	if err != nil {
+		logger.Error(ctx, "failed to get roles by names", zap.Error(err))
+		return UserRoles{}, platformerrors.ErrInternal
+	}
+	for _, name := range newRoles {
+		if !slices.ContainsFunc(known, func(role models.Role) bool { return role.Name == name }) {
+			return UserRoles{}, platformerrors.MakeApiError(http.StatusBadRequest, fmt.Sprintf("unknown role %s", name))
+		}
+	}
+
+	oldRoles, err := s.userRoleNames(ctx, param.UserID)
+	if err != nil {
+		return UserRoles{}, err
+	}
+
+	err = s.db.SetUserRoles(ctx, models.SetUserRolesParams{
+		UserID:    param.UserID,
+		RoleNames: newRoles,
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to set user roles", zap.Error(err))
+		return UserRoles{}, platformerrors.ErrInternal
+	}
+
+	s.submitAudit(ctx, auditlog.NewEvent(auditlog.ActionUserRolesChange, param.ActorID, uuid.Nil,
+		auditlog.UserRolesChangeMetadata{
+			UserID:   param.UserID,
+			OldRoles: oldRoles,
+			NewRoles: newRoles,
+		}))
+
+	return UserRoles{UserID: param.UserID, Roles: newRoles}, nil
+}
+
+func (s *service) userRoleNames(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	roles, err := s.db.GetUserRoleNames(ctx, userID)
+	if err != nil {
+		logger.Error(ctx, "failed to get user roles", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+	if roles == nil {
+		roles = []string{}
+	}
+	return roles, nil
+}
+
+func (s *service) getUser(ctx context.Context, userID uuid.UUID) (models.GetUserByIDRow, error) {
+	user, err := s.db.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.GetUserByIDRow{}, platformerrors.MakeApiError(http.StatusNotFound, "user not found")
+		}
+		logger.Error(ctx, "failed to get user by id", zap.Error(err))
+		return models.GetUserByIDRow{}, platformerrors.ErrInternal
+	}
+	return user, nil
+}
+
+func (s *service) submitAudit(ctx context.Context, event auditlog.Event) {
+	if err := s.auditLog.Submit(ctx, event); err != nil {
+		logger.Error(ctx, "failed to queue audit log", zap.Error(err))
+	}
+}
@@ -0,0 +1,198 @@
+package rbac
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"payter-bank/features/auditlog"
+	"payter-bank/internal/database/models"
+	databasemocks "payter-bank/internal/database/models/mocks"
+	platformerrors "payter-bank/internal/errors"
+	"testing"
+)
+
+func TestService_ListRoles(t *testing.T) {
+	t.Run("lists roles with permissions", func(t *testing.T) {
+		m := newRBACMocker(t)
+		m.db.EXPECT().GetRoles(gomock.Any()).Return([]models.GetRolesRow{
+			{Name: "support_agent", Description: "Read-only", Permissions: []string{"accounts:read", "users:read"}},
+		}, nil)
+
+		roles, err := m.service.ListRoles(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, []Role{
+			{Name: "support_agent", Description: "Read-only", Permissions: []string{"accounts:read", "users:read"}},
+		}, roles)
+	})
+
+	t.Run("fails on database error", func(t *testing.T) {
+		m := newRBACMocker(t)
+		m.db.EXPECT().GetRoles(gomock.Any()).Return(nil, errors.New("db down"))
+
+		_, err := m.service.ListRoles(context.TODO())
+		assert.Equal(t, platformerrors.ErrInternal, err)
+	})
+}
+
+func TestService_GetUserRoles(t *testing.T) {
+	t.Run("returns user roles", func(t *testing.T) {
+		m := newRBACMocker(t)
+		userID := uuid.New()
+		m.db.EXPECT().GetUserByID(gomock.Any(), userID).Return(models.GetUserByIDRow{ID: userID}, nil)
+		m.db.EXPECT().GetUserRoleNames(gomock.Any(), userID).Return([]string{"operations"}, nil)
+
+		roles, err := m.service.GetUserRoles(context.TODO(), userID)
+		assert.NoError(t, err)
+		assert.Equal(t, UserRoles{UserID: userID, Roles: []string{"operations"}}, roles)
+	})
+
+	t.Run("returns empty list for user without roles", func(t *testing.T) {
+		m := newRBACMocker(t)
+		userID := uuid.New()
+		m.db.EXPECT().GetUserByID(gomock.Any(), userID).Return(models.GetUserByIDRow{ID: userID}, nil)
+		m.db.EXPECT().GetUserRoleNames(gomock.Any(), userID).Return(nil, nil)
+
+		roles, err := m.service.GetUserRoles(context.TODO(), userID)
+		assert.NoError(t, err)
+		assert.Equal(t, UserRoles{UserID: userID, Roles: []string{}}, roles)
+	})
+
+	t.Run("fails for unknown user", func(t *testing.T) {
+		m := newRBACMocker(t)
+		userID := uuid.New()
+		m.db.EXPECT().GetUserByID(gomock.Any(), userID).Return(models.GetUserByIDRow{}, sql.ErrNoRows)
+
+		_, err := m.service.GetUserRoles(context.TODO(), userID)
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusNotFound, "user not found"), err)
+	})
+}
+
+func TestService_SetUserRoles(t *testing.T) {
+	t.Run("replaces roles and audits change", func(t *testing.T) {
+		m := newRBACMocker(t)
+		userID, adminID := uuid.New(), uuid.New()
+
+		m.db.EXPECT().GetUserByID(gomock.Any(), userID).
+			Return(models.GetUserByIDRow{ID: userID, UserType: models.UserTypeADMIN}, nil)
+		m.db.EXPECT().GetRolesByNames(gomock.Any(), []string{"operations", "treasury"}).
+			Return([]models.Role{{Name: "operations"}, {Name: "treasury"}}, nil)
+		m.db.EXPECT().GetUserRoleNames(gomock.Any(), userID).Return([]string{"support_agent"}, nil)
+		m.db.EXPECT().SetUserRoles(gomock.Any(), models.SetUserRolesParams{
+			UserID:    userID,
+			RoleNames: []string{"operations", "treasury"},
+		}).Return(nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionUserRolesChange, adminID, uuid.Nil,
+			auditlog.UserRolesChangeMetadata{
+				UserID:   userID,
+				OldRoles: []string{"support_agent"},
+				NewRoles: []string{"operations", "treasury"},
+			})).Return(nil)
+
+		roles, err := m.service.SetUserRoles(context.TODO(), SetUserRolesParams{
+			UserID:  userID,
+			Roles:   []string{"treasury", "operations", "treasury"},
+			ActorID: adminID,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, UserRoles{UserID: userID, Roles: []string{"operations", "treasury"}}, roles)
+	})
+
+	t.Run("removes all roles", func(t *testing.T) {
+		m := newRBACMocker(t)
+		userID, adminID := uuid.New(), uuid.New()
+
+		m.db.EXPECT().GetUserByID(gomock.Any(), userID).
+			Return(models.GetUserByIDRow{ID: userID, UserType: models.UserTypeADMIN}, nil)
+		m.db.EXPECT().GetRolesByNames(gomock.Any(), []string{}).Return(nil, nil)
+		m.db.EXPECT().GetUserRoleNames(gomock.Any(), userID).Return([]string{"operations"}, nil)
+		m.db.EXPECT().SetUserRoles(gomock.Any(), models.SetUserRolesParams{UserID: userID, RoleNames: []string{}}).
+			Return(nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), gomock.Any()).Return(nil)
+
+		roles, err := m.service.SetUserRoles(context.TODO(), SetUserRolesParams{
+			UserID:  userID,
+			Roles:   []string{},
+			ActorID: adminID,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, UserRoles{UserID: userID, Roles: []string{}}, roles)
+	})
+
+	t.Run("refuses to change own roles", func(t *testing.T) {
+		m := newRBACMocker(t)
+		adminID := uuid.New()
+
+		_, err := m.service.SetUserRoles(context.TODO(), SetUserRolesParams{
+			UserID:  adminID,
+			Roles:   []string{"super_admin"},
+			ActorID: adminID,
+		})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusForbidden, "you cannot change your own roles"), err)
+	})
+
+	t.Run("refuses roles for customers", func(t *testing.T) {
+		m := newRBACMocker(t)
+		userID := uuid.New()
+		m.db.EXPECT().GetUserByID(gomock.Any(), userID).
+			Return(models.GetUserByIDRow{ID: userID, UserType: models.UserTypeCUSTOMER}, nil)
+
+		_, err := m.service.SetUserRoles(context.TODO(), SetUserRolesParams{
+			UserID:  userID,
+			Roles:   []string{"operations"},
+			ActorID: uuid.New(),
+		})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, "roles can only be given to admin users"), err)
+	})
+
+	t.Run("refuses unknown role", func(t *testing.T) {
+		m := newRBACMocker(t)
+		userID := uuid.New()
+		m.db.EXPECT().GetUserByID(gomock.Any(), userID).
+			Return(models.GetUserByIDRow{ID: userID, UserType: models.UserTypeADMIN}, nil)
+		m.db.EXPECT().GetRolesByNames(gomock.Any(), []string{"operations", "owner"}).
+			Return([]models.Role{{Name: "operations"}}, nil)
+
+		_, err := m.service.SetUserRoles(context.TODO(), SetUserRolesParams{
+			UserID:  userID,
+			Roles:   []string{"operations", "owner"},
+			ActorID: uuid.New(),
+		})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, "unknown role owner"), err)
+	})
+
+	t.Run("fails for unknown user", func(t *testing.T) {
+		m := newRBACMocker(t)
+		userID := uuid.New()
+		m.db.EXPECT().GetUserByID(gomock.Any(), userID).Return(models.GetUserByIDRow{}, sql.ErrNoRows)
+
+		_, err := m.service.SetUserRoles(context.TODO(), SetUserRolesParams{
+			UserID:  userID,
+			Roles:   []string{"operations"},
+			ActorID: uuid.New(),
+		})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusNotFound, "user not found"), err)
+	})
+}
+
+type rbacMocker struct {
+	db       *databasemocks.MockQuerier
+	auditLog *auditlog.MockService
+
+	service Service
+}
+
+func newRBACMocker(t *testing.T) *rbacMocker {
+	ctrl := gomock.NewController(t)
+	db := databasemocks.NewMockQuerier(ctrl)
+	auditLog := auditlog.NewMockService(ctrl)
+
+	return &rbacMocker{
+		db:       db,
+		auditLog: auditLog,
+		service:  NewService(db, auditLog),
+	}
+}
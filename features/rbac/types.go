@@ -0,0 +1,32 @@
+package rbac
+
+import (
+	"github.com/google/uuid"
+	"payter-bank/internal/database/models"
+)
+
+type Role struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+func RoleFromRow(row models.GetRolesRow) Role {
+	return Role{
+		Name:        row.Name,
+		Description: row.Description,
+		Permissions: row.Permissions,
+	}
+}
+
+type UserRoles struct {
+	UserID uuid.UUID `json:"user_id"`
+	Roles  []string  `json:"roles"`
+}
+
+// SetUserRolesParams replaces the roles of an admin user, an empty list removes all of them.
+type SetUserRolesParams struct {
+	UserID  uuid.UUID `json:"-"`
+	Roles   []string  `json:"roles" binding:"required,dive,required"`
+	ActorID uuid.UUID `json:"-"`
+}
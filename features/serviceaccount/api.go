@@ -0,0 +1,208 @@
+package serviceaccount
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"payter-bank/internal/api"
+	"payter-bank/internal/auth"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// CreateHandler godoc
+// @Summary      Create a service account
+// @Description  Create a service account for an integration. It has no access until an API key is created for it
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        params  body  CreateParams  true  "Service account"
+// @Success      200  {object}  api.SuccessResponse{data=ServiceAccount}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      403  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/admin/service-accounts [post]
+func (h *Handler) CreateHandler(ctx *gin.Context) api.Response {
+	var params CreateParams
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+	params.ActorID = profile.UserID
+
+	account, err := h.service.Create(ctx, params)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("service account created successfully", account)
+}
+
+// ListHandler godoc
+// @Summary      List service accounts
+// @Description  List service accounts by name
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  api.SuccessResponse{data=[]ServiceAccount}
+// @Failure      403  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/admin/service-accounts [get]
+func (h *Handler) ListHandler(ctx *gin.Context) api.Response {
+	accounts, err := h.service.List(ctx)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("service accounts retrieved successfully", accounts)
+}
+
+// GetHandler godoc
+// @Summary      Get a service account
+// @Description  Get a service account and its API keys, including revoked ones. Keys are never returned after they are created
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id  path  string  true  "Service account ID"
+// @Success      200  {object}  api.SuccessResponse{data=ServiceAccount}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/admin/service-accounts/{id} [get]
+func (h *Handler) GetHandler(ctx *gin.Context) api.Response {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return api.BadRequest("service account id is required")
+	}
+
+	account, err := h.service.Get(ctx, id)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("service account retrieved successfully", account)
+}
+
+// CreateKeyHandler godoc
+// @Summary      Create an API key
+// @Description  Create an API key for a service account. The key is only returned in this response, send it in the X-API-Key header. Scopes are permission names and must be ones you hold
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id      path  string           true  "Service account ID"
+// @Param        params  body  CreateKeyParams  true  "Key"
+// @Success      200  {object}  api.SuccessResponse{data=CreatedAPIKey}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      403  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/admin/service-accounts/{id}/keys [post]
+func (h *Handler) CreateKeyHandler(ctx *gin.Context) api.Response {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return api.BadRequest("service account id is required")
+	}
+
+	var params CreateKeyParams
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+	params.ServiceAccountID = id
+	params.ActorID = profile.UserID
+
+	key, err := h.service.CreateKey(ctx, params)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("api key created successfully, it will not be shown again", key)
+}
+
+// RotateKeyHandler godoc
+// @Summary      Rotate an API key
+// @Description  Revoke an API key and create a new one with the same scopes, IP allowlist and expiry. An expired key cannot be rotated. The new key is only returned in this response
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id     path  string  true  "Service account ID"
+// @Param        keyId  path  string  true  "API key ID"
+// @Success      200  {object}  api.SuccessResponse{data=CreatedAPIKey}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      412  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/admin/service-accounts/{id}/keys/{keyId}/rotate [post]
+func (h *Handler) RotateKeyHandler(ctx *gin.Context) api.Response {
+	params, response, ok := keyParams(ctx)
+	if !ok {
+		return response
+	}
+
+	key, err := h.service.RotateKey(ctx, params)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("api key rotated successfully, it will not be shown again", key)
+}
+
+// RevokeKeyHandler godoc
+// @Summary      Revoke an API key
+// @Description  Revoke an API key, requests made with it are rejected from then on
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id     path  string  true  "Service account ID"
+// @Param        keyId  path  string  true  "API key ID"
+// @Success      200  {object}  api.SuccessResponse
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      412  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/admin/service-accounts/{id}/keys/{keyId}/revoke [post]
+func (h *Handler) RevokeKeyHandler(ctx *gin.Context) api.Response {
+	params, response, ok := keyParams(ctx)
+	if !ok {
+		return response
+	}
+
+	if err := h.service.RevokeKey(ctx, params); err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("api key revoked successfully", nil)
+}
+
+func keyParams(ctx *gin.Context) (KeyParams, api.Response, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return KeyParams{}, api.BadRequest("service account id is required"), false
+	}
+	keyID, err := uuid.Parse(ctx.Param("keyId"))
+	if err != nil {
+		return KeyParams{}, api.BadRequest("api key id is required"), false
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return KeyParams{}, api.Unauthorized("unauthorized"), false
+	}
+
+	return KeyParams{ServiceAccountID: id, KeyID: keyID, ActorID: profile.UserID}, api.Response{}, true
+}
@@ -0,0 +1,189 @@
+package serviceaccount
+
+import (
+	"bytes"
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"payter-bank/internal/api"
+	"payter-bank/internal/auth"
+	platformerrors "payter-bank/internal/errors"
+	"testing"
+)
+
+func TestHandler_CreateHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("creates a service account", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		actorID := uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/admin/service-accounts",
+			bytes.NewBufferString(`{"name":"reconciliation","description":"Nightly reconciliation"}`))
+		injectProfile(c, auth.Profile{UserID: actorID})
+
+		account := ServiceAccount{ID: uuid.New(), Name: "reconciliation", Description: "Nightly reconciliation", CreatedBy: actorID}
+		mockService.EXPECT().Create(gomock.Any(), CreateParams{Name: "reconciliation", Description: "Nightly reconciliation", ActorID: actorID}).
+			Return(account, nil)
+
+		response := handler.CreateHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    account,
+			Message: "service account created successfully",
+		}, response.Data)
+	})
+
+	t.Run("requires a name", func(t *testing.T) {
+		handler := NewHandler(NewMockService(gomock.NewController(t)))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/admin/service-accounts", bytes.NewBufferString(`{}`))
+		injectProfile(c, auth.Profile{UserID: uuid.New()})
+
+		response := handler.CreateHandler(c)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestHandler_GetHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("returns not found", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		id := uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/v1/api/admin/service-accounts/"+id.String(), nil)
+		c.Params = gin.Params{{Key: "id", Value: id.String()}}
+
+		mockService.EXPECT().Get(gomock.Any(), id).
+			Return(ServiceAccount{}, platformerrors.MakeApiError(http.StatusNotFound, "service account not found"))
+
+		response := handler.GetHandler(c)
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	})
+}
+
+func TestHandler_CreateKeyHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("creates a key", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		id, actorID := uuid.New(), uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/admin/service-accounts/"+id.String()+"/keys",
+			bytes.NewBufferString(`{"name":"primary","scopes":["accounts:read"],"allowed_ips":["10.0.0.0/8"]}`))
+		c.Params = gin.Params{{Key: "id", Value: id.String()}}
+		injectProfile(c, auth.Profile{UserID: actorID})
+
+		key := CreatedAPIKey{APIKey: APIKey{ID: uuid.New(), ServiceAccountID: id, Name: "primary"}, Key: "pbk_secret"}
+		mockService.EXPECT().CreateKey(gomock.Any(), CreateKeyParams{
+			ServiceAccountID: id,
+			Name:             "primary",
+			Scopes:           []string{"accounts:read"},
+			AllowedIPs:       []string{"10.0.0.0/8"},
+			ActorID:          actorID,
+		}).Return(key, nil)
+
+		response := handler.CreateKeyHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    key,
+			Message: "api key created successfully, it will not be shown again",
+		}, response.Data)
+	})
+
+	t.Run("requires scopes", func(t *testing.T) {
+		handler := NewHandler(NewMockService(gomock.NewController(t)))
+
+		id := uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/admin/service-accounts/"+id.String()+"/keys",
+			bytes.NewBufferString(`{"name":"primary","scopes":[]}`))
+		c.Params = gin.Params{{Key: "id", Value: id.String()}}
+		injectProfile(c, auth.Profile{UserID: uuid.New()})
+
+		response := handler.CreateKeyHandler(c)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestHandler_RotateKeyHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("rotates a key", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		id, keyID, actorID := uuid.New(), uuid.New(), uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/admin/service-accounts/"+id.String()+"/keys/"+keyID.String()+"/rotate", nil)
+		c.Params = gin.Params{{Key: "id", Value: id.String()}, {Key: "keyId", Value: keyID.String()}}
+		injectProfile(c, auth.Profile{UserID: actorID})
+
+		key := CreatedAPIKey{APIKey: APIKey{ID: uuid.New(), ServiceAccountID: id}, Key: "pbk_secret"}
+		mockService.EXPECT().RotateKey(gomock.Any(), KeyParams{ServiceAccountID: id, KeyID: keyID, ActorID: actorID}).Return(key, nil)
+
+		response := handler.RotateKeyHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    key,
+			Message: "api key rotated successfully, it will not be shown again",
+		}, response.Data)
+	})
+
+	t.Run("rejects invalid key id", func(t *testing.T) {
+		handler := NewHandler(NewMockService(gomock.NewController(t)))
+
+		id := uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/admin/service-accounts/"+id.String()+"/keys/abc/rotate", nil)
+		c.Params = gin.Params{{Key: "id", Value: id.String()}, {Key: "keyId", Value: "abc"}}
+		injectProfile(c, auth.Profile{UserID: uuid.New()})
+
+		response := handler.RotateKeyHandler(c)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestHandler_RevokeKeyHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("revokes a key", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		id, keyID, actorID := uuid.New(), uuid.New(), uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/admin/service-accounts/"+id.String()+"/keys/"+keyID.String()+"/revoke", nil)
+		c.Params = gin.Params{{Key: "id", Value: id.String()}, {Key: "keyId", Value: keyID.String()}}
+		injectProfile(c, auth.Profile{UserID: actorID})
+
+		mockService.EXPECT().RevokeKey(gomock.Any(), KeyParams{ServiceAccountID: id, KeyID: keyID, ActorID: actorID}).Return(nil)
+
+		response := handler.RevokeKeyHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+}
+
+func injectProfile(ctx *gin.Context, profile auth.Profile) {
+	ctx.Request = ctx.Request.WithContext(context.WithValue(ctx.Request.Context(), auth.ProfileKey, profile))
+}
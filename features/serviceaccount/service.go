@@ -0,0 +1,411 @@
+//go:generate mockgen -source=service.go -destination=service_mock.go -package=serviceaccount
+
+package serviceaccount
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"net/http"
+	"net/netip"
+	"payter-bank/features/auditlog"
+	"payter-bank/internal/auth"
+	"payter-bank/internal/database"
+	"payter-bank/internal/database/models"
+	platformerrors "payter-bank/internal/errors"
+	"payter-bank/internal/logger"
+	"payter-bank/internal/pkg/generator"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// service accounts get a users row so their actions can be attributed, the address is never mailed
+const serviceAccountEmailDomain = "service-accounts.invalid"
+
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,62}$`)
+
+type Service interface {
+	Create(ctx context.Context, param CreateParams) (ServiceAccount, error)
+	List(ctx context.Context) ([]ServiceAccount, error)
+	// Get returns a service account with all of its keys, including revoked ones.
+	Get(ctx context.Context, id uuid.UUID) (ServiceAccount, error)
+	CreateKey(ctx context.Context, param CreateKeyParams) (CreatedAPIKey, error)
+	// RotateKey revokes a key and creates a new one with the same name, scopes, IP allowlist and
+	// expiry.
+	RotateKey(ctx context.Context, param KeyParams) (CreatedAPIKey, error)
+	RevokeKey(ctx context.Context, param KeyParams) error
+	// Authenticate looks up an API key presented by clientIP and returns the principal it acts as,
+	// its permissions are the scopes of the key.
+	Authenticate(ctx context.Context, key, clientIP string) (auth.Profile, error)
+}
+
+type service struct {
+	db       models.Querier
+	auditLog auditlog.Service
+	now      func() time.Time
+}
+
+func NewService(db models.Querier, auditLog auditlog.Service) Service {
+	return &service{
+		db:       db,
+		auditLog: auditLog,
+		now:      time.Now,
+	}
+}
+
+func (s *service) Create(ctx context.Context, param CreateParams) (ServiceAccount, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Create"),
+		zap.Any(logger.RequestFields, param))
+
+	if !namePattern.MatchString(param.Name) {
+		return ServiceAccount{}, platformerrors.MakeApiError(http.StatusBadRequest,
+			"name must be 2 to 63 lowercase letters, digits or dashes")
+	}
+
+	// the password is never handed out, service accounts cannot log in with one
+	_, passwordHash := generator.DefaultUserTokenGenerator.Generate()
+	row, err := s.db.CreateServiceAccount(ctx, models.CreateServiceAccountParams{
+		Email:       param.Name + "@" + serviceAccountEmailDomain,
+		Password:    passwordHash,
+		Name:        param.Name,
+		Description: param.Description,
+		CreatedBy:   param.ActorID,
+	})
+	if err != nil {
+		if database.IsUniqueViolation(err, "") {
+			return ServiceAccount{}, platformerrors.MakeApiError(http.StatusBadRequest, "service account already exists")
+		}
+		logger.Error(ctx, "failed to create service account", zap.Error(err))
+		return ServiceAccount{}, platformerrors.ErrInternal
+	}
+
+	s.submitAudit(ctx, auditlog.ActionServiceAccountNew, param.ActorID, auditlog.ServiceAccountMetadata{
+		ServiceAccountID: row.ID,
+		Name:             row.Name,
+	})
+	return ServiceAccountFromModel(row), nil
+}
+
+func (s *service) List(ctx context.Context) ([]ServiceAccount, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "List"))
+
+	rows, err := s.db.GetServiceAccounts(ctx)
+	if err != nil {
+		logger.Error(ctx, "failed to get service accounts", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	accounts := make([]ServiceAccount, 0, len(rows))
+	for _, row := range rows {
+		accounts = append(accounts, ServiceAccountFromModel(row))
+	}
+	return accounts, nil
+}
+
+func (s *service) Get(ctx context.Context, id uuid.UUID) (ServiceAccount, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Get"),
+		zap.Any(logger.RequestFields, id))
+
+	account, err := s.get(ctx, id)
+	if err != nil {
+		return ServiceAccount{}, err
+	}
+
+	rows, err := s.db.GetAPIKeys(ctx, id)
+	if err != nil {
+		logger.Error(ctx, "failed to get api keys", zap.Error(err))
+		return ServiceAccount{}, platformerrors.ErrInternal
+	}
+
+	account.Keys = make([]APIKey, 0, len(rows))
+	for _, row := range rows {
+		account.Keys = append(account.Keys, APIKeyFromModel(row))
+	}
+	return account, nil
+}
+
+func (s *service) CreateKey(ctx context.Context, param CreateKeyParams) (CreatedAPIKey, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "CreateKey"),
+		zap.Any(logger.RequestFields, param))
+
+	if _, err := s.get(ctx, param.ServiceAccountID); err != nil {
+		return CreatedAPIKey{}, err
+	}
+
+	if err := s.validateKey(ctx, param); err != nil {
+		return CreatedAPIKey{}, err
+	}
+
+	key, err := s.saveKey(ctx, param)
+	if err != nil {
+		return CreatedAPIKey{}, err
+	}
+
+	s.submitAudit(ctx, auditlog.ActionAPIKeyCreated, param.ActorID, auditlog.APIKeyMetadata{
+		ServiceAccountID: param.ServiceAccountID,
+		KeyID:            key.ID,
+		Scopes:           key.Scopes,
+	})
+	return key, nil
+}
+
+func (s *service) RotateKey(ctx context.Context, param KeyParams) (CreatedAPIKey, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "RotateKey"),
+		zap.Any(logger.RequestFields, param))
+
+	old, err := s.getKey(ctx, param)
+	if err != nil {
+		return CreatedAPIKey{}, err
+	}
+	if old.RevokedAt.Valid {
+		return CreatedAPIKey{}, platformerrors.MakeApiError(http.StatusPreconditionFailed, "api key has already been revoked")
+	}
+	// a key that expired on purpose is not brought back, a new key has to be created for it
+	if old.ExpiresAt.Valid && !old.ExpiresAt.Time.After(s.now()) {
+		return CreatedAPIKey{}, platformerrors.MakeApiError(http.StatusPreconditionFailed, "api key has expired, create a new key instead")
+	}
+
+	// the old key is revoked first so that of two rotations of the same key only one goes through
+	if err := s.revoke(ctx, param); err != nil {
+		return CreatedAPIKey{}, err
+	}
+
+	create := CreateKeyParams{
+		ServiceAccountID: param.ServiceAccountID,
+		Name:             old.Name,
+		Scopes:           old.Scopes,
+		AllowedIPs:       old.AllowedIps,
+		ActorID:          param.ActorID,
+	}
+	if old.ExpiresAt.Valid {
+		create.ExpiresAt = &old.ExpiresAt.Time
+	}
+
+	key, err := s.saveKey(ctx, create)
+	if err != nil {
+		return CreatedAPIKey{}, err
+	}
+
+	s.submitAudit(ctx, auditlog.ActionAPIKeyRotated, param.ActorID, auditlog.APIKeyMetadata{
+		ServiceAccountID: param.ServiceAccountID,
+		KeyID:            key.ID,
+		Scopes:           key.Scopes,
+		ReplacedKeyID:    old.ID,
+	})
+	return key, nil
+}
+
+func (s *service) RevokeKey(ctx context.Context, param KeyParams) error {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "RevokeKey"),
+		zap.Any(logger.RequestFields, param))
+
+	if _, err := s.getKey(ctx, param); err != nil {
+		return err
+	}
+
+	if err := s.revoke(ctx, param); err != nil {
+		return err
+	}
+
+	s.submitAudit(ctx, auditlog.ActionAPIKeyRevoked, param.ActorID, auditlog.APIKeyMetadata{
+		ServiceAccountID: param.ServiceAccountID,
+		KeyID:            param.KeyID,
+	})
+	return nil
+}
+
+func (s *service) Authenticate(ctx context.Context, key, clientIP string) (auth.Profile, error) {
+	// the key is left out of the logs
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Authenticate"),
+		zap.String("client_ip", clientIP))
+
+	invalid := platformerrors.MakeApiError(http.StatusUnauthorized, "invalid api key")
+	if !strings.HasPrefix(key, keyPrefix) {
+		return auth.Profile{}, invalid
+	}
+
+	row, err := s.db.GetAPIKeyByHash(ctx, generator.HashUserToken(key))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return auth.Profile{}, invalid
+		}
+		logger.Error(ctx, "failed to get api key", zap.Error(err))
+		return auth.Profile{}, platformerrors.ErrInternal
+	}
+
+	if row.RevokedAt.Valid || row.DeletedAt.Valid {
+		return auth.Profile{}, platformerrors.MakeApiError(http.StatusUnauthorized, "api key has been revoked")
+	}
+	if row.ExpiresAt.Valid && !s.now().Before(row.ExpiresAt.Time) {
+		return auth.Profile{}, platformerrors.MakeApiError(http.StatusUnauthorized, "api key has expired")
+	}
+	if !ipAllowed(row.AllowedIps, clientIP) {
+		return auth.Profile{}, platformerrors.MakeApiError(http.StatusForbidden, "api key cannot be used from this address")
+	}
+
+	if err := s.db.TouchAPIKey(ctx, row.ID); err != nil {
+		// the key is still valid, a stale last used time is not worth failing the request for
+		logger.Error(ctx, "failed to update api key last used time", zap.Error(err))
+	}
+
+	profile := auth.Profile{
+		UserID:    row.ServiceAccountID,
+		Email:     row.Email,
+		FirstName: row.ServiceAccountName,
+		LastName:  "Service Account",
+		UserType:  string(models.UserTypeSERVICE),
+		APIKeyID:  row.ID,
+	}
+	for _, scope := range row.Scopes {
+		profile.Permissions = append(profile.Permissions, auth.Permission(scope))
+	}
+	return profile, nil
+}
+
+// validateKey checks the scopes, IP allowlist and expiry of a new key. Admins can only grant
+// scopes they hold themselves.
+func (s *service) validateKey(ctx context.Context, param CreateKeyParams) error {
+	permissions, err := s.db.GetUserPermissions(ctx, param.ActorID)
+	if err != nil {
+		logger.Error(ctx, "failed to get user permissions", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+	for _, scope := range param.Scopes {
+		if !slices.Contains(permissions, scope) {
+			return platformerrors.MakeApiError(http.StatusForbidden, fmt.Sprintf("you cannot grant the %s scope", scope))
+		}
+	}
+
+	for _, entry := range param.AllowedIPs {
+		if !validIPEntry(entry) {
+			return platformerrors.MakeApiError(http.StatusBadRequest, fmt.Sprintf("%s is not an ip address or cidr range", entry))
+		}
+	}
+
+	if param.ExpiresAt != nil && !param.ExpiresAt.After(s.now()) {
+		return platformerrors.MakeApiError(http.StatusBadRequest, "expires_at must be in the future")
+	}
+	return nil
+}
+
+func (s *service) saveKey(ctx context.Context, param CreateKeyParams) (CreatedAPIKey, error) {
+	token, _ := generator.DefaultUserTokenGenerator.Generate()
+	key := keyPrefix + token
+
+	allowedIPs := param.AllowedIPs
+	if allowedIPs == nil {
+		allowedIPs = []string{}
+	}
+	expiresAt := sql.NullTime{}
+	if param.ExpiresAt != nil {
+		expiresAt = sql.NullTime{Time: *param.ExpiresAt, Valid: true}
+	}
+
+	row, err := s.db.SaveAPIKey(ctx, models.SaveAPIKeyParams{
+		ServiceAccountID: param.ServiceAccountID,
+		Name:             param.Name,
+		Prefix:           key[:displayPrefixLength],
+		KeyHash:          generator.HashUserToken(key),
+		Scopes:           param.Scopes,
+		AllowedIps:       allowedIPs,
+		ExpiresAt:        expiresAt,
+		CreatedBy:        param.ActorID,
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to save api key", zap.Error(err))
+		return CreatedAPIKey{}, platformerrors.ErrInternal
+	}
+
+	return CreatedAPIKey{APIKey: APIKeyFromModel(row), Key: key}, nil
+}
+
+func (s *service) revoke(ctx context.Context, param KeyParams) error {
+	revoked, err := s.db.RevokeAPIKey(ctx, models.RevokeAPIKeyParams{
+		ID:               param.KeyID,
+		ServiceAccountID: param.ServiceAccountID,
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to revoke api key", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+	if revoked == 0 {
+		return platformerrors.MakeApiError(http.StatusPreconditionFailed, "api key has already been revoked")
+	}
+	return nil
+}
+
+func (s *service) get(ctx context.Context, id uuid.UUID) (ServiceAccount, error) {
+	row, err := s.db.GetServiceAccount(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ServiceAccount{}, platformerrors.MakeApiError(http.StatusNotFound, "service account not found")
+		}
+		logger.Error(ctx, "failed to get service account", zap.Error(err))
+		return ServiceAccount{}, platformerrors.ErrInternal
+	}
+	return ServiceAccountFromModel(row), nil
+}
+
+func (s *service) getKey(ctx context.Context, param KeyParams) (models.ApiKey, error) {
+	row, err := s.db.GetAPIKey(ctx, models.GetAPIKeyParams{
+		ID:               param.KeyID,
+		ServiceAccountID: param.ServiceAccountID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.ApiKey{}, platformerrors.MakeApiError(http.StatusNotFound, "api key not found")
+		}
+		logger.Error(ctx, "failed to get api key", zap.Error(err))
+		return models.ApiKey{}, platformerrors.ErrInternal
+	}
+	return row, nil
+}
+
+func (s *service) submitAudit(ctx context.Context, action auditlog.Action, actorID uuid.UUID, metadata any) {
+	if err := s.auditLog.Submit(ctx, auditlog.NewEvent(action, actorID, uuid.Nil, metadata)); err != nil {
+		logger.Error(ctx, "failed to queue audit log", zap.Error(err))
+	}
+}
+
+func validIPEntry(entry string) bool {
+	if _, err := netip.ParseAddr(entry); err == nil {
+		return true
+	}
+	_, err := netip.ParsePrefix(entry)
+	return err == nil
+}
+
+// ipAllowed reports whether clientIP matches the allowlist of a key, an empty list allows any address.
+func ipAllowed(allowed []string, clientIP string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+
+	addr, err := netip.ParseAddr(clientIP)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+
+	for _, entry := range allowed {
+		if allowedAddr, err := netip.ParseAddr(entry); err == nil && allowedAddr.Unmap() == addr {
+			return true
+		}
+		if prefix, err := netip.ParsePrefix(entry); err == nil && prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,371 @@
+package serviceaccount
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"payter-bank/features/auditlog"
+	"payter-bank/internal/auth"
+	"payter-bank/internal/database/models"
+	databasemocks "payter-bank/internal/database/models/mocks"
+	platformerrors "payter-bank/internal/errors"
+	"payter-bank/internal/pkg/generator"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func TestService_Create(t *testing.T) {
+	t.Run("creates a service account", func(t *testing.T) {
+		m := newServiceAccountMocker(t)
+		actorID, id := uuid.New(), uuid.New()
+		m.db.EXPECT().CreateServiceAccount(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, arg models.CreateServiceAccountParams) (models.ServiceAccount, error) {
+				assert.Equal(t, "reconciliation@service-accounts.invalid", arg.Email)
+				assert.NotEmpty(t, arg.Password)
+				assert.Equal(t, actorID, arg.CreatedBy)
+				return models.ServiceAccount{ID: id, Name: arg.Name, Description: arg.Description, CreatedBy: actorID}, nil
+			})
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionServiceAccountNew, actorID, uuid.Nil,
+			auditlog.ServiceAccountMetadata{ServiceAccountID: id, Name: "reconciliation"})).Return(nil)
+
+		account, err := m.service.Create(context.TODO(), CreateParams{Name: "reconciliation", Description: "Nightly reconciliation", ActorID: actorID})
+		assert.NoError(t, err)
+		assert.Equal(t, ServiceAccount{ID: id, Name: "reconciliation", Description: "Nightly reconciliation", CreatedBy: actorID}, account)
+	})
+
+	t.Run("rejects an invalid name", func(t *testing.T) {
+		m := newServiceAccountMocker(t)
+
+		_, err := m.service.Create(context.TODO(), CreateParams{Name: "Reconciliation Job", ActorID: uuid.New()})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, "name must be 2 to 63 lowercase letters, digits or dashes"), err)
+	})
+
+	t.Run("rejects a duplicate name", func(t *testing.T) {
+		m := newServiceAccountMocker(t)
+		m.db.EXPECT().CreateServiceAccount(gomock.Any(), gomock.Any()).
+			Return(models.ServiceAccount{}, &pq.Error{Code: "23505"})
+
+		_, err := m.service.Create(context.TODO(), CreateParams{Name: "reconciliation", ActorID: uuid.New()})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, "service account already exists"), err)
+	})
+}
+
+func TestService_Get(t *testing.T) {
+	t.Run("returns the account with its keys", func(t *testing.T) {
+		m := newServiceAccountMocker(t)
+		id, keyID := uuid.New(), uuid.New()
+		m.db.EXPECT().GetServiceAccount(gomock.Any(), id).Return(models.ServiceAccount{ID: id, Name: "reconciliation"}, nil)
+		m.db.EXPECT().GetAPIKeys(gomock.Any(), id).Return([]models.ApiKey{
+			{ID: keyID, ServiceAccountID: id, Name: "primary", Prefix: "pbk_abcdefgh", KeyHash: "hash", Scopes: []string{"accounts:read"}, AllowedIps: []string{}},
+		}, nil)
+
+		account, err := m.service.Get(context.TODO(), id)
+		assert.NoError(t, err)
+		assert.Equal(t, []APIKey{
+			{ID: keyID, ServiceAccountID: id, Name: "primary", Prefix: "pbk_abcdefgh", Scopes: []string{"accounts:read"}, AllowedIPs: []string{}},
+		}, account.Keys)
+	})
+
+	t.Run("returns not found", func(t *testing.T) {
+		m := newServiceAccountMocker(t)
+		id := uuid.New()
+		m.db.EXPECT().GetServiceAccount(gomock.Any(), id).Return(models.ServiceAccount{}, sql.ErrNoRows)
+
+		_, err := m.service.Get(context.TODO(), id)
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusNotFound, "service account not found"), err)
+	})
+}
+
+func TestService_CreateKey(t *testing.T) {
+	t.Run("creates a key and returns it once", func(t *testing.T) {
+		m := newServiceAccountMocker(t)
+		id, actorID, keyID := uuid.New(), uuid.New(), uuid.New()
+		expiresAt := testNow.Add(90 * 24 * time.Hour)
+		m.db.EXPECT().GetServiceAccount(gomock.Any(), id).Return(models.ServiceAccount{ID: id}, nil)
+		m.db.EXPECT().GetUserPermissions(gomock.Any(), actorID).Return([]string{"accounts:read", "transactions:read"}, nil)
+
+		var saved models.SaveAPIKeyParams
+		m.db.EXPECT().SaveAPIKey(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, arg models.SaveAPIKeyParams) (models.ApiKey, error) {
+				saved = arg
+				return models.ApiKey{
+					ID: keyID, ServiceAccountID: id, Name: arg.Name, Prefix: arg.Prefix, Scopes: arg.Scopes,
+					AllowedIps: arg.AllowedIps, ExpiresAt: arg.ExpiresAt, CreatedBy: actorID,
+				}, nil
+			})
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionAPIKeyCreated, actorID, uuid.Nil,
+			auditlog.APIKeyMetadata{ServiceAccountID: id, KeyID: keyID, Scopes: []string{"accounts:read"}})).Return(nil)
+
+		key, err := m.service.CreateKey(context.TODO(), CreateKeyParams{
+			ServiceAccountID: id,
+			Name:             "primary",
+			Scopes:           []string{"accounts:read"},
+			AllowedIPs:       []string{"10.0.0.0/8", "192.168.1.10"},
+			ExpiresAt:        &expiresAt,
+			ActorID:          actorID,
+		})
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(key.Key, keyPrefix))
+		assert.Equal(t, key.Key[:displayPrefixLength], key.Prefix)
+		assert.Equal(t, generator.HashUserToken(key.Key), saved.KeyHash)
+		assert.Equal(t, []string{"10.0.0.0/8", "192.168.1.10"}, key.AllowedIPs)
+		assert.Equal(t, &expiresAt, key.ExpiresAt)
+	})
+
+	t.Run("forbids scopes the admin does not hold", func(t *testing.T) {
+		m := newServiceAccountMocker(t)
+		id, actorID := uuid.New(), uuid.New()
+		m.db.EXPECT().GetServiceAccount(gomock.Any(), id).Return(models.ServiceAccount{ID: id}, nil)
+		m.db.EXPECT().GetUserPermissions(gomock.Any(), actorID).Return([]string{"accounts:read"}, nil)
+
+		_, err := m.service.CreateKey(context.TODO(), CreateKeyParams{
+			ServiceAccountID: id, Name: "primary", Scopes: []string{"transactions:write"}, ActorID: actorID,
+		})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusForbidden, "you cannot grant the transactions:write scope"), err)
+	})
+
+	t.Run("rejects an invalid allowlist entry", func(t *testing.T) {
+		m := newServiceAccountMocker(t)
+		id, actorID := uuid.New(), uuid.New()
+		m.db.EXPECT().GetServiceAccount(gomock.Any(), id).Return(models.ServiceAccount{ID: id}, nil)
+		m.db.EXPECT().GetUserPermissions(gomock.Any(), actorID).Return([]string{"accounts:read"}, nil)
+
+		_, err := m.service.CreateKey(context.TODO(), CreateKeyParams{
+			ServiceAccountID: id, Name: "primary", Scopes: []string{"accounts:read"}, AllowedIPs: []string{"office"}, ActorID: actorID,
+		})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, "office is not an ip address or cidr range"), err)
+	})
+
+	t.Run("rejects an expiry in the past", func(t *testing.T) {
+		m := newServiceAccountMocker(t)
+		id, actorID := uuid.New(), uuid.New()
+		expiresAt := testNow.Add(-time.Hour)
+		m.db.EXPECT().GetServiceAccount(gomock.Any(), id).Return(models.ServiceAccount{ID: id}, nil)
+		m.db.EXPECT().GetUserPermissions(gomock.Any(), actorID).Return([]string{"accounts:read"}, nil)
+
+		_, err := m.service.CreateKey(context.TODO(), CreateKeyParams{
+			ServiceAccountID: id, Name: "primary", Scopes: []string{"accounts:read"}, ExpiresAt: &expiresAt, ActorID: actorID,
+		})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, "expires_at must be in the future"), err)
+	})
+}
+
+func TestService_RotateKey(t *testing.T) {
+	t.Run("revokes the key and creates one with the same settings", func(t *testing.T) {
+		m := newServiceAccountMocker(t)
+		id, actorID, oldID, newID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+		expiresAt := sql.NullTime{Time: testNow.Add(time.Hour), Valid: true}
+		params := KeyParams{ServiceAccountID: id, KeyID: oldID, ActorID: actorID}
+
+		m.db.EXPECT().GetAPIKey(gomock.Any(), models.GetAPIKeyParams{ID: oldID, ServiceAccountID: id}).Return(models.ApiKey{
+			ID: oldID, ServiceAccountID: id, Name: "primary", Scopes: []string{"accounts:read"}, AllowedIps: []string{"10.0.0.1"}, ExpiresAt: expiresAt,
+		}, nil)
+		m.db.EXPECT().RevokeAPIKey(gomock.Any(), models.RevokeAPIKeyParams{ID: oldID, ServiceAccountID: id}).Return(int64(1), nil)
+		m.db.EXPECT().SaveAPIKey(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, arg models.SaveAPIKeyParams) (models.ApiKey, error) {
+				assert.Equal(t, "primary", arg.Name)
+				assert.Equal(t, []string{"accounts:read"}, arg.Scopes)
+				assert.Equal(t, []string{"10.0.0.1"}, arg.AllowedIps)
+				assert.Equal(t, expiresAt, arg.ExpiresAt)
+				return models.ApiKey{ID: newID, ServiceAccountID: id, Name: arg.Name, Scopes: arg.Scopes}, nil
+			})
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionAPIKeyRotated, actorID, uuid.Nil,
+			auditlog.APIKeyMetadata{ServiceAccountID: id, KeyID: newID, Scopes: []string{"accounts:read"}, ReplacedKeyID: oldID})).Return(nil)
+
+		key, err := m.service.RotateKey(context.TODO(), params)
+		assert.NoError(t, err)
+		assert.Equal(t, newID, key.ID)
+		assert.NotEmpty(t, key.Key)
+	})
+
+	t.Run("rejects a revoked key", func(t *testing.T) {
+		m := newServiceAccountMocker(t)
+		id, keyID := uuid.New(), uuid.New()
+		m.db.EXPECT().GetAPIKey(gomock.Any(), gomock.Any()).
+			Return(models.ApiKey{ID: keyID, RevokedAt: sql.NullTime{Time: testNow, Valid: true}}, nil)
+
+		_, err := m.service.RotateKey(context.TODO(), KeyParams{ServiceAccountID: id, KeyID: keyID, ActorID: uuid.New()})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusPreconditionFailed, "api key has already been revoked"), err)
+	})
+
+	t.Run("rejects an expired key", func(t *testing.T) {
+		m := newServiceAccountMocker(t)
+		id, keyID := uuid.New(), uuid.New()
+		m.db.EXPECT().GetAPIKey(gomock.Any(), gomock.Any()).
+			Return(models.ApiKey{ID: keyID, ExpiresAt: sql.NullTime{Time: testNow, Valid: true}}, nil)
+		// the expired key is neither revoked nor replaced by one without expiry
+		m.db.EXPECT().RevokeAPIKey(gomock.Any(), gomock.Any()).Times(0)
+		m.db.EXPECT().SaveAPIKey(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := m.service.RotateKey(context.TODO(), KeyParams{ServiceAccountID: id, KeyID: keyID, ActorID: uuid.New()})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusPreconditionFailed, "api key has expired, create a new key instead"), err)
+	})
+}
+
+func TestService_RevokeKey(t *testing.T) {
+	t.Run("revokes a key", func(t *testing.T) {
+		m := newServiceAccountMocker(t)
+		id, keyID, actorID := uuid.New(), uuid.New(), uuid.New()
+		m.db.EXPECT().GetAPIKey(gomock.Any(), models.GetAPIKeyParams{ID: keyID, ServiceAccountID: id}).Return(models.ApiKey{ID: keyID}, nil)
+		m.db.EXPECT().RevokeAPIKey(gomock.Any(), models.RevokeAPIKeyParams{ID: keyID, ServiceAccountID: id}).Return(int64(1), nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionAPIKeyRevoked, actorID, uuid.Nil,
+			auditlog.APIKeyMetadata{ServiceAccountID: id, KeyID: keyID})).Return(nil)
+
+		err := m.service.RevokeKey(context.TODO(), KeyParams{ServiceAccountID: id, KeyID: keyID, ActorID: actorID})
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects an already revoked key", func(t *testing.T) {
+		m := newServiceAccountMocker(t)
+		id, keyID := uuid.New(), uuid.New()
+		m.db.EXPECT().GetAPIKey(gomock.Any(), gomock.Any()).Return(models.ApiKey{ID: keyID}, nil)
+		m.db.EXPECT().RevokeAPIKey(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+
+		err := m.service.RevokeKey(context.TODO(), KeyParams{ServiceAccountID: id, KeyID: keyID, ActorID: uuid.New()})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusPreconditionFailed, "api key has already been revoked"), err)
+	})
+
+	t.Run("returns not found for a key of another account", func(t *testing.T) {
+		m := newServiceAccountMocker(t)
+		m.db.EXPECT().GetAPIKey(gomock.Any(), gomock.Any()).Return(models.ApiKey{}, sql.ErrNoRows)
+
+		err := m.service.RevokeKey(context.TODO(), KeyParams{ServiceAccountID: uuid.New(), KeyID: uuid.New(), ActorID: uuid.New()})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusNotFound, "api key not found"), err)
+	})
+}
+
+func TestService_Authenticate(t *testing.T) {
+	key := keyPrefix + "secret"
+	accountID, keyID := uuid.New(), uuid.New()
+	row := func() models.GetAPIKeyByHashRow {
+		return models.GetAPIKeyByHashRow{
+			ID:                 keyID,
+			ServiceAccountID:   accountID,
+			Scopes:             []string{"accounts:read"},
+			AllowedIps:         []string{},
+			ServiceAccountName: "reconciliation",
+			Email:              "reconciliation@service-accounts.invalid",
+		}
+	}
+
+	t.Run("returns the service account profile", func(t *testing.T) {
+		m := newServiceAccountMocker(t)
+		m.db.EXPECT().GetAPIKeyByHash(gomock.Any(), generator.HashUserToken(key)).Return(row(), nil)
+		m.db.EXPECT().TouchAPIKey(gomock.Any(), keyID).Return(nil)
+
+		profile, err := m.service.Authenticate(context.TODO(), key, "203.0.113.7")
+		assert.NoError(t, err)
+		assert.Equal(t, auth.Profile{
+			UserID:      accountID,
+			Email:       "reconciliation@service-accounts.invalid",
+			FirstName:   "reconciliation",
+			LastName:    "Service Account",
+			UserType:    string(models.UserTypeSERVICE),
+			Permissions: []auth.Permission{auth.PermAccountsRead},
+			APIKeyID:    keyID,
+		}, profile)
+		assert.True(t, profile.IsServiceAccount())
+	})
+
+	t.Run("rejects an unknown key", func(t *testing.T) {
+		m := newServiceAccountMocker(t)
+		m.db.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Return(models.GetAPIKeyByHashRow{}, sql.ErrNoRows)
+
+		_, err := m.service.Authenticate(context.TODO(), key, "203.0.113.7")
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusUnauthorized, "invalid api key"), err)
+	})
+
+	t.Run("rejects a key without the prefix", func(t *testing.T) {
+		m := newServiceAccountMocker(t)
+
+		_, err := m.service.Authenticate(context.TODO(), "secret", "203.0.113.7")
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusUnauthorized, "invalid api key"), err)
+	})
+
+	t.Run("rejects a revoked key", func(t *testing.T) {
+		m := newServiceAccountMocker(t)
+		revoked := row()
+		revoked.RevokedAt = sql.NullTime{Time: testNow, Valid: true}
+		m.db.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Return(revoked, nil)
+
+		_, err := m.service.Authenticate(context.TODO(), key, "203.0.113.7")
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusUnauthorized, "api key has been revoked"), err)
+	})
+
+	t.Run("rejects a key of a deactivated service account", func(t *testing.T) {
+		m := newServiceAccountMocker(t)
+		deactivated := row()
+		deactivated.DeletedAt = sql.NullTime{Time: testNow, Valid: true}
+		m.db.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Return(deactivated, nil)
+
+		_, err := m.service.Authenticate(context.TODO(), key, "203.0.113.7")
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusUnauthorized, "api key has been revoked"), err)
+	})
+
+	t.Run("rejects an expired key", func(t *testing.T) {
+		m := newServiceAccountMocker(t)
+		expired := row()
+		expired.ExpiresAt = sql.NullTime{Time: testNow, Valid: true}
+		m.db.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Return(expired, nil)
+
+		_, err := m.service.Authenticate(context.TODO(), key, "203.0.113.7")
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusUnauthorized, "api key has expired"), err)
+	})
+
+	t.Run("allows an address in the allowlist", func(t *testing.T) {
+		m := newServiceAccountMocker(t)
+		allowed := row()
+		allowed.AllowedIps = []string{"198.51.100.1", "203.0.113.0/24"}
+		m.db.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Return(allowed, nil)
+		m.db.EXPECT().TouchAPIKey(gomock.Any(), keyID).Return(nil)
+
+		_, err := m.service.Authenticate(context.TODO(), key, "203.0.113.7")
+		assert.NoError(t, err)
+	})
+
+	t.Run("forbids an address outside the allowlist", func(t *testing.T) {
+		m := newServiceAccountMocker(t)
+		restricted := row()
+		restricted.AllowedIps = []string{"198.51.100.1"}
+		m.db.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Return(restricted, nil)
+
+		_, err := m.service.Authenticate(context.TODO(), key, "203.0.113.7")
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusForbidden, "api key cannot be used from this address"), err)
+	})
+
+	t.Run("fails on database error", func(t *testing.T) {
+		m := newServiceAccountMocker(t)
+		m.db.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Return(models.GetAPIKeyByHashRow{}, errors.New("db down"))
+
+		_, err := m.service.Authenticate(context.TODO(), key, "203.0.113.7")
+		assert.Equal(t, platformerrors.ErrInternal, err)
+	})
+}
+
+type serviceAccountMocker struct {
+	db       *databasemocks.MockQuerier
+	auditLog *auditlog.MockService
+	service  *service
+}
+
+func newServiceAccountMocker(t *testing.T) *serviceAccountMocker {
+	ctrl := gomock.NewController(t)
+	db := databasemocks.NewMockQuerier(ctrl)
+	auditLog := auditlog.NewMockService(ctrl)
+
+	svc := NewService(db, auditLog).(*service)
+	svc.now = func() time.Time { return testNow }
+	return &serviceAccountMocker{
+		db:       db,
+		auditLog: auditLog,
+		service:  svc,
+	}
+}
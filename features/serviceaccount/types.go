@@ -0,0 +1,103 @@
+package serviceaccount
+
+import (
+	"github.com/google/uuid"
+	"payter-bank/internal/database/models"
+	"time"
+)
+
+// keyPrefix starts every API key so that leaked keys are easy to recognise.
+const keyPrefix = "pbk_"
+
+// displayPrefixLength is how much of a key is kept in plain text to tell keys apart.
+const displayPrefixLength = 12
+
+// ServiceAccount is a non-human principal used by integrations. It authenticates with its API keys
+// and can only do what the scopes of the key in use allow.
+type ServiceAccount struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedBy   uuid.UUID `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	Keys        []APIKey  `json:"keys,omitempty"`
+}
+
+func ServiceAccountFromModel(m models.ServiceAccount) ServiceAccount {
+	return ServiceAccount{
+		ID:          m.ID,
+		Name:        m.Name,
+		Description: m.Description,
+		CreatedBy:   m.CreatedBy,
+		CreatedAt:   m.CreatedAt.Time,
+	}
+}
+
+// APIKey describes a key, the key itself is only returned once when it is created.
+type APIKey struct {
+	ID               uuid.UUID  `json:"id"`
+	ServiceAccountID uuid.UUID  `json:"service_account_id"`
+	Name             string     `json:"name"`
+	Prefix           string     `json:"prefix"`
+	Scopes           []string   `json:"scopes"`
+	AllowedIPs       []string   `json:"allowed_ips"`
+	ExpiresAt        *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt       *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty"`
+	CreatedBy        uuid.UUID  `json:"created_by"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+func APIKeyFromModel(m models.ApiKey) APIKey {
+	k := APIKey{
+		ID:               m.ID,
+		ServiceAccountID: m.ServiceAccountID,
+		Name:             m.Name,
+		Prefix:           m.Prefix,
+		Scopes:           m.Scopes,
+		AllowedIPs:       m.AllowedIps,
+		CreatedBy:        m.CreatedBy,
+		CreatedAt:        m.CreatedAt.Time,
+	}
+	if m.ExpiresAt.Valid {
+		k.ExpiresAt = &m.ExpiresAt.Time
+	}
+	if m.LastUsedAt.Valid {
+		k.LastUsedAt = &m.LastUsedAt.Time
+	}
+	if m.RevokedAt.Valid {
+		k.RevokedAt = &m.RevokedAt.Time
+	}
+	return k
+}
+
+// CreatedAPIKey is returned when a key is created or rotated, Key cannot be retrieved again.
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+type CreateParams struct {
+	Name        string    `json:"name" binding:"required"`
+	Description string    `json:"description"`
+	ActorID     uuid.UUID `json:"-"`
+}
+
+// CreateKeyParams creates a key for a service account. Scopes are permission names and can only
+// be ones the admin creating the key holds. AllowedIPs takes addresses and CIDR ranges, an empty
+// list allows any address.
+type CreateKeyParams struct {
+	ServiceAccountID uuid.UUID  `json:"-"`
+	Name             string     `json:"name" binding:"required"`
+	Scopes           []string   `json:"scopes" binding:"required,min=1,dive,required"`
+	AllowedIPs       []string   `json:"allowed_ips"`
+	ExpiresAt        *time.Time `json:"expires_at"`
+	ActorID          uuid.UUID  `json:"-"`
+}
+
+// KeyParams identifies a key of a service account.
+type KeyParams struct {
+	ServiceAccountID uuid.UUID `json:"-"`
+	KeyID            uuid.UUID `json:"-"`
+	ActorID          uuid.UUID `json:"-"`
+}
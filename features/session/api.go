@@ -0,0 +1,290 @@
+package session
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"payter-bank/internal/api"
+	"payter-bank/internal/auth"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// RefreshHandler godoc
+// @Summary      Refresh tokens
+// @Description  Exchange a refresh token for a new access token and refresh token. Each refresh token can only be used once
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        params  body  RefreshParams  true  "Refresh token"
+// @Success      200  {object}  api.SuccessResponse{data=Tokens}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      401  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/users/refresh [post]
+func (h *Handler) RefreshHandler(ctx *gin.Context) api.Response {
+	var params RefreshParams
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	tokens, err := h.service.Refresh(ctx, params)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("tokens refreshed successfully", tokens)
+}
+
+// LogoutHandler godoc
+// @Summary      Logout
+// @Description  Revoke the access token used for this request and the session it belongs to
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  api.SuccessResponse
+// @Failure      401  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/users/logout [post]
+func (h *Handler) LogoutHandler(ctx *gin.Context) api.Response {
+	claim, err := auth.GetClaim(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+
+	params := LogoutParams{
+		UserID:    claim.UserID,
+		SessionID: claim.SessionID,
+	}
+	if tokenID, err := uuid.Parse(claim.ID); err == nil {
+		params.TokenID = tokenID
+	}
+	if claim.RegisteredClaims.ExpiresAt != nil {
+		params.ExpiresAt = claim.RegisteredClaims.ExpiresAt.Time
+	}
+
+	if err := h.service.Logout(ctx, params); err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("logged out successfully", nil)
+}
+
+// RevokeAllHandler godoc
+// @Summary      Revoke user sessions
+// @Description  Revoke every session of a user, forcing them to log in again. Only an admin can do this
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        id  path  string  true  "User ID"
+// @Success      200  {object}  api.SuccessResponse{data=RevokeAllResponse}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/admin/users/{id}/sessions/revoke [post]
+func (h *Handler) RevokeAllHandler(ctx *gin.Context) api.Response {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return api.BadRequest("user id is required")
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+
+	result, err := h.service.RevokeAll(ctx, RevokeAllParams{
+		UserID:  userID,
+		ActorID: profile.UserID,
+	})
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("sessions revoked successfully", result)
+}
+
+// ListHandler godoc
+// @Summary      List my sessions
+// @Description  List the most recent sessions of the current user with the IP and user agent they were started from. The session the request was made from is marked as current
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  api.SuccessResponse{data=[]Session}
+// @Failure      401  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/me/sessions [get]
+func (h *Handler) ListHandler(ctx *gin.Context) api.Response {
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+
+	params := ListParams{UserID: profile.UserID}
+	if claim, err := auth.GetClaim(ctx); err == nil {
+		params.CurrentSessionID = claim.SessionID
+	}
+
+	sessions, err := h.service.List(ctx, params)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("sessions retrieved successfully", sessions)
+}
+
+// LoginHistoryHandler godoc
+// @Summary      Get my login history
+// @Description  List the most recent login attempts of the current user with their IP, user agent and outcome
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  api.SuccessResponse{data=[]Login}
+// @Failure      401  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/me/logins [get]
+func (h *Handler) LoginHistoryHandler(ctx *gin.Context) api.Response {
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+
+	logins, err := h.service.LoginHistory(ctx, profile.UserID)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("login history retrieved successfully", logins)
+}
+
+// TerminateHandler godoc
+// @Summary      Terminate my session
+// @Description  Revoke one of the current user's sessions, e.g. one started from a device they do not recognise
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        id  path  string  true  "Session ID"
+// @Success      200  {object}  api.SuccessResponse
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/me/sessions/{id}/revoke [post]
+func (h *Handler) TerminateHandler(ctx *gin.Context) api.Response {
+	sessionID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return api.BadRequest("session id is required")
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+
+	err = h.service.Terminate(ctx, TerminateParams{
+		UserID:    profile.UserID,
+		SessionID: sessionID,
+		ActorID:   profile.UserID,
+	})
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("session terminated successfully", nil)
+}
+
+// AdminListHandler godoc
+// @Summary      List user sessions
+// @Description  List the most recent sessions of a user with the IP and user agent they were started from
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        id  path  string  true  "User ID"
+// @Success      200  {object}  api.SuccessResponse{data=[]Session}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/admin/users/{id}/sessions [get]
+func (h *Handler) AdminListHandler(ctx *gin.Context) api.Response {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return api.BadRequest("user id is required")
+	}
+
+	sessions, err := h.service.List(ctx, ListParams{UserID: userID})
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("sessions retrieved successfully", sessions)
+}
+
+// AdminLoginHistoryHandler godoc
+// @Summary      Get user login history
+// @Description  List the most recent login attempts of a user with their IP, user agent and outcome
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        id  path  string  true  "User ID"
+// @Success      200  {object}  api.SuccessResponse{data=[]Login}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/admin/users/{id}/logins [get]
+func (h *Handler) AdminLoginHistoryHandler(ctx *gin.Context) api.Response {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return api.BadRequest("user id is required")
+	}
+
+	logins, err := h.service.LoginHistory(ctx, userID)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("login history retrieved successfully", logins)
+}
+
+// AdminTerminateHandler godoc
+// @Summary      Terminate user session
+// @Description  Revoke a single session of a user
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        id         path  string  true  "User ID"
+// @Param        sessionId  path  string  true  "Session ID"
+// @Success      200  {object}  api.SuccessResponse
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/admin/users/{id}/sessions/{sessionId}/revoke [post]
+func (h *Handler) AdminTerminateHandler(ctx *gin.Context) api.Response {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return api.BadRequest("user id is required")
+	}
+	sessionID, err := uuid.Parse(ctx.Param("sessionId"))
+	if err != nil {
+		return api.BadRequest("session id is required")
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+
+	err = h.service.Terminate(ctx, TerminateParams{
+		UserID:    userID,
+		SessionID: sessionID,
+		ActorID:   profile.UserID,
+	})
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("session terminated successfully", nil)
+}
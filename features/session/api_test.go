@@ -0,0 +1,295 @@
+package session
+
+import (
+	"bytes"
+	"context"
+	jwtmiddleware "github.com/auth0/go-jwt-middleware/v2"
+	"github.com/auth0/go-jwt-middleware/v2/validator"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"payter-bank/internal/api"
+	"payter-bank/internal/auth"
+	platformerrors "payter-bank/internal/errors"
+	"payter-bank/internal/pkg/generator"
+	"testing"
+	"time"
+)
+
+func TestHandler_RefreshHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("refreshes tokens", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/users/refresh", bytes.NewBufferString(
+			`{"refresh_token":"old-token"}`))
+
+		tokens := Tokens{AccessToken: "access", RefreshToken: "refresh", ExpiresIn: 900}
+		mockService.EXPECT().Refresh(gomock.Any(), RefreshParams{RefreshToken: "old-token"}).Return(tokens, nil)
+
+		response := handler.RefreshHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    tokens,
+			Message: "tokens refreshed successfully",
+		}, response.Data)
+	})
+
+	t.Run("rejects missing refresh token", func(t *testing.T) {
+		handler := NewHandler(NewMockService(gomock.NewController(t)))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/users/refresh", bytes.NewBufferString(`{}`))
+
+		response := handler.RefreshHandler(c)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+
+	t.Run("returns unauthorized for invalid token", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/users/refresh", bytes.NewBufferString(
+			`{"refresh_token":"old-token"}`))
+
+		mockService.EXPECT().Refresh(gomock.Any(), gomock.Any()).Return(Tokens{}, errInvalidRefreshToken)
+
+		response := handler.RefreshHandler(c)
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+	})
+}
+
+func TestHandler_LogoutHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("logs out current token", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/users/logout", nil)
+
+		userID, sessionID, tokenID := uuid.New(), uuid.New(), uuid.New()
+		expiresAt := time.Date(2026, 5, 1, 9, 15, 0, 0, time.UTC)
+		injectClaim(c, &generator.Claim{
+			TokenData: generator.TokenData{UserID: userID, SessionID: sessionID},
+			RegisteredClaims: jwt.RegisteredClaims{
+				ID:        tokenID.String(),
+				ExpiresAt: jwt.NewNumericDate(expiresAt),
+			},
+		})
+
+		mockService.EXPECT().Logout(gomock.Any(), LogoutParams{
+			UserID:    userID,
+			SessionID: sessionID,
+			TokenID:   tokenID,
+			ExpiresAt: expiresAt,
+		}).Return(nil)
+
+		response := handler.LogoutHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+
+	t.Run("returns unauthorized without claims", func(t *testing.T) {
+		handler := NewHandler(NewMockService(gomock.NewController(t)))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/users/logout", nil)
+
+		response := handler.LogoutHandler(c)
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+	})
+}
+
+func TestHandler_RevokeAllHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("revokes user sessions", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		userID, adminID := uuid.New(), uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/admin/users/"+userID.String()+"/sessions/revoke", nil)
+		c.Params = gin.Params{{Key: "id", Value: userID.String()}}
+		injectProfile(c, auth.Profile{UserID: adminID, UserType: "ADMIN"})
+
+		mockService.EXPECT().RevokeAll(gomock.Any(), RevokeAllParams{UserID: userID, ActorID: adminID}).
+			Return(RevokeAllResponse{Revoked: 2}, nil)
+
+		response := handler.RevokeAllHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    RevokeAllResponse{Revoked: 2},
+			Message: "sessions revoked successfully",
+		}, response.Data)
+	})
+
+	t.Run("rejects invalid user id", func(t *testing.T) {
+		handler := NewHandler(NewMockService(gomock.NewController(t)))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/admin/users/abc/sessions/revoke", nil)
+		c.Params = gin.Params{{Key: "id", Value: "abc"}}
+
+		response := handler.RevokeAllHandler(c)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+
+	t.Run("returns not found for unknown user", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		userID := uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/admin/users/"+userID.String()+"/sessions/revoke", nil)
+		c.Params = gin.Params{{Key: "id", Value: userID.String()}}
+		injectProfile(c, auth.Profile{UserID: uuid.New()})
+
+		mockService.EXPECT().RevokeAll(gomock.Any(), gomock.Any()).
+			Return(RevokeAllResponse{}, platformerrors.MakeApiError(http.StatusNotFound, "user not found"))
+
+		response := handler.RevokeAllHandler(c)
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	})
+}
+
+func TestHandler_ListHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("lists sessions of current user", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		userID, sessionID := uuid.New(), uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/v1/api/me/sessions", nil)
+		injectClaim(c, &generator.Claim{TokenData: generator.TokenData{UserID: userID, SessionID: sessionID}})
+		injectProfile(c, auth.Profile{UserID: userID})
+
+		sessions := []Session{{ID: sessionID, Active: true, Current: true}}
+		mockService.EXPECT().List(gomock.Any(), ListParams{UserID: userID, CurrentSessionID: sessionID}).
+			Return(sessions, nil)
+
+		response := handler.ListHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    sessions,
+			Message: "sessions retrieved successfully",
+		}, response.Data)
+	})
+
+	t.Run("returns unauthorized without profile", func(t *testing.T) {
+		handler := NewHandler(NewMockService(gomock.NewController(t)))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/v1/api/me/sessions", nil)
+
+		response := handler.ListHandler(c)
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+	})
+}
+
+func TestHandler_TerminateHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("terminates own session", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		userID, sessionID := uuid.New(), uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/me/sessions/"+sessionID.String()+"/revoke", nil)
+		c.Params = gin.Params{{Key: "id", Value: sessionID.String()}}
+		injectProfile(c, auth.Profile{UserID: userID})
+
+		mockService.EXPECT().Terminate(gomock.Any(), TerminateParams{UserID: userID, SessionID: sessionID, ActorID: userID}).
+			Return(nil)
+
+		response := handler.TerminateHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+
+	t.Run("rejects invalid session id", func(t *testing.T) {
+		handler := NewHandler(NewMockService(gomock.NewController(t)))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/me/sessions/abc/revoke", nil)
+		c.Params = gin.Params{{Key: "id", Value: "abc"}}
+
+		response := handler.TerminateHandler(c)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestHandler_AdminTerminateHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("terminates user session", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		userID, adminID, sessionID := uuid.New(), uuid.New(), uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost,
+			"/v1/api/admin/users/"+userID.String()+"/sessions/"+sessionID.String()+"/revoke", nil)
+		c.Params = gin.Params{{Key: "id", Value: userID.String()}, {Key: "sessionId", Value: sessionID.String()}}
+		injectProfile(c, auth.Profile{UserID: adminID, UserType: "ADMIN"})
+
+		mockService.EXPECT().Terminate(gomock.Any(), TerminateParams{UserID: userID, SessionID: sessionID, ActorID: adminID}).
+			Return(nil)
+
+		response := handler.AdminTerminateHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+
+	t.Run("returns not found for unknown session", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		handler := NewHandler(mockService)
+
+		userID, sessionID := uuid.New(), uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost,
+			"/v1/api/admin/users/"+userID.String()+"/sessions/"+sessionID.String()+"/revoke", nil)
+		c.Params = gin.Params{{Key: "id", Value: userID.String()}, {Key: "sessionId", Value: sessionID.String()}}
+		injectProfile(c, auth.Profile{UserID: uuid.New()})
+
+		mockService.EXPECT().Terminate(gomock.Any(), gomock.Any()).Return(errSessionNotFound)
+
+		response := handler.AdminTerminateHandler(c)
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	})
+}
+
+func injectClaim(ctx *gin.Context, claim *generator.Claim) {
+	claims := &validator.ValidatedClaims{
+		CustomClaims: claim,
+	}
+	ctx.Request = ctx.Request.WithContext(context.WithValue(ctx.Request.Context(), jwtmiddleware.ContextKey{}, claims))
+}
+
+func injectProfile(ctx *gin.Context, profile auth.Profile) {
+	ctx.Request = ctx.Request.WithContext(context.WithValue(ctx.Request.Context(), auth.ProfileKey, profile))
+}
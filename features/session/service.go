@@ -0,0 +1,481 @@
+//go:generate mockgen -source=service.go -destination=service_mock.go -package=session
+
+package session
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"net/http"
+	"payter-bank/features/auditlog"
+	"payter-bank/features/notification"
+	"payter-bank/internal/config"
+	"payter-bank/internal/database/models"
+	platformerrors "payter-bank/internal/errors"
+	"payter-bank/internal/logger"
+	"payter-bank/internal/pkg/generator"
+	"strings"
+	"time"
+)
+
+type Service interface {
+	// Create starts a new session for a user who has just authenticated. The user is notified
+	// when the session is started from a device they have not logged in from before.
+	Create(ctx context.Context, data generator.TokenData) (Tokens, error)
+	// Refresh exchanges a refresh token for new tokens. Presenting a refresh token a second
+	// time revokes its whole session, the token is assumed to have been stolen. Tokens issued to
+	// an OAuth client are only refreshed for that client and keep the scopes it was granted.
+	Refresh(ctx context.Context, param RefreshParams) (Tokens, error)
+	Logout(ctx context.Context, param LogoutParams) error
+	// RevokeAll revokes every session of a user, their access tokens stop working immediately.
+	RevokeAll(ctx context.Context, param RevokeAllParams) (RevokeAllResponse, error)
+	// Elevate records that the user has just entered an MFA code in their session and issues an
+	// access token carrying it. The session's refresh token is left as it is.
+	Elevate(ctx context.Context, data generator.TokenData) (Tokens, error)
+	// RecordLogin adds a login attempt to the user's login history. A failure is only logged,
+	// it must not stop the login.
+	RecordLogin(ctx context.Context, attempt LoginAttempt)
+	// List returns the most recent sessions of a user, newest first.
+	List(ctx context.Context, param ListParams) ([]Session, error)
+	// LoginHistory returns the most recent login attempts of a user, newest first.
+	LoginHistory(ctx context.Context, userID uuid.UUID) ([]Login, error)
+	// Terminate revokes a single session of a user, its access tokens stop working immediately.
+	Terminate(ctx context.Context, param TerminateParams) error
+}
+
+var errInvalidRefreshToken = platformerrors.MakeApiError(http.StatusUnauthorized, "refresh token is invalid or has expired")
+
+var errSessionNotFound = platformerrors.MakeApiError(http.StatusNotFound, "session not found or already revoked")
+
+type service struct {
+	db             models.Querier
+	auditLog       auditlog.Service
+	notifications  notification.Service
+	tokenGenerator generator.TokenGenerator
+	cfg            config.JWTConfig
+	now            func() time.Time
+}
+
+func NewService(
+	db models.Querier,
+	auditLog auditlog.Service,
+	notifications notification.Service,
+	tokenGenerator generator.TokenGenerator,
+	cfg config.JWTConfig) Service {
+	return &service{
+		db:             db,
+		auditLog:       auditLog,
+		notifications:  notifications,
+		tokenGenerator: tokenGenerator,
+		cfg:            cfg,
+		now:            time.Now,
+	}
+}
+
+func (s *service) Create(ctx context.Context, data generator.TokenData) (Tokens, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Create"),
+		zap.Any(logger.RequestFields, data.UserID))
+
+	fingerprint := deviceFingerprint(data.UserAgent)
+	newDevice := s.isNewDevice(ctx, data.UserID, fingerprint)
+
+	session, err := s.db.SaveSession(ctx, models.SaveSessionParams{
+		UserID:            data.UserID,
+		MfaVerifiedAt:     sql.NullTime{Time: data.MFAVerifiedAt, Valid: !data.MFAVerifiedAt.IsZero()},
+		Ip:                nullString(data.IP),
+		UserAgent:         nullString(data.UserAgent),
+		DeviceFingerprint: nullString(fingerprint),
+		ClientID:          uuid.NullUUID{UUID: data.ClientID, Valid: data.ClientID != uuid.Nil},
+		Scopes:            scopes(data.Scope),
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to save session", zap.Error(err))
+		return Tokens{}, platformerrors.ErrInternal
+	}
+
+	tokens, err := s.issue(ctx, session.ID, data)
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	if newDevice {
+		s.notifyNewDevice(ctx, session)
+	}
+	return tokens, nil
+}
+
+func (s *service) Refresh(ctx context.Context, param RefreshParams) (Tokens, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Refresh"))
+
+	token, err := s.db.GetRefreshTokenByHash(ctx, generator.HashUserToken(param.RefreshToken))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Tokens{}, errInvalidRefreshToken
+		}
+		logger.Error(ctx, "failed to get refresh token", zap.Error(err))
+		return Tokens{}, platformerrors.ErrInternal
+	}
+
+	if token.SessionRevokedAt.Valid {
+		return Tokens{}, errInvalidRefreshToken
+	}
+
+	// a token issued to an app cannot be used by another app or to log in, and the reverse
+	if token.ClientID.UUID != param.ClientID {
+		return Tokens{}, errInvalidRefreshToken
+	}
+
+	if token.UsedAt.Valid {
+		s.revokeReusedSession(ctx, token)
+		return Tokens{}, errInvalidRefreshToken
+	}
+
+	if !s.now().Before(token.ExpiresAt) {
+		return Tokens{}, errInvalidRefreshToken
+	}
+
+	affected, err := s.db.MarkRefreshTokenUsed(ctx, token.ID)
+	if err != nil {
+		logger.Error(ctx, "failed to mark refresh token as used", zap.Error(err))
+		return Tokens{}, platformerrors.ErrInternal
+	}
+	if affected == 0 {
+		// another request rotated this token first
+		s.revokeReusedSession(ctx, token)
+		return Tokens{}, errInvalidRefreshToken
+	}
+
+	// deactivated users have no profile, their sessions cannot be refreshed
+	profile, err := s.db.GetProfileByUserID(ctx, token.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Tokens{}, errInvalidRefreshToken
+		}
+		logger.Error(ctx, "failed to get profile by user id", zap.Error(err))
+		return Tokens{}, platformerrors.ErrInternal
+	}
+
+	return s.issue(ctx, token.SessionID, generator.TokenData{
+		UserID:        profile.UserID,
+		AccountID:     profile.AccountID,
+		MFAVerifiedAt: token.MfaVerifiedAt.Time,
+		ClientID:      token.ClientID.UUID,
+		Scope:         strings.Join(token.Scopes, " "),
+	})
+}
+
+func (s *service) Logout(ctx context.Context, param LogoutParams) error {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Logout"),
+		zap.Any(logger.RequestFields, param))
+
+	if param.TokenID != uuid.Nil {
+		err := s.db.RevokeAccessToken(ctx, models.RevokeAccessTokenParams{
+			Jti:       param.TokenID,
+			ExpiresAt: param.ExpiresAt,
+		})
+		if err != nil {
+			logger.Error(ctx, "failed to revoke access token", zap.Error(err))
+			return platformerrors.ErrInternal
+		}
+	}
+
+	if param.SessionID != uuid.Nil {
+		_, err := s.db.RevokeSession(ctx, models.RevokeSessionParams{
+			ID:            param.SessionID,
+			RevokedReason: sql.NullString{String: ReasonLogout, Valid: true},
+		})
+		if err != nil {
+			logger.Error(ctx, "failed to revoke session", zap.Error(err))
+			return platformerrors.ErrInternal
+		}
+	}
+	return nil
+}
+
+func (s *service) RevokeAll(ctx context.Context, param RevokeAllParams) (RevokeAllResponse, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "RevokeAll"),
+		zap.Any(logger.RequestFields, param))
+
+	_, err := s.db.GetUserByID(ctx, param.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return RevokeAllResponse{}, platformerrors.MakeApiError(http.StatusNotFound, "user not found")
+		}
+		logger.Error(ctx, "failed to get user by id", zap.Error(err))
+		return RevokeAllResponse{}, platformerrors.ErrInternal
+	}
+
+	revoked, err := s.db.RevokeUserSessions(ctx, models.RevokeUserSessionsParams{
+		UserID:        param.UserID,
+		RevokedReason: sql.NullString{String: ReasonAdminRevoked, Valid: true},
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to revoke user sessions", zap.Error(err))
+		return RevokeAllResponse{}, platformerrors.ErrInternal
+	}
+
+	s.submitAudit(ctx, auditlog.NewEvent(auditlog.ActionUserSessionsRevoked, param.ActorID, uuid.Nil,
+		auditlog.SessionRevocationMetadata{
+			UserID:  param.UserID,
+			Reason:  ReasonAdminRevoked,
+			Revoked: revoked,
+		}))
+	return RevokeAllResponse{Revoked: revoked}, nil
+}
+
+func (s *service) Elevate(ctx context.Context, data generator.TokenData) (Tokens, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Elevate"),
+		zap.Any(logger.RequestFields, data.SessionID))
+
+	if data.SessionID == uuid.Nil {
+		return Tokens{}, platformerrors.MakeApiError(http.StatusUnauthorized, "log in again to verify this session")
+	}
+
+	now := s.now()
+	affected, err := s.db.SetSessionMFAVerified(ctx, models.SetSessionMFAVerifiedParams{
+		ID:            data.SessionID,
+		MfaVerifiedAt: sql.NullTime{Time: now, Valid: true},
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to record session mfa verification", zap.Error(err))
+		return Tokens{}, platformerrors.ErrInternal
+	}
+	if affected == 0 {
+		return Tokens{}, platformerrors.MakeApiError(http.StatusUnauthorized, "session has been revoked")
+	}
+
+	data.MFAVerifiedAt = now
+	accessToken, err := s.tokenGenerator.Generate(data)
+	if err != nil {
+		logger.Error(ctx, "failed to generate token", zap.Error(err))
+		return Tokens{}, platformerrors.ErrInternal
+	}
+
+	return Tokens{
+		AccessToken: accessToken,
+		ExpiresIn:   int64(s.cfg.Expiry.Seconds()),
+	}, nil
+}
+
+func (s *service) RecordLogin(ctx context.Context, attempt LoginAttempt) {
+	err := s.db.SaveLoginAttempt(ctx, models.SaveLoginAttemptParams{
+		UserID:    uuid.NullUUID{UUID: attempt.UserID, Valid: attempt.UserID != uuid.Nil},
+		Email:     attempt.Email,
+		Ip:        nullString(attempt.IP),
+		UserAgent: nullString(attempt.UserAgent),
+		Outcome:   attempt.Outcome,
+		SessionID: uuid.NullUUID{UUID: attempt.SessionID, Valid: attempt.SessionID != uuid.Nil},
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to save login attempt", zap.Error(err),
+			zap.String("outcome", attempt.Outcome))
+	}
+}
+
+func (s *service) List(ctx context.Context, param ListParams) ([]Session, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "List"),
+		zap.Any(logger.RequestFields, param))
+
+	rows, err := s.db.GetUserSessions(ctx, param.UserID)
+	if err != nil {
+		logger.Error(ctx, "failed to get user sessions", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	sessions := make([]Session, 0, len(rows))
+	for _, row := range rows {
+		session := SessionFromModel(row)
+		session.Current = row.ID == param.CurrentSessionID
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+func (s *service) LoginHistory(ctx context.Context, userID uuid.UUID) ([]Login, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "LoginHistory"),
+		zap.Any(logger.RequestFields, userID))
+
+	rows, err := s.db.GetLoginAttemptsByUserID(ctx, uuid.NullUUID{UUID: userID, Valid: true})
+	if err != nil {
+		logger.Error(ctx, "failed to get login attempts", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	logins := make([]Login, 0, len(rows))
+	for _, row := range rows {
+		logins = append(logins, LoginFromModel(row))
+	}
+	return logins, nil
+}
+
+func (s *service) Terminate(ctx context.Context, param TerminateParams) error {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Terminate"),
+		zap.Any(logger.RequestFields, param))
+
+	reason := ReasonTerminated
+	if param.ActorID != param.UserID {
+		reason = ReasonAdminRevoked
+	}
+
+	revoked, err := s.db.RevokeUserSession(ctx, models.RevokeUserSessionParams{
+		ID:            param.SessionID,
+		UserID:        param.UserID,
+		RevokedReason: sql.NullString{String: reason, Valid: true},
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to revoke session", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+	if revoked == 0 {
+		return errSessionNotFound
+	}
+
+	s.submitAudit(ctx, auditlog.NewEvent(auditlog.ActionSessionTerminated, param.ActorID, uuid.Nil,
+		auditlog.SessionRevocationMetadata{
+			UserID:    param.UserID,
+			SessionID: param.SessionID,
+			Reason:    reason,
+			Revoked:   revoked,
+		}))
+	return nil
+}
+
+// isNewDevice reports whether the user has logged in before but never from the device. Sessions
+// started before devices were recorded are not counted, so the first login after that is not
+// reported. A failed lookup is treated as a known device.
+func (s *service) isNewDevice(ctx context.Context, userID uuid.UUID, fingerprint string) bool {
+	if fingerprint == "" {
+		return false
+	}
+
+	device, err := s.db.CheckSessionDevice(ctx, models.CheckSessionDeviceParams{
+		UserID:            userID,
+		DeviceFingerprint: nullString(fingerprint),
+	})
+	if err != nil {
+		logger.Warn(ctx, "failed to check session device", zap.Error(err))
+		return false
+	}
+	return device.HasDevices && !device.Known
+}
+
+func (s *service) notifyNewDevice(ctx context.Context, session models.Session) {
+	device := session.UserAgent.String
+	if device == "" {
+		device = "an unknown device"
+	}
+	ip := session.Ip.String
+	if ip == "" {
+		ip = "an unknown IP address"
+	}
+
+	err := s.notifications.Notify(ctx, notification.Message{
+		UserID:  session.UserID,
+		Type:    notification.TypeNewDeviceLogin,
+		Subject: "New login to your account",
+		Message: fmt.Sprintf("Your account was logged in to from %s at %s on %s. If this was not you, "+
+			"change your password and terminate the session.",
+			device, ip, s.now().UTC().Format(time.RFC1123)),
+		Metadata: map[string]any{
+			"session_id": session.ID,
+			"ip":         session.Ip.String,
+			"user_agent": session.UserAgent.String,
+		},
+	})
+	if err != nil {
+		logger.Warn(ctx, "failed to send new device notification", zap.Error(err))
+	}
+}
+
+// deviceFingerprint identifies the device a login comes from by a hash of its user agent, empty
+// when the client sent none.
+func deviceFingerprint(userAgent string) string {
+	if userAgent == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(userAgent))
+	return hex.EncodeToString(sum[:])
+}
+
+// scopes splits the scopes granted to an OAuth client, sessions started by a login have none.
+func scopes(scope string) []string {
+	if scope == "" {
+		return nil
+	}
+	return strings.Fields(scope)
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+// issue signs an access token for the session and saves a new refresh token for it.
+func (s *service) issue(ctx context.Context, sessionID uuid.UUID, data generator.TokenData) (Tokens, error) {
+	data.SessionID = sessionID
+	accessToken, err := s.tokenGenerator.Generate(data)
+	if err != nil {
+		logger.Error(ctx, "failed to generate token", zap.Error(err))
+		return Tokens{}, platformerrors.ErrInternal
+	}
+
+	refreshToken, hash := generator.DefaultUserTokenGenerator.Generate()
+	_, err = s.db.SaveRefreshToken(ctx, models.SaveRefreshTokenParams{
+		SessionID: sessionID,
+		TokenHash: hash,
+		ExpiresAt: s.now().Add(s.cfg.RefreshExpiry),
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to save refresh token", zap.Error(err))
+		return Tokens{}, platformerrors.ErrInternal
+	}
+
+	return Tokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(s.cfg.Expiry.Seconds()),
+		SessionID:    sessionID,
+		Scope:        data.Scope,
+	}, nil
+}
+
+func (s *service) revokeReusedSession(ctx context.Context, token models.GetRefreshTokenByHashRow) {
+	logger.Warn(ctx, "refresh token reused, revoking session",
+		zap.String("session_id", token.SessionID.String()),
+		zap.String("user_id", token.UserID.String()))
+
+	revoked, err := s.db.RevokeSession(ctx, models.RevokeSessionParams{
+		ID:            token.SessionID,
+		RevokedReason: sql.NullString{String: ReasonTokenReuse, Valid: true},
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to revoke session", zap.Error(err))
+		return
+	}
+
+	s.submitAudit(ctx, auditlog.NewEvent(auditlog.ActionRefreshTokenReuse, token.UserID, uuid.Nil,
+		auditlog.SessionRevocationMetadata{
+			UserID:    token.UserID,
+			SessionID: token.SessionID,
+			Reason:    ReasonTokenReuse,
+			Revoked:   revoked,
+		}))
+}
+
+func (s *service) submitAudit(ctx context.Context, event auditlog.Event) {
+	if err := s.auditLog.Submit(ctx, event); err != nil {
+		logger.Error(ctx, "failed to queue audit log", zap.Error(err))
+	}
+}
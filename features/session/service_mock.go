@@ -0,0 +1,173 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=service_mock.go -package=session
+//
+
+// Package session is a generated GoMock package.
+package session
+
+import (
+	context "context"
+	generator "payter-bank/internal/pkg/generator"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockService) Create(ctx context.Context, data generator.TokenData) (Tokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, data)
+	ret0, _ := ret[0].(Tokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockServiceMockRecorder) Create(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockService)(nil).Create), ctx, data)
+}
+
+// Elevate mocks base method.
+func (m *MockService) Elevate(ctx context.Context, data generator.TokenData) (Tokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Elevate", ctx, data)
+	ret0, _ := ret[0].(Tokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Elevate indicates an expected call of Elevate.
+func (mr *MockServiceMockRecorder) Elevate(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Elevate", reflect.TypeOf((*MockService)(nil).Elevate), ctx, data)
+}
+
+// List mocks base method.
+func (m *MockService) List(ctx context.Context, param ListParams) ([]Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, param)
+	ret0, _ := ret[0].([]Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockServiceMockRecorder) List(ctx, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockService)(nil).List), ctx, param)
+}
+
+// LoginHistory mocks base method.
+func (m *MockService) LoginHistory(ctx context.Context, userID uuid.UUID) ([]Login, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginHistory", ctx, userID)
+	ret0, _ := ret[0].([]Login)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginHistory indicates an expected call of LoginHistory.
+func (mr *MockServiceMockRecorder) LoginHistory(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginHistory", reflect.TypeOf((*MockService)(nil).LoginHistory), ctx, userID)
+}
+
+// Logout mocks base method.
+func (m *MockService) Logout(ctx context.Context, param LogoutParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, param)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockServiceMockRecorder) Logout(ctx, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockService)(nil).Logout), ctx, param)
+}
+
+// RecordLogin mocks base method.
+func (m *MockService) RecordLogin(ctx context.Context, attempt LoginAttempt) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordLogin", ctx, attempt)
+}
+
+// RecordLogin indicates an expected call of RecordLogin.
+func (mr *MockServiceMockRecorder) RecordLogin(ctx, attempt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordLogin", reflect.TypeOf((*MockService)(nil).RecordLogin), ctx, attempt)
+}
+
+// Refresh mocks base method.
+func (m *MockService) Refresh(ctx context.Context, param RefreshParams) (Tokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, param)
+	ret0, _ := ret[0].(Tokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockServiceMockRecorder) Refresh(ctx, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockService)(nil).Refresh), ctx, param)
+}
+
+// RevokeAll mocks base method.
+func (m *MockService) RevokeAll(ctx context.Context, param RevokeAllParams) (RevokeAllResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAll", ctx, param)
+	ret0, _ := ret[0].(RevokeAllResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAll indicates an expected call of RevokeAll.
+func (mr *MockServiceMockRecorder) RevokeAll(ctx, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAll", reflect.TypeOf((*MockService)(nil).RevokeAll), ctx, param)
+}
+
+// Terminate mocks base method.
+func (m *MockService) Terminate(ctx context.Context, param TerminateParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Terminate", ctx, param)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Terminate indicates an expected call of Terminate.
+func (mr *MockServiceMockRecorder) Terminate(ctx, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Terminate", reflect.TypeOf((*MockService)(nil).Terminate), ctx, param)
+}
@@ -0,0 +1,659 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"payter-bank/features/auditlog"
+	"payter-bank/features/notification"
+	"payter-bank/internal/config"
+	"payter-bank/internal/database/models"
+	databasemocks "payter-bank/internal/database/models/mocks"
+	platformerrors "payter-bank/internal/errors"
+	"payter-bank/internal/pkg/generator"
+	generatormocks "payter-bank/internal/pkg/generator/mocks"
+	"testing"
+	"time"
+)
+
+var fixedNow = time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+
+func TestService_Create(t *testing.T) {
+	t.Run("starts session and issues tokens", func(t *testing.T) {
+		m := newSessionMocker(t)
+		userID, accountID, sessionID := uuid.New(), uuid.New(), uuid.New()
+
+		m.db.EXPECT().SaveSession(gomock.Any(), models.SaveSessionParams{UserID: userID}).
+			Return(models.Session{ID: sessionID, UserID: userID}, nil)
+		m.tokenGenerator.EXPECT().Generate(generator.TokenData{
+			UserID:    userID,
+			AccountID: accountID,
+			SessionID: sessionID,
+		}).Return("access-token", nil)
+		m.userTokenGenerator.EXPECT().Generate().Return("refresh-token", "refresh-hash")
+		m.db.EXPECT().SaveRefreshToken(gomock.Any(), models.SaveRefreshTokenParams{
+			SessionID: sessionID,
+			TokenHash: "refresh-hash",
+			ExpiresAt: fixedNow.Add(720 * time.Hour),
+		}).Return(models.RefreshToken{}, nil)
+
+		tokens, err := m.service.Create(context.TODO(), generator.TokenData{UserID: userID, AccountID: accountID})
+		assert.NoError(t, err)
+		assert.Equal(t, Tokens{
+			AccessToken:  "access-token",
+			RefreshToken: "refresh-token",
+			ExpiresIn:    900,
+			SessionID:    sessionID,
+		}, tokens)
+	})
+
+	t.Run("records client of session", func(t *testing.T) {
+		m := newSessionMocker(t)
+		userID, sessionID := uuid.New(), uuid.New()
+		data := generator.TokenData{UserID: userID, IP: "203.0.113.7", UserAgent: "Mozilla/5.0 (X11; Linux x86_64)"}
+		fingerprint := deviceFingerprint("Mozilla/5.0 (X11; Linux x86_64)")
+
+		m.db.EXPECT().CheckSessionDevice(gomock.Any(), models.CheckSessionDeviceParams{
+			UserID:            userID,
+			DeviceFingerprint: sql.NullString{String: fingerprint, Valid: true},
+		}).Return(models.CheckSessionDeviceRow{HasDevices: true, Known: true}, nil)
+		m.db.EXPECT().SaveSession(gomock.Any(), models.SaveSessionParams{
+			UserID:            userID,
+			Ip:                sql.NullString{String: "203.0.113.7", Valid: true},
+			UserAgent:         sql.NullString{String: "Mozilla/5.0 (X11; Linux x86_64)", Valid: true},
+			DeviceFingerprint: sql.NullString{String: fingerprint, Valid: true},
+		}).Return(models.Session{ID: sessionID, UserID: userID}, nil)
+		m.tokenGenerator.EXPECT().Generate(gomock.Any()).Return("access-token", nil)
+		m.userTokenGenerator.EXPECT().Generate().Return("refresh-token", "refresh-hash")
+		m.db.EXPECT().SaveRefreshToken(gomock.Any(), gomock.Any()).Return(models.RefreshToken{}, nil)
+
+		_, err := m.service.Create(context.TODO(), data)
+		assert.NoError(t, err)
+	})
+
+	t.Run("records oauth client and scopes of session", func(t *testing.T) {
+		m := newSessionMocker(t)
+		userID, clientID, sessionID := uuid.New(), uuid.New(), uuid.New()
+
+		m.db.EXPECT().SaveSession(gomock.Any(), models.SaveSessionParams{
+			UserID:   userID,
+			ClientID: uuid.NullUUID{UUID: clientID, Valid: true},
+			Scopes:   []string{"openid", "accounts:read"},
+		}).Return(models.Session{ID: sessionID, UserID: userID}, nil)
+		m.tokenGenerator.EXPECT().Generate(gomock.Any()).Return("access-token", nil)
+		m.userTokenGenerator.EXPECT().Generate().Return("refresh-token", "refresh-hash")
+		m.db.EXPECT().SaveRefreshToken(gomock.Any(), gomock.Any()).Return(models.RefreshToken{}, nil)
+
+		tokens, err := m.service.Create(context.TODO(), generator.TokenData{
+			UserID:   userID,
+			ClientID: clientID,
+			Scope:    "openid accounts:read",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "openid accounts:read", tokens.Scope)
+	})
+
+	t.Run("notifies user of login from new device", func(t *testing.T) {
+		m := newSessionMocker(t)
+		userID, sessionID := uuid.New(), uuid.New()
+		data := generator.TokenData{UserID: userID, IP: "203.0.113.7", UserAgent: "curl/8.5.0"}
+
+		m.db.EXPECT().CheckSessionDevice(gomock.Any(), gomock.Any()).
+			Return(models.CheckSessionDeviceRow{HasDevices: true, Known: false}, nil)
+		m.db.EXPECT().SaveSession(gomock.Any(), gomock.Any()).Return(models.Session{
+			ID:        sessionID,
+			UserID:    userID,
+			Ip:        sql.NullString{String: "203.0.113.7", Valid: true},
+			UserAgent: sql.NullString{String: "curl/8.5.0", Valid: true},
+		}, nil)
+		m.tokenGenerator.EXPECT().Generate(gomock.Any()).Return("access-token", nil)
+		m.userTokenGenerator.EXPECT().Generate().Return("refresh-token", "refresh-hash")
+		m.db.EXPECT().SaveRefreshToken(gomock.Any(), gomock.Any()).Return(models.RefreshToken{}, nil)
+		m.notifications.EXPECT().Notify(gomock.Any(), notification.Message{
+			UserID:  userID,
+			Type:    notification.TypeNewDeviceLogin,
+			Subject: "New login to your account",
+			Message: "Your account was logged in to from curl/8.5.0 at 203.0.113.7 on Fri, 01 May 2026 09:00:00 UTC. " +
+				"If this was not you, change your password and terminate the session.",
+			Metadata: map[string]any{
+				"session_id": sessionID,
+				"ip":         "203.0.113.7",
+				"user_agent": "curl/8.5.0",
+			},
+		}).Return(nil)
+
+		_, err := m.service.Create(context.TODO(), data)
+		assert.NoError(t, err)
+	})
+
+	t.Run("does not notify on first login", func(t *testing.T) {
+		m := newSessionMocker(t)
+		data := generator.TokenData{UserID: uuid.New(), UserAgent: "curl/8.5.0"}
+
+		m.db.EXPECT().CheckSessionDevice(gomock.Any(), gomock.Any()).
+			Return(models.CheckSessionDeviceRow{HasDevices: false, Known: false}, nil)
+		m.db.EXPECT().SaveSession(gomock.Any(), gomock.Any()).Return(models.Session{ID: uuid.New()}, nil)
+		m.tokenGenerator.EXPECT().Generate(gomock.Any()).Return("access-token", nil)
+		m.userTokenGenerator.EXPECT().Generate().Return("refresh-token", "refresh-hash")
+		m.db.EXPECT().SaveRefreshToken(gomock.Any(), gomock.Any()).Return(models.RefreshToken{}, nil)
+
+		_, err := m.service.Create(context.TODO(), data)
+		assert.NoError(t, err)
+	})
+
+	t.Run("records mfa verification on session", func(t *testing.T) {
+		m := newSessionMocker(t)
+		userID, sessionID := uuid.New(), uuid.New()
+		data := generator.TokenData{UserID: userID, MFAVerifiedAt: fixedNow}
+
+		m.db.EXPECT().SaveSession(gomock.Any(), models.SaveSessionParams{
+			UserID:        userID,
+			MfaVerifiedAt: sql.NullTime{Time: fixedNow, Valid: true},
+		}).Return(models.Session{ID: sessionID, UserID: userID}, nil)
+		m.tokenGenerator.EXPECT().Generate(generator.TokenData{
+			UserID:        userID,
+			SessionID:     sessionID,
+			MFAVerifiedAt: fixedNow,
+		}).Return("access-token", nil)
+		m.userTokenGenerator.EXPECT().Generate().Return("refresh-token", "refresh-hash")
+		m.db.EXPECT().SaveRefreshToken(gomock.Any(), gomock.Any()).Return(models.RefreshToken{}, nil)
+
+		_, err := m.service.Create(context.TODO(), data)
+		assert.NoError(t, err)
+	})
+
+	t.Run("fails when session cannot be saved", func(t *testing.T) {
+		m := newSessionMocker(t)
+		m.db.EXPECT().SaveSession(gomock.Any(), gomock.Any()).Return(models.Session{}, errors.New("db down"))
+
+		_, err := m.service.Create(context.TODO(), generator.TokenData{UserID: uuid.New()})
+		assert.Equal(t, platformerrors.ErrInternal, err)
+	})
+
+	t.Run("fails when token cannot be generated", func(t *testing.T) {
+		m := newSessionMocker(t)
+		m.db.EXPECT().SaveSession(gomock.Any(), gomock.Any()).Return(models.Session{ID: uuid.New()}, nil)
+		m.tokenGenerator.EXPECT().Generate(gomock.Any()).Return("", errors.New("signing failed"))
+
+		_, err := m.service.Create(context.TODO(), generator.TokenData{UserID: uuid.New()})
+		assert.Equal(t, platformerrors.ErrInternal, err)
+	})
+}
+
+func TestService_Refresh(t *testing.T) {
+	userID, accountID, sessionID, tokenID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	validToken := func() models.GetRefreshTokenByHashRow {
+		return models.GetRefreshTokenByHashRow{
+			ID:        tokenID,
+			SessionID: sessionID,
+			ExpiresAt: fixedNow.Add(time.Hour),
+			UserID:    userID,
+		}
+	}
+
+	t.Run("rotates refresh token", func(t *testing.T) {
+		m := newSessionMocker(t)
+		m.db.EXPECT().GetRefreshTokenByHash(gomock.Any(), generator.HashUserToken("old-token")).Return(validToken(), nil)
+		m.db.EXPECT().MarkRefreshTokenUsed(gomock.Any(), tokenID).Return(int64(1), nil)
+		m.db.EXPECT().GetProfileByUserID(gomock.Any(), userID).Return(models.GetProfileByUserIDRow{
+			UserID:    userID,
+			AccountID: accountID,
+		}, nil)
+		m.tokenGenerator.EXPECT().Generate(generator.TokenData{
+			UserID:    userID,
+			AccountID: accountID,
+			SessionID: sessionID,
+		}).Return("access-token", nil)
+		m.userTokenGenerator.EXPECT().Generate().Return("new-token", "new-hash")
+		m.db.EXPECT().SaveRefreshToken(gomock.Any(), models.SaveRefreshTokenParams{
+			SessionID: sessionID,
+			TokenHash: "new-hash",
+			ExpiresAt: fixedNow.Add(720 * time.Hour),
+		}).Return(models.RefreshToken{}, nil)
+
+		tokens, err := m.service.Refresh(context.TODO(), RefreshParams{RefreshToken: "old-token"})
+		assert.NoError(t, err)
+		assert.Equal(t, "access-token", tokens.AccessToken)
+		assert.Equal(t, "new-token", tokens.RefreshToken)
+	})
+
+	t.Run("keeps mfa verification of session", func(t *testing.T) {
+		m := newSessionMocker(t)
+		verifiedAt := fixedNow.Add(-time.Hour)
+		token := validToken()
+		token.MfaVerifiedAt = sql.NullTime{Time: verifiedAt, Valid: true}
+
+		m.db.EXPECT().GetRefreshTokenByHash(gomock.Any(), gomock.Any()).Return(token, nil)
+		m.db.EXPECT().MarkRefreshTokenUsed(gomock.Any(), tokenID).Return(int64(1), nil)
+		m.db.EXPECT().GetProfileByUserID(gomock.Any(), userID).Return(models.GetProfileByUserIDRow{
+			UserID:    userID,
+			AccountID: accountID,
+		}, nil)
+		m.tokenGenerator.EXPECT().Generate(generator.TokenData{
+			UserID:        userID,
+			AccountID:     accountID,
+			SessionID:     sessionID,
+			MFAVerifiedAt: verifiedAt,
+		}).Return("access-token", nil)
+		m.userTokenGenerator.EXPECT().Generate().Return("new-token", "new-hash")
+		m.db.EXPECT().SaveRefreshToken(gomock.Any(), gomock.Any()).Return(models.RefreshToken{}, nil)
+
+		_, err := m.service.Refresh(context.TODO(), RefreshParams{RefreshToken: "old-token"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("keeps client and scopes of oauth session", func(t *testing.T) {
+		m := newSessionMocker(t)
+		clientID := uuid.New()
+		token := validToken()
+		token.ClientID = uuid.NullUUID{UUID: clientID, Valid: true}
+		token.Scopes = []string{"openid", "payments:write"}
+
+		m.db.EXPECT().GetRefreshTokenByHash(gomock.Any(), gomock.Any()).Return(token, nil)
+		m.db.EXPECT().MarkRefreshTokenUsed(gomock.Any(), tokenID).Return(int64(1), nil)
+		m.db.EXPECT().GetProfileByUserID(gomock.Any(), userID).Return(models.GetProfileByUserIDRow{
+			UserID:    userID,
+			AccountID: accountID,
+		}, nil)
+		m.tokenGenerator.EXPECT().Generate(generator.TokenData{
+			UserID:    userID,
+			AccountID: accountID,
+			SessionID: sessionID,
+			ClientID:  clientID,
+			Scope:     "openid payments:write",
+		}).Return("access-token", nil)
+		m.userTokenGenerator.EXPECT().Generate().Return("new-token", "new-hash")
+		m.db.EXPECT().SaveRefreshToken(gomock.Any(), gomock.Any()).Return(models.RefreshToken{}, nil)
+
+		tokens, err := m.service.Refresh(context.TODO(), RefreshParams{RefreshToken: "old-token", ClientID: clientID})
+		assert.NoError(t, err)
+		assert.Equal(t, "openid payments:write", tokens.Scope)
+	})
+
+	t.Run("rejects oauth token presented without its client", func(t *testing.T) {
+		m := newSessionMocker(t)
+		token := validToken()
+		token.ClientID = uuid.NullUUID{UUID: uuid.New(), Valid: true}
+		m.db.EXPECT().GetRefreshTokenByHash(gomock.Any(), gomock.Any()).Return(token, nil)
+
+		_, err := m.service.Refresh(context.TODO(), RefreshParams{RefreshToken: "old-token"})
+		assert.Equal(t, errInvalidRefreshToken, err)
+
+		m.db.EXPECT().GetRefreshTokenByHash(gomock.Any(), gomock.Any()).Return(token, nil)
+		_, err = m.service.Refresh(context.TODO(), RefreshParams{RefreshToken: "old-token", ClientID: uuid.New()})
+		assert.Equal(t, errInvalidRefreshToken, err)
+	})
+
+	t.Run("rejects unknown token", func(t *testing.T) {
+		m := newSessionMocker(t)
+		m.db.EXPECT().GetRefreshTokenByHash(gomock.Any(), gomock.Any()).Return(models.GetRefreshTokenByHashRow{}, sql.ErrNoRows)
+
+		_, err := m.service.Refresh(context.TODO(), RefreshParams{RefreshToken: "unknown"})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusUnauthorized, "refresh token is invalid or has expired"), err)
+	})
+
+	t.Run("rejects expired token", func(t *testing.T) {
+		m := newSessionMocker(t)
+		token := validToken()
+		token.ExpiresAt = fixedNow.Add(-time.Minute)
+		m.db.EXPECT().GetRefreshTokenByHash(gomock.Any(), gomock.Any()).Return(token, nil)
+
+		_, err := m.service.Refresh(context.TODO(), RefreshParams{RefreshToken: "old-token"})
+		assert.Equal(t, errInvalidRefreshToken, err)
+	})
+
+	t.Run("rejects token of revoked session", func(t *testing.T) {
+		m := newSessionMocker(t)
+		token := validToken()
+		token.SessionRevokedAt = sql.NullTime{Time: fixedNow, Valid: true}
+		m.db.EXPECT().GetRefreshTokenByHash(gomock.Any(), gomock.Any()).Return(token, nil)
+
+		_, err := m.service.Refresh(context.TODO(), RefreshParams{RefreshToken: "old-token"})
+		assert.Equal(t, errInvalidRefreshToken, err)
+	})
+
+	t.Run("revokes session when used token is presented again", func(t *testing.T) {
+		m := newSessionMocker(t)
+		token := validToken()
+		token.UsedAt = sql.NullTime{Time: fixedNow.Add(-time.Minute), Valid: true}
+		m.db.EXPECT().GetRefreshTokenByHash(gomock.Any(), gomock.Any()).Return(token, nil)
+		m.db.EXPECT().RevokeSession(gomock.Any(), models.RevokeSessionParams{
+			ID:            sessionID,
+			RevokedReason: sql.NullString{String: ReasonTokenReuse, Valid: true},
+		}).Return(int64(1), nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionRefreshTokenReuse, userID, uuid.Nil,
+			auditlog.SessionRevocationMetadata{
+				UserID:    userID,
+				SessionID: sessionID,
+				Reason:    ReasonTokenReuse,
+				Revoked:   1,
+			})).Return(nil)
+
+		_, err := m.service.Refresh(context.TODO(), RefreshParams{RefreshToken: "old-token"})
+		assert.Equal(t, errInvalidRefreshToken, err)
+	})
+
+	t.Run("revokes session when token is rotated concurrently", func(t *testing.T) {
+		m := newSessionMocker(t)
+		m.db.EXPECT().GetRefreshTokenByHash(gomock.Any(), gomock.Any()).Return(validToken(), nil)
+		m.db.EXPECT().MarkRefreshTokenUsed(gomock.Any(), tokenID).Return(int64(0), nil)
+		m.db.EXPECT().RevokeSession(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), gomock.Any()).Return(nil)
+
+		_, err := m.service.Refresh(context.TODO(), RefreshParams{RefreshToken: "old-token"})
+		assert.Equal(t, errInvalidRefreshToken, err)
+	})
+
+	t.Run("rejects refresh for deactivated user", func(t *testing.T) {
+		m := newSessionMocker(t)
+		m.db.EXPECT().GetRefreshTokenByHash(gomock.Any(), gomock.Any()).Return(validToken(), nil)
+		m.db.EXPECT().MarkRefreshTokenUsed(gomock.Any(), tokenID).Return(int64(1), nil)
+		m.db.EXPECT().GetProfileByUserID(gomock.Any(), userID).Return(models.GetProfileByUserIDRow{}, sql.ErrNoRows)
+
+		_, err := m.service.Refresh(context.TODO(), RefreshParams{RefreshToken: "old-token"})
+		assert.Equal(t, errInvalidRefreshToken, err)
+	})
+}
+
+func TestService_Logout(t *testing.T) {
+	t.Run("revokes access token and session", func(t *testing.T) {
+		m := newSessionMocker(t)
+		param := LogoutParams{
+			UserID:    uuid.New(),
+			SessionID: uuid.New(),
+			TokenID:   uuid.New(),
+			ExpiresAt: fixedNow.Add(10 * time.Minute),
+		}
+		m.db.EXPECT().RevokeAccessToken(gomock.Any(), models.RevokeAccessTokenParams{
+			Jti:       param.TokenID,
+			ExpiresAt: param.ExpiresAt,
+		}).Return(nil)
+		m.db.EXPECT().RevokeSession(gomock.Any(), models.RevokeSessionParams{
+			ID:            param.SessionID,
+			RevokedReason: sql.NullString{String: ReasonLogout, Valid: true},
+		}).Return(int64(1), nil)
+
+		assert.NoError(t, m.service.Logout(context.TODO(), param))
+	})
+
+	t.Run("skips session for tokens issued without one", func(t *testing.T) {
+		m := newSessionMocker(t)
+		m.db.EXPECT().RevokeAccessToken(gomock.Any(), gomock.Any()).Return(nil)
+
+		assert.NoError(t, m.service.Logout(context.TODO(), LogoutParams{UserID: uuid.New(), TokenID: uuid.New()}))
+	})
+
+	t.Run("fails when access token cannot be revoked", func(t *testing.T) {
+		m := newSessionMocker(t)
+		m.db.EXPECT().RevokeAccessToken(gomock.Any(), gomock.Any()).Return(errors.New("db down"))
+
+		err := m.service.Logout(context.TODO(), LogoutParams{TokenID: uuid.New(), SessionID: uuid.New()})
+		assert.Equal(t, platformerrors.ErrInternal, err)
+	})
+}
+
+func TestService_RevokeAll(t *testing.T) {
+	userID, adminID := uuid.New(), uuid.New()
+
+	t.Run("revokes all sessions of user", func(t *testing.T) {
+		m := newSessionMocker(t)
+		m.db.EXPECT().GetUserByID(gomock.Any(), userID).Return(models.GetUserByIDRow{ID: userID}, nil)
+		m.db.EXPECT().RevokeUserSessions(gomock.Any(), models.RevokeUserSessionsParams{
+			UserID:        userID,
+			RevokedReason: sql.NullString{String: ReasonAdminRevoked, Valid: true},
+		}).Return(int64(3), nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionUserSessionsRevoked, adminID, uuid.Nil,
+			auditlog.SessionRevocationMetadata{
+				UserID:  userID,
+				Reason:  ReasonAdminRevoked,
+				Revoked: 3,
+			})).Return(nil)
+
+		result, err := m.service.RevokeAll(context.TODO(), RevokeAllParams{UserID: userID, ActorID: adminID})
+		assert.NoError(t, err)
+		assert.Equal(t, RevokeAllResponse{Revoked: 3}, result)
+	})
+
+	t.Run("fails when user does not exist", func(t *testing.T) {
+		m := newSessionMocker(t)
+		m.db.EXPECT().GetUserByID(gomock.Any(), userID).Return(models.GetUserByIDRow{}, sql.ErrNoRows)
+
+		_, err := m.service.RevokeAll(context.TODO(), RevokeAllParams{UserID: userID, ActorID: adminID})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusNotFound, "user not found"), err)
+	})
+}
+
+func TestService_RecordLogin(t *testing.T) {
+	t.Run("saves login attempt", func(t *testing.T) {
+		m := newSessionMocker(t)
+		userID, sessionID := uuid.New(), uuid.New()
+
+		m.db.EXPECT().SaveLoginAttempt(gomock.Any(), models.SaveLoginAttemptParams{
+			UserID:    uuid.NullUUID{UUID: userID, Valid: true},
+			Email:     "jane@example.com",
+			Ip:        sql.NullString{String: "203.0.113.7", Valid: true},
+			UserAgent: sql.NullString{String: "curl/8.5.0", Valid: true},
+			Outcome:   OutcomeSuccess,
+			SessionID: uuid.NullUUID{UUID: sessionID, Valid: true},
+		}).Return(nil)
+
+		m.service.RecordLogin(context.TODO(), LoginAttempt{
+			UserID:    userID,
+			Email:     "jane@example.com",
+			IP:        "203.0.113.7",
+			UserAgent: "curl/8.5.0",
+			Outcome:   OutcomeSuccess,
+			SessionID: sessionID,
+		})
+	})
+
+	t.Run("saves attempt for unknown email without user", func(t *testing.T) {
+		m := newSessionMocker(t)
+		m.db.EXPECT().SaveLoginAttempt(gomock.Any(), models.SaveLoginAttemptParams{
+			Email:   "nobody@example.com",
+			Outcome: OutcomeInvalidCredentials,
+		}).Return(errors.New("db down"))
+
+		m.service.RecordLogin(context.TODO(), LoginAttempt{Email: "nobody@example.com", Outcome: OutcomeInvalidCredentials})
+	})
+}
+
+func TestService_List(t *testing.T) {
+	userID, currentID, otherID := uuid.New(), uuid.New(), uuid.New()
+
+	t.Run("lists sessions and marks current one", func(t *testing.T) {
+		m := newSessionMocker(t)
+		m.db.EXPECT().GetUserSessions(gomock.Any(), userID).Return([]models.GetUserSessionsRow{
+			{
+				ID:        currentID,
+				Ip:        sql.NullString{String: "203.0.113.7", Valid: true},
+				UserAgent: sql.NullString{String: "curl/8.5.0", Valid: true},
+				CreatedAt: sql.NullTime{Time: fixedNow, Valid: true},
+				Active:    true,
+			},
+			{
+				ID:            otherID,
+				RevokedAt:     sql.NullTime{Time: fixedNow, Valid: true},
+				RevokedReason: sql.NullString{String: ReasonLogout, Valid: true},
+				CreatedAt:     sql.NullTime{Time: fixedNow.Add(-time.Hour), Valid: true},
+			},
+		}, nil)
+
+		sessions, err := m.service.List(context.TODO(), ListParams{UserID: userID, CurrentSessionID: currentID})
+		assert.NoError(t, err)
+		assert.Equal(t, []Session{
+			{
+				ID:        currentID,
+				IP:        "203.0.113.7",
+				UserAgent: "curl/8.5.0",
+				Active:    true,
+				Current:   true,
+				CreatedAt: fixedNow,
+			},
+			{
+				ID:            otherID,
+				CreatedAt:     fixedNow.Add(-time.Hour),
+				RevokedAt:     &fixedNow,
+				RevokedReason: ReasonLogout,
+			},
+		}, sessions)
+	})
+
+	t.Run("fails when sessions cannot be read", func(t *testing.T) {
+		m := newSessionMocker(t)
+		m.db.EXPECT().GetUserSessions(gomock.Any(), userID).Return(nil, errors.New("db down"))
+
+		_, err := m.service.List(context.TODO(), ListParams{UserID: userID})
+		assert.Equal(t, platformerrors.ErrInternal, err)
+	})
+}
+
+func TestService_LoginHistory(t *testing.T) {
+	userID, attemptID, sessionID := uuid.New(), uuid.New(), uuid.New()
+
+	m := newSessionMocker(t)
+	m.db.EXPECT().GetLoginAttemptsByUserID(gomock.Any(), uuid.NullUUID{UUID: userID, Valid: true}).
+		Return([]models.LoginAttempt{{
+			ID:        attemptID,
+			Ip:        sql.NullString{String: "203.0.113.7", Valid: true},
+			Outcome:   OutcomeSuccess,
+			SessionID: uuid.NullUUID{UUID: sessionID, Valid: true},
+			CreatedAt: sql.NullTime{Time: fixedNow, Valid: true},
+		}}, nil)
+
+	logins, err := m.service.LoginHistory(context.TODO(), userID)
+	assert.NoError(t, err)
+	assert.Equal(t, []Login{{
+		ID:        attemptID,
+		IP:        "203.0.113.7",
+		Outcome:   OutcomeSuccess,
+		SessionID: &sessionID,
+		CreatedAt: fixedNow,
+	}}, logins)
+}
+
+func TestService_Terminate(t *testing.T) {
+	userID, adminID, sessionID := uuid.New(), uuid.New(), uuid.New()
+
+	t.Run("terminates own session", func(t *testing.T) {
+		m := newSessionMocker(t)
+		m.db.EXPECT().RevokeUserSession(gomock.Any(), models.RevokeUserSessionParams{
+			ID:            sessionID,
+			UserID:        userID,
+			RevokedReason: sql.NullString{String: ReasonTerminated, Valid: true},
+		}).Return(int64(1), nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionSessionTerminated, userID, uuid.Nil,
+			auditlog.SessionRevocationMetadata{
+				UserID:    userID,
+				SessionID: sessionID,
+				Reason:    ReasonTerminated,
+				Revoked:   1,
+			})).Return(nil)
+
+		err := m.service.Terminate(context.TODO(), TerminateParams{UserID: userID, SessionID: sessionID, ActorID: userID})
+		assert.NoError(t, err)
+	})
+
+	t.Run("admin terminates user session", func(t *testing.T) {
+		m := newSessionMocker(t)
+		m.db.EXPECT().RevokeUserSession(gomock.Any(), models.RevokeUserSessionParams{
+			ID:            sessionID,
+			UserID:        userID,
+			RevokedReason: sql.NullString{String: ReasonAdminRevoked, Valid: true},
+		}).Return(int64(1), nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionSessionTerminated, adminID, uuid.Nil,
+			auditlog.SessionRevocationMetadata{
+				UserID:    userID,
+				SessionID: sessionID,
+				Reason:    ReasonAdminRevoked,
+				Revoked:   1,
+			})).Return(nil)
+
+		err := m.service.Terminate(context.TODO(), TerminateParams{UserID: userID, SessionID: sessionID, ActorID: adminID})
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects session of another user or already revoked", func(t *testing.T) {
+		m := newSessionMocker(t)
+		m.db.EXPECT().RevokeUserSession(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+
+		err := m.service.Terminate(context.TODO(), TerminateParams{UserID: userID, SessionID: sessionID, ActorID: userID})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusNotFound, "session not found or already revoked"), err)
+	})
+}
+
+func TestService_Elevate(t *testing.T) {
+	userID, sessionID := uuid.New(), uuid.New()
+
+	t.Run("reissues access token as mfa verified", func(t *testing.T) {
+		m := newSessionMocker(t)
+		m.db.EXPECT().SetSessionMFAVerified(gomock.Any(), models.SetSessionMFAVerifiedParams{
+			ID:            sessionID,
+			MfaVerifiedAt: sql.NullTime{Time: fixedNow, Valid: true},
+		}).Return(int64(1), nil)
+		m.tokenGenerator.EXPECT().Generate(generator.TokenData{
+			UserID:        userID,
+			SessionID:     sessionID,
+			MFAVerifiedAt: fixedNow,
+		}).Return("access-token", nil)
+
+		tokens, err := m.service.Elevate(context.TODO(), generator.TokenData{UserID: userID, SessionID: sessionID})
+		assert.NoError(t, err)
+		assert.Equal(t, Tokens{AccessToken: "access-token", ExpiresIn: 900}, tokens)
+	})
+
+	t.Run("rejects tokens without session", func(t *testing.T) {
+		m := newSessionMocker(t)
+
+		_, err := m.service.Elevate(context.TODO(), generator.TokenData{UserID: userID})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusUnauthorized, "log in again to verify this session"), err)
+	})
+
+	t.Run("rejects revoked session", func(t *testing.T) {
+		m := newSessionMocker(t)
+		m.db.EXPECT().SetSessionMFAVerified(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+
+		_, err := m.service.Elevate(context.TODO(), generator.TokenData{UserID: userID, SessionID: sessionID})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusUnauthorized, "session has been revoked"), err)
+	})
+}
+
+type sessionMocker struct {
+	db                 *databasemocks.MockQuerier
+	auditLog           *auditlog.MockService
+	notifications      *notification.MockService
+	tokenGenerator     *generatormocks.MockTokenGenerator
+	userTokenGenerator *generatormocks.MockUserTokenGenerator
+
+	service Service
+}
+
+func newSessionMocker(t *testing.T) *sessionMocker {
+	ctrl := gomock.NewController(t)
+	db := databasemocks.NewMockQuerier(ctrl)
+	auditLog := auditlog.NewMockService(ctrl)
+	notifications := notification.NewMockService(ctrl)
+	tokenGenerator := generatormocks.NewMockTokenGenerator(ctrl)
+	userTokenGenerator := generatormocks.NewMockUserTokenGenerator(ctrl)
+
+	generator.DefaultUserTokenGenerator = userTokenGenerator
+
+	cfg := config.JWTConfig{
+		Expiry:        15 * time.Minute,
+		RefreshExpiry: 720 * time.Hour,
+	}
+	svc := NewService(db, auditLog, notifications, tokenGenerator, cfg)
+	svc.(*service).now = func() time.Time { return fixedNow }
+	return &sessionMocker{
+		db:                 db,
+		auditLog:           auditLog,
+		notifications:      notifications,
+		tokenGenerator:     tokenGenerator,
+		userTokenGenerator: userTokenGenerator,
+		service:            svc,
+	}
+}
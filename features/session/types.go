@@ -0,0 +1,147 @@
+package session
+
+import (
+	"github.com/google/uuid"
+	"payter-bank/internal/database/models"
+	"time"
+)
+
+// Reasons recorded on revoked sessions.
+const (
+	ReasonLogout         = "logout"
+	ReasonTokenReuse     = "refresh_token_reuse"
+	ReasonAdminRevoked   = "admin_revoked"
+	ReasonPasswordReset  = "password_reset"
+	ReasonPasswordChange = "password_change"
+	ReasonTerminated     = "terminated"
+	ReasonClientRevoked  = "client_revoked"
+	ReasonTokenRevoked   = "token_revoked"
+)
+
+// Outcomes recorded on login attempts.
+const (
+	OutcomeSuccess                = "success"
+	OutcomeInvalidCredentials     = "invalid_credentials"
+	OutcomeLockedOut              = "locked_out"
+	OutcomeDeactivated            = "deactivated"
+	OutcomeEmailUnverified        = "email_unverified"
+	OutcomeMFARequired            = "mfa_required"
+	OutcomePasswordChangeRequired = "password_change_required"
+)
+
+// Tokens are issued on login and on every refresh. The refresh token can only be used once,
+// it is left out when only the access token is reissued. SessionID is the session the tokens
+// belong to and Scope the scopes granted to an OAuth client, neither is sent to the client.
+type Tokens struct {
+	AccessToken  string    `json:"token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	ExpiresIn    int64     `json:"expires_in"`
+	SessionID    uuid.UUID `json:"-"`
+	Scope        string    `json:"-"`
+}
+
+// RefreshParams carry the refresh token to exchange. ClientID is the OAuth client presenting it,
+// a refresh token can only be used by the client it was issued to.
+type RefreshParams struct {
+	RefreshToken string    `json:"refresh_token" binding:"required"`
+	ClientID     uuid.UUID `json:"-"`
+}
+
+// LogoutParams identify the access token used to log out and the session it belongs to.
+type LogoutParams struct {
+	UserID    uuid.UUID
+	SessionID uuid.UUID
+	TokenID   uuid.UUID
+	ExpiresAt time.Time
+}
+
+type RevokeAllParams struct {
+	UserID  uuid.UUID
+	ActorID uuid.UUID
+}
+
+type RevokeAllResponse struct {
+	Revoked int64 `json:"revoked"`
+}
+
+// LoginAttempt is a password login and its outcome. UserID is empty when the email is not registered.
+// SessionID is set when the login started a session.
+type LoginAttempt struct {
+	UserID    uuid.UUID
+	Email     string
+	IP        string
+	UserAgent string
+	Outcome   string
+	SessionID uuid.UUID
+}
+
+// Session is a login session of a user. Active sessions have not been revoked and can still be refreshed.
+// ClientID is set for sessions of an app the user authorized with OAuth.
+type Session struct {
+	ID            uuid.UUID  `json:"id"`
+	ClientID      *uuid.UUID `json:"client_id,omitempty"`
+	IP            string     `json:"ip,omitempty"`
+	UserAgent     string     `json:"user_agent,omitempty"`
+	Active        bool       `json:"active"`
+	MFAVerified   bool       `json:"mfa_verified"`
+	Current       bool       `json:"current"`
+	CreatedAt     time.Time  `json:"created_at"`
+	RevokedAt     *time.Time `json:"revoked_at,omitempty"`
+	RevokedReason string     `json:"revoked_reason,omitempty"`
+}
+
+func SessionFromModel(s models.GetUserSessionsRow) Session {
+	session := Session{
+		ID:            s.ID,
+		IP:            s.Ip.String,
+		UserAgent:     s.UserAgent.String,
+		Active:        s.Active,
+		MFAVerified:   s.MfaVerifiedAt.Valid,
+		CreatedAt:     s.CreatedAt.Time,
+		RevokedReason: s.RevokedReason.String,
+	}
+	if s.ClientID.Valid {
+		session.ClientID = &s.ClientID.UUID
+	}
+	if s.RevokedAt.Valid {
+		session.RevokedAt = &s.RevokedAt.Time
+	}
+	return session
+}
+
+type Login struct {
+	ID        uuid.UUID  `json:"id"`
+	IP        string     `json:"ip,omitempty"`
+	UserAgent string     `json:"user_agent,omitempty"`
+	Outcome   string     `json:"outcome"`
+	SessionID *uuid.UUID `json:"session_id,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func LoginFromModel(a models.LoginAttempt) Login {
+	login := Login{
+		ID:        a.ID,
+		IP:        a.Ip.String,
+		UserAgent: a.UserAgent.String,
+		Outcome:   a.Outcome,
+		CreatedAt: a.CreatedAt.Time,
+	}
+	if a.SessionID.Valid {
+		login.SessionID = &a.SessionID.UUID
+	}
+	return login
+}
+
+// ListParams select the sessions of UserID. CurrentSessionID marks the session the request was made from.
+type ListParams struct {
+	UserID           uuid.UUID
+	CurrentSessionID uuid.UUID
+}
+
+// TerminateParams revoke SessionID, which must belong to UserID. ActorID is the admin terminating
+// it, or the user themselves.
+type TerminateParams struct {
+	UserID    uuid.UUID
+	SessionID uuid.UUID
+	ActorID   uuid.UUID
+}
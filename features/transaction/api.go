@@ -3,17 +3,20 @@ package transaction
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"payter-bank/features/approval"
 	"payter-bank/internal/api"
 	"payter-bank/internal/auth"
 )
 
 type Handler struct {
-	service Service
+	service   Service
+	approvals approval.Service
 }
 
-func NewHandler(service Service) *Handler {
+func NewHandler(service Service, approvals approval.Service) *Handler {
 	return &Handler{
-		service: service,
+		service:   service,
+		approvals: approvals,
 	}
 }
 
@@ -25,6 +28,7 @@ func NewHandler(service Service) *Handler {
 // @Produce      json
 // @Param        account  body  AccountTransactionParams  true  "credit transaction params"
 // @Success      200  {object}  api.SuccessResponse{data=Response}
+// @Success      202  {object}  api.SuccessResponse{data=approval.Request}
 // @Failure      400  {object}  api.ErrorResponse
 // @Failure      404  {object}  api.ErrorResponse
 // @Failure      500  {object}  api.ErrorResponse
@@ -41,6 +45,18 @@ func (h *Handler) CreditAccountHandler(ctx *gin.Context) api.Response {
 	}
 
 	params.UserID = profile.UserID
+	if h.approvals.Requires(approval.OperationCredit, params.Amount) {
+		request, err := h.approvals.Submit(ctx, approval.SubmitParams{
+			Operation:   approval.OperationCredit,
+			Payload:     params,
+			RequestedBy: profile.UserID,
+		})
+		if err != nil {
+			return api.Error(err)
+		}
+		return api.Accepted("credit is awaiting approval", request)
+	}
+
 	resp, err := h.service.CreditAccount(ctx, params)
 	if err != nil {
 		return api.Error(err)
@@ -57,6 +73,7 @@ func (h *Handler) CreditAccountHandler(ctx *gin.Context) api.Response {
 // @Produce      json
 // @Param        account  body  AccountTransactionParams  true  "account transaction params"
 // @Success      200  {object}  api.SuccessResponse{data=Response}
+// @Success      202  {object}  api.SuccessResponse{data=approval.Request}
 // @Failure      400  {object}  api.ErrorResponse
 // @Failure      404  {object}  api.ErrorResponse
 // @Failure      500  {object}  api.ErrorResponse
@@ -73,6 +90,18 @@ func (h *Handler) DebitAccountHandler(ctx *gin.Context) api.Response {
 	}
 
 	params.UserID = profile.UserID
+	if h.approvals.Requires(approval.OperationDebit, params.Amount) {
+		request, err := h.approvals.Submit(ctx, approval.SubmitParams{
+			Operation:   approval.OperationDebit,
+			Payload:     params,
+			RequestedBy: profile.UserID,
+		})
+		if err != nil {
+			return api.Error(err)
+		}
+		return api.Accepted("debit is awaiting approval", request)
+	}
+
 	resp, err := h.service.DebitAccount(ctx, params)
 	if err != nil {
 		return api.Error(err)
@@ -117,6 +146,59 @@ func (h *Handler) TransferFundsHandler(ctx *gin.Context) api.Response {
 	return api.OK("transaction successful", resp)
 }
 
+// ReverseTransactionHandler godoc
+// @Summary      Reverse a transaction
+// @Description  Reverse a transaction by posting its opposite - this endpoint can only be used by the admin. A transaction can only be reversed once and a reversal cannot be reversed.
+// @Tags         transactions
+// @Accept       json
+// @Produce      json
+// @Param        id        path  string                    true  "Transaction ID"
+// @Param        reversal  body  ReverseTransactionParams  true  "reversal params"
+// @Success      200  {object}  api.SuccessResponse{data=Response}
+// @Success      202  {object}  api.SuccessResponse{data=approval.Request}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      409  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/transactions/{id}/reverse [post]
+func (h *Handler) ReverseTransactionHandler(ctx *gin.Context) api.Response {
+	transactionID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return api.BadRequest("transaction ID is required")
+	}
+
+	var params ReverseTransactionParams
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+
+	params.TransactionID = transactionID
+	params.UserID = profile.UserID
+	if h.approvals.Requires(approval.OperationReversal, 0) {
+		request, err := h.approvals.Submit(ctx, approval.SubmitParams{
+			Operation:   approval.OperationReversal,
+			Payload:     params,
+			RequestedBy: profile.UserID,
+		})
+		if err != nil {
+			return api.Error(err)
+		}
+		return api.Accepted("reversal is awaiting approval", request)
+	}
+
+	resp, err := h.service.ReverseTransaction(ctx, params)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("transaction reversed successfully", resp)
+}
+
 // BalanceHandler godoc
 // @Summary      Get account balance.
 // @Description  Get account balance for the specified account.
@@ -139,7 +221,7 @@ func (h *Handler) BalanceHandler(ctx *gin.Context) api.Response {
 		return api.Unauthorized("unauthorized")
 	}
 
-	if profile.UserType != "ADMIN" && profile.AccountID != accountID {
+	if !profile.HasPermission(auth.PermTransactionsRead) && profile.AccountID != accountID {
 		return api.PreConditionFailed("you are not authorized to view this account balance")
 	}
 
@@ -173,7 +255,7 @@ func (h *Handler) GetTransactionHistoryHandler(ctx *gin.Context) api.Response {
 		return api.Unauthorized("unauthorized")
 	}
 
-	if profile.UserType != "ADMIN" && profile.AccountID != accountID {
+	if !profile.HasPermission(auth.PermTransactionsRead) && profile.AccountID != accountID {
 		return api.Unauthorized("you are not authorized to view this account's transactions")
 	}
 
@@ -10,6 +10,7 @@ import (
 	"go.uber.org/mock/gomock"
 	"net/http"
 	"net/http/httptest"
+	"payter-bank/features/approval"
 	"payter-bank/internal/api"
 	"payter-bank/internal/auth"
 	platformerrors "payter-bank/internal/errors"
@@ -39,7 +40,7 @@ func TestHandler_CreditAccountHandler(t *testing.T) {
 		mockService.EXPECT().CreditAccount(gomock.Any(), expectedParam).
 			Return(&response, nil)
 
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Request = httptest.NewRequest("POST", "/v1/api/credit", bytes.NewBuffer(body))
@@ -53,6 +54,42 @@ func TestHandler_CreditAccountHandler(t *testing.T) {
 		})
 	})
 
+	t.Run("credit above the approval threshold waits for approval", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := NewMockService(ctrl)
+		approvals := approval.NewMockService(ctrl)
+		userID := uuid.New()
+		params := AccountTransactionParams{
+			FromAccountID: uuid.New(),
+			ToAccountID:   uuid.New(),
+			Amount:        50000,
+			Narration:     "Large deposit",
+			UserID:        userID,
+		}
+		request := approval.Request{ID: uuid.New(), Operation: approval.OperationCredit, Status: "PENDING"}
+
+		approvals.EXPECT().Requires(approval.OperationCredit, 50000.0).Return(true)
+		approvals.EXPECT().Submit(gomock.Any(), approval.SubmitParams{
+			Operation:   approval.OperationCredit,
+			Payload:     params,
+			RequestedBy: userID,
+		}).Return(request, nil)
+
+		body, _ := json.Marshal(params)
+		handler := NewHandler(mockService, approvals)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/v1/api/credit", bytes.NewBuffer(body))
+		injectProfile(c, auth.Profile{UserID: userID})
+
+		resp := handler.CreditAccountHandler(c)
+		assert.Equal(t, http.StatusAccepted, resp.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    request,
+			Message: "credit is awaiting approval",
+		}, resp.Data)
+	})
+
 	t.Run("failed to credit account - missing amount", func(t *testing.T) {
 		mockService := NewMockService(gomock.NewController(t))
 		fromAccountID := uuid.MustParse("1938dc36-aef5-4ef9-b0ae-1bb08b2ccbab")
@@ -60,7 +97,7 @@ func TestHandler_CreditAccountHandler(t *testing.T) {
 		userID := uuid.MustParse("1938dc36-aef5-4ef9-b0ae-1bb08b2ccbab")
 
 		body := `{"from_account_id": "` + fromAccountID.String() + `", "to_account_id": "` + toAccountID.String() + `", "narration": "Spending money for dinner", "user_id": "` + userID.String() + `"}`
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Request = httptest.NewRequest("POST", "/v1/api/credit", bytes.NewBufferString(body))
@@ -85,7 +122,7 @@ func TestHandler_CreditAccountHandler(t *testing.T) {
 			Return(nil, platformerrors.MakeApiError(http.StatusNotFound, "account not found"))
 
 		body := `{"to_account_id": "` + toAccountID.String() + `", "amount":100, "narration": "Spending money for dinner", "user_id": "` + userID.String() + `"}`
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Request = httptest.NewRequest("POST", "/v1/api/credit", bytes.NewBufferString(body))
@@ -116,7 +153,7 @@ func TestHandler_DebitAccountHandler(t *testing.T) {
 		body, _ := json.Marshal(expectedParam)
 		mockService.EXPECT().DebitAccount(gomock.Any(), expectedParam).
 			Return(&response, nil)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Request = httptest.NewRequest("POST", "/v1/api/debit", bytes.NewBuffer(body))
@@ -130,13 +167,49 @@ func TestHandler_DebitAccountHandler(t *testing.T) {
 		})
 	})
 
+	t.Run("debit above the approval threshold waits for approval", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := NewMockService(ctrl)
+		approvals := approval.NewMockService(ctrl)
+		userID := uuid.New()
+		params := AccountTransactionParams{
+			FromAccountID: uuid.New(),
+			ToAccountID:   uuid.New(),
+			Amount:        50000,
+			Narration:     "Large withdrawal",
+			UserID:        userID,
+		}
+		request := approval.Request{ID: uuid.New(), Operation: approval.OperationDebit, Status: "PENDING"}
+
+		approvals.EXPECT().Requires(approval.OperationDebit, 50000.0).Return(true)
+		approvals.EXPECT().Submit(gomock.Any(), approval.SubmitParams{
+			Operation:   approval.OperationDebit,
+			Payload:     params,
+			RequestedBy: userID,
+		}).Return(request, nil)
+
+		body, _ := json.Marshal(params)
+		handler := NewHandler(mockService, approvals)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/v1/api/debit", bytes.NewBuffer(body))
+		injectProfile(c, auth.Profile{UserID: userID})
+
+		resp := handler.DebitAccountHandler(c)
+		assert.Equal(t, http.StatusAccepted, resp.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    request,
+			Message: "debit is awaiting approval",
+		}, resp.Data)
+	})
+
 	t.Run("failed to debit account - missing amount", func(t *testing.T) {
 		mockService := NewMockService(gomock.NewController(t))
 		fromAccountID := uuid.MustParse("1938dc36-aef5-4ef9-b0ae-1bb08b2ccbab")
 		toAccountID := uuid.MustParse("824312b8-ec3c-467a-8c84-8d14a2f2fc76")
 		userID := uuid.MustParse("1938dc36-aef5-4ef9-b0ae-1bb08b2ccbab")
 		body := `{"from_account_id": "` + fromAccountID.String() + `", "to_account_id": "` + toAccountID.String() + `", "narration": "Spending money for dinner", "user_id": "` + userID.String() + `"}`
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 		w := httptest.NewRecorder()
 
 		c, _ := gin.CreateTestContext(w)
@@ -159,7 +232,7 @@ func TestHandler_DebitAccountHandler(t *testing.T) {
 		mockService.EXPECT().DebitAccount(gomock.Any(), expectedParam).
 			Return(nil, platformerrors.MakeApiError(http.StatusNotFound, "account not found"))
 		body := `{"to_account_id": "` + toAccountID.String() + `", "amount":100, "narration": "Spending money for dinner", "user_id": "` + userID.String() + `"}`
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Request = httptest.NewRequest("POST", "/v1/api/debit", bytes.NewBufferString(body))
@@ -183,7 +256,7 @@ func TestHandler_DebitAccountHandler(t *testing.T) {
 		body, _ := json.Marshal(expectedParam)
 		mockService.EXPECT().DebitAccount(gomock.Any(), expectedParam).
 			Return(nil, platformerrors.ErrInternal)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Request = httptest.NewRequest("POST", "/v1/api/debit", bytes.NewBuffer(body))
@@ -194,12 +267,91 @@ func TestHandler_DebitAccountHandler(t *testing.T) {
 	})
 }
 
+func TestHandler_ReverseTransactionHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("reversal waits for approval", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := NewMockService(ctrl)
+		approvals := approval.NewMockService(ctrl)
+		transactionID, userID := uuid.New(), uuid.New()
+		params := ReverseTransactionParams{
+			TransactionID: transactionID,
+			Reason:        "paid to the wrong account",
+			UserID:        userID,
+		}
+		request := approval.Request{ID: uuid.New(), Operation: approval.OperationReversal, Status: "PENDING"}
+
+		approvals.EXPECT().Requires(approval.OperationReversal, 0.0).Return(true)
+		approvals.EXPECT().Submit(gomock.Any(), approval.SubmitParams{
+			Operation:   approval.OperationReversal,
+			Payload:     params,
+			RequestedBy: userID,
+		}).Return(request, nil)
+		mockService.EXPECT().ReverseTransaction(gomock.Any(), gomock.Any()).Times(0)
+
+		handler := NewHandler(mockService, approvals)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/v1/api/transactions/"+transactionID.String()+"/reverse",
+			bytes.NewBufferString(`{"reason": "paid to the wrong account"}`))
+		c.Params = []gin.Param{{Key: "id", Value: transactionID.String()}}
+		injectProfile(c, auth.Profile{UserID: userID})
+
+		resp := handler.ReverseTransactionHandler(c)
+		assert.Equal(t, http.StatusAccepted, resp.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    request,
+			Message: "reversal is awaiting approval",
+		}, resp.Data)
+	})
+
+	t.Run("reverses transaction when approval is not required", func(t *testing.T) {
+		mockService := NewMockService(gomock.NewController(t))
+		transactionID, userID := uuid.New(), uuid.New()
+		response := Response{TransactionID: uuid.New()}
+		mockService.EXPECT().ReverseTransaction(gomock.Any(), ReverseTransactionParams{
+			TransactionID: transactionID,
+			Reason:        "paid to the wrong account",
+			UserID:        userID,
+		}).Return(&response, nil)
+
+		handler := NewHandler(mockService, withoutApprovals(t))
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/v1/api/transactions/"+transactionID.String()+"/reverse",
+			bytes.NewBufferString(`{"reason": "paid to the wrong account"}`))
+		c.Params = []gin.Param{{Key: "id", Value: transactionID.String()}}
+		injectProfile(c, auth.Profile{UserID: userID})
+
+		resp := handler.ReverseTransactionHandler(c)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    &response,
+			Message: "transaction reversed successfully",
+		}, resp.Data)
+	})
+
+	t.Run("fails without a reason", func(t *testing.T) {
+		handler := NewHandler(NewMockService(gomock.NewController(t)), withoutApprovals(t))
+		transactionID := uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/v1/api/transactions/"+transactionID.String()+"/reverse",
+			bytes.NewBufferString(`{}`))
+		c.Params = []gin.Param{{Key: "id", Value: transactionID.String()}}
+		injectProfile(c, auth.Profile{UserID: uuid.New()})
+
+		resp := handler.ReverseTransactionHandler(c)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+}
+
 func TestTransferFundsHandler(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	t.Run("successfully transfers funds", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := NewMockService(ctrl)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 		userID, accountID := uuid.New(), uuid.New()
 		profile := auth.Profile{
 			UserID:    userID,
@@ -240,7 +392,7 @@ func TestTransferFundsHandler(t *testing.T) {
 	t.Run("fails when user is not authenticated", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := NewMockService(ctrl)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 
 		req := AccountTransactionParams{
 			FromAccountID: uuid.New(),
@@ -262,7 +414,7 @@ func TestTransferFundsHandler(t *testing.T) {
 	t.Run("fails with invalid request body", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := NewMockService(ctrl)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 
 		invalidBody := `{"amount": "invalid"}`
 
@@ -282,7 +434,7 @@ func TestTransferFundsHandler(t *testing.T) {
 	t.Run("fails with missing required fields", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := NewMockService(ctrl)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 
 		req := AccountTransactionParams{
 			FromAccountID: uuid.New(),
@@ -307,7 +459,7 @@ func TestTransferFundsHandler(t *testing.T) {
 	t.Run("fails when service returns error", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := NewMockService(ctrl)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 		userID, accountID := uuid.New(), uuid.New()
 		profile := auth.Profile{
 			UserID:    userID,
@@ -342,7 +494,7 @@ func TestHandler_BalanceHandler(t *testing.T) {
 	t.Run("successfully gets balance for own account", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := NewMockService(ctrl)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 
 		accountID, userID := uuid.New(), uuid.New()
 		expectedBalance := Balance{
@@ -379,7 +531,7 @@ func TestHandler_BalanceHandler(t *testing.T) {
 	t.Run("successfully gets balance for any account as admin", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := NewMockService(ctrl)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 
 		adminAccountID := uuid.New()
 		targetAccountID := uuid.New()
@@ -391,9 +543,10 @@ func TestHandler_BalanceHandler(t *testing.T) {
 			Currency:      "GBP",
 		}
 		profile := auth.Profile{
-			UserID:    uuid.New(),
-			AccountID: adminAccountID,
-			UserType:  "ADMIN",
+			UserID:      uuid.New(),
+			AccountID:   adminAccountID,
+			UserType:    "ADMIN",
+			Permissions: []auth.Permission{auth.PermTransactionsRead},
 		}
 
 		mockService.EXPECT().
@@ -418,7 +571,7 @@ func TestHandler_BalanceHandler(t *testing.T) {
 	t.Run("fails with invalid account ID", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := NewMockService(ctrl)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
@@ -432,7 +585,7 @@ func TestHandler_BalanceHandler(t *testing.T) {
 	t.Run("fails when unauthorized", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := NewMockService(ctrl)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 
 		accountID := uuid.New()
 
@@ -451,7 +604,7 @@ func TestHandler_BalanceHandler(t *testing.T) {
 	t.Run("fails when service returns error", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := NewMockService(ctrl)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 
 		accountID := uuid.New()
 
@@ -479,7 +632,7 @@ func TestHandler_GetTransactionHistoryHandler(t *testing.T) {
 	t.Run("successfully gets transaction history for own account", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := NewMockService(ctrl)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 
 		accountID, transactionID, accountID1 := uuid.New(), uuid.New(), uuid.New()
 		profile := auth.Profile{
@@ -524,12 +677,13 @@ func TestHandler_GetTransactionHistoryHandler(t *testing.T) {
 	t.Run("successfully gets transaction history as admin", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := NewMockService(ctrl)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 		targetAccountID, transactionID, accountID1 := uuid.New(), uuid.New(), uuid.New()
 		profile := auth.Profile{
-			AccountID: uuid.New(),
-			UserID:    uuid.New(),
-			UserType:  "ADMIN",
+			AccountID:   uuid.New(),
+			UserID:      uuid.New(),
+			UserType:    "ADMIN",
+			Permissions: []auth.Permission{auth.PermTransactionsRead},
 		}
 
 		expectedTransactions := []Transaction{
@@ -568,7 +722,7 @@ func TestHandler_GetTransactionHistoryHandler(t *testing.T) {
 	t.Run("fails with invalid account ID", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := NewMockService(ctrl)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
@@ -582,7 +736,7 @@ func TestHandler_GetTransactionHistoryHandler(t *testing.T) {
 	t.Run("fails when unauthorized", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := NewMockService(ctrl)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 
 		accountID := uuid.New()
 
@@ -601,7 +755,7 @@ func TestHandler_GetTransactionHistoryHandler(t *testing.T) {
 	t.Run("fails when customer tries to access different account", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := NewMockService(ctrl)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 
 		userAccountID := uuid.New()
 		differentAccountID := uuid.New()
@@ -623,10 +777,33 @@ func TestHandler_GetTransactionHistoryHandler(t *testing.T) {
 		assert.Contains(t, response.Error.Message, "you are not authorized to view this account's transactions")
 	})
 
+	t.Run("fails when admin without transactions:read accesses different account", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := NewMockService(ctrl)
+		handler := NewHandler(mockService, withoutApprovals(t))
+
+		profile := auth.Profile{
+			AccountID:   uuid.New(),
+			UserID:      uuid.New(),
+			UserType:    "ADMIN",
+			Permissions: []auth.Permission{auth.PermAccountsRead},
+		}
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = []gin.Param{{Key: "id", Value: uuid.NewString()}}
+		c.Request = &http.Request{Header: make(http.Header)}
+		injectProfile(c, profile)
+
+		response := handler.GetTransactionHistoryHandler(c)
+
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+	})
+
 	t.Run("successfully returns empty transaction history", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := NewMockService(ctrl)
-		handler := NewHandler(mockService)
+		handler := NewHandler(mockService, withoutApprovals(t))
 
 		accountID := uuid.New()
 		profile := auth.Profile{
@@ -658,3 +835,10 @@ func TestHandler_GetTransactionHistoryHandler(t *testing.T) {
 func injectProfile(ctx *gin.Context, profile auth.Profile) {
 	ctx.Request = ctx.Request.WithContext(context.WithValue(ctx.Request.Context(), auth.ProfileKey, profile))
 }
+
+// withoutApprovals lets every operation through without a second admin.
+func withoutApprovals(t *testing.T) approval.Service {
+	approvals := approval.NewMockService(gomock.NewController(t))
+	approvals.EXPECT().Requires(gomock.Any(), gomock.Any()).Return(false).AnyTimes()
+	return approvals
+}
@@ -0,0 +1,46 @@
+package transaction
+
+import (
+	"context"
+	"encoding/json"
+	"payter-bank/features/approval"
+)
+
+// CreditExecutor credits the account of an approved credit request. The credit is recorded against
+// the admin who requested it, the approval request records who approved it.
+func CreditExecutor(service Service) approval.Executor {
+	return func(ctx context.Context, request approval.Request) (any, error) {
+		var params AccountTransactionParams
+		if err := json.Unmarshal(request.Payload, &params); err != nil {
+			return nil, err
+		}
+		params.UserID = request.RequestedBy
+		return service.CreditAccount(ctx, params)
+	}
+}
+
+// DebitExecutor debits the account of an approved debit request, recorded against the admin who
+// requested it.
+func DebitExecutor(service Service) approval.Executor {
+	return func(ctx context.Context, request approval.Request) (any, error) {
+		var params AccountTransactionParams
+		if err := json.Unmarshal(request.Payload, &params); err != nil {
+			return nil, err
+		}
+		params.UserID = request.RequestedBy
+		return service.DebitAccount(ctx, params)
+	}
+}
+
+// ReversalExecutor reverses the transaction of an approved reversal request, recorded against the
+// admin who requested it.
+func ReversalExecutor(service Service) approval.Executor {
+	return func(ctx context.Context, request approval.Request) (any, error) {
+		var params ReverseTransactionParams
+		if err := json.Unmarshal(request.Payload, &params); err != nil {
+			return nil, err
+		}
+		params.UserID = request.RequestedBy
+		return service.ReverseTransaction(ctx, params)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"go.uber.org/zap"
 	"net/http"
 	"payter-bank/features/auditlog"
+	"payter-bank/internal/database"
 	"payter-bank/internal/database/models"
 	platformerrors "payter-bank/internal/errors"
 	"payter-bank/internal/logger"
@@ -21,10 +22,17 @@ type Service interface {
 	CreditAccount(ctx context.Context, req AccountTransactionParams) (*Response, error)
 	DebitAccount(ctx context.Context, req AccountTransactionParams) (*Response, error)
 	Transfer(ctx context.Context, req AccountTransactionParams) (*Response, error)
+	ReverseTransaction(ctx context.Context, req ReverseTransactionParams) (*Response, error)
 	GetTransactionHistory(ctx context.Context, accountID uuid.UUID) ([]Transaction, error)
 	GetAccountBalance(ctx context.Context, accountID uuid.UUID) (Balance, error)
 }
 
+const (
+	maxReferenceAttempts      = 3
+	referenceNumberConstraint = "transactions_reference_number_key"
+	reversalConstraint        = "transaction_reversals_pkey"
+)
+
 type transactionService struct {
 	db       models.Querier
 	auditLog auditlog.Service
@@ -64,6 +72,10 @@ func (t *transactionService) CreditAccount(ctx context.Context, req AccountTrans
 		return nil, platformerrors.ErrInternal
 	}
 
+	if err := checkCanPayOut(fromAccount.Status); err != nil {
+		return nil, err
+	}
+
 	balance, err := t.db.GetAccountBalance(ctx, fromAccount.ID)
 	if err != nil {
 		logger.Error(ctx, "failed to get account balance", zap.Error(err))
@@ -78,11 +90,10 @@ func (t *transactionService) CreditAccount(ctx context.Context, req AccountTrans
 		return nil, platformerrors.MakeApiError(http.StatusPreconditionFailed, fmt.Sprintf("you cannot credit %s account with %s account", fromAccount.Currency, toAccount.Currency))
 	}
 
-	transaction, err := t.db.SaveTransaction(ctx, models.SaveTransactionParams{
-		FromAccountID:   fromAccount.ID,
-		ToAccountID:     toAccount.ID,
-		Amount:          req.AmountUnit(),
-		ReferenceNumber: generator.DefaultNumberGenerator.Generate(),
+	transaction, err := t.saveTransaction(ctx, models.SaveTransactionParams{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        req.AmountUnit(),
 		Description: sql.NullString{
 			String: req.Narration,
 			Valid:  req.Narration != "",
@@ -110,8 +121,19 @@ func (t *transactionService) CreditAccount(ctx context.Context, req AccountTrans
 }
 
 func (t *transactionService) DebitAccount(ctx context.Context, req AccountTransactionParams) (*Response, error) {
+	return t.debit(ctx, req, false)
+}
+
+func (t *transactionService) Transfer(ctx context.Context, req AccountTransactionParams) (*Response, error) {
+	return t.debit(ctx, req, true)
+}
+
+// debit pays out of req.FromAccountID. customerInitiated is set for payments the customer made
+// themselves, only those count as activity of the account. Admin and approved debits do not.
+func (t *transactionService) debit(ctx context.Context, req AccountTransactionParams, customerInitiated bool) (*Response, error) {
 	ctx = logger.With(ctx,
 		zap.String(logger.FunctionName, "DebitAccount"),
+		zap.Bool("customer_initiated", customerInitiated),
 		zap.Any(logger.RequestFields, req))
 
 	if req.FromAccountID == req.ToAccountID {
@@ -136,6 +158,10 @@ func (t *transactionService) DebitAccount(ctx context.Context, req AccountTransa
 		return nil, platformerrors.ErrInternal
 	}
 
+	if err := checkCanPayOut(fromAccount.Status); err != nil {
+		return nil, err
+	}
+
 	balance, err := t.db.GetAccountBalance(ctx, fromAccount.ID)
 	if err != nil {
 		logger.Error(ctx, "failed to get account balance", zap.Error(err))
@@ -150,11 +176,10 @@ func (t *transactionService) DebitAccount(ctx context.Context, req AccountTransa
 		return nil, platformerrors.MakeApiError(http.StatusPreconditionFailed, fmt.Sprintf("you cannot debit %s account with %s account", fromAccount.Currency, toAccount.Currency))
 	}
 
-	transaction, err := t.db.SaveTransaction(ctx, models.SaveTransactionParams{
-		FromAccountID:   fromAccount.ID,
-		ToAccountID:     toAccount.ID,
-		Amount:          req.AmountUnit(),
-		ReferenceNumber: generator.DefaultNumberGenerator.Generate(),
+	transaction, err := t.saveTransaction(ctx, models.SaveTransactionParams{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        req.AmountUnit(),
 		Description: sql.NullString{
 			String: req.Narration,
 			Valid:  req.Narration != "",
@@ -169,6 +194,9 @@ func (t *transactionService) DebitAccount(ctx context.Context, req AccountTransa
 
 	t.updateBalance(ctx, fromAccount.ID)
 	t.updateBalance(ctx, toAccount.ID)
+	if customerInitiated {
+		t.touchActivity(ctx, fromAccount.ID)
+	}
 
 	auditEvent := auditlog.NewEvent(auditlog.ActionAccountDebit, req.UserID, fromAccount.ID, transaction)
 	err = t.auditLog.Submit(ctx, auditEvent)
@@ -181,8 +209,115 @@ func (t *transactionService) DebitAccount(ctx context.Context, req AccountTransa
 	}, nil
 }
 
-func (t *transactionService) Transfer(ctx context.Context, req AccountTransactionParams) (*Response, error) {
-	return t.DebitAccount(ctx, req)
+// ReverseTransaction posts the opposite of a transaction, moving the amount back from the account it
+// was paid into. A transaction is reversed at most once and a reversal cannot be reversed.
+func (t *transactionService) ReverseTransaction(ctx context.Context, req ReverseTransactionParams) (*Response, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "ReverseTransaction"),
+		zap.Any(logger.RequestFields, req))
+
+	original, err := t.db.GetTransactionByID(ctx, req.TransactionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, platformerrors.MakeApiError(http.StatusNotFound, "transaction not found")
+		}
+		logger.Error(ctx, "failed to get transaction by ID", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	existing, err := t.db.GetTransactionReversal(ctx, original.ID)
+	switch {
+	case err == nil && existing.ReversalID.UUID == original.ID:
+		return nil, platformerrors.MakeApiError(http.StatusConflict, "a reversal cannot be reversed")
+	case err == nil:
+		return nil, platformerrors.MakeApiError(http.StatusConflict, "transaction has already been reversed")
+	case !errors.Is(err, sql.ErrNoRows):
+		logger.Error(ctx, "failed to get transaction reversal", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	// the money goes back from the account that received it
+	payer, err := t.db.GetAccountByID(ctx, original.ToAccountID)
+	if err != nil {
+		logger.Error(ctx, "failed to get account by ID", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	if err := checkCanPayOut(payer.Status); err != nil {
+		return nil, err
+	}
+
+	balance, err := t.db.GetAccountBalance(ctx, payer.ID)
+	if err != nil {
+		logger.Error(ctx, "failed to get account balance", zap.Error(err))
+		return nil, err
+	}
+
+	if payer.AccountType != models.AccountTypeEXTERNAL && int64(balance.Balance) < original.Amount {
+		return nil, platformerrors.MakeApiError(http.StatusPreconditionFailed, "insufficient funds to reverse the transaction")
+	}
+
+	// the reversal is claimed before it is posted, of two concurrent reversals only one gets the claim
+	_, err = t.db.SaveTransactionReversal(ctx, models.SaveTransactionReversalParams{
+		TransactionID: original.ID,
+		Reason:        req.Reason,
+		ReversedBy:    req.UserID,
+	})
+	if err != nil {
+		if database.IsUniqueViolation(err, reversalConstraint) {
+			return nil, platformerrors.MakeApiError(http.StatusConflict, "transaction has already been reversed")
+		}
+		logger.Error(ctx, "failed to save transaction reversal", zap.Error(err))
+		return nil, platformerrors.ErrInternal
+	}
+
+	reversal, err := t.saveTransaction(ctx, models.SaveTransactionParams{
+		FromAccountID: original.ToAccountID,
+		ToAccountID:   original.FromAccountID,
+		Amount:        original.Amount,
+		Description: sql.NullString{
+			String: fmt.Sprintf("Reversal of %s: %s", original.ReferenceNumber, req.Reason),
+			Valid:  true,
+		},
+		Status:   "COMPLETED",
+		Currency: original.Currency,
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to save transaction", zap.Error(err))
+		if err := t.db.DeleteTransactionReversal(ctx, original.ID); err != nil {
+			logger.Error(ctx, "failed to release transaction reversal", zap.Error(err))
+		}
+		return nil, platformerrors.ErrInternal
+	}
+
+	err = t.db.SetTransactionReversalID(ctx, models.SetTransactionReversalIDParams{
+		TransactionID: original.ID,
+		ReversalID:    uuid.NullUUID{UUID: reversal.ID, Valid: true},
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to link transaction reversal",
+			zap.String("reversal_id", reversal.ID.String()), zap.Error(err))
+	}
+
+	t.updateBalance(ctx, original.ToAccountID)
+	t.updateBalance(ctx, original.FromAccountID)
+
+	auditEvent := auditlog.NewEvent(auditlog.ActionTransactionReversal, req.UserID, original.FromAccountID,
+		auditlog.TransactionReversalMetadata{
+			TransactionID: original.ID,
+			ReversalID:    reversal.ID,
+			Amount:        original.Amount,
+			Currency:      original.Currency,
+			Reason:        req.Reason,
+		})
+	err = t.auditLog.Submit(ctx, auditEvent)
+	if err != nil {
+		logger.Error(ctx, "failed to submit audit event", zap.Error(err))
+	}
+
+	return &Response{
+		TransactionID: reversal.ID,
+	}, nil
 }
 
 func (t *transactionService) GetTransactionHistory(ctx context.Context, accountID uuid.UUID) ([]Transaction, error) {
@@ -215,9 +350,47 @@ func (t *transactionService) GetAccountBalance(ctx context.Context, accountID uu
 	return BalanceFromQueryResult(bal), nil
 }
 
+// saveTransaction assigns a reference to the transaction and saves it. References are unique per
+// process, a conflict can only come from another instance so the save is retried with a new reference.
+func (t *transactionService) saveTransaction(ctx context.Context, param models.SaveTransactionParams) (models.Transaction, error) {
+	for attempt := 1; ; attempt++ {
+		param.ReferenceNumber = generator.DefaultReferenceGenerator.Generate()
+		transaction, err := t.db.SaveTransaction(ctx, param)
+		if err == nil || !database.IsUniqueViolation(err, referenceNumberConstraint) || attempt == maxReferenceAttempts {
+			return transaction, err
+		}
+		logger.Warn(ctx, "transaction reference already taken, retrying",
+			zap.String("reference_number", param.ReferenceNumber))
+	}
+}
+
 func (t *transactionService) updateBalance(ctx context.Context, accountID uuid.UUID) {
 	err := t.db.UpdateBalance(ctx, accountID)
 	if err != nil {
 		logger.Error(ctx, "failed to update account balance", zap.Error(err))
 	}
 }
+
+// touchActivity records customer activity on the account, this is what keeps it from going dormant.
+// Only payments the customer makes out of the account count, interest postings, credits and
+// back-office debits do not.
+func (t *transactionService) touchActivity(ctx context.Context, accountID uuid.UUID) {
+	err := t.db.TouchAccountActivity(ctx, accountID)
+	if err != nil {
+		logger.Error(ctx, "failed to record account activity", zap.Error(err))
+	}
+}
+
+// checkCanPayOut rejects payments out of an account that is dormant, suspended or closed, whoever
+// makes them. Money can still be paid into these accounts.
+func checkCanPayOut(status models.Status) error {
+	switch status {
+	case models.StatusDORMANT:
+		return platformerrors.MakeApiError(http.StatusPreconditionFailed, "account is dormant, payments are blocked until it is reactivated")
+	case models.StatusSUSPENDED:
+		return platformerrors.MakeApiError(http.StatusPreconditionFailed, "account is suspended, payments are blocked")
+	case models.StatusCLOSED:
+		return platformerrors.MakeApiError(http.StatusPreconditionFailed, "account is closed, payments are blocked")
+	}
+	return nil
+}
@@ -101,6 +101,21 @@ func (mr *MockServiceMockRecorder) GetTransactionHistory(ctx, accountID any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionHistory", reflect.TypeOf((*MockService)(nil).GetTransactionHistory), ctx, accountID)
 }
 
+// ReverseTransaction mocks base method.
+func (m *MockService) ReverseTransaction(ctx context.Context, req ReverseTransactionParams) (*Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReverseTransaction", ctx, req)
+	ret0, _ := ret[0].(*Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReverseTransaction indicates an expected call of ReverseTransaction.
+func (mr *MockServiceMockRecorder) ReverseTransaction(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReverseTransaction", reflect.TypeOf((*MockService)(nil).ReverseTransaction), ctx, req)
+}
+
 // Transfer mocks base method.
 func (m *MockService) Transfer(ctx context.Context, req AccountTransactionParams) (*Response, error) {
 	m.ctrl.T.Helper()
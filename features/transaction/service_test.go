@@ -3,9 +3,12 @@ package transaction
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"net/http"
 	"payter-bank/features/auditlog"
 	"payter-bank/internal/database/models"
 	databasemocks "payter-bank/internal/database/models/mocks"
@@ -96,6 +99,8 @@ func TestService_CreditAccount(t *testing.T) {
 		m.db.EXPECT().
 			UpdateBalance(gomock.Any(), req.ToAccountID).
 			Return(nil)
+		// a credit is not customer activity, it keeps neither account from going dormant
+		m.db.EXPECT().TouchAccountActivity(gomock.Any(), gomock.Any()).Times(0)
 
 		m.auditLog.EXPECT().
 			Submit(gomock.Any(), expectedAuditLogEvent).
@@ -106,6 +111,44 @@ func TestService_CreditAccount(t *testing.T) {
 		assert.Equal(t, expectedTx.ID, response.TransactionID)
 	})
 
+	t.Run("retries with a new reference when the reference is taken", func(t *testing.T) {
+		m := newTransactionServiceMocker(t)
+		fromAccountID, toAccountID := uuid.New(), uuid.New()
+		req := AccountTransactionParams{
+			FromAccountID: fromAccountID,
+			ToAccountID:   toAccountID,
+			Amount:        10,
+			UserID:        uuid.New(),
+		}
+		conflict := &pq.Error{Code: "23505", Constraint: "transactions_reference_number_key"}
+		expectedTx := models.Transaction{ID: uuid.New()}
+
+		m.db.EXPECT().GetAccountByID(gomock.Any(), fromAccountID).
+			Return(models.GetAccountByIDRow{ID: fromAccountID, Currency: models.CurrencyGBP, AccountType: models.AccountTypeEXTERNAL}, nil)
+		m.db.EXPECT().GetAccountByID(gomock.Any(), toAccountID).
+			Return(models.GetAccountByIDRow{ID: toAccountID, Currency: models.CurrencyGBP}, nil)
+		m.db.EXPECT().GetAccountBalance(gomock.Any(), fromAccountID).
+			Return(models.GetAccountBalanceRow{}, nil)
+		gomock.InOrder(
+			m.numGen.EXPECT().Generate().Return("TX01"),
+			m.numGen.EXPECT().Generate().Return("TX02"),
+		)
+		gomock.InOrder(
+			m.db.EXPECT().SaveTransaction(gomock.Any(), gomock.Cond(func(p models.SaveTransactionParams) bool {
+				return p.ReferenceNumber == "TX01"
+			})).Return(models.Transaction{}, conflict),
+			m.db.EXPECT().SaveTransaction(gomock.Any(), gomock.Cond(func(p models.SaveTransactionParams) bool {
+				return p.ReferenceNumber == "TX02"
+			})).Return(expectedTx, nil),
+		)
+		m.db.EXPECT().UpdateBalance(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		m.auditLog.EXPECT().Submit(gomock.Any(), gomock.Any()).Return(nil)
+
+		response, err := m.service.CreditAccount(context.TODO(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedTx.ID, response.TransactionID)
+	})
+
 	t.Run("fails when crediting same account", func(t *testing.T) {
 		m := newTransactionServiceMocker(t)
 		accountID := uuid.New()
@@ -120,6 +163,50 @@ func TestService_CreditAccount(t *testing.T) {
 		assert.Contains(t, err.Error(), "cannot credit the same account")
 	})
 
+	t.Run("fails when source account cannot pay out", func(t *testing.T) {
+		testCases := []struct {
+			status models.Status
+			err    string
+		}{
+			{models.StatusDORMANT, "account is dormant, payments are blocked until it is reactivated"},
+			{models.StatusSUSPENDED, "account is suspended, payments are blocked"},
+			{models.StatusCLOSED, "account is closed, payments are blocked"},
+		}
+
+		for _, tc := range testCases {
+			t.Run(string(tc.status), func(t *testing.T) {
+				m := newTransactionServiceMocker(t)
+				req := AccountTransactionParams{
+					FromAccountID: uuid.New(),
+					ToAccountID:   uuid.New(),
+					Amount:        10.00,
+				}
+
+				// an admin credit that names a customer account as the source takes money out of it
+				m.db.EXPECT().
+					GetAccountByID(gomock.Any(), req.FromAccountID).
+					Return(models.GetAccountByIDRow{
+						ID:          req.FromAccountID,
+						Currency:    models.CurrencyGBP,
+						AccountType: models.AccountTypeCURRENT,
+						Status:      tc.status,
+					}, nil)
+
+				m.db.EXPECT().
+					GetAccountByID(gomock.Any(), req.ToAccountID).
+					Return(models.GetAccountByIDRow{
+						ID:          req.ToAccountID,
+						Currency:    models.CurrencyGBP,
+						AccountType: models.AccountTypeCURRENT,
+					}, nil)
+
+				_, err := m.service.CreditAccount(context.TODO(), req)
+
+				assert.Equal(t, platformerrors.MakeApiError(http.StatusPreconditionFailed, tc.err), err)
+			})
+		}
+	})
+
 	t.Run("fails with insufficient funds", func(t *testing.T) {
 		mocker := newTransactionServiceMocker(t)
 		req := AccountTransactionParams{
@@ -247,6 +334,9 @@ func TestService_DebitAccount(t *testing.T) {
 			UpdateBalance(gomock.Any(), req.ToAccountID).
 			Return(nil)
 
+		// a back-office debit is not customer activity, it must not keep the account from going dormant
+		m.db.EXPECT().TouchAccountActivity(gomock.Any(), gomock.Any()).Times(0)
+
 		m.auditLog.EXPECT().
 			Submit(gomock.Any(), expectedAuditLogEvent).
 			Return(nil)
@@ -256,6 +346,35 @@ func TestService_DebitAccount(t *testing.T) {
 		assert.Equal(t, expectedTx.ID, response.TransactionID)
 	})
 
+	t.Run("customer transfer counts as activity of the source account", func(t *testing.T) {
+		m := newTransactionServiceMocker(t)
+		req := AccountTransactionParams{
+			FromAccountID: uuid.New(),
+			ToAccountID:   uuid.New(),
+			Amount:        10.00,
+			UserID:        uuid.New(),
+		}
+		transaction := models.Transaction{ID: uuid.New()}
+
+		m.numGen.EXPECT().Generate().Return("1234567890")
+		m.db.EXPECT().GetAccountByID(gomock.Any(), req.FromAccountID).
+			Return(models.GetAccountByIDRow{ID: req.FromAccountID, Currency: models.CurrencyGBP, AccountType: models.AccountTypeCURRENT}, nil)
+		m.db.EXPECT().GetAccountByID(gomock.Any(), req.ToAccountID).
+			Return(models.GetAccountByIDRow{ID: req.ToAccountID, Currency: models.CurrencyGBP, AccountType: models.AccountTypeCURRENT}, nil)
+		m.db.EXPECT().GetAccountBalance(gomock.Any(), req.FromAccountID).
+			Return(models.GetAccountBalanceRow{AccountID: req.FromAccountID, Balance: 1000}, nil)
+		m.db.EXPECT().SaveTransaction(gomock.Any(), gomock.Any()).Return(transaction, nil)
+		m.db.EXPECT().UpdateBalance(gomock.Any(), req.FromAccountID).Return(nil)
+		m.db.EXPECT().UpdateBalance(gomock.Any(), req.ToAccountID).Return(nil)
+		// the payment is activity of the account it leaves, not of the one it goes to
+		m.db.EXPECT().TouchAccountActivity(gomock.Any(), req.FromAccountID).Return(nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), gomock.Any()).Return(nil)
+
+		response, err := m.service.Transfer(context.TODO(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, transaction.ID, response.TransactionID)
+	})
+
 	t.Run("fails when debiting same account", func(t *testing.T) {
 		m := newTransactionServiceMocker(t)
 		accountID := uuid.New()
@@ -270,6 +389,55 @@ func TestService_DebitAccount(t *testing.T) {
 		assert.Contains(t, err.Error(), "cannot debit the same account")
 	})
 
+	t.Run("fails when source account cannot pay out", func(t *testing.T) {
+		testCases := []struct {
+			status models.Status
+			err    string
+		}{
+			{models.StatusDORMANT, "account is dormant, payments are blocked until it is reactivated"},
+			{models.StatusSUSPENDED, "account is suspended, payments are blocked"},
+			{models.StatusCLOSED, "account is closed, payments are blocked"},
+		}
+
+		for _, tc := range testCases {
+			for _, transfer := range []bool{false, true} {
+				t.Run(fmt.Sprintf("%s transfer=%t", tc.status, transfer), func(t *testing.T) {
+					m := newTransactionServiceMocker(t)
+					req := AccountTransactionParams{
+						FromAccountID: uuid.New(),
+						ToAccountID:   uuid.New(),
+						Amount:        10.00,
+					}
+
+					m.db.EXPECT().
+						GetAccountByID(gomock.Any(), req.FromAccountID).
+						Return(models.GetAccountByIDRow{
+							ID:          req.FromAccountID,
+							Currency:    models.CurrencyGBP,
+							AccountType: models.AccountTypeCURRENT,
+							Status:      tc.status,
+						}, nil)
+
+					m.db.EXPECT().
+						GetAccountByID(gomock.Any(), req.ToAccountID).
+						Return(models.GetAccountByIDRow{
+							ID:          req.ToAccountID,
+							Currency:    models.CurrencyGBP,
+							AccountType: models.AccountTypeCURRENT,
+						}, nil)
+
+					pay := m.service.DebitAccount
+					if transfer {
+						pay = m.service.Transfer
+					}
+					_, err := pay(context.TODO(), req)
+
+					assert.Equal(t, platformerrors.MakeApiError(http.StatusPreconditionFailed, tc.err), err)
+				})
+			}
+		}
+	})
+
 	t.Run("fails with insufficient funds", func(t *testing.T) {
 		m := newTransactionServiceMocker(t)
 		req := AccountTransactionParams{
@@ -397,6 +565,194 @@ func TestService_DebitAccount(t *testing.T) {
 	})
 }
 
+func TestService_ReverseTransaction(t *testing.T) {
+	original := models.Transaction{
+		ID:              uuid.New(),
+		FromAccountID:   uuid.New(),
+		ToAccountID:     uuid.New(),
+		Amount:          10050,
+		ReferenceNumber: "ORIG123",
+		Status:          "COMPLETED",
+		Currency:        "GBP",
+	}
+	req := ReverseTransactionParams{
+		TransactionID: original.ID,
+		Reason:        "paid to the wrong account",
+		UserID:        uuid.New(),
+	}
+	payer := models.GetAccountByIDRow{
+		ID:          original.ToAccountID,
+		Currency:    models.CurrencyGBP,
+		AccountType: models.AccountTypeCURRENT,
+	}
+
+	t.Run("posts the opposite transaction", func(t *testing.T) {
+		m := newTransactionServiceMocker(t)
+		reversal := models.Transaction{ID: uuid.New()}
+
+		m.numGen.EXPECT().Generate().Return("1234567890")
+		m.db.EXPECT().GetTransactionByID(gomock.Any(), original.ID).Return(original, nil)
+		m.db.EXPECT().GetTransactionReversal(gomock.Any(), original.ID).Return(models.TransactionReversal{}, sql.ErrNoRows)
+		m.db.EXPECT().GetAccountByID(gomock.Any(), original.ToAccountID).Return(payer, nil)
+		m.db.EXPECT().GetAccountBalance(gomock.Any(), original.ToAccountID).
+			Return(models.GetAccountBalanceRow{AccountID: original.ToAccountID, Balance: 10050}, nil)
+		m.db.EXPECT().SaveTransactionReversal(gomock.Any(), models.SaveTransactionReversalParams{
+			TransactionID: original.ID,
+			Reason:        req.Reason,
+			ReversedBy:    req.UserID,
+		}).Return(models.TransactionReversal{TransactionID: original.ID}, nil)
+		m.db.EXPECT().SaveTransaction(gomock.Any(), models.SaveTransactionParams{
+			FromAccountID:   original.ToAccountID,
+			ToAccountID:     original.FromAccountID,
+			Amount:          10050,
+			ReferenceNumber: "1234567890",
+			Description: sql.NullString{
+				String: "Reversal of ORIG123: paid to the wrong account",
+				Valid:  true,
+			},
+			Status:   "COMPLETED",
+			Currency: "GBP",
+		}).Return(reversal, nil)
+		m.db.EXPECT().SetTransactionReversalID(gomock.Any(), models.SetTransactionReversalIDParams{
+			TransactionID: original.ID,
+			ReversalID:    uuid.NullUUID{UUID: reversal.ID, Valid: true},
+		}).Return(nil)
+		m.db.EXPECT().UpdateBalance(gomock.Any(), original.ToAccountID).Return(nil)
+		m.db.EXPECT().UpdateBalance(gomock.Any(), original.FromAccountID).Return(nil)
+		// a reversal is not customer activity
+		m.db.EXPECT().TouchAccountActivity(gomock.Any(), gomock.Any()).Times(0)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(auditlog.ActionTransactionReversal, req.UserID, original.FromAccountID,
+			auditlog.TransactionReversalMetadata{
+				TransactionID: original.ID,
+				ReversalID:    reversal.ID,
+				Amount:        10050,
+				Currency:      "GBP",
+				Reason:        req.Reason,
+			})).Return(nil)
+
+		resp, err := m.service.ReverseTransaction(context.TODO(), req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &Response{TransactionID: reversal.ID}, resp)
+	})
+
+	t.Run("fails when transaction does not exist", func(t *testing.T) {
+		m := newTransactionServiceMocker(t)
+		m.db.EXPECT().GetTransactionByID(gomock.Any(), original.ID).Return(models.Transaction{}, sql.ErrNoRows)
+
+		resp, err := m.service.ReverseTransaction(context.TODO(), req)
+
+		assert.Nil(t, resp)
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusNotFound, "transaction not found"), err)
+	})
+
+	t.Run("fails when transaction was already reversed", func(t *testing.T) {
+		m := newTransactionServiceMocker(t)
+		m.db.EXPECT().GetTransactionByID(gomock.Any(), original.ID).Return(original, nil)
+		m.db.EXPECT().GetTransactionReversal(gomock.Any(), original.ID).Return(models.TransactionReversal{
+			TransactionID: original.ID,
+			ReversalID:    uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		}, nil)
+
+		resp, err := m.service.ReverseTransaction(context.TODO(), req)
+
+		assert.Nil(t, resp)
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusConflict, "transaction has already been reversed"), err)
+	})
+
+	t.Run("fails when transaction is a reversal", func(t *testing.T) {
+		m := newTransactionServiceMocker(t)
+		m.db.EXPECT().GetTransactionByID(gomock.Any(), original.ID).Return(original, nil)
+		m.db.EXPECT().GetTransactionReversal(gomock.Any(), original.ID).Return(models.TransactionReversal{
+			TransactionID: uuid.New(),
+			ReversalID:    uuid.NullUUID{UUID: original.ID, Valid: true},
+		}, nil)
+
+		resp, err := m.service.ReverseTransaction(context.TODO(), req)
+
+		assert.Nil(t, resp)
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusConflict, "a reversal cannot be reversed"), err)
+	})
+
+	t.Run("fails when receiving account cannot pay out", func(t *testing.T) {
+		testCases := []struct {
+			status models.Status
+			err    string
+		}{
+			{models.StatusDORMANT, "account is dormant, payments are blocked until it is reactivated"},
+			{models.StatusSUSPENDED, "account is suspended, payments are blocked"},
+			{models.StatusCLOSED, "account is closed, payments are blocked"},
+		}
+
+		for _, tc := range testCases {
+			t.Run(string(tc.status), func(t *testing.T) {
+				m := newTransactionServiceMocker(t)
+				blocked := payer
+				blocked.Status = tc.status
+
+				m.db.EXPECT().GetTransactionByID(gomock.Any(), original.ID).Return(original, nil)
+				m.db.EXPECT().GetTransactionReversal(gomock.Any(), original.ID).Return(models.TransactionReversal{}, sql.ErrNoRows)
+				m.db.EXPECT().GetAccountByID(gomock.Any(), original.ToAccountID).Return(blocked, nil)
+				m.db.EXPECT().SaveTransactionReversal(gomock.Any(), gomock.Any()).Times(0)
+
+				resp, err := m.service.ReverseTransaction(context.TODO(), req)
+
+				assert.Nil(t, resp)
+				assert.Equal(t, platformerrors.MakeApiError(http.StatusPreconditionFailed, tc.err), err)
+			})
+		}
+	})
+
+	t.Run("fails when receiving account cannot pay it back", func(t *testing.T) {
+		m := newTransactionServiceMocker(t)
+		m.db.EXPECT().GetTransactionByID(gomock.Any(), original.ID).Return(original, nil)
+		m.db.EXPECT().GetTransactionReversal(gomock.Any(), original.ID).Return(models.TransactionReversal{}, sql.ErrNoRows)
+		m.db.EXPECT().GetAccountByID(gomock.Any(), original.ToAccountID).Return(payer, nil)
+		m.db.EXPECT().GetAccountBalance(gomock.Any(), original.ToAccountID).
+			Return(models.GetAccountBalanceRow{AccountID: original.ToAccountID, Balance: 10049}, nil)
+
+		resp, err := m.service.ReverseTransaction(context.TODO(), req)
+
+		assert.Nil(t, resp)
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusPreconditionFailed, "insufficient funds to reverse the transaction"), err)
+	})
+
+	t.Run("fails when a concurrent reversal claimed the transaction", func(t *testing.T) {
+		m := newTransactionServiceMocker(t)
+		m.db.EXPECT().GetTransactionByID(gomock.Any(), original.ID).Return(original, nil)
+		m.db.EXPECT().GetTransactionReversal(gomock.Any(), original.ID).Return(models.TransactionReversal{}, sql.ErrNoRows)
+		m.db.EXPECT().GetAccountByID(gomock.Any(), original.ToAccountID).Return(payer, nil)
+		m.db.EXPECT().GetAccountBalance(gomock.Any(), original.ToAccountID).
+			Return(models.GetAccountBalanceRow{AccountID: original.ToAccountID, Balance: 10050}, nil)
+		m.db.EXPECT().SaveTransactionReversal(gomock.Any(), gomock.Any()).
+			Return(models.TransactionReversal{}, &pq.Error{Code: "23505", Constraint: "transaction_reversals_pkey"})
+		m.db.EXPECT().SaveTransaction(gomock.Any(), gomock.Any()).Times(0)
+
+		resp, err := m.service.ReverseTransaction(context.TODO(), req)
+
+		assert.Nil(t, resp)
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusConflict, "transaction has already been reversed"), err)
+	})
+
+	t.Run("releases the claim when the reversal cannot be posted", func(t *testing.T) {
+		m := newTransactionServiceMocker(t)
+		m.numGen.EXPECT().Generate().Return("1234567890")
+		m.db.EXPECT().GetTransactionByID(gomock.Any(), original.ID).Return(original, nil)
+		m.db.EXPECT().GetTransactionReversal(gomock.Any(), original.ID).Return(models.TransactionReversal{}, sql.ErrNoRows)
+		m.db.EXPECT().GetAccountByID(gomock.Any(), original.ToAccountID).Return(payer, nil)
+		m.db.EXPECT().GetAccountBalance(gomock.Any(), original.ToAccountID).
+			Return(models.GetAccountBalanceRow{AccountID: original.ToAccountID, Balance: 10050}, nil)
+		m.db.EXPECT().SaveTransactionReversal(gomock.Any(), gomock.Any()).Return(models.TransactionReversal{}, nil)
+		m.db.EXPECT().SaveTransaction(gomock.Any(), gomock.Any()).Return(models.Transaction{}, fmt.Errorf("connection reset"))
+		m.db.EXPECT().DeleteTransactionReversal(gomock.Any(), original.ID).Return(nil)
+
+		resp, err := m.service.ReverseTransaction(context.TODO(), req)
+
+		assert.Nil(t, resp)
+		assert.Equal(t, platformerrors.ErrInternal, err)
+	})
+}
+
 func TestService_GetTransactionHistory(t *testing.T) {
 	t.Run("successfully gets transaction history", func(t *testing.T) {
 		m := newTransactionServiceMocker(t)
@@ -586,7 +942,7 @@ func newTransactionServiceMocker(t *testing.T) *transactionServiceMocker {
 	auditLog := auditlog.NewMockService(ctrl)
 	mockNumberGen := generatormocks.NewMockNumberGenerator(ctrl)
 
-	generator.DefaultNumberGenerator = mockNumberGen
+	generator.DefaultReferenceGenerator = mockNumberGen
 
 	service := NewService(db, auditLog)
 	return &transactionServiceMocker{
@@ -18,6 +18,14 @@ func (p AccountTransactionParams) AmountUnit() int64 {
 	return int64(p.Amount * 100)
 }
 
+// ReverseTransactionParams reverses TransactionID, the reason is kept with the reversal and on the
+// description of the transaction posted for it.
+type ReverseTransactionParams struct {
+	TransactionID uuid.UUID `json:"transaction_id"`
+	Reason        string    `json:"reason" binding:"required"`
+	UserID        uuid.UUID
+}
+
 type Response struct {
 	TransactionID uuid.UUID `json:"transaction_id"`
 }
@@ -0,0 +1,379 @@
+package user
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"payter-bank/internal/api"
+	"payter-bank/internal/auth"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// RegisterHandler godoc
+// @Summary      Register
+// @Description  Sign up as a new CUSTOMER. A verification link is emailed and the user cannot log in until it is opened
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        params  body  RegisterParams  true  "Registration details"
+// @Success      200  {object}  api.SuccessResponse{data=RegisterResponse}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/users [post]
+func (h *Handler) RegisterHandler(ctx *gin.Context) api.Response {
+	var params RegisterParams
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	user, err := h.service.Register(ctx, params)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("registration successful, check your email to verify your account", user)
+}
+
+// ResendVerificationHandler godoc
+// @Summary      Resend verification email
+// @Description  Send a new verification link to an unverified user. The response is the same whether or not the email is registered
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        params  body  ResendVerificationParams  true  "Email address"
+// @Success      200  {object}  api.SuccessResponse
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/users/resend-verification [post]
+func (h *Handler) ResendVerificationHandler(ctx *gin.Context) api.Response {
+	var params ResendVerificationParams
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	if err := h.service.ResendVerification(ctx, params); err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("if the email needs verification, a new link has been sent", nil)
+}
+
+// UpdateProfileHandler godoc
+// @Summary      Update profile
+// @Description  Update the current user's name or email. A new email must be verified before it replaces the current one
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        params  body  UpdateProfileParams  true  "Fields to update"
+// @Success      200  {object}  api.SuccessResponse{data=User}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/me [patch]
+func (h *Handler) UpdateProfileHandler(ctx *gin.Context) api.Response {
+	var params UpdateProfileParams
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+
+	params.UserID = profile.UserID
+	params.ActorID = profile.UserID
+	user, err := h.service.UpdateProfile(ctx, params)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("profile updated successfully", user)
+}
+
+// ChangePasswordHandler godoc
+// @Summary      Change password
+// @Description  Change the current user's password
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        params  body  ChangePasswordParams  true  "Current and new password"
+// @Success      200  {object}  api.SuccessResponse
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/me/password [post]
+func (h *Handler) ChangePasswordHandler(ctx *gin.Context) api.Response {
+	var params ChangePasswordParams
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+
+	params.UserID = profile.UserID
+	if token, err := auth.GetTokenData(ctx); err == nil {
+		params.SessionID = token.SessionID
+	}
+	if err := h.service.ChangePassword(ctx, params); err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("password changed successfully", nil)
+}
+
+// VerifyEmailHandler godoc
+// @Summary      Verify email
+// @Description  Confirm an email address with the token sent to it
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        params  body  VerifyEmailParams  true  "Verification token"
+// @Success      200  {object}  api.SuccessResponse
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      409  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/users/verify-email [post]
+func (h *Handler) VerifyEmailHandler(ctx *gin.Context) api.Response {
+	var params VerifyEmailParams
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	if err := h.service.VerifyEmail(ctx, params); err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("email verified successfully", nil)
+}
+
+// ForgotPasswordHandler godoc
+// @Summary      Forgot password
+// @Description  Email a password reset link to the user. The response is the same whether or not the email is registered
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        params  body  ForgotPasswordParams  true  "Email address"
+// @Success      200  {object}  api.SuccessResponse
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/users/forgot-password [post]
+func (h *Handler) ForgotPasswordHandler(ctx *gin.Context) api.Response {
+	var params ForgotPasswordParams
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	if err := h.service.ForgotPassword(ctx, params); err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("if the email is registered, a password reset link has been sent", nil)
+}
+
+// ResetPasswordHandler godoc
+// @Summary      Reset password
+// @Description  Set a new password with an emailed reset token, or the token returned on login when the password must be changed. The user is logged out everywhere
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        params  body  ResetPasswordParams  true  "Reset token and new password"
+// @Success      200  {object}  api.SuccessResponse
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/users/reset-password [post]
+func (h *Handler) ResetPasswordHandler(ctx *gin.Context) api.Response {
+	var params ResetPasswordParams
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	if err := h.service.ResetPassword(ctx, params); err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("password reset successfully, log in with the new password", nil)
+}
+
+// GetUserHandler godoc
+// @Summary      Get user
+// @Description  Get a user by ID. Only an admin can do this
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  api.SuccessResponse{data=User}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/admin/users/:id [get]
+func (h *Handler) GetUserHandler(ctx *gin.Context) api.Response {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return api.BadRequest("user id is required")
+	}
+
+	user, err := h.service.GetUser(ctx, userID)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("user retrieved successfully", user)
+}
+
+// AdminUpdateUserHandler godoc
+// @Summary      Update user
+// @Description  Update a user's name or email. A new email must be verified by the user. Only an admin can do this
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        params  body  UpdateProfileParams  true  "Fields to update"
+// @Success      200  {object}  api.SuccessResponse{data=User}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/admin/users/:id [patch]
+func (h *Handler) AdminUpdateUserHandler(ctx *gin.Context) api.Response {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return api.BadRequest("user id is required")
+	}
+
+	var params UpdateProfileParams
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+
+	params.UserID = userID
+	params.ActorID = profile.UserID
+	user, err := h.service.UpdateProfile(ctx, params)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("user updated successfully", user)
+}
+
+// DeactivateUserHandler godoc
+// @Summary      Deactivate user
+// @Description  Deactivate a user - they can no longer log in and all their open accounts are suspended. Only an admin can do this
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        params  body  LifecycleParams  false  "Reason"
+// @Success      200  {object}  api.SuccessResponse
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/admin/users/:id/deactivate [patch]
+func (h *Handler) DeactivateUserHandler(ctx *gin.Context) api.Response {
+	params, resp, ok := lifecycleParams(ctx)
+	if !ok {
+		return resp
+	}
+
+	if err := h.service.DeactivateUser(ctx, params); err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("user deactivated successfully", nil)
+}
+
+// ReactivateUserHandler godoc
+// @Summary      Reactivate user
+// @Description  Allow a deactivated user to log in again. Their accounts stay suspended until activated. Only an admin can do this
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        params  body  LifecycleParams  false  "Reason"
+// @Success      200  {object}  api.SuccessResponse
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/admin/users/:id/reactivate [patch]
+func (h *Handler) ReactivateUserHandler(ctx *gin.Context) api.Response {
+	params, resp, ok := lifecycleParams(ctx)
+	if !ok {
+		return resp
+	}
+
+	if err := h.service.ReactivateUser(ctx, params); err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("user reactivated successfully", nil)
+}
+
+// SetTaxStatusHandler godoc
+// @Summary      Set user tax status
+// @Description  Set the tax residency and exemption tax on a user's interest is withheld by. Only an admin can do this
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        params  body  TaxStatusParams  true  "Tax status"
+// @Success      200  {object}  api.SuccessResponse{data=User}
+// @Failure      400  {object}  api.ErrorResponse
+// @Failure      404  {object}  api.ErrorResponse
+// @Failure      500  {object}  api.ErrorResponse
+// @Router       /v1/api/admin/users/:id/tax [put]
+func (h *Handler) SetTaxStatusHandler(ctx *gin.Context) api.Response {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return api.BadRequest("user id is required")
+	}
+
+	var params TaxStatusParams
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		return api.BadRequest(err.Error())
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return api.Unauthorized("unauthorized")
+	}
+
+	params.UserID = userID
+	params.ActorID = profile.UserID
+	user, err := h.service.SetTaxStatus(ctx, params)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	return api.OK("tax status updated successfully", user)
+}
+
+func lifecycleParams(ctx *gin.Context) (LifecycleParams, api.Response, bool) {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return LifecycleParams{}, api.BadRequest("user id is required"), false
+	}
+
+	var params LifecycleParams
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&params); err != nil {
+			return LifecycleParams{}, api.BadRequest(err.Error()), false
+		}
+	}
+
+	profile, err := auth.GetCurrentProfile(ctx)
+	if err != nil {
+		return LifecycleParams{}, api.Unauthorized("unauthorized"), false
+	}
+
+	params.UserID = userID
+	params.ActorID = profile.UserID
+	return params, api.Response{}, true
+}
@@ -0,0 +1,405 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	jwtmiddleware "github.com/auth0/go-jwt-middleware/v2"
+	"github.com/auth0/go-jwt-middleware/v2/validator"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"payter-bank/internal/api"
+	"payter-bank/internal/auth"
+	platformerrors "payter-bank/internal/errors"
+	"payter-bank/internal/pkg/generator"
+	"testing"
+)
+
+func TestHandler_RegisterHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("registers user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := NewMockService(ctrl)
+		handler := NewHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/users", bytes.NewBufferString(
+			`{"first_name":"Jane","last_name":"Doe","email":"jane@example.com","password":"password123"}`))
+
+		userID := uuid.New()
+		mockService.EXPECT().Register(gomock.Any(), RegisterParams{
+			FirstName: "Jane",
+			LastName:  "Doe",
+			Email:     "jane@example.com",
+			Password:  "password123",
+		}).Return(RegisterResponse{UserID: userID}, nil)
+
+		response := handler.RegisterHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    RegisterResponse{UserID: userID},
+			Message: "registration successful, check your email to verify your account",
+		}, response.Data)
+	})
+
+	t.Run("rejects short password", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		handler := NewHandler(NewMockService(ctrl))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/users", bytes.NewBufferString(
+			`{"first_name":"Jane","last_name":"Doe","email":"jane@example.com","password":"short"}`))
+
+		response := handler.RegisterHandler(c)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestHandler_ResendVerificationHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	mockService := NewMockService(ctrl)
+	handler := NewHandler(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/users/resend-verification",
+		bytes.NewBufferString(`{"email":"jane@example.com"}`))
+
+	mockService.EXPECT().ResendVerification(gomock.Any(), ResendVerificationParams{Email: "jane@example.com"}).Return(nil)
+
+	response := handler.ResendVerificationHandler(c)
+	assert.Equal(t, http.StatusOK, response.Code)
+}
+
+func TestHandler_UpdateProfileHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("updates current user's profile", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := NewMockService(ctrl)
+		handler := NewHandler(mockService)
+
+		userID := uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPatch, "/v1/api/me",
+			bytes.NewBufferString(`{"first_name":"Janet"}`))
+		injectProfile(c, auth.Profile{UserID: userID})
+
+		user := User{UserID: userID, FirstName: "Janet"}
+		mockService.EXPECT().UpdateProfile(gomock.Any(), UpdateProfileParams{
+			FirstName: "Janet",
+			UserID:    userID,
+			ActorID:   userID,
+		}).Return(user, nil)
+
+		response := handler.UpdateProfileHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    user,
+			Message: "profile updated successfully",
+		}, response.Data)
+	})
+
+	t.Run("rejects invalid email", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		handler := NewHandler(NewMockService(ctrl))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPatch, "/v1/api/me",
+			bytes.NewBufferString(`{"email":"not-an-email"}`))
+		injectProfile(c, auth.Profile{UserID: uuid.New()})
+
+		response := handler.UpdateProfileHandler(c)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestHandler_ChangePasswordHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("changes password", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := NewMockService(ctrl)
+		handler := NewHandler(mockService)
+
+		userID, sessionID := uuid.New(), uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/me/password",
+			bytes.NewBufferString(`{"current_password":"old-password","new_password":"new-password"}`))
+		injectProfile(c, auth.Profile{UserID: userID})
+		injectClaim(c, generator.TokenData{UserID: userID, SessionID: sessionID})
+
+		mockService.EXPECT().ChangePassword(gomock.Any(), ChangePasswordParams{
+			CurrentPassword: "old-password",
+			NewPassword:     "new-password",
+			UserID:          userID,
+			SessionID:       sessionID,
+		}).Return(nil)
+
+		response := handler.ChangePasswordHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+
+	t.Run("rejects short password", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		handler := NewHandler(NewMockService(ctrl))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/me/password",
+			bytes.NewBufferString(`{"current_password":"old-password","new_password":"short"}`))
+		injectProfile(c, auth.Profile{UserID: uuid.New()})
+
+		response := handler.ChangePasswordHandler(c)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestHandler_VerifyEmailHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("verifies email", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := NewMockService(ctrl)
+		handler := NewHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/users/verify-email",
+			bytes.NewBufferString(`{"token":"abc"}`))
+
+		mockService.EXPECT().VerifyEmail(gomock.Any(), VerifyEmailParams{Token: "abc"}).Return(nil)
+
+		response := handler.VerifyEmailHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+
+	t.Run("returns service error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := NewMockService(ctrl)
+		handler := NewHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/users/verify-email",
+			bytes.NewBufferString(`{"token":"abc"}`))
+
+		mockService.EXPECT().VerifyEmail(gomock.Any(), gomock.Any()).Return(errInvalidToken)
+
+		response := handler.VerifyEmailHandler(c)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestHandler_ForgotPasswordHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	mockService := NewMockService(ctrl)
+	handler := NewHandler(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/users/forgot-password",
+		bytes.NewBufferString(`{"email":"jane@example.com"}`))
+
+	mockService.EXPECT().ForgotPassword(gomock.Any(), ForgotPasswordParams{Email: "jane@example.com"}).Return(nil)
+
+	response := handler.ForgotPasswordHandler(c)
+	assert.Equal(t, http.StatusOK, response.Code)
+}
+
+func TestHandler_ResetPasswordHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("resets password", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := NewMockService(ctrl)
+		handler := NewHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/users/reset-password",
+			bytes.NewBufferString(`{"token":"abc","new_password":"Tangerine-Kite-42"}`))
+
+		mockService.EXPECT().ResetPassword(gomock.Any(), ResetPasswordParams{Token: "abc", NewPassword: "Tangerine-Kite-42"}).Return(nil)
+
+		response := handler.ResetPasswordHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+
+	t.Run("returns service error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := NewMockService(ctrl)
+		handler := NewHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/api/users/reset-password",
+			bytes.NewBufferString(`{"token":"abc","new_password":"Tangerine-Kite-42"}`))
+
+		mockService.EXPECT().ResetPassword(gomock.Any(), gomock.Any()).Return(errInvalidResetToken)
+
+		response := handler.ResetPasswordHandler(c)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestHandler_DeactivateUserHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("deactivates user with reason", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := NewMockService(ctrl)
+		handler := NewHandler(mockService)
+
+		adminID, userID := uuid.New(), uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPatch, "/v1/api/admin/users/"+userID.String()+"/deactivate",
+			bytes.NewBufferString(`{"reason":"fraud"}`))
+		c.Params = gin.Params{{Key: "id", Value: userID.String()}}
+		injectProfile(c, auth.Profile{UserID: adminID})
+
+		mockService.EXPECT().DeactivateUser(gomock.Any(), LifecycleParams{
+			Reason:  "fraud",
+			UserID:  userID,
+			ActorID: adminID,
+		}).Return(nil)
+
+		response := handler.DeactivateUserHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+
+	t.Run("accepts empty body", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := NewMockService(ctrl)
+		handler := NewHandler(mockService)
+
+		adminID, userID := uuid.New(), uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPatch, "/v1/api/admin/users/"+userID.String()+"/deactivate", nil)
+		c.Params = gin.Params{{Key: "id", Value: userID.String()}}
+		injectProfile(c, auth.Profile{UserID: adminID})
+
+		mockService.EXPECT().DeactivateUser(gomock.Any(), LifecycleParams{
+			UserID:  userID,
+			ActorID: adminID,
+		}).Return(platformerrors.MakeApiError(http.StatusBadRequest, "user is already deactivated"))
+
+		response := handler.DeactivateUserHandler(c)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+
+	t.Run("rejects invalid user id", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		handler := NewHandler(NewMockService(ctrl))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPatch, "/v1/api/admin/users/abc/deactivate", nil)
+		c.Params = gin.Params{{Key: "id", Value: "abc"}}
+		injectProfile(c, auth.Profile{UserID: uuid.New()})
+
+		response := handler.DeactivateUserHandler(c)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestHandler_SetTaxStatusHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("sets the tax status", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := NewMockService(ctrl)
+		handler := NewHandler(mockService)
+
+		adminID, userID := uuid.New(), uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPut, "/v1/api/admin/users/"+userID.String()+"/tax",
+			bytes.NewBufferString(`{"tax_residency":"IE","tax_exempt":true}`))
+		c.Params = gin.Params{{Key: "id", Value: userID.String()}}
+		injectProfile(c, auth.Profile{UserID: adminID})
+
+		user := User{UserID: userID, TaxResidency: "IE", TaxExempt: true}
+		mockService.EXPECT().SetTaxStatus(gomock.Any(), TaxStatusParams{
+			TaxResidency: "IE",
+			TaxExempt:    true,
+			UserID:       userID,
+			ActorID:      adminID,
+		}).Return(user, nil)
+
+		response := handler.SetTaxStatusHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    user,
+			Message: "tax status updated successfully",
+		}, response.Data)
+	})
+
+	t.Run("rejects a residency that is not a country code", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		handler := NewHandler(NewMockService(ctrl))
+
+		userID := uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPut, "/v1/api/admin/users/"+userID.String()+"/tax",
+			bytes.NewBufferString(`{"tax_residency":"UK"}`))
+		c.Params = gin.Params{{Key: "id", Value: userID.String()}}
+		injectProfile(c, auth.Profile{UserID: uuid.New()})
+
+		response := handler.SetTaxStatusHandler(c)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestHandler_GetUserHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("returns user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := NewMockService(ctrl)
+		handler := NewHandler(mockService)
+
+		userID := uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/v1/api/admin/users/"+userID.String(), nil)
+		c.Params = gin.Params{{Key: "id", Value: userID.String()}}
+
+		user := User{UserID: userID}
+		mockService.EXPECT().GetUser(gomock.Any(), userID).Return(user, nil)
+
+		response := handler.GetUserHandler(c)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, api.SuccessResponse{
+			Data:    user,
+			Message: "user retrieved successfully",
+		}, response.Data)
+	})
+}
+
+func injectClaim(ctx *gin.Context, tokenData generator.TokenData) {
+	claims := &validator.ValidatedClaims{
+		CustomClaims: &generator.Claim{TokenData: tokenData},
+	}
+	ctx.Request = ctx.Request.WithContext(context.WithValue(ctx.Request.Context(), jwtmiddleware.ContextKey{}, claims))
+}
+
+func injectProfile(ctx *gin.Context, profile auth.Profile) {
+	ctx.Request = ctx.Request.WithContext(context.WithValue(ctx.Request.Context(), auth.ProfileKey, profile))
+}
@@ -0,0 +1,744 @@
+//go:generate mockgen -source=service.go -destination=service_mock.go -package=user
+
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"net/http"
+	"payter-bank/features/account"
+	"payter-bank/features/auditlog"
+	"payter-bank/features/session"
+	"payter-bank/internal/config"
+	"payter-bank/internal/database"
+	"payter-bank/internal/database/models"
+	platformerrors "payter-bank/internal/errors"
+	"payter-bank/internal/logger"
+	"payter-bank/internal/pkg/generator"
+	"payter-bank/internal/pkg/mailer"
+	"payter-bank/internal/pkg/password"
+	"time"
+)
+
+type Service interface {
+	// Register creates a CUSTOMER that cannot log in until the email address is verified.
+	Register(ctx context.Context, param RegisterParams) (RegisterResponse, error)
+	// ResendVerification sends a new verification link to an unverified user. It does not
+	// report whether the email belongs to a user.
+	ResendVerification(ctx context.Context, param ResendVerificationParams) error
+	GetUser(ctx context.Context, userID uuid.UUID) (User, error)
+	UpdateProfile(ctx context.Context, param UpdateProfileParams) (User, error)
+	ChangePassword(ctx context.Context, param ChangePasswordParams) error
+	// ForgotPassword emails a password reset link to an active, verified user. It does not report
+	// whether the email belongs to a user.
+	ForgotPassword(ctx context.Context, param ForgotPasswordParams) error
+	// ResetPassword sets a new password with a single-use reset token and logs the user out
+	// everywhere.
+	ResetPassword(ctx context.Context, param ResetPasswordParams) error
+	VerifyEmail(ctx context.Context, param VerifyEmailParams) error
+	// DeactivateUser blocks the user from logging in and suspends all of their open accounts.
+	DeactivateUser(ctx context.Context, param LifecycleParams) error
+	// ReactivateUser lets the user log in again. Accounts suspended on deactivation stay
+	// suspended until an admin activates them.
+	ReactivateUser(ctx context.Context, param LifecycleParams) error
+	// SetTaxStatus sets the tax residency and exemption tax on the user's interest is withheld by.
+	SetTaxStatus(ctx context.Context, param TaxStatusParams) (User, error)
+}
+
+const emailConstraint = "users_email_key"
+
+var (
+	errInvalidToken      = platformerrors.MakeApiError(http.StatusBadRequest, "verification link is invalid or has expired")
+	errInvalidResetToken = platformerrors.MakeApiError(http.StatusBadRequest, "password reset link is invalid or has expired")
+)
+
+type service struct {
+	db        models.Querier
+	auditLog  auditlog.Service
+	mailer    mailer.Mailer
+	accounts  account.Service
+	passwords password.Policy
+	cfg       config.AppConfig
+	now       func() time.Time
+}
+
+func NewService(
+	db models.Querier,
+	auditLog auditlog.Service,
+	mail mailer.Mailer,
+	accounts account.Service,
+	passwords password.Policy,
+	cfg config.AppConfig) Service {
+	return &service{
+		db:        db,
+		auditLog:  auditLog,
+		mailer:    mail,
+		accounts:  accounts,
+		passwords: passwords,
+		cfg:       cfg,
+		now:       time.Now,
+	}
+}
+
+func (s *service) Register(ctx context.Context, param RegisterParams) (RegisterResponse, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Register"),
+		zap.Any(logger.RequestFields, param.Email))
+
+	existing, err := s.db.GetUserByEmail(ctx, param.Email)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		logger.Error(ctx, "failed to check if user exists", zap.Error(err))
+		return RegisterResponse{}, platformerrors.ErrInternal
+	}
+	if existing.ID != uuid.Nil {
+		return RegisterResponse{}, platformerrors.MakeApiError(http.StatusBadRequest, "user already exists")
+	}
+
+	hashed, err := s.hashPassword(ctx, param.Password, param.Email)
+	if err != nil {
+		return RegisterResponse{}, err
+	}
+
+	newUser, err := s.db.SaveUser(ctx, models.SaveUserParams{
+		Email:     param.Email,
+		Password:  hashed,
+		FirstName: param.FirstName,
+		LastName:  param.LastName,
+		UserType:  models.UserTypeCUSTOMER,
+	})
+	if err != nil {
+		if database.IsUniqueViolation(err, emailConstraint) {
+			return RegisterResponse{}, platformerrors.MakeApiError(http.StatusBadRequest, "user already exists")
+		}
+		logger.Error(ctx, "failed to save user", zap.Error(err))
+		return RegisterResponse{}, platformerrors.ErrInternal
+	}
+
+	s.submitAudit(ctx, auditlog.NewEvent(auditlog.ActionUserRegistered, newUser.ID, uuid.Nil,
+		auditlog.UserLifecycleMetadata{UserID: newUser.ID}))
+
+	if err := s.sendRegistrationLink(ctx, newUser.ID, param.FirstName, param.Email); err != nil {
+		return RegisterResponse{}, err
+	}
+
+	return RegisterResponse{
+		UserID: newUser.ID,
+	}, nil
+}
+
+func (s *service) ResendVerification(ctx context.Context, param ResendVerificationParams) error {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "ResendVerification"),
+		zap.Any(logger.RequestFields, param.Email))
+
+	user, err := s.db.GetUserByEmail(ctx, param.Email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		logger.Error(ctx, "failed to get user by email", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+
+	if user.EmailVerifiedAt.Valid || user.DeletedAt.Valid {
+		return nil
+	}
+
+	return s.sendRegistrationLink(ctx, user.ID, user.FirstName, user.Email)
+}
+
+func (s *service) sendRegistrationLink(ctx context.Context, userID uuid.UUID, firstName, email string) error {
+	token, err := s.issueVerificationToken(ctx, userID, email)
+	if err != nil {
+		return err
+	}
+
+	s.sendMail(ctx, mailer.Mail{
+		To:      email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nWelcome to Payter Bank. Confirm your email address by opening the link below:\n\n%s\n\nThe link expires in %s.",
+			firstName, s.verificationLink(token), s.cfg.VerificationTokenTTL),
+	})
+	return nil
+}
+
+func (s *service) GetUser(ctx context.Context, userID uuid.UUID) (User, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "GetUser"),
+		zap.Any(logger.RequestFields, userID))
+
+	row, err := s.getUser(ctx, userID)
+	if err != nil {
+		return User{}, err
+	}
+	return UserFromRow(row), nil
+}
+
+func (s *service) UpdateProfile(ctx context.Context, param UpdateProfileParams) (User, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "UpdateProfile"),
+		zap.Any(logger.RequestFields, param))
+
+	user, err := s.getUser(ctx, param.UserID)
+	if err != nil {
+		return User{}, err
+	}
+
+	if user.DeletedAt.Valid {
+		return User{}, platformerrors.MakeApiError(http.StatusPreconditionFailed, "user has been deactivated")
+	}
+
+	firstName, lastName := user.FirstName, user.LastName
+	if param.FirstName != "" {
+		firstName = param.FirstName
+	}
+	if param.LastName != "" {
+		lastName = param.LastName
+	}
+	nameChanged := firstName != user.FirstName || lastName != user.LastName
+	emailChanged := param.Email != "" && param.Email != user.Email
+
+	if !nameChanged && !emailChanged {
+		return UserFromRow(user), nil
+	}
+
+	if emailChanged {
+		existing, err := s.db.GetUserByEmail(ctx, param.Email)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			logger.Error(ctx, "failed to check if email is in use", zap.Error(err))
+			return User{}, platformerrors.ErrInternal
+		}
+		if existing.ID != uuid.Nil {
+			return User{}, platformerrors.MakeApiError(http.StatusBadRequest, "email is already in use")
+		}
+	}
+
+	if nameChanged {
+		err = s.db.UpdateUserName(ctx, models.UpdateUserNameParams{
+			FirstName: firstName,
+			LastName:  lastName,
+			ID:        user.ID,
+		})
+		if err != nil {
+			logger.Error(ctx, "failed to update user name", zap.Error(err))
+			return User{}, platformerrors.ErrInternal
+		}
+	}
+
+	if emailChanged {
+		if err := s.requestEmailChange(ctx, user, param.Email); err != nil {
+			return User{}, err
+		}
+	}
+
+	metadata := auditlog.UserProfileChangeMetadata{
+		UserID:       user.ID,
+		OldFirstName: user.FirstName,
+		NewFirstName: firstName,
+		OldLastName:  user.LastName,
+		NewLastName:  lastName,
+		OldEmail:     user.Email,
+		NewEmail:     user.Email,
+	}
+	if emailChanged {
+		metadata.NewEmail = param.Email
+	}
+	s.submitAudit(ctx, auditlog.NewEvent(auditlog.ActionUserProfileChange, param.ActorID, uuid.Nil, metadata))
+
+	return s.GetUser(ctx, user.ID)
+}
+
+// requestEmailChange parks the new email on the user and sends a verification link to it.
+// Links sent for a previous change stop working.
+func (s *service) requestEmailChange(ctx context.Context, user models.GetUserByIDRow, email string) error {
+	err := s.db.SetPendingEmail(ctx, models.SetPendingEmailParams{
+		PendingEmail: sql.NullString{String: email, Valid: true},
+		ID:           user.ID,
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to set pending email", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+
+	token, err := s.issueVerificationToken(ctx, user.ID, email)
+	if err != nil {
+		return err
+	}
+
+	s.sendMail(ctx, mailer.Mail{
+		To:      email,
+		Subject: "Verify your new email address",
+		Body: fmt.Sprintf("Hi %s,\n\nConfirm this is your new email address by opening the link below:\n\n%s\n\nThe link expires in %s.",
+			user.FirstName, s.verificationLink(token), s.cfg.VerificationTokenTTL),
+	})
+	s.sendMail(ctx, mailer.Mail{
+		To:      user.Email,
+		Subject: "Your email address is being changed",
+		Body: fmt.Sprintf("Hi %s,\n\nA change of your email address to %s was requested. If this was not you, contact support immediately.",
+			user.FirstName, email),
+	})
+	return nil
+}
+
+// issueVerificationToken saves a new verification token for the email and returns it. Tokens
+// issued earlier to the user stop working.
+func (s *service) issueVerificationToken(ctx context.Context, userID uuid.UUID, email string) (string, error) {
+	err := s.db.InvalidateUserTokens(ctx, models.InvalidateUserTokensParams{
+		UserID:  userID,
+		Purpose: PurposeEmailVerification,
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to invalidate previous verification tokens", zap.Error(err))
+		return "", platformerrors.ErrInternal
+	}
+
+	token, hash := generator.DefaultUserTokenGenerator.Generate()
+	_, err = s.db.SaveUserToken(ctx, models.SaveUserTokenParams{
+		UserID:    userID,
+		Purpose:   PurposeEmailVerification,
+		TokenHash: hash,
+		Email:     sql.NullString{String: email, Valid: true},
+		ExpiresAt: s.now().Add(s.cfg.VerificationTokenTTL),
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to save verification token", zap.Error(err))
+		return "", platformerrors.ErrInternal
+	}
+	return token, nil
+}
+
+func (s *service) verificationLink(token string) string {
+	return fmt.Sprintf("%s/verify-email?token=%s", s.cfg.WebURL, token)
+}
+
+func (s *service) ChangePassword(ctx context.Context, param ChangePasswordParams) error {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "ChangePassword"),
+		zap.Any(logger.RequestFields, param.UserID))
+
+	user, err := s.getUser(ctx, param.UserID)
+	if err != nil {
+		return err
+	}
+
+	if !password.DefaultPasswordHasher.Validate(user.Password, param.CurrentPassword) {
+		return platformerrors.MakeApiError(http.StatusBadRequest, "current password is incorrect")
+	}
+
+	if param.CurrentPassword == param.NewPassword {
+		return platformerrors.MakeApiError(http.StatusBadRequest, "new password must be different from the current password")
+	}
+
+	hashed, err := s.hashPassword(ctx, param.NewPassword, user.Email)
+	if err != nil {
+		return err
+	}
+
+	err = s.db.UpdateUserPassword(ctx, models.UpdateUserPasswordParams{
+		Password: hashed,
+		ID:       user.ID,
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to update password", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+
+	s.submitAudit(ctx, auditlog.NewEvent(auditlog.ActionUserPasswordChange, user.ID, uuid.Nil,
+		auditlog.UserPasswordChangeMetadata{UserID: user.ID}))
+
+	// a password is often changed because it leaked, every other session is logged out with it
+	revoked, err := s.db.RevokeUserSessions(ctx, models.RevokeUserSessionsParams{
+		UserID:        user.ID,
+		RevokedReason: sql.NullString{String: session.ReasonPasswordChange, Valid: true},
+		KeepSessionID: uuid.NullUUID{UUID: param.SessionID, Valid: param.SessionID != uuid.Nil},
+	})
+	if err != nil {
+		logger.Warn(ctx, "failed to revoke sessions after password change", zap.Error(err))
+	} else {
+		s.submitAudit(ctx, auditlog.NewEvent(auditlog.ActionUserSessionsRevoked, user.ID, uuid.Nil,
+			auditlog.SessionRevocationMetadata{
+				UserID:  user.ID,
+				Reason:  session.ReasonPasswordChange,
+				Revoked: revoked,
+			}))
+	}
+
+	s.sendMail(ctx, mailer.Mail{
+		To:      user.Email,
+		Subject: "Your password was changed",
+		Body: fmt.Sprintf("Hi %s,\n\nThe password of your account was just changed and your other sessions were logged out. If this was not you, contact support immediately.",
+			user.FirstName),
+	})
+	return nil
+}
+
+func (s *service) ForgotPassword(ctx context.Context, param ForgotPasswordParams) error {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "ForgotPassword"),
+		zap.Any(logger.RequestFields, param.Email))
+
+	user, err := s.db.GetUserByEmail(ctx, param.Email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		logger.Error(ctx, "failed to get user by email", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+
+	// unverified users confirm their email first, service accounts never log in with a password
+	if !user.EmailVerifiedAt.Valid || user.DeletedAt.Valid || user.UserType == models.UserTypeSERVICE {
+		return nil
+	}
+
+	err = s.db.InvalidateUserTokens(ctx, models.InvalidateUserTokensParams{
+		UserID:  user.ID,
+		Purpose: account.PurposePasswordReset,
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to invalidate previous password reset tokens", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+
+	token, hash := generator.DefaultUserTokenGenerator.Generate()
+	_, err = s.db.SaveUserToken(ctx, models.SaveUserTokenParams{
+		UserID:    user.ID,
+		Purpose:   account.PurposePasswordReset,
+		TokenHash: hash,
+		Email:     sql.NullString{String: user.Email, Valid: true},
+		ExpiresAt: s.now().Add(s.cfg.PasswordResetTokenTTL),
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to save password reset token", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+
+	s.sendMail(ctx, mailer.Mail{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nSet a new password by opening the link below:\n\n%s/reset-password?token=%s\n\nThe link expires in %s. If you did not ask for it, you can ignore this email.",
+			user.FirstName, s.cfg.WebURL, token, s.cfg.PasswordResetTokenTTL),
+	})
+	return nil
+}
+
+func (s *service) ResetPassword(ctx context.Context, param ResetPasswordParams) error {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "ResetPassword"))
+
+	token, err := s.db.GetUserTokenByHash(ctx, generator.HashUserToken(param.Token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errInvalidResetToken
+		}
+		logger.Error(ctx, "failed to get password reset token", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+
+	if token.Purpose != account.PurposePasswordReset || token.UsedAt.Valid || !s.now().Before(token.ExpiresAt) {
+		return errInvalidResetToken
+	}
+
+	user, err := s.getUser(ctx, token.UserID)
+	if err != nil {
+		return err
+	}
+
+	// a link emailed before the email address changed is no good
+	if user.DeletedAt.Valid || (token.Email.Valid && token.Email.String != user.Email) {
+		return errInvalidResetToken
+	}
+
+	if password.DefaultPasswordHasher.Validate(user.Password, param.NewPassword) {
+		return platformerrors.MakeApiError(http.StatusBadRequest, "new password must be different from the current password")
+	}
+
+	hashed, err := s.hashPassword(ctx, param.NewPassword, user.Email)
+	if err != nil {
+		return err
+	}
+
+	// the token is spent before the password changes so that it cannot be used twice
+	affected, err := s.db.MarkUserTokenUsed(ctx, token.ID)
+	if err != nil {
+		logger.Error(ctx, "failed to mark password reset token as used", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+	if affected == 0 {
+		return errInvalidResetToken
+	}
+
+	err = s.db.UpdateUserPassword(ctx, models.UpdateUserPasswordParams{
+		Password: hashed,
+		ID:       user.ID,
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to update password", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+
+	_, err = s.db.RevokeUserSessions(ctx, models.RevokeUserSessionsParams{
+		UserID:        user.ID,
+		RevokedReason: sql.NullString{String: session.ReasonPasswordReset, Valid: true},
+	})
+	if err != nil {
+		logger.Warn(ctx, "failed to revoke sessions after password reset", zap.Error(err))
+	}
+
+	s.submitAudit(ctx, auditlog.NewEvent(auditlog.ActionUserPasswordReset, user.ID, uuid.Nil,
+		auditlog.UserPasswordChangeMetadata{UserID: user.ID}))
+	s.sendMail(ctx, mailer.Mail{
+		To:      user.Email,
+		Subject: "Your password was reset",
+		Body: fmt.Sprintf("Hi %s,\n\nThe password of your account was just reset and you were logged out everywhere. If this was not you, contact support immediately.",
+			user.FirstName),
+	})
+	return nil
+}
+
+// hashPassword checks a new password against the password policy and hashes it.
+func (s *service) hashPassword(ctx context.Context, pwd, email string) (string, error) {
+	if err := s.passwords.Check(pwd, email); err != nil {
+		return "", platformerrors.MakeApiError(http.StatusBadRequest, err.Error())
+	}
+
+	hashed, err := password.DefaultPasswordHasher.Hash(pwd)
+	if err != nil {
+		logger.Error(ctx, "failed to hash password", zap.Error(err))
+		return "", platformerrors.ErrInternal
+	}
+	return hashed, nil
+}
+
+func (s *service) VerifyEmail(ctx context.Context, param VerifyEmailParams) error {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "VerifyEmail"))
+
+	token, err := s.db.GetUserTokenByHash(ctx, generator.HashUserToken(param.Token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errInvalidToken
+		}
+		logger.Error(ctx, "failed to get verification token", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+
+	if token.Purpose != PurposeEmailVerification || token.UsedAt.Valid || !s.now().Before(token.ExpiresAt) {
+		return errInvalidToken
+	}
+
+	affected, err := s.db.MarkUserTokenUsed(ctx, token.ID)
+	if err != nil {
+		logger.Error(ctx, "failed to mark verification token as used", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+	if affected == 0 {
+		return errInvalidToken
+	}
+
+	user, err := s.getUser(ctx, token.UserID)
+	if err != nil {
+		return err
+	}
+
+	if !user.EmailVerifiedAt.Valid && token.Email.String == user.Email {
+		return s.completeRegistration(ctx, user)
+	}
+
+	affected, err = s.db.ConfirmPendingEmail(ctx, models.ConfirmPendingEmailParams{
+		ID:           user.ID,
+		PendingEmail: token.Email,
+	})
+	if err != nil {
+		if database.IsUniqueViolation(err, emailConstraint) {
+			return platformerrors.MakeApiError(http.StatusConflict, "email is already in use")
+		}
+		logger.Error(ctx, "failed to confirm email", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+	if affected == 0 {
+		return errInvalidToken
+	}
+
+	s.submitAudit(ctx, auditlog.NewEvent(auditlog.ActionUserEmailChange, user.ID, uuid.Nil,
+		auditlog.UserEmailChangeMetadata{
+			UserID:   user.ID,
+			OldEmail: user.Email,
+			NewEmail: token.Email.String,
+		}))
+	return nil
+}
+
+// completeRegistration marks the email of a self-registered user as verified, which allows them
+// to log in, and opens their first account when configured to.
+func (s *service) completeRegistration(ctx context.Context, user models.GetUserByIDRow) error {
+	affected, err := s.db.MarkEmailVerified(ctx, models.MarkEmailVerifiedParams{
+		ID:    user.ID,
+		Email: user.Email,
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to mark email as verified", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+	if affected == 0 {
+		return errInvalidToken
+	}
+
+	s.submitAudit(ctx, auditlog.NewEvent(auditlog.ActionUserEmailVerified, user.ID, uuid.Nil,
+		auditlog.UserLifecycleMetadata{UserID: user.ID}))
+
+	if s.cfg.SignupOpensAccount {
+		// the email is verified at this point, an admin can still open the account if this fails
+		if err := s.accounts.OpenPendingAccount(ctx, user.ID); err != nil {
+			logger.Error(ctx, "failed to open account for new customer", zap.Error(err))
+		}
+	}
+	return nil
+}
+
+func (s *service) DeactivateUser(ctx context.Context, param LifecycleParams) error {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "DeactivateUser"),
+		zap.Any(logger.RequestFields, param))
+
+	if param.UserID == param.ActorID {
+		return platformerrors.MakeApiError(http.StatusBadRequest, "you cannot deactivate yourself")
+	}
+
+	if param.UserID == uuid.Nil || param.UserID == s.cfg.SystemUserID {
+		return platformerrors.MakeApiError(http.StatusBadRequest, "system users cannot be deactivated")
+	}
+
+	user, err := s.getUser(ctx, param.UserID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := s.db.DeactivateUser(ctx, user.ID)
+	if err != nil {
+		logger.Error(ctx, "failed to deactivate user", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+	if affected == 0 {
+		return platformerrors.MakeApiError(http.StatusBadRequest, "user is already deactivated")
+	}
+
+	suspended, err := s.db.SuspendUserAccounts(ctx, user.ID)
+	if err != nil {
+		logger.Error(ctx, "failed to suspend user accounts", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+
+	suspendedIDs := make([]uuid.UUID, 0, len(suspended))
+	for _, account := range suspended {
+		suspendedIDs = append(suspendedIDs, account.AccountID)
+		s.submitAudit(ctx, auditlog.NewEvent(auditlog.ActionAccountStatusChange, param.ActorID, account.AccountID,
+			auditlog.AccountStatusChangeMetadata{OldStatus: string(account.OldStatus), NewStatus: string(models.StatusSUSPENDED)}))
+	}
+
+	err = s.db.InvalidateUserTokens(ctx, models.InvalidateUserTokensParams{
+		UserID:  user.ID,
+		Purpose: PurposeEmailVerification,
+	})
+	if err != nil {
+		logger.Warn(ctx, "failed to invalidate verification tokens", zap.Error(err))
+	}
+
+	s.submitAudit(ctx, auditlog.NewEvent(auditlog.ActionUserDeactivated, param.ActorID, uuid.Nil,
+		auditlog.UserLifecycleMetadata{
+			UserID:            user.ID,
+			Reason:            param.Reason,
+			SuspendedAccounts: suspendedIDs,
+		}))
+	return nil
+}
+
+func (s *service) ReactivateUser(ctx context.Context, param LifecycleParams) error {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "ReactivateUser"),
+		zap.Any(logger.RequestFields, param))
+
+	user, err := s.getUser(ctx, param.UserID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := s.db.ReactivateUser(ctx, user.ID)
+	if err != nil {
+		logger.Error(ctx, "failed to reactivate user", zap.Error(err))
+		return platformerrors.ErrInternal
+	}
+	if affected == 0 {
+		return platformerrors.MakeApiError(http.StatusBadRequest, "user is not deactivated")
+	}
+
+	s.submitAudit(ctx, auditlog.NewEvent(auditlog.ActionUserReactivated, param.ActorID, uuid.Nil,
+		auditlog.UserLifecycleMetadata{
+			UserID: user.ID,
+			Reason: param.Reason,
+		}))
+	return nil
+}
+
+func (s *service) SetTaxStatus(ctx context.Context, param TaxStatusParams) (User, error) {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "SetTaxStatus"),
+		zap.Any(logger.RequestFields, param))
+
+	user, err := s.getUser(ctx, param.UserID)
+	if err != nil {
+		return User{}, err
+	}
+
+	residency := sql.NullString{String: param.TaxResidency, Valid: param.TaxResidency != ""}
+	err = s.db.SetUserTaxStatus(ctx, models.SetUserTaxStatusParams{
+		TaxResidency: residency,
+		TaxExempt:    param.TaxExempt,
+		ID:           user.ID,
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to set tax status", zap.Error(err))
+		return User{}, platformerrors.ErrInternal
+	}
+
+	s.submitAudit(ctx, auditlog.NewEvent(auditlog.ActionUserTaxStatusChange, param.ActorID, uuid.Nil,
+		auditlog.UserTaxStatusChangeMetadata{
+			UserID:       user.ID,
+			OldResidency: user.TaxResidency.String,
+			NewResidency: param.TaxResidency,
+			OldExempt:    user.TaxExempt,
+			NewExempt:    param.TaxExempt,
+		}))
+
+	user.TaxResidency, user.TaxExempt = residency, param.TaxExempt
+	return UserFromRow(user), nil
+}
+
+func (s *service) getUser(ctx context.Context, userID uuid.UUID) (models.GetUserByIDRow, error) {
+	user, err := s.db.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.GetUserByIDRow{}, platformerrors.MakeApiError(http.StatusNotFound, "user not found")
+		}
+		logger.Error(ctx, "failed to get user by id", zap.Error(err))
+		return models.GetUserByIDRow{}, platformerrors.ErrInternal
+	}
+	return user, nil
+}
+
+func (s *service) submitAudit(ctx context.Context, event auditlog.Event) {
+	if err := s.auditLog.Submit(ctx, event); err != nil {
+		logger.Error(ctx, "failed to queue audit log", zap.Error(err))
+	}
+}
+
+// sendMail delivers an email on a best-effort basis, the change it reports has already been made.
+func (s *service) sendMail(ctx context.Context, mail mailer.Mail) {
+	if err := s.mailer.Send(ctx, mail); err != nil {
+		logger.Warn(ctx, "failed to send email", zap.String("subject", mail.Subject), zap.Error(err))
+	}
+}
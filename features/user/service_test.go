@@ -0,0 +1,857 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"payter-bank/features/account"
+	"payter-bank/features/auditlog"
+	"payter-bank/features/session"
+	"payter-bank/internal/config"
+	"payter-bank/internal/database/models"
+	databasemocks "payter-bank/internal/database/models/mocks"
+	platformerrors "payter-bank/internal/errors"
+	"payter-bank/internal/pkg/generator"
+	generatormocks "payter-bank/internal/pkg/generator/mocks"
+	"payter-bank/internal/pkg/mailer"
+	mailermocks "payter-bank/internal/pkg/mailer/mocks"
+	"payter-bank/internal/pkg/password"
+	passwordhashermocks "payter-bank/internal/pkg/password/mocks"
+	"testing"
+	"time"
+)
+
+var (
+	fixedNow     = time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	systemUserID = uuid.MustParse("00000000-2222-2222-2222-000000000000")
+)
+
+func TestService_Register(t *testing.T) {
+	t.Run("registers unverified customer and sends verification link", func(t *testing.T) {
+		m := newUserMocker(t)
+		userID := uuid.New()
+
+		m.db.EXPECT().GetUserByEmail(gomock.Any(), "jane@example.com").
+			Return(models.GetUserByEmailRow{}, sql.ErrNoRows)
+		m.passwordHasher.EXPECT().Hash("Tangerine-Kite-42").Return("hashed", nil)
+		m.db.EXPECT().SaveUser(gomock.Any(), models.SaveUserParams{
+			Email:     "jane@example.com",
+			Password:  "hashed",
+			FirstName: "Jane",
+			LastName:  "Doe",
+			UserType:  models.UserTypeCUSTOMER,
+		}).Return(models.SaveUserRow{ID: userID, Email: "jane@example.com"}, nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(
+			auditlog.ActionUserRegistered, userID, uuid.Nil,
+			auditlog.UserLifecycleMetadata{UserID: userID})).Return(nil)
+		m.db.EXPECT().InvalidateUserTokens(gomock.Any(), models.InvalidateUserTokensParams{
+			UserID:  userID,
+			Purpose: PurposeEmailVerification,
+		}).Return(nil)
+		m.tokenGenerator.EXPECT().Generate().Return("plain-token", "hashed-token")
+		m.db.EXPECT().SaveUserToken(gomock.Any(), models.SaveUserTokenParams{
+			UserID:    userID,
+			Purpose:   PurposeEmailVerification,
+			TokenHash: "hashed-token",
+			Email:     sql.NullString{String: "jane@example.com", Valid: true},
+			ExpiresAt: fixedNow.Add(24 * time.Hour),
+		}).Return(models.UserToken{}, nil)
+		m.mailer.EXPECT().Send(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, mail mailer.Mail) error {
+				assert.Equal(t, "jane@example.com", mail.To)
+				assert.Contains(t, mail.Body, "https://app.test/verify-email?token=plain-token")
+				return nil
+			})
+
+		result, err := m.service.Register(context.TODO(), RegisterParams{
+			FirstName: "Jane",
+			LastName:  "Doe",
+			Email:     "jane@example.com",
+			Password:  "Tangerine-Kite-42",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, RegisterResponse{UserID: userID}, result)
+	})
+
+	t.Run("rejects existing email", func(t *testing.T) {
+		m := newUserMocker(t)
+		m.db.EXPECT().GetUserByEmail(gomock.Any(), "jane@example.com").
+			Return(models.GetUserByEmailRow{ID: uuid.New(), Email: "jane@example.com"}, nil)
+
+		_, err := m.service.Register(context.TODO(), RegisterParams{Email: "jane@example.com"})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, "user already exists"), err)
+	})
+
+	t.Run("rejects breached password", func(t *testing.T) {
+		m := newUserMocker(t)
+		m.db.EXPECT().GetUserByEmail(gomock.Any(), "jane@example.com").
+			Return(models.GetUserByEmailRow{}, sql.ErrNoRows)
+
+		_, err := m.service.Register(context.TODO(), RegisterParams{Email: "jane@example.com", Password: "Password2025"})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, "password has appeared in a data breach, choose another one"), err)
+	})
+}
+
+func TestService_ResendVerification(t *testing.T) {
+	t.Run("sends new link to unverified user", func(t *testing.T) {
+		m := newUserMocker(t)
+		userID := uuid.New()
+
+		m.db.EXPECT().GetUserByEmail(gomock.Any(), "jane@example.com").
+			Return(models.GetUserByEmailRow{ID: userID, Email: "jane@example.com", FirstName: "Jane"}, nil)
+		m.db.EXPECT().InvalidateUserTokens(gomock.Any(), gomock.Any()).Return(nil)
+		m.tokenGenerator.EXPECT().Generate().Return("plain-token", "hashed-token")
+		m.db.EXPECT().SaveUserToken(gomock.Any(), gomock.Any()).Return(models.UserToken{}, nil)
+		m.mailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+
+		err := m.service.ResendVerification(context.TODO(), ResendVerificationParams{Email: "jane@example.com"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("does nothing for unknown email", func(t *testing.T) {
+		m := newUserMocker(t)
+		m.db.EXPECT().GetUserByEmail(gomock.Any(), "nobody@example.com").
+			Return(models.GetUserByEmailRow{}, sql.ErrNoRows)
+
+		err := m.service.ResendVerification(context.TODO(), ResendVerificationParams{Email: "nobody@example.com"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("does nothing for verified user", func(t *testing.T) {
+		m := newUserMocker(t)
+		m.db.EXPECT().GetUserByEmail(gomock.Any(), "jane@example.com").
+			Return(models.GetUserByEmailRow{
+				ID:              uuid.New(),
+				Email:           "jane@example.com",
+				EmailVerifiedAt: sql.NullTime{Time: fixedNow, Valid: true},
+			}, nil)
+
+		err := m.service.ResendVerification(context.TODO(), ResendVerificationParams{Email: "jane@example.com"})
+		assert.NoError(t, err)
+	})
+}
+
+func TestService_UpdateProfile(t *testing.T) {
+	t.Run("updates name", func(t *testing.T) {
+		m := newUserMocker(t)
+		user := activeUser()
+
+		m.db.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		m.db.EXPECT().UpdateUserName(gomock.Any(), models.UpdateUserNameParams{
+			FirstName: "Janet",
+			LastName:  "Doe",
+			ID:        user.ID,
+		}).Return(nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(
+			auditlog.ActionUserProfileChange, user.ID, uuid.Nil,
+			auditlog.UserProfileChangeMetadata{
+				UserID:       user.ID,
+				OldFirstName: "Jane",
+				NewFirstName: "Janet",
+				OldLastName:  "Doe",
+				NewLastName:  "Doe",
+				OldEmail:     "jane@example.com",
+				NewEmail:     "jane@example.com",
+			})).Return(nil)
+		updated := user
+		updated.FirstName = "Janet"
+		m.db.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(updated, nil)
+
+		result, err := m.service.UpdateProfile(context.TODO(), UpdateProfileParams{
+			FirstName: "Janet",
+			UserID:    user.ID,
+			ActorID:   user.ID,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "Janet", result.FirstName)
+	})
+
+	t.Run("email change is parked until verified", func(t *testing.T) {
+		m := newUserMocker(t)
+		user := activeUser()
+		adminID := uuid.New()
+
+		m.db.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		m.db.EXPECT().GetUserByEmail(gomock.Any(), "new@example.com").
+			Return(models.GetUserByEmailRow{}, sql.ErrNoRows)
+		m.db.EXPECT().SetPendingEmail(gomock.Any(), models.SetPendingEmailParams{
+			PendingEmail: sql.NullString{String: "new@example.com", Valid: true},
+			ID:           user.ID,
+		}).Return(nil)
+		m.db.EXPECT().InvalidateUserTokens(gomock.Any(), models.InvalidateUserTokensParams{
+			UserID:  user.ID,
+			Purpose: PurposeEmailVerification,
+		}).Return(nil)
+		m.tokenGenerator.EXPECT().Generate().Return("plain-token", "hashed-token")
+		m.db.EXPECT().SaveUserToken(gomock.Any(), models.SaveUserTokenParams{
+			UserID:    user.ID,
+			Purpose:   PurposeEmailVerification,
+			TokenHash: "hashed-token",
+			Email:     sql.NullString{String: "new@example.com", Valid: true},
+			ExpiresAt: fixedNow.Add(24 * time.Hour),
+		}).Return(models.UserToken{}, nil)
+		m.mailer.EXPECT().Send(gomock.Any(), gomock.Cond(func(x any) bool {
+			mail := x.(mailer.Mail)
+			return mail.To == "new@example.com"
+		})).DoAndReturn(func(_ context.Context, mail mailer.Mail) error {
+			assert.Contains(t, mail.Body, "https://app.test/verify-email?token=plain-token")
+			return nil
+		})
+		m.mailer.EXPECT().Send(gomock.Any(), gomock.Cond(func(x any) bool {
+			return x.(mailer.Mail).To == "jane@example.com"
+		})).Return(nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(
+			auditlog.ActionUserProfileChange, adminID, uuid.Nil,
+			auditlog.UserProfileChangeMetadata{
+				UserID:       user.ID,
+				OldFirstName: "Jane",
+				NewFirstName: "Jane",
+				OldLastName:  "Doe",
+				NewLastName:  "Doe",
+				OldEmail:     "jane@example.com",
+				NewEmail:     "new@example.com",
+			})).Return(nil)
+		pending := user
+		pending.PendingEmail = sql.NullString{String: "new@example.com", Valid: true}
+		m.db.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(pending, nil)
+
+		result, err := m.service.UpdateProfile(context.TODO(), UpdateProfileParams{
+			Email:   "new@example.com",
+			UserID:  user.ID,
+			ActorID: adminID,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "jane@example.com", result.Email)
+		assert.Equal(t, "new@example.com", result.PendingEmail)
+	})
+
+	t.Run("rejects email used by another user", func(t *testing.T) {
+		m := newUserMocker(t)
+		user := activeUser()
+
+		m.db.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		m.db.EXPECT().GetUserByEmail(gomock.Any(), "taken@example.com").
+			Return(models.GetUserByEmailRow{ID: uuid.New(), Email: "taken@example.com"}, nil)
+
+		_, err := m.service.UpdateProfile(context.TODO(), UpdateProfileParams{
+			Email:  "taken@example.com",
+			UserID: user.ID,
+		})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, "email is already in use"), err)
+	})
+
+	t.Run("returns user unchanged when nothing changes", func(t *testing.T) {
+		m := newUserMocker(t)
+		user := activeUser()
+		m.db.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+
+		result, err := m.service.UpdateProfile(context.TODO(), UpdateProfileParams{
+			FirstName: "Jane",
+			Email:     "jane@example.com",
+			UserID:    user.ID,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID, result.UserID)
+	})
+
+	t.Run("rejects deactivated user", func(t *testing.T) {
+		m := newUserMocker(t)
+		user := activeUser()
+		user.DeletedAt = sql.NullTime{Time: fixedNow, Valid: true}
+		m.db.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+
+		_, err := m.service.UpdateProfile(context.TODO(), UpdateProfileParams{FirstName: "X", UserID: user.ID})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusPreconditionFailed, "user has been deactivated"), err)
+	})
+}
+
+func TestService_ChangePassword(t *testing.T) {
+	t.Run("changes password and logs out the other sessions", func(t *testing.T) {
+		m := newUserMocker(t)
+		user := activeUser()
+		sessionID := uuid.New()
+
+		m.db.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		m.passwordHasher.EXPECT().Validate("hashed", "old-password").Return(true)
+		m.passwordHasher.EXPECT().Hash("Tangerine-Kite-42").Return("new-hash", nil)
+		m.db.EXPECT().UpdateUserPassword(gomock.Any(), models.UpdateUserPasswordParams{
+			Password: "new-hash",
+			ID:       user.ID,
+		}).Return(nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(
+			auditlog.ActionUserPasswordChange, user.ID, uuid.Nil,
+			auditlog.UserPasswordChangeMetadata{UserID: user.ID})).Return(nil)
+		// the session the password was changed from stays logged in
+		m.db.EXPECT().RevokeUserSessions(gomock.Any(), models.RevokeUserSessionsParams{
+			UserID:        user.ID,
+			RevokedReason: sql.NullString{String: session.ReasonPasswordChange, Valid: true},
+			KeepSessionID: uuid.NullUUID{UUID: sessionID, Valid: true},
+		}).Return(int64(2), nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(
+			auditlog.ActionUserSessionsRevoked, user.ID, uuid.Nil,
+			auditlog.SessionRevocationMetadata{UserID: user.ID, Reason: session.ReasonPasswordChange, Revoked: 2})).Return(nil)
+		m.mailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(errors.New("smtp down"))
+
+		err := m.service.ChangePassword(context.TODO(), ChangePasswordParams{
+			CurrentPassword: "old-password",
+			NewPassword:     "Tangerine-Kite-42",
+			UserID:          user.ID,
+			SessionID:       sessionID,
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects new password that breaks the policy", func(t *testing.T) {
+		m := newUserMocker(t)
+		user := activeUser()
+
+		m.db.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		m.passwordHasher.EXPECT().Validate("hashed", "old-password").Return(true)
+
+		err := m.service.ChangePassword(context.TODO(), ChangePasswordParams{
+			CurrentPassword: "old-password",
+			NewPassword:     "tangerine-kite-42",
+			UserID:          user.ID,
+		})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, "password must contain an upper case letter"), err)
+	})
+
+	t.Run("rejects wrong current password", func(t *testing.T) {
+		m := newUserMocker(t)
+		user := activeUser()
+
+		m.db.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		m.passwordHasher.EXPECT().Validate("hashed", "wrong").Return(false)
+
+		err := m.service.ChangePassword(context.TODO(), ChangePasswordParams{
+			CurrentPassword: "wrong",
+			NewPassword:     "new-password",
+			UserID:          user.ID,
+		})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, "current password is incorrect"), err)
+	})
+}
+
+func TestService_ForgotPassword(t *testing.T) {
+	t.Run("emails a reset link", func(t *testing.T) {
+		m := newUserMocker(t)
+		userID := uuid.New()
+
+		m.db.EXPECT().GetUserByEmail(gomock.Any(), "jane@example.com").
+			Return(models.GetUserByEmailRow{
+				ID:              userID,
+				Email:           "jane@example.com",
+				FirstName:       "Jane",
+				UserType:        models.UserTypeCUSTOMER,
+				EmailVerifiedAt: sql.NullTime{Time: fixedNow, Valid: true},
+			}, nil)
+		m.db.EXPECT().InvalidateUserTokens(gomock.Any(), models.InvalidateUserTokensParams{
+			UserID:  userID,
+			Purpose: account.PurposePasswordReset,
+		}).Return(nil)
+		m.tokenGenerator.EXPECT().Generate().Return("plain-token", "hashed-token")
+		m.db.EXPECT().SaveUserToken(gomock.Any(), models.SaveUserTokenParams{
+			UserID:    userID,
+			Purpose:   account.PurposePasswordReset,
+			TokenHash: "hashed-token",
+			Email:     sql.NullString{String: "jane@example.com", Valid: true},
+			ExpiresAt: fixedNow.Add(time.Hour),
+		}).Return(models.UserToken{}, nil)
+		m.mailer.EXPECT().Send(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, mail mailer.Mail) error {
+				assert.Equal(t, "jane@example.com", mail.To)
+				assert.Contains(t, mail.Body, "https://app.test/reset-password?token=plain-token")
+				return nil
+			})
+
+		err := m.service.ForgotPassword(context.TODO(), ForgotPasswordParams{Email: "jane@example.com"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("does nothing for unknown email", func(t *testing.T) {
+		m := newUserMocker(t)
+		m.db.EXPECT().GetUserByEmail(gomock.Any(), "nobody@example.com").
+			Return(models.GetUserByEmailRow{}, sql.ErrNoRows)
+
+		err := m.service.ForgotPassword(context.TODO(), ForgotPasswordParams{Email: "nobody@example.com"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("does nothing for unverified or deactivated users", func(t *testing.T) {
+		for _, user := range []models.GetUserByEmailRow{
+			{ID: uuid.New(), Email: "jane@example.com"},
+			{
+				ID:              uuid.New(),
+				Email:           "jane@example.com",
+				EmailVerifiedAt: sql.NullTime{Time: fixedNow, Valid: true},
+				DeletedAt:       sql.NullTime{Time: fixedNow, Valid: true},
+			},
+		} {
+			m := newUserMocker(t)
+			m.db.EXPECT().GetUserByEmail(gomock.Any(), "jane@example.com").Return(user, nil)
+
+			err := m.service.ForgotPassword(context.TODO(), ForgotPasswordParams{Email: "jane@example.com"})
+			assert.NoError(t, err)
+		}
+	})
+}
+
+func TestService_ResetPassword(t *testing.T) {
+	resetToken := func(userID uuid.UUID) models.UserToken {
+		return models.UserToken{
+			ID:        uuid.New(),
+			UserID:    userID,
+			Purpose:   account.PurposePasswordReset,
+			Email:     sql.NullString{String: "jane@example.com", Valid: true},
+			ExpiresAt: fixedNow.Add(time.Hour),
+		}
+	}
+
+	t.Run("sets the new password and logs the user out everywhere", func(t *testing.T) {
+		m := newUserMocker(t)
+		user := activeUser()
+		token := resetToken(user.ID)
+
+		m.db.EXPECT().GetUserTokenByHash(gomock.Any(), generator.HashUserToken("plain-token")).Return(token, nil)
+		m.db.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		m.passwordHasher.EXPECT().Validate("hashed", "Tangerine-Kite-42").Return(false)
+		m.passwordHasher.EXPECT().Hash("Tangerine-Kite-42").Return("new-hash", nil)
+		m.db.EXPECT().MarkUserTokenUsed(gomock.Any(), token.ID).Return(int64(1), nil)
+		m.db.EXPECT().UpdateUserPassword(gomock.Any(), models.UpdateUserPasswordParams{
+			Password: "new-hash",
+			ID:       user.ID,
+		}).Return(nil)
+		m.db.EXPECT().RevokeUserSessions(gomock.Any(), models.RevokeUserSessionsParams{
+			UserID:        user.ID,
+			RevokedReason: sql.NullString{String: session.ReasonPasswordReset, Valid: true},
+		}).Return(int64(2), nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(
+			auditlog.ActionUserPasswordReset, user.ID, uuid.Nil,
+			auditlog.UserPasswordChangeMetadata{UserID: user.ID})).Return(nil)
+		m.mailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+
+		err := m.service.ResetPassword(context.TODO(), ResetPasswordParams{Token: "plain-token", NewPassword: "Tangerine-Kite-42"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects used, expired and other tokens", func(t *testing.T) {
+		user := activeUser()
+		used := resetToken(user.ID)
+		used.UsedAt = sql.NullTime{Time: fixedNow, Valid: true}
+		expired := resetToken(user.ID)
+		expired.ExpiresAt = fixedNow
+		verification := resetToken(user.ID)
+		verification.Purpose = PurposeEmailVerification
+
+		for _, token := range []models.UserToken{used, expired, verification} {
+			m := newUserMocker(t)
+			m.db.EXPECT().GetUserTokenByHash(gomock.Any(), gomock.Any()).Return(token, nil)
+
+			err := m.service.ResetPassword(context.TODO(), ResetPasswordParams{Token: "plain-token", NewPassword: "Tangerine-Kite-42"})
+			assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, "password reset link is invalid or has expired"), err)
+		}
+	})
+
+	t.Run("rejects a link sent to a previous email address", func(t *testing.T) {
+		m := newUserMocker(t)
+		user := activeUser()
+		token := resetToken(user.ID)
+		token.Email = sql.NullString{String: "old@example.com", Valid: true}
+
+		m.db.EXPECT().GetUserTokenByHash(gomock.Any(), gomock.Any()).Return(token, nil)
+		m.db.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+
+		err := m.service.ResetPassword(context.TODO(), ResetPasswordParams{Token: "plain-token", NewPassword: "Tangerine-Kite-42"})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, "password reset link is invalid or has expired"), err)
+	})
+
+	t.Run("keeps the token when the password breaks the policy", func(t *testing.T) {
+		m := newUserMocker(t)
+		user := activeUser()
+
+		m.db.EXPECT().GetUserTokenByHash(gomock.Any(), gomock.Any()).Return(resetToken(user.ID), nil)
+		m.db.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		m.passwordHasher.EXPECT().Validate("hashed", "short").Return(false)
+
+		err := m.service.ResetPassword(context.TODO(), ResetPasswordParams{Token: "plain-token", NewPassword: "short"})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, "password must be at least 12 characters long"), err)
+	})
+
+	t.Run("rejects the current password", func(t *testing.T) {
+		m := newUserMocker(t)
+		user := activeUser()
+
+		m.db.EXPECT().GetUserTokenByHash(gomock.Any(), gomock.Any()).Return(resetToken(user.ID), nil)
+		m.db.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		m.passwordHasher.EXPECT().Validate("hashed", "Tangerine-Kite-42").Return(true)
+
+		err := m.service.ResetPassword(context.TODO(), ResetPasswordParams{Token: "plain-token", NewPassword: "Tangerine-Kite-42"})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, "new password must be different from the current password"), err)
+	})
+
+	t.Run("fails when the token was used concurrently", func(t *testing.T) {
+		m := newUserMocker(t)
+		user := activeUser()
+		token := resetToken(user.ID)
+
+		m.db.EXPECT().GetUserTokenByHash(gomock.Any(), gomock.Any()).Return(token, nil)
+		m.db.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		m.passwordHasher.EXPECT().Validate("hashed", "Tangerine-Kite-42").Return(false)
+		m.passwordHasher.EXPECT().Hash("Tangerine-Kite-42").Return("new-hash", nil)
+		m.db.EXPECT().MarkUserTokenUsed(gomock.Any(), token.ID).Return(int64(0), nil)
+
+		err := m.service.ResetPassword(context.TODO(), ResetPasswordParams{Token: "plain-token", NewPassword: "Tangerine-Kite-42"})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, "password reset link is invalid or has expired"), err)
+	})
+}
+
+func TestService_VerifyEmail(t *testing.T) {
+	t.Run("confirms pending email", func(t *testing.T) {
+		m := newUserMocker(t)
+		user := activeUser()
+		token := models.UserToken{
+			ID:        uuid.New(),
+			UserID:    user.ID,
+			Purpose:   PurposeEmailVerification,
+			Email:     sql.NullString{String: "new@example.com", Valid: true},
+			ExpiresAt: fixedNow.Add(time.Hour),
+		}
+
+		m.db.EXPECT().GetUserTokenByHash(gomock.Any(), generator.HashUserToken("plain-token")).Return(token, nil)
+		m.db.EXPECT().MarkUserTokenUsed(gomock.Any(), token.ID).Return(int64(1), nil)
+		m.db.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		m.db.EXPECT().ConfirmPendingEmail(gomock.Any(), models.ConfirmPendingEmailParams{
+			ID:           user.ID,
+			PendingEmail: token.Email,
+		}).Return(int64(1), nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(
+			auditlog.ActionUserEmailChange, user.ID, uuid.Nil,
+			auditlog.UserEmailChangeMetadata{
+				UserID:   user.ID,
+				OldEmail: "jane@example.com",
+				NewEmail: "new@example.com",
+			})).Return(nil)
+
+		err := m.service.VerifyEmail(context.TODO(), VerifyEmailParams{Token: "plain-token"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("completes registration and opens account", func(t *testing.T) {
+		m := newUserMocker(t)
+		user := activeUser()
+		user.EmailVerifiedAt = sql.NullTime{}
+		token := models.UserToken{
+			ID:        uuid.New(),
+			UserID:    user.ID,
+			Purpose:   PurposeEmailVerification,
+			Email:     sql.NullString{String: user.Email, Valid: true},
+			ExpiresAt: fixedNow.Add(time.Hour),
+		}
+
+		m.db.EXPECT().GetUserTokenByHash(gomock.Any(), generator.HashUserToken("plain-token")).Return(token, nil)
+		m.db.EXPECT().MarkUserTokenUsed(gomock.Any(), token.ID).Return(int64(1), nil)
+		m.db.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		m.db.EXPECT().MarkEmailVerified(gomock.Any(), models.MarkEmailVerifiedParams{
+			ID:    user.ID,
+			Email: user.Email,
+		}).Return(int64(1), nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(
+			auditlog.ActionUserEmailVerified, user.ID, uuid.Nil,
+			auditlog.UserLifecycleMetadata{UserID: user.ID})).Return(nil)
+		m.accounts.EXPECT().OpenPendingAccount(gomock.Any(), user.ID).Return(nil)
+
+		err := m.service.VerifyEmail(context.TODO(), VerifyEmailParams{Token: "plain-token"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("completes registration when opening account fails", func(t *testing.T) {
+		m := newUserMocker(t)
+		user := activeUser()
+		user.EmailVerifiedAt = sql.NullTime{}
+		token := models.UserToken{
+			ID:        uuid.New(),
+			UserID:    user.ID,
+			Purpose:   PurposeEmailVerification,
+			Email:     sql.NullString{String: user.Email, Valid: true},
+			ExpiresAt: fixedNow.Add(time.Hour),
+		}
+
+		m.db.EXPECT().GetUserTokenByHash(gomock.Any(), gomock.Any()).Return(token, nil)
+		m.db.EXPECT().MarkUserTokenUsed(gomock.Any(), token.ID).Return(int64(1), nil)
+		m.db.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		m.db.EXPECT().MarkEmailVerified(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), gomock.Any()).Return(nil)
+		m.accounts.EXPECT().OpenPendingAccount(gomock.Any(), user.ID).Return(platformerrors.ErrInternal)
+
+		err := m.service.VerifyEmail(context.TODO(), VerifyEmailParams{Token: "plain-token"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects expired token", func(t *testing.T) {
+		m := newUserMocker(t)
+		m.db.EXPECT().GetUserTokenByHash(gomock.Any(), gomock.Any()).Return(models.UserToken{
+			ID:        uuid.New(),
+			Purpose:   PurposeEmailVerification,
+			ExpiresAt: fixedNow.Add(-time.Minute),
+		}, nil)
+
+		err := m.service.VerifyEmail(context.TODO(), VerifyEmailParams{Token: "plain-token"})
+		assert.Equal(t, errInvalidToken, err)
+	})
+
+	t.Run("rejects used token", func(t *testing.T) {
+		m := newUserMocker(t)
+		m.db.EXPECT().GetUserTokenByHash(gomock.Any(), gomock.Any()).Return(models.UserToken{
+			ID:        uuid.New(),
+			Purpose:   PurposeEmailVerification,
+			ExpiresAt: fixedNow.Add(time.Hour),
+			UsedAt:    sql.NullTime{Time: fixedNow, Valid: true},
+		}, nil)
+
+		err := m.service.VerifyEmail(context.TODO(), VerifyEmailParams{Token: "plain-token"})
+		assert.Equal(t, errInvalidToken, err)
+	})
+
+	t.Run("rejects unknown token", func(t *testing.T) {
+		m := newUserMocker(t)
+		m.db.EXPECT().GetUserTokenByHash(gomock.Any(), gomock.Any()).Return(models.UserToken{}, sql.ErrNoRows)
+
+		err := m.service.VerifyEmail(context.TODO(), VerifyEmailParams{Token: "nope"})
+		assert.Equal(t, errInvalidToken, err)
+	})
+
+	t.Run("reports conflict when email was taken meanwhile", func(t *testing.T) {
+		m := newUserMocker(t)
+		user := activeUser()
+		token := models.UserToken{
+			ID:        uuid.New(),
+			UserID:    user.ID,
+			Purpose:   PurposeEmailVerification,
+			Email:     sql.NullString{String: "new@example.com", Valid: true},
+			ExpiresAt: fixedNow.Add(time.Hour),
+		}
+
+		m.db.EXPECT().GetUserTokenByHash(gomock.Any(), gomock.Any()).Return(token, nil)
+		m.db.EXPECT().MarkUserTokenUsed(gomock.Any(), token.ID).Return(int64(1), nil)
+		m.db.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		m.db.EXPECT().ConfirmPendingEmail(gomock.Any(), gomock.Any()).
+			Return(int64(0), &pq.Error{Code: "23505", Constraint: "users_email_key"})
+
+		err := m.service.VerifyEmail(context.TODO(), VerifyEmailParams{Token: "plain-token"})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusConflict, "email is already in use"), err)
+	})
+}
+
+func TestService_DeactivateUser(t *testing.T) {
+	t.Run("deactivates user and suspends their accounts", func(t *testing.T) {
+		m := newUserMocker(t)
+		user := activeUser()
+		adminID := uuid.New()
+		current, dormant := uuid.New(), uuid.New()
+
+		m.db.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		m.db.EXPECT().DeactivateUser(gomock.Any(), user.ID).Return(int64(1), nil)
+		m.db.EXPECT().SuspendUserAccounts(gomock.Any(), user.ID).Return([]models.SuspendUserAccountsRow{
+			{AccountID: current, OldStatus: models.StatusACTIVE},
+			{AccountID: dormant, OldStatus: models.StatusDORMANT},
+		}, nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(
+			auditlog.ActionAccountStatusChange, adminID, current,
+			auditlog.AccountStatusChangeMetadata{OldStatus: "ACTIVE", NewStatus: "SUSPENDED"})).Return(nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(
+			auditlog.ActionAccountStatusChange, adminID, dormant,
+			auditlog.AccountStatusChangeMetadata{OldStatus: "DORMANT", NewStatus: "SUSPENDED"})).Return(nil)
+		m.db.EXPECT().InvalidateUserTokens(gomock.Any(), gomock.Any()).Return(nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(
+			auditlog.ActionUserDeactivated, adminID, uuid.Nil,
+			auditlog.UserLifecycleMetadata{
+				UserID:            user.ID,
+				Reason:            "fraud",
+				SuspendedAccounts: []uuid.UUID{current, dormant},
+			})).Return(nil)
+
+		err := m.service.DeactivateUser(context.TODO(), LifecycleParams{
+			Reason:  "fraud",
+			UserID:  user.ID,
+			ActorID: adminID,
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects deactivating yourself", func(t *testing.T) {
+		m := newUserMocker(t)
+		id := uuid.New()
+
+		err := m.service.DeactivateUser(context.TODO(), LifecycleParams{UserID: id, ActorID: id})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, "you cannot deactivate yourself"), err)
+	})
+
+	t.Run("rejects system users", func(t *testing.T) {
+		m := newUserMocker(t)
+
+		err := m.service.DeactivateUser(context.TODO(), LifecycleParams{UserID: systemUserID, ActorID: uuid.New()})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, "system users cannot be deactivated"), err)
+	})
+
+	t.Run("rejects already deactivated user", func(t *testing.T) {
+		m := newUserMocker(t)
+		user := activeUser()
+
+		m.db.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		m.db.EXPECT().DeactivateUser(gomock.Any(), user.ID).Return(int64(0), nil)
+
+		err := m.service.DeactivateUser(context.TODO(), LifecycleParams{UserID: user.ID, ActorID: uuid.New()})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusBadRequest, "user is already deactivated"), err)
+	})
+}
+
+func TestService_ReactivateUser(t *testing.T) {
+	t.Run("reactivates user", func(t *testing.T) {
+		m := newUserMocker(t)
+		user := activeUser()
+		adminID := uuid.New()
+
+		m.db.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		m.db.EXPECT().ReactivateUser(gomock.Any(), user.ID).Return(int64(1), nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(
+			auditlog.ActionUserReactivated, adminID, uuid.Nil,
+			auditlog.UserLifecycleMetadata{UserID: user.ID})).Return(nil)
+
+		err := m.service.ReactivateUser(context.TODO(), LifecycleParams{UserID: user.ID, ActorID: adminID})
+		assert.NoError(t, err)
+	})
+
+	t.Run("fails when user not found", func(t *testing.T) {
+		m := newUserMocker(t)
+		id := uuid.New()
+		m.db.EXPECT().GetUserByID(gomock.Any(), id).Return(models.GetUserByIDRow{}, sql.ErrNoRows)
+
+		err := m.service.ReactivateUser(context.TODO(), LifecycleParams{UserID: id, ActorID: uuid.New()})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusNotFound, "user not found"), err)
+	})
+}
+
+func TestService_SetTaxStatus(t *testing.T) {
+	t.Run("sets the tax status and audits old and new", func(t *testing.T) {
+		m := newUserMocker(t)
+		user := activeUser()
+		user.TaxResidency = sql.NullString{String: "GB", Valid: true}
+		adminID := uuid.New()
+
+		m.db.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		m.db.EXPECT().SetUserTaxStatus(gomock.Any(), models.SetUserTaxStatusParams{
+			TaxResidency: sql.NullString{String: "IE", Valid: true},
+			TaxExempt:    true,
+			ID:           user.ID,
+		}).Return(nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), auditlog.NewEvent(
+			auditlog.ActionUserTaxStatusChange, adminID, uuid.Nil,
+			auditlog.UserTaxStatusChangeMetadata{
+				UserID:       user.ID,
+				OldResidency: "GB",
+				NewResidency: "IE",
+				OldExempt:    false,
+				NewExempt:    true,
+			})).Return(nil)
+
+		updated, err := m.service.SetTaxStatus(context.TODO(), TaxStatusParams{
+			TaxResidency: "IE",
+			TaxExempt:    true,
+			UserID:       user.ID,
+			ActorID:      adminID,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "IE", updated.TaxResidency)
+		assert.True(t, updated.TaxExempt)
+	})
+
+	t.Run("clears an unknown residency", func(t *testing.T) {
+		m := newUserMocker(t)
+		user := activeUser()
+		user.TaxResidency = sql.NullString{String: "GB", Valid: true}
+
+		m.db.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		m.db.EXPECT().SetUserTaxStatus(gomock.Any(), models.SetUserTaxStatusParams{ID: user.ID}).Return(nil)
+		m.auditLog.EXPECT().Submit(gomock.Any(), gomock.Any()).Return(nil)
+
+		updated, err := m.service.SetTaxStatus(context.TODO(), TaxStatusParams{UserID: user.ID, ActorID: uuid.New()})
+		assert.NoError(t, err)
+		assert.Empty(t, updated.TaxResidency)
+	})
+
+	t.Run("fails when user not found", func(t *testing.T) {
+		m := newUserMocker(t)
+		id := uuid.New()
+		m.db.EXPECT().GetUserByID(gomock.Any(), id).Return(models.GetUserByIDRow{}, sql.ErrNoRows)
+
+		_, err := m.service.SetTaxStatus(context.TODO(), TaxStatusParams{UserID: id, ActorID: uuid.New()})
+		assert.Equal(t, platformerrors.MakeApiError(http.StatusNotFound, "user not found"), err)
+	})
+}
+
+func activeUser() models.GetUserByIDRow {
+	return models.GetUserByIDRow{
+		ID:              uuid.New(),
+		Email:           "jane@example.com",
+		Password:        "hashed",
+		FirstName:       "Jane",
+		LastName:        "Doe",
+		UserType:        models.UserTypeCUSTOMER,
+		EmailVerifiedAt: sql.NullTime{Time: fixedNow.AddDate(-1, 0, 0), Valid: true},
+	}
+}
+
+type userMocker struct {
+	db             *databasemocks.MockQuerier
+	auditLog       *auditlog.MockService
+	mailer         *mailermocks.MockMailer
+	tokenGenerator *generatormocks.MockUserTokenGenerator
+	passwordHasher *passwordhashermocks.MockHasher
+	accounts       *account.MockService
+
+	service Service
+}
+
+func newUserMocker(t *testing.T) *userMocker {
+	ctrl := gomock.NewController(t)
+	db := databasemocks.NewMockQuerier(ctrl)
+	auditLog := auditlog.NewMockService(ctrl)
+	mail := mailermocks.NewMockMailer(ctrl)
+	tokenGenerator := generatormocks.NewMockUserTokenGenerator(ctrl)
+	passwordHasher := passwordhashermocks.NewMockHasher(ctrl)
+	accounts := account.NewMockService(ctrl)
+
+	generator.DefaultUserTokenGenerator = tokenGenerator
+	password.DefaultPasswordHasher = passwordHasher
+
+	cfg := config.AppConfig{
+		SystemUserID:          systemUserID,
+		WebURL:                "https://app.test",
+		VerificationTokenTTL:  24 * time.Hour,
+		PasswordResetTokenTTL: time.Hour,
+		SignupOpensAccount:    true,
+	}
+	policy := password.Policy{
+		MinLength:      12,
+		RequireUpper:   true,
+		RequireLower:   true,
+		RequireDigit:   true,
+		RejectBreached: true,
+	}
+	svc := NewService(db, auditLog, mail, accounts, policy, cfg)
+	svc.(*service).now = func() time.Time { return fixedNow }
+	return &userMocker{
+		db:             db,
+		auditLog:       auditLog,
+		mailer:         mail,
+		tokenGenerator: tokenGenerator,
+		passwordHasher: passwordHasher,
+		accounts:       accounts,
+		service:        svc,
+	}
+}
@@ -0,0 +1,110 @@
+package user
+
+import (
+	"github.com/google/uuid"
+	"payter-bank/internal/database/models"
+	"time"
+)
+
+const (
+	// PurposeEmailVerification marks tokens sent to confirm ownership of an email address.
+	PurposeEmailVerification = "email_verification"
+)
+
+type User struct {
+	UserID        uuid.UUID  `json:"user_id"`
+	Email         string     `json:"email"`
+	PendingEmail  string     `json:"pending_email,omitempty"`
+	FirstName     string     `json:"first_name"`
+	LastName      string     `json:"last_name"`
+	UserType      string     `json:"user_type"`
+	EmailVerified bool       `json:"email_verified"`
+	Active        bool       `json:"active"`
+	TaxResidency  string     `json:"tax_residency,omitempty"`
+	TaxExempt     bool       `json:"tax_exempt"`
+	DeactivatedAt *time.Time `json:"deactivated_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+func UserFromRow(row models.GetUserByIDRow) User {
+	user := User{
+		UserID:        row.ID,
+		Email:         row.Email,
+		PendingEmail:  row.PendingEmail.String,
+		FirstName:     row.FirstName,
+		LastName:      row.LastName,
+		UserType:      string(row.UserType),
+		EmailVerified: row.EmailVerifiedAt.Valid,
+		Active:        !row.DeletedAt.Valid,
+		TaxResidency:  row.TaxResidency.String,
+		TaxExempt:     row.TaxExempt,
+		CreatedAt:     row.CreatedAt.Time,
+	}
+	if row.DeletedAt.Valid {
+		user.DeactivatedAt = &row.DeletedAt.Time
+	}
+	return user
+}
+
+// UpdateProfileParams holds the fields to change, empty fields are left as they are.
+// A new email is only applied once it has been verified.
+type UpdateProfileParams struct {
+	FirstName string    `json:"first_name" binding:"omitempty,max=255"`
+	LastName  string    `json:"last_name" binding:"omitempty,max=255"`
+	Email     string    `json:"email" binding:"omitempty,email"`
+	UserID    uuid.UUID `json:"-"`
+	ActorID   uuid.UUID `json:"-"`
+}
+
+type ChangePasswordParams struct {
+	CurrentPassword string    `json:"current_password" binding:"required"`
+	NewPassword     string    `json:"new_password" binding:"required,min=8"`
+	UserID          uuid.UUID `json:"-"`
+	SessionID       uuid.UUID `json:"-"` // the session the password is changed from, it stays logged in
+}
+
+type RegisterParams struct {
+	FirstName string `json:"first_name" binding:"required"`
+	LastName  string `json:"last_name" binding:"required"`
+	Email     string `json:"email" binding:"required,email"`
+	Password  string `json:"password" binding:"required,min=8"`
+}
+
+type RegisterResponse struct {
+	UserID uuid.UUID `json:"user_id"`
+}
+
+type ResendVerificationParams struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ForgotPasswordParams struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ResetPasswordParams sets a new password with a token that was emailed to the user, or issued on
+// login when the password must be changed.
+type ResetPasswordParams struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=8"`
+}
+
+type VerifyEmailParams struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type LifecycleParams struct {
+	Reason  string    `json:"reason"`
+	UserID  uuid.UUID `json:"-"`
+	ActorID uuid.UUID `json:"-"`
+}
+
+// TaxStatusParams replaces the tax status of a user. Tax is withheld from interest at the rate of
+// the residency, an ISO 3166-1 alpha-2 code, or at the default rate when it is left empty. No tax
+// is withheld from an exempt user.
+type TaxStatusParams struct {
+	TaxResidency string    `json:"tax_residency" binding:"omitempty,iso3166_1_alpha2"`
+	TaxExempt    bool      `json:"tax_exempt"`
+	UserID       uuid.UUID `json:"-"`
+	ActorID      uuid.UUID `json:"-"`
+}
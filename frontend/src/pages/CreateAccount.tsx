@@ -38,7 +38,7 @@ export const CreateAccount: React.FC = () => {
       user_type: formData.user_role,
     }
 
-    const createUserResult = await httpClient.post('users', createUserPayload, {
+    const createUserResult = await httpClient.post('admin/users', createUserPayload, {
         headers: {
           'Content-Type': 'application/json',
           'Authorization': `Bearer ${user?.token}`,
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"github.com/gin-gonic/gin"
+	"mime"
 	"net/http"
 )
 
@@ -40,6 +41,12 @@ func Wrap(handler HttpHandler) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		resp := handler(ctx)
 
+		if file, ok := resp.Data.(File); ok {
+			ctx.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.Name}))
+			ctx.Data(resp.Code, file.ContentType, file.Content)
+			return
+		}
+
 		data, err := resp.Marshal()
 		if err != nil {
 			ctx.JSON(http.StatusInternalServerError, ErrorResponse{
@@ -57,6 +64,13 @@ type SuccessResponse struct {
 	Message string      `json:"message,omitempty"`
 }
 
+// File is a document sent as a download rather than as JSON.
+type File struct {
+	Name        string
+	ContentType string
+	Content     []byte
+}
+
 type ErrorResponse struct {
 	Error string `json:"error"`
 }
@@ -151,3 +165,23 @@ func OK(message string, data interface{}) Response {
 		},
 	}
 }
+
+// Accepted is returned when the request was taken but the action has not happened yet,
+// e.g. it is waiting for approval.
+func Accepted(message string, data interface{}) Response {
+	return Response{
+		Code: http.StatusAccepted,
+		Data: SuccessResponse{
+			Data:    data,
+			Message: message,
+		},
+	}
+}
+
+// Attachment is returned when the response is a file to download, e.g. a PDF.
+func Attachment(file File) Response {
+	return Response{
+		Code: http.StatusOK,
+		Data: file,
+	}
+}
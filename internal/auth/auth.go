@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"errors"
 	jwtmiddleware "github.com/auth0/go-jwt-middleware/v2"
 	"github.com/auth0/go-jwt-middleware/v2/validator"
@@ -12,29 +13,49 @@ import (
 
 var ProfileKey = "current_profile"
 
+// APIKeyIDKey holds the id of the API key a request was authenticated with, it is unset for
+// requests authenticated with a JWT.
+var APIKeyIDKey = "api_key_id"
+
 type Profile struct {
-	AccountID    uuid.UUID `json:"account_id"`
-	UserID       uuid.UUID `json:"user_id"`
-	Email        string    `json:"email"`
-	FirstName    string    `json:"first_name"`
-	LastName     string    `json:"last_name"`
-	AccountType  string    `json:"account_type"`
-	UserType     string    `json:"user_type"`
-	RegisteredAt time.Time `json:"registered_at"`
+	AccountID    uuid.UUID    `json:"account_id"`
+	UserID       uuid.UUID    `json:"user_id"`
+	Email        string       `json:"email"`
+	FirstName    string       `json:"first_name"`
+	LastName     string       `json:"last_name"`
+	AccountType  string       `json:"account_type"`
+	UserType     string       `json:"user_type"`
+	RegisteredAt time.Time    `json:"registered_at"`
+	Permissions  []Permission `json:"permissions,omitempty"`
+	APIKeyID     uuid.UUID    `json:"-"`
+}
+
+// IsServiceAccount reports whether the request was made by a service account with an API key.
+func (p Profile) IsServiceAccount() bool {
+	return p.APIKeyID != uuid.Nil
 }
 
 func GetTokenData(ctx *gin.Context) (generator.TokenData, error) {
+	claim, err := GetClaim(ctx)
+	if err != nil {
+		return generator.TokenData{}, err
+	}
+	return claim.TokenData, nil
+}
+
+// GetClaim returns the validated claims of the access token, including its jti and expiry.
+func GetClaim(ctx *gin.Context) (*generator.Claim, error) {
 	claims, ok := ctx.Request.Context().Value(jwtmiddleware.ContextKey{}).(*validator.ValidatedClaims)
 	if !ok {
-		return generator.TokenData{}, errors.New("could not get claims from context")
+		return nil, errors.New("could not get claims from context")
 	}
 
 	customClaims, ok := claims.CustomClaims.(*generator.Claim)
 	if !ok {
-		return generator.TokenData{}, errors.New("could not get custom claims from context")
+		return nil, errors.New("could not get custom claims from context")
 	}
 
-	return customClaims.TokenData, nil
+	return customClaims, nil
 }
 
 func GetCurrentProfile(ctx *gin.Context) (Profile, error) {
@@ -44,3 +65,9 @@ func GetCurrentProfile(ctx *gin.Context) (Profile, error) {
 	}
 	return profile, nil
 }
+
+// APIKeyID returns the id of the API key the request was authenticated with, or uuid.Nil.
+func APIKeyID(ctx context.Context) uuid.UUID {
+	id, _ := ctx.Value(APIKeyIDKey).(uuid.UUID)
+	return id
+}
@@ -96,3 +96,11 @@ func TestGetCurrentProfile_MissingProfile(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, Profile{}, profile)
 }
+
+func TestProfile_HasPermission(t *testing.T) {
+	profile := Profile{Permissions: []Permission{PermAccountsRead, PermUsersRead}}
+
+	assert.True(t, profile.HasPermission(PermAccountsRead))
+	assert.False(t, profile.HasPermission(PermTransactionsWrite))
+	assert.False(t, Profile{}.HasPermission(PermAccountsRead))
+}
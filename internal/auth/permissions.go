@@ -0,0 +1,37 @@
+package auth
+
+import "slices"
+
+// Permission is the name of a permission in the permissions table. Roles are sets of permissions,
+// they are stored in the database and given to admin users.
+type Permission string
+
+const (
+	PermAccountsRead          Permission = "accounts:read"
+	PermAccountsManage        Permission = "accounts:manage"
+	PermTransactionsRead      Permission = "transactions:read"
+	PermTransactionsWrite     Permission = "transactions:write"
+	PermInterestRead          Permission = "interest:read"
+	PermInterestWrite         Permission = "interest:write"
+	PermUsersRead             Permission = "users:read"
+	PermUsersManage           Permission = "users:manage"
+	PermUsersCreate           Permission = "users:create"
+	PermRolesManage           Permission = "roles:manage"
+	PermAuditRead             Permission = "audit:read"
+	PermApprovalsRead         Permission = "approvals:read"
+	PermApprovalsDecide       Permission = "approvals:decide"
+	PermServiceAccountsManage Permission = "service_accounts:manage"
+	PermOAuthClientsManage    Permission = "oauth_clients:manage"
+)
+
+// RoleSuperAdmin has every permission, it is given to the admin created on startup.
+const RoleSuperAdmin = "super_admin"
+
+func (p Permission) String() string {
+	return string(p)
+}
+
+// HasPermission reports whether one of the user's roles grants permission.
+func (p Profile) HasPermission(permission Permission) bool {
+	return slices.Contains(p.Permissions, permission)
+}
@@ -2,15 +2,58 @@ package config
 
 import (
 	"github.com/google/uuid"
+	"net/url"
 	"time"
 )
 
 type Config struct {
-	DB     DBConfig
-	App    AppConfig
-	Server ServerConfig
-	JWT    JWTConfig
-	Redis  RedisConfig
+	DB        DBConfig
+	App       AppConfig
+	Server    ServerConfig
+	JWT       JWTConfig
+	Redis     RedisConfig
+	Bank      BankConfig
+	Dormancy  DormancyConfig
+	Mail      MailConfig
+	MFA       MFAConfig
+	Lockout   LockoutConfig
+	Approval  ApprovalConfig
+	Password  PasswordConfig
+	OAuth     OAuthConfig
+	Interest  InterestConfig
+	Scheduler SchedulerConfig
+}
+
+// Redacted is the config with its passwords and secrets masked, it is what gets logged.
+func (c Config) Redacted() Config {
+	c.App.AdminPassword = redact(c.App.AdminPassword)
+	c.JWT.Secret = redact(c.JWT.Secret)
+	c.DB.Password = redact(c.DB.Password)
+	c.DB.DSN = redactDSN(c.DB.DSN)
+	c.Mail.SMTPPassword = redact(c.Mail.SMTPPassword)
+	return c
+}
+
+const redacted = "[REDACTED]"
+
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return redacted
+}
+
+// redactDSN masks the password of a URL DSN and keeps where it points to. A key=value DSN is masked
+// as a whole as its password cannot be picked out reliably.
+func redactDSN(dsn string) string {
+	u, err := url.Parse(dsn)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return redact(dsn)
+	}
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), redacted)
+	}
+	return u.String()
 }
 
 type ServerConfig struct {
@@ -18,21 +61,35 @@ type ServerConfig struct {
 	EnableSwagger   bool          `env:"ENABLE_SWAGGER, default=true"`
 	ShutdownTimeout time.Duration `env:"SERVER_SHUTDOWN_TIMEOUT, default=5s"`
 	CorsOrigin      string        `env:"CORS_ORIGIN, default=http://localhost:5173"` // set to VITE default URL
+	TrustedProxies  []string      `env:"TRUSTED_PROXIES"`                            // proxies allowed to set X-Forwarded-For
 }
 
 type AppConfig struct {
-	AdminEmail            string    `env:"ADMIN_EMAIL, default=admin@payterbank.app"`
-	AdminPassword         string    `env:"ADMIN_PASSWORD, default=admin"`
-	Environment           string    `env:"ENVIRONMENT, default=dev"`
-	QueueConcurrency      int       `env:"QUEUE_CONCURRENCY, default=10"`
-	InterestRateAccountID uuid.UUID `env:"INTEREST_RATE_ACCOUNT_ID, default=00000000-1111-1111-1111-000000000000"`
+	AdminEmail            string        `env:"ADMIN_EMAIL, default=admin@payterbank.app"`
+	AdminPassword         string        `env:"ADMIN_PASSWORD, default=admin"`
+	Environment           string        `env:"ENVIRONMENT, default=dev"`
+	QueueConcurrency      int           `env:"QUEUE_CONCURRENCY, default=10"`
+	InterestRateAccountID uuid.UUID     `env:"INTEREST_RATE_ACCOUNT_ID, default=00000000-1111-1111-1111-000000000000"`
+	TaxUserID             uuid.UUID     `env:"TAX_USER_ID, default=00000000-3333-3333-3333-000000000000"`    // holds a tax account per currency, tax withheld from interest is paid into it
+	SystemUserID          uuid.UUID     `env:"SYSTEM_USER_ID, default=00000000-2222-2222-2222-000000000000"` // actor recorded for scheduled jobs
+	WebURL                string        `env:"WEB_URL, default=http://localhost:5173"`                       // base URL of links sent in emails
+	VerificationTokenTTL  time.Duration `env:"VERIFICATION_TOKEN_TTL, default=24h"`
+	PasswordResetTokenTTL time.Duration `env:"PASSWORD_RESET_TOKEN_TTL, default=1h"`
+	SignupOpensAccount    bool          `env:"SIGNUP_OPENS_ACCOUNT, default=true"` // open a PENDING GBP account once a new customer verifies their email
 }
 
+// JWTConfig sets how access tokens are signed. Tokens are signed with the key in SigningKeyFile,
+// VerificationKeyFiles are previous or upcoming keys that tokens are also accepted from. Secret is
+// the HS256 secret, tokens are signed with it when no signing key is set and tokens without a kid
+// are accepted while it is set.
 type JWTConfig struct {
-	Secret   string        `env:"JWT_SECRET, required"`
-	Expiry   time.Duration `env:"JWT_EXPIRY, default=24h"`
-	Issuer   string        `env:"JWT_ISSUER, default=payter-bank"`
-	Audience string        `env:"JWT_AUDIENCE, default=payter-bank"`
+	Secret               string        `env:"JWT_SECRET"`
+	SigningKeyFile       string        `env:"JWT_SIGNING_KEY_FILE"`
+	VerificationKeyFiles []string      `env:"JWT_VERIFICATION_KEY_FILES"`
+	Expiry               time.Duration `env:"JWT_EXPIRY, default=15m"`
+	RefreshExpiry        time.Duration `env:"JWT_REFRESH_EXPIRY, default=720h"` // refresh tokens rotate, each one is valid this long
+	Issuer               string        `env:"JWT_ISSUER, default=payter-bank"`
+	Audience             string        `env:"JWT_AUDIENCE, default=payter-bank"`
 }
 
 type DBConfig struct {
@@ -47,3 +104,84 @@ type DBConfig struct {
 type RedisConfig struct {
 	Addr string `env:"REDIS_ADDR"`
 }
+
+type BankConfig struct {
+	SortCode    string `env:"BANK_SORT_CODE, default=040004"`
+	BankCode    string `env:"BANK_CODE, default=PAYT"`
+	CountryCode string `env:"BANK_COUNTRY_CODE, default=GB"`
+}
+
+type DormancyConfig struct {
+	Period   time.Duration `env:"DORMANCY_PERIOD, default=8760h"`       // no customer activity for this long marks an account dormant
+	Schedule string        `env:"DORMANCY_SCHEDULE, default=0 2 * * *"` // cron expression for the detection job
+}
+
+type MailConfig struct {
+	Driver       string `env:"MAIL_DRIVER, default=log"` // log, smtp or file
+	From         string `env:"MAIL_FROM, default=no-reply@payterbank.app"`
+	SMTPHost     string `env:"SMTP_HOST, default=localhost"`
+	SMTPPort     string `env:"SMTP_PORT, default=1025"`
+	SMTPUsername string `env:"SMTP_USERNAME"`
+	SMTPPassword string `env:"SMTP_PASSWORD"`
+	FileDir      string `env:"MAIL_FILE_DIR, default=./mail"` // where the file driver writes emails
+}
+
+type MFAConfig struct {
+	Issuer            string        `env:"MFA_ISSUER, default=PayterBank"` // shown in authenticator apps
+	ChallengeTTL      time.Duration `env:"MFA_CHALLENGE_TTL, default=5m"`  // time allowed to enter a code after the password
+	MaxAttempts       int32         `env:"MFA_MAX_ATTEMPTS, default=5"`    // wrong codes allowed per login challenge
+	StepUpWindow      time.Duration `env:"MFA_STEP_UP_WINDOW, default=5m"` // how recent a code must be for high-risk actions
+	RequiredForAdmins bool          `env:"MFA_REQUIRED_FOR_ADMINS, default=true"`
+}
+
+type LockoutConfig struct {
+	Store         string        `env:"LOCKOUT_STORE, default=redis"`        // redis or memory
+	MaxFailures   int64         `env:"LOCKOUT_MAX_FAILURES, default=5"`     // failed logins per email before it is locked
+	MaxIPFailures int64         `env:"LOCKOUT_MAX_IP_FAILURES, default=20"` // failed logins per client IP before it is locked
+	Window        time.Duration `env:"LOCKOUT_WINDOW, default=15m"`         // failures older than this are forgotten
+	BaseDuration  time.Duration `env:"LOCKOUT_BASE_DURATION, default=1m"`   // first lockout, doubled for every lockout after it
+	MaxDuration   time.Duration `env:"LOCKOUT_MAX_DURATION, default=24h"`   // longest lockout
+	HistoryExpiry time.Duration `env:"LOCKOUT_HISTORY_EXPIRY, default=24h"` // previous lockouts stop counting towards the backoff after this
+}
+
+// ApprovalConfig sets which admin operations need a second admin to approve them, see features/approval.
+type ApprovalConfig struct {
+	Operations      []string      `env:"APPROVAL_OPERATIONS, default=credit,debit,reversal,interest_rate_create,interest_rate_update,interest_frequency_update,interest_rate_card_create,interest_rate_card_update,interest_rate_card_delete,interest_rate_override_create,interest_rate_override_update,interest_rate_override_delete,admin_create"`
+	CreditThreshold float64       `env:"APPROVAL_CREDIT_THRESHOLD, default=10000"` // credits above this amount need approval
+	DebitThreshold  float64       `env:"APPROVAL_DEBIT_THRESHOLD, default=10000"`  // debits above this amount need approval
+	Expiry          time.Duration `env:"APPROVAL_EXPIRY, default=24h"`             // pending requests expire after this
+}
+
+// PasswordConfig sets the rules new passwords are checked against, see internal/pkg/password.
+type PasswordConfig struct {
+	MinLength      int  `env:"PASSWORD_MIN_LENGTH, default=12"`
+	RequireUpper   bool `env:"PASSWORD_REQUIRE_UPPER, default=true"`
+	RequireLower   bool `env:"PASSWORD_REQUIRE_LOWER, default=true"`
+	RequireDigit   bool `env:"PASSWORD_REQUIRE_DIGIT, default=true"`
+	RequireSymbol  bool `env:"PASSWORD_REQUIRE_SYMBOL, default=false"`
+	RejectBreached bool `env:"PASSWORD_REJECT_BREACHED, default=true"` // reject passwords on the bundled breached-password list
+}
+
+// OAuthConfig sets up the OAuth 2.0 authorization server partner apps use, see features/oauth.
+// BaseURL is where this API is reached, the endpoints in the discovery document are built from it.
+type OAuthConfig struct {
+	BaseURL string        `env:"OAUTH_BASE_URL, default=http://localhost:2025"`
+	CodeTTL time.Duration `env:"OAUTH_CODE_TTL, default=5m"` // time allowed to exchange an authorization code
+}
+
+// InterestConfig sets up interest accrual, see features/interestrate and internal/pkg/interest.
+// Interest for a day is accrued when the schedule first runs after it.
+type InterestConfig struct {
+	Schedule           string            `env:"INTEREST_SCHEDULE, default=5 0 * * *"`                // cron expression for the accrual and capitalisation job
+	DayCount           string            `env:"INTEREST_DAY_COUNT, default=ACT/365"`                 // day-count convention: ACT/365, ACT/360 or 30/360
+	DayCountByCurrency map[string]string `env:"INTEREST_DAY_COUNT_BY_CURRENCY, default=EUR:ACT/360"` // conventions of currencies that differ from INTEREST_DAY_COUNT
+	Rounding           string            `env:"INTEREST_ROUNDING, default=half_even"`                // rounding of interest paid: half_even, half_up or down
+	TaxRates           map[string]string `env:"INTEREST_TAX_RATES, default=GB:2000"`                 // withholding tax in basis points by tax residency (ISO 3166-1 alpha-2)
+	TaxDefaultRate     int64             `env:"INTEREST_TAX_DEFAULT_RATE, default=2000"`             // withholding tax of customers whose residency is unknown or not listed
+}
+
+// SchedulerConfig sets up the scheduler of background jobs, see internal/scheduler.
+type SchedulerConfig struct {
+	LockKey     int64         `env:"SCHEDULER_LOCK_KEY, default=7301"`    // Postgres advisory lock held by the replica that runs jobs
+	StopTimeout time.Duration `env:"SCHEDULER_STOP_TIMEOUT, default=30s"` // time running jobs get to finish on shutdown
+}
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestConfig_Redacted(t *testing.T) {
+	cfg := Config{
+		App:  AppConfig{AdminEmail: "admin@payterbank.app", AdminPassword: "admin"},
+		JWT:  JWTConfig{Secret: "jwt-secret", Issuer: "payter-bank"},
+		DB:   DBConfig{Host: "db", Password: "db-password", DSN: "postgres://payter:db-password@db:5432/payter?sslmode=disable"},
+		Mail: MailConfig{SMTPUsername: "mailer", SMTPPassword: "smtp-password"},
+	}
+
+	redactedCfg := cfg.Redacted()
+
+	assert.Equal(t, "[REDACTED]", redactedCfg.App.AdminPassword)
+	assert.Equal(t, "[REDACTED]", redactedCfg.JWT.Secret)
+	assert.Equal(t, "[REDACTED]", redactedCfg.DB.Password)
+	assert.Equal(t, "postgres://payter:%5BREDACTED%5D@db:5432/payter?sslmode=disable", redactedCfg.DB.DSN)
+	assert.Equal(t, "[REDACTED]", redactedCfg.Mail.SMTPPassword)
+	// everything else is logged as it is and the config itself is left alone
+	assert.Equal(t, "admin@payterbank.app", redactedCfg.App.AdminEmail)
+	assert.Equal(t, "mailer", redactedCfg.Mail.SMTPUsername)
+	assert.Equal(t, "smtp-password", cfg.Mail.SMTPPassword)
+
+	assert.Equal(t, "[REDACTED]", redactDSN("host=db user=payter password=secret"))
+	assert.Empty(t, Config{}.Redacted().Mail.SMTPPassword)
+}
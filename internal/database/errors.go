@@ -0,0 +1,18 @@
+package database
+
+import (
+	"errors"
+	"github.com/lib/pq"
+)
+
+const uniqueViolationCode = "23505"
+
+// IsUniqueViolation reports whether err was caused by the given unique constraint.
+// An empty constraint matches any unique violation.
+func IsUniqueViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	return pqErr.Code == uniqueViolationCode && (constraint == "" || pqErr.Constraint == constraint)
+}
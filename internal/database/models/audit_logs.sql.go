@@ -86,6 +86,7 @@ SELECT
         WHEN 'account_credit' THEN 'Credited Account'
         WHEN 'account_debit' THEN 'Debited Account'
         WHEN 'account_status_change' THEN COALESCE(al.metadata->>'new_status', '') || ' Account'
+        WHEN 'interest_applied' THEN 'Applied Interest'
         ELSE al.action -- Keep the original action if not one of the defined ones
         END AS action,
     COALESCE(al.metadata->>'old_status', '')::varchar AS old_status,
@@ -155,8 +156,8 @@ func (q *Queries) GetAuditLogsForAccount(ctx context.Context, affectedAccountID
 
 const saveAuditLog = `-- name: SaveAuditLog :exec
 INSERT INTO audit_logs(
-    user_id, affected_account_id, action, metadata
-) VALUES ($1, $2, $3, $4)
+    user_id, affected_account_id, action, metadata, api_key_id
+) VALUES ($1, $2, $3, $4, $5)
 `
 
 type SaveAuditLogParams struct {
@@ -164,6 +165,7 @@ type SaveAuditLogParams struct {
 	AffectedAccountID uuid.NullUUID         `json:"affected_account_id"`
 	Action            string                `json:"action"`
 	Metadata          pqtype.NullRawMessage `json:"metadata"`
+	ApiKeyID          uuid.NullUUID         `json:"api_key_id"`
 }
 
 func (q *Queries) SaveAuditLog(ctx context.Context, arg SaveAuditLogParams) error {
@@ -172,6 +174,7 @@ func (q *Queries) SaveAuditLog(ctx context.Context, arg SaveAuditLogParams) erro
 		arg.AffectedAccountID,
 		arg.Action,
 		arg.Metadata,
+		arg.ApiKeyID,
 	)
 	return err
 }
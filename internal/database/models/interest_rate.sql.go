@@ -7,12 +7,69 @@ package models
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+const cancelInterestRate = `-- name: CancelInterestRate :exec
+UPDATE interest_rates
+    SET deleted_at = CURRENT_TIMESTAMP
+    WHERE id = $1
+`
+
+func (q *Queries) CancelInterestRate(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, cancelInterestRate, id)
+	return err
+}
+
+const closeInterestRate = `-- name: CloseInterestRate :exec
+UPDATE interest_rates
+    SET effective_to = $2, updated_at = CURRENT_TIMESTAMP
+    WHERE id = $1
+`
+
+type CloseInterestRateParams struct {
+	ID          uuid.UUID    `json:"id"`
+	EffectiveTo sql.NullTime `json:"effective_to"`
+}
+
+func (q *Queries) CloseInterestRate(ctx context.Context, arg CloseInterestRateParams) error {
+	_, err := q.db.ExecContext(ctx, closeInterestRate, arg.ID, arg.EffectiveTo)
+	return err
+}
+
+const getInterestRateAt = `-- name: GetInterestRateAt :one
+SELECT id, rate, calculation_frequency, created_at, updated_at, deleted_at, effective_from, effective_to, created_by FROM interest_rates
+    WHERE deleted_at IS NULL
+        AND effective_from <= $1::date
+        AND (effective_to IS NULL OR effective_to > $1::date)
+    ORDER BY effective_from DESC, created_at DESC
+    LIMIT 1
+`
+
+func (q *Queries) GetInterestRateAt(ctx context.Context, day time.Time) (InterestRate, error) {
+	row := q.db.QueryRowContext(ctx, getInterestRateAt, day)
+	var i InterestRate
+	err := row.Scan(
+		&i.ID,
+		&i.Rate,
+		&i.CalculationFrequency,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.EffectiveFrom,
+		&i.EffectiveTo,
+		&i.CreatedBy,
+	)
+	return i, err
+}
+
 const getInterestRates = `-- name: GetInterestRates :many
-SELECT id, rate, calculation_frequency, created_at, updated_at, deleted_at FROM interest_rates ORDER BY created_at DESC LIMIT 1
+SELECT id, rate, calculation_frequency, created_at, updated_at, deleted_at, effective_from, effective_to, created_by FROM interest_rates
+    WHERE deleted_at IS NULL
+    ORDER BY effective_from DESC, created_at DESC
 `
 
 func (q *Queries) GetInterestRates(ctx context.Context) ([]InterestRate, error) {
@@ -31,6 +88,9 @@ func (q *Queries) GetInterestRates(ctx context.Context) ([]InterestRate, error)
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.EffectiveFrom,
+			&i.EffectiveTo,
+			&i.CreatedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -47,17 +107,26 @@ func (q *Queries) GetInterestRates(ctx context.Context) ([]InterestRate, error)
 
 const saveInterestRate = `-- name: SaveInterestRate :one
 INSERT INTO interest_rates(
-    rate, calculation_frequency
-) VALUES ($1, $2) RETURNING id, rate, calculation_frequency, created_at, updated_at, deleted_at
+    rate, calculation_frequency, effective_from, effective_to, created_by
+) VALUES ($1, $2, $3, $4, $5) RETURNING id, rate, calculation_frequency, created_at, updated_at, deleted_at, effective_from, effective_to, created_by
 `
 
 type SaveInterestRateParams struct {
-	Rate                 int64  `json:"rate"`
-	CalculationFrequency string `json:"calculation_frequency"`
+	Rate                 int64         `json:"rate"`
+	CalculationFrequency string        `json:"calculation_frequency"`
+	EffectiveFrom        time.Time     `json:"effective_from"`
+	EffectiveTo          sql.NullTime  `json:"effective_to"`
+	CreatedBy            uuid.NullUUID `json:"created_by"`
 }
 
 func (q *Queries) SaveInterestRate(ctx context.Context, arg SaveInterestRateParams) (InterestRate, error) {
-	row := q.db.QueryRowContext(ctx, saveInterestRate, arg.Rate, arg.CalculationFrequency)
+	row := q.db.QueryRowContext(ctx, saveInterestRate,
+		arg.Rate,
+		arg.CalculationFrequency,
+		arg.EffectiveFrom,
+		arg.EffectiveTo,
+		arg.CreatedBy,
+	)
 	var i InterestRate
 	err := row.Scan(
 		&i.ID,
@@ -66,38 +135,25 @@ func (q *Queries) SaveInterestRate(ctx context.Context, arg SaveInterestRatePara
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.EffectiveFrom,
+		&i.EffectiveTo,
+		&i.CreatedBy,
 	)
 	return i, err
 }
 
-const updateCalculationFrequency = `-- name: UpdateCalculationFrequency :exec
+const updateScheduledCalculationFrequency = `-- name: UpdateScheduledCalculationFrequency :exec
 UPDATE interest_rates
     SET calculation_frequency = $1, updated_at = CURRENT_TIMESTAMP
-    WHERE id = $2 RETURNING id, rate, calculation_frequency, created_at, updated_at, deleted_at
+    WHERE deleted_at IS NULL AND effective_from > $2::date
 `
 
-type UpdateCalculationFrequencyParams struct {
+type UpdateScheduledCalculationFrequencyParams struct {
 	CalculationFrequency string    `json:"calculation_frequency"`
-	ID                   uuid.UUID `json:"id"`
-}
-
-func (q *Queries) UpdateCalculationFrequency(ctx context.Context, arg UpdateCalculationFrequencyParams) error {
-	_, err := q.db.ExecContext(ctx, updateCalculationFrequency, arg.CalculationFrequency, arg.ID)
-	return err
-}
-
-const updateRate = `-- name: UpdateRate :exec
-UPDATE interest_rates
-    SET rate = $1, updated_at = CURRENT_TIMESTAMP
-    WHERE id = $2 RETURNING id, rate, calculation_frequency, created_at, updated_at, deleted_at
-`
-
-type UpdateRateParams struct {
-	Rate int64     `json:"rate"`
-	ID   uuid.UUID `json:"id"`
+	After                time.Time `json:"after"`
 }
 
-func (q *Queries) UpdateRate(ctx context.Context, arg UpdateRateParams) error {
-	_, err := q.db.ExecContext(ctx, updateRate, arg.Rate, arg.ID)
+func (q *Queries) UpdateScheduledCalculationFrequency(ctx context.Context, arg UpdateScheduledCalculationFrequencyParams) error {
+	_, err := q.db.ExecContext(ctx, updateScheduledCalculationFrequency, arg.CalculationFrequency, arg.After)
 	return err
 }
@@ -11,8 +11,10 @@ package databasemocks
 
 import (
 	context "context"
+	sql "database/sql"
 	models "payter-bank/internal/database/models"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
@@ -42,303 +44,2078 @@ func (m *MockQuerier) EXPECT() *MockQuerierMockRecorder {
 	return m.recorder
 }
 
+// CancelInterestRate mocks base method.
+func (m *MockQuerier) CancelInterestRate(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelInterestRate", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelInterestRate indicates an expected call of CancelInterestRate.
+func (mr *MockQuerierMockRecorder) CancelInterestRate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelInterestRate", reflect.TypeOf((*MockQuerier)(nil).CancelInterestRate), ctx, id)
+}
+
+// CapitaliseInterestAccruals mocks base method.
+func (m *MockQuerier) CapitaliseInterestAccruals(ctx context.Context, arg models.CapitaliseInterestAccrualsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CapitaliseInterestAccruals", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CapitaliseInterestAccruals indicates an expected call of CapitaliseInterestAccruals.
+func (mr *MockQuerierMockRecorder) CapitaliseInterestAccruals(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CapitaliseInterestAccruals", reflect.TypeOf((*MockQuerier)(nil).CapitaliseInterestAccruals), ctx, arg)
+}
+
+// CheckSessionDevice mocks base method.
+func (m *MockQuerier) CheckSessionDevice(ctx context.Context, arg models.CheckSessionDeviceParams) (models.CheckSessionDeviceRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckSessionDevice", ctx, arg)
+	ret0, _ := ret[0].(models.CheckSessionDeviceRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckSessionDevice indicates an expected call of CheckSessionDevice.
+func (mr *MockQuerierMockRecorder) CheckSessionDevice(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSessionDevice", reflect.TypeOf((*MockQuerier)(nil).CheckSessionDevice), ctx, arg)
+}
+
+// ClaimInterestRunAccount mocks base method.
+func (m *MockQuerier) ClaimInterestRunAccount(ctx context.Context, arg models.ClaimInterestRunAccountParams) (models.InterestRunAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimInterestRunAccount", ctx, arg)
+	ret0, _ := ret[0].(models.InterestRunAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimInterestRunAccount indicates an expected call of ClaimInterestRunAccount.
+func (mr *MockQuerierMockRecorder) ClaimInterestRunAccount(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimInterestRunAccount", reflect.TypeOf((*MockQuerier)(nil).ClaimInterestRunAccount), ctx, arg)
+}
+
+// CloseInterestRate mocks base method.
+func (m *MockQuerier) CloseInterestRate(ctx context.Context, arg models.CloseInterestRateParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseInterestRate", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseInterestRate indicates an expected call of CloseInterestRate.
+func (mr *MockQuerierMockRecorder) CloseInterestRate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseInterestRate", reflect.TypeOf((*MockQuerier)(nil).CloseInterestRate), ctx, arg)
+}
+
+// CompleteApprovalRequest mocks base method.
+func (m *MockQuerier) CompleteApprovalRequest(ctx context.Context, arg models.CompleteApprovalRequestParams) (models.ApprovalRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteApprovalRequest", ctx, arg)
+	ret0, _ := ret[0].(models.ApprovalRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteApprovalRequest indicates an expected call of CompleteApprovalRequest.
+func (mr *MockQuerierMockRecorder) CompleteApprovalRequest(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteApprovalRequest", reflect.TypeOf((*MockQuerier)(nil).CompleteApprovalRequest), ctx, arg)
+}
+
+// CompleteInterestRunAccount mocks base method.
+func (m *MockQuerier) CompleteInterestRunAccount(ctx context.Context, arg models.CompleteInterestRunAccountParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteInterestRunAccount", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteInterestRunAccount indicates an expected call of CompleteInterestRunAccount.
+func (mr *MockQuerierMockRecorder) CompleteInterestRunAccount(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteInterestRunAccount", reflect.TypeOf((*MockQuerier)(nil).CompleteInterestRunAccount), ctx, arg)
+}
+
+// ConfirmPendingEmail mocks base method.
+func (m *MockQuerier) ConfirmPendingEmail(ctx context.Context, arg models.ConfirmPendingEmailParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmPendingEmail", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmPendingEmail indicates an expected call of ConfirmPendingEmail.
+func (mr *MockQuerierMockRecorder) ConfirmPendingEmail(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmPendingEmail", reflect.TypeOf((*MockQuerier)(nil).ConfirmPendingEmail), ctx, arg)
+}
+
+// CountOverlappingInterestRateOverrides mocks base method.
+func (m *MockQuerier) CountOverlappingInterestRateOverrides(ctx context.Context, arg models.CountOverlappingInterestRateOverridesParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOverlappingInterestRateOverrides", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOverlappingInterestRateOverrides indicates an expected call of CountOverlappingInterestRateOverrides.
+func (mr *MockQuerierMockRecorder) CountOverlappingInterestRateOverrides(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOverlappingInterestRateOverrides", reflect.TypeOf((*MockQuerier)(nil).CountOverlappingInterestRateOverrides), ctx, arg)
+}
+
+// CountUnusedRecoveryCodes mocks base method.
+func (m *MockQuerier) CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnusedRecoveryCodes", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnusedRecoveryCodes indicates an expected call of CountUnusedRecoveryCodes.
+func (mr *MockQuerierMockRecorder) CountUnusedRecoveryCodes(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnusedRecoveryCodes", reflect.TypeOf((*MockQuerier)(nil).CountUnusedRecoveryCodes), ctx, userID)
+}
+
+// CreateInterestRateCard mocks base method.
+func (m *MockQuerier) CreateInterestRateCard(ctx context.Context, arg models.CreateInterestRateCardParams) (models.InterestRateCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInterestRateCard", ctx, arg)
+	ret0, _ := ret[0].(models.InterestRateCard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInterestRateCard indicates an expected call of CreateInterestRateCard.
+func (mr *MockQuerierMockRecorder) CreateInterestRateCard(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInterestRateCard", reflect.TypeOf((*MockQuerier)(nil).CreateInterestRateCard), ctx, arg)
+}
+
+// CreateInterestRateOverride mocks base method.
+func (m *MockQuerier) CreateInterestRateOverride(ctx context.Context, arg models.CreateInterestRateOverrideParams) (models.InterestRateOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInterestRateOverride", ctx, arg)
+	ret0, _ := ret[0].(models.InterestRateOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInterestRateOverride indicates an expected call of CreateInterestRateOverride.
+func (mr *MockQuerierMockRecorder) CreateInterestRateOverride(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInterestRateOverride", reflect.TypeOf((*MockQuerier)(nil).CreateInterestRateOverride), ctx, arg)
+}
+
+// CreateOAuthClient mocks base method.
+func (m *MockQuerier) CreateOAuthClient(ctx context.Context, arg models.CreateOAuthClientParams) (models.OauthClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOAuthClient", ctx, arg)
+	ret0, _ := ret[0].(models.OauthClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOAuthClient indicates an expected call of CreateOAuthClient.
+func (mr *MockQuerierMockRecorder) CreateOAuthClient(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOAuthClient", reflect.TypeOf((*MockQuerier)(nil).CreateOAuthClient), ctx, arg)
+}
+
+// CreateServiceAccount mocks base method.
+func (m *MockQuerier) CreateServiceAccount(ctx context.Context, arg models.CreateServiceAccountParams) (models.ServiceAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateServiceAccount", ctx, arg)
+	ret0, _ := ret[0].(models.ServiceAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateServiceAccount indicates an expected call of CreateServiceAccount.
+func (mr *MockQuerierMockRecorder) CreateServiceAccount(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateServiceAccount", reflect.TypeOf((*MockQuerier)(nil).CreateServiceAccount), ctx, arg)
+}
+
+// DeactivateUser mocks base method.
+func (m *MockQuerier) DeactivateUser(ctx context.Context, id uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateUser", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateUser indicates an expected call of DeactivateUser.
+func (mr *MockQuerierMockRecorder) DeactivateUser(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateUser", reflect.TypeOf((*MockQuerier)(nil).DeactivateUser), ctx, id)
+}
+
+// DecideApprovalRequest mocks base method.
+func (m *MockQuerier) DecideApprovalRequest(ctx context.Context, arg models.DecideApprovalRequestParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecideApprovalRequest", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecideApprovalRequest indicates an expected call of DecideApprovalRequest.
+func (mr *MockQuerierMockRecorder) DecideApprovalRequest(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecideApprovalRequest", reflect.TypeOf((*MockQuerier)(nil).DecideApprovalRequest), ctx, arg)
+}
+
+// DeleteInterestRateCard mocks base method.
+func (m *MockQuerier) DeleteInterestRateCard(ctx context.Context, id uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInterestRateCard", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteInterestRateCard indicates an expected call of DeleteInterestRateCard.
+func (mr *MockQuerierMockRecorder) DeleteInterestRateCard(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInterestRateCard", reflect.TypeOf((*MockQuerier)(nil).DeleteInterestRateCard), ctx, id)
+}
+
+// DeleteInterestRateOverride mocks base method.
+func (m *MockQuerier) DeleteInterestRateOverride(ctx context.Context, id uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInterestRateOverride", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteInterestRateOverride indicates an expected call of DeleteInterestRateOverride.
+func (mr *MockQuerierMockRecorder) DeleteInterestRateOverride(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInterestRateOverride", reflect.TypeOf((*MockQuerier)(nil).DeleteInterestRateOverride), ctx, id)
+}
+
+// DeleteRecoveryCodes mocks base method.
+func (m *MockQuerier) DeleteRecoveryCodes(ctx context.Context, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecoveryCodes", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecoveryCodes indicates an expected call of DeleteRecoveryCodes.
+func (mr *MockQuerierMockRecorder) DeleteRecoveryCodes(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecoveryCodes", reflect.TypeOf((*MockQuerier)(nil).DeleteRecoveryCodes), ctx, userID)
+}
+
+// DeleteTransactionReversal mocks base method.
+func (m *MockQuerier) DeleteTransactionReversal(ctx context.Context, transactionID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTransactionReversal", ctx, transactionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTransactionReversal indicates an expected call of DeleteTransactionReversal.
+func (mr *MockQuerierMockRecorder) DeleteTransactionReversal(ctx, transactionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTransactionReversal", reflect.TypeOf((*MockQuerier)(nil).DeleteTransactionReversal), ctx, transactionID)
+}
+
+// DeleteUserMFA mocks base method.
+func (m *MockQuerier) DeleteUserMFA(ctx context.Context, userID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserMFA", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUserMFA indicates an expected call of DeleteUserMFA.
+func (mr *MockQuerierMockRecorder) DeleteUserMFA(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserMFA", reflect.TypeOf((*MockQuerier)(nil).DeleteUserMFA), ctx, userID)
+}
+
+// EnableUserMFA mocks base method.
+func (m *MockQuerier) EnableUserMFA(ctx context.Context, userID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableUserMFA", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableUserMFA indicates an expected call of EnableUserMFA.
+func (mr *MockQuerierMockRecorder) EnableUserMFA(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableUserMFA", reflect.TypeOf((*MockQuerier)(nil).EnableUserMFA), ctx, userID)
+}
+
+// ExpireApprovalRequests mocks base method.
+func (m *MockQuerier) ExpireApprovalRequests(ctx context.Context, now time.Time) ([]models.ApprovalRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireApprovalRequests", ctx, now)
+	ret0, _ := ret[0].([]models.ApprovalRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireApprovalRequests indicates an expected call of ExpireApprovalRequests.
+func (mr *MockQuerierMockRecorder) ExpireApprovalRequests(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireApprovalRequests", reflect.TypeOf((*MockQuerier)(nil).ExpireApprovalRequests), ctx, now)
+}
+
+// FailInterestRunAccount mocks base method.
+func (m *MockQuerier) FailInterestRunAccount(ctx context.Context, arg models.FailInterestRunAccountParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailInterestRunAccount", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailInterestRunAccount indicates an expected call of FailInterestRunAccount.
+func (mr *MockQuerierMockRecorder) FailInterestRunAccount(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailInterestRunAccount", reflect.TypeOf((*MockQuerier)(nil).FailInterestRunAccount), ctx, arg)
+}
+
+// FinishInterestRun mocks base method.
+func (m *MockQuerier) FinishInterestRun(ctx context.Context, id uuid.UUID) (models.InterestRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishInterestRun", ctx, id)
+	ret0, _ := ret[0].(models.InterestRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishInterestRun indicates an expected call of FinishInterestRun.
+func (mr *MockQuerierMockRecorder) FinishInterestRun(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishInterestRun", reflect.TypeOf((*MockQuerier)(nil).FinishInterestRun), ctx, id)
+}
+
+// ForfeitInterestBonus mocks base method.
+func (m *MockQuerier) ForfeitInterestBonus(ctx context.Context, arg models.ForfeitInterestBonusParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForfeitInterestBonus", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForfeitInterestBonus indicates an expected call of ForfeitInterestBonus.
+func (mr *MockQuerierMockRecorder) ForfeitInterestBonus(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForfeitInterestBonus", reflect.TypeOf((*MockQuerier)(nil).ForfeitInterestBonus), ctx, arg)
+}
+
+// GetAPIKey mocks base method.
+func (m *MockQuerier) GetAPIKey(ctx context.Context, arg models.GetAPIKeyParams) (models.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKey", ctx, arg)
+	ret0, _ := ret[0].(models.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKey indicates an expected call of GetAPIKey.
+func (mr *MockQuerierMockRecorder) GetAPIKey(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKey", reflect.TypeOf((*MockQuerier)(nil).GetAPIKey), ctx, arg)
+}
+
+// GetAPIKeyByHash mocks base method.
+func (m *MockQuerier) GetAPIKeyByHash(ctx context.Context, keyHash string) (models.GetAPIKeyByHashRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeyByHash", ctx, keyHash)
+	ret0, _ := ret[0].(models.GetAPIKeyByHashRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeyByHash indicates an expected call of GetAPIKeyByHash.
+func (mr *MockQuerierMockRecorder) GetAPIKeyByHash(ctx, keyHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeyByHash", reflect.TypeOf((*MockQuerier)(nil).GetAPIKeyByHash), ctx, keyHash)
+}
+
+// GetAPIKeys mocks base method.
+func (m *MockQuerier) GetAPIKeys(ctx context.Context, serviceAccountID uuid.UUID) ([]models.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeys", ctx, serviceAccountID)
+	ret0, _ := ret[0].([]models.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeys indicates an expected call of GetAPIKeys.
+func (mr *MockQuerierMockRecorder) GetAPIKeys(ctx, serviceAccountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeys", reflect.TypeOf((*MockQuerier)(nil).GetAPIKeys), ctx, serviceAccountID)
+}
+
 // GetAccountBalance mocks base method.
 func (m *MockQuerier) GetAccountBalance(ctx context.Context, id uuid.UUID) (models.GetAccountBalanceRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccountBalance", ctx, id)
-	ret0, _ := ret[0].(models.GetAccountBalanceRow)
+	ret := m.ctrl.Call(m, "GetAccountBalance", ctx, id)
+	ret0, _ := ret[0].(models.GetAccountBalanceRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountBalance indicates an expected call of GetAccountBalance.
+func (mr *MockQuerierMockRecorder) GetAccountBalance(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountBalance", reflect.TypeOf((*MockQuerier)(nil).GetAccountBalance), ctx, id)
+}
+
+// GetAccountBalanceAt mocks base method.
+func (m *MockQuerier) GetAccountBalanceAt(ctx context.Context, arg models.GetAccountBalanceAtParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountBalanceAt", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountBalanceAt indicates an expected call of GetAccountBalanceAt.
+func (mr *MockQuerierMockRecorder) GetAccountBalanceAt(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountBalanceAt", reflect.TypeOf((*MockQuerier)(nil).GetAccountBalanceAt), ctx, arg)
+}
+
+// GetAccountByCurrency mocks base method.
+func (m *MockQuerier) GetAccountByCurrency(ctx context.Context, arg models.GetAccountByCurrencyParams) (models.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountByCurrency", ctx, arg)
+	ret0, _ := ret[0].(models.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountByCurrency indicates an expected call of GetAccountByCurrency.
+func (mr *MockQuerierMockRecorder) GetAccountByCurrency(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountByCurrency", reflect.TypeOf((*MockQuerier)(nil).GetAccountByCurrency), ctx, arg)
+}
+
+// GetAccountByIBAN mocks base method.
+func (m *MockQuerier) GetAccountByIBAN(ctx context.Context, iban sql.NullString) (models.GetAccountByIBANRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountByIBAN", ctx, iban)
+	ret0, _ := ret[0].(models.GetAccountByIBANRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountByIBAN indicates an expected call of GetAccountByIBAN.
+func (mr *MockQuerierMockRecorder) GetAccountByIBAN(ctx, iban any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountByIBAN", reflect.TypeOf((*MockQuerier)(nil).GetAccountByIBAN), ctx, iban)
+}
+
+// GetAccountByID mocks base method.
+func (m *MockQuerier) GetAccountByID(ctx context.Context, id uuid.UUID) (models.GetAccountByIDRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountByID", ctx, id)
+	ret0, _ := ret[0].(models.GetAccountByIDRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountByID indicates an expected call of GetAccountByID.
+func (mr *MockQuerierMockRecorder) GetAccountByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountByID", reflect.TypeOf((*MockQuerier)(nil).GetAccountByID), ctx, id)
+}
+
+// GetAccountByNumber mocks base method.
+func (m *MockQuerier) GetAccountByNumber(ctx context.Context, accountNumber string) (models.GetAccountByNumberRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountByNumber", ctx, accountNumber)
+	ret0, _ := ret[0].(models.GetAccountByNumberRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountByNumber indicates an expected call of GetAccountByNumber.
+func (mr *MockQuerierMockRecorder) GetAccountByNumber(ctx, accountNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountByNumber", reflect.TypeOf((*MockQuerier)(nil).GetAccountByNumber), ctx, accountNumber)
+}
+
+// GetAccountDetailsByID mocks base method.
+func (m *MockQuerier) GetAccountDetailsByID(ctx context.Context, id uuid.UUID) (models.GetAccountDetailsByIDRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountDetailsByID", ctx, id)
+	ret0, _ := ret[0].(models.GetAccountDetailsByIDRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountDetailsByID indicates an expected call of GetAccountDetailsByID.
+func (mr *MockQuerierMockRecorder) GetAccountDetailsByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountDetailsByID", reflect.TypeOf((*MockQuerier)(nil).GetAccountDetailsByID), ctx, id)
+}
+
+// GetAccountFlows mocks base method.
+func (m *MockQuerier) GetAccountFlows(ctx context.Context, arg models.GetAccountFlowsParams) (models.GetAccountFlowsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountFlows", ctx, arg)
+	ret0, _ := ret[0].(models.GetAccountFlowsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountFlows indicates an expected call of GetAccountFlows.
+func (mr *MockQuerierMockRecorder) GetAccountFlows(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountFlows", reflect.TypeOf((*MockQuerier)(nil).GetAccountFlows), ctx, arg)
+}
+
+// GetAccountStats mocks base method.
+func (m *MockQuerier) GetAccountStats(ctx context.Context) (models.GetAccountStatsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountStats", ctx)
+	ret0, _ := ret[0].(models.GetAccountStatsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountStats indicates an expected call of GetAccountStats.
+func (mr *MockQuerierMockRecorder) GetAccountStats(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountStats", reflect.TypeOf((*MockQuerier)(nil).GetAccountStats), ctx)
+}
+
+// GetAccountStatusHistory mocks base method.
+func (m *MockQuerier) GetAccountStatusHistory(ctx context.Context, affectedAccountID uuid.NullUUID) ([]models.GetAccountStatusHistoryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountStatusHistory", ctx, affectedAccountID)
+	ret0, _ := ret[0].([]models.GetAccountStatusHistoryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountStatusHistory indicates an expected call of GetAccountStatusHistory.
+func (mr *MockQuerierMockRecorder) GetAccountStatusHistory(ctx, affectedAccountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountStatusHistory", reflect.TypeOf((*MockQuerier)(nil).GetAccountStatusHistory), ctx, affectedAccountID)
+}
+
+// GetAccountTaxStatus mocks base method.
+func (m *MockQuerier) GetAccountTaxStatus(ctx context.Context, id uuid.UUID) (models.GetAccountTaxStatusRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountTaxStatus", ctx, id)
+	ret0, _ := ret[0].(models.GetAccountTaxStatusRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountTaxStatus indicates an expected call of GetAccountTaxStatus.
+func (mr *MockQuerierMockRecorder) GetAccountTaxStatus(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountTaxStatus", reflect.TypeOf((*MockQuerier)(nil).GetAccountTaxStatus), ctx, id)
+}
+
+// GetAccountUncapitalisedInterest mocks base method.
+func (m *MockQuerier) GetAccountUncapitalisedInterest(ctx context.Context, arg models.GetAccountUncapitalisedInterestParams) (models.GetAccountUncapitalisedInterestRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountUncapitalisedInterest", ctx, arg)
+	ret0, _ := ret[0].(models.GetAccountUncapitalisedInterestRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountUncapitalisedInterest indicates an expected call of GetAccountUncapitalisedInterest.
+func (mr *MockQuerierMockRecorder) GetAccountUncapitalisedInterest(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountUncapitalisedInterest", reflect.TypeOf((*MockQuerier)(nil).GetAccountUncapitalisedInterest), ctx, arg)
+}
+
+// GetAccountsMissingIdentifiers mocks base method.
+func (m *MockQuerier) GetAccountsMissingIdentifiers(ctx context.Context) ([]models.GetAccountsMissingIdentifiersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountsMissingIdentifiers", ctx)
+	ret0, _ := ret[0].([]models.GetAccountsMissingIdentifiersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountsMissingIdentifiers indicates an expected call of GetAccountsMissingIdentifiers.
+func (mr *MockQuerierMockRecorder) GetAccountsMissingIdentifiers(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountsMissingIdentifiers", reflect.TypeOf((*MockQuerier)(nil).GetAccountsMissingIdentifiers), ctx)
+}
+
+// GetAllActiveAccounts mocks base method.
+func (m *MockQuerier) GetAllActiveAccounts(ctx context.Context) ([]models.GetAllActiveAccountsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllActiveAccounts", ctx)
+	ret0, _ := ret[0].([]models.GetAllActiveAccountsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllActiveAccounts indicates an expected call of GetAllActiveAccounts.
+func (mr *MockQuerierMockRecorder) GetAllActiveAccounts(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllActiveAccounts", reflect.TypeOf((*MockQuerier)(nil).GetAllActiveAccounts), ctx)
+}
+
+// GetAllCurrentAccounts mocks base method.
+func (m *MockQuerier) GetAllCurrentAccounts(ctx context.Context) ([]models.GetAllCurrentAccountsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCurrentAccounts", ctx)
+	ret0, _ := ret[0].([]models.GetAllCurrentAccountsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCurrentAccounts indicates an expected call of GetAllCurrentAccounts.
+func (mr *MockQuerierMockRecorder) GetAllCurrentAccounts(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCurrentAccounts", reflect.TypeOf((*MockQuerier)(nil).GetAllCurrentAccounts), ctx)
+}
+
+// GetApprovalRequest mocks base method.
+func (m *MockQuerier) GetApprovalRequest(ctx context.Context, id uuid.UUID) (models.ApprovalRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApprovalRequest", ctx, id)
+	ret0, _ := ret[0].(models.ApprovalRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApprovalRequest indicates an expected call of GetApprovalRequest.
+func (mr *MockQuerierMockRecorder) GetApprovalRequest(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApprovalRequest", reflect.TypeOf((*MockQuerier)(nil).GetApprovalRequest), ctx, id)
+}
+
+// GetApprovalRequests mocks base method.
+func (m *MockQuerier) GetApprovalRequests(ctx context.Context, status models.NullApprovalStatus) ([]models.ApprovalRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApprovalRequests", ctx, status)
+	ret0, _ := ret[0].([]models.ApprovalRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApprovalRequests indicates an expected call of GetApprovalRequests.
+func (mr *MockQuerierMockRecorder) GetApprovalRequests(ctx, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApprovalRequests", reflect.TypeOf((*MockQuerier)(nil).GetApprovalRequests), ctx, status)
+}
+
+// GetAuditLogsForAccount mocks base method.
+func (m *MockQuerier) GetAuditLogsForAccount(ctx context.Context, affectedAccountID uuid.NullUUID) ([]models.GetAuditLogsForAccountRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditLogsForAccount", ctx, affectedAccountID)
+	ret0, _ := ret[0].([]models.GetAuditLogsForAccountRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditLogsForAccount indicates an expected call of GetAuditLogsForAccount.
+func (mr *MockQuerierMockRecorder) GetAuditLogsForAccount(ctx, affectedAccountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLogsForAccount", reflect.TypeOf((*MockQuerier)(nil).GetAuditLogsForAccount), ctx, affectedAccountID)
+}
+
+// GetAuthorizationCodeByHash mocks base method.
+func (m *MockQuerier) GetAuthorizationCodeByHash(ctx context.Context, codeHash string) (models.OauthAuthorizationCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthorizationCodeByHash", ctx, codeHash)
+	ret0, _ := ret[0].(models.OauthAuthorizationCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthorizationCodeByHash indicates an expected call of GetAuthorizationCodeByHash.
+func (mr *MockQuerierMockRecorder) GetAuthorizationCodeByHash(ctx, codeHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorizationCodeByHash", reflect.TypeOf((*MockQuerier)(nil).GetAuthorizationCodeByHash), ctx, codeHash)
+}
+
+// GetDormancyCandidates mocks base method.
+func (m *MockQuerier) GetDormancyCandidates(ctx context.Context, lastActivityAt sql.NullTime) ([]models.GetDormancyCandidatesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDormancyCandidates", ctx, lastActivityAt)
+	ret0, _ := ret[0].([]models.GetDormancyCandidatesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDormancyCandidates indicates an expected call of GetDormancyCandidates.
+func (mr *MockQuerierMockRecorder) GetDormancyCandidates(ctx, lastActivityAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDormancyCandidates", reflect.TypeOf((*MockQuerier)(nil).GetDormancyCandidates), ctx, lastActivityAt)
+}
+
+// GetDormantAccounts mocks base method.
+func (m *MockQuerier) GetDormantAccounts(ctx context.Context) ([]models.GetDormantAccountsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDormantAccounts", ctx)
+	ret0, _ := ret[0].([]models.GetDormantAccountsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDormantAccounts indicates an expected call of GetDormantAccounts.
+func (mr *MockQuerierMockRecorder) GetDormantAccounts(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDormantAccounts", reflect.TypeOf((*MockQuerier)(nil).GetDormantAccounts), ctx)
+}
+
+// GetInterestPayments mocks base method.
+func (m *MockQuerier) GetInterestPayments(ctx context.Context, arg models.GetInterestPaymentsParams) ([]models.GetInterestPaymentsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInterestPayments", ctx, arg)
+	ret0, _ := ret[0].([]models.GetInterestPaymentsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInterestPayments indicates an expected call of GetInterestPayments.
+func (mr *MockQuerierMockRecorder) GetInterestPayments(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterestPayments", reflect.TypeOf((*MockQuerier)(nil).GetInterestPayments), ctx, arg)
+}
+
+// GetInterestRateAt mocks base method.
+func (m *MockQuerier) GetInterestRateAt(ctx context.Context, day time.Time) (models.InterestRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInterestRateAt", ctx, day)
+	ret0, _ := ret[0].(models.InterestRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInterestRateAt indicates an expected call of GetInterestRateAt.
+func (mr *MockQuerierMockRecorder) GetInterestRateAt(ctx, day any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterestRateAt", reflect.TypeOf((*MockQuerier)(nil).GetInterestRateAt), ctx, day)
+}
+
+// GetInterestRateCard mocks base method.
+func (m *MockQuerier) GetInterestRateCard(ctx context.Context, id uuid.UUID) (models.InterestRateCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInterestRateCard", ctx, id)
+	ret0, _ := ret[0].(models.InterestRateCard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInterestRateCard indicates an expected call of GetInterestRateCard.
+func (mr *MockQuerierMockRecorder) GetInterestRateCard(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterestRateCard", reflect.TypeOf((*MockQuerier)(nil).GetInterestRateCard), ctx, id)
+}
+
+// GetInterestRateCardByProduct mocks base method.
+func (m *MockQuerier) GetInterestRateCardByProduct(ctx context.Context, arg models.GetInterestRateCardByProductParams) (models.InterestRateCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInterestRateCardByProduct", ctx, arg)
+	ret0, _ := ret[0].(models.InterestRateCard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInterestRateCardByProduct indicates an expected call of GetInterestRateCardByProduct.
+func (mr *MockQuerierMockRecorder) GetInterestRateCardByProduct(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterestRateCardByProduct", reflect.TypeOf((*MockQuerier)(nil).GetInterestRateCardByProduct), ctx, arg)
+}
+
+// GetInterestRateOverride mocks base method.
+func (m *MockQuerier) GetInterestRateOverride(ctx context.Context, arg models.GetInterestRateOverrideParams) (models.InterestRateOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInterestRateOverride", ctx, arg)
+	ret0, _ := ret[0].(models.InterestRateOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInterestRateOverride indicates an expected call of GetInterestRateOverride.
+func (mr *MockQuerierMockRecorder) GetInterestRateOverride(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterestRateOverride", reflect.TypeOf((*MockQuerier)(nil).GetInterestRateOverride), ctx, arg)
+}
+
+// GetInterestRates mocks base method.
+func (m *MockQuerier) GetInterestRates(ctx context.Context) ([]models.InterestRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInterestRates", ctx)
+	ret0, _ := ret[0].([]models.InterestRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInterestRates indicates an expected call of GetInterestRates.
+func (mr *MockQuerierMockRecorder) GetInterestRates(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterestRates", reflect.TypeOf((*MockQuerier)(nil).GetInterestRates), ctx)
+}
+
+// GetInterestRun mocks base method.
+func (m *MockQuerier) GetInterestRun(ctx context.Context, id uuid.UUID) (models.InterestRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInterestRun", ctx, id)
+	ret0, _ := ret[0].(models.InterestRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInterestRun indicates an expected call of GetInterestRun.
+func (mr *MockQuerierMockRecorder) GetInterestRun(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterestRun", reflect.TypeOf((*MockQuerier)(nil).GetInterestRun), ctx, id)
+}
+
+// GetInterestRunAccount mocks base method.
+func (m *MockQuerier) GetInterestRunAccount(ctx context.Context, arg models.GetInterestRunAccountParams) (models.InterestRunAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInterestRunAccount", ctx, arg)
+	ret0, _ := ret[0].(models.InterestRunAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInterestRunAccount indicates an expected call of GetInterestRunAccount.
+func (mr *MockQuerierMockRecorder) GetInterestRunAccount(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterestRunAccount", reflect.TypeOf((*MockQuerier)(nil).GetInterestRunAccount), ctx, arg)
+}
+
+// GetInterestRunAccounts mocks base method.
+func (m *MockQuerier) GetInterestRunAccounts(ctx context.Context, runID uuid.UUID) ([]models.InterestRunAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInterestRunAccounts", ctx, runID)
+	ret0, _ := ret[0].([]models.InterestRunAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInterestRunAccounts indicates an expected call of GetInterestRunAccounts.
+func (mr *MockQuerierMockRecorder) GetInterestRunAccounts(ctx, runID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterestRunAccounts", reflect.TypeOf((*MockQuerier)(nil).GetInterestRunAccounts), ctx, runID)
+}
+
+// GetLastInterestRun mocks base method.
+func (m *MockQuerier) GetLastInterestRun(ctx context.Context) (models.InterestRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastInterestRun", ctx)
+	ret0, _ := ret[0].(models.InterestRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastInterestRun indicates an expected call of GetLastInterestRun.
+func (mr *MockQuerierMockRecorder) GetLastInterestRun(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastInterestRun", reflect.TypeOf((*MockQuerier)(nil).GetLastInterestRun), ctx)
+}
+
+// GetLoginAttemptsByUserID mocks base method.
+func (m *MockQuerier) GetLoginAttemptsByUserID(ctx context.Context, userID uuid.NullUUID) ([]models.LoginAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginAttemptsByUserID", ctx, userID)
+	ret0, _ := ret[0].([]models.LoginAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginAttemptsByUserID indicates an expected call of GetLoginAttemptsByUserID.
+func (mr *MockQuerierMockRecorder) GetLoginAttemptsByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginAttemptsByUserID", reflect.TypeOf((*MockQuerier)(nil).GetLoginAttemptsByUserID), ctx, userID)
+}
+
+// GetMFAChallengeByHash mocks base method.
+func (m *MockQuerier) GetMFAChallengeByHash(ctx context.Context, tokenHash string) (models.MfaChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMFAChallengeByHash", ctx, tokenHash)
+	ret0, _ := ret[0].(models.MfaChallenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMFAChallengeByHash indicates an expected call of GetMFAChallengeByHash.
+func (mr *MockQuerierMockRecorder) GetMFAChallengeByHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMFAChallengeByHash", reflect.TypeOf((*MockQuerier)(nil).GetMFAChallengeByHash), ctx, tokenHash)
+}
+
+// GetNotificationsByUserID mocks base method.
+func (m *MockQuerier) GetNotificationsByUserID(ctx context.Context, userID uuid.UUID) ([]models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationsByUserID", ctx, userID)
+	ret0, _ := ret[0].([]models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationsByUserID indicates an expected call of GetNotificationsByUserID.
+func (mr *MockQuerierMockRecorder) GetNotificationsByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationsByUserID", reflect.TypeOf((*MockQuerier)(nil).GetNotificationsByUserID), ctx, userID)
+}
+
+// GetOAuthClient mocks base method.
+func (m *MockQuerier) GetOAuthClient(ctx context.Context, id uuid.UUID) (models.OauthClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOAuthClient", ctx, id)
+	ret0, _ := ret[0].(models.OauthClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOAuthClient indicates an expected call of GetOAuthClient.
+func (mr *MockQuerierMockRecorder) GetOAuthClient(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOAuthClient", reflect.TypeOf((*MockQuerier)(nil).GetOAuthClient), ctx, id)
+}
+
+// GetOAuthClients mocks base method.
+func (m *MockQuerier) GetOAuthClients(ctx context.Context) ([]models.OauthClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOAuthClients", ctx)
+	ret0, _ := ret[0].([]models.OauthClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOAuthClients indicates an expected call of GetOAuthClients.
+func (mr *MockQuerierMockRecorder) GetOAuthClients(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOAuthClients", reflect.TypeOf((*MockQuerier)(nil).GetOAuthClients), ctx)
+}
+
+// GetProfileByUserID mocks base method.
+func (m *MockQuerier) GetProfileByUserID(ctx context.Context, id uuid.UUID) (models.GetProfileByUserIDRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfileByUserID", ctx, id)
+	ret0, _ := ret[0].(models.GetProfileByUserIDRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfileByUserID indicates an expected call of GetProfileByUserID.
+func (mr *MockQuerierMockRecorder) GetProfileByUserID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfileByUserID", reflect.TypeOf((*MockQuerier)(nil).GetProfileByUserID), ctx, id)
+}
+
+// GetRefreshTokenByHash mocks base method.
+func (m *MockQuerier) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (models.GetRefreshTokenByHashRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefreshTokenByHash", ctx, tokenHash)
+	ret0, _ := ret[0].(models.GetRefreshTokenByHashRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRefreshTokenByHash indicates an expected call of GetRefreshTokenByHash.
+func (mr *MockQuerierMockRecorder) GetRefreshTokenByHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefreshTokenByHash", reflect.TypeOf((*MockQuerier)(nil).GetRefreshTokenByHash), ctx, tokenHash)
+}
+
+// GetRoles mocks base method.
+func (m *MockQuerier) GetRoles(ctx context.Context) ([]models.GetRolesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoles", ctx)
+	ret0, _ := ret[0].([]models.GetRolesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoles indicates an expected call of GetRoles.
+func (mr *MockQuerierMockRecorder) GetRoles(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoles", reflect.TypeOf((*MockQuerier)(nil).GetRoles), ctx)
+}
+
+// GetRolesByNames mocks base method.
+func (m *MockQuerier) GetRolesByNames(ctx context.Context, names []string) ([]models.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRolesByNames", ctx, names)
+	ret0, _ := ret[0].([]models.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRolesByNames indicates an expected call of GetRolesByNames.
+func (mr *MockQuerierMockRecorder) GetRolesByNames(ctx, names any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRolesByNames", reflect.TypeOf((*MockQuerier)(nil).GetRolesByNames), ctx, names)
+}
+
+// GetServiceAccount mocks base method.
+func (m *MockQuerier) GetServiceAccount(ctx context.Context, id uuid.UUID) (models.ServiceAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceAccount", ctx, id)
+	ret0, _ := ret[0].(models.ServiceAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceAccount indicates an expected call of GetServiceAccount.
+func (mr *MockQuerierMockRecorder) GetServiceAccount(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceAccount", reflect.TypeOf((*MockQuerier)(nil).GetServiceAccount), ctx, id)
+}
+
+// GetServiceAccounts mocks base method.
+func (m *MockQuerier) GetServiceAccounts(ctx context.Context) ([]models.ServiceAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceAccounts", ctx)
+	ret0, _ := ret[0].([]models.ServiceAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceAccounts indicates an expected call of GetServiceAccounts.
+func (mr *MockQuerierMockRecorder) GetServiceAccounts(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceAccounts", reflect.TypeOf((*MockQuerier)(nil).GetServiceAccounts), ctx)
+}
+
+// GetTransactionByID mocks base method.
+func (m *MockQuerier) GetTransactionByID(ctx context.Context, id uuid.UUID) (models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionByID", ctx, id)
+	ret0, _ := ret[0].(models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactionByID indicates an expected call of GetTransactionByID.
+func (mr *MockQuerierMockRecorder) GetTransactionByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionByID", reflect.TypeOf((*MockQuerier)(nil).GetTransactionByID), ctx, id)
+}
+
+// GetTransactionReversal mocks base method.
+func (m *MockQuerier) GetTransactionReversal(ctx context.Context, transactionID uuid.UUID) (models.TransactionReversal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionReversal", ctx, transactionID)
+	ret0, _ := ret[0].(models.TransactionReversal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactionReversal indicates an expected call of GetTransactionReversal.
+func (mr *MockQuerierMockRecorder) GetTransactionReversal(ctx, transactionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionReversal", reflect.TypeOf((*MockQuerier)(nil).GetTransactionReversal), ctx, transactionID)
+}
+
+// GetTransactionsByAccountID mocks base method.
+func (m *MockQuerier) GetTransactionsByAccountID(ctx context.Context, fromAccountID uuid.UUID) ([]models.GetTransactionsByAccountIDRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionsByAccountID", ctx, fromAccountID)
+	ret0, _ := ret[0].([]models.GetTransactionsByAccountIDRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactionsByAccountID indicates an expected call of GetTransactionsByAccountID.
+func (mr *MockQuerierMockRecorder) GetTransactionsByAccountID(ctx, fromAccountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionsByAccountID", reflect.TypeOf((*MockQuerier)(nil).GetTransactionsByAccountID), ctx, fromAccountID)
+}
+
+// GetUncapitalisedInterest mocks base method.
+func (m *MockQuerier) GetUncapitalisedInterest(ctx context.Context, accrualDate time.Time) ([]models.GetUncapitalisedInterestRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUncapitalisedInterest", ctx, accrualDate)
+	ret0, _ := ret[0].([]models.GetUncapitalisedInterestRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUncapitalisedInterest indicates an expected call of GetUncapitalisedInterest.
+func (mr *MockQuerierMockRecorder) GetUncapitalisedInterest(ctx, accrualDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUncapitalisedInterest", reflect.TypeOf((*MockQuerier)(nil).GetUncapitalisedInterest), ctx, accrualDate)
+}
+
+// GetUnfinishedInterestRuns mocks base method.
+func (m *MockQuerier) GetUnfinishedInterestRuns(ctx context.Context) ([]models.InterestRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnfinishedInterestRuns", ctx)
+	ret0, _ := ret[0].([]models.InterestRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnfinishedInterestRuns indicates an expected call of GetUnfinishedInterestRuns.
+func (mr *MockQuerierMockRecorder) GetUnfinishedInterestRuns(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnfinishedInterestRuns", reflect.TypeOf((*MockQuerier)(nil).GetUnfinishedInterestRuns), ctx)
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockQuerier) GetUserByEmail(ctx context.Context, email string) (models.GetUserByEmailRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
+	ret0, _ := ret[0].(models.GetUserByEmailRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockQuerierMockRecorder) GetUserByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockQuerier)(nil).GetUserByEmail), ctx, email)
+}
+
+// GetUserByID mocks base method.
+func (m *MockQuerier) GetUserByID(ctx context.Context, id uuid.UUID) (models.GetUserByIDRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
+	ret0, _ := ret[0].(models.GetUserByIDRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockQuerierMockRecorder) GetUserByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockQuerier)(nil).GetUserByID), ctx, id)
+}
+
+// GetUserMFA mocks base method.
+func (m *MockQuerier) GetUserMFA(ctx context.Context, userID uuid.UUID) (models.UserMfa, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserMFA", ctx, userID)
+	ret0, _ := ret[0].(models.UserMfa)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserMFA indicates an expected call of GetUserMFA.
+func (mr *MockQuerierMockRecorder) GetUserMFA(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserMFA", reflect.TypeOf((*MockQuerier)(nil).GetUserMFA), ctx, userID)
+}
+
+// GetUserPermissions mocks base method.
+func (m *MockQuerier) GetUserPermissions(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserPermissions", ctx, userID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserPermissions indicates an expected call of GetUserPermissions.
+func (mr *MockQuerierMockRecorder) GetUserPermissions(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPermissions", reflect.TypeOf((*MockQuerier)(nil).GetUserPermissions), ctx, userID)
+}
+
+// GetUserRoleNames mocks base method.
+func (m *MockQuerier) GetUserRoleNames(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRoleNames", ctx, userID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRoleNames indicates an expected call of GetUserRoleNames.
+func (mr *MockQuerierMockRecorder) GetUserRoleNames(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRoleNames", reflect.TypeOf((*MockQuerier)(nil).GetUserRoleNames), ctx, userID)
+}
+
+// GetUserSessions mocks base method.
+func (m *MockQuerier) GetUserSessions(ctx context.Context, userID uuid.UUID) ([]models.GetUserSessionsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSessions", ctx, userID)
+	ret0, _ := ret[0].([]models.GetUserSessionsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSessions indicates an expected call of GetUserSessions.
+func (mr *MockQuerierMockRecorder) GetUserSessions(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSessions", reflect.TypeOf((*MockQuerier)(nil).GetUserSessions), ctx, userID)
+}
+
+// GetUserTokenByHash mocks base method.
+func (m *MockQuerier) GetUserTokenByHash(ctx context.Context, tokenHash string) (models.UserToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTokenByHash", ctx, tokenHash)
+	ret0, _ := ret[0].(models.UserToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTokenByHash indicates an expected call of GetUserTokenByHash.
+func (mr *MockQuerierMockRecorder) GetUserTokenByHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTokenByHash", reflect.TypeOf((*MockQuerier)(nil).GetUserTokenByHash), ctx, tokenHash)
+}
+
+// IncrementMFAChallengeAttempts mocks base method.
+func (m *MockQuerier) IncrementMFAChallengeAttempts(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementMFAChallengeAttempts", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementMFAChallengeAttempts indicates an expected call of IncrementMFAChallengeAttempts.
+func (mr *MockQuerierMockRecorder) IncrementMFAChallengeAttempts(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementMFAChallengeAttempts", reflect.TypeOf((*MockQuerier)(nil).IncrementMFAChallengeAttempts), ctx, id)
+}
+
+// InvalidateUserTokens mocks base method.
+func (m *MockQuerier) InvalidateUserTokens(ctx context.Context, arg models.InvalidateUserTokensParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateUserTokens", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateUserTokens indicates an expected call of InvalidateUserTokens.
+func (mr *MockQuerierMockRecorder) InvalidateUserTokens(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateUserTokens", reflect.TypeOf((*MockQuerier)(nil).InvalidateUserTokens), ctx, arg)
+}
+
+// IsAccessTokenRevoked mocks base method.
+func (m *MockQuerier) IsAccessTokenRevoked(ctx context.Context, arg models.IsAccessTokenRevokedParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAccessTokenRevoked", ctx, arg)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsAccessTokenRevoked indicates an expected call of IsAccessTokenRevoked.
+func (mr *MockQuerierMockRecorder) IsAccessTokenRevoked(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAccessTokenRevoked", reflect.TypeOf((*MockQuerier)(nil).IsAccessTokenRevoked), ctx, arg)
+}
+
+// ListAccountInterestRateOverrides mocks base method.
+func (m *MockQuerier) ListAccountInterestRateOverrides(ctx context.Context, accountID uuid.UUID) ([]models.InterestRateOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountInterestRateOverrides", ctx, accountID)
+	ret0, _ := ret[0].([]models.InterestRateOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountInterestRateOverrides indicates an expected call of ListAccountInterestRateOverrides.
+func (mr *MockQuerierMockRecorder) ListAccountInterestRateOverrides(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountInterestRateOverrides", reflect.TypeOf((*MockQuerier)(nil).ListAccountInterestRateOverrides), ctx, accountID)
+}
+
+// ListInterestRateCards mocks base method.
+func (m *MockQuerier) ListInterestRateCards(ctx context.Context) ([]models.InterestRateCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInterestRateCards", ctx)
+	ret0, _ := ret[0].([]models.InterestRateCard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInterestRateCards indicates an expected call of ListInterestRateCards.
+func (mr *MockQuerierMockRecorder) ListInterestRateCards(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInterestRateCards", reflect.TypeOf((*MockQuerier)(nil).ListInterestRateCards), ctx)
+}
+
+// ListInterestRateOverrides mocks base method.
+func (m *MockQuerier) ListInterestRateOverrides(ctx context.Context, arg models.ListInterestRateOverridesParams) ([]models.InterestRateOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInterestRateOverrides", ctx, arg)
+	ret0, _ := ret[0].([]models.InterestRateOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInterestRateOverrides indicates an expected call of ListInterestRateOverrides.
+func (mr *MockQuerierMockRecorder) ListInterestRateOverrides(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInterestRateOverrides", reflect.TypeOf((*MockQuerier)(nil).ListInterestRateOverrides), ctx, arg)
+}
+
+// ListInterestRuns mocks base method.
+func (m *MockQuerier) ListInterestRuns(ctx context.Context, status sql.NullString) ([]models.InterestRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInterestRuns", ctx, status)
+	ret0, _ := ret[0].([]models.InterestRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInterestRuns indicates an expected call of ListInterestRuns.
+func (mr *MockQuerierMockRecorder) ListInterestRuns(ctx, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInterestRuns", reflect.TypeOf((*MockQuerier)(nil).ListInterestRuns), ctx, status)
+}
+
+// MarkAccountDormant mocks base method.
+func (m *MockQuerier) MarkAccountDormant(ctx context.Context, id uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAccountDormant", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkAccountDormant indicates an expected call of MarkAccountDormant.
+func (mr *MockQuerierMockRecorder) MarkAccountDormant(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAccountDormant", reflect.TypeOf((*MockQuerier)(nil).MarkAccountDormant), ctx, id)
+}
+
+// MarkAuthorizationCodeUsed mocks base method.
+func (m *MockQuerier) MarkAuthorizationCodeUsed(ctx context.Context, id uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAuthorizationCodeUsed", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkAuthorizationCodeUsed indicates an expected call of MarkAuthorizationCodeUsed.
+func (mr *MockQuerierMockRecorder) MarkAuthorizationCodeUsed(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAuthorizationCodeUsed", reflect.TypeOf((*MockQuerier)(nil).MarkAuthorizationCodeUsed), ctx, id)
+}
+
+// MarkEmailVerified mocks base method.
+func (m *MockQuerier) MarkEmailVerified(ctx context.Context, arg models.MarkEmailVerifiedParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEmailVerified", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkEmailVerified indicates an expected call of MarkEmailVerified.
+func (mr *MockQuerierMockRecorder) MarkEmailVerified(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEmailVerified", reflect.TypeOf((*MockQuerier)(nil).MarkEmailVerified), ctx, arg)
+}
+
+// MarkMFAChallengeUsed mocks base method.
+func (m *MockQuerier) MarkMFAChallengeUsed(ctx context.Context, id uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkMFAChallengeUsed", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkMFAChallengeUsed indicates an expected call of MarkMFAChallengeUsed.
+func (mr *MockQuerierMockRecorder) MarkMFAChallengeUsed(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkMFAChallengeUsed", reflect.TypeOf((*MockQuerier)(nil).MarkMFAChallengeUsed), ctx, id)
+}
+
+// MarkNotificationRead mocks base method.
+func (m *MockQuerier) MarkNotificationRead(ctx context.Context, arg models.MarkNotificationReadParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationRead", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkNotificationRead indicates an expected call of MarkNotificationRead.
+func (mr *MockQuerierMockRecorder) MarkNotificationRead(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationRead", reflect.TypeOf((*MockQuerier)(nil).MarkNotificationRead), ctx, arg)
+}
+
+// MarkRefreshTokenUsed mocks base method.
+func (m *MockQuerier) MarkRefreshTokenUsed(ctx context.Context, id uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRefreshTokenUsed", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkRefreshTokenUsed indicates an expected call of MarkRefreshTokenUsed.
+func (mr *MockQuerierMockRecorder) MarkRefreshTokenUsed(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRefreshTokenUsed", reflect.TypeOf((*MockQuerier)(nil).MarkRefreshTokenUsed), ctx, id)
+}
+
+// MarkUserTokenUsed mocks base method.
+func (m *MockQuerier) MarkUserTokenUsed(ctx context.Context, id uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUserTokenUsed", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkUserTokenUsed indicates an expected call of MarkUserTokenUsed.
+func (mr *MockQuerierMockRecorder) MarkUserTokenUsed(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUserTokenUsed", reflect.TypeOf((*MockQuerier)(nil).MarkUserTokenUsed), ctx, id)
+}
+
+// ReactivateDormantAccount mocks base method.
+func (m *MockQuerier) ReactivateDormantAccount(ctx context.Context, id uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReactivateDormantAccount", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReactivateDormantAccount indicates an expected call of ReactivateDormantAccount.
+func (mr *MockQuerierMockRecorder) ReactivateDormantAccount(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReactivateDormantAccount", reflect.TypeOf((*MockQuerier)(nil).ReactivateDormantAccount), ctx, id)
+}
+
+// ReactivateUser mocks base method.
+func (m *MockQuerier) ReactivateUser(ctx context.Context, id uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReactivateUser", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReactivateUser indicates an expected call of ReactivateUser.
+func (mr *MockQuerierMockRecorder) ReactivateUser(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReactivateUser", reflect.TypeOf((*MockQuerier)(nil).ReactivateUser), ctx, id)
+}
+
+// RehashUserPassword mocks base method.
+func (m *MockQuerier) RehashUserPassword(ctx context.Context, arg models.RehashUserPasswordParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RehashUserPassword", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RehashUserPassword indicates an expected call of RehashUserPassword.
+func (mr *MockQuerierMockRecorder) RehashUserPassword(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RehashUserPassword", reflect.TypeOf((*MockQuerier)(nil).RehashUserPassword), ctx, arg)
+}
+
+// RequirePasswordChange mocks base method.
+func (m *MockQuerier) RequirePasswordChange(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequirePasswordChange", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequirePasswordChange indicates an expected call of RequirePasswordChange.
+func (mr *MockQuerierMockRecorder) RequirePasswordChange(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequirePasswordChange", reflect.TypeOf((*MockQuerier)(nil).RequirePasswordChange), ctx, id)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockQuerier) RevokeAPIKey(ctx context.Context, arg models.RevokeAPIKeyParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockQuerierMockRecorder) RevokeAPIKey(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockQuerier)(nil).RevokeAPIKey), ctx, arg)
+}
+
+// RevokeAccessToken mocks base method.
+func (m *MockQuerier) RevokeAccessToken(ctx context.Context, arg models.RevokeAccessTokenParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAccessToken", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAccessToken indicates an expected call of RevokeAccessToken.
+func (mr *MockQuerierMockRecorder) RevokeAccessToken(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAccessToken", reflect.TypeOf((*MockQuerier)(nil).RevokeAccessToken), ctx, arg)
+}
+
+// RevokeOAuthClient mocks base method.
+func (m *MockQuerier) RevokeOAuthClient(ctx context.Context, id uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOAuthClient", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeOAuthClient indicates an expected call of RevokeOAuthClient.
+func (mr *MockQuerierMockRecorder) RevokeOAuthClient(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOAuthClient", reflect.TypeOf((*MockQuerier)(nil).RevokeOAuthClient), ctx, id)
+}
+
+// RevokeOAuthClientSessions mocks base method.
+func (m *MockQuerier) RevokeOAuthClientSessions(ctx context.Context, arg models.RevokeOAuthClientSessionsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOAuthClientSessions", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeOAuthClientSessions indicates an expected call of RevokeOAuthClientSessions.
+func (mr *MockQuerierMockRecorder) RevokeOAuthClientSessions(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOAuthClientSessions", reflect.TypeOf((*MockQuerier)(nil).RevokeOAuthClientSessions), ctx, arg)
+}
+
+// RevokeSession mocks base method.
+func (m *MockQuerier) RevokeSession(ctx context.Context, arg models.RevokeSessionParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockQuerierMockRecorder) RevokeSession(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockQuerier)(nil).RevokeSession), ctx, arg)
+}
+
+// RevokeUserSession mocks base method.
+func (m *MockQuerier) RevokeUserSession(ctx context.Context, arg models.RevokeUserSessionParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserSession", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeUserSession indicates an expected call of RevokeUserSession.
+func (mr *MockQuerierMockRecorder) RevokeUserSession(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserSession", reflect.TypeOf((*MockQuerier)(nil).RevokeUserSession), ctx, arg)
+}
+
+// RevokeUserSessions mocks base method.
+func (m *MockQuerier) RevokeUserSessions(ctx context.Context, arg models.RevokeUserSessionsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserSessions", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeUserSessions indicates an expected call of RevokeUserSessions.
+func (mr *MockQuerierMockRecorder) RevokeUserSessions(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserSessions", reflect.TypeOf((*MockQuerier)(nil).RevokeUserSessions), ctx, arg)
+}
+
+// SaveAPIKey mocks base method.
+func (m *MockQuerier) SaveAPIKey(ctx context.Context, arg models.SaveAPIKeyParams) (models.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAPIKey", ctx, arg)
+	ret0, _ := ret[0].(models.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveAPIKey indicates an expected call of SaveAPIKey.
+func (mr *MockQuerierMockRecorder) SaveAPIKey(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAPIKey", reflect.TypeOf((*MockQuerier)(nil).SaveAPIKey), ctx, arg)
+}
+
+// SaveAccount mocks base method.
+func (m *MockQuerier) SaveAccount(ctx context.Context, arg models.SaveAccountParams) (models.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAccount", ctx, arg)
+	ret0, _ := ret[0].(models.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveAccount indicates an expected call of SaveAccount.
+func (mr *MockQuerierMockRecorder) SaveAccount(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAccount", reflect.TypeOf((*MockQuerier)(nil).SaveAccount), ctx, arg)
+}
+
+// SaveApprovalRequest mocks base method.
+func (m *MockQuerier) SaveApprovalRequest(ctx context.Context, arg models.SaveApprovalRequestParams) (models.ApprovalRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveApprovalRequest", ctx, arg)
+	ret0, _ := ret[0].(models.ApprovalRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveApprovalRequest indicates an expected call of SaveApprovalRequest.
+func (mr *MockQuerierMockRecorder) SaveApprovalRequest(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveApprovalRequest", reflect.TypeOf((*MockQuerier)(nil).SaveApprovalRequest), ctx, arg)
+}
+
+// SaveAuditLog mocks base method.
+func (m *MockQuerier) SaveAuditLog(ctx context.Context, arg models.SaveAuditLogParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAuditLog", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAuditLog indicates an expected call of SaveAuditLog.
+func (mr *MockQuerierMockRecorder) SaveAuditLog(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAuditLog", reflect.TypeOf((*MockQuerier)(nil).SaveAuditLog), ctx, arg)
+}
+
+// SaveAuthorizationCode mocks base method.
+func (m *MockQuerier) SaveAuthorizationCode(ctx context.Context, arg models.SaveAuthorizationCodeParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAuthorizationCode", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAuthorizationCode indicates an expected call of SaveAuthorizationCode.
+func (mr *MockQuerierMockRecorder) SaveAuthorizationCode(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAuthorizationCode", reflect.TypeOf((*MockQuerier)(nil).SaveAuthorizationCode), ctx, arg)
+}
+
+// SaveInterestAccrual mocks base method.
+func (m *MockQuerier) SaveInterestAccrual(ctx context.Context, arg models.SaveInterestAccrualParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveInterestAccrual", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveInterestAccrual indicates an expected call of SaveInterestAccrual.
+func (mr *MockQuerierMockRecorder) SaveInterestAccrual(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveInterestAccrual", reflect.TypeOf((*MockQuerier)(nil).SaveInterestAccrual), ctx, arg)
+}
+
+// SaveInterestRate mocks base method.
+func (m *MockQuerier) SaveInterestRate(ctx context.Context, arg models.SaveInterestRateParams) (models.InterestRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveInterestRate", ctx, arg)
+	ret0, _ := ret[0].(models.InterestRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveInterestRate indicates an expected call of SaveInterestRate.
+func (mr *MockQuerierMockRecorder) SaveInterestRate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveInterestRate", reflect.TypeOf((*MockQuerier)(nil).SaveInterestRate), ctx, arg)
+}
+
+// SaveLoginAttempt mocks base method.
+func (m *MockQuerier) SaveLoginAttempt(ctx context.Context, arg models.SaveLoginAttemptParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveLoginAttempt", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveLoginAttempt indicates an expected call of SaveLoginAttempt.
+func (mr *MockQuerierMockRecorder) SaveLoginAttempt(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLoginAttempt", reflect.TypeOf((*MockQuerier)(nil).SaveLoginAttempt), ctx, arg)
+}
+
+// SaveMFAChallenge mocks base method.
+func (m *MockQuerier) SaveMFAChallenge(ctx context.Context, arg models.SaveMFAChallengeParams) (models.MfaChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveMFAChallenge", ctx, arg)
+	ret0, _ := ret[0].(models.MfaChallenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveMFAChallenge indicates an expected call of SaveMFAChallenge.
+func (mr *MockQuerierMockRecorder) SaveMFAChallenge(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMFAChallenge", reflect.TypeOf((*MockQuerier)(nil).SaveMFAChallenge), ctx, arg)
+}
+
+// SaveNotification mocks base method.
+func (m *MockQuerier) SaveNotification(ctx context.Context, arg models.SaveNotificationParams) (models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveNotification", ctx, arg)
+	ret0, _ := ret[0].(models.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAccountBalance indicates an expected call of GetAccountBalance.
-func (mr *MockQuerierMockRecorder) GetAccountBalance(ctx, id any) *gomock.Call {
+// SaveNotification indicates an expected call of SaveNotification.
+func (mr *MockQuerierMockRecorder) SaveNotification(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountBalance", reflect.TypeOf((*MockQuerier)(nil).GetAccountBalance), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveNotification", reflect.TypeOf((*MockQuerier)(nil).SaveNotification), ctx, arg)
 }
 
-// GetAccountByCurrency mocks base method.
-func (m *MockQuerier) GetAccountByCurrency(ctx context.Context, arg models.GetAccountByCurrencyParams) (models.Account, error) {
+// SaveRecoveryCodes mocks base method.
+func (m *MockQuerier) SaveRecoveryCodes(ctx context.Context, arg models.SaveRecoveryCodesParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccountByCurrency", ctx, arg)
-	ret0, _ := ret[0].(models.Account)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "SaveRecoveryCodes", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetAccountByCurrency indicates an expected call of GetAccountByCurrency.
-func (mr *MockQuerierMockRecorder) GetAccountByCurrency(ctx, arg any) *gomock.Call {
+// SaveRecoveryCodes indicates an expected call of SaveRecoveryCodes.
+func (mr *MockQuerierMockRecorder) SaveRecoveryCodes(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountByCurrency", reflect.TypeOf((*MockQuerier)(nil).GetAccountByCurrency), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRecoveryCodes", reflect.TypeOf((*MockQuerier)(nil).SaveRecoveryCodes), ctx, arg)
 }
 
-// GetAccountByID mocks base method.
-func (m *MockQuerier) GetAccountByID(ctx context.Context, id uuid.UUID) (models.GetAccountByIDRow, error) {
+// SaveRefreshToken mocks base method.
+func (m *MockQuerier) SaveRefreshToken(ctx context.Context, arg models.SaveRefreshTokenParams) (models.RefreshToken, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccountByID", ctx, id)
-	ret0, _ := ret[0].(models.GetAccountByIDRow)
+	ret := m.ctrl.Call(m, "SaveRefreshToken", ctx, arg)
+	ret0, _ := ret[0].(models.RefreshToken)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAccountByID indicates an expected call of GetAccountByID.
-func (mr *MockQuerierMockRecorder) GetAccountByID(ctx, id any) *gomock.Call {
+// SaveRefreshToken indicates an expected call of SaveRefreshToken.
+func (mr *MockQuerierMockRecorder) SaveRefreshToken(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountByID", reflect.TypeOf((*MockQuerier)(nil).GetAccountByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRefreshToken", reflect.TypeOf((*MockQuerier)(nil).SaveRefreshToken), ctx, arg)
 }
 
-// GetAccountDetailsByID mocks base method.
-func (m *MockQuerier) GetAccountDetailsByID(ctx context.Context, id uuid.UUID) (models.GetAccountDetailsByIDRow, error) {
+// SaveSession mocks base method.
+func (m *MockQuerier) SaveSession(ctx context.Context, arg models.SaveSessionParams) (models.Session, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccountDetailsByID", ctx, id)
-	ret0, _ := ret[0].(models.GetAccountDetailsByIDRow)
+	ret := m.ctrl.Call(m, "SaveSession", ctx, arg)
+	ret0, _ := ret[0].(models.Session)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAccountDetailsByID indicates an expected call of GetAccountDetailsByID.
-func (mr *MockQuerierMockRecorder) GetAccountDetailsByID(ctx, id any) *gomock.Call {
+// SaveSession indicates an expected call of SaveSession.
+func (mr *MockQuerierMockRecorder) SaveSession(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountDetailsByID", reflect.TypeOf((*MockQuerier)(nil).GetAccountDetailsByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSession", reflect.TypeOf((*MockQuerier)(nil).SaveSession), ctx, arg)
 }
 
-// GetAccountStats mocks base method.
-func (m *MockQuerier) GetAccountStats(ctx context.Context) (models.GetAccountStatsRow, error) {
+// SaveTransaction mocks base method.
+func (m *MockQuerier) SaveTransaction(ctx context.Context, arg models.SaveTransactionParams) (models.Transaction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccountStats", ctx)
-	ret0, _ := ret[0].(models.GetAccountStatsRow)
+	ret := m.ctrl.Call(m, "SaveTransaction", ctx, arg)
+	ret0, _ := ret[0].(models.Transaction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAccountStats indicates an expected call of GetAccountStats.
-func (mr *MockQuerierMockRecorder) GetAccountStats(ctx any) *gomock.Call {
+// SaveTransaction indicates an expected call of SaveTransaction.
+func (mr *MockQuerierMockRecorder) SaveTransaction(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountStats", reflect.TypeOf((*MockQuerier)(nil).GetAccountStats), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTransaction", reflect.TypeOf((*MockQuerier)(nil).SaveTransaction), ctx, arg)
 }
 
-// GetAccountStatusHistory mocks base method.
-func (m *MockQuerier) GetAccountStatusHistory(ctx context.Context, affectedAccountID uuid.NullUUID) ([]models.GetAccountStatusHistoryRow, error) {
+// SaveTransactionReversal mocks base method.
+func (m *MockQuerier) SaveTransactionReversal(ctx context.Context, arg models.SaveTransactionReversalParams) (models.TransactionReversal, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccountStatusHistory", ctx, affectedAccountID)
-	ret0, _ := ret[0].([]models.GetAccountStatusHistoryRow)
+	ret := m.ctrl.Call(m, "SaveTransactionReversal", ctx, arg)
+	ret0, _ := ret[0].(models.TransactionReversal)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAccountStatusHistory indicates an expected call of GetAccountStatusHistory.
-func (mr *MockQuerierMockRecorder) GetAccountStatusHistory(ctx, affectedAccountID any) *gomock.Call {
+// SaveTransactionReversal indicates an expected call of SaveTransactionReversal.
+func (mr *MockQuerierMockRecorder) SaveTransactionReversal(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountStatusHistory", reflect.TypeOf((*MockQuerier)(nil).GetAccountStatusHistory), ctx, affectedAccountID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTransactionReversal", reflect.TypeOf((*MockQuerier)(nil).SaveTransactionReversal), ctx, arg)
 }
 
-// GetAllActiveAccounts mocks base method.
-func (m *MockQuerier) GetAllActiveAccounts(ctx context.Context) ([]models.GetAllActiveAccountsRow, error) {
+// SaveUser mocks base method.
+func (m *MockQuerier) SaveUser(ctx context.Context, arg models.SaveUserParams) (models.SaveUserRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllActiveAccounts", ctx)
-	ret0, _ := ret[0].([]models.GetAllActiveAccountsRow)
+	ret := m.ctrl.Call(m, "SaveUser", ctx, arg)
+	ret0, _ := ret[0].(models.SaveUserRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllActiveAccounts indicates an expected call of GetAllActiveAccounts.
-func (mr *MockQuerierMockRecorder) GetAllActiveAccounts(ctx any) *gomock.Call {
+// SaveUser indicates an expected call of SaveUser.
+func (mr *MockQuerierMockRecorder) SaveUser(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllActiveAccounts", reflect.TypeOf((*MockQuerier)(nil).GetAllActiveAccounts), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUser", reflect.TypeOf((*MockQuerier)(nil).SaveUser), ctx, arg)
 }
 
-// GetAllCurrentAccounts mocks base method.
-func (m *MockQuerier) GetAllCurrentAccounts(ctx context.Context) ([]models.GetAllCurrentAccountsRow, error) {
+// SaveUserToken mocks base method.
+func (m *MockQuerier) SaveUserToken(ctx context.Context, arg models.SaveUserTokenParams) (models.UserToken, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllCurrentAccounts", ctx)
-	ret0, _ := ret[0].([]models.GetAllCurrentAccountsRow)
+	ret := m.ctrl.Call(m, "SaveUserToken", ctx, arg)
+	ret0, _ := ret[0].(models.UserToken)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllCurrentAccounts indicates an expected call of GetAllCurrentAccounts.
-func (mr *MockQuerierMockRecorder) GetAllCurrentAccounts(ctx any) *gomock.Call {
+// SaveUserToken indicates an expected call of SaveUserToken.
+func (mr *MockQuerierMockRecorder) SaveUserToken(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCurrentAccounts", reflect.TypeOf((*MockQuerier)(nil).GetAllCurrentAccounts), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUserToken", reflect.TypeOf((*MockQuerier)(nil).SaveUserToken), ctx, arg)
 }
 
-// GetAuditLogsForAccount mocks base method.
-func (m *MockQuerier) GetAuditLogsForAccount(ctx context.Context, affectedAccountID uuid.NullUUID) ([]models.GetAuditLogsForAccountRow, error) {
+// SearchAccounts mocks base method.
+func (m *MockQuerier) SearchAccounts(ctx context.Context, arg models.SearchAccountsParams) ([]models.SearchAccountsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAuditLogsForAccount", ctx, affectedAccountID)
-	ret0, _ := ret[0].([]models.GetAuditLogsForAccountRow)
+	ret := m.ctrl.Call(m, "SearchAccounts", ctx, arg)
+	ret0, _ := ret[0].([]models.SearchAccountsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAuditLogsForAccount indicates an expected call of GetAuditLogsForAccount.
-func (mr *MockQuerierMockRecorder) GetAuditLogsForAccount(ctx, affectedAccountID any) *gomock.Call {
+// SearchAccounts indicates an expected call of SearchAccounts.
+func (mr *MockQuerierMockRecorder) SearchAccounts(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLogsForAccount", reflect.TypeOf((*MockQuerier)(nil).GetAuditLogsForAccount), ctx, affectedAccountID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchAccounts", reflect.TypeOf((*MockQuerier)(nil).SearchAccounts), ctx, arg)
 }
 
-// GetInterestRates mocks base method.
-func (m *MockQuerier) GetInterestRates(ctx context.Context) ([]models.InterestRate, error) {
+// SetAuthorizationCodeSession mocks base method.
+func (m *MockQuerier) SetAuthorizationCodeSession(ctx context.Context, arg models.SetAuthorizationCodeSessionParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetInterestRates", ctx)
-	ret0, _ := ret[0].([]models.InterestRate)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "SetAuthorizationCodeSession", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetInterestRates indicates an expected call of GetInterestRates.
-func (mr *MockQuerierMockRecorder) GetInterestRates(ctx any) *gomock.Call {
+// SetAuthorizationCodeSession indicates an expected call of SetAuthorizationCodeSession.
+func (mr *MockQuerierMockRecorder) SetAuthorizationCodeSession(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterestRates", reflect.TypeOf((*MockQuerier)(nil).GetInterestRates), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAuthorizationCodeSession", reflect.TypeOf((*MockQuerier)(nil).SetAuthorizationCodeSession), ctx, arg)
 }
 
-// GetProfileByUserID mocks base method.
-func (m *MockQuerier) GetProfileByUserID(ctx context.Context, id uuid.UUID) (models.GetProfileByUserIDRow, error) {
+// SetInterestCarry mocks base method.
+func (m *MockQuerier) SetInterestCarry(ctx context.Context, arg models.SetInterestCarryParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProfileByUserID", ctx, id)
-	ret0, _ := ret[0].(models.GetProfileByUserIDRow)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "SetInterestCarry", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetProfileByUserID indicates an expected call of GetProfileByUserID.
-func (mr *MockQuerierMockRecorder) GetProfileByUserID(ctx, id any) *gomock.Call {
+// SetInterestCarry indicates an expected call of SetInterestCarry.
+func (mr *MockQuerierMockRecorder) SetInterestCarry(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfileByUserID", reflect.TypeOf((*MockQuerier)(nil).GetProfileByUserID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetInterestCarry", reflect.TypeOf((*MockQuerier)(nil).SetInterestCarry), ctx, arg)
 }
 
-// GetTransactionByID mocks base method.
-func (m *MockQuerier) GetTransactionByID(ctx context.Context, id uuid.UUID) (models.Transaction, error) {
+// SetInterestRunAccountTaxTransaction mocks base method.
+func (m *MockQuerier) SetInterestRunAccountTaxTransaction(ctx context.Context, arg models.SetInterestRunAccountTaxTransactionParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTransactionByID", ctx, id)
-	ret0, _ := ret[0].(models.Transaction)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "SetInterestRunAccountTaxTransaction", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetTransactionByID indicates an expected call of GetTransactionByID.
-func (mr *MockQuerierMockRecorder) GetTransactionByID(ctx, id any) *gomock.Call {
+// SetInterestRunAccountTaxTransaction indicates an expected call of SetInterestRunAccountTaxTransaction.
+func (mr *MockQuerierMockRecorder) SetInterestRunAccountTaxTransaction(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionByID", reflect.TypeOf((*MockQuerier)(nil).GetTransactionByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetInterestRunAccountTaxTransaction", reflect.TypeOf((*MockQuerier)(nil).SetInterestRunAccountTaxTransaction), ctx, arg)
 }
 
-// GetTransactionsByAccountID mocks base method.
-func (m *MockQuerier) GetTransactionsByAccountID(ctx context.Context, fromAccountID uuid.UUID) ([]models.GetTransactionsByAccountIDRow, error) {
+// SetInterestRunAccountTransaction mocks base method.
+func (m *MockQuerier) SetInterestRunAccountTransaction(ctx context.Context, arg models.SetInterestRunAccountTransactionParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTransactionsByAccountID", ctx, fromAccountID)
-	ret0, _ := ret[0].([]models.GetTransactionsByAccountIDRow)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "SetInterestRunAccountTransaction", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetTransactionsByAccountID indicates an expected call of GetTransactionsByAccountID.
-func (mr *MockQuerierMockRecorder) GetTransactionsByAccountID(ctx, fromAccountID any) *gomock.Call {
+// SetInterestRunAccountTransaction indicates an expected call of SetInterestRunAccountTransaction.
+func (mr *MockQuerierMockRecorder) SetInterestRunAccountTransaction(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionsByAccountID", reflect.TypeOf((*MockQuerier)(nil).GetTransactionsByAccountID), ctx, fromAccountID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetInterestRunAccountTransaction", reflect.TypeOf((*MockQuerier)(nil).SetInterestRunAccountTransaction), ctx, arg)
 }
 
-// GetUserByEmail mocks base method.
-func (m *MockQuerier) GetUserByEmail(ctx context.Context, email string) (models.GetUserByEmailRow, error) {
+// SetPendingEmail mocks base method.
+func (m *MockQuerier) SetPendingEmail(ctx context.Context, arg models.SetPendingEmailParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
-	ret0, _ := ret[0].(models.GetUserByEmailRow)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "SetPendingEmail", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetUserByEmail indicates an expected call of GetUserByEmail.
-func (mr *MockQuerierMockRecorder) GetUserByEmail(ctx, email any) *gomock.Call {
+// SetPendingEmail indicates an expected call of SetPendingEmail.
+func (mr *MockQuerierMockRecorder) SetPendingEmail(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockQuerier)(nil).GetUserByEmail), ctx, email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPendingEmail", reflect.TypeOf((*MockQuerier)(nil).SetPendingEmail), ctx, arg)
 }
 
-// GetUserByID mocks base method.
-func (m *MockQuerier) GetUserByID(ctx context.Context, id uuid.UUID) (models.GetUserByIDRow, error) {
+// SetSessionMFAVerified mocks base method.
+func (m *MockQuerier) SetSessionMFAVerified(ctx context.Context, arg models.SetSessionMFAVerifiedParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
-	ret0, _ := ret[0].(models.GetUserByIDRow)
+	ret := m.ctrl.Call(m, "SetSessionMFAVerified", ctx, arg)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserByID indicates an expected call of GetUserByID.
-func (mr *MockQuerierMockRecorder) GetUserByID(ctx, id any) *gomock.Call {
+// SetSessionMFAVerified indicates an expected call of SetSessionMFAVerified.
+func (mr *MockQuerierMockRecorder) SetSessionMFAVerified(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockQuerier)(nil).GetUserByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSessionMFAVerified", reflect.TypeOf((*MockQuerier)(nil).SetSessionMFAVerified), ctx, arg)
 }
 
-// SaveAccount mocks base method.
-func (m *MockQuerier) SaveAccount(ctx context.Context, arg models.SaveAccountParams) (models.Account, error) {
+// SetTransactionReversalID mocks base method.
+func (m *MockQuerier) SetTransactionReversalID(ctx context.Context, arg models.SetTransactionReversalIDParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveAccount", ctx, arg)
-	ret0, _ := ret[0].(models.Account)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "SetTransactionReversalID", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// SaveAccount indicates an expected call of SaveAccount.
-func (mr *MockQuerierMockRecorder) SaveAccount(ctx, arg any) *gomock.Call {
+// SetTransactionReversalID indicates an expected call of SetTransactionReversalID.
+func (mr *MockQuerierMockRecorder) SetTransactionReversalID(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAccount", reflect.TypeOf((*MockQuerier)(nil).SaveAccount), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTransactionReversalID", reflect.TypeOf((*MockQuerier)(nil).SetTransactionReversalID), ctx, arg)
 }
 
-// SaveAuditLog mocks base method.
-func (m *MockQuerier) SaveAuditLog(ctx context.Context, arg models.SaveAuditLogParams) error {
+// SetUserRoles mocks base method.
+func (m *MockQuerier) SetUserRoles(ctx context.Context, arg models.SetUserRolesParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveAuditLog", ctx, arg)
+	ret := m.ctrl.Call(m, "SetUserRoles", ctx, arg)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// SaveAuditLog indicates an expected call of SaveAuditLog.
-func (mr *MockQuerierMockRecorder) SaveAuditLog(ctx, arg any) *gomock.Call {
+// SetUserRoles indicates an expected call of SetUserRoles.
+func (mr *MockQuerierMockRecorder) SetUserRoles(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAuditLog", reflect.TypeOf((*MockQuerier)(nil).SaveAuditLog), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserRoles", reflect.TypeOf((*MockQuerier)(nil).SetUserRoles), ctx, arg)
 }
 
-// SaveInterestRate mocks base method.
-func (m *MockQuerier) SaveInterestRate(ctx context.Context, arg models.SaveInterestRateParams) (models.InterestRate, error) {
+// SetUserTaxStatus mocks base method.
+func (m *MockQuerier) SetUserTaxStatus(ctx context.Context, arg models.SetUserTaxStatusParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveInterestRate", ctx, arg)
-	ret0, _ := ret[0].(models.InterestRate)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "SetUserTaxStatus", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// SaveInterestRate indicates an expected call of SaveInterestRate.
-func (mr *MockQuerierMockRecorder) SaveInterestRate(ctx, arg any) *gomock.Call {
+// SetUserTaxStatus indicates an expected call of SetUserTaxStatus.
+func (mr *MockQuerierMockRecorder) SetUserTaxStatus(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveInterestRate", reflect.TypeOf((*MockQuerier)(nil).SaveInterestRate), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserTaxStatus", reflect.TypeOf((*MockQuerier)(nil).SetUserTaxStatus), ctx, arg)
 }
 
-// SaveTransaction mocks base method.
-func (m *MockQuerier) SaveTransaction(ctx context.Context, arg models.SaveTransactionParams) (models.Transaction, error) {
+// StartInterestRun mocks base method.
+func (m *MockQuerier) StartInterestRun(ctx context.Context, arg models.StartInterestRunParams) (models.InterestRun, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveTransaction", ctx, arg)
-	ret0, _ := ret[0].(models.Transaction)
+	ret := m.ctrl.Call(m, "StartInterestRun", ctx, arg)
+	ret0, _ := ret[0].(models.InterestRun)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SaveTransaction indicates an expected call of SaveTransaction.
-func (mr *MockQuerierMockRecorder) SaveTransaction(ctx, arg any) *gomock.Call {
+// StartInterestRun indicates an expected call of StartInterestRun.
+func (mr *MockQuerierMockRecorder) StartInterestRun(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTransaction", reflect.TypeOf((*MockQuerier)(nil).SaveTransaction), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartInterestRun", reflect.TypeOf((*MockQuerier)(nil).StartInterestRun), ctx, arg)
 }
 
-// SaveUser mocks base method.
-func (m *MockQuerier) SaveUser(ctx context.Context, arg models.SaveUserParams) (models.SaveUserRow, error) {
+// SuspendUserAccounts mocks base method.
+func (m *MockQuerier) SuspendUserAccounts(ctx context.Context, userID uuid.UUID) ([]models.SuspendUserAccountsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveUser", ctx, arg)
-	ret0, _ := ret[0].(models.SaveUserRow)
+	ret := m.ctrl.Call(m, "SuspendUserAccounts", ctx, userID)
+	ret0, _ := ret[0].([]models.SuspendUserAccountsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SaveUser indicates an expected call of SaveUser.
-func (mr *MockQuerierMockRecorder) SaveUser(ctx, arg any) *gomock.Call {
+// SuspendUserAccounts indicates an expected call of SuspendUserAccounts.
+func (mr *MockQuerierMockRecorder) SuspendUserAccounts(ctx, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUser", reflect.TypeOf((*MockQuerier)(nil).SaveUser), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuspendUserAccounts", reflect.TypeOf((*MockQuerier)(nil).SuspendUserAccounts), ctx, userID)
+}
+
+// TouchAPIKey mocks base method.
+func (m *MockQuerier) TouchAPIKey(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchAPIKey", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchAPIKey indicates an expected call of TouchAPIKey.
+func (mr *MockQuerierMockRecorder) TouchAPIKey(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchAPIKey", reflect.TypeOf((*MockQuerier)(nil).TouchAPIKey), ctx, id)
+}
+
+// TouchAccountActivity mocks base method.
+func (m *MockQuerier) TouchAccountActivity(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchAccountActivity", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchAccountActivity indicates an expected call of TouchAccountActivity.
+func (mr *MockQuerierMockRecorder) TouchAccountActivity(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchAccountActivity", reflect.TypeOf((*MockQuerier)(nil).TouchAccountActivity), ctx, id)
+}
+
+// UpdateAccountIdentifiers mocks base method.
+func (m *MockQuerier) UpdateAccountIdentifiers(ctx context.Context, arg models.UpdateAccountIdentifiersParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountIdentifiers", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAccountIdentifiers indicates an expected call of UpdateAccountIdentifiers.
+func (mr *MockQuerierMockRecorder) UpdateAccountIdentifiers(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountIdentifiers", reflect.TypeOf((*MockQuerier)(nil).UpdateAccountIdentifiers), ctx, arg)
 }
 
 // UpdateAccountStatus mocks base method.
@@ -369,30 +2146,119 @@ func (mr *MockQuerierMockRecorder) UpdateBalance(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBalance", reflect.TypeOf((*MockQuerier)(nil).UpdateBalance), ctx, id)
 }
 
-// UpdateCalculationFrequency mocks base method.
-func (m *MockQuerier) UpdateCalculationFrequency(ctx context.Context, arg models.UpdateCalculationFrequencyParams) error {
+// UpdateInterestRateCard mocks base method.
+func (m *MockQuerier) UpdateInterestRateCard(ctx context.Context, arg models.UpdateInterestRateCardParams) (models.InterestRateCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInterestRateCard", ctx, arg)
+	ret0, _ := ret[0].(models.InterestRateCard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateInterestRateCard indicates an expected call of UpdateInterestRateCard.
+func (mr *MockQuerierMockRecorder) UpdateInterestRateCard(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInterestRateCard", reflect.TypeOf((*MockQuerier)(nil).UpdateInterestRateCard), ctx, arg)
+}
+
+// UpdateInterestRateOverride mocks base method.
+func (m *MockQuerier) UpdateInterestRateOverride(ctx context.Context, arg models.UpdateInterestRateOverrideParams) (models.InterestRateOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInterestRateOverride", ctx, arg)
+	ret0, _ := ret[0].(models.InterestRateOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateInterestRateOverride indicates an expected call of UpdateInterestRateOverride.
+func (mr *MockQuerierMockRecorder) UpdateInterestRateOverride(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInterestRateOverride", reflect.TypeOf((*MockQuerier)(nil).UpdateInterestRateOverride), ctx, arg)
+}
+
+// UpdateMFALastUsedStep mocks base method.
+func (m *MockQuerier) UpdateMFALastUsedStep(ctx context.Context, arg models.UpdateMFALastUsedStepParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCalculationFrequency", ctx, arg)
+	ret := m.ctrl.Call(m, "UpdateMFALastUsedStep", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMFALastUsedStep indicates an expected call of UpdateMFALastUsedStep.
+func (mr *MockQuerierMockRecorder) UpdateMFALastUsedStep(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMFALastUsedStep", reflect.TypeOf((*MockQuerier)(nil).UpdateMFALastUsedStep), ctx, arg)
+}
+
+// UpdateScheduledCalculationFrequency mocks base method.
+func (m *MockQuerier) UpdateScheduledCalculationFrequency(ctx context.Context, arg models.UpdateScheduledCalculationFrequencyParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScheduledCalculationFrequency", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateScheduledCalculationFrequency indicates an expected call of UpdateScheduledCalculationFrequency.
+func (mr *MockQuerierMockRecorder) UpdateScheduledCalculationFrequency(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScheduledCalculationFrequency", reflect.TypeOf((*MockQuerier)(nil).UpdateScheduledCalculationFrequency), ctx, arg)
+}
+
+// UpdateUserName mocks base method.
+func (m *MockQuerier) UpdateUserName(ctx context.Context, arg models.UpdateUserNameParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserName", ctx, arg)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateCalculationFrequency indicates an expected call of UpdateCalculationFrequency.
-func (mr *MockQuerierMockRecorder) UpdateCalculationFrequency(ctx, arg any) *gomock.Call {
+// UpdateUserName indicates an expected call of UpdateUserName.
+func (mr *MockQuerierMockRecorder) UpdateUserName(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCalculationFrequency", reflect.TypeOf((*MockQuerier)(nil).UpdateCalculationFrequency), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserName", reflect.TypeOf((*MockQuerier)(nil).UpdateUserName), ctx, arg)
 }
 
-// UpdateRate mocks base method.
-func (m *MockQuerier) UpdateRate(ctx context.Context, arg models.UpdateRateParams) error {
+// UpdateUserPassword mocks base method.
+func (m *MockQuerier) UpdateUserPassword(ctx context.Context, arg models.UpdateUserPasswordParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateRate", ctx, arg)
+	ret := m.ctrl.Call(m, "UpdateUserPassword", ctx, arg)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateRate indicates an expected call of UpdateRate.
-func (mr *MockQuerierMockRecorder) UpdateRate(ctx, arg any) *gomock.Call {
+// UpdateUserPassword indicates an expected call of UpdateUserPassword.
+func (mr *MockQuerierMockRecorder) UpdateUserPassword(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPassword", reflect.TypeOf((*MockQuerier)(nil).UpdateUserPassword), ctx, arg)
+}
+
+// UpsertUserMFA mocks base method.
+func (m *MockQuerier) UpsertUserMFA(ctx context.Context, arg models.UpsertUserMFAParams) (models.UserMfa, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertUserMFA", ctx, arg)
+	ret0, _ := ret[0].(models.UserMfa)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertUserMFA indicates an expected call of UpsertUserMFA.
+func (mr *MockQuerierMockRecorder) UpsertUserMFA(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertUserMFA", reflect.TypeOf((*MockQuerier)(nil).UpsertUserMFA), ctx, arg)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockQuerier) UseRecoveryCode(ctx context.Context, arg models.UseRecoveryCodeParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockQuerierMockRecorder) UseRecoveryCode(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRate", reflect.TypeOf((*MockQuerier)(nil).UpdateRate), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockQuerier)(nil).UseRecoveryCode), ctx, arg)
 }
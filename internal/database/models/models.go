@@ -7,7 +7,9 @@ package models
 import (
 	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/sqlc-dev/pqtype"
@@ -55,6 +57,52 @@ func (ns NullAccountType) Value() (driver.Value, error) {
 	return string(ns.AccountType), nil
 }
 
+type ApprovalStatus string
+
+const (
+	ApprovalStatusPENDING  ApprovalStatus = "PENDING"
+	ApprovalStatusAPPROVED ApprovalStatus = "APPROVED"
+	ApprovalStatusREJECTED ApprovalStatus = "REJECTED"
+	ApprovalStatusEXPIRED  ApprovalStatus = "EXPIRED"
+	ApprovalStatusEXECUTED ApprovalStatus = "EXECUTED"
+	ApprovalStatusFAILED   ApprovalStatus = "FAILED"
+)
+
+func (e *ApprovalStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ApprovalStatus(s)
+	case string:
+		*e = ApprovalStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ApprovalStatus: %T", src)
+	}
+	return nil
+}
+
+type NullApprovalStatus struct {
+	ApprovalStatus ApprovalStatus `json:"approval_status"`
+	Valid          bool           `json:"valid"` // Valid is true if ApprovalStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullApprovalStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ApprovalStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ApprovalStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullApprovalStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ApprovalStatus), nil
+}
+
 type Currency string
 
 const (
@@ -105,6 +153,7 @@ const (
 	StatusACTIVE    Status = "ACTIVE"
 	StatusSUSPENDED Status = "SUSPENDED"
 	StatusCLOSED    Status = "CLOSED"
+	StatusDORMANT   Status = "DORMANT"
 )
 
 func (e *Status) Scan(src interface{}) error {
@@ -148,6 +197,7 @@ const (
 	UserTypeEXTERNAL UserType = "EXTERNAL"
 	UserTypeADMIN    UserType = "ADMIN"
 	UserTypeCUSTOMER UserType = "CUSTOMER"
+	UserTypeSERVICE  UserType = "SERVICE"
 )
 
 func (e *UserType) Scan(src interface{}) error {
@@ -186,16 +236,52 @@ func (ns NullUserType) Value() (driver.Value, error) {
 }
 
 type Account struct {
-	ID            uuid.UUID     `json:"id"`
-	UserID        uuid.UUID     `json:"user_id"`
-	AccountNumber string        `json:"account_number"`
-	AccountType   AccountType   `json:"account_type"`
-	Status        Status        `json:"status"`
-	Currency      Currency      `json:"currency"`
-	CreatedAt     sql.NullTime  `json:"created_at"`
-	UpdatedAt     sql.NullTime  `json:"updated_at"`
-	DeletedAt     sql.NullTime  `json:"deleted_at"`
-	Balance       sql.NullInt64 `json:"balance"`
+	ID             uuid.UUID      `json:"id"`
+	UserID         uuid.UUID      `json:"user_id"`
+	AccountNumber  string         `json:"account_number"`
+	AccountType    AccountType    `json:"account_type"`
+	Status         Status         `json:"status"`
+	Currency       Currency       `json:"currency"`
+	CreatedAt      sql.NullTime   `json:"created_at"`
+	UpdatedAt      sql.NullTime   `json:"updated_at"`
+	DeletedAt      sql.NullTime   `json:"deleted_at"`
+	Balance        sql.NullInt64  `json:"balance"`
+	SortCode       sql.NullString `json:"sort_code"`
+	Iban           sql.NullString `json:"iban"`
+	LastActivityAt sql.NullTime   `json:"last_activity_at"`
+	DormantSince   sql.NullTime   `json:"dormant_since"`
+	InterestCarry  string         `json:"interest_carry"`
+}
+
+type ApiKey struct {
+	ID               uuid.UUID    `json:"id"`
+	ServiceAccountID uuid.UUID    `json:"service_account_id"`
+	Name             string       `json:"name"`
+	Prefix           string       `json:"prefix"`
+	KeyHash          string       `json:"key_hash"`
+	Scopes           []string     `json:"scopes"`
+	AllowedIps       []string     `json:"allowed_ips"`
+	ExpiresAt        sql.NullTime `json:"expires_at"`
+	LastUsedAt       sql.NullTime `json:"last_used_at"`
+	RevokedAt        sql.NullTime `json:"revoked_at"`
+	CreatedBy        uuid.UUID    `json:"created_by"`
+	CreatedAt        sql.NullTime `json:"created_at"`
+}
+
+type ApprovalRequest struct {
+	ID             uuid.UUID             `json:"id"`
+	Operation      string                `json:"operation"`
+	Payload        json.RawMessage       `json:"payload"`
+	Secret         pqtype.NullRawMessage `json:"secret"`
+	Status         ApprovalStatus        `json:"status"`
+	RequestedBy    uuid.UUID             `json:"requested_by"`
+	DecidedBy      uuid.NullUUID         `json:"decided_by"`
+	DecisionReason sql.NullString        `json:"decision_reason"`
+	Result         pqtype.NullRawMessage `json:"result"`
+	Error          sql.NullString        `json:"error"`
+	ExpiresAt      time.Time             `json:"expires_at"`
+	DecidedAt      sql.NullTime          `json:"decided_at"`
+	CreatedAt      sql.NullTime          `json:"created_at"`
 }
 
 type AuditLog struct {
@@ -207,15 +293,215 @@ type AuditLog struct {
 	CreatedAt         sql.NullTime          `json:"created_at"`
 	UpdatedAt         sql.NullTime          `json:"updated_at"`
 	DeletedAt         sql.NullTime          `json:"deleted_at"`
+	ApiKeyID          uuid.NullUUID         `json:"api_key_id"`
+}
+
+type InterestAccrual struct {
+	ID            uuid.UUID     `json:"id"`
+	AccountID     uuid.UUID     `json:"account_id"`
+	AccrualDate   time.Time     `json:"accrual_date"`
+	Balance       int64         `json:"balance"`
+	Rate          int64         `json:"rate"`
+	Amount        string        `json:"amount"`
+	CapitalisedAt sql.NullTime  `json:"capitalised_at"`
+	TransactionID uuid.NullUUID `json:"transaction_id"`
+	CreatedAt     sql.NullTime  `json:"created_at"`
+	DayCount      string        `json:"day_count"`
+	CardID        uuid.NullUUID `json:"card_id"`
+	BonusRate     int64         `json:"bonus_rate"`
+	BonusAmount   string        `json:"bonus_amount"`
 }
 
 type InterestRate struct {
-	ID                   uuid.UUID    `json:"id"`
-	Rate                 int64        `json:"rate"`
-	CalculationFrequency string       `json:"calculation_frequency"`
-	CreatedAt            sql.NullTime `json:"created_at"`
-	UpdatedAt            sql.NullTime `json:"updated_at"`
-	DeletedAt            sql.NullTime `json:"deleted_at"`
+	ID                   uuid.UUID     `json:"id"`
+	Rate                 int64         `json:"rate"`
+	CalculationFrequency string        `json:"calculation_frequency"`
+	CreatedAt            sql.NullTime  `json:"created_at"`
+	UpdatedAt            sql.NullTime  `json:"updated_at"`
+	DeletedAt            sql.NullTime  `json:"deleted_at"`
+	EffectiveFrom        time.Time     `json:"effective_from"`
+	EffectiveTo          sql.NullTime  `json:"effective_to"`
+	CreatedBy            uuid.NullUUID `json:"created_by"`
+}
+
+type InterestRateCard struct {
+	ID                uuid.UUID       `json:"id"`
+	Currency          Currency        `json:"currency"`
+	Product           AccountType     `json:"product"`
+	Method            string          `json:"method"`
+	Bands             json.RawMessage `json:"bands"`
+	BonusRate         int64           `json:"bonus_rate"`
+	BonusConditions   []string        `json:"bonus_conditions"`
+	MinMonthlyDeposit int64           `json:"min_monthly_deposit"`
+	CreatedBy         uuid.UUID       `json:"created_by"`
+	CreatedAt         sql.NullTime    `json:"created_at"`
+	UpdatedAt         sql.NullTime    `json:"updated_at"`
+	DeletedAt         sql.NullTime    `json:"deleted_at"`
+}
+
+type InterestRateOverride struct {
+	ID        uuid.UUID    `json:"id"`
+	AccountID uuid.UUID    `json:"account_id"`
+	Kind      string       `json:"kind"`
+	Rate      int64        `json:"rate"`
+	StartsOn  time.Time    `json:"starts_on"`
+	EndsOn    sql.NullTime `json:"ends_on"`
+	Reason    string       `json:"reason"`
+	CreatedBy uuid.UUID    `json:"created_by"`
+	CreatedAt sql.NullTime `json:"created_at"`
+	UpdatedAt sql.NullTime `json:"updated_at"`
+}
+
+type InterestRun struct {
+	ID         uuid.UUID      `json:"id"`
+	RunDate    time.Time      `json:"run_date"`
+	RateID     uuid.UUID      `json:"rate_id"`
+	Period     sql.NullString `json:"period"`
+	Status     string         `json:"status"`
+	Accounts   int32          `json:"accounts"`
+	Failed     int32          `json:"failed"`
+	StartedAt  time.Time      `json:"started_at"`
+	FinishedAt sql.NullTime   `json:"finished_at"`
+}
+
+type InterestRunAccount struct {
+	ID               uuid.UUID      `json:"id"`
+	RunID            uuid.UUID      `json:"run_id"`
+	AccountID        uuid.UUID      `json:"account_id"`
+	RunDate          time.Time      `json:"run_date"`
+	Status           string         `json:"status"`
+	Accrued          string         `json:"accrued"`
+	Paid             int64          `json:"paid"`
+	TransactionID    uuid.NullUUID  `json:"transaction_id"`
+	Error            sql.NullString `json:"error"`
+	Attempts         int32          `json:"attempts"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	Tax              int64          `json:"tax"`
+	TaxRate          int64          `json:"tax_rate"`
+	TaxTransactionID uuid.NullUUID  `json:"tax_transaction_id"`
+}
+
+type LoginAttempt struct {
+	ID        uuid.UUID      `json:"id"`
+	UserID    uuid.NullUUID  `json:"user_id"`
+	Email     string         `json:"email"`
+	Ip        sql.NullString `json:"ip"`
+	UserAgent sql.NullString `json:"user_agent"`
+	Outcome   string         `json:"outcome"`
+	SessionID uuid.NullUUID  `json:"session_id"`
+	CreatedAt sql.NullTime   `json:"created_at"`
+}
+
+type MfaChallenge struct {
+	ID        uuid.UUID    `json:"id"`
+	UserID    uuid.UUID    `json:"user_id"`
+	TokenHash string       `json:"token_hash"`
+	Attempts  int32        `json:"attempts"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	CreatedAt sql.NullTime `json:"created_at"`
+}
+
+type MfaRecoveryCode struct {
+	ID        uuid.UUID    `json:"id"`
+	UserID    uuid.UUID    `json:"user_id"`
+	CodeHash  string       `json:"code_hash"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	CreatedAt sql.NullTime `json:"created_at"`
+}
+
+type Notification struct {
+	ID        uuid.UUID             `json:"id"`
+	UserID    uuid.UUID             `json:"user_id"`
+	Type      string                `json:"type"`
+	Subject   string                `json:"subject"`
+	Message   string                `json:"message"`
+	Metadata  pqtype.NullRawMessage `json:"metadata"`
+	ReadAt    sql.NullTime          `json:"read_at"`
+	CreatedAt sql.NullTime          `json:"created_at"`
+	UpdatedAt sql.NullTime          `json:"updated_at"`
+	DeletedAt sql.NullTime          `json:"deleted_at"`
+}
+
+type OauthAuthorizationCode struct {
+	ID            uuid.UUID      `json:"id"`
+	CodeHash      string         `json:"code_hash"`
+	ClientID      uuid.UUID      `json:"client_id"`
+	UserID        uuid.UUID      `json:"user_id"`
+	RedirectUri   string         `json:"redirect_uri"`
+	Scopes        []string       `json:"scopes"`
+	CodeChallenge string         `json:"code_challenge"`
+	Nonce         sql.NullString `json:"nonce"`
+	ExpiresAt     time.Time      `json:"expires_at"`
+	UsedAt        sql.NullTime   `json:"used_at"`
+	SessionID     uuid.NullUUID  `json:"session_id"`
+	CreatedAt     sql.NullTime   `json:"created_at"`
+}
+
+type OauthClient struct {
+	ID           uuid.UUID      `json:"id"`
+	Name         string         `json:"name"`
+	SecretHash   sql.NullString `json:"secret_hash"`
+	RedirectUris []string       `json:"redirect_uris"`
+	Scopes       []string       `json:"scopes"`
+	CreatedBy    uuid.UUID      `json:"created_by"`
+	RevokedAt    sql.NullTime   `json:"revoked_at"`
+	CreatedAt    sql.NullTime   `json:"created_at"`
+}
+
+type Permission struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type RefreshToken struct {
+	ID        uuid.UUID    `json:"id"`
+	SessionID uuid.UUID    `json:"session_id"`
+	TokenHash string       `json:"token_hash"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	CreatedAt sql.NullTime `json:"created_at"`
+}
+
+type RevokedAccessToken struct {
+	Jti       uuid.UUID    `json:"jti"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	CreatedAt sql.NullTime `json:"created_at"`
+}
+
+type Role struct {
+	ID          uuid.UUID    `json:"id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	CreatedAt   sql.NullTime `json:"created_at"`
+}
+
+type RolePermission struct {
+	RoleID     uuid.UUID `json:"role_id"`
+	Permission string    `json:"permission"`
+}
+
+type ServiceAccount struct {
+	ID          uuid.UUID    `json:"id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	CreatedBy   uuid.UUID    `json:"created_by"`
+	CreatedAt   sql.NullTime `json:"created_at"`
+}
+
+type Session struct {
+	ID                uuid.UUID      `json:"id"`
+	UserID            uuid.UUID      `json:"user_id"`
+	RevokedAt         sql.NullTime   `json:"revoked_at"`
+	RevokedReason     sql.NullString `json:"revoked_reason"`
+	CreatedAt         sql.NullTime   `json:"created_at"`
+	MfaVerifiedAt     sql.NullTime   `json:"mfa_verified_at"`
+	Ip                sql.NullString `json:"ip"`
+	UserAgent         sql.NullString `json:"user_agent"`
+	DeviceFingerprint sql.NullString `json:"device_fingerprint"`
+	ClientID          uuid.NullUUID  `json:"client_id"`
+	Scopes            []string       `json:"scopes"`
 }
 
 type Transaction struct {
@@ -232,14 +518,53 @@ type Transaction struct {
 	DeletedAt       sql.NullTime   `json:"deleted_at"`
 }
 
+type TransactionReversal struct {
+	TransactionID uuid.UUID     `json:"transaction_id"`
+	ReversalID    uuid.NullUUID `json:"reversal_id"`
+	Reason        string        `json:"reason"`
+	ReversedBy    uuid.UUID     `json:"reversed_by"`
+	CreatedAt     sql.NullTime  `json:"created_at"`
+}
+
 type User struct {
-	ID        uuid.UUID    `json:"id"`
-	Email     string       `json:"email"`
-	Password  string       `json:"password"`
-	FirstName string       `json:"first_name"`
-	LastName  string       `json:"last_name"`
-	UserType  UserType     `json:"user_type"`
+	ID                     uuid.UUID      `json:"id"`
+	Email                  string         `json:"email"`
+	Password               string         `json:"password"`
+	FirstName              string         `json:"first_name"`
+	LastName               string         `json:"last_name"`
+	UserType               UserType       `json:"user_type"`
+	CreatedAt              sql.NullTime   `json:"created_at"`
+	UpdatedAt              sql.NullTime   `json:"updated_at"`
+	DeletedAt              sql.NullTime   `json:"deleted_at"`
+	EmailVerifiedAt        sql.NullTime   `json:"email_verified_at"`
+	PendingEmail           sql.NullString `json:"pending_email"`
+	PasswordChangeRequired bool           `json:"password_change_required"`
+	TaxResidency           sql.NullString `json:"tax_residency"`
+	TaxExempt              bool           `json:"tax_exempt"`
+}
+
+type UserMfa struct {
+	UserID       uuid.UUID    `json:"user_id"`
+	Secret       string       `json:"secret"`
+	EnabledAt    sql.NullTime `json:"enabled_at"`
+	LastUsedStep int64        `json:"last_used_step"`
+	CreatedAt    sql.NullTime `json:"created_at"`
+	UpdatedAt    sql.NullTime `json:"updated_at"`
+}
+
+type UserRole struct {
+	UserID    uuid.UUID    `json:"user_id"`
+	RoleID    uuid.UUID    `json:"role_id"`
 	CreatedAt sql.NullTime `json:"created_at"`
-	UpdatedAt sql.NullTime `json:"updated_at"`
-	DeletedAt sql.NullTime `json:"deleted_at"`
+}
+
+type UserToken struct {
+	ID        uuid.UUID      `json:"id"`
+	UserID    uuid.UUID      `json:"user_id"`
+	Purpose   string         `json:"purpose"`
+	TokenHash string         `json:"token_hash"`
+	Email     sql.NullString `json:"email"`
+	ExpiresAt time.Time      `json:"expires_at"`
+	UsedAt    sql.NullTime   `json:"used_at"`
+	CreatedAt sql.NullTime   `json:"created_at"`
 }
@@ -6,35 +6,163 @@ package models
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	CancelInterestRate(ctx context.Context, id uuid.UUID) error
+	CapitaliseInterestAccruals(ctx context.Context, arg CapitaliseInterestAccrualsParams) (int64, error)
+	CheckSessionDevice(ctx context.Context, arg CheckSessionDeviceParams) (CheckSessionDeviceRow, error)
+	ClaimInterestRunAccount(ctx context.Context, arg ClaimInterestRunAccountParams) (InterestRunAccount, error)
+	CloseInterestRate(ctx context.Context, arg CloseInterestRateParams) error
+	CompleteApprovalRequest(ctx context.Context, arg CompleteApprovalRequestParams) (ApprovalRequest, error)
+	CompleteInterestRunAccount(ctx context.Context, arg CompleteInterestRunAccountParams) error
+	ConfirmPendingEmail(ctx context.Context, arg ConfirmPendingEmailParams) (int64, error)
+	CountOverlappingInterestRateOverrides(ctx context.Context, arg CountOverlappingInterestRateOverridesParams) (int64, error)
+	CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int64, error)
+	CreateInterestRateCard(ctx context.Context, arg CreateInterestRateCardParams) (InterestRateCard, error)
+	CreateInterestRateOverride(ctx context.Context, arg CreateInterestRateOverrideParams) (InterestRateOverride, error)
+	CreateOAuthClient(ctx context.Context, arg CreateOAuthClientParams) (OauthClient, error)
+	CreateServiceAccount(ctx context.Context, arg CreateServiceAccountParams) (ServiceAccount, error)
+	DeactivateUser(ctx context.Context, id uuid.UUID) (int64, error)
+	DecideApprovalRequest(ctx context.Context, arg DecideApprovalRequestParams) (int64, error)
+	DeleteInterestRateCard(ctx context.Context, id uuid.UUID) (int64, error)
+	DeleteInterestRateOverride(ctx context.Context, id uuid.UUID) (int64, error)
+	DeleteRecoveryCodes(ctx context.Context, userID uuid.UUID) error
+	DeleteTransactionReversal(ctx context.Context, transactionID uuid.UUID) error
+	DeleteUserMFA(ctx context.Context, userID uuid.UUID) (int64, error)
+	EnableUserMFA(ctx context.Context, userID uuid.UUID) (int64, error)
+	ExpireApprovalRequests(ctx context.Context, now time.Time) ([]ApprovalRequest, error)
+	FailInterestRunAccount(ctx context.Context, arg FailInterestRunAccountParams) error
+	FinishInterestRun(ctx context.Context, id uuid.UUID) (InterestRun, error)
+	ForfeitInterestBonus(ctx context.Context, arg ForfeitInterestBonusParams) (int64, error)
+	GetAPIKey(ctx context.Context, arg GetAPIKeyParams) (ApiKey, error)
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (GetAPIKeyByHashRow, error)
+	GetAPIKeys(ctx context.Context, serviceAccountID uuid.UUID) ([]ApiKey, error)
 	GetAccountBalance(ctx context.Context, id uuid.UUID) (GetAccountBalanceRow, error)
+	GetAccountBalanceAt(ctx context.Context, arg GetAccountBalanceAtParams) (int64, error)
 	GetAccountByCurrency(ctx context.Context, arg GetAccountByCurrencyParams) (Account, error)
+	GetAccountByIBAN(ctx context.Context, iban sql.NullString) (GetAccountByIBANRow, error)
 	GetAccountByID(ctx context.Context, id uuid.UUID) (GetAccountByIDRow, error)
+	GetAccountByNumber(ctx context.Context, accountNumber string) (GetAccountByNumberRow, error)
 	GetAccountDetailsByID(ctx context.Context, id uuid.UUID) (GetAccountDetailsByIDRow, error)
+	GetAccountFlows(ctx context.Context, arg GetAccountFlowsParams) (GetAccountFlowsRow, error)
 	GetAccountStats(ctx context.Context) (GetAccountStatsRow, error)
 	GetAccountStatusHistory(ctx context.Context, affectedAccountID uuid.NullUUID) ([]GetAccountStatusHistoryRow, error)
+	GetAccountTaxStatus(ctx context.Context, id uuid.UUID) (GetAccountTaxStatusRow, error)
+	GetAccountUncapitalisedInterest(ctx context.Context, arg GetAccountUncapitalisedInterestParams) (GetAccountUncapitalisedInterestRow, error)
+	GetAccountsMissingIdentifiers(ctx context.Context) ([]GetAccountsMissingIdentifiersRow, error)
 	GetAllActiveAccounts(ctx context.Context) ([]GetAllActiveAccountsRow, error)
 	GetAllCurrentAccounts(ctx context.Context) ([]GetAllCurrentAccountsRow, error)
+	GetApprovalRequest(ctx context.Context, id uuid.UUID) (ApprovalRequest, error)
+	GetApprovalRequests(ctx context.Context, status NullApprovalStatus) ([]ApprovalRequest, error)
 	GetAuditLogsForAccount(ctx context.Context, affectedAccountID uuid.NullUUID) ([]GetAuditLogsForAccountRow, error)
+	GetAuthorizationCodeByHash(ctx context.Context, codeHash string) (OauthAuthorizationCode, error)
+	GetDormancyCandidates(ctx context.Context, lastActivityAt sql.NullTime) ([]GetDormancyCandidatesRow, error)
+	GetDormantAccounts(ctx context.Context) ([]GetDormantAccountsRow, error)
+	GetInterestPayments(ctx context.Context, arg GetInterestPaymentsParams) ([]GetInterestPaymentsRow, error)
+	GetInterestRateAt(ctx context.Context, day time.Time) (InterestRate, error)
+	GetInterestRateCard(ctx context.Context, id uuid.UUID) (InterestRateCard, error)
+	GetInterestRateCardByProduct(ctx context.Context, arg GetInterestRateCardByProductParams) (InterestRateCard, error)
+	GetInterestRateOverride(ctx context.Context, arg GetInterestRateOverrideParams) (InterestRateOverride, error)
 	GetInterestRates(ctx context.Context) ([]InterestRate, error)
+	GetInterestRun(ctx context.Context, id uuid.UUID) (InterestRun, error)
+	GetInterestRunAccount(ctx context.Context, arg GetInterestRunAccountParams) (InterestRunAccount, error)
+	GetInterestRunAccounts(ctx context.Context, runID uuid.UUID) ([]InterestRunAccount, error)
+	GetLastInterestRun(ctx context.Context) (InterestRun, error)
+	GetLoginAttemptsByUserID(ctx context.Context, userID uuid.NullUUID) ([]LoginAttempt, error)
+	GetMFAChallengeByHash(ctx context.Context, tokenHash string) (MfaChallenge, error)
+	GetNotificationsByUserID(ctx context.Context, userID uuid.UUID) ([]Notification, error)
+	GetOAuthClient(ctx context.Context, id uuid.UUID) (OauthClient, error)
+	GetOAuthClients(ctx context.Context) ([]OauthClient, error)
 	GetProfileByUserID(ctx context.Context, id uuid.UUID) (GetProfileByUserIDRow, error)
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (GetRefreshTokenByHashRow, error)
+	GetRoles(ctx context.Context) ([]GetRolesRow, error)
+	GetRolesByNames(ctx context.Context, names []string) ([]Role, error)
+	GetServiceAccount(ctx context.Context, id uuid.UUID) (ServiceAccount, error)
+	GetServiceAccounts(ctx context.Context) ([]ServiceAccount, error)
 	GetTransactionByID(ctx context.Context, id uuid.UUID) (Transaction, error)
+	GetTransactionReversal(ctx context.Context, transactionID uuid.UUID) (TransactionReversal, error)
 	GetTransactionsByAccountID(ctx context.Context, fromAccountID uuid.UUID) ([]GetTransactionsByAccountIDRow, error)
+	GetUncapitalisedInterest(ctx context.Context, accrualDate time.Time) ([]GetUncapitalisedInterestRow, error)
+	GetUnfinishedInterestRuns(ctx context.Context) ([]InterestRun, error)
 	GetUserByEmail(ctx context.Context, email string) (GetUserByEmailRow, error)
 	GetUserByID(ctx context.Context, id uuid.UUID) (GetUserByIDRow, error)
+	GetUserMFA(ctx context.Context, userID uuid.UUID) (UserMfa, error)
+	GetUserPermissions(ctx context.Context, userID uuid.UUID) ([]string, error)
+	GetUserRoleNames(ctx context.Context, userID uuid.UUID) ([]string, error)
+	GetUserSessions(ctx context.Context, userID uuid.UUID) ([]GetUserSessionsRow, error)
+	GetUserTokenByHash(ctx context.Context, tokenHash string) (UserToken, error)
+	IncrementMFAChallengeAttempts(ctx context.Context, id uuid.UUID) error
+	InvalidateUserTokens(ctx context.Context, arg InvalidateUserTokensParams) error
+	IsAccessTokenRevoked(ctx context.Context, arg IsAccessTokenRevokedParams) (bool, error)
+	ListAccountInterestRateOverrides(ctx context.Context, accountID uuid.UUID) ([]InterestRateOverride, error)
+	ListInterestRateCards(ctx context.Context) ([]InterestRateCard, error)
+	ListInterestRateOverrides(ctx context.Context, arg ListInterestRateOverridesParams) ([]InterestRateOverride, error)
+	ListInterestRuns(ctx context.Context, status sql.NullString) ([]InterestRun, error)
+	MarkAccountDormant(ctx context.Context, id uuid.UUID) (int64, error)
+	MarkAuthorizationCodeUsed(ctx context.Context, id uuid.UUID) (int64, error)
+	MarkEmailVerified(ctx context.Context, arg MarkEmailVerifiedParams) (int64, error)
+	MarkMFAChallengeUsed(ctx context.Context, id uuid.UUID) (int64, error)
+	MarkNotificationRead(ctx context.Context, arg MarkNotificationReadParams) (int64, error)
+	MarkRefreshTokenUsed(ctx context.Context, id uuid.UUID) (int64, error)
+	MarkUserTokenUsed(ctx context.Context, id uuid.UUID) (int64, error)
+	ReactivateDormantAccount(ctx context.Context, id uuid.UUID) (int64, error)
+	ReactivateUser(ctx context.Context, id uuid.UUID) (int64, error)
+	RehashUserPassword(ctx context.Context, arg RehashUserPasswordParams) error
+	RequirePasswordChange(ctx context.Context, id uuid.UUID) error
+	RevokeAPIKey(ctx context.Context, arg RevokeAPIKeyParams) (int64, error)
+	RevokeAccessToken(ctx context.Context, arg RevokeAccessTokenParams) error
+	RevokeOAuthClient(ctx context.Context, id uuid.UUID) (int64, error)
+	RevokeOAuthClientSessions(ctx context.Context, arg RevokeOAuthClientSessionsParams) (int64, error)
+	RevokeSession(ctx context.Context, arg RevokeSessionParams) (int64, error)
+	RevokeUserSession(ctx context.Context, arg RevokeUserSessionParams) (int64, error)
+	RevokeUserSessions(ctx context.Context, arg RevokeUserSessionsParams) (int64, error)
+	SaveAPIKey(ctx context.Context, arg SaveAPIKeyParams) (ApiKey, error)
 	SaveAccount(ctx context.Context, arg SaveAccountParams) (Account, error)
+	SaveApprovalRequest(ctx context.Context, arg SaveApprovalRequestParams) (ApprovalRequest, error)
 	SaveAuditLog(ctx context.Context, arg SaveAuditLogParams) error
+	SaveAuthorizationCode(ctx context.Context, arg SaveAuthorizationCodeParams) error
+	SaveInterestAccrual(ctx context.Context, arg SaveInterestAccrualParams) (int64, error)
 	SaveInterestRate(ctx context.Context, arg SaveInterestRateParams) (InterestRate, error)
+	SaveLoginAttempt(ctx context.Context, arg SaveLoginAttemptParams) error
+	SaveMFAChallenge(ctx context.Context, arg SaveMFAChallengeParams) (MfaChallenge, error)
+	SaveNotification(ctx context.Context, arg SaveNotificationParams) (Notification, error)
+	SaveRecoveryCodes(ctx context.Context, arg SaveRecoveryCodesParams) error
+	SaveRefreshToken(ctx context.Context, arg SaveRefreshTokenParams) (RefreshToken, error)
+	SaveSession(ctx context.Context, arg SaveSessionParams) (Session, error)
 	SaveTransaction(ctx context.Context, arg SaveTransactionParams) (Transaction, error)
+	SaveTransactionReversal(ctx context.Context, arg SaveTransactionReversalParams) (TransactionReversal, error)
 	SaveUser(ctx context.Context, arg SaveUserParams) (SaveUserRow, error)
+	SaveUserToken(ctx context.Context, arg SaveUserTokenParams) (UserToken, error)
+	SearchAccounts(ctx context.Context, arg SearchAccountsParams) ([]SearchAccountsRow, error)
+	SetAuthorizationCodeSession(ctx context.Context, arg SetAuthorizationCodeSessionParams) error
+	SetInterestCarry(ctx context.Context, arg SetInterestCarryParams) error
+	SetInterestRunAccountTaxTransaction(ctx context.Context, arg SetInterestRunAccountTaxTransactionParams) error
+	SetInterestRunAccountTransaction(ctx context.Context, arg SetInterestRunAccountTransactionParams) error
+	SetPendingEmail(ctx context.Context, arg SetPendingEmailParams) error
+	SetSessionMFAVerified(ctx context.Context, arg SetSessionMFAVerifiedParams) (int64, error)
+	SetTransactionReversalID(ctx context.Context, arg SetTransactionReversalIDParams) error
+	SetUserRoles(ctx context.Context, arg SetUserRolesParams) error
+	SetUserTaxStatus(ctx context.Context, arg SetUserTaxStatusParams) error
+	StartInterestRun(ctx context.Context, arg StartInterestRunParams) (InterestRun, error)
+	SuspendUserAccounts(ctx context.Context, userID uuid.UUID) ([]SuspendUserAccountsRow, error)
+	TouchAPIKey(ctx context.Context, id uuid.UUID) error
+	TouchAccountActivity(ctx context.Context, id uuid.UUID) error
+	UpdateAccountIdentifiers(ctx context.Context, arg UpdateAccountIdentifiersParams) error
 	UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) error
 	UpdateBalance(ctx context.Context, id uuid.UUID) error
-	UpdateCalculationFrequency(ctx context.Context, arg UpdateCalculationFrequencyParams) error
-	UpdateRate(ctx context.Context, arg UpdateRateParams) error
+	UpdateInterestRateCard(ctx context.Context, arg UpdateInterestRateCardParams) (InterestRateCard, error)
+	UpdateInterestRateOverride(ctx context.Context, arg UpdateInterestRateOverrideParams) (InterestRateOverride, error)
+	UpdateMFALastUsedStep(ctx context.Context, arg UpdateMFALastUsedStepParams) (int64, error)
+	UpdateScheduledCalculationFrequency(ctx context.Context, arg UpdateScheduledCalculationFrequencyParams) error
+	UpdateUserName(ctx context.Context, arg UpdateUserNameParams) error
+	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error
+	UpsertUserMFA(ctx context.Context, arg UpsertUserMFAParams) (UserMfa, error)
+	UseRecoveryCode(ctx context.Context, arg UseRecoveryCodeParams) (int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -8,6 +8,7 @@ package models
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -51,6 +52,64 @@ func (q *Queries) GetAccountBalance(ctx context.Context, id uuid.UUID) (GetAccou
 	return i, err
 }
 
+const getAccountBalanceAt = `-- name: GetAccountBalanceAt :one
+SELECT (
+    COALESCE(SUM(CASE WHEN to_account_id = $1 THEN amount ELSE 0 END), 0) -
+    COALESCE(SUM(CASE WHEN from_account_id = $1 THEN amount ELSE 0 END), 0)
+)::BIGINT AS balance
+FROM transactions
+WHERE (to_account_id = $1 OR from_account_id = $1)
+    AND created_at < $2::timestamp
+`
+
+type GetAccountBalanceAtParams struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Before    time.Time `json:"before"`
+}
+
+func (q *Queries) GetAccountBalanceAt(ctx context.Context, arg GetAccountBalanceAtParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getAccountBalanceAt, arg.AccountID, arg.Before)
+	var balance int64
+	err := row.Scan(&balance)
+	return balance, err
+}
+
+const getAccountFlows = `-- name: GetAccountFlows :one
+SELECT
+    COALESCE(SUM(amount) FILTER (WHERE from_account_id = $1), 0)::BIGINT AS withdrawn,
+    COALESCE(SUM(amount) FILTER (
+        WHERE to_account_id = $1 AND from_account_id <> $2
+    ), 0)::BIGINT AS deposited
+FROM transactions
+WHERE (to_account_id = $1 OR from_account_id = $1)
+    AND created_at >= $3::timestamp
+    AND created_at < $4::timestamp
+`
+
+type GetAccountFlowsParams struct {
+	AccountID         uuid.UUID `json:"account_id"`
+	InterestAccountID uuid.UUID `json:"interest_account_id"`
+	From              time.Time `json:"from"`
+	To                time.Time `json:"to"`
+}
+
+type GetAccountFlowsRow struct {
+	Withdrawn int64 `json:"withdrawn"`
+	Deposited int64 `json:"deposited"`
+}
+
+func (q *Queries) GetAccountFlows(ctx context.Context, arg GetAccountFlowsParams) (GetAccountFlowsRow, error) {
+	row := q.db.QueryRowContext(ctx, getAccountFlows,
+		arg.AccountID,
+		arg.InterestAccountID,
+		arg.From,
+		arg.To,
+	)
+	var i GetAccountFlowsRow
+	err := row.Scan(&i.Withdrawn, &i.Deposited)
+	return i, err
+}
+
 const getTransactionByID = `-- name: GetTransactionByID :one
 SELECT id, from_account_id, to_account_id, amount, reference_number, description, status, currency, created_at, updated_at, deleted_at FROM transactions WHERE id = $1
 `
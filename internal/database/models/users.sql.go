@@ -13,7 +13,7 @@ import (
 )
 
 const getAccountByCurrency = `-- name: GetAccountByCurrency :one
-SELECT id, user_id, account_number, account_type, status, currency, created_at, updated_at, deleted_at, balance FROM accounts WHERE currency = $1 AND user_id = $2 LIMIT 1
+SELECT id, user_id, account_number, account_type, status, currency, created_at, updated_at, deleted_at, balance, sort_code, iban, last_activity_at, dormant_since, interest_carry FROM accounts WHERE currency = $1 AND user_id = $2 LIMIT 1
 `
 
 type GetAccountByCurrencyParams struct {
@@ -35,6 +35,43 @@ func (q *Queries) GetAccountByCurrency(ctx context.Context, arg GetAccountByCurr
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.Balance,
+		&i.SortCode,
+		&i.Iban,
+		&i.LastActivityAt,
+		&i.DormantSince,
+		&i.InterestCarry,
+	)
+	return i, err
+}
+
+const getAccountByIBAN = `-- name: GetAccountByIBAN :one
+SELECT id, user_id, account_number, sort_code, iban, status, account_type, currency
+    FROM accounts WHERE iban = $1 LIMIT 1
+`
+
+type GetAccountByIBANRow struct {
+	ID            uuid.UUID      `json:"id"`
+	UserID        uuid.UUID      `json:"user_id"`
+	AccountNumber string         `json:"account_number"`
+	SortCode      sql.NullString `json:"sort_code"`
+	Iban          sql.NullString `json:"iban"`
+	Status        Status         `json:"status"`
+	AccountType   AccountType    `json:"account_type"`
+	Currency      Currency       `json:"currency"`
+}
+
+func (q *Queries) GetAccountByIBAN(ctx context.Context, iban sql.NullString) (GetAccountByIBANRow, error) {
+	row := q.db.QueryRowContext(ctx, getAccountByIBAN, iban)
+	var i GetAccountByIBANRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.AccountNumber,
+		&i.SortCode,
+		&i.Iban,
+		&i.Status,
+		&i.AccountType,
+		&i.Currency,
 	)
 	return i, err
 }
@@ -71,6 +108,38 @@ func (q *Queries) GetAccountByID(ctx context.Context, id uuid.UUID) (GetAccountB
 	return i, err
 }
 
+const getAccountByNumber = `-- name: GetAccountByNumber :one
+SELECT id, user_id, account_number, sort_code, iban, status, account_type, currency
+    FROM accounts WHERE account_number = $1 LIMIT 1
+`
+
+type GetAccountByNumberRow struct {
+	ID            uuid.UUID      `json:"id"`
+	UserID        uuid.UUID      `json:"user_id"`
+	AccountNumber string         `json:"account_number"`
+	SortCode      sql.NullString `json:"sort_code"`
+	Iban          sql.NullString `json:"iban"`
+	Status        Status         `json:"status"`
+	AccountType   AccountType    `json:"account_type"`
+	Currency      Currency       `json:"currency"`
+}
+
+func (q *Queries) GetAccountByNumber(ctx context.Context, accountNumber string) (GetAccountByNumberRow, error) {
+	row := q.db.QueryRowContext(ctx, getAccountByNumber, accountNumber)
+	var i GetAccountByNumberRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.AccountNumber,
+		&i.SortCode,
+		&i.Iban,
+		&i.Status,
+		&i.AccountType,
+		&i.Currency,
+	)
+	return i, err
+}
+
 const getAccountDetailsByID = `-- name: GetAccountDetailsByID :one
 SELECT
     users.id as user_id,
@@ -82,23 +151,32 @@ SELECT
     account_type,
     currency,
     accounts.balance,
-    accounts.created_at
+    accounts.created_at,
+    accounts.sort_code,
+    accounts.iban,
+    (
+        SELECT COALESCE(SUM(ia.amount + ia.bonus_amount), 0) + accounts.interest_carry FROM interest_accruals ia
+            WHERE ia.account_id = accounts.id AND ia.capitalised_at IS NULL
+    )::FLOAT8 AS accrued_interest
 FROM accounts
          JOIN users ON users.id = accounts.user_id
 WHERE accounts.id = $1
 `
 
 type GetAccountDetailsByIDRow struct {
-	UserID        uuid.UUID     `json:"user_id"`
-	AccountID     uuid.UUID     `json:"account_id"`
-	FirstName     string        `json:"first_name"`
-	LastName      string        `json:"last_name"`
-	AccountNumber string        `json:"account_number"`
-	Status        Status        `json:"status"`
-	AccountType   AccountType   `json:"account_type"`
-	Currency      Currency      `json:"currency"`
-	Balance       sql.NullInt64 `json:"balance"`
-	CreatedAt     sql.NullTime  `json:"created_at"`
+	UserID          uuid.UUID      `json:"user_id"`
+	AccountID       uuid.UUID      `json:"account_id"`
+	FirstName       string         `json:"first_name"`
+	LastName        string         `json:"last_name"`
+	AccountNumber   string         `json:"account_number"`
+	Status          Status         `json:"status"`
+	AccountType     AccountType    `json:"account_type"`
+	Currency        Currency       `json:"currency"`
+	Balance         sql.NullInt64  `json:"balance"`
+	CreatedAt       sql.NullTime   `json:"created_at"`
+	SortCode        sql.NullString `json:"sort_code"`
+	Iban            sql.NullString `json:"iban"`
+	AccruedInterest float64        `json:"accrued_interest"`
 }
 
 func (q *Queries) GetAccountDetailsByID(ctx context.Context, id uuid.UUID) (GetAccountDetailsByIDRow, error) {
@@ -115,6 +193,9 @@ func (q *Queries) GetAccountDetailsByID(ctx context.Context, id uuid.UUID) (GetA
 		&i.Currency,
 		&i.Balance,
 		&i.CreatedAt,
+		&i.SortCode,
+		&i.Iban,
+		&i.AccruedInterest,
 	)
 	return i, err
 }
@@ -147,6 +228,61 @@ func (q *Queries) GetAccountStats(ctx context.Context) (GetAccountStatsRow, erro
 	return i, err
 }
 
+const getAccountTaxStatus = `-- name: GetAccountTaxStatus :one
+SELECT users.tax_residency, users.tax_exempt
+    FROM accounts
+    JOIN users ON users.id = accounts.user_id
+WHERE accounts.id = $1
+`
+
+type GetAccountTaxStatusRow struct {
+	TaxResidency sql.NullString `json:"tax_residency"`
+	TaxExempt    bool           `json:"tax_exempt"`
+}
+
+func (q *Queries) GetAccountTaxStatus(ctx context.Context, id uuid.UUID) (GetAccountTaxStatusRow, error) {
+	row := q.db.QueryRowContext(ctx, getAccountTaxStatus, id)
+	var i GetAccountTaxStatusRow
+	err := row.Scan(&i.TaxResidency, &i.TaxExempt)
+	return i, err
+}
+
+const getAccountsMissingIdentifiers = `-- name: GetAccountsMissingIdentifiers :many
+SELECT id, account_number, currency
+    FROM accounts
+WHERE account_type = 'CURRENT'
+    AND (sort_code IS NULL OR (currency = 'EUR' AND iban IS NULL))
+`
+
+type GetAccountsMissingIdentifiersRow struct {
+	ID            uuid.UUID `json:"id"`
+	AccountNumber string    `json:"account_number"`
+	Currency      Currency  `json:"currency"`
+}
+
+func (q *Queries) GetAccountsMissingIdentifiers(ctx context.Context) ([]GetAccountsMissingIdentifiersRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAccountsMissingIdentifiers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAccountsMissingIdentifiersRow
+	for rows.Next() {
+		var i GetAccountsMissingIdentifiersRow
+		if err := rows.Scan(&i.ID, &i.AccountNumber, &i.Currency); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getAllActiveAccounts = `-- name: GetAllActiveAccounts :many
 SELECT
     users.id as user_id,
@@ -158,7 +294,7 @@ SELECT
     FROM accounts
     JOIN users ON users.id = accounts.user_id
 WHERE users.user_type='CUSTOMER'
-    AND accounts.status = 'ACTIVE'
+    AND accounts.status IN ('ACTIVE', 'DORMANT')
     AND accounts.account_type = 'CURRENT'
 `
 
@@ -275,21 +411,23 @@ SELECT
     users.last_name AS last_name,
     accounts.account_type AS account_type,
     users.user_type AS user_type,
-    users.created_at AS registered_at
+    users.created_at AS registered_at,
+    users.password_change_required AS password_change_required
 FROM users
          JOIN accounts ON users.id = accounts.user_id
-WHERE users.id = $1 LIMIT 1
+WHERE users.id = $1 AND users.deleted_at IS NULL LIMIT 1
 `
 
 type GetProfileByUserIDRow struct {
-	AccountID    uuid.UUID    `json:"account_id"`
-	UserID       uuid.UUID    `json:"user_id"`
-	Email        string       `json:"email"`
-	FirstName    string       `json:"first_name"`
-	LastName     string       `json:"last_name"`
-	AccountType  AccountType  `json:"account_type"`
-	UserType     UserType     `json:"user_type"`
-	RegisteredAt sql.NullTime `json:"registered_at"`
+	AccountID              uuid.UUID    `json:"account_id"`
+	UserID                 uuid.UUID    `json:"user_id"`
+	Email                  string       `json:"email"`
+	FirstName              string       `json:"first_name"`
+	LastName               string       `json:"last_name"`
+	AccountType            AccountType  `json:"account_type"`
+	UserType               UserType     `json:"user_type"`
+	RegisteredAt           sql.NullTime `json:"registered_at"`
+	PasswordChangeRequired bool         `json:"password_change_required"`
 }
 
 func (q *Queries) GetProfileByUserID(ctx context.Context, id uuid.UUID) (GetProfileByUserIDRow, error) {
@@ -304,24 +442,28 @@ func (q *Queries) GetProfileByUserID(ctx context.Context, id uuid.UUID) (GetProf
 		&i.AccountType,
 		&i.UserType,
 		&i.RegisteredAt,
+		&i.PasswordChangeRequired,
 	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, password, first_name, last_name, user_type, created_at, updated_at
+SELECT id, email, password, first_name, last_name, user_type, created_at, updated_at, deleted_at, email_verified_at, password_change_required
     FROM users WHERE email = $1 LIMIT 1
 `
 
 type GetUserByEmailRow struct {
-	ID        uuid.UUID    `json:"id"`
-	Email     string       `json:"email"`
-	Password  string       `json:"password"`
-	FirstName string       `json:"first_name"`
-	LastName  string       `json:"last_name"`
-	UserType  UserType     `json:"user_type"`
-	CreatedAt sql.NullTime `json:"created_at"`
-	UpdatedAt sql.NullTime `json:"updated_at"`
+	ID                     uuid.UUID    `json:"id"`
+	Email                  string       `json:"email"`
+	Password               string       `json:"password"`
+	FirstName              string       `json:"first_name"`
+	LastName               string       `json:"last_name"`
+	UserType               UserType     `json:"user_type"`
+	CreatedAt              sql.NullTime `json:"created_at"`
+	UpdatedAt              sql.NullTime `json:"updated_at"`
+	DeletedAt              sql.NullTime `json:"deleted_at"`
+	EmailVerifiedAt        sql.NullTime `json:"email_verified_at"`
+	PasswordChangeRequired bool         `json:"password_change_required"`
 }
 
 func (q *Queries) GetUserByEmail(ctx context.Context, email string) (GetUserByEmailRow, error) {
@@ -336,24 +478,33 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (GetUserByEm
 		&i.UserType,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.EmailVerifiedAt,
+		&i.PasswordChangeRequired,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, email, password, first_name, last_name, user_type, created_at, updated_at
+SELECT id, email, password, first_name, last_name, user_type, created_at, updated_at, deleted_at, email_verified_at, pending_email,
+    tax_residency, tax_exempt
     FROM users WHERE id = $1 LIMIT 1
 `
 
 type GetUserByIDRow struct {
-	ID        uuid.UUID    `json:"id"`
-	Email     string       `json:"email"`
-	Password  string       `json:"password"`
-	FirstName string       `json:"first_name"`
-	LastName  string       `json:"last_name"`
-	UserType  UserType     `json:"user_type"`
-	CreatedAt sql.NullTime `json:"created_at"`
-	UpdatedAt sql.NullTime `json:"updated_at"`
+	ID              uuid.UUID      `json:"id"`
+	Email           string         `json:"email"`
+	Password        string         `json:"password"`
+	FirstName       string         `json:"first_name"`
+	LastName        string         `json:"last_name"`
+	UserType        UserType       `json:"user_type"`
+	CreatedAt       sql.NullTime   `json:"created_at"`
+	UpdatedAt       sql.NullTime   `json:"updated_at"`
+	DeletedAt       sql.NullTime   `json:"deleted_at"`
+	EmailVerifiedAt sql.NullTime   `json:"email_verified_at"`
+	PendingEmail    sql.NullString `json:"pending_email"`
+	TaxResidency    sql.NullString `json:"tax_residency"`
+	TaxExempt       bool           `json:"tax_exempt"`
 }
 
 func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (GetUserByIDRow, error) {
@@ -368,22 +519,29 @@ func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (GetUserByIDRow
 		&i.UserType,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.EmailVerifiedAt,
+		&i.PendingEmail,
+		&i.TaxResidency,
+		&i.TaxExempt,
 	)
 	return i, err
 }
 
 const saveAccount = `-- name: SaveAccount :one
 INSERT INTO accounts(
-    user_id, account_number, status, account_type, currency
-) VALUES ($1, $2, $3, $4, $5) RETURNING id, user_id, account_number, account_type, status, currency, created_at, updated_at, deleted_at, balance
+    user_id, account_number, status, account_type, currency, sort_code, iban
+) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, user_id, account_number, account_type, status, currency, created_at, updated_at, deleted_at, balance, sort_code, iban, last_activity_at, dormant_since, interest_carry
 `
 
 type SaveAccountParams struct {
-	UserID        uuid.UUID   `json:"user_id"`
-	AccountNumber string      `json:"account_number"`
-	Status        Status      `json:"status"`
-	AccountType   AccountType `json:"account_type"`
-	Currency      Currency    `json:"currency"`
+	UserID        uuid.UUID      `json:"user_id"`
+	AccountNumber string         `json:"account_number"`
+	Status        Status         `json:"status"`
+	AccountType   AccountType    `json:"account_type"`
+	Currency      Currency       `json:"currency"`
+	SortCode      sql.NullString `json:"sort_code"`
+	Iban          sql.NullString `json:"iban"`
 }
 
 func (q *Queries) SaveAccount(ctx context.Context, arg SaveAccountParams) (Account, error) {
@@ -393,6 +551,8 @@ func (q *Queries) SaveAccount(ctx context.Context, arg SaveAccountParams) (Accou
 		arg.Status,
 		arg.AccountType,
 		arg.Currency,
+		arg.SortCode,
+		arg.Iban,
 	)
 	var i Account
 	err := row.Scan(
@@ -406,22 +566,31 @@ func (q *Queries) SaveAccount(ctx context.Context, arg SaveAccountParams) (Accou
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.Balance,
+		&i.SortCode,
+		&i.Iban,
+		&i.LastActivityAt,
+		&i.DormantSince,
+		&i.InterestCarry,
 	)
 	return i, err
 }
 
 const saveUser = `-- name: SaveUser :one
 INSERT INTO users(
-    email, password, first_name, last_name, user_type
-) VALUES ($1, $2, $3, $4, $5) RETURNING id, email
+    email, password, first_name, last_name, user_type, email_verified_at
+) VALUES (
+    $1, $2, $3, $4, $5,
+    CASE WHEN $6::boolean THEN CURRENT_TIMESTAMP END
+) RETURNING id, email
 `
 
 type SaveUserParams struct {
-	Email     string   `json:"email"`
-	Password  string   `json:"password"`
-	FirstName string   `json:"first_name"`
-	LastName  string   `json:"last_name"`
-	UserType  UserType `json:"user_type"`
+	Email         string   `json:"email"`
+	Password      string   `json:"password"`
+	FirstName     string   `json:"first_name"`
+	LastName      string   `json:"last_name"`
+	UserType      UserType `json:"user_type"`
+	EmailVerified bool     `json:"email_verified"`
 }
 
 type SaveUserRow struct {
@@ -436,12 +605,30 @@ func (q *Queries) SaveUser(ctx context.Context, arg SaveUserParams) (SaveUserRow
 		arg.FirstName,
 		arg.LastName,
 		arg.UserType,
+		arg.EmailVerified,
 	)
 	var i SaveUserRow
 	err := row.Scan(&i.ID, &i.Email)
 	return i, err
 }
 
+const updateAccountIdentifiers = `-- name: UpdateAccountIdentifiers :exec
+UPDATE accounts
+    SET sort_code = $1, iban = $2, updated_at = CURRENT_TIMESTAMP
+    WHERE id = $3
+`
+
+type UpdateAccountIdentifiersParams struct {
+	SortCode sql.NullString `json:"sort_code"`
+	Iban     sql.NullString `json:"iban"`
+	ID       uuid.UUID      `json:"id"`
+}
+
+func (q *Queries) UpdateAccountIdentifiers(ctx context.Context, arg UpdateAccountIdentifiersParams) error {
+	_, err := q.db.ExecContext(ctx, updateAccountIdentifiers, arg.SortCode, arg.Iban, arg.ID)
+	return err
+}
+
 const updateAccountStatus = `-- name: UpdateAccountStatus :exec
 UPDATE accounts
     SET status = $1, updated_at = CURRENT_TIMESTAMP
@@ -0,0 +1,40 @@
+-- name: SearchAccounts :many
+SELECT
+    u.id AS user_id,
+    u.first_name,
+    u.last_name,
+    u.email,
+    u.user_type,
+    a.id AS account_id,
+    a.account_number,
+    a.sort_code,
+    a.iban,
+    a.account_type,
+    a.status,
+    a.currency,
+    COALESCE(a.balance, 0)::bigint AS balance,
+    a.created_at,
+    COUNT(*) OVER () AS total_count
+FROM accounts a
+    JOIN users u ON u.id = a.user_id
+WHERE (sqlc.narg('name')::text IS NULL OR (u.first_name || ' ' || u.last_name) ILIKE '%' || sqlc.narg('name') || '%')
+    AND (sqlc.narg('email')::text IS NULL OR u.email ILIKE '%' || sqlc.narg('email') || '%')
+    AND (sqlc.narg('account_number')::text IS NULL OR a.account_number LIKE sqlc.narg('account_number') || '%')
+    AND (sqlc.narg('status')::status IS NULL OR a.status = sqlc.narg('status'))
+    AND (sqlc.narg('currency')::currency IS NULL OR a.currency = sqlc.narg('currency'))
+    AND (sqlc.narg('min_balance')::bigint IS NULL OR COALESCE(a.balance, 0) >= sqlc.narg('min_balance'))
+    AND (sqlc.narg('max_balance')::bigint IS NULL OR COALESCE(a.balance, 0) <= sqlc.narg('max_balance'))
+    AND (sqlc.narg('created_from')::timestamp IS NULL OR a.created_at >= sqlc.narg('created_from'))
+    AND (sqlc.narg('created_to')::timestamp IS NULL OR a.created_at < sqlc.narg('created_to'))
+    AND (sqlc.arg('include_internal')::boolean OR (a.account_type = 'CURRENT' AND u.user_type = 'CUSTOMER'))
+ORDER BY
+    CASE WHEN sqlc.arg('sort_by')::text = 'balance' AND NOT sqlc.arg('sort_desc')::boolean THEN COALESCE(a.balance, 0) END ASC,
+    CASE WHEN sqlc.arg('sort_by')::text = 'balance' AND sqlc.arg('sort_desc')::boolean THEN COALESCE(a.balance, 0) END DESC,
+    CASE WHEN sqlc.arg('sort_by')::text = 'account_number' AND NOT sqlc.arg('sort_desc')::boolean THEN a.account_number END ASC,
+    CASE WHEN sqlc.arg('sort_by')::text = 'account_number' AND sqlc.arg('sort_desc')::boolean THEN a.account_number END DESC,
+    CASE WHEN sqlc.arg('sort_by')::text = 'name' AND NOT sqlc.arg('sort_desc')::boolean THEN u.last_name || ' ' || u.first_name END ASC,
+    CASE WHEN sqlc.arg('sort_by')::text = 'name' AND sqlc.arg('sort_desc')::boolean THEN u.last_name || ' ' || u.first_name END DESC,
+    CASE WHEN NOT sqlc.arg('sort_desc')::boolean THEN a.created_at END ASC,
+    a.created_at DESC,
+    a.id
+LIMIT sqlc.arg('page_size') OFFSET sqlc.arg('page_offset');
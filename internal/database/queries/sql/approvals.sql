@@ -0,0 +1,30 @@
+-- name: SaveApprovalRequest :one
+INSERT INTO approval_requests (operation, payload, secret, requested_by, expires_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetApprovalRequest :one
+SELECT * FROM approval_requests WHERE id = $1;
+
+-- name: GetApprovalRequests :many
+SELECT * FROM approval_requests
+WHERE sqlc.narg('status')::approval_status IS NULL OR status = sqlc.narg('status')
+ORDER BY created_at DESC;
+
+-- name: DecideApprovalRequest :execrows
+UPDATE approval_requests
+SET status = sqlc.arg('status'), decided_by = sqlc.arg('decided_by'), decision_reason = sqlc.arg('decision_reason'),
+    decided_at = sqlc.arg('now')::timestamp, secret = NULL
+WHERE id = sqlc.arg('id') AND status = 'PENDING' AND expires_at > sqlc.arg('now');
+
+-- name: CompleteApprovalRequest :one
+UPDATE approval_requests
+SET status = $2, result = $3, error = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: ExpireApprovalRequests :many
+UPDATE approval_requests
+SET status = 'EXPIRED', decided_at = sqlc.arg('now')::timestamp, secret = NULL
+WHERE status = 'PENDING' AND expires_at <= sqlc.arg('now')
+RETURNING *;
@@ -1,7 +1,7 @@
 -- name: SaveAuditLog :exec
 INSERT INTO audit_logs(
-    user_id, affected_account_id, action, metadata
-) VALUES ($1, $2, $3, $4);
+    user_id, affected_account_id, action, metadata, api_key_id
+) VALUES ($1, $2, $3, $4, $5);
 
 -- name: GetAccountStatusHistory :many
 SELECT
@@ -34,6 +34,7 @@ SELECT
         WHEN 'account_credit' THEN 'Credited Account'
         WHEN 'account_debit' THEN 'Debited Account'
         WHEN 'account_status_change' THEN COALESCE(al.metadata->>'new_status', '') || ' Account'
+        WHEN 'interest_applied' THEN 'Applied Interest'
         ELSE al.action -- Keep the original action if not one of the defined ones
         END AS action,
     COALESCE(al.metadata->>'old_status', '')::varchar AS old_status,
@@ -0,0 +1,44 @@
+-- name: GetDormancyCandidates :many
+SELECT
+    a.id AS account_id,
+    a.user_id,
+    a.account_number,
+    a.currency,
+    COALESCE(a.last_activity_at, a.created_at)::timestamp AS last_activity_at
+FROM accounts a
+    JOIN users u ON u.id = a.user_id
+WHERE u.user_type = 'CUSTOMER'
+    AND a.account_type = 'CURRENT'
+    AND a.status = 'ACTIVE'
+    AND COALESCE(a.last_activity_at, a.created_at) < $1;
+
+-- name: MarkAccountDormant :execrows
+UPDATE accounts
+    SET status = 'DORMANT', dormant_since = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+    WHERE id = $1 AND status = 'ACTIVE';
+
+-- name: ReactivateDormantAccount :execrows
+UPDATE accounts
+    SET status = 'ACTIVE', dormant_since = NULL, last_activity_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+    WHERE id = $1 AND status = 'DORMANT';
+
+-- name: TouchAccountActivity :exec
+UPDATE accounts
+    SET last_activity_at = CURRENT_TIMESTAMP
+    WHERE id = $1 AND account_type = 'CURRENT';
+
+-- name: GetDormantAccounts :many
+SELECT
+    a.id AS account_id,
+    a.user_id,
+    u.first_name,
+    u.last_name,
+    a.account_number,
+    a.currency,
+    COALESCE(a.balance, 0)::bigint AS balance,
+    a.last_activity_at,
+    a.dormant_since
+FROM accounts a
+    JOIN users u ON u.id = a.user_id
+WHERE a.status = 'DORMANT'
+ORDER BY a.dormant_since ASC;
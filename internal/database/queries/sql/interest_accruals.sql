@@ -0,0 +1,49 @@
+-- name: SaveInterestAccrual :execrows
+INSERT INTO interest_accruals(
+    account_id, accrual_date, balance, rate, amount, day_count, card_id, bonus_rate, bonus_amount
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (account_id, accrual_date) DO NOTHING;
+
+-- name: ForfeitInterestBonus :execrows
+UPDATE interest_accruals
+    SET bonus_amount = 0
+    WHERE account_id = sqlc.arg('account_id')
+        AND accrual_date BETWEEN sqlc.arg('from')::date AND sqlc.arg('to')::date
+        AND capitalised_at IS NULL;
+
+-- name: GetAccountUncapitalisedInterest :one
+SELECT
+    a.currency,
+    COALESCE(SUM(ia.amount + ia.bonus_amount), 0)::TEXT AS amount,
+    a.interest_carry::TEXT AS carry,
+    COUNT(ia.id) AS days,
+    COALESCE(ROUND(AVG(ia.balance)), 0)::BIGINT AS average_balance,
+    COALESCE(ROUND(AVG(ia.rate + CASE WHEN ia.bonus_amount > 0 THEN ia.bonus_rate ELSE 0 END)), 0)::BIGINT AS average_rate
+FROM accounts a
+    LEFT JOIN interest_accruals ia ON ia.account_id = a.id
+        AND ia.capitalised_at IS NULL
+        AND ia.accrual_date <= sqlc.arg('accrual_date')
+WHERE a.id = sqlc.arg('account_id')
+GROUP BY a.currency, a.interest_carry;
+
+-- name: GetUncapitalisedInterest :many
+SELECT
+    ia.account_id,
+    a.currency,
+    SUM(ia.amount + ia.bonus_amount)::TEXT AS amount,
+    a.interest_carry::TEXT AS carry
+FROM interest_accruals ia
+    JOIN accounts a ON a.id = ia.account_id
+WHERE ia.capitalised_at IS NULL
+    AND ia.accrual_date <= $1
+GROUP BY ia.account_id, a.currency, a.interest_carry;
+
+-- name: CapitaliseInterestAccruals :execrows
+UPDATE interest_accruals
+    SET capitalised_at = CURRENT_TIMESTAMP, transaction_id = $3
+    WHERE account_id = $1 AND accrual_date <= $2 AND capitalised_at IS NULL;
+
+-- name: SetInterestCarry :exec
+UPDATE accounts
+    SET interest_carry = $2
+    WHERE id = $1;
@@ -1,17 +1,32 @@
 -- name: SaveInterestRate :one
 INSERT INTO interest_rates(
-    rate, calculation_frequency
-) VALUES ($1, $2) RETURNING *;
+    rate, calculation_frequency, effective_from, effective_to, created_by
+) VALUES ($1, $2, $3, $4, $5) RETURNING *;
 
--- name: UpdateRate :exec
+-- name: CloseInterestRate :exec
 UPDATE interest_rates
-    SET rate = $1, updated_at = CURRENT_TIMESTAMP
-    WHERE id = $2 RETURNING *;
+    SET effective_to = $2, updated_at = CURRENT_TIMESTAMP
+    WHERE id = $1;
 
--- name: UpdateCalculationFrequency :exec
+-- name: CancelInterestRate :exec
+UPDATE interest_rates
+    SET deleted_at = CURRENT_TIMESTAMP
+    WHERE id = $1;
+
+-- name: UpdateScheduledCalculationFrequency :exec
 UPDATE interest_rates
     SET calculation_frequency = $1, updated_at = CURRENT_TIMESTAMP
-    WHERE id = $2 RETURNING *;
+    WHERE deleted_at IS NULL AND effective_from > sqlc.arg('after')::date;
+
+-- name: GetInterestRateAt :one
+SELECT * FROM interest_rates
+    WHERE deleted_at IS NULL
+        AND effective_from <= sqlc.arg('day')::date
+        AND (effective_to IS NULL OR effective_to > sqlc.arg('day')::date)
+    ORDER BY effective_from DESC, created_at DESC
+    LIMIT 1;
 
 -- name: GetInterestRates :many
-SELECT * FROM interest_rates ORDER BY created_at DESC LIMIT 1;
\ No newline at end of file
+SELECT * FROM interest_rates
+    WHERE deleted_at IS NULL
+    ORDER BY effective_from DESC, created_at DESC;
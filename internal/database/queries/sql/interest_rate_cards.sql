@@ -0,0 +1,32 @@
+-- name: CreateInterestRateCard :one
+INSERT INTO interest_rate_cards(
+    currency, product, method, bands, bonus_rate, bonus_conditions, min_monthly_deposit, created_by
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING *;
+
+-- name: DeleteInterestRateCard :execrows
+UPDATE interest_rate_cards
+    SET deleted_at = CURRENT_TIMESTAMP
+    WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: GetInterestRateCard :one
+SELECT * FROM interest_rate_cards WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: GetInterestRateCardByProduct :one
+SELECT * FROM interest_rate_cards
+    WHERE currency = $1 AND product = $2 AND deleted_at IS NULL;
+
+-- name: ListInterestRateCards :many
+SELECT * FROM interest_rate_cards
+    WHERE deleted_at IS NULL
+    ORDER BY currency, product;
+
+-- name: UpdateInterestRateCard :one
+UPDATE interest_rate_cards
+    SET method = $2,
+        bands = $3,
+        bonus_rate = $4,
+        bonus_conditions = $5,
+        min_monthly_deposit = $6,
+        updated_at = CURRENT_TIMESTAMP
+    WHERE id = $1 AND deleted_at IS NULL
+    RETURNING *;
@@ -0,0 +1,37 @@
+-- name: CountOverlappingInterestRateOverrides :one
+SELECT COUNT(*) FROM interest_rate_overrides
+    WHERE account_id = $1 AND kind = $2 AND id <> $3
+        AND (sqlc.narg('ends_on')::DATE IS NULL OR starts_on <= sqlc.narg('ends_on'))
+        AND (ends_on IS NULL OR ends_on >= sqlc.arg('starts_on'));
+
+-- name: CreateInterestRateOverride :one
+INSERT INTO interest_rate_overrides(
+    account_id, kind, rate, starts_on, ends_on, reason, created_by
+) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING *;
+
+-- name: DeleteInterestRateOverride :execrows
+DELETE FROM interest_rate_overrides WHERE id = $1;
+
+-- name: GetInterestRateOverride :one
+SELECT * FROM interest_rate_overrides WHERE id = $1 AND account_id = $2;
+
+-- name: ListAccountInterestRateOverrides :many
+SELECT * FROM interest_rate_overrides
+    WHERE account_id = $1
+    ORDER BY starts_on DESC, created_at DESC;
+
+-- name: ListInterestRateOverrides :many
+SELECT * FROM interest_rate_overrides
+    WHERE starts_on <= sqlc.arg('to') AND (ends_on IS NULL OR ends_on >= sqlc.arg('from'))
+    ORDER BY account_id, starts_on;
+
+-- name: UpdateInterestRateOverride :one
+UPDATE interest_rate_overrides
+    SET kind = $2,
+        rate = $3,
+        starts_on = $4,
+        ends_on = $5,
+        reason = $6,
+        updated_at = CURRENT_TIMESTAMP
+    WHERE id = $1
+    RETURNING *;
@@ -0,0 +1,81 @@
+-- name: StartInterestRun :one
+INSERT INTO interest_runs (run_date, rate_id, period)
+VALUES ($1, $2, $3)
+ON CONFLICT (run_date) DO UPDATE
+    SET status = 'RUNNING', finished_at = NULL
+    WHERE interest_runs.status <> 'COMPLETED'
+RETURNING *;
+
+-- name: FinishInterestRun :one
+UPDATE interest_runs
+SET accounts = (SELECT COUNT(*) FROM interest_run_accounts WHERE run_id = sqlc.arg('id')),
+    failed = (SELECT COUNT(*) FROM interest_run_accounts WHERE run_id = sqlc.arg('id') AND status <> 'COMPLETED'),
+    status = CASE
+        WHEN EXISTS (SELECT 1 FROM interest_run_accounts WHERE run_id = sqlc.arg('id') AND status <> 'COMPLETED')
+            THEN 'FAILED'
+        ELSE 'COMPLETED'
+    END,
+    finished_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: GetInterestRun :one
+SELECT * FROM interest_runs WHERE id = $1;
+
+-- name: GetLastInterestRun :one
+SELECT * FROM interest_runs ORDER BY run_date DESC LIMIT 1;
+
+-- name: GetUnfinishedInterestRuns :many
+SELECT * FROM interest_runs WHERE status <> 'COMPLETED' ORDER BY run_date;
+
+-- name: ListInterestRuns :many
+SELECT * FROM interest_runs
+WHERE sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status')
+ORDER BY run_date DESC;
+
+-- name: ClaimInterestRunAccount :one
+INSERT INTO interest_run_accounts (run_id, account_id, run_date)
+VALUES (sqlc.arg('run_id'), sqlc.arg('account_id'), sqlc.arg('run_date'))
+ON CONFLICT (account_id, run_date) DO UPDATE
+    SET status = 'RUNNING', error = NULL, attempts = interest_run_accounts.attempts + 1,
+        updated_at = CURRENT_TIMESTAMP
+    WHERE interest_run_accounts.status = 'FAILED'
+        OR (interest_run_accounts.status = 'RUNNING' AND interest_run_accounts.updated_at < sqlc.arg('stale_before'))
+RETURNING *;
+
+-- name: SetInterestRunAccountTransaction :exec
+UPDATE interest_run_accounts
+SET transaction_id = $2, paid = $3, tax = $4, tax_rate = $5, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: SetInterestRunAccountTaxTransaction :exec
+UPDATE interest_run_accounts
+SET tax_transaction_id = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: CompleteInterestRunAccount :exec
+UPDATE interest_run_accounts
+SET status = 'COMPLETED', accrued = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: FailInterestRunAccount :exec
+UPDATE interest_run_accounts
+SET status = 'FAILED', error = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: GetInterestRunAccount :one
+SELECT * FROM interest_run_accounts WHERE run_id = $1 AND account_id = $2;
+
+-- name: GetInterestRunAccounts :many
+SELECT * FROM interest_run_accounts WHERE run_id = $1 ORDER BY status = 'COMPLETED', created_at;
+
+-- name: GetInterestPayments :many
+SELECT interest_run_accounts.run_date, interest_runs.period, transactions.created_at AS paid_at,
+    transactions.currency, interest_run_accounts.paid, interest_run_accounts.tax, interest_run_accounts.tax_rate
+FROM interest_run_accounts
+    JOIN interest_runs ON interest_runs.id = interest_run_accounts.run_id
+    JOIN transactions ON transactions.id = interest_run_accounts.transaction_id
+WHERE interest_run_accounts.account_id = sqlc.arg('account_id')
+    AND transactions.created_at >= sqlc.arg('from')
+    AND transactions.created_at < sqlc.arg('to')
+ORDER BY transactions.created_at;
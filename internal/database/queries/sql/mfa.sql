@@ -0,0 +1,54 @@
+-- name: GetUserMFA :one
+SELECT * FROM user_mfa WHERE user_id = $1 LIMIT 1;
+
+-- name: UpsertUserMFA :one
+INSERT INTO user_mfa (
+    user_id, secret
+) VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+    SET secret = EXCLUDED.secret, enabled_at = NULL, last_used_step = 0, updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: EnableUserMFA :execrows
+UPDATE user_mfa
+    SET enabled_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+    WHERE user_id = $1 AND enabled_at IS NULL;
+
+-- name: DeleteUserMFA :execrows
+DELETE FROM user_mfa WHERE user_id = $1;
+
+-- name: UpdateMFALastUsedStep :execrows
+UPDATE user_mfa
+    SET last_used_step = $2, updated_at = CURRENT_TIMESTAMP
+    WHERE user_id = $1 AND last_used_step < $2;
+
+-- name: SaveRecoveryCodes :exec
+INSERT INTO mfa_recovery_codes (user_id, code_hash)
+SELECT sqlc.arg('user_id'), unnest(sqlc.arg('code_hashes')::text[]);
+
+-- name: DeleteRecoveryCodes :exec
+DELETE FROM mfa_recovery_codes WHERE user_id = $1;
+
+-- name: UseRecoveryCode :execrows
+UPDATE mfa_recovery_codes
+    SET used_at = CURRENT_TIMESTAMP
+    WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL;
+
+-- name: CountUnusedRecoveryCodes :one
+SELECT COUNT(*) FROM mfa_recovery_codes WHERE user_id = $1 AND used_at IS NULL;
+
+-- name: SaveMFAChallenge :one
+INSERT INTO mfa_challenges (
+    user_id, token_hash, expires_at
+) VALUES ($1, $2, $3) RETURNING *;
+
+-- name: GetMFAChallengeByHash :one
+SELECT * FROM mfa_challenges WHERE token_hash = $1 LIMIT 1;
+
+-- name: IncrementMFAChallengeAttempts :exec
+UPDATE mfa_challenges SET attempts = attempts + 1 WHERE id = $1;
+
+-- name: MarkMFAChallengeUsed :execrows
+UPDATE mfa_challenges
+    SET used_at = CURRENT_TIMESTAMP
+    WHERE id = $1 AND used_at IS NULL;
@@ -0,0 +1,15 @@
+-- name: SaveNotification :one
+INSERT INTO notifications(
+    user_id, type, subject, message, metadata
+) VALUES ($1, $2, $3, $4, $5) RETURNING *;
+
+-- name: GetNotificationsByUserID :many
+SELECT * FROM notifications
+    WHERE user_id = $1 AND deleted_at IS NULL
+ORDER BY created_at DESC
+LIMIT 100;
+
+-- name: MarkNotificationRead :execrows
+UPDATE notifications
+    SET read_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+    WHERE id = $1 AND user_id = $2 AND read_at IS NULL;
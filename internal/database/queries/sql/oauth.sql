@@ -0,0 +1,38 @@
+-- name: CreateOAuthClient :one
+INSERT INTO oauth_clients (name, secret_hash, redirect_uris, scopes, created_by)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetOAuthClients :many
+SELECT * FROM oauth_clients ORDER BY created_at DESC;
+
+-- name: GetOAuthClient :one
+SELECT * FROM oauth_clients WHERE id = $1;
+
+-- name: RevokeOAuthClient :execrows
+UPDATE oauth_clients
+    SET revoked_at = CURRENT_TIMESTAMP
+    WHERE id = $1 AND revoked_at IS NULL;
+
+-- name: RevokeOAuthClientSessions :execrows
+UPDATE sessions
+    SET revoked_at = CURRENT_TIMESTAMP, revoked_reason = $2
+    WHERE client_id = $1 AND revoked_at IS NULL;
+
+-- name: SaveAuthorizationCode :exec
+INSERT INTO oauth_authorization_codes (
+    code_hash, client_id, user_id, redirect_uri, scopes, code_challenge, nonce, expires_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetAuthorizationCodeByHash :one
+SELECT * FROM oauth_authorization_codes WHERE code_hash = $1;
+
+-- name: MarkAuthorizationCodeUsed :execrows
+UPDATE oauth_authorization_codes
+    SET used_at = CURRENT_TIMESTAMP
+    WHERE id = $1 AND used_at IS NULL;
+
+-- name: SetAuthorizationCodeSession :exec
+UPDATE oauth_authorization_codes
+    SET session_id = $2
+    WHERE id = $1;
@@ -0,0 +1,33 @@
+-- name: GetUserPermissions :many
+SELECT DISTINCT rp.permission
+FROM user_roles ur
+JOIN role_permissions rp ON rp.role_id = ur.role_id
+WHERE ur.user_id = $1
+ORDER BY rp.permission;
+
+-- name: GetRoles :many
+SELECT r.name, r.description, array_agg(rp.permission ORDER BY rp.permission)::text[] AS permissions
+FROM roles r
+JOIN role_permissions rp ON rp.role_id = r.id
+GROUP BY r.id, r.name, r.description
+ORDER BY r.name;
+
+-- name: GetRolesByNames :many
+SELECT * FROM roles WHERE name = ANY(sqlc.arg('names')::text[]) ORDER BY name;
+
+-- name: GetUserRoleNames :many
+SELECT r.name
+FROM user_roles ur
+JOIN roles r ON r.id = ur.role_id
+WHERE ur.user_id = $1
+ORDER BY r.name;
+
+-- name: SetUserRoles :exec
+WITH removed AS (
+    DELETE FROM user_roles
+    WHERE user_id = sqlc.arg('user_id')
+      AND role_id NOT IN (SELECT id FROM roles WHERE name = ANY(sqlc.arg('role_names')::text[]))
+)
+INSERT INTO user_roles (user_id, role_id)
+SELECT sqlc.arg('user_id'), id FROM roles WHERE name = ANY(sqlc.arg('role_names')::text[])
+ON CONFLICT DO NOTHING;
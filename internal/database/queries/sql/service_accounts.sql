@@ -0,0 +1,41 @@
+-- name: CreateServiceAccount :one
+WITH service_user AS (
+    INSERT INTO users (email, password, first_name, last_name, user_type, email_verified_at)
+    VALUES (sqlc.arg('email'), sqlc.arg('password'), sqlc.arg('name'), 'Service Account', 'SERVICE', CURRENT_TIMESTAMP)
+    RETURNING id
+)
+INSERT INTO service_accounts (id, name, description, created_by)
+SELECT service_user.id, sqlc.arg('name'), sqlc.arg('description'), sqlc.arg('created_by') FROM service_user
+RETURNING *;
+
+-- name: GetServiceAccounts :many
+SELECT * FROM service_accounts ORDER BY name;
+
+-- name: GetServiceAccount :one
+SELECT * FROM service_accounts WHERE id = $1;
+
+-- name: SaveAPIKey :one
+INSERT INTO api_keys (service_account_id, name, prefix, key_hash, scopes, allowed_ips, expires_at, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: GetAPIKeys :many
+SELECT * FROM api_keys WHERE service_account_id = $1 ORDER BY created_at DESC;
+
+-- name: GetAPIKey :one
+SELECT * FROM api_keys WHERE id = $1 AND service_account_id = $2;
+
+-- name: GetAPIKeyByHash :one
+SELECT k.id, k.service_account_id, k.scopes, k.allowed_ips, k.expires_at, k.revoked_at,
+    s.name AS service_account_name, u.email, u.deleted_at
+FROM api_keys k
+    JOIN service_accounts s ON s.id = k.service_account_id
+    JOIN users u ON u.id = s.id
+WHERE k.key_hash = $1;
+
+-- name: RevokeAPIKey :execrows
+UPDATE api_keys SET revoked_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND service_account_id = $2 AND revoked_at IS NULL;
+
+-- name: TouchAPIKey :exec
+UPDATE api_keys SET last_used_at = CURRENT_TIMESTAMP WHERE id = $1;
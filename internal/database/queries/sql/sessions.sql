@@ -0,0 +1,100 @@
+-- name: SaveSession :one
+INSERT INTO sessions (
+    user_id, mfa_verified_at, ip, user_agent, device_fingerprint, client_id, scopes
+) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING *;
+
+-- name: SaveRefreshToken :one
+INSERT INTO refresh_tokens (
+    session_id, token_hash, expires_at
+) VALUES ($1, $2, $3) RETURNING *;
+
+-- name: GetRefreshTokenByHash :one
+SELECT
+    rt.id,
+    rt.session_id,
+    rt.expires_at,
+    rt.used_at,
+    s.user_id,
+    s.revoked_at AS session_revoked_at,
+    s.mfa_verified_at,
+    s.client_id,
+    s.scopes
+FROM refresh_tokens rt
+    JOIN sessions s ON s.id = rt.session_id
+WHERE rt.token_hash = $1 LIMIT 1;
+
+-- name: MarkRefreshTokenUsed :execrows
+UPDATE refresh_tokens
+    SET used_at = CURRENT_TIMESTAMP
+    WHERE id = $1 AND used_at IS NULL;
+
+-- name: RevokeSession :execrows
+UPDATE sessions
+    SET revoked_at = CURRENT_TIMESTAMP, revoked_reason = $2
+    WHERE id = $1 AND revoked_at IS NULL;
+
+-- name: RevokeUserSessions :execrows
+UPDATE sessions
+    SET revoked_at = CURRENT_TIMESTAMP, revoked_reason = $2
+    WHERE user_id = $1 AND revoked_at IS NULL
+        AND (sqlc.narg('keep_session_id')::UUID IS NULL OR id <> sqlc.narg('keep_session_id'));
+
+-- name: RevokeAccessToken :exec
+INSERT INTO revoked_access_tokens (
+    jti, expires_at
+) VALUES ($1, $2) ON CONFLICT (jti) DO NOTHING;
+
+-- name: IsAccessTokenRevoked :one
+SELECT (
+    EXISTS (SELECT 1 FROM revoked_access_tokens WHERE jti = sqlc.arg('jti'))
+    OR EXISTS (SELECT 1 FROM sessions WHERE id = sqlc.arg('session_id') AND revoked_at IS NOT NULL)
+)::boolean AS revoked;
+
+-- name: SetSessionMFAVerified :execrows
+UPDATE sessions
+    SET mfa_verified_at = $2
+    WHERE id = $1 AND revoked_at IS NULL;
+
+-- name: RevokeUserSession :execrows
+UPDATE sessions
+    SET revoked_at = CURRENT_TIMESTAMP, revoked_reason = $3
+    WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL;
+
+-- name: GetUserSessions :many
+SELECT
+    s.id,
+    s.ip,
+    s.user_agent,
+    s.mfa_verified_at,
+    s.revoked_at,
+    s.revoked_reason,
+    s.created_at,
+    s.client_id,
+    (s.revoked_at IS NULL AND EXISTS (
+        SELECT 1 FROM refresh_tokens rt
+        WHERE rt.session_id = s.id AND rt.used_at IS NULL AND rt.expires_at > CURRENT_TIMESTAMP
+    ))::boolean AS active
+FROM sessions s
+WHERE s.user_id = $1
+ORDER BY s.created_at DESC
+LIMIT 50;
+
+-- name: CheckSessionDevice :one
+SELECT
+    EXISTS (
+        SELECT 1 FROM sessions WHERE user_id = sqlc.arg('user_id') AND device_fingerprint IS NOT NULL
+    )::boolean AS has_devices,
+    EXISTS (
+        SELECT 1 FROM sessions WHERE user_id = sqlc.arg('user_id') AND device_fingerprint = sqlc.arg('device_fingerprint')
+    )::boolean AS known;
+
+-- name: SaveLoginAttempt :exec
+INSERT INTO login_attempts (
+    user_id, email, ip, user_agent, outcome, session_id
+) VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: GetLoginAttemptsByUserID :many
+SELECT * FROM login_attempts
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT 100;
@@ -0,0 +1,13 @@
+-- name: DeleteTransactionReversal :exec
+DELETE FROM transaction_reversals WHERE transaction_id = $1 AND reversal_id IS NULL;
+
+-- name: GetTransactionReversal :one
+SELECT * FROM transaction_reversals WHERE transaction_id = $1 OR reversal_id = $1 LIMIT 1;
+
+-- name: SaveTransactionReversal :one
+INSERT INTO transaction_reversals(
+    transaction_id, reason, reversed_by
+) VALUES ($1, $2, $3) RETURNING *;
+
+-- name: SetTransactionReversalID :exec
+UPDATE transaction_reversals SET reversal_id = $2 WHERE transaction_id = $1;
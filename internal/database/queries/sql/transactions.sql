@@ -15,6 +15,26 @@ FROM accounts a
 GROUP BY
     a.id, a.account_number, a.currency LIMIT 1;
 
+-- name: GetAccountBalanceAt :one
+SELECT (
+    COALESCE(SUM(CASE WHEN to_account_id = sqlc.arg('account_id') THEN amount ELSE 0 END), 0) -
+    COALESCE(SUM(CASE WHEN from_account_id = sqlc.arg('account_id') THEN amount ELSE 0 END), 0)
+)::BIGINT AS balance
+FROM transactions
+WHERE (to_account_id = sqlc.arg('account_id') OR from_account_id = sqlc.arg('account_id'))
+    AND created_at < sqlc.arg('before')::timestamp;
+
+-- name: GetAccountFlows :one
+SELECT
+    COALESCE(SUM(amount) FILTER (WHERE from_account_id = sqlc.arg('account_id')), 0)::BIGINT AS withdrawn,
+    COALESCE(SUM(amount) FILTER (
+        WHERE to_account_id = sqlc.arg('account_id') AND from_account_id <> sqlc.arg('interest_account_id')
+    ), 0)::BIGINT AS deposited
+FROM transactions
+WHERE (to_account_id = sqlc.arg('account_id') OR from_account_id = sqlc.arg('account_id'))
+    AND created_at >= sqlc.arg('from')::timestamp
+    AND created_at < sqlc.arg('to')::timestamp;
+
 -- name: GetTransactionByID :one
 SELECT * FROM transactions WHERE id = $1;
 
@@ -0,0 +1,60 @@
+-- name: UpdateUserName :exec
+UPDATE users
+    SET first_name = $1, last_name = $2, updated_at = CURRENT_TIMESTAMP
+    WHERE id = $3;
+
+-- name: SetPendingEmail :exec
+UPDATE users
+    SET pending_email = $1, updated_at = CURRENT_TIMESTAMP
+    WHERE id = $2;
+
+-- name: ConfirmPendingEmail :execrows
+UPDATE users
+    SET email = pending_email, pending_email = NULL, email_verified_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+    WHERE id = $1 AND pending_email = $2;
+
+-- name: MarkEmailVerified :execrows
+UPDATE users
+    SET email_verified_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+    WHERE id = $1 AND email = $2 AND email_verified_at IS NULL;
+
+-- name: SetUserTaxStatus :exec
+UPDATE users
+    SET tax_residency = $1, tax_exempt = $2, updated_at = CURRENT_TIMESTAMP
+    WHERE id = $3;
+
+-- name: UpdateUserPassword :exec
+UPDATE users
+    SET password = $1, password_change_required = FALSE, updated_at = CURRENT_TIMESTAMP
+    WHERE id = $2;
+
+-- name: RehashUserPassword :exec
+UPDATE users
+    SET password = sqlc.arg('new_password')
+    WHERE id = sqlc.arg('id') AND password = sqlc.arg('old_password');
+
+-- name: RequirePasswordChange :exec
+UPDATE users
+    SET password_change_required = TRUE, updated_at = CURRENT_TIMESTAMP
+    WHERE id = $1;
+
+-- name: DeactivateUser :execrows
+UPDATE users
+    SET deleted_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+    WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: ReactivateUser :execrows
+UPDATE users
+    SET deleted_at = NULL, updated_at = CURRENT_TIMESTAMP
+    WHERE id = $1 AND deleted_at IS NOT NULL;
+
+-- name: SuspendUserAccounts :many
+UPDATE accounts a
+    SET status = 'SUSPENDED', updated_at = CURRENT_TIMESTAMP
+    FROM (
+        SELECT id, status FROM accounts
+        WHERE user_id = $1 AND status IN ('PENDING', 'ACTIVE', 'DORMANT')
+        FOR UPDATE
+    ) AS previous
+    WHERE a.id = previous.id
+RETURNING a.id AS account_id, previous.status AS old_status;
@@ -0,0 +1,17 @@
+-- name: SaveUserToken :one
+INSERT INTO user_tokens(
+    user_id, purpose, token_hash, email, expires_at
+) VALUES ($1, $2, $3, $4, $5) RETURNING *;
+
+-- name: GetUserTokenByHash :one
+SELECT * FROM user_tokens WHERE token_hash = $1 LIMIT 1;
+
+-- name: MarkUserTokenUsed :execrows
+UPDATE user_tokens
+    SET used_at = CURRENT_TIMESTAMP
+    WHERE id = $1 AND used_at IS NULL;
+
+-- name: InvalidateUserTokens :exec
+UPDATE user_tokens
+    SET used_at = CURRENT_TIMESTAMP
+    WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL;
@@ -1,14 +1,18 @@
 -- name: SaveUser :one
 INSERT INTO users(
-    email, password, first_name, last_name, user_type
-) VALUES ($1, $2, $3, $4, $5) RETURNING id, email;
+    email, password, first_name, last_name, user_type, email_verified_at
+) VALUES (
+    $1, $2, $3, $4, $5,
+    CASE WHEN sqlc.arg('email_verified')::boolean THEN CURRENT_TIMESTAMP END
+) RETURNING id, email;
 
 -- name: GetUserByEmail :one
-SELECT id, email, password, first_name, last_name, user_type, created_at, updated_at
+SELECT id, email, password, first_name, last_name, user_type, created_at, updated_at, deleted_at, email_verified_at, password_change_required
     FROM users WHERE email = $1 LIMIT 1;
 
 -- name: GetUserByID :one
-SELECT id, email, password, first_name, last_name, user_type, created_at, updated_at
+SELECT id, email, password, first_name, last_name, user_type, created_at, updated_at, deleted_at, email_verified_at, pending_email,
+    tax_residency, tax_exempt
     FROM users WHERE id = $1 LIMIT 1;
 
 -- name: GetProfileByUserID :one
@@ -20,15 +24,16 @@ SELECT
     users.last_name AS last_name,
     accounts.account_type AS account_type,
     users.user_type AS user_type,
-    users.created_at AS registered_at
+    users.created_at AS registered_at,
+    users.password_change_required AS password_change_required
 FROM users
          JOIN accounts ON users.id = accounts.user_id
-WHERE users.id = $1 LIMIT 1;
+WHERE users.id = $1 AND users.deleted_at IS NULL LIMIT 1;
 
 -- name: SaveAccount :one
 INSERT INTO accounts(
-    user_id, account_number, status, account_type, currency
-) VALUES ($1, $2, $3, $4, $5) RETURNING *;
+    user_id, account_number, status, account_type, currency, sort_code, iban
+) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING *;
 
 -- name: GetAccountByID :one
 SELECT id, user_id, account_number, status, account_type, currency, created_at, updated_at
@@ -50,7 +55,7 @@ SELECT
     FROM accounts
     JOIN users ON users.id = accounts.user_id
 WHERE users.user_type='CUSTOMER'
-    AND accounts.status = 'ACTIVE'
+    AND accounts.status IN ('ACTIVE', 'DORMANT')
     AND accounts.account_type = 'CURRENT';
 
 -- name: GetAccountByCurrency :one
@@ -92,7 +97,38 @@ SELECT
     account_type,
     currency,
     accounts.balance,
-    accounts.created_at
+    accounts.created_at,
+    accounts.sort_code,
+    accounts.iban,
+    (
+        SELECT COALESCE(SUM(ia.amount + ia.bonus_amount), 0) + accounts.interest_carry FROM interest_accruals ia
+            WHERE ia.account_id = accounts.id AND ia.capitalised_at IS NULL
+    )::FLOAT8 AS accrued_interest
 FROM accounts
          JOIN users ON users.id = accounts.user_id
-WHERE accounts.id = $1;
\ No newline at end of file
+WHERE accounts.id = $1;
+
+-- name: GetAccountByNumber :one
+SELECT id, user_id, account_number, sort_code, iban, status, account_type, currency
+    FROM accounts WHERE account_number = $1 LIMIT 1;
+
+-- name: GetAccountByIBAN :one
+SELECT id, user_id, account_number, sort_code, iban, status, account_type, currency
+    FROM accounts WHERE iban = $1 LIMIT 1;
+
+-- name: GetAccountsMissingIdentifiers :many
+SELECT id, account_number, currency
+    FROM accounts
+WHERE account_type = 'CURRENT'
+    AND (sort_code IS NULL OR (currency = 'EUR' AND iban IS NULL));
+
+-- name: UpdateAccountIdentifiers :exec
+UPDATE accounts
+    SET sort_code = $1, iban = $2, updated_at = CURRENT_TIMESTAMP
+    WHERE id = $3;
+
+-- name: GetAccountTaxStatus :one
+SELECT users.tax_residency, users.tax_exempt
+    FROM accounts
+    JOIN users ON users.id = accounts.user_id
+WHERE accounts.id = $1;
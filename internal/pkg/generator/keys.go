@@ -0,0 +1,354 @@
+package generator
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"math/big"
+	"os"
+	"payter-bank/internal/config"
+)
+
+// SigningKey is a key access tokens are signed or verified with. ID is the kid of the tokens it
+// signs, the RFC 7638 thumbprint of its public key.
+type SigningKey struct {
+	ID        string
+	Method    jwt.SigningMethod
+	Private   crypto.Signer
+	PublicKey crypto.PublicKey
+}
+
+// KeySet holds the key new access tokens are signed with and the keys tokens are still accepted
+// from. Keys are swapped on restart, see the README for the rotation procedure.
+type KeySet struct {
+	signing      *SigningKey
+	verification map[string]*SigningKey
+	// secret is the HS256 secret used before keys were configured. Tokens signed with it carry
+	// no kid and are accepted until they expire.
+	secret []byte
+}
+
+// NewKeySet loads the signing and verification keys named in cfg. Without a signing key tokens are
+// signed with the HS256 secret, which is only meant for local development.
+func NewKeySet(cfg config.JWTConfig) (*KeySet, error) {
+	keys := &KeySet{
+		verification: make(map[string]*SigningKey),
+		secret:       []byte(cfg.Secret),
+	}
+
+	if cfg.SigningKeyFile == "" {
+		if cfg.Secret == "" {
+			return nil, errors.New("either JWT_SIGNING_KEY_FILE or JWT_SECRET must be set")
+		}
+		return keys, nil
+	}
+
+	signing, err := loadKeyFile(cfg.SigningKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	if signing.Private == nil {
+		return nil, fmt.Errorf("%s holds a public key, the signing key must be a private key", cfg.SigningKeyFile)
+	}
+	keys.signing = signing
+	keys.verification[signing.ID] = signing
+
+	for _, path := range cfg.VerificationKeyFiles {
+		key, err := loadKeyFile(path)
+		if err != nil {
+			return nil, err
+		}
+		keys.verification[key.ID] = key
+	}
+	return keys, nil
+}
+
+// NewSecretKeySet returns a key set that signs and verifies with an HS256 secret.
+func NewSecretKeySet(secret string) *KeySet {
+	return &KeySet{
+		verification: make(map[string]*SigningKey),
+		secret:       []byte(secret),
+	}
+}
+
+// NewKeySetFromKeys returns a key set that signs with signing and also accepts tokens signed with
+// verification. It is used by tests that do not read keys from files.
+func NewKeySetFromKeys(signing crypto.Signer, verification ...crypto.PublicKey) (*KeySet, error) {
+	key, err := newSigningKey(signing, signing.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	keys := &KeySet{
+		signing:      key,
+		verification: map[string]*SigningKey{key.ID: key},
+	}
+	for _, public := range verification {
+		key, err := newSigningKey(nil, public)
+		if err != nil {
+			return nil, err
+		}
+		keys.verification[key.ID] = key
+	}
+	return keys, nil
+}
+
+// sign signs claims with the signing key, or the HS256 secret when there is none.
+func (k *KeySet) sign(claims jwt.Claims) (string, error) {
+	if k.signing == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(k.secret)
+	}
+
+	token := jwt.NewWithClaims(k.signing.Method, claims)
+	token.Header["kid"] = k.signing.ID
+	return token.SignedString(k.signing.Private)
+}
+
+// Algorithm is the algorithm new tokens are signed with.
+func (k *KeySet) Algorithm() string {
+	if k.signing == nil {
+		return jwt.SigningMethodHS256.Alg()
+	}
+	return k.signing.Method.Alg()
+}
+
+// keyFunc picks the key a token is verified with from its kid. The algorithm in the token header
+// must be the one of the key, so a public key can never be used as an HMAC secret.
+func (k *KeySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok || len(k.secret) == 0 {
+			return nil, jwt.ErrTokenUnverifiable
+		}
+		return k.secret, nil
+	}
+
+	key, ok := k.verification[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown kid %q", jwt.ErrTokenUnverifiable, kid)
+	}
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, jwt.ErrTokenSignatureInvalid
+	}
+	return key.PublicKey, nil
+}
+
+// JWKS is the JSON Web Key Set published at /.well-known/jwks.json.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK is the public part of a signing key, RFC 7517.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	ID        string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Curve     string `json:"crv,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	X         string `json:"x,omitempty"`
+	Y         string `json:"y,omitempty"`
+}
+
+// JWKS returns the public keys tokens are verified with, the signing key first. The HS256 secret
+// is never published.
+func (k *KeySet) JWKS() JWKS {
+	jwks := JWKS{Keys: []JWK{}}
+	if k.signing != nil {
+		jwks.Keys = append(jwks.Keys, k.signing.jwk())
+	}
+	for id, key := range k.verification {
+		if k.signing != nil && id == k.signing.ID {
+			continue
+		}
+		jwks.Keys = append(jwks.Keys, key.jwk())
+	}
+	return jwks
+}
+
+func (k *SigningKey) jwk() JWK {
+	jwk := publicJWK(k.PublicKey)
+	jwk.ID = k.ID
+	jwk.Use = "sig"
+	jwk.Algorithm = k.Method.Alg()
+	return jwk
+}
+
+func loadKeyFile(path string) (*SigningKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read jwt key: %w", err)
+	}
+
+	key, err := ParseKeyPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return key, nil
+}
+
+// ParseKeyPEM parses an RSA, ECDSA (P-256 or P-384) or Ed25519 key. A private key can sign and
+// verify, a public key can only verify.
+func ParseKeyPEM(data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	switch block.Type {
+	case "PRIVATE KEY", "RSA PRIVATE KEY", "EC PRIVATE KEY":
+		private, err := parsePrivateKey(block)
+		if err != nil {
+			return nil, err
+		}
+		return newSigningKey(private, private.Public())
+	case "PUBLIC KEY", "RSA PUBLIC KEY":
+		public, err := parsePublicKey(block)
+		if err != nil {
+			return nil, err
+		}
+		return newSigningKey(nil, public)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+}
+
+func parsePrivateKey(block *pem.Block) (crypto.Signer, error) {
+	var (
+		key any
+		err error
+	)
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key %T", key)
+	}
+	return signer, nil
+}
+
+func parsePublicKey(block *pem.Block) (crypto.PublicKey, error) {
+	if block.Type == "RSA PUBLIC KEY" {
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	}
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
+
+// newSigningKey works out the algorithm of a key from its type, private is nil for keys that are
+// only used to verify.
+func newSigningKey(private crypto.Signer, public crypto.PublicKey) (*SigningKey, error) {
+	var method jwt.SigningMethod
+	switch key := public.(type) {
+	case *rsa.PublicKey:
+		if key.N.BitLen() < 2048 {
+			return nil, errors.New("rsa keys must be at least 2048 bits")
+		}
+		method = jwt.SigningMethodRS256
+	case *ecdsa.PublicKey:
+		switch key.Curve {
+		case elliptic.P256():
+			method = jwt.SigningMethodES256
+		case elliptic.P384():
+			method = jwt.SigningMethodES384
+		default:
+			return nil, errors.New("ecdsa keys must use P-256 or P-384")
+		}
+	case ed25519.PublicKey:
+		method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("unsupported public key %T", public)
+	}
+
+	return &SigningKey{
+		ID:        thumbprint(public),
+		Method:    method,
+		Private:   private,
+		PublicKey: public,
+	}, nil
+}
+
+// publicJWK returns the members of the JWK that describe the key itself.
+func publicJWK(public crypto.PublicKey) JWK {
+	switch key := public.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			KeyType: "RSA",
+			N:       encodeSegment(key.N.Bytes()),
+			E:       encodeSegment(big.NewInt(int64(key.E)).Bytes()),
+		}
+	case *ecdsa.PublicKey:
+		size := (key.Curve.Params().BitSize + 7) / 8
+		return JWK{
+			KeyType: "EC",
+			Curve:   key.Curve.Params().Name,
+			X:       encodeSegment(key.X.FillBytes(make([]byte, size))),
+			Y:       encodeSegment(key.Y.FillBytes(make([]byte, size))),
+		}
+	case ed25519.PublicKey:
+		return JWK{
+			KeyType: "OKP",
+			Curve:   "Ed25519",
+			X:       encodeSegment(key),
+		}
+	}
+	return JWK{}
+}
+
+// thumbprint is the RFC 7638 thumbprint of a public key. It only depends on the key, so the kid of
+// a key is the same on every instance and after every restart.
+func thumbprint(public crypto.PublicKey) string {
+	jwk := publicJWK(public)
+
+	// the required members in lexicographic order
+	var members any
+	switch jwk.KeyType {
+	case "RSA":
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.KeyType, jwk.N}
+	case "EC":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		}{jwk.Curve, jwk.KeyType, jwk.X, jwk.Y}
+	default:
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Curve, jwk.KeyType, jwk.X}
+	}
+
+	data, _ := json.Marshal(members)
+	sum := sha256.Sum256(data)
+	return encodeSegment(sum[:])
+}
+
+func encodeSegment(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
@@ -0,0 +1,209 @@
+package generator
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"os"
+	"path/filepath"
+	"payter-bank/internal/config"
+	"testing"
+	"time"
+)
+
+var keysTestConfig = config.JWTConfig{
+	Issuer:   "test-issuer",
+	Audience: "test-audience",
+	Expiry:   time.Hour,
+}
+
+func TestKeySet_SignsWithKid(t *testing.T) {
+	for name, key := range map[string]crypto.Signer{
+		"RS256": mustRSAKey(t),
+		"ES256": mustECKey(t, elliptic.P256()),
+		"ES384": mustECKey(t, elliptic.P384()),
+		"EdDSA": mustEdKey(t),
+	} {
+		t.Run(name, func(t *testing.T) {
+			keys, err := NewKeySetFromKeys(key)
+			assert.NoError(t, err)
+			gen := NewTokenGenerator(keysTestConfig, keys)
+
+			data := TokenData{UserID: uuid.New(), SessionID: uuid.New()}
+			token, err := gen.Generate(data)
+			assert.NoError(t, err)
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, &Claim{})
+			assert.NoError(t, err)
+			assert.Equal(t, name, parsed.Method.Alg())
+			assert.Equal(t, keys.signing.ID, parsed.Header["kid"])
+
+			claim, err := gen.Parse(token)
+			assert.NoError(t, err)
+			assert.Equal(t, data.UserID, claim.UserID)
+			assert.Equal(t, data.SessionID, claim.SessionID)
+		})
+	}
+}
+
+func TestKeySet_Rotation(t *testing.T) {
+	oldKey, newKey := mustECKey(t, elliptic.P256()), mustECKey(t, elliptic.P256())
+	oldKeys, err := NewKeySetFromKeys(oldKey)
+	assert.NoError(t, err)
+	token, err := NewTokenGenerator(keysTestConfig, oldKeys).Generate(TokenData{UserID: uuid.New()})
+	assert.NoError(t, err)
+
+	t.Run("accepts tokens of the previous key during the grace window", func(t *testing.T) {
+		keys, err := NewKeySetFromKeys(newKey, oldKey.Public())
+		assert.NoError(t, err)
+
+		_, err = NewTokenGenerator(keysTestConfig, keys).Parse(token)
+		assert.NoError(t, err)
+		assert.Len(t, keys.JWKS().Keys, 2)
+		assert.Equal(t, keys.signing.ID, keys.JWKS().Keys[0].ID)
+	})
+
+	t.Run("rejects tokens of a retired key", func(t *testing.T) {
+		keys, err := NewKeySetFromKeys(newKey)
+		assert.NoError(t, err)
+
+		_, err = NewTokenGenerator(keysTestConfig, keys).Parse(token)
+		assert.ErrorIs(t, err, jwt.ErrTokenUnverifiable)
+	})
+}
+
+func TestKeySet_RejectsAlgorithmConfusion(t *testing.T) {
+	key := mustRSAKey(t)
+	keys, err := NewKeySetFromKeys(key)
+	assert.NoError(t, err)
+
+	// an HS256 token signed with the public key as the secret, claiming the kid of the RSA key
+	publicDER, err := x509.MarshalPKIXPublicKey(key.Public())
+	assert.NoError(t, err)
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claim{RegisteredClaims: jwt.RegisteredClaims{
+		Issuer:    keysTestConfig.Issuer,
+		Audience:  []string{keysTestConfig.Audience},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}})
+	forged.Header["kid"] = keys.signing.ID
+	token, err := forged.SignedString(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+	assert.NoError(t, err)
+
+	_, err = NewTokenGenerator(keysTestConfig, keys).Parse(token)
+	assert.Error(t, err)
+}
+
+func TestKeySet_AcceptsSecretTokensDuringMigration(t *testing.T) {
+	legacy := NewSecretKeySet("testsecret")
+	token, err := NewTokenGenerator(keysTestConfig, legacy).Generate(TokenData{UserID: uuid.New()})
+	assert.NoError(t, err)
+
+	keys, err := NewKeySetFromKeys(mustECKey(t, elliptic.P256()))
+	assert.NoError(t, err)
+	_, err = NewTokenGenerator(keysTestConfig, keys).Parse(token)
+	assert.Error(t, err, "tokens without a kid are rejected once the secret is removed")
+
+	keys.secret = []byte("testsecret")
+	_, err = NewTokenGenerator(keysTestConfig, keys).Parse(token)
+	assert.NoError(t, err)
+	assert.Empty(t, legacy.JWKS().Keys)
+}
+
+func TestNewKeySet(t *testing.T) {
+	dir := t.TempDir()
+	signing := mustECKey(t, elliptic.P256())
+	previous := mustRSAKey(t)
+	signingPath := writeKey(t, dir, "signing.pem", "EC PRIVATE KEY", func() []byte {
+		der, _ := x509.MarshalECPrivateKey(signing.(*ecdsa.PrivateKey))
+		return der
+	}())
+	previousPath := writeKey(t, dir, "previous.pem.pub", "PUBLIC KEY", func() []byte {
+		der, _ := x509.MarshalPKIXPublicKey(previous.Public())
+		return der
+	}())
+
+	t.Run("loads the signing and verification keys", func(t *testing.T) {
+		keys, err := NewKeySet(config.JWTConfig{SigningKeyFile: signingPath, VerificationKeyFiles: []string{previousPath}})
+		assert.NoError(t, err)
+		assert.Equal(t, jwt.SigningMethodES256, keys.signing.Method)
+
+		jwks := keys.JWKS()
+		assert.Len(t, jwks.Keys, 2)
+		assert.Equal(t, JWK{
+			KeyType:   "EC",
+			ID:        keys.signing.ID,
+			Use:       "sig",
+			Algorithm: "ES256",
+			Curve:     "P-256",
+			X:         jwks.Keys[0].X,
+			Y:         jwks.Keys[0].Y,
+		}, jwks.Keys[0])
+		assert.Equal(t, "RSA", jwks.Keys[1].KeyType)
+		assert.Equal(t, "RS256", jwks.Keys[1].Algorithm)
+		assert.Equal(t, "AQAB", jwks.Keys[1].E)
+	})
+
+	t.Run("requires a private signing key", func(t *testing.T) {
+		_, err := NewKeySet(config.JWTConfig{SigningKeyFile: previousPath})
+		assert.Error(t, err)
+	})
+
+	t.Run("requires a key or a secret", func(t *testing.T) {
+		_, err := NewKeySet(config.JWTConfig{})
+		assert.Error(t, err)
+	})
+
+	t.Run("falls back to the secret", func(t *testing.T) {
+		keys, err := NewKeySet(config.JWTConfig{Secret: "testsecret"})
+		assert.NoError(t, err)
+		assert.Nil(t, keys.signing)
+	})
+
+	t.Run("fails on a missing file", func(t *testing.T) {
+		_, err := NewKeySet(config.JWTConfig{SigningKeyFile: filepath.Join(dir, "missing.pem")})
+		assert.Error(t, err)
+	})
+}
+
+func TestThumbprint(t *testing.T) {
+	// RFC 7638 section 3.1
+	n := "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw"
+	modulus, err := base64.RawURLEncoding.DecodeString(n)
+	assert.NoError(t, err)
+	key := &rsa.PublicKey{N: new(big.Int).SetBytes(modulus), E: 65537}
+	assert.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", thumbprint(key))
+}
+
+func mustRSAKey(t *testing.T) crypto.Signer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	return key
+}
+
+func mustECKey(t *testing.T, curve elliptic.Curve) crypto.Signer {
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	assert.NoError(t, err)
+	return key
+}
+
+func mustEdKey(t *testing.T) crypto.Signer {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	return key
+}
+
+func writeKey(t *testing.T, dir, name, blockType string, der []byte) string {
+	path := filepath.Join(dir, name)
+	assert.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+	return path
+}
@@ -55,6 +55,50 @@ func (mr *MockTokenGeneratorMockRecorder) Generate(data any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockTokenGenerator)(nil).Generate), data)
 }
 
+// GenerateIDToken mocks base method.
+func (m *MockTokenGenerator) GenerateIDToken(data generator.IDTokenData) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateIDToken", data)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateIDToken indicates an expected call of GenerateIDToken.
+func (mr *MockTokenGeneratorMockRecorder) GenerateIDToken(data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateIDToken", reflect.TypeOf((*MockTokenGenerator)(nil).GenerateIDToken), data)
+}
+
+// Metadata mocks base method.
+func (m *MockTokenGenerator) Metadata() generator.TokenMetadata {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Metadata")
+	ret0, _ := ret[0].(generator.TokenMetadata)
+	return ret0
+}
+
+// Metadata indicates an expected call of Metadata.
+func (mr *MockTokenGeneratorMockRecorder) Metadata() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Metadata", reflect.TypeOf((*MockTokenGenerator)(nil).Metadata))
+}
+
+// Parse mocks base method.
+func (m *MockTokenGenerator) Parse(token string) (*generator.Claim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Parse", token)
+	ret0, _ := ret[0].(*generator.Claim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Parse indicates an expected call of Parse.
+func (mr *MockTokenGeneratorMockRecorder) Parse(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parse", reflect.TypeOf((*MockTokenGenerator)(nil).Parse), token)
+}
+
 // Validate mocks base method.
 func (m *MockTokenGenerator) Validate(token string) (generator.TokenData, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user_token_generator.go
+//
+// Generated by this command:
+//
+//	mockgen -source=user_token_generator.go -destination=./mocks/user_token_generator_mocks.go -package=generatormocks
+//
+
+// Package generatormocks is a generated GoMock package.
+package generatormocks
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserTokenGenerator is a mock of UserTokenGenerator interface.
+type MockUserTokenGenerator struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserTokenGeneratorMockRecorder
+	isgomock struct{}
+}
+
+// MockUserTokenGeneratorMockRecorder is the mock recorder for MockUserTokenGenerator.
+type MockUserTokenGeneratorMockRecorder struct {
+	mock *MockUserTokenGenerator
+}
+
+// NewMockUserTokenGenerator creates a new mock instance.
+func NewMockUserTokenGenerator(ctrl *gomock.Controller) *MockUserTokenGenerator {
+	mock := &MockUserTokenGenerator{ctrl: ctrl}
+	mock.recorder = &MockUserTokenGeneratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserTokenGenerator) EXPECT() *MockUserTokenGeneratorMockRecorder {
+	return m.recorder
+}
+
+// Generate mocks base method.
+func (m *MockUserTokenGenerator) Generate() (string, string) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Generate")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	return ret0, ret1
+}
+
+// Generate indicates an expected call of Generate.
+func (mr *MockUserTokenGeneratorMockRecorder) Generate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockUserTokenGenerator)(nil).Generate))
+}
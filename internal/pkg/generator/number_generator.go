@@ -3,25 +3,38 @@
 package generator
 
 import (
+	"crypto/rand"
 	"fmt"
-	"math/rand"
+	"math/big"
+	"payter-bank/internal/pkg/identifier"
 )
 
 type NumberGenerator interface {
 	Generate() string
 }
 
-type numberGenerator struct {
-	n int
-}
+type accountNumberGenerator struct{}
 
-func NewNumberGenerator(n int) NumberGenerator {
-	return &numberGenerator{n: n}
+// NewAccountNumberGenerator returns a generator of 8-digit account numbers made of 7 random
+// digits and a modulus 11 check digit. Uniqueness is not guaranteed - callers must retry
+// when the number is already taken.
+func NewAccountNumberGenerator() NumberGenerator {
+	return &accountNumberGenerator{}
 }
 
-func (g *numberGenerator) Generate() string {
-	value := rand.Intn(g.n)
-	return fmt.Sprintf("%08d", value)
+func (g *accountNumberGenerator) Generate() string {
+	limit := big.NewInt(10_000_000)
+	for {
+		n, err := rand.Int(rand.Reader, limit)
+		if err != nil {
+			panic(err)
+		}
+
+		base := fmt.Sprintf("%07d", n.Int64())
+		if digit, ok := identifier.CheckDigit(base); ok {
+			return fmt.Sprintf("%s%d", base, digit)
+		}
+	}
 }
 
-var DefaultNumberGenerator = NewNumberGenerator(99999999)
+var DefaultNumberGenerator = NewAccountNumberGenerator()
@@ -2,14 +2,22 @@ package generator
 
 import (
 	"github.com/stretchr/testify/assert"
+	"payter-bank/internal/pkg/identifier"
 	"testing"
 )
 
 func TestNumberGenerator_Generate(t *testing.T) {
 	t.Run("generate", func(t *testing.T) {
-		g := NewNumberGenerator(9999)
+		g := NewAccountNumberGenerator()
 		number := g.Generate()
 		t.Log(number)
 		assert.Equal(t, 8, len(number))
 	})
+
+	t.Run("generated numbers pass the modulus check", func(t *testing.T) {
+		g := NewAccountNumberGenerator()
+		for i := 0; i < 1000; i++ {
+			assert.NoError(t, identifier.ValidateAccountNumber(g.Generate()))
+		}
+	})
 }
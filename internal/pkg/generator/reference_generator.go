@@ -0,0 +1,77 @@
+package generator
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"sync"
+	"time"
+)
+
+// crockford is the Crockford base32 alphabet, its ordering matches ASCII ordering so the
+// encoded references sort the same way as the underlying bytes.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+const referencePrefix = "TX"
+
+// referenceGenerator produces ULID based transaction references: a 48-bit millisecond
+// timestamp followed by 80 bits of randomness. References generated within the same
+// millisecond increment the random part, so they are strictly increasing per process.
+type referenceGenerator struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	lastMs  uint64
+	lastRnd [10]byte
+}
+
+// NewReferenceGenerator returns a generator of unique, lexicographically sortable transaction references.
+func NewReferenceGenerator() NumberGenerator {
+	return &referenceGenerator{now: time.Now}
+}
+
+func (g *referenceGenerator) Generate() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ms := uint64(g.now().UnixMilli())
+	if ms <= g.lastMs {
+		ms = g.lastMs
+		increment(&g.lastRnd)
+	} else {
+		if _, err := rand.Read(g.lastRnd[:]); err != nil {
+			panic(err)
+		}
+		g.lastMs = ms
+	}
+
+	var id [16]byte
+	var ts [8]byte
+	binary.BigEndian.PutUint64(ts[:], ms)
+	copy(id[:6], ts[2:])
+	copy(id[6:], g.lastRnd[:])
+	return referencePrefix + encodeULID(id)
+}
+
+func increment(b *[10]byte) {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return
+		}
+	}
+}
+
+// encodeULID encodes 128 bits into 26 Crockford base32 characters.
+func encodeULID(id [16]byte) string {
+	hi := binary.BigEndian.Uint64(id[:8])
+	lo := binary.BigEndian.Uint64(id[8:])
+
+	out := make([]byte, 26)
+	for i := 25; i >= 0; i-- {
+		out[i] = crockford[lo&0x1f]
+		lo = (lo >> 5) | (hi << 59)
+		hi >>= 5
+	}
+	return string(out)
+}
+
+var DefaultReferenceGenerator = NewReferenceGenerator()
@@ -0,0 +1,56 @@
+package generator
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestReferenceGenerator_Generate(t *testing.T) {
+	t.Run("generates prefixed 28 character references", func(t *testing.T) {
+		ref := NewReferenceGenerator().Generate()
+		assert.Len(t, ref, 28)
+		assert.Equal(t, "TX", ref[:2])
+	})
+
+	t.Run("references are unique and strictly increasing within the same millisecond", func(t *testing.T) {
+		fixed := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+		g := &referenceGenerator{now: func() time.Time { return fixed }}
+
+		seen := make(map[string]struct{})
+		prev := ""
+		for i := 0; i < 10000; i++ {
+			ref := g.Generate()
+			_, duplicate := seen[ref]
+			assert.False(t, duplicate)
+			assert.Greater(t, ref, prev)
+			seen[ref] = struct{}{}
+			prev = ref
+		}
+	})
+
+	t.Run("references sort by creation time", func(t *testing.T) {
+		current := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+		g := &referenceGenerator{now: func() time.Time { return current }}
+
+		first := g.Generate()
+		current = current.Add(time.Millisecond)
+		second := g.Generate()
+		current = current.Add(time.Hour)
+		third := g.Generate()
+
+		assert.Less(t, first, second)
+		assert.Less(t, second, third)
+	})
+
+	t.Run("clock moving backwards keeps references increasing", func(t *testing.T) {
+		current := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+		g := &referenceGenerator{now: func() time.Time { return current }}
+
+		first := g.Generate()
+		current = current.Add(-time.Second)
+		second := g.Generate()
+
+		assert.Less(t, first, second)
+	})
+}
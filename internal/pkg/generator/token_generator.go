@@ -15,14 +15,59 @@ import (
 type TokenGenerator interface {
 	Generate(data TokenData) (string, error)
 	Validate(token string) (TokenData, error)
+	// Parse verifies a token against the key set and returns its claims, including its jti and expiry.
+	Parse(token string) (*Claim, error)
+	// GenerateIDToken signs an OpenID Connect ID token for a client the user has authorized.
+	GenerateIDToken(data IDTokenData) (string, error)
+	// Metadata describes the tokens the generator issues, it is published for OpenID Connect discovery.
+	Metadata() TokenMetadata
 }
 
 type TokenData struct {
 	UserID    uuid.UUID
 	AccountID uuid.UUID
 	ExpiresAt time.Time
+	// SessionID is the login session the token was issued for, revoking the session revokes the token.
+	SessionID uuid.UUID
+	// MFAVerifiedAt is when the user last entered an MFA code in this session, zero if they have not.
+	MFAVerifiedAt time.Time
+	// IP and UserAgent identify the client a session is started from. They are recorded on the
+	// session and are not put in the token.
+	IP        string `json:"-"`
+	UserAgent string `json:"-"`
+	// ClientID is the OAuth client a token was issued to on behalf of the user, it is nil for tokens
+	// issued on login. Scope is the space separated scopes the user granted the client.
+	ClientID uuid.UUID `json:"client_id"`
+	Scope    string    `json:"scope,omitempty"`
 }
 
+// IDTokenData is what an ID token tells a client about the user who authorized it. Email and Name
+// are only set when the user granted the profile scope.
+type IDTokenData struct {
+	UserID   uuid.UUID
+	ClientID uuid.UUID
+	Nonce    string
+	Email    string
+	Name     string
+}
+
+type IDTokenClaim struct {
+	Nonce string `json:"nonce,omitempty"`
+	Email string `json:"email,omitempty"`
+	Name  string `json:"name,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// TokenMetadata describes the issuer, signing algorithm and claims of the tokens a generator issues.
+type TokenMetadata struct {
+	Issuer            string
+	SigningAlgorithms []string
+	Claims            []string
+}
+
+// tokenClaims are the claims access and ID tokens can carry.
+var tokenClaims = []string{"iss", "sub", "aud", "exp", "iat", "jti", "nonce", "email", "name", "scope", "client_id"}
+
 type Claim struct {
 	TokenData
 	jwt.RegisteredClaims
@@ -32,13 +77,25 @@ func (a Claim) Validate(ctx context.Context) error {
 	return nil
 }
 
+// validMethods are the algorithms tokens can be signed with, HS256 is only accepted from tokens
+// without a kid.
+var validMethods = []string{
+	jwt.SigningMethodRS256.Alg(),
+	jwt.SigningMethodES256.Alg(),
+	jwt.SigningMethodES384.Alg(),
+	jwt.SigningMethodEdDSA.Alg(),
+	jwt.SigningMethodHS256.Alg(),
+}
+
 type tokenGenerator struct {
-	cfg config.JWTConfig
+	cfg  config.JWTConfig
+	keys *KeySet
 }
 
-func NewTokenGenerator(cfg config.JWTConfig) TokenGenerator {
+func NewTokenGenerator(cfg config.JWTConfig, keys *KeySet) TokenGenerator {
 	return &tokenGenerator{
-		cfg: cfg,
+		cfg:  cfg,
+		keys: keys,
 	}
 }
 
@@ -46,6 +103,7 @@ func (g *tokenGenerator) Generate(data TokenData) (string, error) {
 	claims := &Claim{
 		TokenData: data,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
 			Issuer:    g.cfg.Issuer,
 			Subject:   data.UserID.String(),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(g.cfg.Expiry)),
@@ -53,30 +111,64 @@ func (g *tokenGenerator) Generate(data TokenData) (string, error) {
 			Audience:  []string{g.cfg.Audience},
 		},
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(g.cfg.Secret))
+	return g.keys.sign(claims)
 }
 
-func (g *tokenGenerator) Validate(tk string) (TokenData, error) {
-	parsedToken, err := jwt.Parse(tk, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, jwt.ErrSignatureInvalid
-		}
-		return []byte(g.cfg.Secret), nil
-	})
+func (g *tokenGenerator) GenerateIDToken(data IDTokenData) (string, error) {
+	claims := &IDTokenClaim{
+		Nonce: data.Nonce,
+		Email: data.Email,
+		Name:  data.Name,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Issuer:    g.cfg.Issuer,
+			Subject:   data.UserID.String(),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(g.cfg.Expiry)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Audience:  []string{data.ClientID.String()},
+		},
+	}
+	return g.keys.sign(claims)
+}
+
+func (g *tokenGenerator) Metadata() TokenMetadata {
+	return TokenMetadata{
+		Issuer:            g.cfg.Issuer,
+		SigningAlgorithms: []string{g.keys.Algorithm()},
+		Claims:            tokenClaims,
+	}
+}
+
+func (g *tokenGenerator) Parse(tk string) (*Claim, error) {
+	claims := &Claim{}
+	parsedToken, err := jwt.ParseWithClaims(tk, claims, g.keys.keyFunc,
+		jwt.WithValidMethods(validMethods),
+		jwt.WithIssuer(g.cfg.Issuer),
+		jwt.WithAudience(g.cfg.Audience),
+		jwt.WithExpirationRequired())
 	if err != nil {
-		return TokenData{}, err
+		return nil, err
 	}
+	if !parsedToken.Valid {
+		return nil, errors.New("invalid token")
+	}
+	return claims, nil
+}
 
-	claims, ok := parsedToken.Claims.(Claim)
-	if !ok || !parsedToken.Valid {
-		return TokenData{}, errors.New("invalid token")
+func (g *tokenGenerator) Validate(tk string) (TokenData, error) {
+	claims, err := g.Parse(tk)
+	if err != nil {
+		return TokenData{}, err
 	}
 
 	data := claims.TokenData
 	return TokenData{
-		UserID:    data.UserID,
-		AccountID: data.AccountID,
-		ExpiresAt: data.ExpiresAt,
+		UserID:        data.UserID,
+		AccountID:     data.AccountID,
+		ExpiresAt:     data.ExpiresAt,
+		SessionID:     data.SessionID,
+		MFAVerifiedAt: data.MFAVerifiedAt,
+		ClientID:      data.ClientID,
+		Scope:         data.Scope,
 	}, nil
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"payter-bank/internal/config"
@@ -16,7 +17,7 @@ func setupTokenGenerator() TokenGenerator {
 		Audience: "test-audience",
 		Expiry:   time.Hour,
 	}
-	return NewTokenGenerator(cfg)
+	return NewTokenGenerator(cfg, NewSecretKeySet(cfg.Secret))
 }
 
 func TestGenerateAndValidate_Success(t *testing.T) {
@@ -49,7 +50,7 @@ func TestValidate_InvalidSignature(t *testing.T) {
 		Audience: "test-audience",
 		Expiry:   time.Hour,
 	}
-	wrongGen := NewTokenGenerator(cfg)
+	wrongGen := NewTokenGenerator(cfg, NewSecretKeySet(cfg.Secret))
 
 	data := TokenData{
 		UserID:    uuid.New(),
@@ -73,7 +74,7 @@ func TestValidate_ExpiredToken(t *testing.T) {
 		Audience: "test-audience",
 		Expiry:   -time.Minute, // Already expired
 	}
-	gen := NewTokenGenerator(cfg)
+	gen := NewTokenGenerator(cfg, NewSecretKeySet(cfg.Secret))
 
 	data := TokenData{
 		UserID:    uuid.New(),
@@ -89,3 +90,76 @@ func TestValidate_ExpiredToken(t *testing.T) {
 	assert.Error(t, err) // Should error due to expiry
 	assert.Equal(t, TokenData{}, parsedData)
 }
+
+func TestGenerate_SetsTokenIDAndSession(t *testing.T) {
+	gen := setupTokenGenerator()
+	data := TokenData{
+		UserID:    uuid.New(),
+		AccountID: uuid.New(),
+		SessionID: uuid.New(),
+	}
+
+	token, err := gen.Generate(data)
+	assert.NoError(t, err)
+
+	claim := &Claim{}
+	_, err = jwt.ParseWithClaims(token, claim, func(token *jwt.Token) (interface{}, error) {
+		return []byte("testsecret"), nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, data.SessionID, claim.SessionID)
+	_, err = uuid.Parse(claim.ID)
+	assert.NoError(t, err)
+}
+
+func TestGenerateIDToken(t *testing.T) {
+	gen := setupTokenGenerator()
+	data := IDTokenData{
+		UserID:   uuid.New(),
+		ClientID: uuid.New(),
+		Nonce:    "n-0S6_WzA2Mj",
+		Email:    "user@example.com",
+	}
+
+	token, err := gen.GenerateIDToken(data)
+	assert.NoError(t, err)
+
+	claims := &IDTokenClaim{}
+	_, err = jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte("testsecret"), nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, data.UserID.String(), claims.Subject)
+	assert.Equal(t, jwt.ClaimStrings{data.ClientID.String()}, claims.Audience)
+	assert.Equal(t, "test-issuer", claims.Issuer)
+	assert.Equal(t, data.Nonce, claims.Nonce)
+	assert.Equal(t, data.Email, claims.Email)
+
+	// an ID token is not an access token, its audience is the client
+	_, err = gen.Validate(token)
+	assert.Error(t, err)
+}
+
+func TestValidate_KeepsClientAndScope(t *testing.T) {
+	gen := setupTokenGenerator()
+	data := TokenData{
+		UserID:   uuid.New(),
+		ClientID: uuid.New(),
+		Scope:    "openid accounts:read",
+	}
+
+	token, err := gen.Generate(data)
+	assert.NoError(t, err)
+
+	parsed, err := gen.Validate(token)
+	assert.NoError(t, err)
+	assert.Equal(t, data.ClientID, parsed.ClientID)
+	assert.Equal(t, data.Scope, parsed.Scope)
+}
+
+func TestMetadata(t *testing.T) {
+	metadata := setupTokenGenerator().Metadata()
+	assert.Equal(t, "test-issuer", metadata.Issuer)
+	assert.Equal(t, []string{"HS256"}, metadata.SigningAlgorithms)
+	assert.Contains(t, metadata.Claims, "nonce")
+}
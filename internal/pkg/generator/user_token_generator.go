@@ -0,0 +1,41 @@
+//go:generate mockgen -source=user_token_generator.go -destination=./mocks/user_token_generator_mocks.go -package=generatormocks
+
+package generator
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// UserTokenGenerator generates single-use tokens that are sent to users e.g. email verification links.
+type UserTokenGenerator interface {
+	// Generate returns a random token to hand to the user and the hash to store in its place.
+	Generate() (token string, hash string)
+}
+
+type userTokenGenerator struct{}
+
+func NewUserTokenGenerator() UserTokenGenerator {
+	return userTokenGenerator{}
+}
+
+func (userTokenGenerator) Generate() (string, string) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		panic(err)
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashUserToken(token)
+}
+
+// HashUserToken returns the hash stored for a user token. Tokens are never stored in plain text,
+// a token presented by a user is hashed and looked up.
+func HashUserToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+var DefaultUserTokenGenerator = NewUserTokenGenerator()
@@ -0,0 +1,25 @@
+package generator
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestUserTokenGenerator_Generate(t *testing.T) {
+	t.Run("hash matches the token", func(t *testing.T) {
+		token, hash := NewUserTokenGenerator().Generate()
+		assert.Len(t, token, 43)
+		assert.Len(t, hash, 64)
+		assert.Equal(t, hash, HashUserToken(token))
+	})
+
+	t.Run("tokens are unique", func(t *testing.T) {
+		g := NewUserTokenGenerator()
+		seen := make(map[string]bool)
+		for i := 0; i < 1000; i++ {
+			token, _ := g.Generate()
+			assert.False(t, seen[token])
+			seen[token] = true
+		}
+	})
+}
@@ -0,0 +1,61 @@
+package identifier
+
+import (
+	"errors"
+)
+
+const AccountNumberLength = 8
+
+var (
+	ErrInvalidAccountNumber = errors.New("account number must be 8 digits")
+	ErrAccountNumberCheck   = errors.New("account number failed modulus check")
+)
+
+// accountNumberWeights are applied to the first seven digits of an account number,
+// the eighth digit is the modulus 11 check digit.
+var accountNumberWeights = [AccountNumberLength - 1]int{8, 7, 6, 5, 4, 3, 2}
+
+// CheckDigit computes the modulus 11 check digit for the 7-digit base of an account number.
+// ok is false when the base has no valid single-digit check digit (remainder of 1) and
+// must be discarded.
+func CheckDigit(base string) (digit int, ok bool) {
+	if len(base) != AccountNumberLength-1 || !isDigits(base) {
+		return 0, false
+	}
+
+	sum := 0
+	for i, c := range base {
+		sum += int(c-'0') * accountNumberWeights[i]
+	}
+
+	digit = (11 - sum%11) % 11
+	if digit == 10 {
+		return 0, false
+	}
+	return digit, true
+}
+
+// ValidateAccountNumber verifies the length, format and check digit of an account number.
+func ValidateAccountNumber(accountNumber string) error {
+	if len(accountNumber) != AccountNumberLength || !isDigits(accountNumber) {
+		return ErrInvalidAccountNumber
+	}
+
+	digit, ok := CheckDigit(accountNumber[:AccountNumberLength-1])
+	if !ok || int(accountNumber[AccountNumberLength-1]-'0') != digit {
+		return ErrAccountNumberCheck
+	}
+	return nil
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
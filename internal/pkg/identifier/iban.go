@@ -0,0 +1,107 @@
+package identifier
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var (
+	ErrInvalidIBAN     = errors.New("iban is malformed")
+	ErrIBANCheckDigits = errors.New("iban failed mod-97 check")
+)
+
+// IBAN is a decoded domestic (GB style) IBAN: country code, check digits, bank identifier,
+// sort code and account number.
+type IBAN struct {
+	CountryCode   string `json:"country_code"`
+	CheckDigits   string `json:"check_digits"`
+	BankCode      string `json:"bank_code"`
+	SortCode      string `json:"sort_code"`
+	AccountNumber string `json:"account_number"`
+}
+
+// ibanLength is the length of an IBAN built from a 4 letter bank code, 6 digit sort code
+// and 8 digit account number.
+const ibanLength = 2 + 2 + 4 + SortCodeLength + AccountNumberLength
+
+// NewIBAN derives an IBAN from the bank code, sort code and account number,
+// calculating the ISO 13616 mod-97 check digits.
+func NewIBAN(countryCode, bankCode, sortCode, accountNumber string) (string, error) {
+	countryCode, bankCode = strings.ToUpper(countryCode), strings.ToUpper(bankCode)
+	if len(countryCode) != 2 || !isLetters(countryCode) {
+		return "", fmt.Errorf("invalid country code: %s", countryCode)
+	}
+	if len(bankCode) != 4 || !isLetters(bankCode) {
+		return "", fmt.Errorf("invalid bank code: %s", bankCode)
+	}
+	sortCode, err := NormaliseSortCode(sortCode)
+	if err != nil {
+		return "", err
+	}
+	if len(accountNumber) != AccountNumberLength || !isDigits(accountNumber) {
+		return "", ErrInvalidAccountNumber
+	}
+
+	bban := bankCode + sortCode + accountNumber
+	remainder := mod97(bban + countryCode + "00")
+	return fmt.Sprintf("%s%02d%s", countryCode, 98-remainder, bban), nil
+}
+
+// ParseIBAN normalises (upper-cases, strips spaces), validates and decodes an IBAN.
+func ParseIBAN(iban string) (IBAN, error) {
+	s := strings.ToUpper(strings.ReplaceAll(iban, " ", ""))
+	if len(s) != ibanLength || !isLetters(s[0:2]) || !isDigits(s[2:4]) ||
+		!isLetters(s[4:8]) || !isDigits(s[8:]) {
+		return IBAN{}, ErrInvalidIBAN
+	}
+
+	if mod97(s[4:]+s[:4]) != 1 {
+		return IBAN{}, ErrIBANCheckDigits
+	}
+
+	return IBAN{
+		CountryCode:   s[0:2],
+		CheckDigits:   s[2:4],
+		BankCode:      s[4:8],
+		SortCode:      s[8 : 8+SortCodeLength],
+		AccountNumber: s[8+SortCodeLength:],
+	}, nil
+}
+
+// FormatIBAN renders an IBAN in groups of four characters for display.
+func FormatIBAN(iban string) string {
+	var b strings.Builder
+	for i, c := range iban {
+		if i > 0 && i%4 == 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// mod97 converts letters to numbers (A=10 ... Z=35) and returns the value modulo 97.
+func mod97(s string) int64 {
+	var digits strings.Builder
+	for _, c := range s {
+		if c >= 'A' && c <= 'Z' {
+			digits.WriteString(fmt.Sprintf("%d", c-'A'+10))
+			continue
+		}
+		digits.WriteRune(c)
+	}
+
+	n, _ := new(big.Int).SetString(digits.String(), 10)
+	return new(big.Int).Mod(n, big.NewInt(97)).Int64()
+}
+
+func isLetters(s string) bool {
+	for _, c := range s {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,122 @@
+package identifier
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCheckDigit(t *testing.T) {
+	t.Run("computes modulus 11 check digit", func(t *testing.T) {
+		// 1*8 + 2*7 + 3*6 + 4*5 + 5*4 + 6*3 + 7*2 = 112, 112 % 11 = 2, 11 - 2 = 9
+		digit, ok := CheckDigit("1234567")
+		assert.True(t, ok)
+		assert.Equal(t, 9, digit)
+	})
+
+	t.Run("rejects bases without a single digit check digit", func(t *testing.T) {
+		// 6*2 = 12, 12 % 11 = 1, 11 - 1 = 10 which is not a single digit
+		_, ok := CheckDigit("0000006")
+		assert.False(t, ok)
+	})
+
+	t.Run("rejects malformed input", func(t *testing.T) {
+		_, ok := CheckDigit("12345")
+		assert.False(t, ok)
+
+		_, ok = CheckDigit("12a4567")
+		assert.False(t, ok)
+	})
+}
+
+func TestValidateAccountNumber(t *testing.T) {
+	testCases := []struct {
+		name          string
+		accountNumber string
+		expectedError error
+	}{
+		{name: "valid", accountNumber: "12345679"},
+		{name: "wrong check digit", accountNumber: "12345678", expectedError: ErrAccountNumberCheck},
+		{name: "too short", accountNumber: "1234567", expectedError: ErrInvalidAccountNumber},
+		{name: "non numeric", accountNumber: "1234567A", expectedError: ErrInvalidAccountNumber},
+		{name: "empty", accountNumber: "", expectedError: ErrInvalidAccountNumber},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedError, ValidateAccountNumber(tc.accountNumber))
+		})
+	}
+}
+
+func TestNormaliseSortCode(t *testing.T) {
+	for _, input := range []string{"040004", "04-00-04", "04 00 04"} {
+		s, err := NormaliseSortCode(input)
+		assert.NoError(t, err)
+		assert.Equal(t, "040004", s)
+	}
+
+	_, err := NormaliseSortCode("04-00")
+	assert.Equal(t, ErrInvalidSortCode, err)
+
+	_, err = NormaliseSortCode("04-00-0A")
+	assert.Equal(t, ErrInvalidSortCode, err)
+
+	assert.Equal(t, "04-00-04", FormatSortCode("040004"))
+}
+
+func TestNewIBAN(t *testing.T) {
+	t.Run("derives the check digits of the ISO 13616 example", func(t *testing.T) {
+		iban, err := NewIBAN("GB", "WEST", "12-34-56", "98765432")
+		assert.NoError(t, err)
+		assert.Equal(t, "GB82WEST12345698765432", iban)
+		assert.Equal(t, "GB82 WEST 1234 5698 7654 32", FormatIBAN(iban))
+	})
+
+	t.Run("rejects invalid components", func(t *testing.T) {
+		_, err := NewIBAN("G1", "WEST", "123456", "98765432")
+		assert.Error(t, err)
+
+		_, err = NewIBAN("GB", "WE", "123456", "98765432")
+		assert.Error(t, err)
+
+		_, err = NewIBAN("GB", "WEST", "1234", "98765432")
+		assert.Equal(t, ErrInvalidSortCode, err)
+
+		_, err = NewIBAN("GB", "WEST", "123456", "987")
+		assert.Equal(t, ErrInvalidAccountNumber, err)
+	})
+}
+
+func TestParseIBAN(t *testing.T) {
+	t.Run("parses a valid iban", func(t *testing.T) {
+		iban, err := ParseIBAN("gb82 west 1234 5698 7654 32")
+		assert.NoError(t, err)
+		assert.Equal(t, IBAN{
+			CountryCode:   "GB",
+			CheckDigits:   "82",
+			BankCode:      "WEST",
+			SortCode:      "123456",
+			AccountNumber: "98765432",
+		}, iban)
+	})
+
+	t.Run("rejects wrong check digits", func(t *testing.T) {
+		_, err := ParseIBAN("GB83WEST12345698765432")
+		assert.Equal(t, ErrIBANCheckDigits, err)
+	})
+
+	t.Run("rejects malformed iban", func(t *testing.T) {
+		_, err := ParseIBAN("GB82WEST1234569876543")
+		assert.Equal(t, ErrInvalidIBAN, err)
+	})
+
+	t.Run("round trips generated ibans", func(t *testing.T) {
+		generated, err := NewIBAN("GB", "PAYT", "040004", "12345679")
+		assert.NoError(t, err)
+
+		parsed, err := ParseIBAN(generated)
+		assert.NoError(t, err)
+		assert.Equal(t, "12345679", parsed.AccountNumber)
+		assert.Equal(t, "040004", parsed.SortCode)
+	})
+}
@@ -0,0 +1,28 @@
+package identifier
+
+import (
+	"errors"
+	"strings"
+)
+
+const SortCodeLength = 6
+
+var ErrInvalidSortCode = errors.New("sort code must be 6 digits")
+
+// NormaliseSortCode strips the separators commonly used when writing sort codes
+// (e.g. 04-00-04 or 04 00 04) and validates the result.
+func NormaliseSortCode(sortCode string) (string, error) {
+	s := strings.NewReplacer("-", "", " ", "").Replace(sortCode)
+	if len(s) != SortCodeLength || !isDigits(s) {
+		return "", ErrInvalidSortCode
+	}
+	return s, nil
+}
+
+// FormatSortCode renders a normalised sort code in the 00-00-00 display format.
+func FormatSortCode(sortCode string) string {
+	if len(sortCode) != SortCodeLength {
+		return sortCode
+	}
+	return sortCode[0:2] + "-" + sortCode[2:4] + "-" + sortCode[4:6]
+}
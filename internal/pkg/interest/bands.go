@@ -0,0 +1,70 @@
+package interest
+
+import "math/big"
+
+// Method is how the balance bands of a rate card apply to a balance.
+type Method string
+
+const (
+	// Tiered pays each band's rate on the part of the balance within the band.
+	Tiered Method = "tiered"
+	// WholeBalance pays the rate of the band the balance falls in on the whole balance.
+	WholeBalance Method = "whole_balance"
+)
+
+func (m Method) Valid() bool {
+	return m == Tiered || m == WholeBalance
+}
+
+// Band is a balance band of a rate card. Its annual Rate, in basis points, applies to the balance
+// from From, in minor units, up to where the next band starts.
+type Band struct {
+	From int64
+	Rate int64
+}
+
+// ValidBands reports whether bands start at 0 and increase.
+func ValidBands(bands []Band) bool {
+	if len(bands) == 0 || bands[0].From != 0 {
+		return false
+	}
+	for i := 1; i < len(bands); i++ {
+		if bands[i].From <= bands[i-1].From {
+			return false
+		}
+	}
+	return true
+}
+
+// Rate returns the annual rate in basis points balance earns under bands, which are expected to be
+// valid. For Tiered it is the blended rate of the bands the balance spans.
+func (m Method) Rate(balance int64, bands []Band) *big.Rat {
+	if balance <= 0 || len(bands) == 0 {
+		return new(big.Rat)
+	}
+
+	if m == WholeBalance {
+		rate := bands[0].Rate
+		for _, band := range bands {
+			if balance >= band.From {
+				rate = band.Rate
+			}
+		}
+		return big.NewRat(rate, 1)
+	}
+
+	// sum the interest of the part of the balance within each band, then spread it over the balance
+	weighted := new(big.Int)
+	for i, band := range bands {
+		if balance <= band.From {
+			break
+		}
+		upper := balance
+		if i+1 < len(bands) && bands[i+1].From < balance {
+			upper = bands[i+1].From
+		}
+		part := new(big.Int).Mul(big.NewInt(upper-band.From), big.NewInt(band.Rate))
+		weighted.Add(weighted, part)
+	}
+	return new(big.Rat).SetFrac(weighted, big.NewInt(balance))
+}
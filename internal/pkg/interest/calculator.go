@@ -0,0 +1,111 @@
+package interest
+
+import (
+	"fmt"
+	"math/big"
+	"payter-bank/internal/config"
+	"payter-bank/internal/database/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Calculator applies the day-count convention of an account's currency and the rounding policy
+// interest is paid with.
+type Calculator struct {
+	convention  Convention
+	conventions map[string]Convention
+	rounding    Rounding
+	taxRate     int64
+	taxRates    map[string]int64
+}
+
+func NewCalculator(cfg config.InterestConfig) (*Calculator, error) {
+	c := &Calculator{
+		convention:  Convention(cfg.DayCount),
+		conventions: make(map[string]Convention, len(cfg.DayCountByCurrency)),
+		rounding:    Rounding(cfg.Rounding),
+		taxRate:     cfg.TaxDefaultRate,
+		taxRates:    make(map[string]int64, len(cfg.TaxRates)),
+	}
+	if !c.convention.Valid() {
+		return nil, fmt.Errorf("unknown day-count convention: %s", cfg.DayCount)
+	}
+	for currency, dayCount := range cfg.DayCountByCurrency {
+		// a misspelt currency would never match an account and fall back to the default unnoticed
+		if !knownCurrency(models.Currency(currency)) {
+			return nil, fmt.Errorf("unknown currency for day-count convention: %s", currency)
+		}
+		convention := Convention(dayCount)
+		if !convention.Valid() {
+			return nil, fmt.Errorf("unknown day-count convention for %s: %s", currency, dayCount)
+		}
+		c.conventions[currency] = convention
+	}
+	if !c.rounding.Valid() {
+		return nil, fmt.Errorf("unknown rounding: %s", cfg.Rounding)
+	}
+	if !validTaxRate(c.taxRate) {
+		return nil, fmt.Errorf("invalid default tax rate: %d", cfg.TaxDefaultRate)
+	}
+	for residency, taxRate := range cfg.TaxRates {
+		rate, err := strconv.ParseInt(taxRate, 10, 64)
+		if err != nil || !validTaxRate(rate) {
+			return nil, fmt.Errorf("invalid tax rate for %s: %s", residency, taxRate)
+		}
+		c.taxRates[strings.ToUpper(residency)] = rate
+	}
+	return c, nil
+}
+
+func knownCurrency(currency models.Currency) bool {
+	switch currency {
+	case models.CurrencyGBP, models.CurrencyEUR, models.CurrencyJPY:
+		return true
+	}
+	return false
+}
+
+// Convention is the day-count convention interest on accounts in currency accrues under.
+func (c *Calculator) Convention(currency string) Convention {
+	if convention, ok := c.conventions[currency]; ok {
+		return convention
+	}
+	return c.convention
+}
+
+// Accrue is the interest balance earns at rate, in basis points, on day kept to Scale decimal
+// places. rate may be fractional, as blended rates of tiered bands are. Digits past Scale are
+// rounded half-even whatever the rounding policy, they are too small to favour anyone.
+func (c *Calculator) Accrue(balance int64, rate *big.Rat, day time.Time, currency string) *big.Rat {
+	amount := interestAt(balance, rate, day, day.AddDate(0, 0, 1), c.Convention(currency))
+	return HalfEven.Round(amount, Scale)
+}
+
+// Settle pays the interest accrued over a period together with the remainder carried from earlier
+// periods. It returns the whole minor units to pay and the remainder to carry forward, so that no
+// fraction of a minor unit is lost between periods.
+func (c *Calculator) Settle(accrued, carried *big.Rat) (int64, *big.Rat) {
+	return Settle(new(big.Rat).Add(accrued, carried), c.rounding)
+}
+
+// TaxRate is the rate, in basis points, tax is withheld from the interest of a customer resident in
+// residency at. Customers whose residency is unknown or has no rate of its own pay the default.
+func (c *Calculator) TaxRate(residency string) int64 {
+	if rate, ok := c.taxRates[strings.ToUpper(residency)]; ok {
+		return rate
+	}
+	return c.taxRate
+}
+
+// Withhold is the tax withheld at rate from the interest paid, in whole minor units. It is rounded
+// down so that no more than the rate is withheld and some interest is always paid.
+func (c *Calculator) Withhold(paid, rate int64) int64 {
+	tax := new(big.Int).Mul(big.NewInt(paid), big.NewInt(rate))
+	return tax.Quo(tax, big.NewInt(10000)).Int64()
+}
+
+// validTaxRate accepts rates from nothing up to, but not including, all of the interest.
+func validTaxRate(rate int64) bool {
+	return rate >= 0 && rate < 10000
+}
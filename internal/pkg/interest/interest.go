@@ -0,0 +1,126 @@
+// Package interest does the interest arithmetic: day-count conventions, exact decimal amounts and
+// rounding. Amounts are in minor units and rates in basis points a year. Amounts are kept as
+// big.Rat so that no precision is lost between accruing interest and paying it.
+package interest
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// Scale is the number of decimal places of a minor unit accrued interest is kept to.
+const Scale = 10
+
+// Convention is a day-count convention, it sets how much of a year a number of days is.
+type Convention string
+
+const (
+	// Actual365 counts the actual days over a 365 day year.
+	Actual365 Convention = "ACT/365"
+	// Actual360 counts the actual days over a 360 day year, it is common for EUR and USD.
+	Actual360 Convention = "ACT/360"
+	// Thirty360 counts every month as 30 days over a 360 day year (30/360 bond basis).
+	Thirty360 Convention = "30/360"
+)
+
+func (c Convention) Valid() bool {
+	return c == Actual365 || c == Actual360 || c == Thirty360
+}
+
+// Days returns the number of days from start up to end counted under the convention, and the days
+// in a year they are divided by. start and end are dates, their time of day is ignored.
+func (c Convention) Days(start, end time.Time) (days, year int64) {
+	start, end = date(start), date(end)
+	switch c {
+	case Thirty360:
+		d1, d2 := start.Day(), end.Day()
+		if d1 == 31 {
+			d1 = 30
+		}
+		if d2 == 31 && d1 == 30 {
+			d2 = 30
+		}
+		days = int64(360*(end.Year()-start.Year()) + 30*(int(end.Month())-int(start.Month())) + d2 - d1)
+		return days, 360
+	case Actual360:
+		return int64(end.Sub(start).Hours() / 24), 360
+	default:
+		return int64(end.Sub(start).Hours() / 24), 365
+	}
+}
+
+// Rounding is how an amount is rounded when it is cut to fewer decimal places.
+type Rounding string
+
+const (
+	// HalfEven rounds halves to the even neighbour (banker's rounding).
+	HalfEven Rounding = "half_even"
+	// HalfUp rounds halves away from zero.
+	HalfUp Rounding = "half_up"
+	// Down drops the digits, rounding towards zero.
+	Down Rounding = "down"
+)
+
+func (r Rounding) Valid() bool {
+	return r == HalfEven || r == HalfUp || r == Down
+}
+
+// Round rounds x to scale decimal places.
+func (r Rounding) Round(x *big.Rat, scale int) *big.Rat {
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)
+	scaled := new(big.Rat).Mul(x, new(big.Rat).SetInt(unit))
+
+	quotient, remainder := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+	if remainder.Sign() != 0 && r != Down {
+		// compare twice the remainder with the denominator to tell whether x is past the half
+		half := new(big.Int).Abs(remainder)
+		half.Lsh(half, 1)
+		cmp := half.Cmp(scaled.Denom())
+		if cmp > 0 || (cmp == 0 && (r == HalfUp || quotient.Bit(0) == 1)) {
+			quotient.Add(quotient, big.NewInt(int64(scaled.Sign())))
+		}
+	}
+
+	return new(big.Rat).SetFrac(quotient, unit)
+}
+
+// Interest is the interest balance earns at rate from start up to end under the convention,
+// exactly.
+func Interest(balance, rate int64, start, end time.Time, convention Convention) *big.Rat {
+	return interestAt(balance, big.NewRat(rate, 1), start, end, convention)
+}
+
+func interestAt(balance int64, rate *big.Rat, start, end time.Time, convention Convention) *big.Rat {
+	days, year := convention.Days(start, end)
+	amount := new(big.Rat).SetInt64(balance)
+	amount.Mul(amount, rate)
+	return amount.Mul(amount, big.NewRat(days, 10000*year))
+}
+
+// Settle splits accrued interest into the whole minor units that are paid, rounded with rounding,
+// and the remainder that is carried into the next period. The remainder is negative when rounding
+// paid out more than was accrued.
+func Settle(accrued *big.Rat, rounding Rounding) (int64, *big.Rat) {
+	paid := rounding.Round(accrued, 0)
+	return paid.Num().Int64(), new(big.Rat).Sub(accrued, paid)
+}
+
+// Format writes x with Scale decimal places, as it is stored in NUMERIC columns. x is expected to
+// be rounded to Scale already.
+func Format(x *big.Rat) string {
+	return x.FloatString(Scale)
+}
+
+// Parse reads an amount stored in a NUMERIC column.
+func Parse(s string) (*big.Rat, error) {
+	x, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount: %q", s)
+	}
+	return x, nil
+}
+
+func date(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
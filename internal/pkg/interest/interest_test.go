@@ -0,0 +1,216 @@
+package interest
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"os"
+	"payter-bank/internal/config"
+	"testing"
+	"time"
+)
+
+// vector is a case of testdata/vectors.golden. The expected values in the file were worked out
+// with exact fractions independently of this package and must not be regenerated from it.
+type vector struct {
+	Name       string `json:"name"`
+	Balance    int64  `json:"balance"`
+	Rate       int64  `json:"rate"`
+	Start      string `json:"start"`
+	End        string `json:"end"`
+	Convention string `json:"convention"`
+	Rounding   string `json:"rounding"`
+	Days       int64  `json:"days"`
+	Year       int64  `json:"year"`
+	Interest   string `json:"interest"`
+	Paid       int64  `json:"paid"`
+	Remainder  string `json:"remainder"`
+}
+
+func TestVectors(t *testing.T) {
+	data, err := os.ReadFile("testdata/vectors.golden")
+	assert.NoError(t, err)
+
+	var vectors []vector
+	assert.NoError(t, json.Unmarshal(data, &vectors))
+	assert.NotEmpty(t, vectors)
+
+	for _, v := range vectors {
+		t.Run(v.Name+" "+v.Convention+" "+v.Rounding, func(t *testing.T) {
+			start, err := time.Parse(time.DateOnly, v.Start)
+			assert.NoError(t, err)
+			end, err := time.Parse(time.DateOnly, v.End)
+			assert.NoError(t, err)
+			convention := Convention(v.Convention)
+
+			days, year := convention.Days(start, end)
+			assert.Equal(t, v.Days, days)
+			assert.Equal(t, v.Year, year)
+
+			amount := Interest(v.Balance, v.Rate, start, end, convention)
+			assert.Equal(t, v.Interest, Format(HalfEven.Round(amount, Scale)))
+
+			paid, remainder := Settle(amount, Rounding(v.Rounding))
+			assert.Equal(t, v.Paid, paid)
+			assert.Equal(t, v.Remainder, Format(HalfEven.Round(remainder, Scale)))
+		})
+	}
+}
+
+func TestRounding_Round(t *testing.T) {
+	tests := []struct {
+		value    *big.Rat
+		scale    int
+		halfEven string
+		halfUp   string
+		down     string
+	}{
+		{big.NewRat(25, 10), 0, "2", "3", "2"},
+		{big.NewRat(35, 10), 0, "4", "4", "3"},
+		{big.NewRat(-25, 10), 0, "-2", "-3", "-2"},
+		{big.NewRat(26, 10), 0, "3", "3", "2"},
+		{big.NewRat(1, 3), 2, "33/100", "33/100", "33/100"},
+		{big.NewRat(2, 3), 2, "67/100", "67/100", "33/50"},
+		{big.NewRat(125, 1000), 2, "3/25", "13/100", "3/25"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.halfEven, HalfEven.Round(tt.value, tt.scale).RatString(), tt.value.String())
+		assert.Equal(t, tt.halfUp, HalfUp.Round(tt.value, tt.scale).RatString(), tt.value.String())
+		assert.Equal(t, tt.down, Down.Round(tt.value, tt.scale).RatString(), tt.value.String())
+	}
+}
+
+// TestCalculator_CarriesRemainder accrues a year of daily interest, settles it monthly and checks
+// that what is paid plus what is carried is exactly what accrued.
+func TestCalculator_CarriesRemainder(t *testing.T) {
+	for _, rounding := range []string{"half_even", "half_up", "down"} {
+		t.Run(rounding, func(t *testing.T) {
+			calculator, err := NewCalculator(config.InterestConfig{DayCount: "ACT/365", Rounding: rounding})
+			assert.NoError(t, err)
+
+			total, period, carried := new(big.Rat), new(big.Rat), new(big.Rat)
+			var paid int64
+			for day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC); day.Year() == 2026; day = day.AddDate(0, 0, 1) {
+				accrued := calculator.Accrue(123457, big.NewRat(375, 1), day, "GBP")
+				total.Add(total, accrued)
+				period.Add(period, accrued)
+
+				if day.AddDate(0, 0, 1).Day() == 1 {
+					amount, remainder := calculator.Settle(period, carried)
+					paid += amount
+					period, carried = new(big.Rat), remainder
+					assert.True(t, carried.Cmp(big.NewRat(-1, 1)) > 0 && carried.Cmp(big.NewRat(1, 1)) < 0)
+				}
+			}
+
+			assert.Equal(t, total.RatString(), new(big.Rat).Add(big.NewRat(paid, 1), carried).RatString())
+			// 3.75% of 1,234.57 for a year is 46.296375
+			assert.InDelta(t, 4629.6375, float64(paid), 1)
+		})
+	}
+}
+
+func TestCalculator_Convention(t *testing.T) {
+	calculator, err := NewCalculator(config.InterestConfig{
+		DayCount:           "ACT/365",
+		DayCountByCurrency: map[string]string{"EUR": "ACT/360", "JPY": "30/360"},
+		Rounding:           "half_even",
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, Actual365, calculator.Convention("GBP"))
+	assert.Equal(t, Actual360, calculator.Convention("EUR"))
+	assert.Equal(t, Thirty360, calculator.Convention("JPY"))
+
+	day := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, "36.0000000000", Format(calculator.Accrue(72000, big.NewRat(1800, 1), day, "EUR")))
+	assert.Equal(t, "35.5068493151", Format(calculator.Accrue(72000, big.NewRat(1800, 1), day, "GBP")))
+}
+
+func TestCalculator_Tax(t *testing.T) {
+	calculator, err := NewCalculator(config.InterestConfig{
+		DayCount:       "ACT/365",
+		Rounding:       "half_even",
+		TaxRates:       map[string]string{"GB": "2000", "ie": "3300", "JE": "0"},
+		TaxDefaultRate: 2500,
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, int64(2000), calculator.TaxRate("GB"))
+	assert.Equal(t, int64(3300), calculator.TaxRate("IE"))
+	assert.Equal(t, int64(0), calculator.TaxRate("JE"))
+	assert.Equal(t, int64(2500), calculator.TaxRate("FR"))
+	assert.Equal(t, int64(2500), calculator.TaxRate(""))
+
+	assert.Equal(t, int64(200), calculator.Withhold(1000, 2000))
+	// 33% of 1.01 is 0.3333, rounded down
+	assert.Equal(t, int64(33), calculator.Withhold(101, 3300))
+	// a single minor unit is paid in full rather than withheld
+	assert.Equal(t, int64(0), calculator.Withhold(1, 9999))
+	assert.Equal(t, int64(0), calculator.Withhold(1000, 0))
+}
+
+func TestNewCalculator(t *testing.T) {
+	tests := []struct {
+		cfg config.InterestConfig
+		err string
+	}{
+		{config.InterestConfig{DayCount: "ACT/366", Rounding: "half_even"}, "unknown day-count convention: ACT/366"},
+		{config.InterestConfig{DayCount: "ACT/365", DayCountByCurrency: map[string]string{"EUR": "act/360"}, Rounding: "half_even"},
+			"unknown day-count convention for EUR: act/360"},
+		{config.InterestConfig{DayCount: "ACT/365", DayCountByCurrency: map[string]string{"eur": "ACT/360"}, Rounding: "half_even"},
+			"unknown currency for day-count convention: eur"},
+		{config.InterestConfig{DayCount: "ACT/365", DayCountByCurrency: map[string]string{"USD": "ACT/360"}, Rounding: "half_even"},
+			"unknown currency for day-count convention: USD"},
+		{config.InterestConfig{DayCount: "ACT/365", Rounding: "ceiling"}, "unknown rounding: ceiling"},
+		{config.InterestConfig{DayCount: "ACT/365", Rounding: "down", TaxDefaultRate: 10000}, "invalid default tax rate: 10000"},
+		{config.InterestConfig{DayCount: "ACT/365", Rounding: "down", TaxRates: map[string]string{"GB": "20%"}}, "invalid tax rate for GB: 20%"},
+		{config.InterestConfig{DayCount: "ACT/365", Rounding: "down", TaxRates: map[string]string{"IE": "-100"}}, "invalid tax rate for IE: -100"},
+	}
+
+	for _, tt := range tests {
+		_, err := NewCalculator(tt.cfg)
+		assert.EqualError(t, err, tt.err)
+	}
+}
+
+func TestMethod_Rate(t *testing.T) {
+	// 1% up to 10,000.00, 2% up to 50,000.00 and 2.5% above
+	bands := []Band{{From: 0, Rate: 100}, {From: 1000000, Rate: 200}, {From: 5000000, Rate: 250}}
+
+	tests := []struct {
+		balance      int64
+		tiered       string
+		wholeBalance string
+	}{
+		{0, "0", "0"},
+		{500000, "100", "100"},
+		{1000000, "100", "200"},
+		{2000000, "150", "200"},
+		{6000000, "575/3", "250"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.tiered, Tiered.Rate(tt.balance, bands).RatString(), tt.balance)
+		assert.Equal(t, tt.wholeBalance, WholeBalance.Rate(tt.balance, bands).RatString(), tt.balance)
+	}
+
+	// tiered interest is the interest of each band on its part of the balance
+	calculator, err := NewCalculator(config.InterestConfig{DayCount: "ACT/365", Rounding: "half_even"})
+	assert.NoError(t, err)
+	day := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	blended := calculator.Accrue(6000000, Tiered.Rate(6000000, bands), day, "GBP")
+	parts := new(big.Rat).Add(Interest(1000000, 100, day, day.AddDate(0, 0, 1), Actual365),
+		Interest(4000000, 200, day, day.AddDate(0, 0, 1), Actual365))
+	parts.Add(parts, Interest(1000000, 250, day, day.AddDate(0, 0, 1), Actual365))
+	assert.Equal(t, Format(HalfEven.Round(parts, Scale)), Format(blended))
+}
+
+func TestValidBands(t *testing.T) {
+	assert.True(t, ValidBands([]Band{{From: 0, Rate: 100}}))
+	assert.True(t, ValidBands([]Band{{From: 0, Rate: 100}, {From: 1000000, Rate: 200}}))
+	assert.False(t, ValidBands(nil))
+	assert.False(t, ValidBands([]Band{{From: 100, Rate: 100}}))
+	assert.False(t, ValidBands([]Band{{From: 0, Rate: 100}, {From: 1000000, Rate: 200}, {From: 1000000, Rate: 300}}))
+}
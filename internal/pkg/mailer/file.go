@@ -0,0 +1,34 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"os"
+	"path/filepath"
+	"payter-bank/internal/config"
+	"time"
+)
+
+type fileMailer struct {
+	dir  string
+	from string
+}
+
+// NewFileMailer returns a Mailer that writes every email to its own .eml file in dir, useful in
+// development to open verification links without a mail server.
+func NewFileMailer(cfg config.MailConfig) (Mailer, error) {
+	if err := os.MkdirAll(cfg.FileDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create mail directory: %w", err)
+	}
+	return &fileMailer{
+		dir:  cfg.FileDir,
+		from: cfg.From,
+	}, nil
+}
+
+func (m *fileMailer) Send(_ context.Context, mail Mail) error {
+	now := time.Now()
+	name := fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405"), uuid.NewString())
+	return os.WriteFile(filepath.Join(m.dir, name), message(m.from, mail, now), 0o644)
+}
@@ -0,0 +1,51 @@
+//go:generate mockgen -source=mailer.go -destination=./mocks/mailer_mocks.go -package=mailermocks
+
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"payter-bank/internal/config"
+	"payter-bank/internal/logger"
+)
+
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers emails to users.
+type Mailer interface {
+	Send(ctx context.Context, mail Mail) error
+}
+
+// New returns the Mailer selected by the configured driver.
+func New(cfg config.MailConfig) (Mailer, error) {
+	switch cfg.Driver {
+	case "log", "":
+		return NewLogMailer(), nil
+	case "smtp":
+		return NewSMTPMailer(cfg), nil
+	case "file":
+		return NewFileMailer(cfg)
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", cfg.Driver)
+	}
+}
+
+type logMailer struct{}
+
+// NewLogMailer returns a Mailer that writes emails to the application log instead of sending them.
+func NewLogMailer() Mailer {
+	return logMailer{}
+}
+
+func (logMailer) Send(ctx context.Context, mail Mail) error {
+	logger.Info(ctx, "email sent",
+		zap.String("to", mail.To),
+		zap.String("subject", mail.Subject),
+		zap.String("body", mail.Body))
+	return nil
+}
@@ -0,0 +1,61 @@
+package mailer
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"payter-bank/internal/config"
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("selects driver", func(t *testing.T) {
+		m, err := New(config.MailConfig{Driver: "log"})
+		assert.NoError(t, err)
+		assert.IsType(t, logMailer{}, m)
+
+		m, err = New(config.MailConfig{Driver: "smtp", SMTPHost: "localhost", SMTPPort: "1025"})
+		assert.NoError(t, err)
+		assert.Equal(t, "localhost:1025", m.(*smtpMailer).addr)
+		assert.Nil(t, m.(*smtpMailer).auth)
+	})
+
+	t.Run("rejects unknown driver", func(t *testing.T) {
+		_, err := New(config.MailConfig{Driver: "pigeon"})
+		assert.EqualError(t, err, `unknown mail driver "pigeon"`)
+	})
+}
+
+func TestFileMailer_Send(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	m, err := New(config.MailConfig{Driver: "file", FileDir: dir, From: "no-reply@payterbank.app"})
+	assert.NoError(t, err)
+
+	err = m.Send(context.TODO(), Mail{To: "jane@example.com", Subject: "Verify your email", Body: "hello"})
+	assert.NoError(t, err)
+
+	files, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	assert.Equal(t, ".eml", filepath.Ext(files[0].Name()))
+
+	content, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "To: jane@example.com\r\n")
+	assert.Contains(t, string(content), "Subject: Verify your email\r\n")
+	assert.Contains(t, string(content), "\r\n\r\nhello\r\n")
+}
+
+func TestMessage(t *testing.T) {
+	date := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	msg := message("bank@example.com", Mail{To: "jane@example.com", Subject: "Hi", Body: "body"}, date)
+	assert.Equal(t, "From: bank@example.com\r\n"+
+		"To: jane@example.com\r\n"+
+		"Subject: Hi\r\n"+
+		"Date: Fri, 01 May 2026 09:00:00 +0000\r\n"+
+		"MIME-Version: 1.0\r\n"+
+		"Content-Type: text/plain; charset=UTF-8\r\n\r\n"+
+		"body\r\n", string(msg))
+}
@@ -40,11 +40,12 @@ func (m *MockHasher) EXPECT() *MockHasherMockRecorder {
 }
 
 // Hash mocks base method.
-func (m *MockHasher) Hash(password string) string {
+func (m *MockHasher) Hash(password string) (string, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Hash", password)
 	ret0, _ := ret[0].(string)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Hash indicates an expected call of Hash.
@@ -53,6 +54,20 @@ func (mr *MockHasherMockRecorder) Hash(password any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hash", reflect.TypeOf((*MockHasher)(nil).Hash), password)
 }
 
+// NeedsRehash mocks base method.
+func (m *MockHasher) NeedsRehash(hashed string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NeedsRehash", hashed)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// NeedsRehash indicates an expected call of NeedsRehash.
+func (mr *MockHasherMockRecorder) NeedsRehash(hashed any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NeedsRehash", reflect.TypeOf((*MockHasher)(nil).NeedsRehash), hashed)
+}
+
 // Validate mocks base method.
 func (m *MockHasher) Validate(hashed, password string) bool {
 	m.ctrl.T.Helper()
@@ -2,29 +2,119 @@
 
 package password
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+)
 
 type Hasher interface {
-	Hash(password string) string
+	// Hash returns the argon2id hash of password in the PHC string format.
+	Hash(password string) (string, error)
+	// Validate checks password against an argon2id hash or a bcrypt hash made before argon2id
+	// was introduced.
 	Validate(hashed, password string) bool
+	// NeedsRehash reports whether hashed was not made with the current algorithm and parameters,
+	// the password should then be hashed again the next time it is known.
+	NeedsRehash(hashed string) bool
 }
 
-type hasher struct {}
+// argon2Params are the argon2id parameters of new hashes, the OWASP recommendation of 19 MiB
+// memory, 2 iterations and 1 degree of parallelism.
+var argon2Params = params{
+	memory:     19 * 1024,
+	iterations: 2,
+	threads:    1,
+	saltLength: 16,
+	keyLength:  32,
+}
 
-func (h hasher) Hash(password string) string {
-	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	if err != nil {
-		panic(err)
+type params struct {
+	memory     uint32
+	iterations uint32
+	threads    uint8
+	saltLength uint32
+	keyLength  uint32
+}
+
+var errInvalidHash = errors.New("hash is not in the argon2id PHC format")
+
+type hasher struct {
+	params params
+}
+
+func (h hasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.params.saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
 	}
-	return string(hashed)
+
+	key := argon2.IDKey([]byte(password), salt, h.params.iterations, h.params.memory, h.params.threads, h.params.keyLength)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, h.params.memory, h.params.iterations, h.params.threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key)), nil
 }
 
 func (h hasher) Validate(hashed, password string) bool {
-	return bcrypt.CompareHashAndPassword([]byte(hashed), []byte(password)) == nil
+	if !strings.HasPrefix(hashed, "$argon2id$") {
+		return bcrypt.CompareHashAndPassword([]byte(hashed), []byte(password)) == nil
+	}
+
+	p, salt, key, err := decodeHash(hashed)
+	if err != nil {
+		return false
+	}
+	other := argon2.IDKey([]byte(password), salt, p.iterations, p.memory, p.threads, p.keyLength)
+	return subtle.ConstantTimeCompare(key, other) == 1
+}
+
+func (h hasher) NeedsRehash(hashed string) bool {
+	p, _, _, err := decodeHash(hashed)
+	if err != nil {
+		return true
+	}
+	return p.memory != h.params.memory || p.iterations != h.params.iterations || p.threads != h.params.threads ||
+		p.saltLength != h.params.saltLength || p.keyLength != h.params.keyLength
+}
+
+// decodeHash reads the parameters, salt and key of an argon2id hash.
+func decodeHash(hashed string) (params, []byte, []byte, error) {
+	parts := strings.Split(hashed, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return params{}, nil, nil, errInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params{}, nil, nil, errInvalidHash
+	}
+
+	var p params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.memory, &p.iterations, &p.threads); err != nil {
+		return params{}, nil, nil, errInvalidHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params{}, nil, nil, errInvalidHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return params{}, nil, nil, errInvalidHash
+	}
+	p.saltLength = uint32(len(salt))
+	p.keyLength = uint32(len(key))
+	return p, salt, key, nil
 }
 
 func newPasswordHasher() Hasher {
-	return hasher{}
+	return hasher{params: argon2Params}
 }
 
-var DefaultPasswordHasher = newPasswordHasher()
\ No newline at end of file
+var DefaultPasswordHasher = newPasswordHasher()
@@ -0,0 +1,101 @@
+package password
+
+import (
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+	"testing"
+)
+
+func TestHasher(t *testing.T) {
+	h := newPasswordHasher()
+
+	t.Run("hashes with argon2id", func(t *testing.T) {
+		hashed, err := h.Hash("correct horse battery staple")
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(hashed, "$argon2id$v=19$m=19456,t=2,p=1$"))
+		assert.True(t, h.Validate(hashed, "correct horse battery staple"))
+		assert.False(t, h.Validate(hashed, "correct horse battery stapl"))
+		assert.False(t, h.NeedsRehash(hashed))
+
+		other, err := h.Hash("correct horse battery staple")
+		assert.NoError(t, err)
+		assert.NotEqual(t, hashed, other, "every hash has its own salt")
+	})
+
+	t.Run("validates bcrypt hashes and asks for them to be upgraded", func(t *testing.T) {
+		hashed, err := bcrypt.GenerateFromPassword([]byte("legacy password"), bcrypt.MinCost)
+		assert.NoError(t, err)
+		assert.True(t, h.Validate(string(hashed), "legacy password"))
+		assert.False(t, h.Validate(string(hashed), "wrong password"))
+		assert.True(t, h.NeedsRehash(string(hashed)))
+	})
+
+	t.Run("asks for hashes with other parameters to be upgraded", func(t *testing.T) {
+		weaker := hasher{params: params{memory: 8 * 1024, iterations: 1, threads: 1, saltLength: 16, keyLength: 32}}
+		hashed, err := weaker.Hash("correct horse battery staple")
+		assert.NoError(t, err)
+		assert.True(t, h.Validate(hashed, "correct horse battery staple"))
+		assert.True(t, h.NeedsRehash(hashed))
+	})
+
+	t.Run("rejects malformed hashes", func(t *testing.T) {
+		assert.False(t, h.Validate("$argon2id$v=19$m=19456,t=2,p=1$bm90IGJhc2U2NA", "password"))
+		assert.False(t, h.Validate("", "password"))
+		assert.True(t, h.NeedsRehash("$argon2id$v=18$m=19456,t=2,p=1$c2FsdA$a2V5"))
+	})
+}
+
+func TestPolicy_Check(t *testing.T) {
+	policy := Policy{
+		MinLength:      12,
+		RequireUpper:   true,
+		RequireLower:   true,
+		RequireDigit:   true,
+		RejectBreached: true,
+	}
+
+	for name, tc := range map[string]struct {
+		password string
+		email    string
+		err      string
+	}{
+		"accepts a strong password": {password: "Tangerine-Kite-42", email: "jane@example.com"},
+		"too short":                 {password: "Short1a", err: "password must be at least 12 characters long"},
+		"too long":                  {password: "Aa1" + strings.Repeat("x", 126), err: "password must be at most 128 characters long"},
+		"no upper case":             {password: "tangerine-kite-42", err: "password must contain an upper case letter"},
+		"no lower case":             {password: "TANGERINE-KITE-42", err: "password must contain a lower case letter"},
+		"no digit":                  {password: "Tangerine-Kite-XX", err: "password must contain a digit"},
+		"contains the email":        {password: "Janedoe-Kite-42", email: "JaneDoe@example.com", err: "password must not contain your email address"},
+		"breached":                  {password: "Password2025", err: "password has appeared in a data breach, choose another one"},
+		"breached ignoring case":    {password: "pASSWORD2025", err: "password has appeared in a data breach, choose another one"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := policy.Check(tc.password, tc.email)
+			if tc.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+
+	t.Run("requires a symbol when configured", func(t *testing.T) {
+		strict := policy
+		strict.RequireSymbol = true
+		assert.EqualError(t, strict.Check("TangerineKite42", ""), "password must contain a symbol")
+		assert.NoError(t, strict.Check("Tangerine Kite 42", ""))
+	})
+
+	t.Run("allows breached passwords when the check is off", func(t *testing.T) {
+		lenient := policy
+		lenient.RejectBreached = false
+		assert.NoError(t, lenient.Check("Password2025", ""))
+	})
+}
+
+func TestBreachedList(t *testing.T) {
+	assert.Contains(t, breached, "password123")
+	assert.Contains(t, breached, "p@ssw0rd123")
+	assert.NotContains(t, breached, "# common passwords from public breach corpora, one per line and compared case-insensitively.")
+}
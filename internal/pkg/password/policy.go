@@ -0,0 +1,103 @@
+package password
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"fmt"
+	"payter-bank/internal/config"
+	"strings"
+	"unicode"
+)
+
+// maxLength caps passwords so that hashing an oversized one cannot be used to tie up the server.
+const maxLength = 128
+
+//go:embed breached.txt
+var breachedList string
+
+// breached holds the lower-cased entries of breached.txt.
+var breached = loadBreached(breachedList)
+
+// Policy is the set of rules a new password must follow. Existing passwords are not checked
+// against it.
+type Policy struct {
+	MinLength      int
+	RequireUpper   bool
+	RequireLower   bool
+	RequireDigit   bool
+	RequireSymbol  bool
+	RejectBreached bool
+}
+
+func NewPolicy(cfg config.PasswordConfig) Policy {
+	return Policy{
+		MinLength:      cfg.MinLength,
+		RequireUpper:   cfg.RequireUpper,
+		RequireLower:   cfg.RequireLower,
+		RequireDigit:   cfg.RequireDigit,
+		RequireSymbol:  cfg.RequireSymbol,
+		RejectBreached: cfg.RejectBreached,
+	}
+}
+
+// Check returns an error naming the first rule password breaks, its message can be shown to the
+// user. The password may not contain the local part of email either.
+func (p Policy) Check(password, email string) error {
+	length := len([]rune(password))
+	if length < p.MinLength {
+		return fmt.Errorf("password must be at least %d characters long", p.MinLength)
+	}
+	if length > maxLength {
+		return fmt.Errorf("password must be at most %d characters long", maxLength)
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+	switch {
+	case p.RequireUpper && !upper:
+		return errors.New("password must contain an upper case letter")
+	case p.RequireLower && !lower:
+		return errors.New("password must contain a lower case letter")
+	case p.RequireDigit && !digit:
+		return errors.New("password must contain a digit")
+	case p.RequireSymbol && !symbol:
+		return errors.New("password must contain a symbol")
+	}
+
+	lowered := strings.ToLower(password)
+	if local, _, _ := strings.Cut(strings.ToLower(email), "@"); len(local) >= 3 && strings.Contains(lowered, local) {
+		return errors.New("password must not contain your email address")
+	}
+
+	if p.RejectBreached {
+		if _, ok := breached[lowered]; ok {
+			return errors.New("password has appeared in a data breach, choose another one")
+		}
+	}
+	return nil
+}
+
+func loadBreached(list string) map[string]struct{} {
+	entries := make(map[string]struct{})
+	scanner := bufio.NewScanner(strings.NewReader(list))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		entries[strings.ToLower(line)] = struct{}{}
+	}
+	return entries
+}
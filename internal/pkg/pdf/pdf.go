@@ -0,0 +1,131 @@
+// Package pdf writes plain text documents as PDF: A4 pages of lines set in Helvetica. Helvetica is
+// one of the standard fonts every PDF reader has, so no font is embedded and documents stay
+// small. It is meant for statements and certificates, not for layout.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const (
+	pageWidth  = 595 // A4 in points
+	pageHeight = 842
+	margin     = 56
+	leading    = 1.4 // line height as a multiple of the font size
+)
+
+// Cell is text on a line that starts X points from the left margin.
+type Cell struct {
+	X    float64
+	Text string
+}
+
+// Document is written top to bottom, a line that does not fit on the page starts a new one.
+type Document struct {
+	pages []*bytes.Buffer
+	y     float64
+}
+
+func New() *Document {
+	d := &Document{}
+	d.newPage()
+	return d
+}
+
+// Text writes a line of text at size points, in bold when bold is set.
+func (d *Document) Text(size float64, bold bool, text string) {
+	d.Line(size, bold, Cell{Text: text})
+}
+
+// Line writes cells side by side on one line at size points, in bold when bold is set.
+func (d *Document) Line(size float64, bold bool, cells ...Cell) {
+	height := size * leading
+	if d.y-height < margin {
+		d.newPage()
+	}
+	d.y -= height
+
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	page := d.pages[len(d.pages)-1]
+	for _, cell := range cells {
+		fmt.Fprintf(page, "BT /%s %s Tf %s %s Td (%s) Tj ET\n",
+			font, number(size), number(margin+cell.X), number(d.y), escape(cell.Text))
+	}
+}
+
+// Space leaves points of blank space below the last line.
+func (d *Document) Space(points float64) {
+	d.y -= points
+}
+
+// Bytes is the PDF file of the document.
+func (d *Document) Bytes() []byte {
+	var out bytes.Buffer
+	// the comment of bytes over 127 tells tools that the file is binary
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for i, page := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>", pageWidth, pageHeight, 6+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.Bytes()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+func (d *Document) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pageHeight - margin
+}
+
+// escape encodes text in WinAnsiEncoding as a PDF string. Characters the encoding does not have
+// are written as "?".
+func escape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= ' ' && r < 0x7f:
+			b.WriteRune(r)
+		case r == '€':
+			b.WriteString(`\200`)
+		case r >= 0xa0 && r <= 0xff:
+			// WinAnsiEncoding matches Latin-1 from here on
+			fmt.Fprintf(&b, `\%03o`, r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+func number(f float64) string {
+	return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.2f", f), "0"), ".")
+}
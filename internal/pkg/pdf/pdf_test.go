@@ -0,0 +1,63 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestDocument_Bytes(t *testing.T) {
+	d := New()
+	d.Text(16, true, "Tax certificate (2026)")
+	d.Space(10)
+	d.Line(10, false, Cell{Text: "Gross"}, Cell{X: 200, Text: "£1,234.50"})
+
+	out := d.Bytes()
+
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+	assert.Contains(t, string(out), "BT /F2 16 Tf 56 763.6 Td (Tax certificate \\(2026\\)) Tj ET\n")
+	assert.Contains(t, string(out), "BT /F1 10 Tf 256 739.6 Td (\\2431,234.50) Tj ET\n")
+	assertXref(t, out)
+}
+
+func TestDocument_PageBreak(t *testing.T) {
+	d := New()
+	for i := 0; i < 100; i++ {
+		d.Text(10, false, fmt.Sprintf("line %d", i))
+	}
+
+	out := d.Bytes()
+
+	// 52 lines of 14 points fit between the margins of a page
+	assert.Contains(t, string(out), "/Count 2")
+	assert.Contains(t, string(out), "Td (line 51) Tj")
+	assert.Contains(t, string(out), "BT /F1 10 Tf 56 772 Td (line 52) Tj ET")
+	assertXref(t, out)
+}
+
+func TestEscape(t *testing.T) {
+	assert.Equal(t, `a\\b \(c\) \200 \351 ?`, escape(`a\b (c) € é ✓`))
+}
+
+// assertXref checks that every object is where the cross-reference table says it is.
+func assertXref(t *testing.T, out []byte) {
+	t.Helper()
+
+	start := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+	assert.NotNil(t, start)
+	xref, err := strconv.Atoi(string(start[1]))
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(out[xref:], []byte("xref\n")))
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(out[xref:], -1)
+	assert.NotEmpty(t, entries)
+	for i, entry := range entries {
+		offset, err := strconv.Atoi(string(entry[1]))
+		assert.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(out[offset:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))), "object %d", i+1)
+	}
+}
@@ -0,0 +1,84 @@
+package totp
+
+import (
+	"encoding/base32"
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA1 key from the RFC 6238 test vectors.
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCode_RFC6238Vectors(t *testing.T) {
+	// the RFC lists 8 digit codes, the last 6 digits are the 6 digit code
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+
+	for unix, expected := range vectors {
+		code, err := Code(rfcSecret, time.Unix(unix, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, code, "time %d", unix)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+
+	t.Run("accepts current code", func(t *testing.T) {
+		step, err := Validate(rfcSecret, "081804", now)
+		assert.NoError(t, err)
+		assert.Equal(t, Step(now), step)
+	})
+
+	t.Run("accepts code from previous period", func(t *testing.T) {
+		previous, _ := Code(rfcSecret, now.Add(-Period))
+		step, err := Validate(rfcSecret, previous, now)
+		assert.NoError(t, err)
+		assert.Equal(t, Step(now)-1, step)
+	})
+
+	t.Run("rejects code outside skew", func(t *testing.T) {
+		old, _ := Code(rfcSecret, now.Add(-3*Period))
+		_, err := Validate(rfcSecret, old, now)
+		assert.Equal(t, ErrInvalidCode, err)
+	})
+
+	t.Run("rejects malformed code", func(t *testing.T) {
+		_, err := Validate(rfcSecret, "12345", now)
+		assert.Equal(t, ErrInvalidCode, err)
+	})
+
+	t.Run("rejects invalid secret", func(t *testing.T) {
+		_, err := Validate("not base32!", "081804", now)
+		assert.Equal(t, ErrInvalidSecret, err)
+	})
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	assert.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	_, err = Code(secret, time.Now())
+	assert.NoError(t, err)
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri := ProvisioningURI("JBSWY3DPEHPK3PXP", "PayterBank", "jane@example.com")
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/PayterBank:jane@example.com?"))
+
+	parsed, err := url.Parse(uri)
+	assert.NoError(t, err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", parsed.Query().Get("secret"))
+	assert.Equal(t, "PayterBank", parsed.Query().Get("issuer"))
+	assert.Equal(t, "6", parsed.Query().Get("digits"))
+}
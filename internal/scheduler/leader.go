@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"payter-bank/internal/config"
+	"sync"
+)
+
+// ErrNotLeader is returned by IsLeader when another replica holds the leader lock.
+var ErrNotLeader = errors.New("another replica is the scheduler leader")
+
+// Leader elects the replica that runs jobs with a Postgres advisory lock. The lock belongs to a
+// database session, so the leader keeps a connection of its own for as long as it leads. When the
+// leader dies its session ends, the lock is freed and the next replica to check takes it.
+type Leader struct {
+	db  *sql.DB
+	key int64
+
+	mu   sync.Mutex
+	conn *sql.Conn
+}
+
+func NewLeader(db *sql.DB, cfg config.SchedulerConfig) *Leader {
+	return &Leader{
+		db:  db,
+		key: cfg.LockKey,
+	}
+}
+
+// IsLeader returns nil when this replica holds the leader lock, taking it when it is free.
+func (l *Leader) IsLeader(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn != nil {
+		if err := l.conn.PingContext(ctx); err == nil {
+			return nil
+		}
+		// the session is gone and the lock with it, another replica may have taken over
+		_ = l.conn.Close()
+		l.conn = nil
+	}
+
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	var locked bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", l.key).Scan(&locked); err != nil {
+		_ = conn.Close()
+		return err
+	}
+	if !locked {
+		_ = conn.Close()
+		return ErrNotLeader
+	}
+
+	l.conn = conn
+	return nil
+}
+
+// Release gives up the leader lock if this replica holds it.
+func (l *Leader) Release(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil {
+		return nil
+	}
+
+	_, err := l.conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", l.key)
+	if closeErr := l.conn.Close(); err == nil {
+		err = closeErr
+	}
+	l.conn = nil
+	return err
+}
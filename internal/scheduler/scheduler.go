@@ -0,0 +1,92 @@
+// Package scheduler runs the background jobs of the server on cron schedules. There is one
+// scheduler for the life of the process: jobs are scheduled on it by name, and scheduling a name
+// again reschedules the job in place. When several replicas run, only the leader runs jobs.
+package scheduler
+
+import (
+	"context"
+	"github.com/go-co-op/gocron/v2"
+	"github.com/google/uuid"
+	"github.com/jonboulle/clockwork"
+	"go.uber.org/zap"
+	"payter-bank/internal/config"
+	"payter-bank/internal/logger"
+)
+
+// Elector decides whether this replica runs jobs. IsLeader returns an error when it does not.
+type Elector interface {
+	gocron.Elector
+	// Release steps down so that another replica can take over without waiting.
+	Release(ctx context.Context) error
+}
+
+// Job is a task run on a cron schedule.
+type Job struct {
+	// Name identifies the job, scheduling the same name again replaces its schedule and task.
+	Name string
+	// Spec is the cron expression the job runs on.
+	Spec string
+	// Task is given a context that is cancelled when the scheduler shuts down.
+	Task func(ctx context.Context)
+	// RunOnStart runs the job as soon as the scheduler starts as well.
+	RunOnStart bool
+}
+
+type Scheduler struct {
+	cron    gocron.Scheduler
+	elector Elector
+}
+
+// New creates the scheduler. Jobs are run by the leader only, and at most one run of a job at a
+// time: a run due while the last one is still going is skipped.
+func New(cfg config.SchedulerConfig, elector Elector, clock clockwork.Clock) (*Scheduler, error) {
+	cron, err := gocron.NewScheduler(
+		gocron.WithClock(clock),
+		gocron.WithDistributedElector(elector),
+		gocron.WithStopTimeout(cfg.StopTimeout),
+		gocron.WithGlobalJobOptions(gocron.WithSingletonMode(gocron.LimitModeReschedule)))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Scheduler{cron: cron, elector: elector}, nil
+}
+
+// Schedule adds job, or reschedules it when a job of the same name is already scheduled.
+func (s *Scheduler) Schedule(job Job) error {
+	options := []gocron.JobOption{gocron.WithName(job.Name)}
+	if job.RunOnStart {
+		options = append(options, gocron.WithStartAt(gocron.WithStartImmediately()))
+	}
+
+	// the ID is derived from the name so that updating it replaces the job rather than adding one
+	_, err := s.cron.Update(uuid.NewSHA1(uuid.NameSpaceOID, []byte(job.Name)),
+		gocron.CronJob(job.Spec, false),
+		gocron.NewTask(job.Task),
+		options...)
+	return err
+}
+
+// Start runs the scheduled jobs until ctx is cancelled, then waits for the jobs that are running to
+// finish, up to SCHEDULER_STOP_TIMEOUT, and steps down as leader.
+func (s *Scheduler) Start(ctx context.Context) error {
+	ctx = logger.With(ctx,
+		zap.String(logger.FunctionName, "Start"))
+
+	s.cron.Start()
+	logger.Info(ctx, "scheduler started",
+		zap.Int("jobs", len(s.cron.Jobs())))
+
+	<-ctx.Done()
+
+	logger.Info(ctx, "shutting down scheduler")
+	err := s.cron.Shutdown()
+	if err != nil {
+		logger.Error(ctx, "failed to shutdown scheduler", zap.Error(err))
+	}
+
+	if err := s.elector.Release(context.WithoutCancel(ctx)); err != nil {
+		logger.Warn(ctx, "failed to release leader lock", zap.Error(err))
+	}
+	return err
+}
@@ -0,0 +1,174 @@
+package scheduler
+
+import (
+	"context"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"payter-bank/internal/config"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// elector is an Elector that leads when leader is set.
+type elector struct {
+	leader   atomic.Bool
+	asked    chan struct{}
+	released atomic.Bool
+}
+
+func newElector(leader bool) *elector {
+	e := &elector{asked: make(chan struct{}, 10)}
+	e.leader.Store(leader)
+	return e
+}
+
+func (e *elector) IsLeader(context.Context) error {
+	e.asked <- struct{}{}
+	if !e.leader.Load() {
+		return ErrNotLeader
+	}
+	return nil
+}
+
+func (e *elector) Release(context.Context) error {
+	e.released.Store(true)
+	return nil
+}
+
+// start runs s in the background until the test ends, the channel receives what Start returns.
+func start(t *testing.T, s *Scheduler) (context.CancelFunc, <-chan error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- s.Start(ctx)
+	}()
+	t.Cleanup(cancel)
+	return cancel, errChan
+}
+
+func newScheduler(t *testing.T, e Elector, clock clockwork.Clock) *Scheduler {
+	s, err := New(config.SchedulerConfig{StopTimeout: time.Second}, e, clock)
+	assert.NoError(t, err)
+	return s
+}
+
+func TestScheduler_Schedule(t *testing.T) {
+	t.Run("runs a job on its schedule", func(t *testing.T) {
+		clock := clockwork.NewFakeClockAt(time.Date(2026, 4, 15, 0, 4, 30, 0, time.UTC))
+		s := newScheduler(t, newElector(true), clock)
+
+		ran := make(chan struct{}, 1)
+		assert.NoError(t, s.Schedule(Job{Name: "job", Spec: "* * * * *", Task: func(context.Context) {
+			ran <- struct{}{}
+		}}))
+		start(t, s)
+
+		assert.NoError(t, clock.BlockUntilContext(context.Background(), 1))
+		select {
+		case <-ran:
+			t.Fatal("job ran before it was due")
+		default:
+		}
+
+		clock.Advance(30 * time.Second)
+		select {
+		case <-ran:
+		case <-time.After(time.Second):
+			t.Fatal("job did not run when it was due")
+		}
+	})
+
+	t.Run("runs a job on start", func(t *testing.T) {
+		clock := clockwork.NewFakeClockAt(time.Date(2026, 4, 15, 0, 4, 30, 0, time.UTC))
+		s := newScheduler(t, newElector(true), clock)
+
+		ran := make(chan struct{}, 1)
+		assert.NoError(t, s.Schedule(Job{Name: "job", Spec: "5 0 * * *", RunOnStart: true, Task: func(context.Context) {
+			ran <- struct{}{}
+		}}))
+		start(t, s)
+
+		select {
+		case <-ran:
+		case <-time.After(time.Second):
+			t.Fatal("job did not run on start")
+		}
+	})
+
+	t.Run("does not run jobs on a replica that is not the leader", func(t *testing.T) {
+		clock := clockwork.NewFakeClockAt(time.Date(2026, 4, 15, 0, 4, 30, 0, time.UTC))
+		e := newElector(false)
+		s := newScheduler(t, e, clock)
+
+		ran := make(chan struct{}, 1)
+		assert.NoError(t, s.Schedule(Job{Name: "job", Spec: "* * * * *", RunOnStart: true, Task: func(context.Context) {
+			ran <- struct{}{}
+		}}))
+		start(t, s)
+
+		select {
+		case <-e.asked:
+		case <-time.After(time.Second):
+			t.Fatal("job was never due")
+		}
+		select {
+		case <-ran:
+			t.Fatal("job ran on a replica that is not the leader")
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	t.Run("reschedules a job scheduled again under the same name", func(t *testing.T) {
+		clock := clockwork.NewFakeClockAt(time.Date(2026, 4, 15, 0, 4, 30, 0, time.UTC))
+		s := newScheduler(t, newElector(true), clock)
+
+		ran := make(chan string, 2)
+		assert.NoError(t, s.Schedule(Job{Name: "job", Spec: "5 0 * * *", Task: func(context.Context) {
+			ran <- "first"
+		}}))
+		start(t, s)
+		assert.NoError(t, s.Schedule(Job{Name: "job", Spec: "5 0 * * *", RunOnStart: true, Task: func(context.Context) {
+			ran <- "second"
+		}}))
+
+		select {
+		case task := <-ran:
+			assert.Equal(t, "second", task)
+		case <-time.After(time.Second):
+			t.Fatal("rescheduled job did not run")
+		}
+		assert.Len(t, s.cron.Jobs(), 1)
+	})
+}
+
+func TestScheduler_Start(t *testing.T) {
+	t.Run("stops when the context is cancelled and steps down as leader", func(t *testing.T) {
+		e := newElector(true)
+		s := newScheduler(t, e, clockwork.NewFakeClock())
+
+		stopped := make(chan struct{})
+		assert.NoError(t, s.Schedule(Job{Name: "job", Spec: "* * * * *", RunOnStart: true, Task: func(ctx context.Context) {
+			<-ctx.Done()
+			close(stopped)
+		}}))
+		cancel, errChan := start(t, s)
+		<-e.asked
+
+		cancel()
+
+		select {
+		case err := <-errChan:
+			assert.NoError(t, err)
+		case <-time.After(2 * time.Second):
+			t.Fatal("scheduler did not stop")
+		}
+		// the running job was told to stop and Start waited for it
+		select {
+		case <-stopped:
+		default:
+			t.Fatal("Start returned before the running job stopped")
+		}
+		assert.True(t, e.released.Load())
+	})
+}
@@ -0,0 +1,6 @@
+ALTER TABLE transactions
+    DROP CONSTRAINT IF EXISTS transactions_reference_number_key;
+
+ALTER TABLE accounts
+    DROP COLUMN IF EXISTS iban,
+    DROP COLUMN IF EXISTS sort_code;
@@ -0,0 +1,15 @@
+ALTER TABLE accounts
+    ADD COLUMN IF NOT EXISTS sort_code VARCHAR(6),
+    ADD COLUMN IF NOT EXISTS iban VARCHAR(34) UNIQUE;
+
+-- references were random 8-digit numbers without a uniqueness guarantee, suffix any
+-- duplicates with the transaction id before enforcing uniqueness.
+UPDATE transactions t
+    SET reference_number = t.reference_number || '-' || LEFT(t.id::text, 8)
+    WHERE EXISTS (
+        SELECT 1 FROM transactions d
+            WHERE d.reference_number = t.reference_number AND d.id <> t.id
+    );
+
+ALTER TABLE transactions
+    ADD CONSTRAINT transactions_reference_number_key UNIQUE (reference_number);
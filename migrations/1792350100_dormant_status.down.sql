@@ -0,0 +1,2 @@
+-- enum values cannot be dropped, DORMANT accounts are moved back to ACTIVE instead.
+UPDATE accounts SET status = 'ACTIVE' WHERE status = 'DORMANT';
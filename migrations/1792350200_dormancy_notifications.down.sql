@@ -0,0 +1,7 @@
+DELETE FROM users WHERE id = '00000000-2222-2222-2222-000000000000';
+
+DROP TABLE IF EXISTS notifications;
+
+ALTER TABLE accounts
+    DROP COLUMN IF EXISTS dormant_since,
+    DROP COLUMN IF EXISTS last_activity_at;
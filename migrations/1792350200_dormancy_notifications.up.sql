@@ -0,0 +1,42 @@
+ALTER TABLE accounts
+    ADD COLUMN IF NOT EXISTS last_activity_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS dormant_since TIMESTAMP;
+
+-- only payments the customer made out of the account are activity, the same rule the transaction
+-- service applies. Transfers and admin debits are both audited as account_debit, a transfer is the
+-- one made by a customer. Interest postings, credits and admin debits do not count.
+UPDATE accounts a
+    SET last_activity_at = COALESCE(
+        (SELECT MAX(l.created_at) FROM audit_logs l
+            JOIN users u ON u.id = l.user_id
+            WHERE l.affected_account_id = a.id
+                AND l.action = 'account_debit'
+                AND u.user_type = 'CUSTOMER'),
+        a.created_at
+    );
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id     UUID NOT NULL REFERENCES users(id),
+    type        VARCHAR(100) NOT NULL,
+    subject     VARCHAR(255) NOT NULL,
+    message     TEXT NOT NULL,
+    metadata    JSONB,
+    read_at     TIMESTAMP,
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at  TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS notifications_user_id_idx ON notifications(user_id, created_at DESC);
+
+-- system user that automated jobs (e.g. dormancy detection) act as in audit logs
+INSERT INTO users (id, email, password, first_name, last_name, user_type)
+    VALUES (
+        '00000000-2222-2222-2222-000000000000',
+        'system@payterbank.app',
+        gen_random_uuid(),
+        'System',
+        'Job',
+        'ADMIN'
+) ON CONFLICT DO NOTHING;
@@ -0,0 +1,32 @@
+-- secret is only trusted once enabled_at is set, i.e. the user has confirmed a code from it.
+CREATE TABLE IF NOT EXISTS user_mfa (
+    user_id         UUID PRIMARY KEY REFERENCES users(id),
+    secret          VARCHAR(64) NOT NULL,
+    enabled_at      TIMESTAMP,
+    last_used_step  BIGINT NOT NULL DEFAULT 0,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id     UUID NOT NULL REFERENCES users(id),
+    code_hash   VARCHAR(64) NOT NULL,
+    used_at     TIMESTAMP,
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS mfa_recovery_codes_user_id_code_hash_idx ON mfa_recovery_codes(user_id, code_hash);
+
+-- issued after a correct password for users with MFA enabled, exchanged for tokens with a valid code.
+CREATE TABLE IF NOT EXISTS mfa_challenges (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id     UUID NOT NULL REFERENCES users(id),
+    token_hash  VARCHAR(64) NOT NULL UNIQUE,
+    attempts    INT NOT NULL DEFAULT 0,
+    expires_at  TIMESTAMP NOT NULL,
+    used_at     TIMESTAMP,
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS mfa_verified_at TIMESTAMP;
@@ -0,0 +1,80 @@
+-- permission names are referenced from code, see internal/auth/permissions.go.
+CREATE TABLE IF NOT EXISTS permissions (
+    name        VARCHAR(64) PRIMARY KEY,
+    description TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS roles (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name        VARCHAR(64) NOT NULL UNIQUE,
+    description TEXT NOT NULL,
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role_id     UUID NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    permission  VARCHAR(64) NOT NULL REFERENCES permissions(name) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission)
+);
+
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id     UUID NOT NULL REFERENCES users(id),
+    role_id     UUID NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, role_id)
+);
+
+INSERT INTO permissions (name, description) VALUES
+    ('accounts:read', 'View, search and report on any account'),
+    ('accounts:manage', 'Suspend, activate, close and reactivate accounts'),
+    ('transactions:read', 'View the balance and transactions of any account'),
+    ('transactions:write', 'Credit and debit accounts'),
+    ('interest:read', 'View the interest rate'),
+    ('interest:write', 'Change the interest rate and calculation frequency'),
+    ('users:read', 'View users, their roles and login lockouts'),
+    ('users:manage', 'Update, deactivate and reactivate users, revoke sessions, reset MFA and unlock logins'),
+    ('users:create', 'Create admin users'),
+    ('roles:manage', 'Give roles to admin users'),
+    ('audit:read', 'View account audit logs')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO roles (name, description) VALUES
+    ('support_agent', 'Read-only access to customers, accounts and transactions'),
+    ('operations', 'Manages account and user status'),
+    ('treasury', 'Moves money and manages the interest rate'),
+    ('super_admin', 'Every permission')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, p.permission
+FROM roles r
+JOIN (VALUES
+    ('support_agent', 'accounts:read'),
+    ('support_agent', 'transactions:read'),
+    ('support_agent', 'interest:read'),
+    ('support_agent', 'users:read'),
+    ('support_agent', 'audit:read'),
+    ('operations', 'accounts:read'),
+    ('operations', 'accounts:manage'),
+    ('operations', 'transactions:read'),
+    ('operations', 'users:read'),
+    ('operations', 'users:manage'),
+    ('operations', 'audit:read'),
+    ('treasury', 'accounts:read'),
+    ('treasury', 'transactions:read'),
+    ('treasury', 'transactions:write'),
+    ('treasury', 'interest:read'),
+    ('treasury', 'interest:write')
+) AS p(role, permission) ON p.role = r.name
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, p.name FROM roles r CROSS JOIN permissions p WHERE r.name = 'super_admin'
+ON CONFLICT DO NOTHING;
+
+-- existing admins keep the access they had, the system user never logs in.
+INSERT INTO user_roles (user_id, role_id)
+SELECT u.id, r.id
+FROM users u CROSS JOIN roles r
+WHERE u.user_type = 'ADMIN' AND u.id <> '00000000-2222-2222-2222-000000000000' AND r.name = 'super_admin'
+ON CONFLICT DO NOTHING;
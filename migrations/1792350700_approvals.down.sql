@@ -0,0 +1,3 @@
+DELETE FROM permissions WHERE name IN ('approvals:read', 'approvals:decide');
+DROP TABLE IF EXISTS approval_requests;
+DROP TYPE IF EXISTS approval_status;
@@ -0,0 +1,38 @@
+CREATE TYPE approval_status AS ENUM ('PENDING', 'APPROVED', 'REJECTED', 'EXPIRED', 'EXECUTED', 'FAILED');
+
+-- operations held for a second admin, see features/approval.
+-- secret holds data the approver must not see (e.g. a password hash), it is cleared once the request is decided.
+CREATE TABLE IF NOT EXISTS approval_requests (
+    id               UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    operation        VARCHAR(64) NOT NULL,
+    payload          JSONB NOT NULL,
+    secret           JSONB,
+    status           approval_status NOT NULL DEFAULT 'PENDING',
+    requested_by     UUID NOT NULL REFERENCES users(id),
+    decided_by       UUID REFERENCES users(id),
+    decision_reason  TEXT,
+    result           JSONB,
+    error            TEXT,
+    expires_at       TIMESTAMP NOT NULL,
+    decided_at       TIMESTAMP,
+    created_at       TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS approval_requests_status_idx ON approval_requests(status, created_at DESC);
+
+INSERT INTO permissions (name, description) VALUES
+    ('approvals:read', 'View approval requests'),
+    ('approvals:decide', 'Approve or reject approval requests')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, p.permission
+FROM roles r
+JOIN (VALUES
+    ('operations', 'approvals:read'),
+    ('treasury', 'approvals:read'),
+    ('treasury', 'approvals:decide'),
+    ('super_admin', 'approvals:read'),
+    ('super_admin', 'approvals:decide')
+) AS p(role, permission) ON p.role = r.name
+ON CONFLICT DO NOTHING;
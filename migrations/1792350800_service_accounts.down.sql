@@ -0,0 +1,5 @@
+DELETE FROM permissions WHERE name = 'service_accounts:manage';
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS api_key_id;
+DROP TABLE IF EXISTS api_keys;
+DROP TABLE IF EXISTS service_accounts;
+-- postgres cannot drop an enum value, SERVICE is left on user_type.
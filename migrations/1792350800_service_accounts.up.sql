@@ -0,0 +1,41 @@
+ALTER TYPE user_type ADD VALUE IF NOT EXISTS 'SERVICE';
+
+-- a service account is backed by a SERVICE user so that its actions can be attributed in the audit log.
+-- it cannot log in with a password, it authenticates with its API keys.
+CREATE TABLE IF NOT EXISTS service_accounts (
+    id          UUID PRIMARY KEY REFERENCES users(id),
+    name        VARCHAR(64) NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    created_by  UUID NOT NULL REFERENCES users(id),
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- keys are stored as a sha256 hash, prefix is kept to tell keys apart.
+-- scopes are permission names, see internal/auth/permissions.go.
+CREATE TABLE IF NOT EXISTS api_keys (
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    service_account_id  UUID NOT NULL REFERENCES service_accounts(id),
+    name                VARCHAR(100) NOT NULL,
+    prefix              VARCHAR(16) NOT NULL,
+    key_hash            VARCHAR(64) NOT NULL UNIQUE,
+    scopes              TEXT[] NOT NULL,
+    allowed_ips         TEXT[] NOT NULL DEFAULT '{}',
+    expires_at          TIMESTAMP,
+    last_used_at        TIMESTAMP,
+    revoked_at          TIMESTAMP,
+    created_by          UUID NOT NULL REFERENCES users(id),
+    created_at          TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS api_keys_service_account_id_idx ON api_keys(service_account_id);
+
+-- the key used for an action taken by a service account.
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS api_key_id UUID REFERENCES api_keys(id);
+
+INSERT INTO permissions (name, description) VALUES
+    ('service_accounts:manage', 'Create service accounts and manage their API keys')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, 'service_accounts:manage' FROM roles r WHERE r.name = 'super_admin'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,4 @@
+-- users flagged here must set a new password before they are given access tokens, the bootstrap
+-- admin is flagged while it still has the configured password.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS password_change_required BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS login_attempts;
+
+DROP INDEX IF EXISTS sessions_user_id_device_fingerprint_idx;
+
+ALTER TABLE sessions
+    DROP COLUMN IF EXISTS device_fingerprint,
+    DROP COLUMN IF EXISTS user_agent,
+    DROP COLUMN IF EXISTS ip;
@@ -0,0 +1,23 @@
+-- the client a session was started from. device_fingerprint is a hash of the user agent, a login
+-- with a fingerprint the user has no session with raises a new device notification.
+ALTER TABLE sessions
+    ADD COLUMN IF NOT EXISTS ip VARCHAR(45),
+    ADD COLUMN IF NOT EXISTS user_agent TEXT,
+    ADD COLUMN IF NOT EXISTS device_fingerprint VARCHAR(64);
+
+CREATE INDEX IF NOT EXISTS sessions_user_id_device_fingerprint_idx ON sessions(user_id, device_fingerprint);
+
+-- every password login and its outcome. user_id is empty when the email is not registered,
+-- session_id is set once the login started a session.
+CREATE TABLE IF NOT EXISTS login_attempts (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id     UUID REFERENCES users(id),
+    email       VARCHAR(255) NOT NULL,
+    ip          VARCHAR(45),
+    user_agent  TEXT,
+    outcome     VARCHAR(30) NOT NULL,
+    session_id  UUID REFERENCES sessions(id),
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS login_attempts_user_id_created_at_idx ON login_attempts(user_id, created_at DESC);
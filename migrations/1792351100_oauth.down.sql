@@ -0,0 +1,10 @@
+DELETE FROM permissions WHERE name = 'oauth_clients:manage';
+
+DROP INDEX IF EXISTS sessions_client_id_idx;
+
+ALTER TABLE sessions
+    DROP COLUMN IF EXISTS scopes,
+    DROP COLUMN IF EXISTS client_id;
+
+DROP TABLE IF EXISTS oauth_authorization_codes;
+DROP TABLE IF EXISTS oauth_clients;
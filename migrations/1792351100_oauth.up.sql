@@ -0,0 +1,44 @@
+-- apps registered by partners to act on behalf of customers. public clients (e.g. mobile apps) have
+-- no secret and rely on PKCE alone. scopes are the ones the client may ask for, see features/oauth.
+CREATE TABLE IF NOT EXISTS oauth_clients (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name           VARCHAR(100) NOT NULL,
+    secret_hash    VARCHAR(64),
+    redirect_uris  TEXT[] NOT NULL,
+    scopes         TEXT[] NOT NULL,
+    created_by     UUID NOT NULL REFERENCES users(id),
+    revoked_at     TIMESTAMP,
+    created_at     TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- codes are stored as a sha256 hash and can be exchanged once. session_id is the session the code
+-- was exchanged for, it is revoked when the code is presented again.
+CREATE TABLE IF NOT EXISTS oauth_authorization_codes (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code_hash       VARCHAR(64) NOT NULL UNIQUE,
+    client_id       UUID NOT NULL REFERENCES oauth_clients(id),
+    user_id         UUID NOT NULL REFERENCES users(id),
+    redirect_uri    TEXT NOT NULL,
+    scopes          TEXT[] NOT NULL,
+    code_challenge  VARCHAR(128) NOT NULL,
+    nonce           TEXT,
+    expires_at      TIMESTAMP NOT NULL,
+    used_at         TIMESTAMP,
+    session_id      UUID REFERENCES sessions(id),
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- sessions started by an oauth client carry the client and the scopes the user granted it.
+ALTER TABLE sessions
+    ADD COLUMN IF NOT EXISTS client_id UUID REFERENCES oauth_clients(id),
+    ADD COLUMN IF NOT EXISTS scopes TEXT[];
+
+CREATE INDEX IF NOT EXISTS sessions_client_id_idx ON sessions(client_id) WHERE client_id IS NOT NULL;
+
+INSERT INTO permissions (name, description) VALUES
+    ('oauth_clients:manage', 'Register and revoke OAuth clients')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, 'oauth_clients:manage' FROM roles r WHERE r.name = 'super_admin'
+ON CONFLICT DO NOTHING;
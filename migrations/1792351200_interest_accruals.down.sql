@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS interest_accruals_uncapitalised_idx;
+DROP TABLE IF EXISTS interest_accruals;
@@ -0,0 +1,23 @@
+-- interest accrues daily on the end-of-day balance of an account at the annual rate, in fractions
+-- of a minor unit. Accruals are capitalised (paid into the account) at the end of each period of
+-- the calculation frequency, transaction_id is the payment they were capitalised with.
+CREATE TABLE IF NOT EXISTS interest_accruals (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_id     UUID NOT NULL REFERENCES accounts(id),
+    accrual_date   DATE NOT NULL,
+    balance        BIGINT NOT NULL,
+    rate           BIGINT NOT NULL,
+    amount         NUMERIC(24, 10) NOT NULL,
+    capitalised_at TIMESTAMP,
+    transaction_id UUID REFERENCES transactions(id),
+    created_at     TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (account_id, accrual_date)
+);
+
+CREATE INDEX IF NOT EXISTS interest_accruals_uncapitalised_idx
+    ON interest_accruals(account_id, accrual_date) WHERE capitalised_at IS NULL;
+
+-- interest is capitalised monthly, quarterly or yearly, shorter frequencies paid the full annual
+-- rate every period and are moved to monthly.
+UPDATE interest_rates SET calculation_frequency = 'monthly'
+    WHERE calculation_frequency NOT IN ('monthly', 'quarterly', 'yearly');
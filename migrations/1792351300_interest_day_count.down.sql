@@ -0,0 +1,2 @@
+ALTER TABLE accounts DROP COLUMN IF EXISTS interest_carry;
+ALTER TABLE interest_accruals DROP COLUMN IF EXISTS day_count;
@@ -0,0 +1,9 @@
+-- the day-count convention each accrual was worked out with. Accruals from before conventions
+-- were added used ACT/365.
+ALTER TABLE interest_accruals
+    ADD COLUMN IF NOT EXISTS day_count VARCHAR(10) NOT NULL DEFAULT 'ACT/365';
+
+-- the fraction of a minor unit left over when accrued interest was last rounded to be paid, it is
+-- added to the interest of the next period. It is negative when rounding paid out more.
+ALTER TABLE accounts
+    ADD COLUMN IF NOT EXISTS interest_carry NUMERIC(24, 10) NOT NULL DEFAULT 0;
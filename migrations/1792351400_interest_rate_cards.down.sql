@@ -0,0 +1,6 @@
+ALTER TABLE interest_accruals
+    DROP COLUMN IF EXISTS bonus_amount,
+    DROP COLUMN IF EXISTS bonus_rate,
+    DROP COLUMN IF EXISTS card_id;
+
+DROP TABLE IF EXISTS interest_rate_cards;
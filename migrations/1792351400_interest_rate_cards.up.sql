@@ -0,0 +1,32 @@
+-- a rate card sets the interest of the accounts of a currency and product (account type), it takes
+-- precedence over the global rate in interest_rates. bands are the balance bands of the card as
+-- [{"from": minor units, "rate": basis points}], sorted by from with the first from 0. method is
+-- tiered, where each band's rate is paid on the part of the balance within it, or whole_balance,
+-- where the rate of the band the balance falls in is paid on the whole balance.
+-- bonus_rate is paid on the whole balance on top for every calendar month that meets all of
+-- bonus_conditions.
+CREATE TABLE IF NOT EXISTS interest_rate_cards (
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    currency            currency NOT NULL,
+    product             account_type NOT NULL DEFAULT 'CURRENT',
+    method              VARCHAR(20) NOT NULL,
+    bands               JSONB NOT NULL,
+    bonus_rate          BIGINT NOT NULL DEFAULT 0,
+    bonus_conditions    TEXT[] NOT NULL DEFAULT '{}',
+    min_monthly_deposit BIGINT NOT NULL DEFAULT 0,
+    created_by          UUID NOT NULL REFERENCES users(id),
+    created_at          TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at          TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at          TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS interest_rate_cards_currency_product_idx
+    ON interest_rate_cards(currency, product) WHERE deleted_at IS NULL;
+
+-- rate is the rate the balance earned, blended across the bands of a tiered card. bonus_amount is
+-- the bonus interest of the day, it is forfeited (set to 0) when the month does not meet the
+-- card's bonus conditions.
+ALTER TABLE interest_accruals
+    ADD COLUMN IF NOT EXISTS card_id UUID REFERENCES interest_rate_cards(id),
+    ADD COLUMN IF NOT EXISTS bonus_rate BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS bonus_amount NUMERIC(24, 10) NOT NULL DEFAULT 0;
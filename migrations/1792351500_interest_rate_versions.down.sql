@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS interest_rates_effective_idx;
+
+ALTER TABLE interest_rates
+    DROP COLUMN IF EXISTS created_by,
+    DROP COLUMN IF EXISTS effective_to,
+    DROP COLUMN IF EXISTS effective_from;
@@ -0,0 +1,25 @@
+-- interest rates are versions: each row is the rate in effect from effective_from up to, but not
+-- including, effective_to, or open-ended when effective_to is NULL. A version is not changed once it
+-- has taken effect, a new rate is a new version and the one before it ends where it starts. Versions
+-- that start in the future are scheduled changes, a cancelled one is marked deleted.
+ALTER TABLE interest_rates
+    ADD COLUMN IF NOT EXISTS effective_from DATE,
+    ADD COLUMN IF NOT EXISTS effective_to DATE,
+    ADD COLUMN IF NOT EXISTS created_by UUID REFERENCES users(id);
+
+UPDATE interest_rates SET effective_from = COALESCE(created_at::date, CURRENT_DATE);
+
+-- the latest rate was the one in use, earlier ones end where the next one starts
+UPDATE interest_rates r
+    SET effective_to = next.effective_from
+    FROM (
+        SELECT id, LEAD(effective_from) OVER (ORDER BY created_at) AS effective_from
+        FROM interest_rates
+        WHERE deleted_at IS NULL
+    ) next
+    WHERE r.id = next.id AND next.effective_from IS NOT NULL;
+
+ALTER TABLE interest_rates ALTER COLUMN effective_from SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS interest_rates_effective_idx
+    ON interest_rates(effective_from) WHERE deleted_at IS NULL;
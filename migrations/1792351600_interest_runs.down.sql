@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS interest_run_accounts;
+DROP TABLE IF EXISTS interest_runs;
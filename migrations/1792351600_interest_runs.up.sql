@@ -0,0 +1,39 @@
+-- an interest run accrues a day of interest, run_date, on every account and, when the day closes a
+-- period of the calculation frequency, capitalises the interest of the period named in period
+-- (for example June 2026). A run is RUNNING until every account has a result, it is then
+-- COMPLETED, or FAILED when an account failed. There is one run a day.
+CREATE TABLE IF NOT EXISTS interest_runs (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    run_date    DATE NOT NULL,
+    rate_id     UUID NOT NULL REFERENCES interest_rates(id),
+    period      VARCHAR(20),
+    status      VARCHAR(20) NOT NULL DEFAULT 'RUNNING' CHECK (status IN ('RUNNING', 'COMPLETED', 'FAILED')),
+    accounts    INT NOT NULL DEFAULT 0,
+    failed      INT NOT NULL DEFAULT 0,
+    started_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS interest_runs_run_date_idx ON interest_runs(run_date);
+
+-- the result of a run for an account. An account is claimed by inserting its result before it is
+-- processed, the unique index makes sure no two runs accrue or pay an account for the same day.
+-- transaction_id is set as soon as interest is paid so a retry does not pay it again.
+CREATE TABLE IF NOT EXISTS interest_run_accounts (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    run_id         UUID NOT NULL REFERENCES interest_runs(id),
+    account_id     UUID NOT NULL REFERENCES accounts(id),
+    run_date       DATE NOT NULL,
+    status         VARCHAR(20) NOT NULL DEFAULT 'RUNNING' CHECK (status IN ('RUNNING', 'COMPLETED', 'FAILED')),
+    accrued        NUMERIC(24, 10) NOT NULL DEFAULT 0,
+    paid           BIGINT NOT NULL DEFAULT 0,
+    transaction_id UUID REFERENCES transactions(id),
+    error          TEXT,
+    attempts       INT NOT NULL DEFAULT 1,
+    created_at     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS interest_run_accounts_account_date_idx
+    ON interest_run_accounts(account_id, run_date);
+CREATE INDEX IF NOT EXISTS interest_run_accounts_run_idx ON interest_run_accounts(run_id);
@@ -0,0 +1,9 @@
+-- the tax account is kept, transactions may refer to it
+ALTER TABLE interest_run_accounts
+    DROP COLUMN IF EXISTS tax_transaction_id,
+    DROP COLUMN IF EXISTS tax_rate,
+    DROP COLUMN IF EXISTS tax;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS tax_exempt,
+    DROP COLUMN IF EXISTS tax_residency;
@@ -0,0 +1,33 @@
+-- the country a customer is resident in for tax (ISO 3166-1 alpha-2), it sets the rate of tax
+-- withheld on their interest. Exempt customers are paid gross.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS tax_residency VARCHAR(2),
+    ADD COLUMN IF NOT EXISTS tax_exempt BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- paid is the gross interest of the period, tax what was withheld from it at tax_rate (basis
+-- points). The account is paid the difference and the tax goes to the tax account in
+-- tax_transaction_id, set as soon as it is posted so a retry does not post it again.
+ALTER TABLE interest_run_accounts
+    ADD COLUMN IF NOT EXISTS tax BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS tax_rate BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS tax_transaction_id UUID REFERENCES transactions(id);
+
+-- Create an account to hold the tax withheld on interest until it is paid to the tax authority
+INSERT INTO users (id, email, password, first_name, last_name)
+    VALUES (
+        '00000000-3333-3333-3333-000000000000',
+        'taxaccount@payterbank.app',
+        gen_random_uuid(),
+        'Tax',
+        'Account'
+);
+
+INSERT INTO accounts (id, user_id, account_number, status, account_type, currency)
+    VALUES (
+        '00000000-3333-3333-3333-000000000000',
+        '00000000-3333-3333-3333-000000000000',
+        '00003333',
+        'ACTIVE',
+        'EXTERNAL',
+        'GBP'
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS interest_rate_overrides;
@@ -0,0 +1,20 @@
+-- an override sets the interest of a single account from starts_on up to and including ends_on, or
+-- for good when ends_on is not set. A FIXED override is a bespoke rate that takes the place of the
+-- account's rate card or the global rate, a BOOST is a promotion whose rate is paid on top of
+-- whichever rate applies. An account has at most one override of each kind on any day.
+CREATE TABLE IF NOT EXISTS interest_rate_overrides (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    kind       VARCHAR(10) NOT NULL,
+    rate       BIGINT NOT NULL,
+    starts_on  DATE NOT NULL,
+    ends_on    DATE,
+    reason     TEXT NOT NULL DEFAULT '',
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (ends_on IS NULL OR ends_on >= starts_on)
+);
+
+CREATE INDEX IF NOT EXISTS interest_rate_overrides_account_id_idx
+    ON interest_rate_overrides(account_id, starts_on);
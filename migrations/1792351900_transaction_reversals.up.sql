@@ -0,0 +1,9 @@
+-- a reversal posts the opposite of a transaction. The row is saved before the reversal is posted so
+-- a transaction is reversed at most once, reversal_id is set once the reversal has been posted.
+CREATE TABLE IF NOT EXISTS transaction_reversals (
+    transaction_id UUID PRIMARY KEY REFERENCES transactions(id),
+    reversal_id    UUID UNIQUE REFERENCES transactions(id),
+    reason         TEXT NOT NULL,
+    reversed_by    UUID NOT NULL REFERENCES users(id),
+    created_at     TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1 @@
+-- the tax accounts are kept, transactions may refer to them
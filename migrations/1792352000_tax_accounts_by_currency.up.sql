@@ -0,0 +1,21 @@
+-- tax withheld from interest is paid into the tax account of the interest's currency, the GBP one
+-- was created with the withholding tax
+INSERT INTO accounts (id, user_id, account_number, status, account_type, currency)
+    VALUES
+        (
+            '00000000-3333-3333-3333-000000000001',
+            '00000000-3333-3333-3333-000000000000',
+            '00003334',
+            'ACTIVE',
+            'EXTERNAL',
+            'EUR'
+        ),
+        (
+            '00000000-3333-3333-3333-000000000002',
+            '00000000-3333-3333-3333-000000000000',
+            '00003335',
+            'ACTIVE',
+            'EXTERNAL',
+            'JPY'
+        )
+ON CONFLICT (id) DO NOTHING;
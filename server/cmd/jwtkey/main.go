@@ -0,0 +1,84 @@
+// jwtkey generates a key to sign access tokens with. The private key is written to -out and its
+// public key to -out.pub, the kid tokens signed with it will carry is printed.
+//
+//	go run ./server/cmd/jwtkey -alg ES256 -out keys/jwt-2026-10.pem
+package main
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"os"
+	"payter-bank/internal/pkg/generator"
+)
+
+func main() {
+	alg := flag.String("alg", "ES256", "RS256, ES256, ES384 or EdDSA")
+	out := flag.String("out", "", "file to write the private key to")
+	flag.Parse()
+
+	if *out == "" {
+		fmt.Fprintln(os.Stderr, "-out is required")
+		os.Exit(2)
+	}
+
+	if err := run(*alg, *out); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(alg, out string) error {
+	private, err := generate(alg)
+	if err != nil {
+		return err
+	}
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return err
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(private.Public())
+	if err != nil {
+		return err
+	}
+
+	privatePEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER})
+	key, err := generator.ParseKeyPEM(privatePEM)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(out, privatePEM, 0o600); err != nil {
+		return err
+	}
+	if err := os.WriteFile(out+".pub", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}), 0o644); err != nil {
+		return err
+	}
+
+	fmt.Printf("wrote %s and %s.pub, kid %s\n", out, out, key.ID)
+	return nil
+}
+
+func generate(alg string) (crypto.Signer, error) {
+	switch alg {
+	case "RS256":
+		return rsa.GenerateKey(rand.Reader, 3072)
+	case "ES256":
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case "ES384":
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case "EdDSA":
+		_, private, err := ed25519.GenerateKey(rand.Reader)
+		return private, err
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %q", alg)
+	}
+}
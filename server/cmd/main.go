@@ -2,21 +2,38 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/jonboulle/clockwork"
 	"github.com/sethvargo/go-envconfig"
 	"go.uber.org/zap"
 	"net/http"
 	"os"
 	"os/signal"
 	"payter-bank/features/account"
+	"payter-bank/features/approval"
 	"payter-bank/features/auditlog"
+	"payter-bank/features/dormancy"
 	"payter-bank/features/interestrate"
+	"payter-bank/features/lockout"
+	"payter-bank/features/mfa"
+	"payter-bank/features/notification"
+	"payter-bank/features/oauth"
+	"payter-bank/features/rbac"
+	"payter-bank/features/serviceaccount"
+	"payter-bank/features/session"
 	"payter-bank/features/transaction"
+	"payter-bank/features/user"
+	"payter-bank/internal/auth"
 	"payter-bank/internal/config"
 	"payter-bank/internal/database"
 	"payter-bank/internal/database/models"
 	"payter-bank/internal/logger"
 	"payter-bank/internal/pkg/generator"
+	"payter-bank/internal/pkg/interest"
+	"payter-bank/internal/pkg/mailer"
+	"payter-bank/internal/pkg/password"
+	"payter-bank/internal/scheduler"
 	"payter-bank/server"
 	"syscall"
 )
@@ -46,7 +63,7 @@ func main() {
 	}
 
 	logger.Info(ctx, "Configuration loaded",
-		zap.Any("config", cfg))
+		zap.Any("config", cfg.Redacted()))
 
 	db, err := database.Open(ctx, cfg.DB.DSN)
 	if err != nil {
@@ -61,22 +78,97 @@ func main() {
 
 	auditLogClient := auditlog.NewClient(cfg.Redis)
 
+	mail, err := mailer.New(cfg.Mail)
+	if err != nil {
+		logger.Fatal(ctx, "Error creating mailer", zap.Error(err))
+	}
+
+	lockoutStore, err := lockout.NewStore(cfg)
+	if err != nil {
+		logger.Fatal(ctx, "Error creating lockout store", zap.Error(err))
+	}
+
+	jwtKeys, err := generator.NewKeySet(cfg.JWT)
+	if err != nil {
+		logger.Fatal(ctx, "Error loading jwt keys", zap.Error(err))
+	}
+
+	interestCalculator, err := interest.NewCalculator(cfg.Interest)
+	if err != nil {
+		logger.Fatal(ctx, "Error loading interest configuration", zap.Error(err))
+	}
+
 	querier := models.New(db)
-	tokenGenerator := generator.NewTokenGenerator(cfg.JWT)
+	tokenGenerator := generator.NewTokenGenerator(cfg.JWT, jwtKeys)
 	auditLogService := auditlog.NewService(cfg, auditLogClient, querier)
-	interestRateApplicationRunner := interestrate.NewRunner(querier, cfg.App)
+	leader := scheduler.NewLeader(db, cfg.Scheduler)
+	jobScheduler, err := scheduler.New(cfg.Scheduler, leader, clockwork.NewRealClock())
+	if err != nil {
+		logger.Fatal(ctx, "Error creating scheduler", zap.Error(err))
+	}
 
+	approvalService := approval.NewService(querier, auditLogService, cfg)
 	transactionService := transaction.NewService(querier, auditLogService)
-	accountService := account.NewService(querier, auditLogService, transactionService, tokenGenerator)
-	interestService := interestrate.NewService(querier, cfg.App, auditLogService, interestRateApplicationRunner)
+	notificationService := notification.NewService(querier, notification.NewLogSender())
+	sessionService := session.NewService(querier, auditLogService, notificationService, tokenGenerator, cfg.JWT)
+	mfaService := mfa.NewService(querier, auditLogService, sessionService, cfg.MFA)
+	lockoutService := lockout.NewService(lockoutStore, auditLogService, cfg)
+	passwordPolicy := password.NewPolicy(cfg.Password)
+	accountService := account.NewService(querier, auditLogService, transactionService, sessionService, mfaService,
+		lockoutService, passwordPolicy, cfg.Bank)
+	interestService := interestrate.NewService(querier, cfg, auditLogService, notificationService, jobScheduler, interestCalculator)
 	auditLogQueryService := auditlog.NewQueryService(querier)
-
-	accountHandler := account.NewHandler(accountService)
-	transactionHandler := transaction.NewHandler(transactionService)
-	interestRateHandler := interestrate.NewHandler(interestService)
+	dormancyService := dormancy.NewService(querier, auditLogService, notificationService, jobScheduler, cfg)
+	rbacService := rbac.NewService(querier, auditLogService)
+	serviceAccountService := serviceaccount.NewService(querier, auditLogService)
+	oauthService := oauth.NewService(querier, auditLogService, sessionService, tokenGenerator, cfg)
+	userService := user.NewService(querier, auditLogService, mail, accountService, passwordPolicy, cfg.App)
+
+	approvalService.Register(approval.OperationCredit, auth.PermTransactionsWrite,
+		transaction.CreditExecutor(transactionService))
+	approvalService.Register(approval.OperationDebit, auth.PermTransactionsWrite,
+		transaction.DebitExecutor(transactionService))
+	approvalService.Register(approval.OperationReversal, auth.PermTransactionsWrite,
+		transaction.ReversalExecutor(transactionService))
+	approvalService.Register(approval.OperationInterestRateCreate, auth.PermInterestWrite,
+		interestrate.CreateRateExecutor(interestService))
+	approvalService.Register(approval.OperationInterestRateUpdate, auth.PermInterestWrite,
+		interestrate.UpdateRateExecutor(interestService))
+	approvalService.Register(approval.OperationInterestFrequencyUpdate, auth.PermInterestWrite,
+		interestrate.UpdateCalculationFrequencyExecutor(interestService))
+	approvalService.Register(approval.OperationInterestRateCardCreate, auth.PermInterestWrite,
+		interestrate.CreateRateCardExecutor(interestService))
+	approvalService.Register(approval.OperationInterestRateCardUpdate, auth.PermInterestWrite,
+		interestrate.UpdateRateCardExecutor(interestService))
+	approvalService.Register(approval.OperationInterestRateCardDelete, auth.PermInterestWrite,
+		interestrate.DeleteRateCardExecutor(interestService))
+	approvalService.Register(approval.OperationInterestRateOverrideCreate, auth.PermInterestWrite,
+		interestrate.CreateRateOverrideExecutor(interestService))
+	approvalService.Register(approval.OperationInterestRateOverrideUpdate, auth.PermInterestWrite,
+		interestrate.UpdateRateOverrideExecutor(interestService))
+	approvalService.Register(approval.OperationInterestRateOverrideDelete, auth.PermInterestWrite,
+		interestrate.DeleteRateOverrideExecutor(interestService))
+	approvalService.Register(approval.OperationAdminCreate, auth.PermUsersCreate,
+		account.AdminCreationExecutor(accountService))
+
+	accountHandler := account.NewHandler(accountService, approvalService)
+	transactionHandler := transaction.NewHandler(transactionService, approvalService)
+	interestRateHandler := interestrate.NewHandler(interestService, approvalService)
 	auditLogHandler := auditlog.NewHandler(auditLogQueryService)
-
-	srvHandler := server.New(cfg, querier, accountHandler, transactionHandler, interestRateHandler, auditLogHandler)
+	dormancyHandler := dormancy.NewHandler(dormancyService)
+	notificationHandler := notification.NewHandler(notificationService)
+	userHandler := user.NewHandler(userService)
+	sessionHandler := session.NewHandler(sessionService)
+	mfaHandler := mfa.NewHandler(mfaService)
+	lockoutHandler := lockout.NewHandler(lockoutService)
+	rbacHandler := rbac.NewHandler(rbacService)
+	approvalHandler := approval.NewHandler(approvalService)
+	serviceAccountHandler := serviceaccount.NewHandler(serviceAccountService)
+	oauthHandler := oauth.NewHandler(oauthService)
+
+	srvHandler := server.New(cfg, querier, jwtKeys, accountHandler, transactionHandler, interestRateHandler, auditLogHandler,
+		dormancyHandler, notificationHandler, userHandler, sessionHandler, mfaHandler, lockoutHandler,
+		rbacHandler, approvalHandler, serviceAccountService, serviceAccountHandler, oauthHandler)
 	routes, err := srvHandler.BuildRoutes()
 	if err != nil {
 		logger.Fatal(ctx, "Error building routes", zap.Error(err))
@@ -88,9 +180,19 @@ func main() {
 		}
 	}()
 
+	if err := interestService.Start(ctx); err != nil {
+		logger.Warn(ctx, "Error starting interest-rate calculation job", zap.Error(err))
+	}
+
+	if err := dormancyService.Start(ctx); err != nil {
+		logger.Warn(ctx, "Error starting dormancy detection job", zap.Error(err))
+	}
+
+	schedulerStopped := make(chan struct{})
 	go func() {
-		if err := interestRateApplicationRunner.Start(ctx); err != nil {
-			logger.Warn(ctx, "Error starting interest-rate calculation job", zap.Error(err))
+		defer close(schedulerStopped)
+		if err := jobScheduler.Start(ctx); err != nil {
+			logger.Error(ctx, "Error stopping scheduler", zap.Error(err))
 		}
 	}()
 
@@ -98,6 +200,10 @@ func main() {
 		logger.Fatal(ctx, "Error initializing admin account", zap.Error(err))
 	}
 
+	if err := accountService.BackfillIdentifiers(ctx); err != nil {
+		logger.Warn(ctx, "Error backfilling account identifiers", zap.Error(err))
+	}
+
 	addr := ":" + cfg.Server.Port
 	srv := http.Server{
 		Addr:    addr,
@@ -105,7 +211,8 @@ func main() {
 	}
 	go func() {
 		logger.Info(ctx, fmt.Sprintf("Listening and serving HTTP on %s", addr))
-		if err := srv.ListenAndServe(); err != nil {
+		// Shutdown makes ListenAndServe return ErrServerClosed, main then waits for the scheduler
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			logger.Fatal(ctx, "Server closed", zap.Error(err))
 		}
 	}()
@@ -120,4 +227,7 @@ func main() {
 	if err := srv.Shutdown(ctx); err != nil {
 		logger.Fatal(ctx, "Server forced to shutdown", zap.Error(err))
 	}
+
+	// the scheduler stops with the signal, wait for the jobs it is running before closing the database
+	<-schedulerStopped
 }
@@ -2,12 +2,70 @@ package server
 
 import (
 	"context"
+	"errors"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"net/http"
+	"payter-bank/features/oauth"
+	"payter-bank/features/serviceaccount"
 	"payter-bank/internal/api"
 	"payter-bank/internal/auth"
 	"payter-bank/internal/database/models"
+	platformerrors "payter-bank/internal/errors"
+	"payter-bank/internal/logger"
+	"slices"
+	"strings"
+	"time"
 )
 
+// apiKeyHeader carries the API key of a service account, see features/serviceaccount.
+const apiKeyHeader = "X-API-Key"
+
+// apiKeyMiddleware authenticates requests that carry an API key and sets the service account as the
+// current profile. Requests without one are left to the JWT middleware.
+func apiKeyMiddleware(service serviceaccount.Service) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader(apiKeyHeader)
+		if key == "" {
+			ctx.Next()
+			return
+		}
+
+		profile, err := service.Authenticate(ctx.Request.Context(), key, ctx.ClientIP())
+		if err != nil {
+			code, message := http.StatusInternalServerError, platformerrors.ErrInternal.Error()
+			var apiErr *api.ApiError
+			if errors.As(err, &apiErr) {
+				code, message = apiErr.Code, apiErr.Message
+			}
+			ctx.JSON(code, api.ErrorResponse{
+				Error: message,
+			})
+			ctx.Abort()
+			return
+		}
+
+		// the key id is kept on both contexts, services are handed either one
+		ctx.Set(auth.APIKeyIDKey, profile.APIKeyID)
+		c := context.WithValue(ctx.Request.Context(), auth.ProfileKey, profile)
+		c = context.WithValue(c, auth.APIKeyIDKey, profile.APIKeyID)
+		ctx.Request = ctx.Request.WithContext(c)
+		ctx.Next()
+	}
+}
+
+// unlessAPIKey skips handler for requests already authenticated with an API key.
+func unlessAPIKey(handler gin.HandlerFunc) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if auth.APIKeyID(ctx) != uuid.Nil {
+			ctx.Next()
+			return
+		}
+		handler(ctx)
+	}
+}
+
 func currentProfileMiddleWare(db models.Querier) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		token, err := auth.GetTokenData(ctx)
@@ -39,13 +97,66 @@ func currentProfileMiddleWare(db models.Querier) gin.HandlerFunc {
 			RegisteredAt: row.RegisteredAt.Time,
 		}
 
+		// only admins hold roles, customers are limited to their own accounts by the handlers
+		if row.UserType == models.UserTypeADMIN {
+			permissions, err := db.GetUserPermissions(ctx.Request.Context(), row.UserID)
+			if err != nil {
+				logger.Error(ctx.Request.Context(), "failed to get user permissions", zap.Error(err))
+				ctx.JSON(http.StatusInternalServerError, api.ErrorResponse{
+					Error: platformerrors.ErrInternal.Error(),
+				})
+				ctx.Abort()
+				return
+			}
+			for _, permission := range permissions {
+				profile.Permissions = append(profile.Permissions, auth.Permission(permission))
+			}
+		}
+
 		c := context.WithValue(ctx.Request.Context(), auth.ProfileKey, profile)
 		ctx.Request = ctx.Request.WithContext(c)
 		ctx.Next()
 	}
 }
 
-func ensureAdminMiddleware() gin.HandlerFunc {
+// delegatedRoutes are the routes a token issued to an OAuth client can reach and the scope each one
+// needs, every other route is closed to such tokens. Handlers still limit customers to their own accounts.
+var delegatedRoutes = map[string]string{
+	"GET /api/v1/me":                        oauth.ScopeProfile,
+	"GET /api/v1/accounts/:id":              oauth.ScopeAccountsRead,
+	"GET /api/v1/accounts/:id/transactions": oauth.ScopeAccountsRead,
+	"GET /api/v1/accounts/:id/balance":      oauth.ScopeAccountsRead,
+	"POST /api/v1/accounts/validate":        oauth.ScopePaymentsWrite,
+	"POST /api/v1/accounts/validate-iban":   oauth.ScopePaymentsWrite,
+	"POST /api/v1/transfer":                 oauth.ScopePaymentsWrite,
+}
+
+// delegatedScopeMiddleware limits tokens issued to an OAuth client to delegatedRoutes their scopes
+// allow. Tokens issued on login are let through.
+func delegatedScopeMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		token, err := auth.GetTokenData(ctx)
+		if err != nil || token.ClientID == uuid.Nil {
+			ctx.Next()
+			return
+		}
+
+		scope, ok := delegatedRoutes[ctx.Request.Method+" "+ctx.FullPath()]
+		if !ok || !slices.Contains(strings.Fields(token.Scope), scope) {
+			ctx.JSON(http.StatusForbidden, api.ErrorResponse{
+				Error: "token does not grant access to this route",
+			})
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// ensureAdminMiddleware only lets admins and service accounts through. When requireMFA is set the
+// admin must also have logged in with MFA, admins without it can still reach /me/mfa to enroll.
+func ensureAdminMiddleware(requireMFA bool) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		profile, err := auth.GetCurrentProfile(ctx)
 		if err != nil {
@@ -56,6 +167,12 @@ func ensureAdminMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		if profile.IsServiceAccount() {
+			// service accounts are limited to the scopes of their key and have no MFA
+			ctx.Next()
+			return
+		}
+
 		if profile.UserType != string(models.UserTypeADMIN) {
 			ctx.JSON(403, api.ErrorResponse{
 				Error: "Forbidden",
@@ -64,6 +181,72 @@ func ensureAdminMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		if requireMFA {
+			token, err := auth.GetTokenData(ctx)
+			if err != nil || token.MFAVerifiedAt.IsZero() {
+				ctx.JSON(403, api.ErrorResponse{
+					Error: "mfa is required for admins, enroll at /me/mfa and log in again",
+				})
+				ctx.Abort()
+				return
+			}
+		}
+
+		ctx.Next()
+	}
+}
+
+// requirePermissionMiddleware only lets through users with a role that grants permission.
+func requirePermissionMiddleware(permission auth.Permission) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		profile, err := auth.GetCurrentProfile(ctx)
+		if err != nil {
+			ctx.JSON(403, api.ErrorResponse{
+				Error: "Unauthorized",
+			})
+			ctx.Abort()
+			return
+		}
+
+		if !profile.HasPermission(permission) {
+			ctx.JSON(403, api.ErrorResponse{
+				Error: "Forbidden",
+			})
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// requireStepUpMiddleware guards high-risk actions, the user must have entered an MFA code within
+// window. Older sessions are refreshed through POST /me/mfa/verify. API keys cannot step up, a key
+// holding the scope for the action is let through.
+func requireStepUpMiddleware(window time.Duration) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if auth.APIKeyID(ctx) != uuid.Nil {
+			ctx.Next()
+			return
+		}
+
+		token, err := auth.GetTokenData(ctx)
+		if err != nil {
+			ctx.JSON(403, api.ErrorResponse{
+				Error: "Unauthorized",
+			})
+			ctx.Abort()
+			return
+		}
+
+		if token.MFAVerifiedAt.IsZero() || time.Since(token.MFAVerifiedAt) > window {
+			ctx.JSON(403, api.ErrorResponse{
+				Error: "step-up authentication required, verify an mfa code at /me/mfa/verify",
+			})
+			ctx.Abort()
+			return
+		}
+
 		ctx.Next()
 	}
 }
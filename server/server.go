@@ -6,41 +6,75 @@ import (
 	"github.com/auth0/go-jwt-middleware/v2/validator"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.uber.org/zap"
 	"net/http"
 	_ "payter-bank/docs"
 	"payter-bank/features/account"
+	"payter-bank/features/approval"
 	"payter-bank/features/auditlog"
+	"payter-bank/features/dormancy"
 	"payter-bank/features/interestrate"
+	"payter-bank/features/lockout"
+	"payter-bank/features/mfa"
+	"payter-bank/features/notification"
+	"payter-bank/features/oauth"
+	"payter-bank/features/rbac"
+	"payter-bank/features/serviceaccount"
+	"payter-bank/features/session"
 	"payter-bank/features/transaction"
+	"payter-bank/features/user"
 	"payter-bank/internal/api"
+	"payter-bank/internal/auth"
 	"payter-bank/internal/config"
 	"payter-bank/internal/database/models"
+	platformerrors "payter-bank/internal/errors"
+	"payter-bank/internal/logger"
 	"payter-bank/internal/pkg/generator"
 )
 
 type Server struct {
-	accountHandler      *account.Handler
-	transactionHandler  *transaction.Handler
-	interestRateHandler *interestrate.Handler
-	auditLogHandler     *auditlog.Handler
-	cfg                 config.Config
-	db                  models.Querier
+	accountHandler        *account.Handler
+	transactionHandler    *transaction.Handler
+	interestRateHandler   *interestrate.Handler
+	auditLogHandler       *auditlog.Handler
+	dormancyHandler       *dormancy.Handler
+	notificationHandler   *notification.Handler
+	userHandler           *user.Handler
+	sessionHandler        *session.Handler
+	mfaHandler            *mfa.Handler
+	lockoutHandler        *lockout.Handler
+	rbacHandler           *rbac.Handler
+	approvalHandler       *approval.Handler
+	serviceAccounts       serviceaccount.Service
+	serviceAccountHandler *serviceaccount.Handler
+	oauthHandler          *oauth.Handler
+	cfg                   config.Config
+	db                    models.Querier
+	keys                  *generator.KeySet
 }
 
-func New(cfg config.Config, db models.Querier,
-	accountHandler *account.Handler, txHandler *transaction.Handler, interestRateHandler *interestrate.Handler, auditLogHandler *auditlog.Handler) *Server {
-	return &Server{accountHandler: accountHandler, db: db, cfg: cfg, transactionHandler: txHandler, interestRateHandler: interestRateHandler, auditLogHandler: auditLogHandler}
+func New(cfg config.Config, db models.Querier, keys *generator.KeySet,
+	accountHandler *account.Handler, txHandler *transaction.Handler, interestRateHandler *interestrate.Handler, auditLogHandler *auditlog.Handler,
+	dormancyHandler *dormancy.Handler, notificationHandler *notification.Handler, userHandler *user.Handler, sessionHandler *session.Handler, mfaHandler *mfa.Handler,
+	lockoutHandler *lockout.Handler, rbacHandler *rbac.Handler, approvalHandler *approval.Handler,
+	serviceAccounts serviceaccount.Service, serviceAccountHandler *serviceaccount.Handler, oauthHandler *oauth.Handler) *Server {
+	return &Server{accountHandler: accountHandler, db: db, cfg: cfg, keys: keys, transactionHandler: txHandler, interestRateHandler: interestRateHandler, auditLogHandler: auditLogHandler,
+		dormancyHandler: dormancyHandler, notificationHandler: notificationHandler, userHandler: userHandler, sessionHandler: sessionHandler, mfaHandler: mfaHandler,
+		lockoutHandler: lockoutHandler, rbacHandler: rbacHandler, approvalHandler: approvalHandler,
+		serviceAccounts: serviceAccounts, serviceAccountHandler: serviceAccountHandler, oauthHandler: oauthHandler}
 }
 
 func (s *Server) BuildRoutes() (*gin.Engine, error) {
-	authMW, err := s.jwtMiddleware()
-	if err != nil {
-		return nil, err
-	}
+	authMW := s.jwtMiddleware()
 
 	r := gin.New()
+	// the client IP is used for login lockouts, it is only taken from X-Forwarded-For when set by a trusted proxy
+	if err := r.SetTrustedProxies(s.cfg.Server.TrustedProxies); err != nil {
+		return nil, err
+	}
 	r.Use(gin.Logger())
 	r.Use(gin.Recovery())
 	r.Use(cors.New(s.corsConfig()))
@@ -48,91 +82,229 @@ func (s *Server) BuildRoutes() (*gin.Engine, error) {
 		r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	}
 
+	// other services verify our access tokens with these keys
+	r.GET("/.well-known/jwks.json", s.jwksHandler)
+
+	// OAuth 2.0 and OpenID Connect endpoints for partner apps, see features/oauth
+	r.GET("/.well-known/openid-configuration", s.oauthHandler.DiscoveryHandler)
+	r.GET("/oauth/authorize", s.oauthHandler.AuthorizeRedirectHandler)
+	r.POST("/oauth/token", api.Wrap(s.oauthHandler.TokenHandler))
+	r.POST("/oauth/introspect", api.Wrap(s.oauthHandler.IntrospectHandler))
+	r.POST("/oauth/revoke", api.Wrap(s.oauthHandler.RevokeHandler))
+
 	v1 := r.Group("/api/v1")
-	v1.POST("/users", api.Wrap(s.accountHandler.CreateUserHandler))
+	v1.POST("/users", api.Wrap(s.userHandler.RegisterHandler))
 	v1.POST("/users/authenticate", api.Wrap(s.accountHandler.AuthenticateAccountHandler))
+	v1.POST("/users/authenticate/mfa", api.Wrap(s.accountHandler.AuthenticateMFAHandler))
+	v1.POST("/users/refresh", api.Wrap(s.sessionHandler.RefreshHandler))
+	v1.POST("/users/verify-email", api.Wrap(s.userHandler.VerifyEmailHandler))
+	v1.POST("/users/resend-verification", api.Wrap(s.userHandler.ResendVerificationHandler))
+	v1.POST("/users/forgot-password", api.Wrap(s.userHandler.ForgotPasswordHandler))
+	v1.POST("/users/reset-password", api.Wrap(s.userHandler.ResetPasswordHandler))
+
+	// requests are authenticated with either a bearer JWT or an X-API-Key header, JWTs issued to an
+	// OAuth client only reach the routes their scopes allow
+	authenticate := []gin.HandlerFunc{apiKeyMiddleware(s.serviceAccounts), unlessAPIKey(authMW), unlessAPIKey(currentProfileMiddleWare(s.db)),
+		unlessAPIKey(delegatedScopeMiddleware())}
 
 	authenticated := r.Group("/api/v1")
-	authenticated.Use(authMW, currentProfileMiddleWare(s.db))
+	authenticated.Use(authenticate...)
 	authenticated.POST("/accounts", api.Wrap(s.accountHandler.CreateAccountHandler))
+	authenticated.POST("/users/logout", api.Wrap(s.sessionHandler.LogoutHandler))
 	authenticated.GET("/me", api.Wrap(s.accountHandler.MeHandler))
+	authenticated.PATCH("/me", api.Wrap(s.userHandler.UpdateProfileHandler))
+	authenticated.POST("/me/password", api.Wrap(s.userHandler.ChangePasswordHandler))
+	authenticated.GET("/me/mfa", api.Wrap(s.mfaHandler.StatusHandler))
+	authenticated.POST("/me/mfa/enroll", api.Wrap(s.mfaHandler.EnrollHandler))
+	authenticated.POST("/me/mfa/confirm", api.Wrap(s.mfaHandler.ConfirmHandler))
+	authenticated.POST("/me/mfa/disable", api.Wrap(s.mfaHandler.DisableHandler))
+	authenticated.POST("/me/mfa/verify", api.Wrap(s.mfaHandler.StepUpHandler))
+	authenticated.GET("/me/sessions", api.Wrap(s.sessionHandler.ListHandler))
+	authenticated.POST("/me/sessions/:id/revoke", api.Wrap(s.sessionHandler.TerminateHandler))
+	authenticated.GET("/me/logins", api.Wrap(s.sessionHandler.LoginHistoryHandler))
+	authenticated.GET("/me/notifications", api.Wrap(s.notificationHandler.GetNotificationsHandler))
+	authenticated.PATCH("/me/notifications/:id/read", api.Wrap(s.notificationHandler.MarkReadHandler))
+	authenticated.GET("/oauth/authorize", api.Wrap(s.oauthHandler.ConsentHandler))
+	authenticated.POST("/oauth/authorize", api.Wrap(s.oauthHandler.DecideHandler))
+	authenticated.POST("/accounts/validate", api.Wrap(s.accountHandler.ValidateAccountNumberHandler))
+	authenticated.POST("/accounts/validate-iban", api.Wrap(s.accountHandler.ValidateIBANHandler))
 	authenticated.PATCH(
-		"/accounts/:id/suspend", ensureAdminMiddleware(), api.Wrap(s.accountHandler.SuspendAccountHandler))
+		"/accounts/:id/suspend",
+		s.ensureAdmin(),
+		s.can(auth.PermAccountsManage),
+		api.Wrap(s.accountHandler.SuspendAccountHandler))
 	authenticated.PATCH(
-		"/accounts/:id/activate", ensureAdminMiddleware(), api.Wrap(s.accountHandler.ActivateAccountHandler))
+		"/accounts/:id/activate",
+		s.ensureAdmin(),
+		s.can(auth.PermAccountsManage),
+		api.Wrap(s.accountHandler.ActivateAccountHandler))
 	authenticated.PATCH(
-		"/accounts/:id/close", ensureAdminMiddleware(), api.Wrap(s.accountHandler.CloseAccountHandler))
+		"/accounts/:id/close",
+		s.ensureAdmin(),
+		s.can(auth.PermAccountsManage),
+		s.stepUp(),
+		api.Wrap(s.accountHandler.CloseAccountHandler))
 	authenticated.GET(
-		"/accounts/:id/status-history", ensureAdminMiddleware(), api.Wrap(s.accountHandler.GetAccountStatusHistoryHandler))
+		"/accounts/:id/status-history",
+		s.ensureAdmin(),
+		s.can(auth.PermAccountsRead),
+		api.Wrap(s.accountHandler.GetAccountStatusHistoryHandler))
 	authenticated.GET(
 		"/accounts/:id",
 		api.Wrap(s.accountHandler.GetAccountDetailsHandler))
 	authenticated.POST(
 		"/credit",
-		ensureAdminMiddleware(),
+		s.ensureAdmin(),
+		s.can(auth.PermTransactionsWrite),
+		s.stepUp(),
 		api.Wrap(s.transactionHandler.CreditAccountHandler))
 	authenticated.POST(
 		"/debit",
-		ensureAdminMiddleware(),
+		s.ensureAdmin(),
+		s.can(auth.PermTransactionsWrite),
+		s.stepUp(),
 		api.Wrap(s.transactionHandler.DebitAccountHandler))
+	authenticated.POST(
+		"/transactions/:id/reverse",
+		s.ensureAdmin(),
+		s.can(auth.PermTransactionsWrite),
+		s.stepUp(),
+		api.Wrap(s.transactionHandler.ReverseTransactionHandler))
 	authenticated.GET(
 		"/accounts/:id/transactions",
 		api.Wrap(s.transactionHandler.GetTransactionHistoryHandler))
 	authenticated.GET(
 		"/accounts/:id/balance",
 		api.Wrap(s.transactionHandler.BalanceHandler))
+	authenticated.GET(
+		"/accounts/:id/tax-certificate",
+		api.Wrap(s.interestRateHandler.TaxCertificateHandler))
 	authenticated.POST(
 		"/transfer",
 		api.Wrap(s.transactionHandler.TransferFundsHandler))
 
+	// every admin route declares the permission it needs, see internal/auth/permissions.go
 	adminOnly := r.Group("/api/v1")
-	adminOnly.Use(authMW, currentProfileMiddleWare(s.db), ensureAdminMiddleware())
+	adminOnly.Use(append(authenticate, s.ensureAdmin())...)
 	adminOnly.POST(
 		"/interest-rate",
+		s.can(auth.PermInterestWrite),
 		api.Wrap(s.interestRateHandler.CreateInterestRateHandler))
 	adminOnly.PUT(
 		"/interest-rate",
+		s.can(auth.PermInterestWrite),
 		api.Wrap(s.interestRateHandler.UpdateRateHandler))
 	adminOnly.PUT(
 		"/interest-rate/calculation-frequency",
+		s.can(auth.PermInterestWrite),
 		api.Wrap(s.interestRateHandler.UpdateCalculationFrequencyHandler))
 	adminOnly.GET(
 		"/interest-rate/current",
+		s.can(auth.PermInterestRead),
 		api.Wrap(s.interestRateHandler.GetCurrentRateHandler))
-	adminOnly.POST("/admin/users", api.Wrap(s.accountHandler.CreateAdminUserHandler))
-	adminOnly.GET("/accounts", api.Wrap(s.accountHandler.GetAllCurrentAccountsHandler))
-	adminOnly.GET("/accounts/stats", api.Wrap(s.accountHandler.GetAccountsStatsHandler))
-	adminOnly.GET("/accounts/:id/logs", api.Wrap(s.auditLogHandler.GetAccountAuditLogsHandler))
+	adminOnly.GET("/interest-rate/history", s.can(auth.PermInterestRead), api.Wrap(s.interestRateHandler.GetRateHistoryHandler))
+	adminOnly.POST("/interest-rate/simulate", s.can(auth.PermInterestRead), api.Wrap(s.interestRateHandler.SimulateHandler))
+	adminOnly.POST("/interest-rate/cards", s.can(auth.PermInterestWrite), api.Wrap(s.interestRateHandler.CreateRateCardHandler))
+	adminOnly.GET("/interest-rate/cards", s.can(auth.PermInterestRead), api.Wrap(s.interestRateHandler.ListRateCardsHandler))
+	adminOnly.GET("/interest-rate/cards/:id", s.can(auth.PermInterestRead), api.Wrap(s.interestRateHandler.GetRateCardHandler))
+	adminOnly.PUT("/interest-rate/cards/:id", s.can(auth.PermInterestWrite), api.Wrap(s.interestRateHandler.UpdateRateCardHandler))
+	adminOnly.DELETE("/interest-rate/cards/:id", s.can(auth.PermInterestWrite), api.Wrap(s.interestRateHandler.DeleteRateCardHandler))
+	adminOnly.GET("/interest-rate/runs", s.can(auth.PermInterestRead), api.Wrap(s.interestRateHandler.ListRunsHandler))
+	adminOnly.GET("/interest-rate/runs/:id", s.can(auth.PermInterestRead), api.Wrap(s.interestRateHandler.GetRunHandler))
+	adminOnly.POST("/interest-rate/runs/:id/accounts/:account_id/retry", s.can(auth.PermInterestWrite),
+		api.Wrap(s.interestRateHandler.RetryRunAccountHandler))
+	adminOnly.POST("/admin/users", s.can(auth.PermUsersCreate), api.Wrap(s.accountHandler.CreateAdminUserHandler))
+	adminOnly.GET("/admin/users/:id", s.can(auth.PermUsersRead), api.Wrap(s.userHandler.GetUserHandler))
+	adminOnly.PATCH("/admin/users/:id", s.can(auth.PermUsersManage), api.Wrap(s.userHandler.AdminUpdateUserHandler))
+	adminOnly.PATCH("/admin/users/:id/deactivate", s.can(auth.PermUsersManage), s.stepUp(), api.Wrap(s.userHandler.DeactivateUserHandler))
+	adminOnly.PATCH("/admin/users/:id/reactivate", s.can(auth.PermUsersManage), api.Wrap(s.userHandler.ReactivateUserHandler))
+	adminOnly.PUT("/admin/users/:id/tax", s.can(auth.PermUsersManage), api.Wrap(s.userHandler.SetTaxStatusHandler))
+	adminOnly.GET("/admin/users/:id/sessions", s.can(auth.PermUsersRead), api.Wrap(s.sessionHandler.AdminListHandler))
+	adminOnly.POST("/admin/users/:id/sessions/revoke", s.can(auth.PermUsersManage), api.Wrap(s.sessionHandler.RevokeAllHandler))
+	adminOnly.POST("/admin/users/:id/sessions/:sessionId/revoke", s.can(auth.PermUsersManage), api.Wrap(s.sessionHandler.AdminTerminateHandler))
+	adminOnly.GET("/admin/users/:id/logins", s.can(auth.PermUsersRead), api.Wrap(s.sessionHandler.AdminLoginHistoryHandler))
+	adminOnly.POST("/admin/users/:id/mfa/reset", s.can(auth.PermUsersManage), s.stepUp(), api.Wrap(s.mfaHandler.ResetHandler))
+	adminOnly.GET("/admin/users/:id/roles", s.can(auth.PermUsersRead), api.Wrap(s.rbacHandler.GetUserRolesHandler))
+	adminOnly.PUT("/admin/users/:id/roles", s.can(auth.PermRolesManage), s.stepUp(), api.Wrap(s.rbacHandler.SetUserRolesHandler))
+	adminOnly.GET("/admin/roles", s.can(auth.PermUsersRead), api.Wrap(s.rbacHandler.ListRolesHandler))
+	adminOnly.GET("/admin/approvals", s.can(auth.PermApprovalsRead), api.Wrap(s.approvalHandler.ListHandler))
+	adminOnly.GET("/admin/approvals/:id", s.can(auth.PermApprovalsRead), api.Wrap(s.approvalHandler.GetHandler))
+	adminOnly.POST("/admin/approvals/:id/approve", s.can(auth.PermApprovalsDecide), s.stepUp(), api.Wrap(s.approvalHandler.ApproveHandler))
+	adminOnly.POST("/admin/approvals/:id/reject", s.can(auth.PermApprovalsDecide), api.Wrap(s.approvalHandler.RejectHandler))
+	adminOnly.POST("/admin/service-accounts", s.can(auth.PermServiceAccountsManage), s.stepUp(), api.Wrap(s.serviceAccountHandler.CreateHandler))
+	adminOnly.GET("/admin/service-accounts", s.can(auth.PermServiceAccountsManage), api.Wrap(s.serviceAccountHandler.ListHandler))
+	adminOnly.GET("/admin/service-accounts/:id", s.can(auth.PermServiceAccountsManage), api.Wrap(s.serviceAccountHandler.GetHandler))
+	adminOnly.POST("/admin/service-accounts/:id/keys", s.can(auth.PermServiceAccountsManage), s.stepUp(), api.Wrap(s.serviceAccountHandler.CreateKeyHandler))
+	adminOnly.POST("/admin/service-accounts/:id/keys/:keyId/rotate", s.can(auth.PermServiceAccountsManage), s.stepUp(), api.Wrap(s.serviceAccountHandler.RotateKeyHandler))
+	adminOnly.POST("/admin/service-accounts/:id/keys/:keyId/revoke", s.can(auth.PermServiceAccountsManage), api.Wrap(s.serviceAccountHandler.RevokeKeyHandler))
+	adminOnly.POST("/admin/oauth/clients", s.can(auth.PermOAuthClientsManage), s.stepUp(), api.Wrap(s.oauthHandler.CreateClientHandler))
+	adminOnly.GET("/admin/oauth/clients", s.can(auth.PermOAuthClientsManage), api.Wrap(s.oauthHandler.ListClientsHandler))
+	adminOnly.POST("/admin/oauth/clients/:id/revoke", s.can(auth.PermOAuthClientsManage), api.Wrap(s.oauthHandler.RevokeClientHandler))
+	adminOnly.GET("/admin/lockouts", s.can(auth.PermUsersRead), api.Wrap(s.lockoutHandler.StatusHandler))
+	adminOnly.POST("/admin/lockouts/unlock", s.can(auth.PermUsersManage), api.Wrap(s.lockoutHandler.UnlockHandler))
+	adminOnly.GET("/accounts", s.can(auth.PermAccountsRead), api.Wrap(s.accountHandler.GetAllCurrentAccountsHandler))
+	adminOnly.GET("/accounts/search", s.can(auth.PermAccountsRead), api.Wrap(s.accountHandler.SearchAccountsHandler))
+	adminOnly.GET("/accounts/stats", s.can(auth.PermAccountsRead), api.Wrap(s.accountHandler.GetAccountsStatsHandler))
+	adminOnly.GET("/accounts/:id/logs", s.can(auth.PermAuditRead), api.Wrap(s.auditLogHandler.GetAccountAuditLogsHandler))
+	adminOnly.POST("/accounts/:id/reactivate", s.can(auth.PermAccountsManage), api.Wrap(s.dormancyHandler.ReactivateAccountHandler))
+	adminOnly.GET("/accounts/:id/interest-overrides", s.can(auth.PermInterestRead), api.Wrap(s.interestRateHandler.ListRateOverridesHandler))
+	adminOnly.POST("/accounts/:id/interest-overrides", s.can(auth.PermInterestWrite), s.stepUp(),
+		api.Wrap(s.interestRateHandler.CreateRateOverrideHandler))
+	adminOnly.PUT("/accounts/:id/interest-overrides/:override_id", s.can(auth.PermInterestWrite), s.stepUp(),
+		api.Wrap(s.interestRateHandler.UpdateRateOverrideHandler))
+	adminOnly.DELETE("/accounts/:id/interest-overrides/:override_id", s.can(auth.PermInterestWrite), s.stepUp(),
+		api.Wrap(s.interestRateHandler.DeleteRateOverrideHandler))
+	adminOnly.GET("/reports/dormancy", s.can(auth.PermAccountsRead), api.Wrap(s.dormancyHandler.GetDormancyReportHandler))
 
 	return r, nil
 }
 
-func (s *Server) jwtMiddleware() (gin.HandlerFunc, error) {
-	keyFunc := func(ctx context.Context) (interface{}, error) {
-		return []byte(s.cfg.JWT.Secret), nil
-	}
+func (s *Server) ensureAdmin() gin.HandlerFunc {
+	return ensureAdminMiddleware(s.cfg.MFA.RequiredForAdmins)
+}
 
-	customClaims := func() validator.CustomClaims {
-		return &generator.Claim{}
-	}
+// can lets through admins with a role that grants permission.
+func (s *Server) can(permission auth.Permission) gin.HandlerFunc {
+	return requirePermissionMiddleware(permission)
+}
 
-	jwtValidator, err := validator.New(
-		keyFunc,
-		validator.HS256,
-		s.cfg.JWT.Issuer,
-		[]string{s.cfg.JWT.Audience},
-		validator.WithCustomClaims(customClaims),
-	)
-	if err != nil {
-		return nil, err
+// stepUp is applied to high-risk actions such as moving money and removing a user's access.
+func (s *Server) stepUp() gin.HandlerFunc {
+	return requireStepUpMiddleware(s.cfg.MFA.StepUpWindow)
+}
+
+// jwtMiddleware verifies the bearer token against the key set, tokens signed with a previous key
+// are accepted while the key is in JWT_VERIFICATION_KEY_FILES.
+func (s *Server) jwtMiddleware() gin.HandlerFunc {
+	tokens := generator.NewTokenGenerator(s.cfg.JWT, s.keys)
+	validate := func(ctx context.Context, token string) (interface{}, error) {
+		claim, err := tokens.Parse(token)
+		if err != nil {
+			return nil, err
+		}
+		registered := validator.RegisteredClaims{
+			ID:       claim.RegisteredClaims.ID,
+			Issuer:   claim.Issuer,
+			Subject:  claim.Subject,
+			Audience: claim.Audience,
+			Expiry:   claim.RegisteredClaims.ExpiresAt.Unix(),
+		}
+		if claim.IssuedAt != nil {
+			registered.IssuedAt = claim.IssuedAt.Unix()
+		}
+		return &validator.ValidatedClaims{CustomClaims: claim, RegisteredClaims: registered}, nil
 	}
 
-	mw := jwtmiddleware.New(jwtValidator.ValidateToken)
+	mw := jwtmiddleware.New(validate)
 	return func(ctx *gin.Context) {
 		encounteredError := true
 		var handler http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
 			encounteredError = false
 			ctx.Request = r
+			if s.tokenRevoked(ctx) {
+				ctx.Abort()
+				return
+			}
 			ctx.Next()
 		}
 
@@ -141,14 +313,54 @@ func (s *Server) jwtMiddleware() (gin.HandlerFunc, error) {
 		if encounteredError {
 			ctx.Abort()
 		}
-	}, nil
+	}
+}
+
+// jwksHandler publishes the public keys access tokens are verified with. It answers with a bare JWK
+// Set rather than the API envelope so that standard JWT libraries can read it.
+func (s *Server) jwksHandler(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, s.keys.JWKS())
+}
+
+// tokenRevoked rejects access tokens that were logged out, or whose session has been revoked.
+// Tokens issued before sessions existed carry no jti or session and are only checked for expiry.
+func (s *Server) tokenRevoked(ctx *gin.Context) bool {
+	claim, err := auth.GetClaim(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, api.ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return true
+	}
+
+	jti, _ := uuid.Parse(claim.ID)
+	revoked, err := s.db.IsAccessTokenRevoked(ctx.Request.Context(), models.IsAccessTokenRevokedParams{
+		Jti:       jti,
+		SessionID: claim.SessionID,
+	})
+	if err != nil {
+		logger.Error(ctx.Request.Context(), "failed to check access token revocation", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Error: platformerrors.ErrInternal.Error(),
+		})
+		return true
+	}
+
+	if revoked {
+		ctx.JSON(http.StatusUnauthorized, api.ErrorResponse{
+			Error: "token has been revoked",
+		})
+		return true
+	}
+	return false
 }
 
 func (s *Server) corsConfig() cors.Config {
 	cfg := cors.DefaultConfig()
 	cfg.AllowOrigins = []string{s.cfg.Server.CorsOrigin}
 	cfg.AllowMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
-	cfg.AllowHeaders = []string{"Origin", "Content-Length", "Content-Type", "Authorization"}
+	cfg.AllowHeaders = []string{"Origin", "Content-Length", "Content-Type", "Authorization", apiKeyHeader}
 	cfg.AllowCredentials = true
 	return cfg
 }
@@ -0,0 +1,462 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"payter-bank/features/account"
+	"payter-bank/features/approval"
+	"payter-bank/features/auditlog"
+	"payter-bank/features/dormancy"
+	"payter-bank/features/interestrate"
+	"payter-bank/features/lockout"
+	"payter-bank/features/mfa"
+	"payter-bank/features/notification"
+	"payter-bank/features/oauth"
+	"payter-bank/features/rbac"
+	"payter-bank/features/serviceaccount"
+	"payter-bank/features/session"
+	"payter-bank/features/transaction"
+	"payter-bank/features/user"
+	"payter-bank/internal/auth"
+	"payter-bank/internal/config"
+	"payter-bank/internal/database/models"
+	databasemocks "payter-bank/internal/database/models/mocks"
+	platformerrors "payter-bank/internal/errors"
+	"payter-bank/internal/pkg/generator"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+type routePermission struct {
+	method     string
+	path       string
+	permission auth.Permission
+}
+
+// routePermissions is the permission each admin route must require. Adding an admin route
+// without adding it here fails TestBuildRoutes_EveryAdminRouteHasPermission.
+var routePermissions = []routePermission{
+	{http.MethodPatch, "/api/v1/accounts/:id/suspend", auth.PermAccountsManage},
+	{http.MethodPatch, "/api/v1/accounts/:id/activate", auth.PermAccountsManage},
+	{http.MethodPatch, "/api/v1/accounts/:id/close", auth.PermAccountsManage},
+	{http.MethodGet, "/api/v1/accounts/:id/status-history", auth.PermAccountsRead},
+	{http.MethodPost, "/api/v1/credit", auth.PermTransactionsWrite},
+	{http.MethodPost, "/api/v1/debit", auth.PermTransactionsWrite},
+	{http.MethodPost, "/api/v1/transactions/:id/reverse", auth.PermTransactionsWrite},
+	{http.MethodPost, "/api/v1/interest-rate", auth.PermInterestWrite},
+	{http.MethodPut, "/api/v1/interest-rate", auth.PermInterestWrite},
+	{http.MethodPut, "/api/v1/interest-rate/calculation-frequency", auth.PermInterestWrite},
+	{http.MethodGet, "/api/v1/interest-rate/current", auth.PermInterestRead},
+	{http.MethodGet, "/api/v1/interest-rate/history", auth.PermInterestRead},
+	{http.MethodPost, "/api/v1/interest-rate/simulate", auth.PermInterestRead},
+	{http.MethodPost, "/api/v1/interest-rate/cards", auth.PermInterestWrite},
+	{http.MethodGet, "/api/v1/interest-rate/cards", auth.PermInterestRead},
+	{http.MethodGet, "/api/v1/interest-rate/cards/:id", auth.PermInterestRead},
+	{http.MethodPut, "/api/v1/interest-rate/cards/:id", auth.PermInterestWrite},
+	{http.MethodDelete, "/api/v1/interest-rate/cards/:id", auth.PermInterestWrite},
+	{http.MethodGet, "/api/v1/interest-rate/runs", auth.PermInterestRead},
+	{http.MethodGet, "/api/v1/interest-rate/runs/:id", auth.PermInterestRead},
+	{http.MethodPost, "/api/v1/interest-rate/runs/:id/accounts/:account_id/retry", auth.PermInterestWrite},
+	{http.MethodPost, "/api/v1/admin/users", auth.PermUsersCreate},
+	{http.MethodGet, "/api/v1/admin/users/:id", auth.PermUsersRead},
+	{http.MethodPatch, "/api/v1/admin/users/:id", auth.PermUsersManage},
+	{http.MethodPatch, "/api/v1/admin/users/:id/deactivate", auth.PermUsersManage},
+	{http.MethodPatch, "/api/v1/admin/users/:id/reactivate", auth.PermUsersManage},
+	{http.MethodPut, "/api/v1/admin/users/:id/tax", auth.PermUsersManage},
+	{http.MethodGet, "/api/v1/admin/users/:id/sessions", auth.PermUsersRead},
+	{http.MethodPost, "/api/v1/admin/users/:id/sessions/revoke", auth.PermUsersManage},
+	{http.MethodPost, "/api/v1/admin/users/:id/sessions/:sessionId/revoke", auth.PermUsersManage},
+	{http.MethodGet, "/api/v1/admin/users/:id/logins", auth.PermUsersRead},
+	{http.MethodPost, "/api/v1/admin/users/:id/mfa/reset", auth.PermUsersManage},
+	{http.MethodGet, "/api/v1/admin/users/:id/roles", auth.PermUsersRead},
+	{http.MethodPut, "/api/v1/admin/users/:id/roles", auth.PermRolesManage},
+	{http.MethodGet, "/api/v1/admin/roles", auth.PermUsersRead},
+	{http.MethodGet, "/api/v1/admin/approvals", auth.PermApprovalsRead},
+	{http.MethodGet, "/api/v1/admin/approvals/:id", auth.PermApprovalsRead},
+	{http.MethodPost, "/api/v1/admin/approvals/:id/approve", auth.PermApprovalsDecide},
+	{http.MethodPost, "/api/v1/admin/approvals/:id/reject", auth.PermApprovalsDecide},
+	{http.MethodPost, "/api/v1/admin/service-accounts", auth.PermServiceAccountsManage},
+	{http.MethodGet, "/api/v1/admin/service-accounts", auth.PermServiceAccountsManage},
+	{http.MethodGet, "/api/v1/admin/service-accounts/:id", auth.PermServiceAccountsManage},
+	{http.MethodPost, "/api/v1/admin/service-accounts/:id/keys", auth.PermServiceAccountsManage},
+	{http.MethodPost, "/api/v1/admin/service-accounts/:id/keys/:keyId/rotate", auth.PermServiceAccountsManage},
+	{http.MethodPost, "/api/v1/admin/service-accounts/:id/keys/:keyId/revoke", auth.PermServiceAccountsManage},
+	{http.MethodPost, "/api/v1/admin/oauth/clients", auth.PermOAuthClientsManage},
+	{http.MethodGet, "/api/v1/admin/oauth/clients", auth.PermOAuthClientsManage},
+	{http.MethodPost, "/api/v1/admin/oauth/clients/:id/revoke", auth.PermOAuthClientsManage},
+	{http.MethodGet, "/api/v1/admin/lockouts", auth.PermUsersRead},
+	{http.MethodPost, "/api/v1/admin/lockouts/unlock", auth.PermUsersManage},
+	{http.MethodGet, "/api/v1/accounts", auth.PermAccountsRead},
+	{http.MethodGet, "/api/v1/accounts/search", auth.PermAccountsRead},
+	{http.MethodGet, "/api/v1/accounts/stats", auth.PermAccountsRead},
+	{http.MethodGet, "/api/v1/accounts/:id/logs", auth.PermAuditRead},
+	{http.MethodPost, "/api/v1/accounts/:id/reactivate", auth.PermAccountsManage},
+	{http.MethodGet, "/api/v1/accounts/:id/interest-overrides", auth.PermInterestRead},
+	{http.MethodPost, "/api/v1/accounts/:id/interest-overrides", auth.PermInterestWrite},
+	{http.MethodPut, "/api/v1/accounts/:id/interest-overrides/:override_id", auth.PermInterestWrite},
+	{http.MethodDelete, "/api/v1/accounts/:id/interest-overrides/:override_id", auth.PermInterestWrite},
+	{http.MethodGet, "/api/v1/reports/dormancy", auth.PermAccountsRead},
+}
+
+// customerRoutes are open to every logged in user, handlers limit customers to their own data.
+var customerRoutes = []string{
+	"POST /api/v1/accounts",
+	"POST /api/v1/users/logout",
+	"GET /api/v1/me",
+	"PATCH /api/v1/me",
+	"POST /api/v1/me/password",
+	"GET /api/v1/me/mfa",
+	"POST /api/v1/me/mfa/enroll",
+	"POST /api/v1/me/mfa/confirm",
+	"POST /api/v1/me/mfa/disable",
+	"POST /api/v1/me/mfa/verify",
+	"GET /api/v1/me/sessions",
+	"POST /api/v1/me/sessions/:id/revoke",
+	"GET /api/v1/me/logins",
+	"GET /api/v1/me/notifications",
+	"PATCH /api/v1/me/notifications/:id/read",
+	"GET /api/v1/oauth/authorize",
+	"POST /api/v1/oauth/authorize",
+	"POST /api/v1/accounts/validate",
+	"POST /api/v1/accounts/validate-iban",
+	"GET /api/v1/accounts/:id",
+	"GET /api/v1/accounts/:id/transactions",
+	"GET /api/v1/accounts/:id/balance",
+	"GET /api/v1/accounts/:id/tax-certificate",
+	"POST /api/v1/transfer",
+}
+
+var publicRoutes = []string{
+	"GET /.well-known/jwks.json",
+	"GET /.well-known/openid-configuration",
+	"GET /oauth/authorize",
+	"POST /oauth/token",
+	"POST /oauth/introspect",
+	"POST /oauth/revoke",
+	"POST /api/v1/users",
+	"POST /api/v1/users/authenticate",
+	"POST /api/v1/users/authenticate/mfa",
+	"POST /api/v1/users/refresh",
+	"POST /api/v1/users/verify-email",
+	"POST /api/v1/users/resend-verification",
+	"POST /api/v1/users/forgot-password",
+	"POST /api/v1/users/reset-password",
+}
+
+var allPermissions = []auth.Permission{
+	auth.PermAccountsRead,
+	auth.PermAccountsManage,
+	auth.PermTransactionsRead,
+	auth.PermTransactionsWrite,
+	auth.PermInterestRead,
+	auth.PermInterestWrite,
+	auth.PermUsersRead,
+	auth.PermUsersManage,
+	auth.PermUsersCreate,
+	auth.PermRolesManage,
+	auth.PermAuditRead,
+	auth.PermApprovalsRead,
+	auth.PermApprovalsDecide,
+	auth.PermServiceAccountsManage,
+	auth.PermOAuthClientsManage,
+}
+
+func TestBuildRoutes_EveryAdminRouteHasPermission(t *testing.T) {
+	routes, _ := newTestServer(t, models.UserTypeADMIN, nil)
+
+	for _, route := range routes.Routes() {
+		key := route.Method + " " + route.Path
+		if route.Path == "/swagger/*any" || slices.Contains(publicRoutes, key) || slices.Contains(customerRoutes, key) {
+			continue
+		}
+		assert.True(t, slices.ContainsFunc(routePermissions, func(rp routePermission) bool {
+			return rp.method == route.Method && rp.path == route.Path
+		}), "%s has no entry in the permission matrix", key)
+	}
+}
+
+func TestBuildRoutes_PermissionMatrix(t *testing.T) {
+	for _, route := range routePermissions {
+		name := route.method + " " + route.path
+
+		t.Run(name+" forbids admin without "+route.permission.String(), func(t *testing.T) {
+			others := slices.DeleteFunc(slices.Clone(allPermissions), func(p auth.Permission) bool {
+				return p == route.permission
+			})
+			routes, token := newTestServer(t, models.UserTypeADMIN, others)
+
+			w := serve(routes, route.method, route.path, token)
+			assert.Equal(t, http.StatusForbidden, w.Code)
+			assert.Contains(t, w.Body.String(), "Forbidden")
+		})
+
+		t.Run(name+" allows admin with "+route.permission.String(), func(t *testing.T) {
+			routes, token := newTestServer(t, models.UserTypeADMIN, []auth.Permission{route.permission})
+
+			// the handlers have no services, a request that gets past the middlewares fails with a 500
+			w := serve(routes, route.method, route.path, token)
+			assert.NotEqual(t, http.StatusForbidden, w.Code, w.Body.String())
+			assert.NotEqual(t, http.StatusUnauthorized, w.Code, w.Body.String())
+		})
+
+		t.Run(name+" forbids customer", func(t *testing.T) {
+			routes, token := newTestServer(t, models.UserTypeCUSTOMER, nil)
+
+			w := serve(routes, route.method, route.path, token)
+			assert.Equal(t, http.StatusForbidden, w.Code)
+		})
+	}
+}
+
+// stepUpRoutes are the high-risk admin routes that need an MFA code entered within the step-up window.
+var stepUpRoutes = []string{
+	"POST /api/v1/credit",
+	"POST /api/v1/debit",
+	"POST /api/v1/transactions/:id/reverse",
+	"POST /api/v1/accounts/:id/interest-overrides",
+	"PUT /api/v1/accounts/:id/interest-overrides/:override_id",
+	"DELETE /api/v1/accounts/:id/interest-overrides/:override_id",
+}
+
+func TestBuildRoutes_StepUp(t *testing.T) {
+	routes, token := newTestServerVerifiedAt(t, models.UserTypeADMIN, allPermissions, time.Now().Add(-time.Hour))
+
+	for _, route := range stepUpRoutes {
+		method, path, _ := strings.Cut(route, " ")
+		w := serve(routes, method, path, token)
+		assert.Equal(t, http.StatusForbidden, w.Code, route)
+		assert.Contains(t, w.Body.String(), "step-up authentication required", route)
+	}
+}
+
+func TestBuildRoutes_AdminWithoutRoles(t *testing.T) {
+	routes, token := newTestServer(t, models.UserTypeADMIN, nil)
+
+	w := serve(routes, http.MethodGet, "/api/v1/accounts/stats", token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestBuildRoutes_APIKey(t *testing.T) {
+	profile := auth.Profile{
+		UserID:      uuid.New(),
+		UserType:    string(models.UserTypeSERVICE),
+		Permissions: []auth.Permission{auth.PermAccountsRead},
+		APIKeyID:    uuid.New(),
+	}
+
+	t.Run("allows a key with the scope", func(t *testing.T) {
+		accounts := serviceaccount.NewMockService(gomock.NewController(t))
+		accounts.EXPECT().Authenticate(gomock.Any(), "pbk_valid", gomock.Any()).Return(profile, nil)
+		routes := newServer(t, databasemocks.NewMockQuerier(gomock.NewController(t)), testKeys, accounts)
+
+		w := serveWithAPIKey(routes, http.MethodGet, "/api/v1/accounts/stats", "pbk_valid")
+		assert.NotEqual(t, http.StatusForbidden, w.Code, w.Body.String())
+		assert.NotEqual(t, http.StatusUnauthorized, w.Code, w.Body.String())
+	})
+
+	t.Run("forbids a key without the scope", func(t *testing.T) {
+		accounts := serviceaccount.NewMockService(gomock.NewController(t))
+		accounts.EXPECT().Authenticate(gomock.Any(), "pbk_valid", gomock.Any()).Return(profile, nil)
+		routes := newServer(t, databasemocks.NewMockQuerier(gomock.NewController(t)), testKeys, accounts)
+
+		w := serveWithAPIKey(routes, http.MethodGet, "/api/v1/accounts/:id/logs", "pbk_valid")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("rejects an invalid key", func(t *testing.T) {
+		accounts := serviceaccount.NewMockService(gomock.NewController(t))
+		accounts.EXPECT().Authenticate(gomock.Any(), "pbk_invalid", gomock.Any()).
+			Return(auth.Profile{}, platformerrors.MakeApiError(http.StatusUnauthorized, "invalid api key"))
+		routes := newServer(t, databasemocks.NewMockQuerier(gomock.NewController(t)), testKeys, accounts)
+
+		w := serveWithAPIKey(routes, http.MethodGet, "/api/v1/accounts/stats", "pbk_invalid")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid api key")
+	})
+}
+
+func TestBuildRoutes_JWKS(t *testing.T) {
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	previous, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	keys, err := generator.NewKeySetFromKeys(private, previous.Public())
+	assert.NoError(t, err)
+
+	db := databasemocks.NewMockQuerier(gomock.NewController(t))
+	routes := newServer(t, db, keys, serviceaccount.NewMockService(gomock.NewController(t)))
+
+	t.Run("publishes the verification keys", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		routes.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var jwks generator.JWKS
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &jwks))
+		assert.Len(t, jwks.Keys, 2)
+		assert.Equal(t, "ES256", jwks.Keys[0].Algorithm)
+		assert.NotContains(t, w.Body.String(), `"d"`)
+	})
+
+	t.Run("accepts tokens signed with a published key", func(t *testing.T) {
+		userID := uuid.New()
+		db.EXPECT().IsAccessTokenRevoked(gomock.Any(), gomock.Any()).Return(false, nil)
+		db.EXPECT().GetProfileByUserID(gomock.Any(), userID).
+			Return(models.GetProfileByUserIDRow{UserID: userID, UserType: models.UserTypeCUSTOMER}, nil)
+
+		previousKeys, err := generator.NewKeySetFromKeys(previous)
+		assert.NoError(t, err)
+		token, err := generator.NewTokenGenerator(testConfig.JWT, previousKeys).Generate(generator.TokenData{UserID: userID})
+		assert.NoError(t, err)
+
+		w := serve(routes, http.MethodGet, "/api/v1/reports/dormancy", token)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("rejects tokens signed with an unknown key", func(t *testing.T) {
+		unknown, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.NoError(t, err)
+		unknownKeys, err := generator.NewKeySetFromKeys(unknown)
+		assert.NoError(t, err)
+		token, err := generator.NewTokenGenerator(testConfig.JWT, unknownKeys).Generate(generator.TokenData{UserID: uuid.New()})
+		assert.NoError(t, err)
+
+		w := serve(routes, http.MethodGet, "/api/v1/reports/dormancy", token)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestBuildRoutes_DelegatedToken(t *testing.T) {
+	userID := uuid.New()
+	db := databasemocks.NewMockQuerier(gomock.NewController(t))
+	db.EXPECT().IsAccessTokenRevoked(gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+	db.EXPECT().GetProfileByUserID(gomock.Any(), userID).
+		Return(models.GetProfileByUserIDRow{UserID: userID, UserType: models.UserTypeCUSTOMER}, nil).AnyTimes()
+	routes := newServer(t, db, testKeys, serviceaccount.NewMockService(gomock.NewController(t)))
+
+	token, err := generator.NewTokenGenerator(testConfig.JWT, testKeys).Generate(generator.TokenData{
+		UserID:   userID,
+		ClientID: uuid.New(),
+		Scope:    "openid accounts:read",
+	})
+	assert.NoError(t, err)
+
+	t.Run("reaches routes its scopes allow", func(t *testing.T) {
+		for _, path := range []string{"/api/v1/accounts/:id", "/api/v1/accounts/:id/transactions", "/api/v1/accounts/:id/balance"} {
+			// the handlers have no services, a request that gets past the middlewares fails with a 500
+			w := serve(routes, http.MethodGet, path, token)
+			assert.NotEqual(t, http.StatusForbidden, w.Code, path)
+		}
+	})
+
+	t.Run("is refused routes of scopes it was not granted", func(t *testing.T) {
+		w := serve(routes, http.MethodPost, "/api/v1/transfer", token)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = serve(routes, http.MethodGet, "/api/v1/me", token)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("is refused routes no scope grants", func(t *testing.T) {
+		for _, route := range []string{
+			"POST /api/v1/users/logout",
+			"GET /api/v1/me/sessions",
+			"POST /api/v1/me/password",
+			"POST /api/v1/oauth/authorize",
+			"GET /api/v1/reports/dormancy",
+		} {
+			method, path, _ := strings.Cut(route, " ")
+			w := serve(routes, method, path, token)
+			assert.Equal(t, http.StatusForbidden, w.Code, route)
+		}
+	})
+}
+
+func newTestServer(t *testing.T, userType models.UserType, permissions []auth.Permission) (*gin.Engine, string) {
+	return newTestServerVerifiedAt(t, userType, permissions, time.Now())
+}
+
+// newTestServerVerifiedAt is newTestServer for a session that last entered an MFA code at mfaVerifiedAt.
+func newTestServerVerifiedAt(t *testing.T, userType models.UserType, permissions []auth.Permission, mfaVerifiedAt time.Time) (*gin.Engine, string) {
+	userID := uuid.New()
+	db := databasemocks.NewMockQuerier(gomock.NewController(t))
+	db.EXPECT().IsAccessTokenRevoked(gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+	db.EXPECT().GetProfileByUserID(gomock.Any(), userID).
+		Return(models.GetProfileByUserIDRow{UserID: userID, UserType: userType}, nil).AnyTimes()
+	names := make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		names = append(names, permission.String())
+	}
+	db.EXPECT().GetUserPermissions(gomock.Any(), userID).Return(names, nil).AnyTimes()
+
+	routes := newServer(t, db, testKeys, serviceaccount.NewMockService(gomock.NewController(t)))
+
+	token, err := generator.NewTokenGenerator(testConfig.JWT, testKeys).Generate(generator.TokenData{
+		UserID:        userID,
+		MFAVerifiedAt: mfaVerifiedAt,
+	})
+	assert.NoError(t, err)
+	return routes, token
+}
+
+var testKeys = generator.NewSecretKeySet(testConfig.JWT.Secret)
+
+var testConfig = config.Config{
+	Server: config.ServerConfig{CorsOrigin: "http://localhost:5173"},
+	JWT: config.JWTConfig{
+		Secret:   "secret",
+		Expiry:   15 * time.Minute,
+		Issuer:   "payter-bank",
+		Audience: "payter-bank",
+	},
+	MFA: config.MFAConfig{
+		RequiredForAdmins: true,
+		StepUpWindow:      5 * time.Minute,
+	},
+}
+
+func newServer(t *testing.T, db models.Querier, keys *generator.KeySet, accounts serviceaccount.Service) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	gin.DefaultWriter = io.Discard
+	gin.DefaultErrorWriter = io.Discard
+
+	srv := New(testConfig, db, keys,
+		account.NewHandler(nil, nil), transaction.NewHandler(nil, nil), interestrate.NewHandler(nil, nil), auditlog.NewHandler(nil),
+		dormancy.NewHandler(nil), notification.NewHandler(nil), user.NewHandler(nil), session.NewHandler(nil),
+		mfa.NewHandler(nil), lockout.NewHandler(nil), rbac.NewHandler(nil), approval.NewHandler(nil),
+		accounts, serviceaccount.NewHandler(nil), oauth.NewHandler(nil))
+	routes, err := srv.BuildRoutes()
+	assert.NoError(t, err)
+	return routes
+}
+
+func serve(routes *gin.Engine, method, path, token string) *httptest.ResponseRecorder {
+	return serveWith(routes, method, path, "Authorization", "Bearer "+token)
+}
+
+func serveWithAPIKey(routes *gin.Engine, method, path, key string) *httptest.ResponseRecorder {
+	return serveWith(routes, method, path, apiKeyHeader, key)
+}
+
+func serveWith(routes *gin.Engine, method, path, header, value string) *httptest.ResponseRecorder {
+	path = strings.ReplaceAll(path, ":id", uuid.NewString())
+	path = strings.ReplaceAll(path, ":keyId", uuid.NewString())
+	req := httptest.NewRequest(method, path, strings.NewReader("{}"))
+	req.Header.Set(header, value)
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, req)
+	return w
+}